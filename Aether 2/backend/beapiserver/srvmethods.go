@@ -4,12 +4,15 @@
 package beapiserver
 
 import (
-	// "aether-core/services/globals"
 	"aether-core/backend/dispatch"
+	"aether-core/backend/eventhorizon"
+	"aether-core/backend/feapiconsumer"
 	"aether-core/io/api"
+	"aether-core/io/blobstore"
 	"aether-core/io/persistence"
 	pb "aether-core/protos/beapi"
 	"aether-core/services/create"
+	"aether-core/services/globals"
 	"aether-core/services/logging"
 	// "google.golang.org/grpc"
 	// "google.golang.org/grpc/reflection"
@@ -247,6 +250,16 @@ func requestAllowed(req interface{}) bool {
 		return true
 	case *pb.ConnectToRemoteRequest:
 		return true
+	case *pb.SubscribedBoardsPayload:
+		return true
+	case *pb.PinPayload:
+		return true
+	case *pb.BlobsPayload:
+		return true
+	case *pb.BlobsRequest:
+		return true
+	case *pb.RevisionsRequest:
+		return true
 	default:
 		return false
 	}
@@ -379,6 +392,7 @@ func (s *server) SendMintedContent(
 	// }
 	// ^ TODO FUTURE when needed.
 	persistence.BatchInsert(allItems)
+	dispatch.Announce(allItems)
 	resp.Status.StatusCode = 200
 	return &resp, nil
 }
@@ -406,6 +420,130 @@ func (s *server) SendConnectToRemoteRequest(
 	return &resp, nil
 }
 
+// SendSubscribedBoards receives the list of boards the frontend is subscribed to. If selective sync is on, these are the boards we replicate. The frontend sends the full list every time, so this replaces what we had.
+func (s *server) SendSubscribedBoards(
+	ctx context.Context, req *pb.SubscribedBoardsPayload) (*pb.SubscribedBoardsResponse, error) {
+	resp := pb.SubscribedBoardsResponse{Status: &pb.Status{}}
+	if !requestAllowed(req) {
+		resp.Status.StatusCode = 401 // HTTP 401 Unauthorised
+		return &resp, nil
+	}
+	fps := req.GetFingerprints()
+	if len(fps) > api.MAX_APIRESPONSE_FILTER_VALUES_BOARD_V1_0 {
+		resp.Status.StatusCode = 400 // HTTP 400 Bad Request
+		resp.Status.ErrorMessage = fmt.Sprintf("Too many boards. Max: %d", api.MAX_APIRESPONSE_FILTER_VALUES_BOARD_V1_0)
+		return &resp, nil
+	}
+	logging.Logf(1, "Backend received the list of subscribed boards from the frontend. Count: %d", len(fps))
+	globals.BackendTransientConfig.SubscribedBoards.Set(fps)
+	resp.Status.StatusCode = 200
+	return &resp, nil
+}
+
+// SendPinRequest pins or unpins a board, thread or key. Pinned content is exempt from the event horizon.
+func (s *server) SendPinRequest(
+	ctx context.Context, req *pb.PinPayload) (*pb.PinResponse, error) {
+	resp := pb.PinResponse{Status: &pb.Status{}}
+	if !requestAllowed(req) {
+		resp.Status.StatusCode = 401 // HTTP 401 Unauthorised
+		return &resp, nil
+	}
+	if !persistence.IsPinnableEntityType(req.GetEntityType()) {
+		resp.Status.StatusCode = 400 // HTTP 400 Bad Request
+		resp.Status.ErrorMessage = fmt.Sprintf("This entity type cannot be pinned. Entity type: %s", req.GetEntityType())
+		return &resp, nil
+	}
+	var err error
+	if req.GetPinned() {
+		err = persistence.PinEntity(req.GetEntityType(), api.Fingerprint(req.GetFingerprint()))
+	} else {
+		err = persistence.UnpinEntity(req.GetEntityType(), api.Fingerprint(req.GetFingerprint()))
+	}
+	if err != nil {
+		resp.Status.StatusCode = 500 // HTTP 500 Internal Server Error
+		resp.Status.ErrorMessage = err.Error()
+		return &resp, nil
+	}
+	pinnedSize := int64(eventhorizon.PinnedDbSizeMb())
+	feapiconsumer.BackendAmbientStatus.PinnedDbSizeMb = pinnedSize
+	resp.PinnedDbSizeMb = pinnedSize
+	resp.Status.StatusCode = 200
+	return &resp, nil
+}
+
+// SendBlobs saves the chunks of an attachment the user is about to post. They are saved the same way as the ones we receive from remotes, so that we can serve them to the network once the thread or the post is out.
+func (s *server) SendBlobs(
+	ctx context.Context, req *pb.BlobsPayload) (*pb.BlobsResponse, error) {
+	resp := pb.BlobsResponse{Status: &pb.Status{}}
+	if !requestAllowed(req) {
+		resp.Status.StatusCode = 401 // HTTP 401 Unauthorised
+		return &resp, nil
+	}
+	for _, b := range req.GetBlobs() {
+		err := blobstore.Put(b.GetHash(), b.GetData())
+		if err != nil {
+			resp.Status.StatusCode = 400 // HTTP 400 Bad Request
+			resp.Status.ErrorMessage = err.Error()
+			return &resp, nil
+		}
+	}
+	blobstore.Prune()
+	resp.Status.StatusCode = 200
+	return &resp, nil
+}
+
+// GetBlobs gives the frontend the chunks it asks for, the ones we have of them.
+func (s *server) GetBlobs(
+	ctx context.Context, req *pb.BlobsRequest) (*pb.BlobsResponse, error) {
+	resp := pb.BlobsResponse{Status: &pb.Status{}}
+	if !requestAllowed(req) {
+		resp.Status.StatusCode = 401 // HTTP 401 Unauthorised
+		return &resp, nil
+	}
+	for _, h := range req.GetHashes() {
+		data, err := blobstore.Get(h)
+		if err != nil {
+			continue
+		}
+		resp.Blobs = append(resp.Blobs, &pb.Blob{Hash: h, Data: data})
+	}
+	resp.Status.StatusCode = 200
+	return &resp, nil
+}
+
+// GetRevisions returns the versions of a thread or post that this node has kept.
+func (s *server) GetRevisions(
+	ctx context.Context, req *pb.RevisionsRequest) (*pb.RevisionsResponse, error) {
+	resp := pb.RevisionsResponse{Status: &pb.Status{}}
+	if !requestAllowed(req) {
+		resp.Status.StatusCode = 401 // HTTP 401 Unauthorised
+		return &resp, nil
+	}
+	if req.GetEntityType() != "thread" && req.GetEntityType() != "post" {
+		resp.Status.StatusCode = 400 // HTTP 400 Bad Request
+		resp.Status.ErrorMessage = fmt.Sprintf("This entity type does not have revisions. Entity type: %s", req.GetEntityType())
+		return &resp, nil
+	}
+	revs, err := persistence.ReadRevisions(req.GetEntityType(), api.Fingerprint(req.GetFingerprint()))
+	if err != nil {
+		resp.Status.StatusCode = 500 // HTTP 500 Internal Server Error
+		resp.Status.ErrorMessage = err.Error()
+		return &resp, nil
+	}
+	for key, _ := range revs {
+		resp.Revisions = append(resp.Revisions, &pb.Revision{
+			LastUpdate:        int64(revs[key].LastUpdate),
+			Body:              revs[key].Body,
+			Meta:              revs[key].Meta,
+			UpdateProofOfWork: string(revs[key].UpdateProofOfWork),
+			UpdateSignature:   string(revs[key].UpdateSignature),
+			LocalArrival:      int64(revs[key].LocalArrival),
+		})
+	}
+	resp.Status.StatusCode = 200
+	return &resp, nil
+}
+
 func constructDirectConnectAddress(loc, subloc string, port int) api.Address {
 	subprots := []api.Subprotocol{api.Subprotocol{"c0", 1, 0, []string{"board", "thread", "post", "vote", "key", "truststate"}}}
 	addr, err := create.CreateAddress(api.Location(loc), api.Location(subloc), 4, uint16(port), 2, 1, 1, 1, 0, subprots, 2, 0, 0, "Aether", "")
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"aether-core/backend/dispatch"
+	"aether-core/backend/responsegenerator"
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+func init() {
+	var since int64
+	var out string
+	cmdBundleCreate.Flags().Int64VarP(&since, "since", "", 0, "Unix timestamp. Every entity that has arrived to this node after this will be in the bundle. Defaults to 0, which bundles everything this node has.")
+	cmdBundleCreate.Flags().StringVarP(&out, "out", "", "", "Path of the bundle file to write. Defaults to a file named after the current time, in the current directory.")
+	cmdBundle.AddCommand(cmdBundleCreate)
+	cmdBundle.AddCommand(cmdBundleIngest)
+	cmdRoot.AddCommand(cmdBundle)
+}
+
+var cmdBundle = &cobra.Command{
+	Use:   "bundle",
+	Short: "Create and ingest bundles, files that carry content between nodes that are not connected to each other.",
+	Long: `Create and ingest bundles, files that carry content between nodes that are not connected to each other.
+
+A bundle is made of the same signed pages a node serves over the network. When a bundle is ingested, it is verified and inserted the same way as a sync would, so a bundle can be carried over untrusted means, like a USB drive.
+`,
+}
+
+var cmdBundleCreate = &cobra.Command{
+	Use:   "create",
+	Short: "Write every entity that has arrived after the given timestamp into a bundle file.",
+	Run: func(cmd *cobra.Command, args []string) {
+		EstablishConfigs(cmd)
+		persistence.CreateDatabase()
+		persistence.CheckDatabaseReady()
+		since, _ := cmd.Flags().GetInt64("since")
+		out, _ := cmd.Flags().GetString("out")
+		if len(out) == 0 {
+			out = fmt.Sprintf("bundle-%d.mimbundle", time.Now().Unix())
+		}
+		b, err := responsegenerator.GenerateBundle(api.Timestamp(since))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err2 := b.WriteToFile(out)
+		if err2 != nil {
+			fmt.Println(err2)
+			os.Exit(1)
+		}
+		fmt.Printf("Bundle created. Pages: %d, Path: %s\n", len(b.Pages), out)
+	},
+}
+
+var cmdBundleIngest = &cobra.Command{
+	Use:   "ingest [file]",
+	Short: "Verify a bundle file and insert its contents.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		EstablishConfigs(cmd)
+		persistence.CreateDatabase()
+		persistence.CheckDatabaseReady()
+		failedPages, err := dispatch.IngestBundle(args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if failedPages > 0 {
+			fmt.Printf("Bundle ingested. %d pages failed verification and were skipped, see the logs for details.\n", failedPages)
+			return
+		}
+		fmt.Println("Bundle ingested.")
+	},
+}
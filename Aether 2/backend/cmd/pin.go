@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"aether-core/backend/eventhorizon"
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/logging"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func init() {
+	var unpin bool
+	var list bool
+	cmdPin.Flags().BoolVarP(&unpin, "unpin", "", false, "Remove the pin of the given entity instead of adding one. The entity becomes subject to the event horizon again.")
+	cmdPin.Flags().BoolVarP(&list, "list", "", false, "List all pinned entities and the estimated size of the pinned content, and quit.")
+	cmdRoot.AddCommand(cmdPin)
+}
+
+var cmdPin = &cobra.Command{
+	Use:   "pin [board|thread|key] [fingerprint]",
+	Short: "Pin a board, thread or key so that it is never deleted by the event horizon.",
+	Long: `Pin a board, thread or key so that it is never deleted by the event horizon.
+
+Normally, when the database reaches its max size, the oldest content is deleted to make room. Pinned content is exempt from this, along with what is needed to display it:
+
+- Pinning a board keeps all of its threads, posts and votes.
+- Pinning a thread keeps all of its posts and votes, and the board it is in.
+- Pinning a key keeps everything the key has created, and the threads it has participated in.
+
+Pinned content does not count against the max database size. This is safe to run while the node is running.
+`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		list, _ := cmd.Flags().GetBool("list")
+		if list {
+			return nil
+		}
+		if len(args) != 2 {
+			return fmt.Errorf("Pin requires an entity type and a fingerprint. Example: mre pin board <fingerprint>")
+		}
+		if !persistence.IsPinnableEntityType(args[0]) {
+			return fmt.Errorf("This entity type cannot be pinned: %s. Pinnable entity types are: board, thread, key.", args[0])
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		EstablishConfigs(cmd)
+		persistence.CreateDatabase()
+		persistence.CheckDatabaseReady()
+		list, _ := cmd.Flags().GetBool("list")
+		if list {
+			printPins()
+			return
+		}
+		unpin, _ := cmd.Flags().GetBool("unpin")
+		var err error
+		if unpin {
+			err = persistence.UnpinEntity(args[0], api.Fingerprint(args[1]))
+		} else {
+			err = persistence.PinEntity(args[0], api.Fingerprint(args[1]))
+		}
+		if err != nil {
+			logging.Log(1, err)
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if unpin {
+			fmt.Printf("Unpinned the %s %s.\n", args[0], args[1])
+		} else {
+			fmt.Printf("Pinned the %s %s.\n", args[0], args[1])
+		}
+		fmt.Printf("Pinned content size (estimated): %d MB\n", eventhorizon.PinnedDbSizeMb())
+	},
+}
+
+func printPins() {
+	pins, err := persistence.ReadPins()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for _, entityType := range []string{"board", "thread", "key"} {
+		for _, fp := range pins[entityType] {
+			fmt.Printf("%s\t%s\n", entityType, fp)
+		}
+	}
+	fmt.Printf("Pinned content size (estimated): %d MB\n", eventhorizon.PinnedDbSizeMb())
+}
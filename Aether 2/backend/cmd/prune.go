@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"aether-core/backend/eventhorizon"
+	"aether-core/io/blobstore"
+	"aether-core/io/persistence"
+	"aether-core/services/globals"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func init() {
+	var dryRun bool
+	cmdPrune.Flags().BoolVarP(&dryRun, "dryrun", "", false, "Print what would be deleted, without deleting anything.")
+	cmdRoot.AddCommand(cmdPrune)
+}
+
+var cmdPrune = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete the content that is past the local memory, and if the database is still over its max size, the least valuable content until it is not.",
+	Long: `Delete the content that is past the local memory, and if the database is still over its max size, the least valuable content until it is not.
+
+Content of boards you are not subscribed to goes first, then the content of less active boards, oldest first. Content within the network head and pinned content are never deleted. On SQLite, the database is compacted afterwards, so that the file actually shrinks.
+
+The chunks of attachments are kept outside the database, and they are pruned to their own max size, the ones that were used the longest time ago first.
+
+Run with --dryrun to see the plan without deleting anything. This should not be run while the node is running.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		EstablishConfigs(cmd)
+		persistence.CreateDatabase()
+		persistence.CheckDatabaseReady()
+		dryRun, _ := cmd.Flags().GetBool("dryrun")
+		plan, err := eventhorizon.PlanPrune()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Print(plan.Report())
+		if dryRun {
+			return
+		}
+		eventhorizon.PruneDB()
+		blobstore.Prune()
+		fmt.Printf("Done. Database size: %d MB, Blob store size: %d MB\n", globals.GetDbSize(), blobstore.SizeMb())
+	},
+}
@@ -130,6 +130,16 @@ func EstablishConfigs(cmd *cobra.Command) flags {
 	}
 	becfg.Cycle()
 	globals.BackendConfig = becfg
+	// Unlock the keystore. If the frontend that spawned us has a passphrase, it hands it over through the environment. We clear it right after, so that it does not leak into anything we might spawn.
+	passphrase := os.Getenv(configstore.KeystorePassphraseEnvVar)
+	os.Unsetenv(configstore.KeystorePassphraseEnvVar)
+	migrated, err2 := globals.BackendConfig.UnlockKeystore(passphrase)
+	if err2 != nil {
+		logging.LogCrash(err2)
+	}
+	if migrated {
+		logging.Log(1, "The backend key pair has been encrypted with the keystore passphrase.")
+	}
 	// fecfg, err := configstore.EstablishFrontendConfig()
 	// if err != nil {
 	// 	logging.LogCrash(err)
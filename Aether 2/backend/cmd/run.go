@@ -3,7 +3,9 @@ package cmd
 import (
 	"aether-core/backend/beapiserver"
 	"aether-core/backend/dispatch"
+	"aether-core/backend/eventhorizon"
 	"aether-core/backend/feapiconsumer"
+	"aether-core/backend/gateway"
 	"aether-core/backend/responsegenerator"
 	"aether-core/backend/server"
 	// "aether-core/io/api"
@@ -57,6 +59,9 @@ This will do three main things:
 		feapiconsumer.SendBackendReady()
 		collectAmbientStatusData()
 		feapiconsumer.SendBackendAmbientStatus()
+		if gateway.Enabled() {
+			go gateway.StartGateway()
+		}
 		server.StartMimServer()
 		shutdown()
 	},
@@ -84,6 +89,7 @@ func collectAmbientStatusData() {
 	feapiconsumer.BackendAmbientStatus.DatabaseStatus = "Available"
 	feapiconsumer.BackendAmbientStatus.DbSizeMb = int64(globals.GetDbSize())
 	feapiconsumer.BackendAmbientStatus.MaxDbSizeMb = int64(globals.BackendConfig.GetMaxDbSizeMb())
+	feapiconsumer.BackendAmbientStatus.PinnedDbSizeMb = int64(eventhorizon.PinnedDbSizeMb())
 	/*----------  Caching  ----------*/
 	feapiconsumer.BackendAmbientStatus.CachingStatus = "Idle"
 	feapiconsumer.BackendAmbientStatus.LastCacheGenerationTimestamp = globals.BackendConfig.GetLastCacheGenerationTimestamp()
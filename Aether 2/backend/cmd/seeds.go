@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"aether-core/backend/dispatch"
+	"aether-core/services/signaturing"
+	"fmt"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ed25519"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func init() {
+	var keyfile string
+	cmdSeedsSign.Flags().StringVarP(&keyfile, "keyfile", "", "", "The file that has the hex private key to sign with, as written by 'mre seeds keygen'.")
+	cmdSeeds.AddCommand(cmdSeedsKeygen)
+	cmdSeeds.AddCommand(cmdSeedsSign)
+	cmdRoot.AddCommand(cmdSeeds)
+}
+
+var cmdSeeds = &cobra.Command{
+	Use:   "seeds",
+	Short: "Create and sign seed lists for bootstrap.",
+	Long: `Create and sign seed lists for bootstrap.
+
+A seed list is a list of host:port entries of nodes that new nodes can ask for bootstrappers. It can be shipped as the seeds.txt file in the backend folder of the user directory, or published as a DNS TXT record. If a node has a seed list public key in its config, it only accepts seed lists signed with the private key of it.
+`,
+}
+
+var cmdSeedsKeygen = &cobra.Command{
+	Use:   "keygen [keyfile]",
+	Short: "Create a key pair for signing seed lists.",
+	Long: `Create a key pair for signing seed lists. The private key is written into the given file, only readable by you, and the public key is printed. The public key goes into the SeedListPublicKey field of the backend config of the nodes that should trust your lists.
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		privKey, err := signaturing.CreateKeyPair()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		f, err2 := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err2 != nil {
+			fmt.Println(err2)
+			os.Exit(1)
+		}
+		defer f.Close()
+		_, err3 := f.WriteString(signaturing.MarshalPrivateKey(*privKey))
+		if err3 != nil {
+			fmt.Println(err3)
+			os.Exit(1)
+		}
+		fmt.Printf("Private key written. Path: %s\nPublic key: %s\n", args[0], signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey)))
+	},
+}
+
+var cmdSeedsSign = &cobra.Command{
+	Use:   "sign [seeds file]",
+	Short: "Sign a seed list, and print the signed list.",
+	Long: `Sign a seed list, and print the signed list. The seeds file has one host:port entry per line. The output can be saved as a seeds file, and the same entries with the signature can be published as a DNS TXT record in this form:
+
+aether-seeds <host:port> <host:port> ... signature:<hex>
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		keyfile, _ := cmd.Flags().GetString("keyfile")
+		if len(keyfile) == 0 {
+			fmt.Println("Signing requires a key file. Create one with 'mre seeds keygen'.")
+			os.Exit(1)
+		}
+		keyHex, err := ioutil.ReadFile(keyfile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		privKey, err2 := signaturing.UnmarshalPrivateKey(strings.TrimSpace(string(keyHex)))
+		if err2 != nil || len(privKey) != ed25519.PrivateKeySize {
+			fmt.Println("The key file does not have a valid private key.")
+			os.Exit(1)
+		}
+		seeds, err3 := ioutil.ReadFile(args[0])
+		if err3 != nil {
+			fmt.Println(err3)
+			os.Exit(1)
+		}
+		signed, err4 := dispatch.SignSeedList(strings.Split(string(seeds), "\n"), &privKey)
+		if err4 != nil {
+			fmt.Println(err4)
+			os.Exit(1)
+		}
+		fmt.Print(signed)
+	},
+}
@@ -92,6 +92,8 @@ func findOnlineNodes(count int, addrType int, excl *[]api.Address) ([]api.Addres
 	// logging.Logf(1, "All addresses: %s", )
 	// logging.LogObj(2, "All addresses", Dbg_convertAddrSliceToNameSlice(addrs))
 	addrs, _ = filterByType(addrType, addrs)
+	// No point in pinging the addresses of an IP version we don't use.
+	addrs = filterByIpType(addrs, globals.BackendConfig.GetIpTypePreference())
 	// logging.LogObj(2, "Filtered addresses", Dbg_convertAddrSliceToNameSlice(addrs))
 	if excl != nil {
 		for _, addr := range *excl {
@@ -104,6 +106,8 @@ func findOnlineNodes(count int, addrType int, excl *[]api.Address) ([]api.Addres
 		errors.Wrap(err, "findOnlineNodes: updateAddress within this function failed.")
 	}
 	liveNodes := filterByLastSuccessfulPing(updatedAddrs, start)
+	// If a node is online at both an IPv4 and an IPv6 address, keep the one we prefer, so that it doesn't get two chances to be picked.
+	liveNodes = applyIpTypePreference(liveNodes)
 	// logging.Logf(2, "Live addresses: %s", Dbg_convertAddrSliceToNameSlice(updatedAddrs))
 	if count == 0 { // count == 0: return everything found.
 		return liveNodes, nil
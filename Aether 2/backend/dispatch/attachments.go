@@ -0,0 +1,127 @@
+// Backend > Dispatch > Attachments
+// This file fetches the chunks of the attachments of the threads and posts we receive.
+
+package dispatch
+
+import (
+	"aether-core/io/api"
+	"aether-core/io/blobstore"
+	"aether-core/services/logging"
+	"aether-core/services/metaparse"
+	"net"
+	"sync"
+	"time"
+)
+
+/*
+How do the chunks get to us?
+
+The entities come first, and the chunks of their attachments after. When a thread or a post with attachments arrives, we note down the chunks we don't have. At the end of the sync, we ask the remote we just synced with for some of them. It most likely has them, since it had the entity. If it doesn't, they stay on the list, and the next remote gets asked.
+
+The list is capped, and the chunks on it expire, so a flood of attachments can't grow it without bound, and a chunk nobody has doesn't stay on it forever. A chunk we never get only means an attachment we can't show.
+*/
+
+const (
+	maxPendingChunks        = 1000
+	pendingChunkExpiry      = 24 * time.Hour
+	maxChunksFetchedPerSync = 64
+)
+
+type pendingChunkList struct {
+	lock   sync.Mutex
+	chunks map[string]time.Time // hash > when it was noted
+}
+
+var pendingChunks = pendingChunkList{chunks: make(map[string]time.Time)}
+
+func (l *pendingChunkList) add(hash string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, ok := l.chunks[hash]; ok {
+		return
+	}
+	if len(l.chunks) >= maxPendingChunks {
+		return
+	}
+	l.chunks[hash] = time.Now()
+}
+
+func (l *pendingChunkList) remove(hash string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.chunks, hash)
+}
+
+// list returns up to max of the hashes on the list, and drops the expired ones on the way.
+func (l *pendingChunkList) list(max int) []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	hashes := []string{}
+	for h, noted := range l.chunks {
+		if time.Since(noted) > pendingChunkExpiry {
+			delete(l.chunks, h)
+			continue
+		}
+		if len(hashes) < max {
+			hashes = append(hashes, h)
+		}
+	}
+	return hashes
+}
+
+// noteAttachments goes through the entities about to be inserted, and adds the chunks of their attachments that we don't have to the pending list.
+func noteAttachments(items []interface{}) {
+	for key, _ := range items {
+		var atts []metaparse.Attachment
+		switch item := items[key].(type) {
+		case api.Thread:
+			atts = metaparse.ReadAttachments("Thread", item.Meta)
+		case api.Post:
+			atts = metaparse.ReadAttachments("Post", item.Meta)
+		default:
+			continue
+		}
+		for i, _ := range atts {
+			if !blobstore.ValidAttachment(&atts[i]) {
+				continue
+			}
+			for _, h := range blobstore.Missing(atts[i].Chunks) {
+				pendingChunks.add(h)
+			}
+		}
+	}
+}
+
+// fetchPendingChunks asks the remote for the chunks on the pending list, and saves the ones it gives.
+func fetchPendingChunks(a api.Address, reverseConn *net.Conn) {
+	hashes := pendingChunks.list(maxChunksFetchedPerSync)
+	if len(hashes) == 0 {
+		return
+	}
+	host, subhost, port := string(a.Location), string(a.Sublocation), a.Port
+	saved := 0
+	for i := 0; i < len(hashes); i += api.MaxBlobsPerRequest {
+		end := i + api.MaxBlobsPerRequest
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		blobs, err := api.GetBlobs(host, subhost, port, hashes[i:end], reverseConn)
+		if err != nil {
+			logging.Logf(1, "Fetching the chunks of attachments failed. Remote: %s:%d, Error: %v", host, port, err)
+			break
+		}
+		for key, _ := range blobs {
+			err2 := blobstore.Put(blobs[key].Hash, blobs[key].Data)
+			if err2 != nil {
+				logging.Logf(1, "A chunk we received could not be saved. Remote: %s:%d, Error: %v", host, port, err2)
+				continue
+			}
+			pendingChunks.remove(blobs[key].Hash)
+			saved++
+		}
+	}
+	logging.Logf(2, "Fetched the chunks of attachments. Remote: %s:%d, Asked: %d, Saved: %d", host, port, len(hashes), saved)
+	if saved > 0 {
+		blobstore.Prune()
+	}
+}
@@ -4,11 +4,17 @@
 package dispatch
 
 import (
+	"aether-core/backend/feapiconsumer"
 	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/protos/feobjects"
 	"aether-core/services/configstore"
 	"aether-core/services/globals"
 	"aether-core/services/logging"
+	"aether-core/services/toolbox"
 	// "github.com/davecgh/go-spew/spew"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,21 +24,46 @@ const (
 	bsPort   = configstore.DefaultBootstrapperPort
 )
 
-func getBootstrappers() []api.Address {
-	// defaultBootstrapper := constructBootstrapper(bsLoc, bsSubloc, bsPort)
-	resp, err := api.GetPageRaw(bsLoc, bsSubloc, bsPort, "bootstrappers", "GET", []byte{}, nil)
+// getBootstrappersFrom asks the seed for the bootstrappers it knows. The seed itself is one of them, if it is a bootstrapper.
+func getBootstrappersFrom(seed api.Address) []api.Address {
+	resp, err := api.GetPageRaw(string(seed.Location), string(seed.Sublocation), seed.Port, "bootstrappers", "GET", []byte{}, nil)
 	if err != nil {
-		logging.Logf(1, "Getting bootstrappers failed from this address. Error: %v, Address: %v/%v:%v", err, bsLoc, bsSubloc, bsPort)
+		logging.Logf(1, "Getting bootstrappers failed from this address. Error: %v, Address: %v/%v:%v", err, seed.Location, seed.Sublocation, seed.Port)
+		return []api.Address{}
 	}
 	// spew.Dump(resp)
 	bsers := []api.Address{}
 	if resp.Address.Type == 254 || resp.Address.Type == 3 {
-		resp.Address.Location = bsLoc
-		resp.Address.Sublocation = bsSubloc
-		resp.Address.Port = bsPort
+		resp.Address.Location = seed.Location
+		resp.Address.Sublocation = seed.Sublocation
+		resp.Address.Port = seed.Port
 		bsers = append(bsers, resp.Address) // The first bootstrapper is the address we connected to if it's a bootstrapper itself (type=3)
 	}
 	bsers = append(bsers, resp.ResponseBody.Addresses...)
+	return bsers
+}
+
+// getBootstrappers asks all of our seeds (see seeds.go) for bootstrappers, in parallel, and returns the union of what they know.
+func getBootstrappers() []api.Address {
+	seeds := getSeeds()
+	results := make([][]api.Address, len(seeds))
+	var wg sync.WaitGroup
+	for key, _ := range seeds {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			results[k] = getBootstrappersFrom(seeds[k])
+		}(key)
+	}
+	wg.Wait()
+	bsers := []api.Address{}
+	for _, r := range results {
+		for key, _ := range r {
+			if !addrsInGivenSlice(&r[key], &bsers) {
+				bsers = append(bsers, r[key])
+			}
+		}
+	}
 	slen := len(bsers)
 	if slen > 99 {
 		slen = 99
@@ -40,69 +71,402 @@ func getBootstrappers() []api.Address {
 	return bsers[0:slen] // limit it to protect from DDoSs
 }
 
-type execPlan struct {
-	addr      api.Address
-	endpoints []string
+/*
+How does a bootstrap work?
+
+A bootstrap is a download of everything the network has, from scratch or from far behind. It is the most expensive thing a node does, so we spread it across all of the bootstrappers that are online, and we do not take any one bootstrapper's word for what the network has.
+
+1) Ask every bootstrapper for the caches it has for every endpoint, in parallel.
+2) Plan: for every endpoint, pick a set of caches from all bootstrappers that together cover the whole time range, spreading them across the bootstrappers as evenly as possible (see PlanBootstrapCaches).
+3) Download: every bootstrapper gets a worker, and the workers run in parallel. Each downloads its caches, and then the manifests of all of the caches it has.
+4) Cross-check: the manifests list everything in a cache without the content. If a bootstrapper's caches cover a time range, but its manifests do not list something others list for that range, it is withholding (see FindWithheld). We only report this, bootstrappers can legitimately differ on what they keep. Anything listed by anyone that we still don't have at this point is retried from a bootstrapper that lists it.
+5) Run a normal sync with every bootstrapper. These should download very little, they're there to pick up the POST responses after the last caches, and to save the last checkin timestamps for each bootstrapper, so that later syncs with them only look at what's new.
+
+Progress of each bootstrapper is reported to the frontend in the backend ambient status.
+*/
+
+// How many bootstrappers we download from at the same time. The bootstrap downloads do not take outbound slots, a bootstrap is a burst that runs mostly at first boot, before there is anything else to do.
+const maxParallelBootstrapSources = 4
+
+// CacheAssignment is a cache of an endpoint that we will download from a bootstrapper. Source is the index of the bootstrapper.
+type CacheAssignment struct {
+	Source   int
+	Endpoint string
+	Cache    api.ResultCache
+}
+
+/*
+PlanBootstrapCaches picks the caches of an endpoint to download, given the caches each bootstrapper has (caches[i] is the caches of bootstrapper i). The chosen caches cover the whole time range that any bootstrapper covers.
+
+Bootstrappers generate their caches on their own schedules, so their time ranges do not line up. We walk the time range from the oldest start, and at every point, we pick a cache that starts at or before that point and ends after it. Among those, we pick the one of the bootstrapper with the fewest caches assigned so far, and if that is a tie, the one that reaches furthest. If no cache covers a point, nobody has anything there, and we skip ahead to the next cache start.
+
+Load is the number of caches assigned to each bootstrapper so far, and it is updated, so that the planning of all endpoints together spreads the load.
+*/
+func PlanBootstrapCaches(endpoint string, caches [][]api.ResultCache, load []int) []CacheAssignment {
+	type candidate struct {
+		source int
+		cache  api.ResultCache
+		used   bool
+	}
+	cands := []candidate{}
+	for src, _ := range caches {
+		for _, c := range caches[src] {
+			cands = append(cands, candidate{source: src, cache: c})
+		}
+	}
+	plan := []CacheAssignment{}
+	if len(cands) == 0 {
+		return plan
+	}
+	cursor := cands[0].cache.StartsFrom
+	for _, c := range cands {
+		if c.cache.StartsFrom < cursor {
+			cursor = c.cache.StartsFrom
+		}
+	}
+	for {
+		best := -1
+		for k, c := range cands {
+			if c.used || c.cache.StartsFrom > cursor || c.cache.EndsAt <= cursor {
+				continue
+			}
+			if best == -1 ||
+				load[c.source] < load[cands[best].source] ||
+				(load[c.source] == load[cands[best].source] && c.cache.EndsAt > cands[best].cache.EndsAt) {
+				best = k
+			}
+		}
+		if best != -1 {
+			cands[best].used = true
+			load[cands[best].source]++
+			plan = append(plan, CacheAssignment{Source: cands[best].source, Endpoint: endpoint, Cache: cands[best].cache})
+			cursor = cands[best].cache.EndsAt
+			continue
+		}
+		// Nothing covers the cursor. Skip ahead to the next start, if there is one.
+		next := api.Timestamp(-1)
+		for _, c := range cands {
+			if !c.used && c.cache.StartsFrom > cursor && (next == -1 || c.cache.StartsFrom < next) {
+				next = c.cache.StartsFrom
+			}
+		}
+		if next == -1 {
+			break
+		}
+		cursor = next
+	}
+	return plan
 }
 
-// constructExecPlan creates an execution plan based on the addresses given. It splits all the endpoins we have to call across.
-func constructExecPlans(bootstrappers []api.Address) []execPlan {
-	if len(bootstrappers) == 0 {
-		return []execPlan{}
+// EntityListing is where a bootstrapper's manifests listed an entity.
+type EntityListing struct {
+	LastUpdate api.Timestamp
+	Cache      api.ResultCache
+}
+
+// SourceListing is what a bootstrapper has for an endpoint: its caches, and everything its manifests list.
+type SourceListing struct {
+	Caches   []api.ResultCache
+	Entities map[api.Fingerprint]EntityListing
+}
+
+// covers returns whether the caches together cover the whole time range.
+func covers(caches []api.ResultCache, start, end api.Timestamp) bool {
+	cursor := start
+	for progressed := true; progressed; {
+		progressed = false
+		for _, c := range caches {
+			if c.StartsFrom <= cursor && c.EndsAt > cursor {
+				cursor = c.EndsAt
+				progressed = true
+			}
+		}
+		if cursor >= end {
+			return true
+		}
 	}
-	execplans := []execPlan{}
-	for key, _ := range bootstrappers {
-		execplans = append(execplans, execPlan{addr: bootstrappers[key]})
+	return false
+}
+
+// FindWithheld returns, for every bootstrapper, how many entities others listed in a time range its caches cover, that its own manifests do not list. We only count an entity against a bootstrapper if its caches cover the whole cache the entity was listed in, since we do not know exactly when in that range the entity falls.
+func FindWithheld(listings []SourceListing) []int {
+	withheld := make([]int, len(listings))
+	for i, _ := range listings {
+		counted := make(map[api.Fingerprint]bool)
+		for j, _ := range listings {
+			if i == j {
+				continue
+			}
+			for fp, l := range listings[j].Entities {
+				if counted[fp] {
+					continue
+				}
+				if _, ok := listings[i].Entities[fp]; ok {
+					continue
+				}
+				if covers(listings[i].Caches, l.Cache.StartsFrom, l.Cache.EndsAt) {
+					counted[fp] = true
+					withheld[i]++
+				}
+			}
+		}
 	}
+	return withheld
+}
+
+// bootstrap is the state of a single bootstrap run.
+type bootstrap struct {
+	sources   []api.Address
+	endpoints []string
+	caches    []map[string][]api.ResultCache // per source: endpoint > caches
+	listings  []map[string]SourceListing     // per source: endpoint > listing
+	purgatory Purgatory
+	lock      sync.Mutex // guards progress, and listings
+	progress  []*feobjects.BootstrapSourceProgress
+}
+
+func newBootstrap(sources []api.Address) *bootstrap {
+	b := bootstrap{sources: sources}
 	servingSubprots := globals.BackendConfig.GetServingSubprotocols()
-	entities := []string{}
 	for _, subprot := range servingSubprots {
-		entities = append(entities, subprot.SupportedEntities...)
+		for _, ep := range subprot.SupportedEntities {
+			// Addresses do not have manifests, and we only ever take 100 of them from a remote. The normal syncs at the end pick them up.
+			if ep != "addresses" {
+				b.endpoints = append(b.endpoints, ep)
+			}
+		}
+	}
+	for key, _ := range sources {
+		b.caches = append(b.caches, make(map[string][]api.ResultCache))
+		b.listings = append(b.listings, make(map[string]SourceListing))
+		b.progress = append(b.progress, &feobjects.BootstrapSourceProgress{
+			Address: toolbox.JoinHostPort(string(sources[key].Location), sources[key].Port)})
+	}
+	return &b
+}
+
+// report updates the progress of a bootstrapper (if src is not -1) and the status, and sends it to the frontend.
+func (b *bootstrap) report(status string, src int, update func(p *feobjects.BootstrapSourceProgress)) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if src != -1 && update != nil {
+		update(b.progress[src])
 	}
-	for key, _ := range entities {
-		mod := key % len(execplans) // 0 % 7 = 0, 3 % 7 = 3, 7 % 7 = 0 (loops over)
-		execplans[mod].endpoints = append(execplans[mod].endpoints, entities[key])
+	if len(status) > 0 {
+		feapiconsumer.BackendAmbientStatus.BootstrapStatus = status
 	}
-	// spew.Dump(execplans)
-	return execplans
+	feapiconsumer.BackendAmbientStatus.BootstrapSources = b.progress
+	feapiconsumer.SendBackendAmbientStatus()
+}
+
+// forEachSource runs the function for every bootstrapper, maxParallelBootstrapSources at a time, and returns when all are done. A bootstrapper we are already syncing with is skipped.
+func (b *bootstrap) forEachSource(f func(src int)) {
+	slots := make(chan bool, maxParallelBootstrapSources)
+	var wg sync.WaitGroup
+	for key, _ := range b.sources {
+		slots <- true
+		wg.Add(1)
+		go func(src int) {
+			defer func() { <-slots; wg.Done() }()
+			remote := remoteKey(b.sources[src], nil)
+			if !inflightSyncs.claim(remote, true) {
+				logging.Logf(1, "Bootstrap skipped a bootstrapper because there is an ongoing sync with it. Remote: %s", remote)
+				return
+			}
+			defer inflightSyncs.release(remote)
+			f(src)
+		}(key)
+	}
+	wg.Wait()
+}
+
+func (b *bootstrap) fetchCaches(src int) {
+	a := b.sources[src]
+	for _, ep := range b.endpoints {
+		caches, err := api.GetEndpointCaches(string(a.Location), string(a.Sublocation), a.Port, ep, nil)
+		if err != nil {
+			logging.Logf(1, "Bootstrap could not get the caches of a bootstrapper. Endpoint: %s, Address: %s:%d, Error: %v", ep, a.Location, a.Port, err)
+			continue
+		}
+		b.caches[src][ep] = caches
+	}
+}
+
+func (b *bootstrap) plan() [][]CacheAssignment {
+	plans := make([][]CacheAssignment, len(b.sources))
+	load := make([]int, len(b.sources))
+	for _, ep := range b.endpoints {
+		caches := [][]api.ResultCache{}
+		for src, _ := range b.sources {
+			caches = append(caches, b.caches[src][ep])
+		}
+		for _, ca := range PlanBootstrapCaches(ep, caches, load) {
+			plans[ca.Source] = append(plans[ca.Source], ca)
+		}
+	}
+	for src, _ := range plans {
+		b.progress[src].CachesAssigned = int32(len(plans[src]))
+	}
+	return plans
+}
+
+// download gets the cache from the bootstrapper, and commits it. Only the pages that have something we don't have are downloaded.
+func (b *bootstrap) download(src int, endpoint string, cache api.ResultCache) error {
+	a := b.sources[src]
+	resp, err := api.GetManifestGatedCache(string(a.Location), string(a.Sublocation), a.Port, api.CacheLocation(endpoint, cache), endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp.StripUnsyncedBoards(api.SyncedBoards())
+	b.purgatory.Filter(&resp)
+	iface := prepareForBatchInsert(&resp)
+	_, err2 := persistence.BatchInsert(*iface)
+	return err2
+}
+
+func (b *bootstrap) fetchListings(src int) {
+	a := b.sources[src]
+	for ep, caches := range b.caches[src] {
+		l := SourceListing{Caches: caches, Entities: make(map[api.Fingerprint]EntityListing)}
+		for _, c := range caches {
+			manifests, err := api.GetCacheManifests(string(a.Location), string(a.Sublocation), a.Port, ep, c, nil)
+			if err != nil {
+				logging.Logf(1, "Bootstrap could not get the manifest of a cache. Endpoint: %s, Cache: %s, Address: %s:%d, Error: %v", ep, c.ResponseUrl, a.Location, a.Port, err)
+				continue
+			}
+			for _, m := range manifests {
+				for _, e := range m.Entities {
+					l.Entities[e.Fingerprint] = EntityListing{LastUpdate: e.LastUpdate, Cache: c}
+				}
+			}
+		}
+		b.lock.Lock()
+		b.listings[src][ep] = l
+		b.lock.Unlock()
+	}
+}
+
+// crossCheck reports the entities each bootstrapper withheld, and returns the caches to retry the entities we are still missing from. For each missing entity, we pick the bootstrapper that lists its newest version, and among those, the one we have retried the least from.
+func (b *bootstrap) crossCheck() [][]CacheAssignment {
+	retries := make([][]CacheAssignment, len(b.sources))
+	retryLoad := make([]int, len(b.sources))
+	boards := api.SyncedBoards()
+	for _, ep := range b.endpoints {
+		if boards != nil && api.IsBoardScoped(ep) {
+			// In selective sync, we do not want most of what the manifests list, and they cannot tell us which board an entity belongs to.
+			continue
+		}
+		listings := []SourceListing{}
+		for src, _ := range b.sources {
+			listings = append(listings, b.listings[src][ep])
+		}
+		withheld := FindWithheld(listings)
+		for src, _ := range b.sources {
+			if withheld[src] > 0 {
+				logging.Logf(1, "A bootstrapper did not list entities that others listed in a time range it covers. Endpoint: %s, Address: %s:%d, Count: %d", ep, b.sources[src].Location, b.sources[src].Port, withheld[src])
+			}
+			n := withheld[src]
+			b.report("", src, func(p *feobjects.BootstrapSourceProgress) { p.EntitiesWithheld += int32(n) })
+		}
+		// Find what we still don't have.
+		newest := make(map[api.Fingerprint]api.Timestamp)
+		for _, l := range listings {
+			for fp, e := range l.Entities {
+				if e.LastUpdate >= newest[fp] {
+					newest[fp] = e.LastUpdate
+				}
+			}
+		}
+		toRetry := make(map[int]map[string]api.ResultCache) // source > cache url > cache
+		retried := make([]int, len(b.sources))
+		for fp, lu := range newest {
+			if api.ExistsInDB(strings.TrimSuffix(ep, "s"), fp, lu) {
+				continue
+			}
+			pick := -1
+			for src, l := range listings {
+				if e, ok := l.Entities[fp]; ok && e.LastUpdate == lu &&
+					(pick == -1 || retryLoad[src] < retryLoad[pick]) {
+					pick = src
+				}
+			}
+			c := listings[pick].Entities[fp].Cache
+			if toRetry[pick] == nil {
+				toRetry[pick] = make(map[string]api.ResultCache)
+			}
+			if _, ok := toRetry[pick][c.ResponseUrl]; !ok {
+				toRetry[pick][c.ResponseUrl] = c
+				retryLoad[pick]++
+			}
+			retried[pick]++
+		}
+		for src, caches := range toRetry {
+			for _, c := range caches {
+				retries[src] = append(retries[src], CacheAssignment{Source: src, Endpoint: ep, Cache: c})
+			}
+			n := retried[src]
+			b.report("", src, func(p *feobjects.BootstrapSourceProgress) { p.EntitiesRetried += int32(n) })
+		}
+	}
+	return retries
 }
 
 // Bootstrap is the 'catch-up' logic that runs whenever a node falls too far behind the network head for any reason. One of the main uses is the start from the first boot, but it can also be that the node has been offline for more than bootstrap hit interval.
 func doBootstrap() {
-	/*
-			  Logic:
-			  1) Hit /bootstrappers on GET. Collect all bootstrappers.
-			  2) Do a check over them to find out which ones are online.
-			  3) Create a mapper that splits the endpoints apart and creates an execution plan
-			  4) Run the execution plan as a series of syncs.
-		    5) Run all bootstrap nodes as normal syncs to collect manifests and find missing data.
-	*/
 	bootstrappers := getBootstrappers()
 	onlineBootstrappers := Pinger(bootstrappers)
 	if len(onlineBootstrappers) == 0 {
 		logging.Logf(1, "No online bootstrappers were found. Exiting bootstrap.")
 		return
 	}
-	execPlans := constructExecPlans(onlineBootstrappers)
-	// Do a partial sync for everything in the exec plan.
-	errs := []error{}
-	// Go through each remote in the exec plans and call them based on the types we want to pull from it.
-	for key, _ := range execPlans {
-		err := Sync(execPlans[key].addr, execPlans[key].endpoints, nil)
+	b := newBootstrap(onlineBootstrappers)
+	if globals.BackendConfig.GetScaledMode() && len(api.SyncedBoards()) == 0 {
+		// We're not accepting new content, see the scaled mode explanation in Sync. The normal syncs below will only update addresses.
+		logging.Logf(1, "This node is in scaled mode, so the bootstrap is skipping the downloads.")
+	} else {
+		/*----------  Plan  ----------*/
+		b.report("Looking at what bootstrappers have...", -1, nil)
+		b.forEachSource(b.fetchCaches)
+		plans := b.plan()
+		/*----------  Download  ----------*/
+		b.report("Downloading...", -1, nil)
+		b.forEachSource(func(src int) {
+			for _, ca := range plans[src] {
+				err := b.download(src, ca.Endpoint, ca.Cache)
+				b.report("", src, func(p *feobjects.BootstrapSourceProgress) {
+					if err != nil {
+						p.CachesFailed++
+					} else {
+						p.CachesDone++
+					}
+				})
+				if err != nil {
+					logging.Logf(1, "Bootstrap could not download a cache. Endpoint: %s, Cache: %s, Address: %s:%d, Error: %v", ca.Endpoint, ca.Cache.ResponseUrl, b.sources[src].Location, b.sources[src].Port, err)
+				}
+			}
+			b.fetchListings(src)
+		})
+		/*----------  Cross-check and retry  ----------*/
+		b.report("Checking for missing content...", -1, nil)
+		retries := b.crossCheck()
+		b.forEachSource(func(src int) {
+			for _, ca := range retries[src] {
+				err := b.download(src, ca.Endpoint, ca.Cache)
+				if err != nil {
+					logging.Logf(1, "Bootstrap could not download a cache in the retry pass. Endpoint: %s, Cache: %s, Address: %s:%d, Error: %v", ca.Endpoint, ca.Cache.ResponseUrl, b.sources[src].Location, b.sources[src].Port, err)
+				}
+			}
+		})
+		// Anything that was held back because it looked too old, but turned out to be an ancestor of something we got.
+		_, err := persistence.BatchInsert(b.purgatory.Process())
 		if err != nil {
-			errs = append(errs, err)
+			logging.Logf(1, "Purgatory BatchInsert inside Bootstrap has errored out. Error: %v", err)
 		}
 	}
-	// Go through each remote in the exec plan and call all endpoints in them. This should cause a manifest scan and not much download, and a timestamp setting. This is insurance to make sure that the data we have is the union of all bootstrappers we connected to.
-	for key, _ := range execPlans {
-		err := Sync(execPlans[key].addr, []string{}, nil)
-		if err != nil {
-			errs = append(errs, err)
-		}
-	}
-	if len(errs) > 0 {
-		logging.Logf(1, "These were the errors encountered in the bootstrap syncs: Errors: %#v", errs)
-	}
+	/*----------  Normal syncs  ----------*/
+	b.report("Syncing with bootstrappers...", -1, nil)
+	syncAll(onlineBootstrappers)
+	b.report("Complete", -1, nil)
 	globals.BackendConfig.SetLastBootstrapAddressConnectionTimestamp(time.Now().Unix())
 }
 
@@ -0,0 +1,49 @@
+// Backend > Routines > Bundle
+// This file ingests bundles, the file form of a sync. The content of a bundle goes through the same verification, purgatory and batch insert path as the content we receive from a remote over the network.
+
+package dispatch
+
+import (
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/logging"
+	"errors"
+	"fmt"
+)
+
+// IngestBundle verifies the bundle at the given path and inserts its contents. It returns the number of pages that failed verification, those are skipped and the rest is inserted.
+func IngestBundle(path string) (int, error) {
+	b, err := api.ReadBundleFromFile(path)
+	if err != nil {
+		return 0, err
+	}
+	if len(b.NodePublicKey) == 0 {
+		return 0, errors.New("This bundle does not have a node public key, so its pages cannot be verified.")
+	}
+	logging.Logf(1, "Ingesting bundle. Path: %s, Pages: %d, Since: %d, Until: %d", path, len(b.Pages), b.Since, b.Until)
+	resp, errs := b.Verify()
+	for _, err := range errs {
+		logging.Log(1, err)
+	}
+	if len(errs) > 0 && len(errs) == len(b.Pages) {
+		return len(errs), errors.New(fmt.Sprintf("No page of this bundle passed verification. Path: %s", path))
+	}
+	// Same limit as a sync: we accept up to 100 addresses from a single remote.
+	if len(resp.Addresses) > 100 {
+		resp.Addresses = resp.Addresses[0:100]
+	}
+	resp.StripUnsyncedBoards(api.SyncedBoards())
+	p := Purgatory{}
+	p.Filter(&resp) // Older items are held in the purgatory, and only inserted if something newer in this bundle needs them.
+	iface := prepareForBatchInsert(&resp)
+	_, err2 := persistence.BatchInsert(*iface)
+	if err2 != nil {
+		return len(errs), errors.New(fmt.Sprintf("BatchInsert of the bundle has errored out. Error: %v", err2))
+	}
+	_, err3 := persistence.BatchInsert(p.Process())
+	if err3 != nil {
+		return len(errs), errors.New(fmt.Sprintf("Purgatory BatchInsert of the bundle has errored out. Error: %v", err3))
+	}
+	logging.Logf(1, "Bundle ingested. Path: %s", path)
+	return len(errs), nil
+}
@@ -11,6 +11,7 @@ import (
 	"aether-core/services/globals"
 	// "aether-core/services/logging"
 	// tb "aether-core/services/toolbox"
+	"aether-core/services/toolbox"
 	// "aether-core/services/verify"
 	"errors"
 	"fmt"
@@ -63,6 +64,11 @@ func Check(a api.Address, reverseConn *net.Conn) (api.Address, bool, api.ApiResp
 	if !permissible {
 		return api.Address{}, NODE_STATIC, api.ApiResponse{}, directlyConnectible, permissionErr
 	}
+	// Make sure we can talk to it at all before we go any further. What exactly we have in common, Sync looks at.
+	_, negotiationErr := negotiate(apiResp.Address)
+	if negotiationErr != nil {
+		return api.Address{}, NODE_STATIC, api.ApiResponse{}, directlyConnectible, negotiationErr
+	}
 	// if apiResp.NodeId == api.Fingerprint(globals.BackendConfig.GetNodeId()) {
 	/*
 	   This node is using the same NodeId as we do. This is, in most cases, a node connecting to itself over a loopback interface. Most router will not allow their own address to be pinged from within network, but in testing and in other rare occasions this can happen.
@@ -132,6 +138,8 @@ func Check(a api.Address, reverseConn *net.Conn) (api.Address, bool, api.ApiResp
 		directlyConnectible = checkDirectConnectivity(addr, apiResp.NodePublicKey)
 	} else {
 		directlyConnectible = true
+		// Remember who is at this address, so that if the same node is also at an address of the other IP version, we can pick one. (See iptype.go)
+		rememberNodeKey(a, apiResp.NodePublicKey)
 	}
 	return addr, NODE_STATIC, apiResp, directlyConnectible, nil
 }
@@ -158,6 +166,15 @@ func permissible(apiResp api.ApiResponse, a api.Address) (bool, error) {
 	return true, nil
 }
 
+// negotiate finds what we have in common with the remote at this address. (See api.NegotiateCapabilities)
+func negotiate(remote api.Address) (api.Capabilities, error) {
+	caps, err := api.NegotiateCapabilities(api.LocalProtocol(), remote.Protocol)
+	if err != nil {
+		return caps, errors.New(fmt.Sprintf("We could not negotiate with this remote. IP: %s:%d, Error: %v", remote.Location, remote.Port, err))
+	}
+	return caps, nil
+}
+
 // checkDirectConnectivity checks whether the node given is publicly connectable, and is the node it says it is.
 func checkDirectConnectivity(a api.Address, npk string) bool {
 	apiResp, err := api.GetPageRaw(string(a.Location), string(a.Sublocation), a.Port, "node", "GET", []byte{}, nil)
@@ -193,14 +210,8 @@ func insertFirstPartyAddressData(inboundAddrPtr *api.Address, localAddrPtr *api.
 		// Determine IP type from the local address we just used to connect to this remote.
 		addr.Port = localAddrPtr.Port // Because we just connected to this port and it worked. If the remote says it's a different port, it's lying.
 	}
-	ipAddrAsIP := net.ParseIP(string(addr.Location))
-	ipV4Test := ipAddrAsIP.To4()
-	if ipV4Test == nil {
-		// This is an IpV6 address
-		addr.LocationType = 6
-	} else {
-		addr.LocationType = 4
-	}
+	addr.Location = api.Location(toolbox.NormalizeLocation(string(addr.Location)))
+	addr.LocationType = toolbox.IPType(string(addr.Location))
 	addr.LastSuccessfulPing = lastSuccessfulPing
 	addr.EntityVersion = globals.BackendTransientConfig.EntityVersions.Address
 	return &addr
@@ -14,6 +14,7 @@ import (
 	// "github.com/davecgh/go-spew/spew"
 	"github.com/pkg/errors"
 	"strings"
+	"sync"
 	// tb "aether-core/services/toolbox"
 	// "net"
 	"time"
@@ -30,9 +31,21 @@ const (
 	// ^ This is how many times scout will try another node if the node found is too busy. If zero no retries are made, but the main attempt will still go through.
 )
 
-// NeighbourWatch keeps in sync with all our neighbours.
+// NeighbourWatch keeps in sync with all our neighbours. At every tick, it starts a sync for every outbound slot that is free, and does not wait for them to finish. This way, a slow remote only holds up its own slot, and the rest of the slots keep cycling through the neighbourhood at every tick.
 func NeighbourWatch() {
 	logging.Log(2, "NeighbourWatch triggers.")
+	free := inflightSyncs.free()
+	if free <= 0 {
+		logging.Log(2, "NeighbourWatch: All outbound slots are in use. Skipping this tick.")
+		return
+	}
+	for i := 0; i < free; i++ {
+		go watchNeighbour()
+	}
+}
+
+// watchNeighbour pops a neighbour and syncs with it. If the neighbourhood is empty, it scouts for a new one instead.
+func watchNeighbour() {
 	loc, subloc, port := globals.BackendTransientConfig.NeighboursList.Pop()
 	a := api.Address{
 		Location:    api.Location(loc),
@@ -164,6 +177,9 @@ Internal functions
 //////////
 */
 
+// exclusionsLock guards the dispatcher exclusions, since syncs can finish at the same time.
+var exclusionsLock sync.Mutex
+
 func isBlank(a api.Address) bool {
 	return len(a.Location) == 0 &&
 		len(a.Sublocation) == 0 &&
@@ -180,11 +196,29 @@ func connect(a api.Address) error {
 	now := time.Now()
 	// Add to exclusions for a while
 	addrIface := interface{}(a)
+	exclusionsLock.Lock()
 	globals.BackendTransientConfig.DispatcherExclusions[&addrIface] = now
+	exclusionsLock.Unlock()
 	globals.BackendTransientConfig.NeighboursList.Push(string(a.Location), string(a.Sublocation), a.Port)
 	return nil
 }
 
+// syncAll syncs with all of the given addresses, running as many at the same time as the outbound limit allows (see syncTracker), and returns when all of them are done.
+func syncAll(addrs []api.Address) {
+	var wg sync.WaitGroup
+	for key, _ := range addrs {
+		wg.Add(1)
+		go func(a api.Address) {
+			defer wg.Done()
+			err := Sync(a, []string{}, nil)
+			if err != nil {
+				logging.Logf(2, "Sync failed. Address: %#v, Error: %v", a, err)
+			}
+		}(addrs[key])
+	}
+	wg.Wait()
+}
+
 // sameAddress checks if the addresses given are the same
 func sameAddress(a1 *api.Address, a2 *api.Address) bool {
 	if a1.Location == a2.Location && a1.Sublocation == a2.Sublocation && a1.Port == a2.Port {
@@ -24,3 +24,551 @@ fmt.Printf("%#v\n", addresses)
 
 
 */
+
+import (
+	"aether-core/backend/cmd"
+	"aether-core/backend/dispatch"
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/configstore"
+	"aether-core/services/globals"
+	"aether-core/services/signaturing"
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/ed25519"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Infrastructure, setup and teardown
+
+func TestMain(m *testing.M) {
+	cmd.EstablishConfigs(nil)
+	persistence.CreateDatabase()
+	persistence.CheckDatabaseReady()
+	globals.BackendTransientConfig.PermConfigReadOnly = true
+	globals.BackendConfig.SetLoggingLevel(0)
+	// The test remotes are plain HTTP.
+	globals.BackendTransientConfig.TLSEnabled = false
+	// Inserts tell the admin frontend what the backend is doing. There is none here, so point it to a port nothing listens on, which fails the call right away.
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	globals.BackendConfig.SetAdminFrontendAddress(l.Addr().String())
+	l.Close()
+	exitVal := m.Run()
+	// The orphan tests need the parents they ask for to not be in the database from an earlier run.
+	persistence.DeleteDatabase()
+	os.Exit(exitVal)
+}
+
+// testRemote is a local server standing in for a remote node. A slow remote holds every request until it is released. A fast remote fails every request right away, which is enough for a sync to start and end.
+type testRemote struct {
+	srv     *httptest.Server
+	hit     chan bool
+	release chan bool
+}
+
+func newTestRemote(slow bool) *testRemote {
+	r := testRemote{hit: make(chan bool, 100), release: make(chan bool)}
+	r.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.hit <- true
+		if slow {
+			select {
+			case <-r.release:
+			case <-req.Context().Done():
+			}
+		}
+		http.NotFound(w, req)
+	}))
+	return &r
+}
+
+func (r *testRemote) addr() api.Address {
+	host, port, _ := net.SplitHostPort(r.srv.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return api.Address{Location: api.Location(host), Port: uint16(p)}
+}
+
+func (r *testRemote) close() {
+	close(r.release)
+	r.srv.Close()
+}
+
+// A sync with a remote that only gives 404s still takes a few seconds, since it goes through every endpoint.
+const syncTimeout = 20 * time.Second
+
+func waitForHit(r *testRemote, timeout time.Duration) bool {
+	select {
+	case <-r.hit:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func syncInBackground(a api.Address) chan error {
+	done := make(chan error, 1)
+	go func() { done <- dispatch.Sync(a, []string{}, nil) }()
+	return done
+}
+
+// Tests
+
+func TestSync_SlowRemoteDoesNotBlockOthers(t *testing.T) {
+	globals.BackendConfig.SetMaxOutboundConns(2)
+	slow := newTestRemote(true)
+	fast := newTestRemote(false)
+	defer fast.close()
+	slowDone := syncInBackground(slow.addr())
+	if !waitForHit(slow, syncTimeout) {
+		t.Fatalf("Test failed, the sync with the slow remote did not start.")
+	}
+	select {
+	case <-syncInBackground(fast.addr()):
+	case <-time.After(syncTimeout):
+		t.Errorf("Test failed, the sync with the fast remote was held back by the slow remote.")
+	}
+	slow.close()
+	<-slowDone
+}
+
+func TestSync_WaitsForAFreeSlot(t *testing.T) {
+	globals.BackendConfig.SetMaxOutboundConns(1)
+	slow := newTestRemote(true)
+	fast := newTestRemote(false)
+	defer fast.close()
+	slowDone := syncInBackground(slow.addr())
+	if !waitForHit(slow, syncTimeout) {
+		t.Fatalf("Test failed, the sync with the slow remote did not start.")
+	}
+	fastDone := syncInBackground(fast.addr())
+	if waitForHit(fast, 500*time.Millisecond) {
+		t.Errorf("Test failed, the sync with the fast remote started while all outbound slots were in use.")
+	}
+	slow.close()
+	<-slowDone
+	if !waitForHit(fast, syncTimeout) {
+		t.Errorf("Test failed, the sync with the fast remote did not start after the slot was freed.")
+	}
+	<-fastDone
+}
+
+func TestSync_SeveralInParallel(t *testing.T) {
+	globals.BackendConfig.SetMaxOutboundConns(3)
+	remotes := []*testRemote{newTestRemote(true), newTestRemote(true), newTestRemote(true)}
+	dones := []chan error{}
+	for _, r := range remotes {
+		dones = append(dones, syncInBackground(r.addr()))
+	}
+	// All three have to be in flight at the same time, since none of them returns until released.
+	for k, r := range remotes {
+		if !waitForHit(r, syncTimeout) {
+			t.Errorf("Test failed, the sync with the remote %d did not start while the others were ongoing.", k)
+		}
+	}
+	if dispatch.ActiveSyncCount() != 3 {
+		t.Errorf("Test failed, expected: '3' active syncs, got: '%d'", dispatch.ActiveSyncCount())
+	}
+	for k, r := range remotes {
+		r.close()
+		<-dones[k]
+	}
+	if dispatch.ActiveSyncCount() != 0 {
+		t.Errorf("Test failed, expected: '0' active syncs, got: '%d'", dispatch.ActiveSyncCount())
+	}
+}
+
+func TestSync_SameRemoteTwice(t *testing.T) {
+	globals.BackendConfig.SetMaxOutboundConns(2)
+	slow := newTestRemote(true)
+	slowDone := syncInBackground(slow.addr())
+	if !waitForHit(slow, syncTimeout) {
+		t.Fatalf("Test failed, the sync with the slow remote did not start.")
+	}
+	err := dispatch.Sync(slow.addr(), []string{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "found an ongoing sync with this remote") {
+		t.Errorf("Test failed, expected an ongoing sync error, got: '%v'", err)
+	}
+	slow.close()
+	<-slowDone
+}
+
+// Bootstrap tests
+
+func rc(url string, start, end int64) api.ResultCache {
+	return api.ResultCache{ResponseUrl: url, StartsFrom: api.Timestamp(start), EndsAt: api.Timestamp(end)}
+}
+
+func TestPlanBootstrapCaches_SpreadsAcrossSources(t *testing.T) {
+	caches := [][]api.ResultCache{
+		[]api.ResultCache{rc("a0", 0, 10), rc("a1", 10, 20), rc("a2", 20, 30), rc("a3", 30, 40)},
+		[]api.ResultCache{rc("b0", 0, 10), rc("b1", 10, 20), rc("b2", 20, 30), rc("b3", 30, 40)},
+	}
+	load := []int{0, 0}
+	plan := dispatch.PlanBootstrapCaches("posts", caches, load)
+	if len(plan) != 4 {
+		t.Fatalf("Test failed, expected: '4' caches, got: '%d'", len(plan))
+	}
+	if load[0] != 2 || load[1] != 2 {
+		t.Errorf("Test failed, expected the load to be split evenly, got: '%v'", load)
+	}
+	// Consecutive caches, no gaps.
+	for k := 1; k < len(plan); k++ {
+		if plan[k].Cache.StartsFrom != plan[k-1].Cache.EndsAt {
+			t.Errorf("Test failed, the plan has a gap or an overlap at: '%d', plan: '%v'", k, plan)
+		}
+	}
+}
+
+func TestPlanBootstrapCaches_CoversWhatOnlyOneSourceHas(t *testing.T) {
+	caches := [][]api.ResultCache{
+		[]api.ResultCache{rc("a0", 0, 10)},
+		[]api.ResultCache{rc("b0", 0, 10), rc("b1", 10, 20)},
+		[]api.ResultCache{rc("c0", 30, 40)},
+	}
+	plan := dispatch.PlanBootstrapCaches("posts", caches, []int{0, 0, 0})
+	urls := map[string]bool{}
+	for _, ca := range plan {
+		urls[ca.Cache.ResponseUrl] = true
+	}
+	if !urls["b1"] || !urls["c0"] || (!urls["a0"] && !urls["b0"]) {
+		t.Errorf("Test failed, the plan does not cover the whole range, plan: '%v'", plan)
+	}
+	if len(plan) != 3 {
+		t.Errorf("Test failed, expected: '3' caches, got: '%d'", len(plan))
+	}
+}
+
+func TestPlanBootstrapCaches_Empty(t *testing.T) {
+	plan := dispatch.PlanBootstrapCaches("posts", [][]api.ResultCache{[]api.ResultCache{}}, []int{0})
+	if len(plan) != 0 {
+		t.Errorf("Test failed, expected an empty plan, got: '%v'", plan)
+	}
+}
+
+func TestFindWithheld(t *testing.T) {
+	c := rc("x", 0, 10)
+	full := dispatch.SourceListing{
+		Caches: []api.ResultCache{c},
+		Entities: map[api.Fingerprint]dispatch.EntityListing{
+			"e1": dispatch.EntityListing{Cache: c},
+			"e2": dispatch.EntityListing{Cache: c},
+		},
+	}
+	// Covers the same range, but lists only one of the two.
+	partial := dispatch.SourceListing{
+		Caches: []api.ResultCache{rc("y0", 0, 5), rc("y1", 5, 12)},
+		Entities: map[api.Fingerprint]dispatch.EntityListing{
+			"e1": dispatch.EntityListing{Cache: rc("y0", 0, 5)},
+		},
+	}
+	// Does not cover the range at all, so it can't be withholding.
+	elsewhere := dispatch.SourceListing{
+		Caches:   []api.ResultCache{rc("z", 20, 30)},
+		Entities: map[api.Fingerprint]dispatch.EntityListing{},
+	}
+	withheld := dispatch.FindWithheld([]dispatch.SourceListing{full, partial, elsewhere})
+	if withheld[0] != 0 || withheld[1] != 1 || withheld[2] != 0 {
+		t.Errorf("Test failed, expected: '[0 1 0]', got: '%v'", withheld)
+	}
+}
+
+// Seed tests
+
+type fakeResolver struct {
+	txts map[string][]string
+	srvs map[string][]*net.SRV
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	txts, ok := r.txts[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return txts, nil
+}
+
+func (r *fakeResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	srvs, ok := r.srvs[name]
+	if !ok {
+		return "", nil, errors.New("no such host")
+	}
+	return "", srvs, nil
+}
+
+func seedKey(t *testing.T) (*ed25519.PrivateKey, string) {
+	privKey, err := signaturing.CreateKeyPair()
+	if err != nil {
+		t.Fatalf("Test failed, err: '%s'", err)
+	}
+	return privKey, signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
+}
+
+func signedTXT(t *testing.T, privKey *ed25519.PrivateKey, entries []string) string {
+	signed, err := dispatch.SignSeedList(entries, privKey)
+	if err != nil {
+		t.Fatalf("Test failed, err: '%s'", err)
+	}
+	return "aether-seeds " + strings.Join(strings.Fields(signed), " ")
+}
+
+func TestResolveDNSSeeds_TXTAndSRV(t *testing.T) {
+	privKey, _ := seedKey(t)
+	dispatch.DNSSeedResolver = &fakeResolver{
+		txts: map[string][]string{"seeds.example.org": []string{
+			"v=spf1 -all",
+			signedTXT(t, privKey, []string{"203.0.113.5:49999", "node.example.org:49998"}),
+		}},
+		srvs: map[string][]*net.SRV{"seeds.example.org": []*net.SRV{
+			&net.SRV{Target: "srv.example.org.", Port: 49997},
+		}},
+	}
+	defer func() { dispatch.DNSSeedResolver = net.DefaultResolver }()
+	seeds := dispatch.ResolveDNSSeeds([]string{"seeds.example.org", "nonexistent.example.org"}, "")
+	if len(seeds) != 3 {
+		t.Fatalf("Test failed, expected: '3' seeds, got: '%v'", seeds)
+	}
+	if seeds[0].Location != "203.0.113.5" || seeds[0].Port != 49999 ||
+		seeds[1].Location != "node.example.org" || seeds[2].Location != "srv.example.org" || seeds[2].Port != 49997 {
+		t.Errorf("Test failed, got: '%v'", seeds)
+	}
+}
+
+func TestResolveDNSSeeds_PublicKeyRequiresSignature(t *testing.T) {
+	privKey, pubKey := seedKey(t)
+	otherPrivKey, _ := seedKey(t)
+	dispatch.DNSSeedResolver = &fakeResolver{
+		txts: map[string][]string{"seeds.example.org": []string{
+			"aether-seeds 198.51.100.1:49999",
+			signedTXT(t, otherPrivKey, []string{"198.51.100.2:49999"}),
+			signedTXT(t, privKey, []string{"203.0.113.5:49999"}),
+		}},
+		srvs: map[string][]*net.SRV{"seeds.example.org": []*net.SRV{
+			&net.SRV{Target: "srv.example.org.", Port: 49997},
+		}},
+	}
+	defer func() { dispatch.DNSSeedResolver = net.DefaultResolver }()
+	// Only the list signed with the configured key, and no SRV.
+	seeds := dispatch.ResolveDNSSeeds([]string{"seeds.example.org"}, pubKey)
+	if len(seeds) != 1 || seeds[0].Location != "203.0.113.5" {
+		t.Errorf("Test failed, expected only the signed seed, got: '%v'", seeds)
+	}
+}
+
+func TestReadSeedsFile(t *testing.T) {
+	privKey, pubKey := seedKey(t)
+	dir, _ := ioutil.TempDir("", "aether-seeds")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "seeds.txt")
+	signed, _ := dispatch.SignSeedList([]string{"# Our seeds", "203.0.113.5:49999", "", "node.example.org:49998"}, privKey)
+	ioutil.WriteFile(path, []byte(signed), 0600)
+	seeds, err := dispatch.ReadSeedsFile(path, pubKey)
+	if err != nil || len(seeds) != 2 {
+		t.Errorf("Test failed, expected: '2' seeds, got: '%v', err: '%v'", seeds, err)
+	}
+	// Tampered: an entry added after signing.
+	ioutil.WriteFile(path, []byte("198.51.100.1:49999\n"+signed), 0600)
+	_, err2 := dispatch.ReadSeedsFile(path, pubKey)
+	if err2 == nil {
+		t.Errorf("Test failed, a tampered seeds file was accepted.")
+	}
+	// Missing file is no seeds, not an error.
+	seeds3, err3 := dispatch.ReadSeedsFile(filepath.Join(dir, "nonexistent.txt"), pubKey)
+	if err3 != nil || len(seeds3) != 0 {
+		t.Errorf("Test failed, expected no seeds and no error, got: '%v', err: '%v'", seeds3, err3)
+	}
+}
+
+func TestApplyIpTypePreference(t *testing.T) {
+	v4 := api.Address{Location: "203.0.113.5", Port: 49999}
+	v6 := api.Address{Location: "2001:db8::5", Port: 49999}
+	otherV4 := api.Address{Location: "198.51.100.7", Port: 49999}
+	otherV6 := api.Address{Location: "2001:db8::7", Port: 49999}
+	keys := map[api.Location]string{v4.Location: "node-a", v6.Location: "node-a"}
+	keyOf := func(a *api.Address) string { return keys[a.Location] }
+	addrs := []api.Address{v4, otherV4, v6, otherV6}
+	check := func(pref string, expected []api.Address) {
+		result := dispatch.ApplyIpTypePreference(addrs, pref, keyOf)
+		if len(result) != len(expected) {
+			t.Errorf("The preference %s gave the wrong addresses. Expected: %v, Got: %v", pref, expected, result)
+			return
+		}
+		for key, _ := range expected {
+			if result[key].Location != expected[key].Location {
+				t.Errorf("The preference %s gave the wrong addresses. Expected: %v, Got: %v", pref, expected, result)
+				return
+			}
+		}
+	}
+	check(configstore.IpTypePreferIPv6, []api.Address{otherV4, v6, otherV6})
+	check(configstore.IpTypePreferIPv4, []api.Address{v4, otherV4, otherV6})
+	check(configstore.IpTypeIPv4Only, []api.Address{v4, otherV4})
+	check(configstore.IpTypeIPv6Only, []api.Address{v6, otherV6})
+}
+
+// Orphan tests
+
+func orphanPost(fp, board, thread, parent api.Fingerprint) api.Post {
+	var p api.Post
+	p.SetVerified(true)
+	p.Fingerprint = fp
+	p.Body = "body"
+	p.EntityVersion = 1
+	p.Owner = "owner"
+	p.OwnerPublicKey = "ownerpk"
+	p.Creation = 1
+	p.Signature = "sig"
+	p.ProofOfWork = "pow"
+	p.Board = board
+	p.Thread = thread
+	p.Parent = parent
+	return p
+}
+
+// The parents we have are checked against the database in chunks, all of them, so that they don't crowd out the ones we don't have. The max applies to what is returned, and what doesn't fit stays for later.
+func TestOrphanResolver_Missing_ChecksAllParents(t *testing.T) {
+	have := []interface{}{}
+	children := []interface{}{}
+	for i := 0; i < 600; i++ {
+		pfp := api.Fingerprint(fmt.Sprintf("orphan-chunk-have-%d", i))
+		have = append(have, orphanPost(pfp, "orphan-chunk-board", "orphan-chunk-thread", "orphan-chunk-thread"))
+		children = append(children, orphanPost(api.Fingerprint(fmt.Sprintf("orphan-chunk-child-%d", i)), "orphan-chunk-board", "orphan-chunk-thread", pfp))
+	}
+	for i := 0; i < 3; i++ {
+		children = append(children, orphanPost(api.Fingerprint(fmt.Sprintf("orphan-chunk-lonely-%d", i)), "orphan-chunk-board", "orphan-chunk-thread", api.Fingerprint(fmt.Sprintf("orphan-chunk-missing-%d", i))))
+	}
+	_, err := persistence.BatchInsert(have)
+	if err != nil {
+		t.Fatalf("Test failed, the parents could not be inserted. Err: '%s'", err)
+	}
+	o := dispatch.NewOrphanResolver()
+	o.Note(children)
+	// The board, the thread, and the three posts.
+	first := o.Missing(2)
+	if len(first) != 2 {
+		t.Errorf("Test failed, expected 2 missing parents, got: '%v'", first)
+	}
+	rest := o.Missing(100)
+	if len(rest) != 3 {
+		t.Errorf("Test failed, expected the 3 missing parents that did not fit before, got: '%v'", rest)
+	}
+	seen := make(map[api.Fingerprint]bool)
+	for _, fp := range append(first, rest...) {
+		if strings.HasPrefix(string(fp), "orphan-chunk-have-") || seen[fp] {
+			t.Errorf("Test failed, a parent we have, or one we already returned, was returned as missing: '%s'", fp)
+		}
+		seen[fp] = true
+	}
+	if again := o.Missing(100); len(again) != 0 {
+		t.Errorf("Test failed, expected no more missing parents, got: '%v'", again)
+	}
+}
+
+func TestOrphanResolver_Resolve_Budget(t *testing.T) {
+	children := []interface{}{}
+	for i := 0; i < 300; i++ {
+		children = append(children, orphanPost(api.Fingerprint(fmt.Sprintf("orphan-budget-child-%d", i)), "orphan-budget-board", "orphan-budget-thread", api.Fingerprint(fmt.Sprintf("orphan-budget-missing-%d", i))))
+	}
+	o := dispatch.NewOrphanResolver()
+	o.Note(children)
+	asked := 0
+	o.Resolve(func(fps []api.Fingerprint) (api.Response, error) {
+		if len(fps) > api.MaxEntitiesPerRequest {
+			t.Errorf("Test failed, %d fingerprints were asked for in one request.", len(fps))
+		}
+		asked = asked + len(fps)
+		return api.Response{}, nil
+	})
+	if asked != 256 {
+		t.Errorf("Test failed, expected 256 parents to be asked for, got: %d", asked)
+	}
+}
+
+func TestOrphanResolver_Resolve_RoundLimit(t *testing.T) {
+	remote := make(map[api.Fingerprint]api.Post)
+	for i := 1; i < 10; i++ {
+		parent := api.Fingerprint(fmt.Sprintf("orphan-round-%d", i-1))
+		if i == 1 {
+			parent = "orphan-round-thread"
+		}
+		fp := api.Fingerprint(fmt.Sprintf("orphan-round-%d", i))
+		remote[fp] = orphanPost(fp, "orphan-round-board", "orphan-round-thread", parent)
+	}
+	o := dispatch.NewOrphanResolver()
+	o.Note([]interface{}{orphanPost("orphan-round-10", "orphan-round-board", "orphan-round-thread", "orphan-round-9")})
+	asked := make(map[api.Fingerprint]bool)
+	o.Resolve(func(fps []api.Fingerprint) (api.Response, error) {
+		var r api.Response
+		for _, fp := range fps {
+			asked[fp] = true
+			if p, ok := remote[fp]; ok {
+				r.Posts = append(r.Posts, p)
+			}
+		}
+		return r, nil
+	})
+	// Five rounds: from 9 up to 5.
+	for i := 5; i < 10; i++ {
+		if !asked[api.Fingerprint(fmt.Sprintf("orphan-round-%d", i))] {
+			t.Errorf("Test failed, the parent %d levels up was not asked for.", 10-i)
+		}
+	}
+	if asked["orphan-round-4"] {
+		t.Errorf("Test failed, the resolver went up more than %d levels.", 5)
+	}
+}
+
+// Gossip tests
+
+func freshAnnouncement(prefix string, count int) api.Answer {
+	var a api.Answer
+	now := api.Timestamp(time.Now().Unix())
+	for i := 0; i < count; i++ {
+		a.PostIndexes = append(a.PostIndexes, api.PostIndex{Fingerprint: api.Fingerprint(fmt.Sprintf("%s-%d", prefix, i)), Creation: now})
+	}
+	return a
+}
+
+// The limits of receiving are shared over the whole window, so these two tests are written to hold whichever runs first.
+func TestReceiveAnnouncement_PerRemoteLimit(t *testing.T) {
+	r := newTestRemote(false)
+	defer r.close()
+	for i := 0; i < 20; i++ {
+		if n := dispatch.ReceiveAnnouncement(r.addr(), freshAnnouncement(fmt.Sprintf("gossip-perremote-%d", i), 1)); n != 1 {
+			t.Fatalf("Test failed, announcement %d within the limit of the remote was not fetched, got: %d", i, n)
+		}
+	}
+	if n := dispatch.ReceiveAnnouncement(r.addr(), freshAnnouncement("gossip-perremote-over", 1)); n != 0 {
+		t.Errorf("Test failed, an announcement over the limit of the remote was fetched, got: %d", n)
+	}
+}
+
+func TestReceiveAnnouncement_FetchLimit(t *testing.T) {
+	// Nothing listens at these, so that the fetches fail right away. They are at different locations, so that the limits of the remotes are separate.
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	l.Close()
+	p, _ := strconv.Atoi(port)
+	addrOf := func(i int) api.Address {
+		return api.Address{Location: api.Location(fmt.Sprintf("127.0.0.%d", i+10)), Port: uint16(p)}
+	}
+	first := dispatch.ReceiveAnnouncement(addrOf(0), freshAnnouncement("gossip-fetchlimit-0", api.MaxAnnouncedEntities))
+	if first != api.MaxAnnouncedEntities {
+		t.Errorf("Test failed, expected %d to be fetched, got: %d", api.MaxAnnouncedEntities, first)
+	}
+	second := dispatch.ReceiveAnnouncement(addrOf(1), freshAnnouncement("gossip-fetchlimit-1", api.MaxAnnouncedEntities))
+	if second <= 0 || second >= api.MaxAnnouncedEntities {
+		t.Errorf("Test failed, expected a partial grant of the fetches that are left in the window, got: %d", second)
+	}
+	if third := dispatch.ReceiveAnnouncement(addrOf(2), freshAnnouncement("gossip-fetchlimit-2", 1)); third != 0 {
+		t.Errorf("Test failed, a fetch over the limit was made, got: %d", third)
+	}
+}
@@ -34,9 +34,7 @@ func Explore() {
 			logging.Logf(1, "There was an error when we tried to read static bootstrapper addresses for Explore schedule. Error: %#v", err2)
 		}
 		bsAddrs := append(liveBs, staticBs...)
-		for key, _ := range bsAddrs {
-			Sync(bsAddrs[key], []string{}, nil)
-		}
+		syncAll(bsAddrs)
 		// call all CA nodes and sync with them. These should be fairly short. We are not limiting them to x number of CAs because each CA will likely have their own data only. (We terminate the connection without sync if it's a CA that we do not trust.)
 		liveCA, err3 := persistence.ReadAddresses("", "", 0, 0, 0, 0, 0, 4, "limit")
 		if err3 != nil {
@@ -47,9 +45,7 @@ func Explore() {
 			logging.Logf(1, "There was an error when we tried to read static CA addresses for Explore schedule. Error: %#v", err4)
 		}
 		caAddrs := append(liveCA, staticCA...)
-		for key, _ := range caAddrs {
-			Sync(caAddrs[key], []string{}, nil)
-		}
+		syncAll(caAddrs)
 	} else if ticker%6 == 0 && ticker != 0 {
 
 		////////////////////////////////////////////
@@ -62,9 +58,7 @@ func Explore() {
 		if err != nil {
 			logging.Logf(1, "There was an error when we tried to read static addresses for Explore schedule. Error: %#v", err)
 		}
-		for key, _ := range statics {
-			Sync(statics[key], []string{}, nil)
-		}
+		syncAll(statics)
 	} else {
 		// find a new node that we haven't synced before, and sync with it.
 		Scout(nil)
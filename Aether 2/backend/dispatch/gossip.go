@@ -0,0 +1,351 @@
+// Backend > Dispatch > Gossip
+// This file pushes the new entities we get to our neighbours, and fetches the ones they push to us.
+
+package dispatch
+
+import (
+	"aether-core/backend/responsegenerator"
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"aether-core/services/rollingbloom"
+	"fmt"
+	"sync"
+	"time"
+)
+
+/*
+How does gossip work?
+
+Without it, a new post reaches another node only when that node syncs with us next, or with somebody who synced with us. That takes minutes, and more than one hop takes more.
+
+With it, when we get new entities, from a frontend (SendMintedContent) or from a sync, we announce them to our neighbours. An announcement has only the index forms of the entities, not the entities themselves. A neighbour that doesn't have an entity fetches it from us (see api.GetEntities), inserts it, and announces it to its own neighbours in turn.
+
+What keeps this from flooding the network:
+
+- Only the fresh entities are announced. Entities we get in the initial sync, or older ones that we were missing, reach the others with the usual sync.
+
+- Every node remembers what it has announced in a rolling bloom, and announces every entity once. That's also what ends the gossip: an entity comes back to a node that has announced it already, and it stops there.
+
+- The announcements a remote can send us, the announcements we send, and the fetches we make are all rate limited. What doesn't fit in the limits is dropped, it'll arrive with the next sync anyway.
+
+- A fetch takes an outbound lease from the bouncer, like a sync.
+
+The announcements go only to the neighbours (see NeighboursList), not to every node we know. Gossip is opt-in, see GossipEnabled in the config.
+*/
+
+const (
+	// Entities older than this are left to the sync.
+	gossipFreshness = 1 * time.Hour
+	// The rolling bloom of what we have announced. It's a day long, and a day in a bucket.
+	gossipSeenDays   = 1
+	gossipSeenSize   = 100000
+	gossipSeenFPRate = 0.001
+	// Limits, per minute.
+	maxAnnouncementsSentPerMinute     = 30
+	maxAnnouncementsReceivedPerRemote = 20
+	maxGossipFetchesPerMinute         = 120
+	maxConcurrentGossipFetches        = 4
+	gossipRateWindow                  = 1 * time.Minute
+)
+
+var gossipSeen = rollingbloom.NewRollingBloomWithRate(gossipSeenDays, gossipSeenDays, gossipSeenSize, gossipSeenFPRate)
+
+/*----------  Rate limits  ----------*/
+
+// gossipLimiter counts the uses of a limit in the current window. The counts start over in the next window.
+type gossipLimiter struct {
+	lock        sync.Mutex
+	window      time.Duration
+	windowStart time.Time
+	counts      map[string]int
+}
+
+func newGossipLimiter(window time.Duration) *gossipLimiter {
+	return &gossipLimiter{window: window, counts: make(map[string]int)}
+}
+
+var gossipLimits = newGossipLimiter(gossipRateWindow)
+
+// take returns up to n of the uses of the limit under the key, as many as are left in this window.
+func (l *gossipLimiter) take(key string, n int, limit int) int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if time.Since(l.windowStart) > l.window {
+		l.windowStart = time.Now()
+		l.counts = make(map[string]int)
+	}
+	left := limit - l.counts[key]
+	if left < 0 {
+		left = 0
+	}
+	if n > left {
+		n = left
+	}
+	l.counts[key] = l.counts[key] + n
+	return n
+}
+
+/*----------  In-flight fetches  ----------*/
+
+type gossipFetchList struct {
+	lock  sync.Mutex
+	items map[string]bool
+	slots chan struct{}
+}
+
+var gossipFetches = gossipFetchList{items: make(map[string]bool), slots: make(chan struct{}, maxConcurrentGossipFetches)}
+
+// claim returns false if the entity is being fetched already.
+func (l *gossipFetchList) claim(key string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.items[key] {
+		return false
+	}
+	l.items[key] = true
+	return true
+}
+
+func (l *gossipFetchList) release(key string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.items, key)
+}
+
+/*----------  Announcing  ----------*/
+
+func gossipKey(entityType string, fp api.Fingerprint, lastUpdate api.Timestamp) string {
+	return fmt.Sprintf("%s:%s:%d", entityType, fp, lastUpdate)
+}
+
+func isFresh(creation, lastUpdate api.Timestamp) bool {
+	cutoff := api.Timestamp(time.Now().Add(-gossipFreshness).Unix())
+	return creation > cutoff || lastUpdate > cutoff
+}
+
+// Announce announces the fresh entities among the given ones to our neighbours, if gossip is enabled. The items are in the form BatchInsert takes. It returns right away, the announcements are sent in the background.
+func Announce(items []interface{}) {
+	announce(items, "")
+}
+
+// announce is Announce, leaving out the remote at the given location. That's the remote that announced the entities to us.
+func announce(items []interface{}, except api.Location) {
+	if !globals.BackendConfig.GetGossipEnabled() {
+		return
+	}
+	var r api.Response
+	announced := 0
+	for key, _ := range items {
+		if announced >= api.MaxAnnouncedEntities {
+			logging.Logf(2, "Gossip: There are more fresh entities than fit in an announcement. The rest will reach the neighbours with the sync.")
+			break
+		}
+		switch e := items[key].(type) {
+		case api.Board:
+			if e.Verified && isFresh(e.Creation, e.LastUpdate) && markAnnounced("board", e.Fingerprint, e.LastUpdate) {
+				r.Boards = append(r.Boards, e)
+				announced++
+			}
+		case api.Thread:
+			if e.Verified && isFresh(e.Creation, e.LastUpdate) && markAnnounced("thread", e.Fingerprint, e.LastUpdate) {
+				r.Threads = append(r.Threads, e)
+				announced++
+			}
+		case api.Post:
+			if e.Verified && isFresh(e.Creation, e.LastUpdate) && markAnnounced("post", e.Fingerprint, e.LastUpdate) {
+				r.Posts = append(r.Posts, e)
+				announced++
+			}
+		case api.Vote:
+			if e.Verified && isFresh(e.Creation, e.LastUpdate) && markAnnounced("vote", e.Fingerprint, e.LastUpdate) {
+				r.Votes = append(r.Votes, e)
+				announced++
+			}
+		case api.Key:
+			if e.Verified && isFresh(e.Creation, e.LastUpdate) && markAnnounced("key", e.Fingerprint, e.LastUpdate) {
+				r.Keys = append(r.Keys, e)
+				announced++
+			}
+		case api.Truststate:
+			if e.Verified && isFresh(e.Creation, e.LastUpdate) && markAnnounced("truststate", e.Fingerprint, e.LastUpdate) {
+				r.Truststates = append(r.Truststates, e)
+				announced++
+			}
+		}
+	}
+	announcement := responsegenerator.CreateAnnouncement(&r)
+	if api.AnnouncementSize(&announcement) == 0 {
+		return
+	}
+	neighbours := globals.BackendTransientConfig.NeighboursList.Addresses()
+	go func() {
+		sent := 0
+		for _, n := range neighbours {
+			if api.Location(n.Location) == except {
+				continue
+			}
+			if gossipLimits.take("sent", 1, maxAnnouncementsSentPerMinute) == 0 {
+				logging.Logf(2, "Gossip: We are at the limit of announcements we can send. The rest of the neighbours will get these entities with the sync.")
+				break
+			}
+			err := api.Announce(n.Location, n.Sublocation, n.Port, announcement, nil)
+			if err != nil {
+				logging.Logf(2, "Gossip: Announcing to the neighbour %s:%d failed. Error: %v", n.Location, n.Port, err)
+				continue
+			}
+			sent++
+		}
+		logging.Logf(2, "Gossip: Announced %d entities to %d neighbours.", api.AnnouncementSize(&announcement), sent)
+	}()
+}
+
+// markAnnounced returns true if the entity wasn't announced before, and marks it as announced.
+func markAnnounced(entityType string, fp api.Fingerprint, lastUpdate api.Timestamp) bool {
+	k := gossipKey(entityType, fp, lastUpdate)
+	if gossipSeen.TestString(k) {
+		return false
+	}
+	gossipSeen.AddString(k)
+	return true
+}
+
+// trimAnnouncement leaves at most max entities in the announcement. The boards and the keys go first, since the rest need them.
+func trimAnnouncement(a *api.Answer, max int) {
+	left := max
+	take := func(n int) int {
+		if n > left {
+			n = left
+		}
+		left = left - n
+		return n
+	}
+	a.BoardIndexes = a.BoardIndexes[:take(len(a.BoardIndexes))]
+	a.KeyIndexes = a.KeyIndexes[:take(len(a.KeyIndexes))]
+	a.ThreadIndexes = a.ThreadIndexes[:take(len(a.ThreadIndexes))]
+	a.PostIndexes = a.PostIndexes[:take(len(a.PostIndexes))]
+	a.VoteIndexes = a.VoteIndexes[:take(len(a.VoteIndexes))]
+	a.TruststateIndexes = a.TruststateIndexes[:take(len(a.TruststateIndexes))]
+}
+
+/*----------  Receiving  ----------*/
+
+// ReceiveAnnouncement takes the announcement a remote has sent us, and fetches the entities in it that we don't have, from that remote. It returns how many entities it fetches. It returns right away, the fetches happen in the background.
+func ReceiveAnnouncement(from api.Address, announcement api.Answer) int {
+	if gossipLimits.take(fmt.Sprint("received:", from.Location), 1, maxAnnouncementsReceivedPerRemote) == 0 {
+		logging.Logf(2, "Gossip: The remote %s:%d is over its limit of announcements. Ignored.", from.Location, from.Port)
+		return 0
+	}
+	trimAnnouncement(&announcement, api.MaxAnnouncedEntities)
+	queries := []api.QueryData{}
+	for _, e := range announcement.BoardIndexes {
+		queries = appendIfMissing(queries, "board", e.Fingerprint, e.Creation, e.LastUpdate)
+	}
+	for _, e := range announcement.KeyIndexes {
+		queries = appendIfMissing(queries, "key", e.Fingerprint, e.Creation, e.LastUpdate)
+	}
+	for _, e := range announcement.ThreadIndexes {
+		queries = appendIfMissing(queries, "thread", e.Fingerprint, e.Creation, e.LastUpdate)
+	}
+	for _, e := range announcement.PostIndexes {
+		queries = appendIfMissing(queries, "post", e.Fingerprint, e.Creation, e.LastUpdate)
+	}
+	for _, e := range announcement.VoteIndexes {
+		queries = appendIfMissing(queries, "vote", e.Fingerprint, e.Creation, e.LastUpdate)
+	}
+	for _, e := range announcement.TruststateIndexes {
+		queries = appendIfMissing(queries, "truststate", e.Fingerprint, e.Creation, e.LastUpdate)
+	}
+	if len(queries) == 0 {
+		return 0
+	}
+	allowed := gossipLimits.take("fetched", len(queries), maxGossipFetchesPerMinute)
+	if allowed < len(queries) {
+		logging.Logf(2, "Gossip: We are at the limit of fetches. %d of the announced entities are left to the sync.", len(queries)-allowed)
+	}
+	queries = queries[:allowed]
+	if len(queries) == 0 {
+		return 0
+	}
+	go fetchAnnounced(from, queries)
+	return len(queries)
+}
+
+// appendIfMissing adds the query for the entity if it's fresh, and if we neither have nor announced it. The query is in the plural form of the entity type, the way api.Query takes it.
+func appendIfMissing(queries []api.QueryData, entityType string, fp api.Fingerprint, creation, lastUpdate api.Timestamp) []api.QueryData {
+	if !isFresh(creation, lastUpdate) {
+		return queries
+	}
+	if gossipSeen.TestString(gossipKey(entityType, fp, lastUpdate)) {
+		return queries
+	}
+	if api.ExistsInDB(entityType, fp, lastUpdate) {
+		return queries
+	}
+	return append(queries, api.QueryData{
+		EntityType:  entityType + "s",
+		Fingerprint: fp,
+		Creation:    creation,
+		LastUpdate:  lastUpdate,
+	})
+}
+
+// fetchAnnounced fetches the entities in the queries from the remote that announced them, inserts them, and announces them to our own neighbours.
+func fetchAnnounced(from api.Address, queries []api.QueryData) {
+	gossipFetches.slots <- struct{}{}
+	defer func() { <-gossipFetches.slots }()
+	allowed, leaseTerminator, _ := isAllowed(from, nil)
+	if !allowed {
+		return
+	}
+	wasSuccessful := false
+	defer leaseTerminator(&wasSuccessful)
+	host, subhost, port := string(from.Location), string(from.Sublocation), from.Port
+	claimed := []api.QueryData{}
+	fps := []api.Fingerprint{}
+	for _, q := range queries {
+		if gossipFetches.claim(gossipKey(q.EntityType, q.Fingerprint, q.LastUpdate)) {
+			claimed = append(claimed, q)
+			fps = append(fps, q.Fingerprint)
+		}
+	}
+	defer func() {
+		for _, q := range claimed {
+			gossipFetches.release(gossipKey(q.EntityType, q.Fingerprint, q.LastUpdate))
+		}
+	}()
+	if len(claimed) == 0 {
+		return
+	}
+	resp, err := api.GetEntities(host, subhost, port, fps, nil)
+	if err != nil {
+		// The remote might be from before the entities endpoint. Those we can only ask one by one.
+		logging.Logf(2, "Gossip: Fetching the announced entities failed, falling back to queries. Remote: %s:%d, Error: %v", host, port, err)
+		resp = api.Response{}
+		for _, q := range claimed {
+			r, err2 := api.QueryFresh(host, subhost, port, q, nil)
+			if err2 != nil {
+				logging.Logf(2, "Gossip: Fetching an announced entity failed. Remote: %s:%d, Query: %#v, Error: %v", host, port, q, err2)
+				continue
+			}
+			resp.Insert(&r)
+		}
+	}
+	if resp.Empty() {
+		return
+	}
+	wasSuccessful = true
+	iface := prepareForBatchInsert(&resp)
+	_, err3 := persistence.BatchInsertAccepted(iface)
+	if err3 != nil {
+		logging.Logf(1, "Gossip BatchInsert has errored out. Error: %v", err3)
+		return
+	}
+	logging.Logf(2, "Gossip: Fetched and inserted %d announced entities from %s:%d.", len(*iface), host, port)
+	announce(*iface, from.Location)
+	// A reply announced to us can be to a post we don't have. The remote that announced it has the parent.
+	o := NewOrphanResolver()
+	o.Note(*iface)
+	o.Resolve(RemoteEntityFetcher(from, nil))
+}
@@ -0,0 +1,61 @@
+// Unlike the rest of the dispatch tests, these are not in dispatch_test because the gossip limits and the announcement helpers are internal to the package.
+
+package dispatch
+
+import (
+	"aether-core/io/api"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGossipLimiter_Take(t *testing.T) {
+	l := newGossipLimiter(100 * time.Millisecond)
+	if n := l.take("a", 3, 5); n != 3 {
+		t.Errorf("Test failed, expected 3, got: %d", n)
+	}
+	// Only 2 are left, so only 2 are given.
+	if n := l.take("a", 3, 5); n != 2 {
+		t.Errorf("Test failed, expected a partial grant of 2, got: %d", n)
+	}
+	if n := l.take("a", 1, 5); n != 0 {
+		t.Errorf("Test failed, expected 0, got: %d", n)
+	}
+	if n := l.take("b", 1, 5); n != 1 {
+		t.Errorf("Test failed, another key should have its own limit, got: %d", n)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if n := l.take("a", 5, 5); n != 5 {
+		t.Errorf("Test failed, the limit should start over in the next window, got: %d", n)
+	}
+}
+
+func TestMarkAnnounced(t *testing.T) {
+	if !markAnnounced("post", "gossip-mark-1", 10) {
+		t.Errorf("Test failed, an entity that was never announced was reported as announced.")
+	}
+	if markAnnounced("post", "gossip-mark-1", 10) {
+		t.Errorf("Test failed, an entity was announced twice.")
+	}
+	if !markAnnounced("post", "gossip-mark-1", 20) {
+		t.Errorf("Test failed, an update of an announced entity was not announced.")
+	}
+	if !markAnnounced("thread", "gossip-mark-1", 10) {
+		t.Errorf("Test failed, an entity of another type with the same fingerprint was not announced.")
+	}
+}
+
+func TestTrimAnnouncement(t *testing.T) {
+	var a api.Answer
+	for i := 0; i < 3; i++ {
+		fp := api.Fingerprint(fmt.Sprintf("gossip-trim-%d", i))
+		a.BoardIndexes = append(a.BoardIndexes, api.BoardIndex{Fingerprint: fp})
+		a.KeyIndexes = append(a.KeyIndexes, api.KeyIndex{Fingerprint: fp})
+		a.ThreadIndexes = append(a.ThreadIndexes, api.ThreadIndex{Fingerprint: fp})
+		a.PostIndexes = append(a.PostIndexes, api.PostIndex{Fingerprint: fp})
+	}
+	trimAnnouncement(&a, 7)
+	if len(a.BoardIndexes) != 3 || len(a.KeyIndexes) != 3 || len(a.ThreadIndexes) != 1 || len(a.PostIndexes) != 0 {
+		t.Errorf("Test failed, expected 3 boards, 3 keys, 1 thread and 0 posts, got: %d, %d, %d, %d", len(a.BoardIndexes), len(a.KeyIndexes), len(a.ThreadIndexes), len(a.PostIndexes))
+	}
+}
@@ -0,0 +1,94 @@
+// Backend > Dispatch > IP Type
+// This file decides which address of a node we connect to, when it has both an IPv4 and an IPv6 one.
+
+package dispatch
+
+import (
+	"aether-core/io/api"
+	"aether-core/services/globals"
+	"aether-core/services/toolbox"
+	"fmt"
+	"sync"
+)
+
+/*
+How do we know two addresses are the same node?
+
+An address does not say which node is on it, so the database cannot tell us. But every time we check an address (pings, syncs), the remote signs its response with its node key. We remember which key we saw at which address, and if the same key shows up at two addresses, those two are the same node, and we only need one of them.
+
+This is only in memory, so after a restart we'll connect to both for a while, until we've checked them again.
+*/
+
+const maxRememberedNodeKeys = 10000
+
+var nodeKeys = struct {
+	lock   sync.Mutex
+	byAddr map[string]string
+}{byAddr: make(map[string]string)}
+
+func nodeKeyAddrKey(a *api.Address) string {
+	return fmt.Sprintf("%s/%s/%d", toolbox.NormalizeLocation(string(a.Location)), a.Sublocation, a.Port)
+}
+
+func rememberNodeKey(a api.Address, nodePublicKey string) {
+	if len(nodePublicKey) == 0 {
+		return
+	}
+	nodeKeys.lock.Lock()
+	defer nodeKeys.lock.Unlock()
+	if len(nodeKeys.byAddr) >= maxRememberedNodeKeys {
+		// Start over, rather than keeping track of which is the oldest. The ones we still use will come back at the next check.
+		nodeKeys.byAddr = make(map[string]string)
+	}
+	nodeKeys.byAddr[nodeKeyAddrKey(&a)] = nodePublicKey
+}
+
+func nodeKeyOf(a *api.Address) string {
+	nodeKeys.lock.Lock()
+	defer nodeKeys.lock.Unlock()
+	return nodeKeys.byAddr[nodeKeyAddrKey(a)]
+}
+
+// filterByIpType removes the addresses we cannot connect to under the IP type preference, and those on overlay networks (Tor, I2P) we do not have a proxy for.
+func filterByIpType(addrs []api.Address, pref string) []api.Address {
+	allowed := []api.Address{}
+	for key, _ := range addrs {
+		if api.IpTypeAllowed(addrs[key].Location, pref) && api.OverlayReachable(addrs[key].Location) {
+			allowed = append(allowed, addrs[key])
+		}
+	}
+	return allowed
+}
+
+/*
+ApplyIpTypePreference removes the addresses we cannot connect to under the IP type preference, and of the addresses that belong to the same node, keeps only the most preferred one. Addresses we don't know the node of are kept as they are. The order of the addresses is kept.
+
+keyOf returns the node key at an address, or blank if it is not known.
+*/
+func ApplyIpTypePreference(addrs []api.Address, pref string, keyOf func(a *api.Address) string) []api.Address {
+	addrs = filterByIpType(addrs, pref)
+	best := make(map[string]int) // node key > index of its most preferred address
+	for key, _ := range addrs {
+		k := keyOf(&addrs[key])
+		if len(k) == 0 {
+			continue
+		}
+		current, ok := best[k]
+		if !ok || api.IpTypeRank(addrs[key].Location, pref) < api.IpTypeRank(addrs[current].Location, pref) {
+			best[k] = key
+		}
+	}
+	result := []api.Address{}
+	for key, _ := range addrs {
+		k := keyOf(&addrs[key])
+		if len(k) > 0 && best[k] != key {
+			continue
+		}
+		result = append(result, addrs[key])
+	}
+	return result
+}
+
+func applyIpTypePreference(addrs []api.Address) []api.Address {
+	return ApplyIpTypePreference(addrs, globals.BackendConfig.GetIpTypePreference(), nodeKeyOf)
+}
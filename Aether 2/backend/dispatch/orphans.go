@@ -0,0 +1,184 @@
+// Backend > Dispatch > Orphans
+// This file fetches the parents we are missing for the threads and posts we receive.
+
+package dispatch
+
+import (
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/logging"
+	"errors"
+	"fmt"
+	"net"
+)
+
+/*
+What is an orphan?
+
+A post whose parent post, thread, or board we don't have. A thread whose board we don't have. The frontend can't put these into the tree where they belong, so they are either hidden, or shown as orphans.
+
+They happen when a sync doesn't finish, when the remote has a shorter memory than we do, or when the purgatory drops an ancestor because nothing in the same sync needed it.
+
+How do we fix it?
+
+During a sync, we note down the parents of the threads and posts we receive. At the end of the sync, we check which of those we still don't have, and ask the remote we synced with for them, by their fingerprints, in as few requests as we can. (See api.GetEntities) It most likely has them, since it had the children.
+
+The parents can be orphans themselves: a reply to a reply to a post we don't have. So the parents we get are noted down too, and we go up one more level, until we have everything, or for a few levels at most. What the remote doesn't have stays missing, another remote might have it on the next sync.
+
+The parents don't go through the purgatory. We asked for them because something we have needs them.
+*/
+
+const (
+	// How many parents we fetch at most at the end of a sync, in total over all levels.
+	maxOrphanParentsFetchedPerSync = 256
+	// How many levels up we go.
+	maxOrphanResolutionRounds = 5
+	// How many parents we check against the database in one read.
+	orphanParentsReadChunk = 500
+)
+
+type orphanParent struct {
+	entityType  string // plural
+	fingerprint api.Fingerprint
+}
+
+// EntityFetcher gets the entities with the given fingerprints from wherever the orphans came from, at most api.MaxEntitiesPerRequest at a time.
+type EntityFetcher func(fps []api.Fingerprint) (api.Response, error)
+
+// RemoteEntityFetcher fetches the entities from the remote. (See api.GetEntities)
+func RemoteEntityFetcher(a api.Address, reverseConn *net.Conn) EntityFetcher {
+	host, subhost, port := string(a.Location), string(a.Sublocation), a.Port
+	return func(fps []api.Fingerprint) (api.Response, error) {
+		r, err := api.GetEntities(host, subhost, port, fps, reverseConn)
+		if err != nil {
+			return r, errors.New(fmt.Sprintf("Remote: %s:%d, Error: %v", host, port, err))
+		}
+		return r, nil
+	}
+}
+
+// OrphanResolver keeps the parents of what we received from one remote.
+type OrphanResolver struct {
+	parents  map[orphanParent]bool
+	received map[api.Fingerprint]bool
+	asked    map[api.Fingerprint]bool
+}
+
+func NewOrphanResolver() *OrphanResolver {
+	return &OrphanResolver{
+		parents:  make(map[orphanParent]bool),
+		received: make(map[api.Fingerprint]bool),
+		asked:    make(map[api.Fingerprint]bool),
+	}
+}
+
+// Note notes down the parents of the threads and posts in the items. The items are in the form BatchInsert takes.
+func (o *OrphanResolver) Note(items []interface{}) {
+	for key, _ := range items {
+		switch e := items[key].(type) {
+		case api.Board:
+			o.received[e.Fingerprint] = true
+		case api.Thread:
+			o.received[e.Fingerprint] = true
+			o.parents[orphanParent{"boards", e.Board}] = true
+		case api.Post:
+			o.received[e.Fingerprint] = true
+			o.parents[orphanParent{"boards", e.Board}] = true
+			o.parents[orphanParent{"threads", e.Thread}] = true
+			if e.Parent != e.Thread {
+				o.parents[orphanParent{"posts", e.Parent}] = true
+			}
+		}
+	}
+}
+
+// Missing returns up to max parents we don't have, and haven't asked for yet. All of the parents are checked against the database, so that the ones we have don't take up the room of the ones we don't. The ones we have, and the ones returned, are removed from the list. The ones that didn't fit stay for the next round.
+func (o *OrphanResolver) Missing(max int) []api.Fingerprint {
+	candidates := make(map[string][]api.Fingerprint)
+	for p, _ := range o.parents {
+		if len(p.fingerprint) == 0 || o.received[p.fingerprint] || o.asked[p.fingerprint] {
+			delete(o.parents, p)
+			continue
+		}
+		candidates[p.entityType] = append(candidates[p.entityType], p.fingerprint)
+	}
+	missing := []api.Fingerprint{}
+	for _, entityType := range []string{"boards", "threads", "posts"} {
+		fps := candidates[entityType]
+		for i := 0; i < len(fps) && len(missing) < max; i += orphanParentsReadChunk {
+			end := i + orphanParentsReadChunk
+			if end > len(fps) {
+				end = len(fps)
+			}
+			existing, err := persistence.Read(entityType, fps[i:end], []string{}, 0, 0, true, nil)
+			if err != nil {
+				logging.Logf(1, "Checking the parents of the orphans against the database failed. Error: %v", err)
+				break
+			}
+			have := make(map[api.Fingerprint]bool)
+			for key, _ := range existing.Boards {
+				have[existing.Boards[key].Fingerprint] = true
+			}
+			for key, _ := range existing.Threads {
+				have[existing.Threads[key].Fingerprint] = true
+			}
+			for key, _ := range existing.Posts {
+				have[existing.Posts[key].Fingerprint] = true
+			}
+			for _, fp := range fps[i:end] {
+				if have[fp] {
+					delete(o.parents, orphanParent{entityType, fp})
+					continue
+				}
+				if len(missing) < max {
+					delete(o.parents, orphanParent{entityType, fp})
+					missing = append(missing, fp)
+				}
+			}
+		}
+	}
+	return missing
+}
+
+// Resolve fetches the missing parents, inserts them, and goes up a level with what arrives.
+func (o *OrphanResolver) Resolve(fetch EntityFetcher) {
+	budget := maxOrphanParentsFetchedPerSync
+	fetched := 0
+	for round := 0; round < maxOrphanResolutionRounds && budget > 0; round++ {
+		missing := o.Missing(budget)
+		if len(missing) == 0 {
+			break
+		}
+		budget = budget - len(missing)
+		var resp api.Response
+		for i := 0; i < len(missing); i += api.MaxEntitiesPerRequest {
+			end := i + api.MaxEntitiesPerRequest
+			if end > len(missing) {
+				end = len(missing)
+			}
+			for _, fp := range missing[i:end] {
+				o.asked[fp] = true
+			}
+			r, err := fetch(missing[i:end])
+			if err != nil {
+				logging.Logf(1, "Fetching the parents of the orphans failed. Error: %v", err)
+				return
+			}
+			resp.Insert(&r)
+		}
+		iface := prepareForBatchInsert(&resp)
+		if len(*iface) == 0 {
+			break
+		}
+		_, err := persistence.BatchInsert(*iface)
+		if err != nil {
+			logging.Logf(1, "Orphan parents BatchInsert has errored out. Error: %v", err)
+			return
+		}
+		fetched = fetched + len(*iface)
+		o.Note(*iface)
+	}
+	if fetched > 0 {
+		logging.Logf(1, "Fetched %d missing parents of orphans.", fetched)
+	}
+}
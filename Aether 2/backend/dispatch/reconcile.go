@@ -0,0 +1,100 @@
+// Backend > Routines > Reconcile
+// This file contains the Merkle tree reconciliation that runs at the end of a sync. Regular sync decides what to download from the time ranges of caches and POST responses, so an entity missed in a given window is never looked at again. Reconciliation compares our Merkle trees with those of the remote, and fetches exactly the fingerprints we are missing.
+
+package dispatch
+
+import (
+	"aether-core/backend/responsegenerator"
+	"aether-core/io/api"
+	"aether-core/services/logging"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	// Every missing entity is fetched with a separate query, which is expensive. We cap the number of entities we fetch per entity type per sync, and whatever remains is picked up in the next sync with this or some other remote.
+	maxReconciliationFetchesPerEntity = 100
+)
+
+func leavesOf(nodes []api.MerkleNode) []api.MerkleLeaf {
+	leaves := []api.MerkleLeaf{}
+	for _, n := range nodes {
+		leaves = append(leaves, n.Leaves...)
+	}
+	return leaves
+}
+
+// findMissingLeaves walks the Merkle tree of the remote for the given entity type, only descending into the subtrees that differ from ours, and returns the leaves that the remote has and we don't.
+func findMissingLeaves(a api.Address, endpointName string, local *api.MerkleTree, reverseConn *net.Conn) ([]api.MerkleLeaf, error) {
+	host, subhost, port := string(a.Location), string(a.Sublocation), a.Port
+	missing := []api.MerkleLeaf{}
+	windowStart := responsegenerator.MerkleWindowStart()
+	// The bucket we are in right now is still being filled, and the regular sync covers it already. Comparing it would mostly show us entities in flight.
+	currentBucket := api.MerkleBucketStart(api.Timestamp(time.Now().Unix()))
+	remoteBuckets, err := api.GetMerkleNodes(host, subhost, port, endpointName, "", reverseConn)
+	if err != nil {
+		return missing, err
+	}
+	localBuckets, _ := local.Children("")
+	for _, bucket := range api.DiffMerkleNodes(localBuckets, remoteBuckets) {
+		b, err := strconv.ParseInt(bucket.Path, 10, 64)
+		if err != nil {
+			return missing, errors.New(fmt.Sprintf("The remote returned a malformed Merkle bucket. Path: %s", bucket.Path))
+		}
+		if api.Timestamp(b) < windowStart || b >= currentBucket {
+			// Outside of our memory, or still being filled.
+			continue
+		}
+		remotePrefixes, err := api.GetMerkleNodes(host, subhost, port, endpointName, bucket.Path, reverseConn)
+		if err != nil {
+			return missing, err
+		}
+		localPrefixes, _ := local.Children(bucket.Path)
+		for _, prefix := range api.DiffMerkleNodes(localPrefixes, remotePrefixes) {
+			remoteLeaves, err := api.GetMerkleNodes(host, subhost, port, endpointName, prefix.Path, reverseConn)
+			if err != nil {
+				return missing, err
+			}
+			localLeaves, _ := local.Children(prefix.Path)
+			missing = append(missing, api.MissingMerkleLeaves(leavesOf(localLeaves), leavesOf(remoteLeaves))...)
+			if len(missing) >= maxReconciliationFetchesPerEntity {
+				return missing[0:maxReconciliationFetchesPerEntity], nil
+			}
+		}
+	}
+	return missing, nil
+}
+
+// reconcile compares our Merkle tree for the given entity type with the remote's, and fetches the entities the remote has but we do not.
+func reconcile(a api.Address, endpointName string, reverseConn *net.Conn) (api.Response, error) {
+	var result api.Response
+	local, err := responsegenerator.BuildLocalMerkleTree(endpointName)
+	if err != nil {
+		return result, err
+	}
+	missing, err := findMissingLeaves(a, endpointName, local, reverseConn)
+	if err != nil {
+		return result, errors.New(fmt.Sprintf("Walking the Merkle tree of the remote failed. Entity type: %s, Error: %v", endpointName, err))
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	logging.Logf(1, "Reconciliation found %d %s that we are missing from the remote %s:%d. Fetching.", len(missing), endpointName, a.Location, a.Port)
+	for _, l := range missing {
+		q := api.QueryData{
+			EntityType:  endpointName,
+			Fingerprint: l.Fingerprint,
+			Creation:    l.Creation,
+		}
+		resp, err := api.Query(string(a.Location), string(a.Sublocation), a.Port, q, reverseConn)
+		if err != nil {
+			logging.Logf(1, "Reconciliation query failed. Entity type: %s, Fingerprint: %s, Error: %v", endpointName, l.Fingerprint, err)
+			continue
+		}
+		result.Insert(&resp)
+	}
+	return result, nil
+}
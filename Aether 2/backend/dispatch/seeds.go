@@ -0,0 +1,233 @@
+// Backend > Dispatch > Seeds
+// This file finds the places we can ask for bootstrappers, beyond the default bootstrapper.
+
+package dispatch
+
+import (
+	"aether-core/io/api"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"aether-core/services/signaturing"
+	"aether-core/services/toolbox"
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/ed25519"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Where do seeds come from?
+
+A seed is a node we ask for bootstrappers. The default bootstrapper is one, but if it is down, a fresh node has nothing else to go on. So we also look at:
+
+- The seeds file, seeds.txt in the backend folder of the user directory. This can be shipped with the app, or put there by the user.
+- The DNS seed names in the config. For every name, the seed lists in its TXT records, and the targets of its _aether._tcp SRV records.
+
+A seed list, whether it is in the file or in a TXT record, is a list of host:port entries, and an optional signature. In the file, these are one per line, and lines starting with # are comments:
+
+	# Aether seeds
+	203.0.113.5:49999
+	seeds.example.org:49999
+	signature:<hex>
+
+In a TXT record, these are separated by spaces, and the record starts with "aether-seeds":
+
+	aether-seeds 203.0.113.5:49999 seeds.example.org:49999 signature:<hex>
+
+The signature is over the entries joined with newlines, in the order they're given. If the config has a seed list public key, only the lists signed with its private key are accepted, and SRV records, which cannot be signed, are ignored. That way, whoever controls the DNS or the file cannot point a new node at a network of their own, unless they also have the key.
+*/
+
+const (
+	seedsFileName       = "seeds.txt"
+	seedTXTPrefix       = "aether-seeds"
+	seedSRVService      = "aether"
+	seedSignaturePrefix = "signature:"
+	maxSeeds            = 100 // Per source.
+	seedLookupTimeout   = 10 * time.Second
+)
+
+// SeedResolver is what the DNS seeds are resolved through. net.DefaultResolver satisfies this.
+type SeedResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// DNSSeedResolver is the resolver used for DNS seeds. Tests replace this with a fake.
+var DNSSeedResolver SeedResolver = net.DefaultResolver
+
+// parseSeed converts a host:port entry into an address.
+func parseSeed(entry string) (api.Address, error) {
+	host, portStr, err := net.SplitHostPort(entry)
+	if err != nil {
+		return api.Address{}, errors.New(fmt.Sprintf("This seed is not in the host:port format. Seed: %s, Error: %v", entry, err))
+	}
+	port, err2 := strconv.ParseUint(portStr, 10, 16)
+	if err2 != nil || port == 0 || len(host) == 0 {
+		return api.Address{}, errors.New(fmt.Sprintf("This seed does not have a valid host or port. Seed: %s", entry))
+	}
+	return api.Address{Location: api.Location(toolbox.NormalizeLocation(strings.TrimSuffix(host, "."))), Port: uint16(port)}, nil
+}
+
+// seedSigningBody is what the signature of a seed list is over.
+func seedSigningBody(entries []string) string {
+	return strings.Join(entries, "\n")
+}
+
+/*
+ParseSeedList reads the tokens of a seed list (the lines of a file, or the space separated parts of a TXT record) into addresses. If pubKey is not empty, the list has to have a signature by it.
+
+Entries that do not parse are skipped, but they are still a part of what is signed.
+*/
+func ParseSeedList(tokens []string, pubKey string) ([]api.Address, error) {
+	entries := []string{}
+	var sig string
+	for _, t := range tokens {
+		t = strings.TrimSpace(t)
+		if len(t) == 0 || strings.HasPrefix(t, "#") {
+			continue
+		}
+		if strings.HasPrefix(t, seedSignaturePrefix) {
+			sig = strings.TrimPrefix(t, seedSignaturePrefix)
+			continue
+		}
+		entries = append(entries, t)
+	}
+	if len(pubKey) > 0 {
+		if len(sig) == 0 {
+			return []api.Address{}, errors.New("This seed list is not signed, and a seed list public key is configured.")
+		}
+		// ed25519 panics on a public key of the wrong size, and the key could have been edited into the config by hand.
+		pk, err := signaturing.UnmarshalPublicKey(pubKey)
+		if err != nil || len(pk) != ed25519.PublicKeySize {
+			return []api.Address{}, errors.New(fmt.Sprintf("The seed list public key in the config is malformed. Key: %s", pubKey))
+		}
+		if !signaturing.Verify(seedSigningBody(entries), sig, pubKey) {
+			return []api.Address{}, errors.New("The signature of this seed list did not verify with the configured seed list public key.")
+		}
+	}
+	addrs := []api.Address{}
+	for _, e := range entries {
+		a, err := parseSeed(e)
+		if err != nil {
+			logging.Logf(1, "A seed was skipped. Error: %v", err)
+			continue
+		}
+		addrs = append(addrs, a)
+		if len(addrs) >= maxSeeds {
+			break
+		}
+	}
+	return addrs, nil
+}
+
+// SignSeedList returns the seed list with the entries given, signed with the private key, in the seeds file format.
+func SignSeedList(entries []string, privKey *ed25519.PrivateKey) (string, error) {
+	clean := []string{}
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if len(e) == 0 || strings.HasPrefix(e, "#") || strings.HasPrefix(e, seedSignaturePrefix) {
+			continue
+		}
+		_, err := parseSeed(e)
+		if err != nil {
+			return "", err
+		}
+		clean = append(clean, e)
+	}
+	sig, err := signaturing.Sign(seedSigningBody(clean), privKey)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s\n%s%s\n", seedSigningBody(clean), seedSignaturePrefix, sig), nil
+}
+
+// ReadSeedsFile reads the seed list at the path. A missing file is not an error, it just has no seeds.
+func ReadSeedsFile(path string, pubKey string) ([]api.Address, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []api.Address{}, nil
+		}
+		return []api.Address{}, errors.New(fmt.Sprintf("The seeds file could not be read. Path: %s, Error: %v", path, err))
+	}
+	return ParseSeedList(strings.Split(string(content), "\n"), pubKey)
+}
+
+// ResolveDNSSeeds looks up the seeds of the DNS names through DNSSeedResolver. Names that fail to resolve are skipped.
+func ResolveDNSSeeds(names []string, pubKey string) []api.Address {
+	addrs := []api.Address{}
+	for _, name := range names {
+		ctx, cancel := context.WithTimeout(context.Background(), seedLookupTimeout)
+		txts, err := DNSSeedResolver.LookupTXT(ctx, name)
+		if err != nil {
+			logging.Logf(1, "The TXT records of a DNS seed name could not be looked up. Name: %s, Error: %v", name, err)
+		}
+		for _, txt := range txts {
+			tokens := strings.Fields(txt)
+			if len(tokens) == 0 || tokens[0] != seedTXTPrefix {
+				// Not ours. Domains have all sorts of TXT records.
+				continue
+			}
+			seeds, err := ParseSeedList(tokens[1:], pubKey)
+			if err != nil {
+				logging.Logf(1, "A seed list in the TXT records of a DNS seed name was rejected. Name: %s, Error: %v", name, err)
+				continue
+			}
+			addrs = append(addrs, seeds...)
+		}
+		if len(pubKey) > 0 {
+			// SRV records can't carry a signature.
+			cancel()
+			continue
+		}
+		_, srvs, err2 := DNSSeedResolver.LookupSRV(ctx, seedSRVService, "tcp", name)
+		cancel()
+		if err2 != nil {
+			logging.Logf(1, "The SRV records of a DNS seed name could not be looked up. Name: %s, Error: %v", name, err2)
+			continue
+		}
+		for k, srv := range srvs {
+			if k >= maxSeeds {
+				break
+			}
+			if len(srv.Target) == 0 || srv.Port == 0 {
+				continue
+			}
+			addrs = append(addrs, api.Address{Location: api.Location(strings.TrimSuffix(srv.Target, ".")), Port: srv.Port})
+		}
+	}
+	return addrs
+}
+
+// getSeeds returns all the places we can ask for bootstrappers: the default bootstrapper first, then the seeds file, then the DNS seeds. Duplicates are removed.
+func getSeeds() []api.Address {
+	seeds := []api.Address{api.Address{Location: bsLoc, Sublocation: bsSubloc, Port: bsPort}}
+	pubKey := globals.BackendConfig.GetSeedListPublicKey()
+	fileSeeds, err := ReadSeedsFile(filepath.Join(globals.BackendConfig.GetUserDirectory(), "backend", seedsFileName), pubKey)
+	if err != nil {
+		logging.Logf(1, "The seeds file was rejected. Error: %v", err)
+	}
+	seeds = append(seeds, fileSeeds...)
+	if names := globals.BackendConfig.GetSeedDNSNames(); len(names) > 0 {
+		if globals.BackendConfig.GetSOCKS5ProxyEnabled() {
+			// The lookups would go around the proxy, and tell the DNS server that this machine runs Aether.
+			logging.Logf(1, "The SOCKS5 proxy is enabled, so the DNS seeds are skipped.")
+		} else {
+			seeds = append(seeds, ResolveDNSSeeds(names, pubKey)...)
+		}
+	}
+	deduped := []api.Address{}
+	for key, _ := range seeds {
+		if !addrsInGivenSlice(&seeds[key], &deduped) {
+			deduped = append(deduped, seeds[key])
+		}
+	}
+	return deduped
+}
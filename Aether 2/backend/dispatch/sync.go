@@ -21,6 +21,7 @@ import (
 	"net"
 	// "strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -62,15 +63,101 @@ func isAllowed(a api.Address, reverseConn *net.Conn) (bool, func(wasSuccessful *
 	return allowed, leaseTerminator, leaseRenewer
 }
 
+// In-flight tracking.
+
+/*
+syncTracker keeps the set of remotes we are syncing with right now, and holds back new syncs when all outbound slots are in use. This is what used to be a single mutex around the whole sync, which meant one slow remote held back all network progress. Now, every sync waits only for a free slot, and there are as many slots as the outbound connection limit.
+
+Syncs over reverse connections do not take a slot. The bouncer does not count them against the outbound limit either, they are triggered by us and limited where we trigger them. Bootstrap downloads do not take a slot either, they have their own limit (see bootstrap.go).
+*/
+type syncTracker struct {
+	lock    sync.Mutex
+	cond    *sync.Cond
+	active  map[string]bool // remote > does not take a slot
+	waiting int
+}
+
+var inflightSyncs = newSyncTracker()
+
+func newSyncTracker() *syncTracker {
+	t := syncTracker{active: make(map[string]bool)}
+	t.cond = sync.NewCond(&t.lock)
+	return &t
+}
+
+func (t *syncTracker) outboundCount() int {
+	c := 0
+	for _, slotless := range t.active {
+		if !slotless {
+			c++
+		}
+	}
+	return c
+}
+
+// claim waits for a free slot, and marks the remote as being synced with. Returns false without waiting if there is already a sync ongoing with the remote. If slotless, it does not wait for, or take, a slot.
+func (t *syncTracker) claim(remote string, slotless bool) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.active[remote]; ok {
+		return false
+	}
+	t.waiting++
+	for !slotless && t.outboundCount() >= globals.BackendConfig.GetMaxOutboundConns() {
+		t.cond.Wait()
+	}
+	t.waiting--
+	// Someone else might have claimed the same remote while we were waiting.
+	if _, ok := t.active[remote]; ok {
+		return false
+	}
+	t.active[remote] = slotless
+	return true
+}
+
+func (t *syncTracker) release(remote string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.active, remote)
+	t.cond.Broadcast()
+}
+
+// free returns how many syncs can start right now without waiting.
+func (t *syncTracker) free() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return globals.BackendConfig.GetMaxOutboundConns() - t.outboundCount() - t.waiting
+}
+
+// ActiveSyncCount returns how many syncs are running right now, including those over reverse connections.
+func ActiveSyncCount() int {
+	inflightSyncs.lock.Lock()
+	defer inflightSyncs.lock.Unlock()
+	return len(inflightSyncs.active)
+}
+
+// remoteKey is how we tell remotes apart for in-flight tracking. For reverse connections, the address is not known (or not trusted), so we use the other end of the connection.
+func remoteKey(a api.Address, reverseConn *net.Conn) string {
+	if reverseConn != nil {
+		return fmt.Sprintf("reverse/%s", (*reverseConn).RemoteAddr().String())
+	}
+	return fmt.Sprintf("%s/%s/%d", a.Location, a.Sublocation, a.Port)
+}
+
 // Sync is the core logic of a single connection. It pulls updates from a remote node and patches it to the current node.
 func Sync(a api.Address, lineup []string, reverseConn *net.Conn) error {
 	//////////
 	// PREP //
 	//////////
 
-	// MUTEX
-	globals.BackendTransientConfig.SyncActive.Lock()
-	defer globals.BackendTransientConfig.SyncActive.Unlock()
+	// IN-FLIGHT
+
+	// Syncs run concurrently, up to the outbound limit. If all slots are in use, this waits for one to free up. We never run two syncs with the same remote at the same time, though, they would be fetching the same things.
+	remote := remoteKey(a, reverseConn)
+	if !inflightSyncs.claim(remote, reverseConn != nil) {
+		return errors.New(fmt.Sprintf("Sync() found an ongoing sync with this remote, so it did not start another. Remote: %s", remote))
+	}
+	defer inflightSyncs.release(remote)
 
 	// REVERSE CONN STATUS
 
@@ -125,8 +212,10 @@ func Sync(a api.Address, lineup []string, reverseConn *net.Conn) error {
 		return err
 	}
 
-	// Establish purgatory. This is where we keep received items that are older than our network head. At the end of the sync, we will take a look at those items and determine if they're ancestor of something that arrived in the sync. If so, we'll insert them as the last step of the sync. If not so, we'll discard them.
+	// Establish purgatory. Every sync has its own, since what is in it only makes sense against what arrived in the same sync. This is where we keep received items that are older than our network head. At the end of the sync, we will take a look at those items and determine if they're ancestor of something that arrived in the sync. If so, we'll insert them as the last step of the sync. If not so, we'll discard them.
 	p := Purgatory{}
+	// The parents of what arrives in this sync. At the end of the sync, we ask the remote for the ones we don't have.
+	o := NewOrphanResolver()
 
 	// FULLY TRUSTED ADDRESS ENTRY
 	// Anything here will be committed in and will write over existing data, since all of this data is either coming from a first-party remote, or from the client.
@@ -186,7 +275,12 @@ func Sync(a api.Address, lineup []string, reverseConn *net.Conn) error {
 	logging.Log(2, fmt.Sprintf("Endpoints: %#v", endpoints))
 	ims := []persistence.InsertMetrics{}
 	// callOrder := []string{"addresses", "votes", "truststates", "posts", "threads", "boards", "keys"}
-	callOrder := constructCallOrder(addr, lineup)
+	// Check has already made sure that this works.
+	caps, _ := negotiate(addr)
+	logging.Logf(2, "Capabilities in common with the remote %s:%d: %#v, Entity version: %d", a.Location, a.Port, caps.Subprotocols, caps.EntityVersion())
+	callOrder := constructCallOrder(caps, lineup)
+	// If we're in selective sync, this is the set of boards whose threads, posts and votes we replicate. Nil means we replicate everything.
+	boards := api.SyncedBoards()
 	for _, endpointName := range callOrder {
 		addrSatiated := false
 		logging.Logf(1, "Getting: %s", endpointName)
@@ -215,7 +309,7 @@ func Sync(a api.Address, lineup []string, reverseConn *net.Conn) error {
 			c.TotalNetworkRemoteWait = c.TotalNetworkRemoteWait + c.AddressesPOSTTimeToFirstResponse
 			c.AddressesSinglePage = len(postResp.CacheLinks) == 0
 			postIface := prepareForBatchInsert(&postResp)
-			im, err := persistence.BatchInsert(*postIface)
+			im, err := persistence.BatchInsertAccepted(postIface)
 			if err != nil {
 				logging.Logf(1, "Addresses POST BatchInsert inside Sync has errored out. Error: %v", err)
 			}
@@ -227,17 +321,22 @@ func Sync(a api.Address, lineup []string, reverseConn *net.Conn) error {
 			continue
 		}
 		start := time.Now()
-		if globals.BackendConfig.GetScaledMode() {
+		if globals.BackendConfig.GetScaledMode() && len(boards) == 0 {
 			/*
 				First check if we're in the scaled mode. If so, skip this part - we'll only sync addresses until we're out of the scaled mode.
 				Why?
-				Scaled mode means that the node is under so much disk pressure that the event horizon (the threshold of history deletion that can move forwards or backwards in time) has touched the network head, which renders this node one that is not able to provide a full network head to its peers. In the future, in this mode the node will switch to a mode where it only tracks the boards and people followed by its users, but for now, it temporarily stops accepting new content until the network head moves far enough ahead that event horizon can reduce the DB size to under maximum allowable.
+				Scaled mode means that the node is under so much disk pressure that the event horizon (the threshold of history deletion that can move forwards or backwards in time) has touched the network head, which renders this node one that is not able to provide a full network head to its peers. If the users of this node track some boards, the node switches to selective sync and only replicates those (see api.SyncedBoards). If not, it temporarily stops accepting new content until the network head moves far enough ahead that event horizon can reduce the DB size to under maximum allowable.
 				To prepare for that moment, though, we keep updating the addresses tables. Since that table is limited to 1000 addresses, it takes up a constant space.
 				(This also appropriately skips setting up the timestamps, so that it won't set timestamps for things that it did not sync.)
 			*/
 			logging.Logf(1, "This node is in scaled mode, so it's skipping sync with this remote. Remote: %s:%d", a.Location, a.Port)
 			continue
 		}
+		if boards != nil && len(boards) == 0 && api.IsBoardScoped(endpointName) {
+			// Selective sync is on, but there are no boards to track yet. Without a board filter, the remote would send us everything, only for us to strip it, so we skip this entity type entirely. This also leaves the last checkin timestamp for it untouched, so when the first board is tracked, we pick up from here.
+			logging.Logf(1, "Selective sync is on and there are no boards to track, skipping %s. Remote: %s:%d", endpointName, a.Location, a.Port)
+			continue
+		}
 
 		/////////////
 		//// GET //// (all)
@@ -259,10 +358,12 @@ func Sync(a api.Address, lineup []string, reverseConn *net.Conn) error {
 		p.Filter(&resp) // Filter through purgatory. Older items will be held in purgatory and removed from the resp. At the end of the sync, we'll deal with the items in the purgatory.
 		iface := prepareForBatchInsert(&resp)
 		// Save the response to the database.
-		im, err := persistence.BatchInsert(*iface)
+		im, err := persistence.BatchInsertAccepted(iface)
 		if err != nil {
 			logging.Logf(1, "GET BatchInsert inside Sync has errored out. Entity Type: %v, Error: %v", endpointName, err)
 		}
+		announce(*iface, a.Location)
+		o.Note(*iface)
 		ims = append(ims, im)
 		// Set the last checkin timestamp for each entity type to the beginning of this process. (We will update this later before committing the node checkin set based on the POST response receipts, if any)
 		// Check if the apiResp.Timestamp is newer or older than the timestamp we have. It might actually be older,because we might have received a POST response from this node, and that might have been a later Timestamp than that of the last cache's.
@@ -310,10 +411,12 @@ func Sync(a api.Address, lineup []string, reverseConn *net.Conn) error {
 			elapsed = time.Since(start)
 			p.Filter(&postResp)
 			postIface := prepareForBatchInsert(&postResp)
-			im, err := persistence.BatchInsert(*postIface)
+			im, err := persistence.BatchInsertAccepted(postIface)
 			if err != nil {
 				logging.Logf(1, "POST BatchInsert inside Sync has errored out. Entity Type: %v, Error: %v", endpointName, err)
 			}
+			announce(*postIface, a.Location)
+			o.Note(*postIface)
 			ims = append(ims, im)
 			var singlePage bool
 			if len(postResp.CacheLinks) == 0 {
@@ -359,15 +462,50 @@ func Sync(a api.Address, lineup []string, reverseConn *net.Conn) error {
 			}
 		}
 	}
+	// Reconciliation. Everything above only looks at the time windows of the caches and POST responses, so anything we missed in a window before is never looked at again. Here we compare our Merkle trees with the remote's to find and fetch exactly those.
+	if !NODE_STATIC && !(globals.BackendConfig.GetScaledMode() && len(boards) == 0) {
+		for _, endpointName := range callOrder {
+			if endpointName == "addresses" {
+				continue
+			}
+			if boards != nil && api.IsBoardScoped(endpointName) {
+				// Our Merkle trees do not know about boards, so in selective sync, the trees of board-scoped entities would never match the remote's. We only reconcile the entity types we replicate in full.
+				continue
+			}
+			recResp, err := reconcile(a, endpointName, reverseConn)
+			if err != nil {
+				// Remotes that predate reconciliation do not have the endpoint. This is not a failure of the sync.
+				logging.Logf(1, "Reconciliation with the remote %s:%d failed for %s. Error: %v", a.Location, a.Port, endpointName, err)
+				continue
+			}
+			if recResp.Empty() {
+				continue
+			}
+			// These do not go through the purgatory. Purgatory holds back items older than the network head unless something in this sync needs them, but reconciled items are old by definition, and they are ones we should already have had.
+			recIface := prepareForBatchInsert(&recResp)
+			im, err := persistence.BatchInsertAccepted(recIface)
+			if err != nil {
+				logging.Logf(1, "Reconciliation BatchInsert inside Sync has errored out. Entity Type: %v, Error: %v", endpointName, err)
+			}
+			announce(*recIface, a.Location)
+			o.Note(*recIface)
+			ims = append(ims, im)
+		}
+	}
 	// Here, after all the endpoint pulls are complete, we process the purgatory and commit it separately.
 	iface := p.Process()
+	noteAttachments(iface)
 	// Save the response to the database.
 	im, err := persistence.BatchInsert(iface)
 	if err != nil {
 		logging.Logf(1, "Purgatory BatchInsert inside Sync has errored out. Error: %v", err)
 	}
 	ims = append(ims, im)
+	o.Note(iface)
 	// Purgatory end.
+	o.Resolve(RemoteEntityFetcher(a, reverseConn))
+	// The chunks of the attachments of what we just received. These come after the entities, so that a remote that doesn't have them doesn't hold back the sync.
+	fetchPendingChunks(a, reverseConn)
 	logging.Log(2, fmt.Sprintf("SYNC:PULL COMPLETE with data from node: %s:%d", a.Location, a.Port))
 	// Both POST and GETs are committed into the database. We now need to save the Node LastCheckin timestamps into the database.
 	n.BoardsLastCheckin = endpoints["boards"]
@@ -425,44 +563,26 @@ Internal functions
 //////////
 */
 
-func constructCallOrder(remote api.Address, lineup []string) []string {
+// The entity types we know how to sync, in the order we sync them in.
+var syncedEntities = []string{"vote", "truststate", "post", "thread", "board", "key"}
+
+// constructCallOrder returns the endpoints we will sync with the remote: addresses, and then the entity types in the lineup that both of us serve.
+func constructCallOrder(caps api.Capabilities, lineup []string) []string {
 	// All mim nodes support addresses to enable proper protocol function.
 	supported := []string{"addresses"}
-	// supported := []string{"addresses", "addresses"}
-	// supported := []string{"boards"}
-	// return supported
 	if len(lineup) == 0 {
 		// If not specified, all entities are allowed.
-		//FUTURE: This needs to read from a central somewhere else — otherwise when we add a new entity, we're going to forget adding it here and it's gonna be a lot of unnecessary pain to find it out.
-		lineup = []string{"vote", "truststate", "post", "thread", "board", "key"}
-	}
-	availableSubprots := remote.Protocol.Subprotocols
-	for _, val := range availableSubprots {
-		if val.Name == "c0" {
-			if tb.IndexOf(tb.Singular("votes"), val.SupportedEntities) != -1 &&
-				tb.IndexOf("vote", lineup) != -1 {
-				supported = append(supported, "votes")
-			}
-			if tb.IndexOf(tb.Singular("truststates"), val.SupportedEntities) != -1 &&
-				tb.IndexOf("truststate", lineup) != -1 {
-				supported = append(supported, "truststates")
-			}
-			if tb.IndexOf(tb.Singular("posts"), val.SupportedEntities) != -1 &&
-				tb.IndexOf("post", lineup) != -1 {
-				supported = append(supported, "posts")
-			}
-			if tb.IndexOf(tb.Singular("threads"), val.SupportedEntities) != -1 &&
-				tb.IndexOf("thread", lineup) != -1 {
-				supported = append(supported, "threads")
-			}
-			if tb.IndexOf(tb.Singular("boards"), val.SupportedEntities) != -1 &&
-				tb.IndexOf("board", lineup) != -1 {
-				supported = append(supported, "boards")
-			}
-			if tb.IndexOf(tb.Singular("keys"), val.SupportedEntities) != -1 &&
-				tb.IndexOf("key", lineup) != -1 {
-				supported = append(supported, "keys")
-			}
+		lineup = syncedEntities
+	}
+	for _, entity := range syncedEntities {
+		if caps.Serves(entity) && tb.IndexOf(entity, lineup) != -1 {
+			supported = append(supported, tb.Plural(entity))
+		}
+	}
+	for _, entity := range caps.Entities {
+		if tb.IndexOf(entity, syncedEntities) == -1 {
+			// A subprotocol we both speak has an entity type this version of the app can't sync yet.
+			logging.Logf(2, "Both sides serve the entity type %s, but we don't know how to sync it, so we skip it.", entity)
 		}
 	}
 	return supported
@@ -493,5 +613,6 @@ func prepareForBatchInsert(r *api.Response) *[]interface{} {
 	for i, _ := range resp.Addresses {
 		carrier = append(carrier, resp.Addresses[i])
 	}
+	noteAttachments(carrier)
 	return &carrier
 }
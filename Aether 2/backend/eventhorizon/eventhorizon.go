@@ -16,9 +16,10 @@ package eventhorizon
 import (
 	"aether-core/services/globals"
 	"aether-core/services/logging"
-	tb "aether-core/services/toolbox"
+	"aether-core/services/toolbox"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 )
 
@@ -28,7 +29,8 @@ const (
 	Day = Timestamp(86400) // UNIX timestamp format. Otherwise Go's internal format isn't seconds, it's smaller.
 )
 
-func delete(ts Timestamp, entityType string) {
+// delete removes the entities of the given type that were last referenced before the timestamp, sparing the ones that are protected by a pin.
+func delete(ts Timestamp, entityType string, pins *pinSet) {
 	tableName := ""
 	switch entityType {
 	case "boards":
@@ -40,7 +42,7 @@ func delete(ts Timestamp, entityType string) {
 	case "votes":
 		tableName = "Votes"
 	case "keys":
-		tableName = "Keys"
+		tableName = "PublicKeys"
 	case "truststates":
 		tableName = "Truststates"
 	case "addresses":
@@ -49,16 +51,40 @@ func delete(ts Timestamp, entityType string) {
 		return
 	}
 	query := fmt.Sprintf("DELETE FROM %s WHERE LastReferenced < ?", tableName)
+	args := []interface{}{ts}
+	if cond, condArgs := pins.exemption(tableName); len(cond) > 0 {
+		query = fmt.Sprintf("%s AND %s", query, cond)
+		args = append(args, condArgs...)
+	}
 	tx, err := globals.DbInstance.Beginx()
 	if err != nil {
 		tx.Rollback()
 		logging.Logf(1, "We couldn't begin the deletion process, transaction open failed. Error: %v", err)
 		return
 	}
-	tx.Exec(query, ts)
+	_, err2 := tx.Exec(tx.Rebind(query), args...)
+	if err2 != nil {
+		tx.Rollback()
+		logging.Logf(1, "The deletion failed. Table: %s, Error: %v", tableName, err2)
+		return
+	}
 	tx.Commit()
 }
 
+// deleteOrphanedRevisions removes the revisions of the threads and posts that are no longer in the database. Revisions don't have a LastReferenced of their own, they go when their entity goes.
+func deleteOrphanedRevisions() {
+	queries := []string{
+		"DELETE FROM Revisions WHERE EntityType = 'thread' AND Fingerprint NOT IN (SELECT Fingerprint FROM Threads)",
+		"DELETE FROM Revisions WHERE EntityType = 'post' AND Fingerprint NOT IN (SELECT Fingerprint FROM Posts)",
+	}
+	for _, query := range queries {
+		_, err := globals.DbInstance.Exec(query)
+		if err != nil {
+			logging.Logf(1, "Deleting the orphaned revisions failed. Error: %v", err)
+		}
+	}
+}
+
 // func CnvToCutoffDays(days int) Timestamp {
 // 	return Timestamp(time.Now().Add(-(time.Duration(days) * time.Hour * time.Duration(24))).Unix())
 // }
@@ -70,29 +96,19 @@ func max(ts1 Timestamp, ts2 Timestamp) Timestamp {
 	return ts2
 }
 
-func deleteUpToLocalMemory() {
+func deleteUpToLocalMemory(pins *pinSet) {
 	lmD := globals.BackendConfig.GetLocalMemoryDays()
 	lmCutoff := Timestamp(toolbox.CnvToCutoffDays(lmD))
 	vmD := globals.BackendConfig.GetVotesMemoryDays()
 	vmCutoff := Timestamp(toolbox.CnvToCutoffDays(vmD))
-	delete(lmCutoff, "boards")
-	delete(lmCutoff, "threads")
-	delete(lmCutoff, "posts")
-	delete(lmCutoff, "keys")
-	delete(lmCutoff, "truststates")
-	delete(lmCutoff, "addresses")
+	delete(lmCutoff, "boards", pins)
+	delete(lmCutoff, "threads", pins)
+	delete(lmCutoff, "posts", pins)
+	delete(lmCutoff, "keys", pins)
+	delete(lmCutoff, "truststates", pins)
+	delete(lmCutoff, "addresses", pins)
 	// These are the special ones
-	delete(vmCutoff, "votes")
-}
-
-func deleteUpToEH(eventhorizon Timestamp) {
-	delete(eventhorizon, "boards")
-	delete(eventhorizon, "threads")
-	delete(eventhorizon, "posts")
-	delete(eventhorizon, "keys")
-	delete(eventhorizon, "truststates")
-	// Addresses is limited to 1000 items and it has its own cycling logic. No need to delete based on event horizon, it will likely yield not many items. The LM cutoff deletion (deleteUpToLocalMemory) does that for us.
-	// delete(eventhorizon, "addresses")
+	delete(vmCutoff, "votes", pins)
 }
 
 func getDbSize() int {
@@ -127,16 +143,25 @@ func getDbSize() int {
 	return -1 // Should never happen
 }
 
+// PruneDB deletes what is past the local memory, and if the database is still over its max size, plans and executes the deletions that get it under.
 func PruneDB() {
 	lmD := globals.BackendConfig.GetLocalMemoryDays()
 	lmCutoff := Timestamp(toolbox.CnvToCutoffDays(lmD))
-	nhD := globals.BackendConfig.GetNetworkHeadDays()
-	nhCutoff := Timestamp(toolbox.CnvToCutoffDays(nhD))
 	tempeh := Timestamp(globals.BackendConfig.GetEventHorizonTimestamp())
 	logging.Logf(2, "DbSize at the beginning of PruneDB: %v", getDbSize())
 	logging.Logf(2, "Event horizon at the beginning of PruneDB: %v", time.Unix(int64(tempeh), 0).String())
-	deleteUpToLocalMemory()
-	if getDbSize() <= globals.BackendConfig.GetMaxDbSizeMb() {
+	pins, err := readPinSet()
+	if err != nil {
+		// If we can't tell what's pinned, we can't delete safely. Better to overflow for a cycle than to delete someone's archive.
+		logging.Logf(1, "We could not read the pins, so we are skipping this PruneDB cycle. Error: %v", err)
+		return
+	}
+	// The sizes below don't count the free pages, so we don't need to compact in between deletions, only once at the end.
+	defer compactDb()
+	deleteUpToLocalMemory(&pins)
+	deleteOrphanedRevisions()
+	// Pinned content does not count against the max database size. Otherwise a large enough pin would push the event horizon to the network head, and we'd delete everything else trying to make room for it.
+	if unpinnedDbSize() <= globals.BackendConfig.GetMaxDbSizeMb() {
 		/*
 			Below, we move event horizon one day behind, OR, if one day behind the EH goes out of the range for local memory, the local memory.
 
@@ -145,49 +170,34 @@ func PruneDB() {
 			If DB size is below max after we remove up to local memory cutoff, set the EH to one day into the past, or, to the local memory cutoff, whichever is more recent.
 		*/
 		tempeh = max(tempeh-Day, Timestamp(lmCutoff))
+		globals.BackendConfig.SetEventHorizonTimestamp(int64(tempeh))
+		logging.Logf(2, "DbSize at the end of PruneDB: %v", getDbSize())
+		logging.Logf(2, "Event horizon at the end of PruneDB: %v", time.Unix(int64(tempeh), 0).String())
+		return
 	}
-	itercount := 0
-	now := Timestamp(time.Now().Unix())
-	for getDbSize() > globals.BackendConfig.GetMaxDbSizeMb() {
-		// First, a sanity check / guard against infinite loop.
-		if tempeh > now {
-			logging.Logf(1, "Something went haywire and event horizon is in the future. This might mean that the addresses table + votes table (both of which are not deleted by this loop) might have gone above the max database size threshold, and the network head is set incorrectly. Bailing. Event Horizon: %v, Now: %v", tempeh, now)
-			tempeh = lmCutoff
-			break
-		}
-		// If the user hasn't fixed the scaled mode to a setting or another,
+	plan, err := planPrune(&pins)
+	if err != nil {
+		logging.Logf(1, "Planning the pruning failed, so we are skipping this PruneDB cycle. Error: %v", err)
+		return
+	}
+	logging.Logf(2, "PruneDB plan:\n%s", plan.Report())
+	executePlan(&plan, &pins)
+	deleteOrphanedRevisions()
+	if plan.ReachesNetworkHead {
+		// We do not delete from within the network head. If the user hasn't fixed the scaled mode to a setting or another, flip it on, so that the database stops growing. Force-setting the scaled mode off will make DB size grow, it won't eat into the network head.
 		if !globals.BackendConfig.GetScaledModeUserSet() {
-			// Check if the EH is in danger of crossing the network head threshold. If so, flip on the scaled mode.
-			if tempeh+Day >= nhCutoff {
-				globals.BackendConfig.SetScaledMode(true)
-				logging.Log(2, "Event horizon crossed the network head. We're stopping deletion and enabling the scaled mode.")
-				break // Stop deleting. We do not delete from within the network head.
-			} else {
-				// This triggers not because the EH moves, but because nhCutoff does with time.
-				globals.BackendConfig.SetScaledMode(false)
-			}
-		} else {
-			if tempeh+Day >= nhCutoff {
-				break // We do not delete from within the network head. Force-setting the scaled mode off will make DB size grow, it won't eat into the network head.
-			}
-		}
-		// If DB size is still larger than the max after we remove up to local memory cutoff, start deleting iteratively moving one day by day closer to now.
-		// One inefficiency, if the EH is exactly at the cutoff when overflow happens, the cutoff deletion will run twice. It's OK to do that - negligible cost, and reduces complexity of logic here.
-		// insert nh cutoff here.
-		logging.Logf(2, "DbSize at the beginning of this iteration of PruneDB: \n%v", getDbSize())
-		logging.Logf(2, "Event horizon at the beginning of this iteration of PruneDB: \n%v", time.Unix(int64(tempeh), 0).String())
-		if itercount > 0 {
-			// Delete up to EH first. then the next cycle delete up to eh + 1 day
-			tempeh = tempeh + Day
+			globals.BackendConfig.SetScaledMode(true)
+			logging.Log(2, "The database does not fit even when we delete everything outside the network head. We're enabling the scaled mode.")
 		}
-		deleteUpToEH(tempeh)
-		itercount++
-
-		logging.Logf(2, "DbSize at the end of this iteration of PruneDB: \n%v", getDbSize())
-		logging.Logf(2, "Event horizon at the end of this iteration of PruneDB: \n%v", time.Unix(int64(tempeh), 0).String())
-
+	} else if !globals.BackendConfig.GetScaledModeUserSet() {
+		globals.BackendConfig.SetScaledMode(false)
+	}
+	if !plan.ReachesNetworkHead && unpinnedDbSize() > globals.BackendConfig.GetMaxDbSizeMb() {
+		// Our estimate was short. This is rare, and the next cycle will plan with the database as it is after this deletion.
+		logging.Logf(1, "The database is still over its max size after the planned deletions. DbSize: %v, Estimated freed: %.1f MB", getDbSize(), plan.EstimatedFreedMb)
 	}
-	// At the end, save the new EH.
+	// Boards are now complete after the most recent cutoff we have applied. Nothing before this point is guaranteed to be there.
+	tempeh = max(tempeh, plan.Horizon())
 	globals.BackendConfig.SetEventHorizonTimestamp(int64(tempeh))
 	logging.Logf(2, "DbSize at the end of PruneDB: %v", getDbSize())
 	logging.Logf(2, "Event horizon at the end of PruneDB: %v", time.Unix(int64(tempeh), 0).String())
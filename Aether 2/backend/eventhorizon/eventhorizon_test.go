@@ -151,7 +151,7 @@ func setEventHorizonToNow() {
 
 func setEventHorizonToEndOfLocalMemory() {
 	lmD := globals.BackendConfig.GetLocalMemoryDays()
-	lmCutoff := Timestamp(toolbox.CnvToCutoffDays(lmD))
+	lmCutoff := toolbox.CnvToCutoffDays(lmD)
 	globals.BackendConfig.SetEventHorizonTimestamp(lmCutoff)
 }
 
@@ -163,7 +163,7 @@ func TestPostInsert_Success(t *testing.T) {
 	ps := generatePosts(count, "")
 	insertPosts(ps, time.Unix(5, 0))
 	now := api.Timestamp(time.Now().Unix())
-	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, now)
+	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, now, "", "", "", "", 0, 0)
 	if count > len(p) {
 		t.Errorf("Insertion failed, not all data requested has been inserted.")
 	}
@@ -181,7 +181,7 @@ func TestPruneDB_PastLocalMemory_Success(t *testing.T) {
 	insertPosts(ps, time.Unix(5, 0))
 	eventhorizon.PruneDB()
 	now := api.Timestamp(time.Now().Unix())
-	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, now)
+	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, now, "", "", "", "", 0, 0)
 	if len(p) != 0 {
 		t.Errorf("Event horizon failed to clear data that is past local memory. Local memory still has %v posts", len(p))
 
@@ -197,7 +197,7 @@ func TestPruneDB_WithinLocalMemory_Success(t *testing.T) {
 	insertPosts(ps, time.Now().Add(-time.Duration(1)*time.Second))
 	eventhorizon.PruneDB()
 	now := api.Timestamp(time.Now().Unix())
-	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, now)
+	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, now, "", "", "", "", 0, 0)
 	if len(p) != count {
 		t.Errorf("Event horizon accidentally cleared data that was within the network memory.")
 	}
@@ -223,7 +223,7 @@ func TestPruneDB_WithinLocalMemory_TooBigDb_Success(t *testing.T) {
 	insertPosts(ps3, time.Now().Add(-time.Duration(38*time.Hour*24)))
 	eventhorizon.PruneDB()
 	now := api.Timestamp(time.Now().Unix())
-	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, now)
+	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, now, "", "", "", "", 0, 0)
 	if len(p) != count1+count2 {
 		t.Errorf("Event horizon accidentally cleared data that was within the network memory.")
 	}
@@ -256,7 +256,7 @@ func TestPruneDB_NonBacktrack_Success(t *testing.T) {
 	eventhorizon.PruneDB()
 	newEh := globals.BackendConfig.GetEventHorizonTimestamp()
 	lmD := globals.BackendConfig.GetLocalMemoryDays()
-	lmCutoff := Timestamp(toolbox.CnvToCutoffDays(lmD))
+	lmCutoff := toolbox.CnvToCutoffDays(lmD)
 	newSupposedEh := lmCutoff
 	if newEh != newSupposedEh {
 		t.Errorf("Event horizon failed to not backtrack backtrack on 3 runs. EH: %v, Supposed EH: %v", newEh, newSupposedEh)
@@ -278,7 +278,7 @@ func TestPruneDB_ScaledModeGetsEnabled_Success(t *testing.T) {
 	if globals.BackendConfig.GetScaledMode() != true {
 		t.Errorf("Event horizon failed to enable the scaled mode when it should have.")
 	}
-	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, api.Timestamp(time.Now().Unix()))
+	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, api.Timestamp(time.Now().Unix()), "", "", "", "", 0, 0)
 	// fmt.Println(len(p))
 	if len(p) != count1 {
 		t.Errorf("Event horizon did not stop deleting from within the network head when it should have.")
@@ -298,9 +298,87 @@ func TestPruneDB_ScaledModeManuallySet_Success(t *testing.T) {
 	if globals.BackendConfig.GetScaledMode() != false {
 		t.Errorf("Event horizon shouldn't have touched the scaled mode because the it is manually set by the user.")
 	}
-	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, api.Timestamp(time.Now().Unix()))
+	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, api.Timestamp(time.Now().Unix()), "", "", "", "", 0, 0)
 	// fmt.Println(len(p))
 	if len(p) != count1 {
 		t.Errorf("Event horizon did not stop deleting from within the network head when it should have.")
 	}
 }
+
+// Posts in a pinned board survive past the local memory.
+func TestPruneDB_PinnedBoard_Success(t *testing.T) {
+	deleteAllPosts()
+	setEventHorizonToEndOfLocalMemory()
+	count := 1000
+	ps := generatePosts(count, "")
+	insertPosts(ps, time.Unix(5, 0))
+	persistence.PinEntity("board", "boardpk")
+	eventhorizon.PruneDB()
+	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, api.Timestamp(time.Now().Unix()), "", "", "", "", 0, 0)
+	if len(p) != count {
+		t.Errorf("Event horizon deleted posts in a pinned board. Remaining: %v, Expected: %v", len(p), count)
+	}
+	persistence.UnpinEntity("board", "boardpk")
+	eventhorizon.PruneDB()
+	p2, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, api.Timestamp(time.Now().Unix()), "", "", "", "", 0, 0)
+	if len(p2) != 0 {
+		t.Errorf("Event horizon failed to clear posts past local memory after the board was unpinned. Remaining: %v", len(p2))
+	}
+}
+
+// Anonymous posts past the local memory are deleted, whether or not something else is pinned. With a pin, the exemption is in the deletion, and its empty key list must not protect the posts without an owner.
+func TestPruneDB_AnonymousPastLocalMemory_Success(t *testing.T) {
+	for _, pinned := range []bool{false, true} {
+		deleteAllPosts()
+		setEventHorizonToEndOfLocalMemory()
+		count := 100
+		ps := generatePosts(count, "anon")
+		for k, _ := range ps {
+			ps[k].Owner = ""
+			ps[k].OwnerPublicKey = ""
+		}
+		insertPosts(ps, time.Unix(5, 0))
+		if pinned {
+			persistence.PinEntity("board", "someotherboard")
+		}
+		eventhorizon.PruneDB()
+		if pinned {
+			persistence.UnpinEntity("board", "someotherboard")
+		}
+		p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, api.Timestamp(time.Now().Unix()), "", "", "", "", 0, 0)
+		if len(p) != 0 {
+			t.Errorf("Event horizon failed to clear anonymous posts past local memory. Pinned: %v, Remaining: %v", pinned, len(p))
+		}
+	}
+}
+
+func generateBoardPosts(amt int, prefix string, board api.Fingerprint) []api.Post {
+	ps := generatePosts(amt, prefix)
+	for k, _ := range ps {
+		ps[k].Board = board
+	}
+	return ps
+}
+
+// The planner should pick the quieter board first, and a dry run should not delete anything.
+func TestPlanPrune_QuietBoardFirst_Success(t *testing.T) {
+	deleteAllPosts()
+	setEventHorizonToEndOfLocalMemory()
+	priorMaxDbSize := globals.BackendConfig.GetMaxDbSizeMb()
+	globals.BackendConfig.SetMaxDbSizeMb(1)
+	insertPosts(generateBoardPosts(1000, "-quiet-", "quietboard"), time.Now().Add(-time.Duration(40*time.Hour*24)))
+	insertPosts(generateBoardPosts(1000, "-busy-", "busyboard"), time.Now().Add(-time.Duration(40*time.Hour*24)))
+	insertPosts(generateBoardPosts(100, "-busy-recent-", "busyboard"), time.Now().Add(-time.Duration(1*time.Hour)))
+	plan, err := eventhorizon.PlanPrune()
+	if err != nil {
+		t.Errorf("Test failed, err: '%s'", err)
+	}
+	if len(plan.Steps) == 0 || plan.Steps[0].Board != "quietboard" {
+		t.Errorf("The planner did not pick the quieter board first. Plan: %s", plan.Report())
+	}
+	p, _ := persistence.ReadPosts([]api.Fingerprint{}, 0, api.Timestamp(time.Now().Unix()), "", "", "", "", 0, 0)
+	if len(p) != 2100 {
+		t.Errorf("Planning deleted posts. Remaining: %v", len(p))
+	}
+	globals.BackendConfig.SetMaxDbSizeMb(priorMaxDbSize)
+}
@@ -0,0 +1,195 @@
+// Backend > Event Horizon > Pins
+// This file handles the exemption of pinned content from the event horizon. A pinned entity survives both the local memory cutoff and the event horizon, along with the entities above it (so that it can still be displayed and verified) and below it (so that it is still useful).
+
+package eventhorizon
+
+import (
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"fmt"
+	"strings"
+)
+
+/*
+What does a pin keep?
+
+  Board pin: the board, all of its threads, posts and votes, and the keys that created them.
+  Thread pin: the thread, all of its posts and votes, the board it is in, and the keys that created them.
+  Key pin: the key, everything it has created, the threads it has posted in (with their posts and votes), and the boards those are in.
+
+Truststates and addresses are never exempt. They are small, and they are refreshed by the network constantly.
+*/
+
+type pinSet struct {
+	boards  []interface{}
+	threads []interface{}
+	keys    []interface{}
+}
+
+func (p *pinSet) empty() bool {
+	return len(p.boards) == 0 && len(p.threads) == 0 && len(p.keys) == 0
+}
+
+func readPinSet() (pinSet, error) {
+	var p pinSet
+	pins, err := persistence.ReadPins()
+	if err != nil {
+		return p, err
+	}
+	p.boards = toArgs(pins["board"])
+	p.threads = toArgs(pins["thread"])
+	p.keys = toArgs(pins["key"])
+	return p, nil
+}
+
+func toArgs(fps []api.Fingerprint) []interface{} {
+	args := []interface{}{}
+	for _, fp := range fps {
+		args = append(args, string(fp))
+	}
+	return args
+}
+
+// clause accumulates an SQL condition and its arguments, so that the placeholders and the arguments stay in the same order. The conditions are exclusions, and an exclusion with no values excludes nothing, so it's left out entirely: IN () is not valid SQL, and any literal standing in for the empty list would match some rows.
+type clause struct {
+	table string // The table being deleted from.
+	parts []string
+	args  []interface{}
+}
+
+// filter is one branch of the WHERE of a subquery: column IN (values).
+type filter struct {
+	column string
+	values []interface{}
+}
+
+func placeholders(values []interface{}) string {
+	return strings.TrimSuffix(strings.Repeat("?,", len(values)), ",")
+}
+
+// notIn adds "column NOT IN (values)".
+func (c *clause) notIn(column string, values []interface{}) {
+	if len(values) == 0 {
+		return
+	}
+	c.parts = append(c.parts, fmt.Sprintf("%s NOT IN (%s)", column, placeholders(values)))
+	c.args = append(c.args, values...)
+}
+
+// notInSelect adds "column NOT IN (SELECT selected FROM from WHERE ... OR ...)", with a branch in the WHERE for each filter that has values.
+// MySQL does not allow a subquery to read from the table being deleted from, so those are wrapped into a derived table.
+func (c *clause) notInSelect(column, selected, from string, filters ...filter) {
+	branches := []string{}
+	args := []interface{}{}
+	for _, f := range filters {
+		if len(f.values) == 0 {
+			continue
+		}
+		branches = append(branches, fmt.Sprintf("%s IN (%s)", f.column, placeholders(f.values)))
+		args = append(args, f.values...)
+	}
+	if len(branches) == 0 {
+		return
+	}
+	sub := fmt.Sprintf("SELECT %s FROM %s WHERE %s", selected, from, strings.Join(branches, " OR "))
+	if from == c.table {
+		sub = fmt.Sprintf("SELECT %s FROM (%s) AS Pinned", selected, sub)
+	}
+	c.parts = append(c.parts, fmt.Sprintf("%s NOT IN (%s)", column, sub))
+	c.args = append(c.args, args...)
+}
+
+func (c *clause) sql() string {
+	return strings.Join(c.parts, " AND ")
+}
+
+// exemption returns the condition that is true for the rows of the given table that are NOT protected by a pin, so that it can be appended to a deletion. If nothing in this table is protected, it returns an empty string.
+func (p *pinSet) exemption(tableName string) (string, []interface{}) {
+	if p.empty() {
+		return "", []interface{}{}
+	}
+	c := clause{table: tableName}
+	switch tableName {
+	case "Boards":
+		c.notIn("Fingerprint", p.boards)
+		c.notIn("Owner", p.keys)
+		c.notInSelect("Fingerprint", "Board", "Threads", filter{"Fingerprint", p.threads}, filter{"Owner", p.keys})
+		c.notInSelect("Fingerprint", "Board", "Posts", filter{"Owner", p.keys})
+	case "Threads":
+		c.notIn("Board", p.boards)
+		c.notIn("Fingerprint", p.threads)
+		c.notIn("Owner", p.keys)
+		c.notInSelect("Fingerprint", "Thread", "Posts", filter{"Owner", p.keys})
+	case "Posts":
+		c.notIn("Board", p.boards)
+		c.notIn("Thread", p.threads)
+		c.notIn("Owner", p.keys)
+		c.notInSelect("Thread", "Fingerprint", "Threads", filter{"Owner", p.keys})
+		c.notInSelect("Thread", "Thread", "Posts", filter{"Owner", p.keys})
+	case "Votes":
+		c.notIn("Board", p.boards)
+		c.notIn("Thread", p.threads)
+		c.notIn("Owner", p.keys)
+		c.notInSelect("Thread", "Fingerprint", "Threads", filter{"Owner", p.keys})
+		c.notInSelect("Thread", "Thread", "Posts", filter{"Owner", p.keys})
+	case "PublicKeys":
+		c.notIn("Fingerprint", p.keys)
+		c.notInSelect("Fingerprint", "Owner", "Boards", filter{"Fingerprint", p.boards})
+		c.notInSelect("Fingerprint", "Owner", "Threads", filter{"Board", p.boards}, filter{"Fingerprint", p.threads})
+		c.notInSelect("Fingerprint", "Owner", "Posts", filter{"Board", p.boards}, filter{"Thread", p.threads})
+	default:
+		return "", []interface{}{}
+	}
+	return c.sql(), c.args
+}
+
+var pinnableTables = []string{"Boards", "Threads", "Posts", "Votes", "PublicKeys"}
+
+// PinnedDbSizeMb estimates how much of the database is taken by pinned content. We do not know the on-disk size of individual rows, so this is the database size, scaled by the share of the rows that are protected by a pin.
+func PinnedDbSizeMb() int {
+	p, err := readPinSet()
+	if err != nil {
+		logging.Logf(1, "We could not read the pins to compute the pinned database size. Error: %v", err)
+		return 0
+	}
+	if p.empty() {
+		return 0
+	}
+	total, pinned := 0, 0
+	for _, tableName := range pinnableTables {
+		var t, u int
+		err := globals.DbInstance.Get(&t, fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName))
+		if err != nil {
+			logging.Logf(1, "We could not count the rows of a table to compute the pinned database size. Table: %s, Error: %v", tableName, err)
+			return 0
+		}
+		cond, args := p.exemption(tableName)
+		if len(cond) == 0 {
+			// Nothing in this table is pinned.
+			total += t
+			continue
+		}
+		err2 := globals.DbInstance.Get(&u, globals.DbInstance.Rebind(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", tableName, cond)), args...)
+		if err2 != nil {
+			logging.Logf(1, "We could not count the unpinned rows of a table to compute the pinned database size. Table: %s, Error: %v", tableName, err2)
+			return 0
+		}
+		total += t
+		pinned += t - u
+	}
+	if total == 0 {
+		return 0
+	}
+	return int(int64(liveDbSizeMb()) * int64(pinned) / int64(total))
+}
+
+// unpinnedDbSize is the size that the event horizon is responsible for. Pinned content is kept regardless, so it does not count against the max database size.
+func unpinnedDbSize() int {
+	size := liveDbSizeMb() - PinnedDbSizeMb()
+	if size < 0 {
+		return 0
+	}
+	return size
+}
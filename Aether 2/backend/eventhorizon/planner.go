@@ -0,0 +1,330 @@
+// Backend > Event Horizon > Planner
+// This file decides what to delete when the database is over its max size. Instead of moving the event horizon one day at a time and measuring the database after every step, we estimate how many bytes every board holds for every day, and pick the least valuable days to delete until we are under the limit. This is computed once, and executed in one pass.
+
+package eventhorizon
+
+import (
+	"aether-core/io/api"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"aether-core/services/toolbox"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+Which content goes first?
+
+  1) Boards that the user is not subscribed to, before boards that the user is subscribed to.
+  2) Within those, boards with less activity in the network head, before boards with more.
+  3) Within a board, older days before newer days.
+
+This means a busy board the user reads keeps the most of its history, and a quiet board nobody here reads is the first to go. Content within the network head is never deleted, and pinned content is never counted or deleted.
+
+Only threads, posts and votes are planned. Boards, keys and truststates are small, and they are handled by the local memory cutoff.
+*/
+
+const (
+	// A row takes more space on disk than the sum of its text fields. Fingerprints, integers and the index entries add up to roughly this many bytes per row.
+	rowOverheadBytes = 400
+	// The estimates are scaled to match the actual database size, since the text fields are not the whole story. We cap the scaling, because if the database is mostly made of other things (addresses, keys), scaling the board content to cover it would make us delete too little per bucket.
+	maxCalibrationRatio = 4.0
+	// A VACUUM rewrites the whole database file. SQLite reuses free pages for new rows anyway, so it's only worth it when a good share of the file is free.
+	minFreePageShareToCompact = 0.2
+)
+
+var plannedTables = []string{"Threads", "Posts", "Votes"}
+
+// revisionsSize is the SQL expression that estimates the bytes the revisions of a thread or post take. Revisions have no board or LastReferenced of their own, they go when their entity goes, so they are counted as a part of it.
+func revisionsSize(entityType, tableName string) string {
+	return fmt.Sprintf("COALESCE((SELECT SUM(LENGTH(Revisions.Body)+LENGTH(Revisions.Meta)+LENGTH(Revisions.UpdateProofOfWork)+LENGTH(Revisions.UpdateSignature)+%d) FROM Revisions WHERE Revisions.EntityType = '%s' AND Revisions.Fingerprint = %s.Fingerprint), 0)", rowOverheadBytes, entityType, tableName)
+}
+
+// sizeExpressions are the SQL expressions that estimate the bytes a row takes, per table.
+var sizeExpressions = map[string]string{
+	"Threads": "LENGTH(Name)+LENGTH(Body)+LENGTH(Link)+LENGTH(OwnerPublicKey)+LENGTH(ProofOfWork)+LENGTH(Signature)+LENGTH(UpdateProofOfWork)+LENGTH(UpdateSignature)+LENGTH(Meta)+LENGTH(EncrContent)+" + revisionsSize("thread", "Threads"),
+	"Posts":   "LENGTH(Body)+LENGTH(OwnerPublicKey)+LENGTH(ProofOfWork)+LENGTH(Signature)+LENGTH(UpdateProofOfWork)+LENGTH(UpdateSignature)+LENGTH(Meta)+LENGTH(EncrContent)+" + revisionsSize("post", "Posts"),
+	"Votes":   "LENGTH(OwnerPublicKey)+LENGTH(ProofOfWork)+LENGTH(Signature)+LENGTH(UpdateProofOfWork)+LENGTH(UpdateSignature)+LENGTH(Meta)+LENGTH(EncrContent)",
+}
+
+// boardDay is the content of one board that was last referenced within one day.
+type boardDay struct {
+	Board     api.Fingerprint `db:"Board"`
+	DayStart  Timestamp       `db:"DayStart"`
+	RowCount  int             `db:"RowCount"`
+	ByteCount int64           `db:"ByteCount"`
+}
+
+// PruneStep is the deletion of everything in one board last referenced before the cutoff.
+type PruneStep struct {
+	Board       api.Fingerprint
+	Subscribed  bool
+	Activity    int // Number of entities in the board within the network head.
+	Cutoff      Timestamp
+	RowCount    int
+	EstimatedMb float64
+}
+
+// PrunePlan is what the planner has decided to delete. It can be executed, or printed as a dry-run report.
+type PrunePlan struct {
+	DbSizeMb          int
+	PinnedDbSizeMb    int
+	MaxDbSizeMb       int
+	LocalMemoryCutoff Timestamp
+	NetworkHeadCutoff Timestamp
+	// LocalMemoryMb is the estimated size of the content that will be deleted by the local memory cutoff, before the planned steps.
+	LocalMemoryMb    float64
+	TargetFreeMb     float64
+	Steps            []PruneStep
+	EstimatedFreedMb float64
+	// ReachesNetworkHead is true if deleting everything outside the network head is still not enough to get under the max size.
+	ReachesNetworkHead bool
+}
+
+// Horizon returns the most recent cutoff of the plan. Every board is complete after this point.
+func (p *PrunePlan) Horizon() Timestamp {
+	var h Timestamp
+	for _, s := range p.Steps {
+		h = max(h, s.Cutoff)
+	}
+	return h
+}
+
+// Report renders the plan in a human readable form.
+func (p *PrunePlan) Report() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Database size: %d MB (pinned: %d MB), max: %d MB\n", p.DbSizeMb, p.PinnedDbSizeMb, p.MaxDbSizeMb)
+	fmt.Fprintf(&b, "Local memory cutoff: %s, estimated to free %.1f MB\n", time.Unix(int64(p.LocalMemoryCutoff), 0).Format("2006-01-02"), p.LocalMemoryMb)
+	fmt.Fprintf(&b, "Network head cutoff: %s, nothing after this is deleted\n", time.Unix(int64(p.NetworkHeadCutoff), 0).Format("2006-01-02"))
+	if len(p.Steps) == 0 {
+		b.WriteString("No further deletion is needed.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Needs to free %.1f MB. Planned deletions:\n", p.TargetFreeMb)
+	for _, s := range p.Steps {
+		sub := "not subscribed"
+		if s.Subscribed {
+			sub = "subscribed"
+		}
+		fmt.Fprintf(&b, "  %s (%s, activity: %d): %d entities before %s, ~%.1f MB\n", s.Board, sub, s.Activity, s.RowCount, time.Unix(int64(s.Cutoff), 0).Format("2006-01-02"), s.EstimatedMb)
+	}
+	fmt.Fprintf(&b, "Estimated to free %.1f MB.\n", p.EstimatedFreedMb)
+	if p.ReachesNetworkHead {
+		b.WriteString("Deleting everything outside the network head is not enough to get under the max size.\n")
+	}
+	return b.String()
+}
+
+// subscribedBoards returns the boards that the user is subscribed to, or has asked us to keep in sync.
+func subscribedBoards() map[api.Fingerprint]bool {
+	boards := make(map[api.Fingerprint]bool)
+	for _, fp := range globals.BackendConfig.GetSelectiveSyncBoards() {
+		boards[api.Fingerprint(fp)] = true
+	}
+	for _, fp := range globals.BackendTransientConfig.SubscribedBoards.List() {
+		boards[api.Fingerprint(fp)] = true
+	}
+	return boards
+}
+
+// readBoardDays reads how many rows and (estimated, uncalibrated) bytes every board has for every day, summed over the planned tables. Pinned rows are left out.
+func readBoardDays(pins *pinSet) (map[api.Fingerprint]map[Timestamp]*boardDay, int64, error) {
+	result := make(map[api.Fingerprint]map[Timestamp]*boardDay)
+	var total int64
+	for _, tableName := range plannedTables {
+		query := fmt.Sprintf("SELECT Board, (LastReferenced - (LastReferenced %% %d)) AS DayStart, COUNT(*) AS RowCount, SUM(%s) AS ByteCount FROM %s", Day, sizeExpressions[tableName], tableName)
+		cond, args := pins.exemption(tableName)
+		if len(cond) > 0 {
+			query = fmt.Sprintf("%s WHERE %s", query, cond)
+		}
+		query = fmt.Sprintf("%s GROUP BY Board, DayStart", query)
+		rows, err := globals.DbInstance.Queryx(globals.DbInstance.Rebind(query), args...)
+		if err != nil {
+			return result, total, errors.New(fmt.Sprintf("Reading the per-board sizes failed. Table: %s, Error: %v", tableName, err))
+		}
+		for rows.Next() {
+			var bd boardDay
+			err := rows.StructScan(&bd)
+			if err != nil {
+				rows.Close()
+				return result, total, errors.New(fmt.Sprintf("Reading the per-board sizes failed. Table: %s, Error: %v", tableName, err))
+			}
+			bd.ByteCount += int64(bd.RowCount * rowOverheadBytes)
+			total += bd.ByteCount
+			if result[bd.Board] == nil {
+				result[bd.Board] = make(map[Timestamp]*boardDay)
+			}
+			if existing := result[bd.Board][bd.DayStart]; existing != nil {
+				existing.RowCount += bd.RowCount
+				existing.ByteCount += bd.ByteCount
+				continue
+			}
+			b := bd
+			result[bd.Board][bd.DayStart] = &b
+		}
+		rows.Close()
+	}
+	return result, total, nil
+}
+
+// planPrune builds the plan that gets the database under its max size.
+func planPrune(pins *pinSet) (PrunePlan, error) {
+	plan := PrunePlan{
+		DbSizeMb:          liveDbSizeMb(),
+		PinnedDbSizeMb:    PinnedDbSizeMb(),
+		MaxDbSizeMb:       globals.BackendConfig.GetMaxDbSizeMb(),
+		LocalMemoryCutoff: Timestamp(toolbox.CnvToCutoffDays(globals.BackendConfig.GetLocalMemoryDays())),
+		NetworkHeadCutoff: Timestamp(toolbox.CnvToCutoffDays(globals.BackendConfig.GetNetworkHeadDays())),
+	}
+	days, totalBytes, err := readBoardDays(pins)
+	if err != nil {
+		return plan, err
+	}
+	unpinnedMb := float64(plan.DbSizeMb - plan.PinnedDbSizeMb)
+	ratio := 1.0
+	if totalBytes > 0 {
+		ratio = unpinnedMb * 1000000 / float64(totalBytes)
+	}
+	if ratio < 1 {
+		ratio = 1
+	}
+	if ratio > maxCalibrationRatio {
+		ratio = maxCalibrationRatio
+	}
+	toMb := func(bytes int64) float64 {
+		return float64(bytes) * ratio / 1000000
+	}
+	// Gather the candidates, and the activity of every board.
+	subs := subscribedBoards()
+	activity := make(map[api.Fingerprint]int)
+	candidates := []*boardDay{}
+	for board, bds := range days {
+		for dayStart, bd := range bds {
+			if dayStart+Day <= plan.LocalMemoryCutoff {
+				// The local memory cutoff deletes this regardless.
+				plan.LocalMemoryMb += toMb(bd.ByteCount)
+				continue
+			}
+			if dayStart+Day > plan.NetworkHeadCutoff {
+				activity[board] += bd.RowCount
+				continue
+			}
+			candidates = append(candidates, bd)
+		}
+	}
+	plan.TargetFreeMb = unpinnedMb - plan.LocalMemoryMb - float64(plan.MaxDbSizeMb)
+	if plan.TargetFreeMb <= 0 {
+		plan.TargetFreeMb = 0
+		return plan, nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if subs[a.Board] != subs[b.Board] {
+			return !subs[a.Board]
+		}
+		if activity[a.Board] != activity[b.Board] {
+			return activity[a.Board] < activity[b.Board]
+		}
+		if a.Board != b.Board {
+			return a.Board < b.Board
+		}
+		return a.DayStart < b.DayStart
+	})
+	steps := make(map[api.Fingerprint]*PruneStep)
+	order := []api.Fingerprint{}
+	for _, c := range candidates {
+		if plan.EstimatedFreedMb >= plan.TargetFreeMb {
+			break
+		}
+		s := steps[c.Board]
+		if s == nil {
+			s = &PruneStep{Board: c.Board, Subscribed: subs[c.Board], Activity: activity[c.Board]}
+			steps[c.Board] = s
+			order = append(order, c.Board)
+		}
+		s.Cutoff = max(s.Cutoff, c.DayStart+Day)
+		s.RowCount += c.RowCount
+		s.EstimatedMb += toMb(c.ByteCount)
+		plan.EstimatedFreedMb += toMb(c.ByteCount)
+	}
+	for _, board := range order {
+		plan.Steps = append(plan.Steps, *steps[board])
+	}
+	plan.ReachesNetworkHead = plan.EstimatedFreedMb < plan.TargetFreeMb
+	return plan, nil
+}
+
+// PlanPrune returns what PruneDB would delete right now, without deleting anything.
+func PlanPrune() (PrunePlan, error) {
+	pins, err := readPinSet()
+	if err != nil {
+		return PrunePlan{}, err
+	}
+	return planPrune(&pins)
+}
+
+// executePlan deletes the content of the plan.
+func executePlan(plan *PrunePlan, pins *pinSet) {
+	for _, s := range plan.Steps {
+		for _, tableName := range plannedTables {
+			query := fmt.Sprintf("DELETE FROM %s WHERE Board = ? AND LastReferenced < ?", tableName)
+			args := []interface{}{string(s.Board), s.Cutoff}
+			if cond, condArgs := pins.exemption(tableName); len(cond) > 0 {
+				query = fmt.Sprintf("%s AND %s", query, cond)
+				args = append(args, condArgs...)
+			}
+			_, err := globals.DbInstance.Exec(globals.DbInstance.Rebind(query), args...)
+			if err != nil {
+				logging.Logf(1, "The planned deletion failed. Table: %s, Board: %s, Error: %v", tableName, s.Board, err)
+			}
+		}
+	}
+}
+
+// liveDbSizeMb is the size of the data in the database. In SQLite, the database file does not shrink when rows are deleted: the freed pages are kept for reuse. Those don't count.
+func liveDbSizeMb() int {
+	if globals.BackendConfig.GetDbEngine() != "sqlite" {
+		return getDbSize()
+	}
+	var pageCount, freelistCount, pageSize int64
+	err := globals.DbInstance.Get(&pageCount, "PRAGMA page_count")
+	err2 := globals.DbInstance.Get(&freelistCount, "PRAGMA freelist_count")
+	err3 := globals.DbInstance.Get(&pageSize, "PRAGMA page_size")
+	if err != nil || err2 != nil || err3 != nil {
+		logging.Logf(1, "Reading the live database size failed, falling back to the file size. Errors: %v, %v, %v", err, err2, err3)
+		return getDbSize()
+	}
+	return int((pageCount - freelistCount) * pageSize / 1000000)
+}
+
+// compactDb returns the free pages of the SQLite database to the file system, so that the file size actually drops after a deletion, once they are at least minFreePageShareToCompact of the file. MySQL reuses freed pages within its tablespace, so it does not need this.
+func compactDb() {
+	if globals.BackendConfig.GetDbEngine() != "sqlite" {
+		return
+	}
+	var pageCount, freelistCount int
+	err := globals.DbInstance.Get(&pageCount, "PRAGMA page_count")
+	err2 := globals.DbInstance.Get(&freelistCount, "PRAGMA freelist_count")
+	if err != nil || err2 != nil || pageCount == 0 {
+		return
+	}
+	if float64(freelistCount) < float64(pageCount)*minFreePageShareToCompact {
+		return
+	}
+	var autoVacuum int
+	globals.DbInstance.Get(&autoVacuum, "PRAGMA auto_vacuum")
+	start := time.Now()
+	if autoVacuum == 2 { // Incremental
+		_, err = globals.DbInstance.Exec("PRAGMA incremental_vacuum")
+	} else {
+		_, err = globals.DbInstance.Exec("VACUUM")
+	}
+	if err != nil {
+		logging.Logf(1, "Compacting the database failed. Error: %v", err)
+		return
+	}
+	logging.Logf(2, "Compacted the database, %d free pages released. Time spent: %v", freelistCount, time.Since(start))
+}
@@ -0,0 +1,165 @@
+// Backend > Gateway
+// This package serves a read-only web version of the boards, threads and posts this node has, for the people who don't have Aether installed.
+
+package gateway
+
+import (
+	"aether-core/io/api"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"github.com/NYTimes/gziphandler"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+What is the gateway?
+
+A node serves other nodes: the caches and the POST endpoints in the Mim server. None of it is meant to be read by people. The gateway is a separate HTTP server, off by default, that renders what this node has into plain HTML pages, so that a link to a discussion can be opened in a browser.
+
+	/                   the boards
+	/board/<fingerprint>   the threads of a board
+	/thread/<fingerprint>  a thread, and its posts as a tree
+
+It only reads. There is no form, no POST, and nothing in the pages that runs: it's HTML and a little CSS, and the Content-Security-Policy header says so, in case something the users wrote gets through.
+
+Every page is read from the database, with the mod actions and the F451s applied (see moderation.go), and kept for a minute, so that a link shared widely doesn't mean a database read per visit. The boards in GatewayExcludedBoards aren't shown, nor are their threads.
+*/
+
+const (
+	// How long a rendered page is served before it's rendered again.
+	gatewayCacheDuration = 1 * time.Minute
+	// How many rendered pages we keep. When this is reached, the expired ones are dropped, and if that's not enough, all of them are.
+	gatewayCacheMaxPages = 1000
+	// How many pages can be rendering at the same time. The requests that come while this many are rendering get a 503, instead of queueing up against the database.
+	gatewayMaxConcurrentRenders = 4
+)
+
+type cachedPage struct {
+	body    []byte
+	expires time.Time
+}
+
+type pageCache struct {
+	lock  sync.Mutex
+	pages map[string]cachedPage
+}
+
+func (c *pageCache) get(path string, now time.Time) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	p, ok := c.pages[path]
+	if !ok || now.After(p.expires) {
+		return []byte{}, false
+	}
+	return p.body, true
+}
+
+func (c *pageCache) put(path string, body []byte, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.pages) >= gatewayCacheMaxPages {
+		for key, _ := range c.pages {
+			if now.After(c.pages[key].expires) {
+				delete(c.pages, key)
+			}
+		}
+		if len(c.pages) >= gatewayCacheMaxPages {
+			c.pages = make(map[string]cachedPage)
+		}
+	}
+	c.pages[path] = cachedPage{body: body, expires: now.Add(gatewayCacheDuration)}
+}
+
+var (
+	cache   = pageCache{pages: make(map[string]cachedPage)}
+	renders = make(chan struct{}, gatewayMaxConcurrentRenders)
+)
+
+// validFingerprint checks the fingerprint in the path before it goes anywhere near the database.
+func validFingerprint(fp string) bool {
+	if len(fp) == 0 || len(fp) > 64 {
+		return false
+	}
+	for _, r := range fp {
+		if !(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// render renders the page at the path. It returns errNotFound for the paths that aren't pages.
+func render(path string, now int64) ([]byte, error) {
+	if path == "/" {
+		return renderBoards(now)
+	}
+	if fp := strings.TrimPrefix(path, "/board/"); fp != path && validFingerprint(fp) {
+		return renderBoard(api.Fingerprint(fp), now)
+	}
+	if fp := strings.TrimPrefix(path, "/thread/"); fp != path && validFingerprint(fp) {
+		return renderThread(api.Fingerprint(fp), now)
+	}
+	return []byte{}, errNotFound
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	if r.Method != "GET" && r.Method != "HEAD" {
+		w.Header().Set("Allow", "GET, HEAD")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	now := time.Now()
+	body, ok := cache.get(r.URL.Path, now)
+	if !ok {
+		select {
+		case renders <- struct{}{}:
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var err error
+		body, err = render(r.URL.Path, now.Unix())
+		<-renders
+		if err == errNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Not found."))
+			return
+		}
+		if err != nil {
+			logging.Logf(1, "The gateway couldn't render this page. Path: %s, Error: %v", r.URL.Path, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		cache.put(r.URL.Path, body, now)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	w.Write(body)
+}
+
+// StartGateway serves the gateway on GatewayListenAddress. This blocks, run it in a goroutine.
+func StartGateway() {
+	listenAddr := globals.BackendConfig.GetGatewayListenAddress()
+	mux := http.NewServeMux()
+	mux.Handle("/", gziphandler.GzipHandler(http.HandlerFunc(handle)))
+	srv := &http.Server{
+		Addr:         listenAddr,
+		Handler:      mux,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 60 * time.Second,
+	}
+	logging.Logf(1, "Serving the read-only web gateway at: %s", listenAddr)
+	srvErr := srv.ListenAndServe()
+	logging.Logf(1, "The gateway stopped. Error: %v", srvErr)
+}
+
+// Enabled returns whether the gateway is turned on in the config.
+func Enabled() bool {
+	return len(globals.BackendConfig.GetGatewayListenAddress()) > 0
+}
@@ -0,0 +1,74 @@
+package gateway_test
+
+import (
+	"aether-core/backend/gateway"
+	"aether-core/io/api"
+	"testing"
+)
+
+func TestBoardMods_LeavesOutExpiredOwners(t *testing.T) {
+	b := api.Board{
+		Owner: "creator",
+		BoardOwners: []api.BoardOwner{
+			api.BoardOwner{KeyFingerprint: "current", Expiry: 2000, Level: 1},
+			api.BoardOwner{KeyFingerprint: "permanent", Level: 1},
+			api.BoardOwner{KeyFingerprint: "expired", Expiry: 500, Level: 1},
+		},
+	}
+	mods := gateway.BoardMods(&b, 1000)
+	for _, fp := range []api.Fingerprint{"creator", "current", "permanent"} {
+		if !mods[fp] {
+			t.Errorf("%s should be a mod of the board. Mods: %v", fp, mods)
+		}
+	}
+	if mods["expired"] {
+		t.Errorf("The owner whose ownership expired should not be a mod of the board. Mods: %v", mods)
+	}
+}
+
+func TestModBlocked(t *testing.T) {
+	mods := map[api.Fingerprint]bool{"mod": true}
+	block := api.Vote{Target: "post", Owner: "mod", TypeClass: 3, Type: 1}
+	approve := api.Vote{Target: "post", Owner: "mod", TypeClass: 3, Type: 2}
+	userBlock := api.Vote{Target: "post", Owner: "user", TypeClass: 3, Type: 1}
+	otherBlock := api.Vote{Target: "other", Owner: "mod", TypeClass: 3, Type: 1}
+	upvote := api.Vote{Target: "post", Owner: "mod", TypeClass: 1, Type: 1}
+	cases := []struct {
+		votes    []api.Vote
+		expected bool
+	}{
+		{[]api.Vote{block}, true},
+		{[]api.Vote{block, approve}, false},
+		{[]api.Vote{approve, block}, false},
+		{[]api.Vote{userBlock}, false},
+		{[]api.Vote{otherBlock}, false},
+		{[]api.Vote{upvote}, false},
+	}
+	for key, _ := range cases {
+		if result := gateway.ModBlocked("post", mods, cases[key].votes); result != cases[key].expected {
+			t.Errorf("ModBlocked gave the wrong result. Votes: %v, Expected: %v, Got: %v", cases[key].votes, cases[key].expected, result)
+		}
+	}
+}
+
+// caKey is the CA key listed in services/ca.
+const caKey = "VALIDCA1PK"
+
+func TestF451d_DomainAndExpiry(t *testing.T) {
+	tss := []api.Truststate{
+		api.Truststate{Target: "everywhere", TypeClass: 3, Type: 1, Expiry: 2000, OwnerPublicKey: caKey},
+		api.Truststate{Target: "inboard", TypeClass: 3, Type: 1, Domain: "board", Expiry: 2000, OwnerPublicKey: caKey},
+		api.Truststate{Target: "inotherboard", TypeClass: 3, Type: 1, Domain: "otherboard", Expiry: 2000, OwnerPublicKey: caKey},
+		api.Truststate{Target: "expired", TypeClass: 3, Type: 1, Expiry: 500, OwnerPublicKey: caKey},
+		api.Truststate{Target: "notf451", TypeClass: 1, Type: 1, Expiry: 2000, OwnerPublicKey: caKey},
+		api.Truststate{Target: "notca", TypeClass: 3, Type: 1, Expiry: 2000, OwnerPublicKey: "not a CA key"},
+	}
+	inBoard := gateway.F451d("board", tss, 1000)
+	if !inBoard["everywhere"] || !inBoard["inboard"] || inBoard["inotherboard"] || inBoard["expired"] || inBoard["notf451"] || inBoard["notca"] {
+		t.Errorf("The F451s in the board are wrong. Got: %v", inBoard)
+	}
+	global := gateway.F451d("", tss, 1000)
+	if !global["everywhere"] || global["inboard"] || global["inotherboard"] {
+		t.Errorf("The F451s everywhere are wrong. Got: %v", global)
+	}
+}
@@ -0,0 +1,91 @@
+// Backend > Gateway > Moderation
+// This file decides which of the boards, threads and posts the gateway doesn't show.
+
+package gateway
+
+import (
+	"aether-core/io/api"
+	"aether-core/services/ca"
+)
+
+/*
+What does the gateway hide?
+
+The gateway has no user, so it can't have the user's own blocks and approvals the way the frontend does (SelfModBlocked, SelfModApproved). What it has is the network's: the mod actions of the mods of a board, and the F451s of the CAs.
+
+- A thread or a post that a mod of its board has blocked is hidden, unless a mod of the board has also approved it. This is the same rule the frontend uses when it's not showing deleted content. The replies to a hidden post are hidden with it, like the orphans in the frontend.
+- Whatever a user F451'd by a CA has written is hidden, if the F451 is for everywhere, or for the board it's in. Only the CA keys we actually list count, the frontend's debug override that trusts every key as a CA does not apply here.
+- Whatever is encrypted for a realm is hidden. We can't read it, and it's not for everybody to read anyway.
+
+The mods of a board are its owner, and the board owners whose ownership hasn't expired. The frontend also counts the mods the users elect, but those need the user's own signals to compute, which we don't have here. So on the gateway, a board has fewer mods than in the app, never more.
+*/
+
+const (
+	voteTypeClassModActions = 3
+	voteTypeModBlock        = 1
+	voteTypeModApprove      = 2
+
+	truststateTypeClassF451    = 3
+	truststateTypeCensorAssign = 1
+)
+
+// BoardMods returns the fingerprints of the keys that can moderate the board.
+func BoardMods(b *api.Board, now int64) map[api.Fingerprint]bool {
+	mods := make(map[api.Fingerprint]bool)
+	if len(b.Owner) > 0 {
+		mods[b.Owner] = true
+	}
+	for key, _ := range b.BoardOwners {
+		bo := b.BoardOwners[key]
+		if bo.Expiry != 0 && int64(bo.Expiry) < now {
+			continue
+		}
+		mods[bo.KeyFingerprint] = true
+	}
+	return mods
+}
+
+// ModBlocked returns whether the mods have blocked the entity with the given fingerprint, and haven't approved it. The votes can be of any target, the ones that aren't mod actions on this entity are ignored.
+func ModBlocked(fp api.Fingerprint, mods map[api.Fingerprint]bool, votes []api.Vote) bool {
+	blocked := false
+	for key, _ := range votes {
+		v := votes[key]
+		if v.Target != fp || v.TypeClass != voteTypeClassModActions || !mods[v.Owner] {
+			continue
+		}
+		if v.Type == voteTypeModApprove {
+			return false
+		}
+		if v.Type == voteTypeModBlock {
+			blocked = true
+		}
+	}
+	return blocked
+}
+
+// F451d returns the users that the CAs have F451'd, everywhere, or in the given board. Give a blank board for only the ones that are F451'd everywhere.
+func F451d(boardfp api.Fingerprint, truststates []api.Truststate, now int64) map[api.Fingerprint]bool {
+	users := make(map[api.Fingerprint]bool)
+	for key, _ := range truststates {
+		ts := truststates[key]
+		if ts.TypeClass != truststateTypeClassF451 || ts.Type != truststateTypeCensorAssign {
+			continue
+		}
+		if int64(ts.Expiry) < now {
+			continue
+		}
+		if len(ts.Domain) > 0 && ts.Domain != boardfp {
+			continue
+		}
+		if !ca.IsListedCAKey(ts.OwnerPublicKey) {
+			continue
+		}
+		users[ts.Target] = true
+	}
+	return users
+}
+
+// encrypted returns whether the entity has content only the members of a realm can read.
+func encrypted(encrContent string) bool {
+	return len(encrContent) > 0
+}
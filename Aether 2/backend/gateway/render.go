@@ -0,0 +1,394 @@
+// Backend > Gateway > Render
+// This file reads the boards, threads and posts from the database, and renders them into HTML pages.
+
+package gateway
+
+import (
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/globals"
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"time"
+)
+
+const (
+	// How many of the boards, threads, and posts we show at most in one page. The boards are the most recently referenced ones, and the threads are the newest ones. The posts are the oldest ones, so that a thread is shown from the start.
+	maxBoardsShown  = 500
+	maxThreadsShown = 200
+	maxPostsShown   = 2000
+)
+
+// errNotFound is for the boards and threads we don't have, or we don't show.
+var errNotFound = errors.New("Not found.")
+
+type boardView struct {
+	Fingerprint string
+	Name        string
+	Description string
+	Author      string
+	Created     string
+}
+
+type threadView struct {
+	Fingerprint string
+	Name        string
+	Body        string
+	Link        string
+	Author      string
+	Created     string
+}
+
+type postView struct {
+	Fingerprint string
+	Body        string
+	Author      string
+	Created     string
+	Children    []*postView
+}
+
+type boardsPage struct {
+	Title  string
+	Boards []boardView
+}
+
+type boardPage struct {
+	Title   string
+	Board   boardView
+	Threads []threadView
+}
+
+type threadPage struct {
+	Title  string
+	Board  boardView
+	Thread threadView
+	Posts  []*postView
+}
+
+func renderTime(ts api.Timestamp) string {
+	return time.Unix(int64(ts), 0).UTC().Format("2006-01-02 15:04 UTC")
+}
+
+func excluded(boardfp api.Fingerprint) bool {
+	for _, fp := range globals.BackendConfig.GetGatewayExcludedBoards() {
+		if api.Fingerprint(fp) == boardfp {
+			return true
+		}
+	}
+	return false
+}
+
+// readF451s reads all F451s. There are few of these, so it's cheaper to read them all once than to ask for the ones of every user in the page.
+func readF451s(now int64) ([]api.Truststate, error) {
+	return persistence.ReadTruststates(nil, 0, api.Timestamp(now), truststateTypeClassF451, truststateTypeCensorAssign, "", "", "", 0, 0)
+}
+
+// authorNames returns the names of the users with the given key fingerprints. The ones we don't have the keys of are given by their fingerprints.
+func authorNames(fps map[api.Fingerprint]bool) map[api.Fingerprint]string {
+	names := make(map[api.Fingerprint]string)
+	list := []api.Fingerprint{}
+	for fp, _ := range fps {
+		if len(fp) == 0 {
+			continue
+		}
+		list = append(list, fp)
+		if len(fp) > 12 {
+			names[fp] = string(fp[:12])
+		} else {
+			names[fp] = string(fp)
+		}
+	}
+	if len(list) == 0 {
+		return names
+	}
+	keys, err := persistence.ReadKeys(list, 0, 0, "", 0, 0)
+	if err != nil {
+		// The page is still useful without the names.
+		return names
+	}
+	for key, _ := range keys {
+		if len(keys[key].Name) > 0 && !encrypted(keys[key].EncrContent) {
+			names[keys[key].Fingerprint] = keys[key].Name
+		}
+	}
+	return names
+}
+
+func readBoard(fp api.Fingerprint, f451d map[api.Fingerprint]bool) (api.Board, error) {
+	if excluded(fp) {
+		return api.Board{}, errNotFound
+	}
+	boards, err := persistence.ReadBoards([]api.Fingerprint{fp}, 0, 0, "", 0, 0)
+	if err != nil {
+		return api.Board{}, err
+	}
+	if len(boards) == 0 || encrypted(boards[0].EncrContent) || f451d[boards[0].Owner] {
+		return api.Board{}, errNotFound
+	}
+	return boards[0], nil
+}
+
+func renderBoards(now int64) ([]byte, error) {
+	boards, err := persistence.ReadBoards(nil, 0, api.Timestamp(now), "", 0, 0)
+	if err != nil {
+		return []byte{}, err
+	}
+	tss, err2 := readF451s(now)
+	if err2 != nil {
+		return []byte{}, err2
+	}
+	f451d := F451d("", tss, now)
+	page := boardsPage{Title: "Boards"}
+	owners := make(map[api.Fingerprint]bool)
+	shown := []api.Board{}
+	for key, _ := range boards {
+		b := boards[key]
+		if excluded(b.Fingerprint) || encrypted(b.EncrContent) || f451d[b.Owner] {
+			continue
+		}
+		shown = append(shown, b)
+		owners[b.Owner] = true
+		if len(shown) >= maxBoardsShown {
+			break
+		}
+	}
+	names := authorNames(owners)
+	for key, _ := range shown {
+		page.Boards = append(page.Boards, boardView{
+			Fingerprint: string(shown[key].Fingerprint),
+			Name:        shown[key].Name,
+			Description: shown[key].Description,
+			Author:      names[shown[key].Owner],
+			Created:     renderTime(shown[key].Creation),
+		})
+	}
+	return execute("boards", page)
+}
+
+func renderBoard(fp api.Fingerprint, now int64) ([]byte, error) {
+	tss, err := readF451s(now)
+	if err != nil {
+		return []byte{}, err
+	}
+	b, err2 := readBoard(fp, F451d("", tss, now))
+	if err2 != nil {
+		return []byte{}, err2
+	}
+	threads, err3 := persistence.ReadThreads(nil, 0, api.Timestamp(now), string(fp), "", 0, 0)
+	if err3 != nil {
+		return []byte{}, err3
+	}
+	// Only the mod actions on the threads, not on their posts.
+	votes, err4 := persistence.ReadVotes(nil, 0, api.Timestamp(now), voteTypeClassModActions, -1, string(fp), "", "", true, "", 0, 0)
+	if err4 != nil {
+		return []byte{}, err4
+	}
+	mods := BoardMods(&b, now)
+	f451d := F451d(fp, tss, now)
+	sort.Slice(threads, func(i, j int) bool { return threads[i].Creation > threads[j].Creation })
+	shown := []api.Thread{}
+	authors := map[api.Fingerprint]bool{b.Owner: true}
+	for key, _ := range threads {
+		t := threads[key]
+		if encrypted(t.EncrContent) || f451d[t.Owner] || ModBlocked(t.Fingerprint, mods, votes) {
+			continue
+		}
+		shown = append(shown, t)
+		authors[t.Owner] = true
+		if len(shown) >= maxThreadsShown {
+			break
+		}
+	}
+	names := authorNames(authors)
+	page := boardPage{Title: b.Name, Board: boardToView(&b, names)}
+	for key, _ := range shown {
+		page.Threads = append(page.Threads, threadToView(&shown[key], names))
+	}
+	return execute("board", page)
+}
+
+func renderThread(fp api.Fingerprint, now int64) ([]byte, error) {
+	threads, err := persistence.ReadThreads([]api.Fingerprint{fp}, 0, 0, "", "", 0, 0)
+	if err != nil {
+		return []byte{}, err
+	}
+	if len(threads) == 0 {
+		return []byte{}, errNotFound
+	}
+	t := threads[0]
+	tss, err2 := readF451s(now)
+	if err2 != nil {
+		return []byte{}, err2
+	}
+	b, err3 := readBoard(t.Board, F451d("", tss, now))
+	if err3 != nil {
+		return []byte{}, err3
+	}
+	// The mod actions on the thread and on its posts.
+	votes, err4 := persistence.ReadVotes(nil, 0, api.Timestamp(now), voteTypeClassModActions, -1, "", string(fp), "", false, "", 0, 0)
+	if err4 != nil {
+		return []byte{}, err4
+	}
+	mods := BoardMods(&b, now)
+	f451d := F451d(b.Fingerprint, tss, now)
+	if encrypted(t.EncrContent) || f451d[t.Owner] || ModBlocked(t.Fingerprint, mods, votes) {
+		return []byte{}, errNotFound
+	}
+	posts, err5 := persistence.ReadPosts(nil, 0, api.Timestamp(now), "", string(fp), "", "", 0, 0)
+	if err5 != nil {
+		return []byte{}, err5
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].Creation < posts[j].Creation })
+	visible := []api.Post{}
+	authors := map[api.Fingerprint]bool{b.Owner: true, t.Owner: true}
+	for key, _ := range posts {
+		p := posts[key]
+		if encrypted(p.EncrContent) || f451d[p.Owner] || ModBlocked(p.Fingerprint, mods, votes) {
+			continue
+		}
+		visible = append(visible, p)
+		authors[p.Owner] = true
+	}
+	names := authorNames(authors)
+	page := threadPage{
+		Title:  t.Name,
+		Board:  boardToView(&b, names),
+		Thread: threadToView(&t, names),
+		Posts:  buildPostTree(t.Fingerprint, visible, names),
+	}
+	return execute("thread", page)
+}
+
+// buildPostTree puts the posts under their parents, the root posts being the ones whose parent is the thread. The posts whose parents aren't in the list are left out: their parents are either hidden, or we don't have them.
+func buildPostTree(threadfp api.Fingerprint, posts []api.Post, names map[api.Fingerprint]string) []*postView {
+	children := make(map[api.Fingerprint][]*api.Post)
+	for key, _ := range posts {
+		children[posts[key].Parent] = append(children[posts[key].Parent], &posts[key])
+	}
+	count := 0
+	seen := make(map[api.Fingerprint]bool)
+	var build func(parent api.Fingerprint) []*postView
+	build = func(parent api.Fingerprint) []*postView {
+		views := []*postView{}
+		for _, p := range children[parent] {
+			if seen[p.Fingerprint] || count >= maxPostsShown {
+				continue
+			}
+			seen[p.Fingerprint] = true
+			count++
+			views = append(views, &postView{
+				Fingerprint: string(p.Fingerprint),
+				Body:        p.Body,
+				Author:      names[p.Owner],
+				Created:     renderTime(p.Creation),
+				Children:    build(p.Fingerprint),
+			})
+		}
+		return views
+	}
+	return build(threadfp)
+}
+
+func boardToView(b *api.Board, names map[api.Fingerprint]string) boardView {
+	return boardView{
+		Fingerprint: string(b.Fingerprint),
+		Name:        b.Name,
+		Description: b.Description,
+		Author:      names[b.Owner],
+		Created:     renderTime(b.Creation),
+	}
+}
+
+func threadToView(t *api.Thread, names map[api.Fingerprint]string) threadView {
+	return threadView{
+		Fingerprint: string(t.Fingerprint),
+		Name:        t.Name,
+		Body:        t.Body,
+		Link:        t.Link,
+		Author:      names[t.Owner],
+		Created:     renderTime(t.Creation),
+	}
+}
+
+func execute(name string, data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	err := pages.ExecuteTemplate(&buf, name, data)
+	if err != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The gateway page failed to render. Page: %s, Error: %v", name, err))
+	}
+	return buf.Bytes(), nil
+}
+
+// The content is shown as plain text. The app renders it as Markdown, but html/template escaping the plain text is the only way we can be sure nothing the users write ends up as markup in the page.
+var pages = template.Must(template.New("pages").Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noarchive">
+<title>{{.Title}} - Aether</title>
+<style>
+body { font-family: sans-serif; max-width: 50em; margin: 0 auto; padding: 1em; color: #222; }
+a { color: #2a5db0; }
+.meta { color: #777; font-size: 0.85em; }
+.body { white-space: pre-wrap; word-wrap: break-word; }
+.post { border-left: 2px solid #ddd; padding-left: 0.8em; margin: 0.8em 0; }
+.notice { color: #777; font-size: 0.85em; border-top: 1px solid #ddd; margin-top: 2em; padding-top: 0.5em; }
+</style>
+</head>
+<body>
+{{end}}
+
+{{define "footer"}}
+<p class="notice">This is a read-only copy of the content this Aether node has. To reply, vote, or see everything, use the Aether app.</p>
+</body>
+</html>
+{{end}}
+
+{{define "post"}}
+<div class="post" id="{{.Fingerprint}}">
+<p class="meta">{{.Author}} · {{.Created}}</p>
+<div class="body">{{.Body}}</div>
+{{range .Children}}{{template "post" .}}{{end}}
+</div>
+{{end}}
+
+{{define "boards"}}{{template "header" .}}
+<h1>Boards</h1>
+{{range .Boards}}
+<div>
+<h3><a href="/board/{{.Fingerprint}}">{{.Name}}</a></h3>
+<p class="body">{{.Description}}</p>
+</div>
+{{else}}
+<p>There are no boards to show.</p>
+{{end}}
+{{template "footer" .}}{{end}}
+
+{{define "board"}}{{template "header" .}}
+<p class="meta"><a href="/">Boards</a></p>
+<h1>{{.Board.Name}}</h1>
+<p class="body">{{.Board.Description}}</p>
+{{range .Threads}}
+<div>
+<h3><a href="/thread/{{.Fingerprint}}">{{.Name}}</a></h3>
+<p class="meta">{{.Author}} · {{.Created}}</p>
+</div>
+{{else}}
+<p>There are no threads to show in this board.</p>
+{{end}}
+{{template "footer" .}}{{end}}
+
+{{define "thread"}}{{template "header" .}}
+<p class="meta"><a href="/">Boards</a> › <a href="/board/{{.Board.Fingerprint}}">{{.Board.Name}}</a></p>
+<h1>{{.Thread.Name}}</h1>
+<p class="meta">{{.Thread.Author}} · {{.Thread.Created}}</p>
+{{if .Thread.Link}}<p><a href="{{.Thread.Link}}" rel="nofollow noopener noreferrer">{{.Thread.Link}}</a></p>{{end}}
+<div class="body">{{.Thread.Body}}</div>
+{{range .Posts}}{{template "post" .}}{{end}}
+{{template "footer" .}}{{end}}
+`))
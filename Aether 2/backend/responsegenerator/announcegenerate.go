@@ -0,0 +1,29 @@
+// Backend > ResponseGenerator > AnnounceGenerate
+// This file provides the function that responds to the remotes announcing new entities to us.
+
+package responsegenerator
+
+import (
+	"aether-core/io/api"
+	"aether-core/services/globals"
+	"errors"
+	"fmt"
+)
+
+// GenerateAnnounceResponse responds to a remote announcing entities to us. There is nothing to give back, the response only tells the remote that we got the announcement. Whether we fetch what is in it is up to us.
+func GenerateAnnounceResponse() ([]byte, error) {
+	var resp api.ApiResponse
+	resp.Prefill()
+	resp.Endpoint = "announce"
+	// There are no entities in this response. The name of the endpoint stands in for the entity type, which the bounds check on the receiving end needs.
+	resp.Entity = "announce"
+	signingErr := resp.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+	if signingErr != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The announce response that was prepared to respond to this query failed to be page-signed. Error: %#v", signingErr))
+	}
+	jsonResp, err := resp.ToJSON()
+	if err != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The announce response that was prepared to respond to this query failed to convert to JSON. Error: %#v", err))
+	}
+	return jsonResp, nil
+}
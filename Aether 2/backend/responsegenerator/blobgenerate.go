@@ -0,0 +1,50 @@
+// Backend > ResponseGenerator > BlobGenerate
+// This file provides the function that responds to the remotes asking for the chunks of attachments.
+
+package responsegenerator
+
+import (
+	"aether-core/io/api"
+	"aether-core/io/blobstore"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"errors"
+	"fmt"
+)
+
+// GenerateBlobsResponse responds to a remote asking for chunks. The hashes are given as a 'blob' filter. We give the chunks we have, and leave out the ones we don't, the remote can ask somebody else for those.
+func GenerateBlobsResponse(req api.ApiResponse) ([]byte, error) {
+	hashes := []string{}
+	for _, filter := range req.Filters {
+		if filter.Type == "blob" {
+			hashes = append(hashes, filter.Values...)
+		}
+	}
+	if len(hashes) > api.MaxBlobsPerRequest {
+		hashes = hashes[:api.MaxBlobsPerRequest]
+	}
+	blobs := []api.Blob{}
+	for _, h := range hashes {
+		data, err := blobstore.Get(h)
+		if err != nil {
+			continue
+		}
+		blobs = append(blobs, api.Blob{Hash: h, Data: data})
+	}
+	logging.Logf(2, "Responding to a chunk request. Asked: %d, Given: %d", len(hashes), len(blobs))
+	var resp api.ApiResponse
+	resp.Prefill()
+	resp.Endpoint = "blobs"
+	// Chunks are not an entity type. The name of the endpoint stands in for one, which the bounds check on the receiving end needs.
+	resp.Entity = "blobs"
+	resp.ResponseBody.Blobs = blobs
+	signingErr := resp.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+	if signingErr != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The chunk response that was prepared to respond to this query failed to be page-signed. Error: %#v", signingErr))
+	}
+	jsonResp, err := resp.ToJSON()
+	if err != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The chunk response that was prepared to respond to this query failed to convert to JSON. Error: %#v", err))
+	}
+	return jsonResp, nil
+}
@@ -0,0 +1,63 @@
+// Backend > ResponseGenerator > BundleGenerate
+// This file generates bundles, the file form of a sync. A bundle is made of the same signed pages that we serve in our caches, so the receiving node can verify it the same way it verifies a sync.
+
+package responsegenerator
+
+import (
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var bundledEntityTypes = []string{"boards", "threads", "posts", "votes", "keys", "truststates", "addresses"}
+
+// GenerateBundle collects every entity that has arrived to this node after the given timestamp into signed pages.
+func GenerateBundle(since api.Timestamp) (api.Bundle, error) {
+	now := api.Timestamp(time.Now().Unix())
+	b := api.Bundle{
+		BundleVersion: api.BUNDLE_VERSION,
+		NodePublicKey: globals.BackendConfig.GetMarshaledBackendPublicKey(),
+		Since:         since,
+		Until:         now,
+	}
+	for _, respType := range bundledEntityTypes {
+		var localData api.Response
+		if respType == "addresses" {
+			// Same as the caches, only the addresses that this computer has personally connected to.
+			addresses, err := persistence.ReadAddresses("", "", 0, since, now, 0, 0, 0, "timerange_all")
+			if err != nil {
+				return b, errors.New(fmt.Sprintf("Reading the addresses for the bundle failed. Error: %v", err))
+			}
+			localData.Addresses = *sanitiseOutboundAddresses(&addresses)
+		} else {
+			data, err := persistence.Read(respType, []api.Fingerprint{}, []string{}, since, now, false, nil)
+			if err != nil {
+				return b, errors.New(fmt.Sprintf("Reading the entities for the bundle failed. Entity type: %s, Error: %v", respType, err))
+			}
+			localData = data
+		}
+		pages := convertResponsesToApiResponses(splitEntitiesToPages(&localData))
+		counts := countEntities(&localData)
+		for i, _ := range *pages {
+			page := &(*pages)[i]
+			page.Caching.EntityCounts = *counts
+			page.Pagination.Pages = uint64(len(*pages))
+			page.Pagination.CurrentPage = uint64(i)
+			page.StartsFrom = since
+			page.EndsAt = now
+			page.Entity = respType
+			page.Endpoint = respType
+			signingErr := page.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+			if signingErr != nil {
+				return b, errors.New(fmt.Sprintf("A page of the bundle failed to be page-signed. Entity type: %s, Error: %v", respType, signingErr))
+			}
+			b.Pages = append(b.Pages, *page)
+		}
+		logging.Logf(1, "Bundled %d pages of %s.", len(*pages), respType)
+	}
+	return b, nil
+}
@@ -208,7 +208,7 @@ func CreateNewCache(respType string, start api.Timestamp, end api.Timestamp, all
 	startAsString := strconv.FormatInt(int64(cacheData.start), 10)
 	endAsString := strconv.FormatInt(int64(cacheData.end), 10)
 	filter := api.Filter{Type: "timestamp", Values: []string{startAsString, endAsString}}
-	generateContainer(ePagesApiresp, iPagesApiresp, mPagesApiresp, cacheData.counts, &[]api.Filter{filter}, cacheData.cacheName, false, respType, api.Timestamp(cacheData.start))
+	generateContainer(ePagesApiresp, iPagesApiresp, mPagesApiresp, cacheData.counts, &[]api.Filter{filter}, cacheData.cacheName, false, respType, api.Timestamp(cacheData.start), false)
 	// Generate endpoint index.
 	epd, err := generateEndpointDir(respType)
 	if err != nil {
@@ -409,6 +409,7 @@ func GenerateCaches() {
 	for _, val := range entityTypes {
 		GenerateCachedEndpoint(val)
 	}
+	DropServedMerkleTrees()
 	// We're setting this for the purposes of denying POST requests with a timestamp that is partially or wholly available within our cache bracket. (That is, it's not used to determine where to start generating caches from, we read the actual saved cache for that.)
 	globals.BackendConfig.SetLastCacheGenerationTimestamp(time.Now().Unix())
 	elapsed := time.Since(start)
@@ -0,0 +1,60 @@
+// Backend > ResponseGenerator > EntityGenerate
+// This file provides the function that responds to the remotes asking for entities by their fingerprints.
+
+package responsegenerator
+
+import (
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"errors"
+	"fmt"
+)
+
+// GenerateEntitiesResponse responds to a remote asking for entities by their fingerprints. The fingerprints are given as a 'fingerprint' filter, and they can be of any entity type. We give the entities we have, and leave out the ones we don't.
+func GenerateEntitiesResponse(req api.ApiResponse) ([]byte, error) {
+	fps := []api.Fingerprint{}
+	for _, filter := range req.Filters {
+		if filter.Type == "fingerprint" {
+			for _, fp := range filter.Values {
+				fps = append(fps, api.Fingerprint(fp))
+			}
+		}
+	}
+	if len(fps) > api.MaxEntitiesPerRequest {
+		fps = fps[:api.MaxEntitiesPerRequest]
+	}
+	var localData api.Response
+	if len(fps) > 0 {
+		for _, entityType := range []string{"boards", "threads", "posts", "votes", "keys", "truststates"} {
+			r, err := persistence.Read(entityType, fps, []string{}, 0, 0, false, nil)
+			if err != nil {
+				return []byte{}, errors.New(fmt.Sprintf("The query coming from the remote caused an error in the local database while trying to respond to this request. Error: %#v\n, Request: %#v\n", err, req))
+			}
+			localData.Insert(&r)
+		}
+	}
+	localData.FilterForRemote(&req.Address)
+	logging.Logf(2, "Responding to an entity request. Asked: %d, Given: %d", len(fps), len(localData.Boards)+len(localData.Threads)+len(localData.Posts)+len(localData.Votes)+len(localData.Keys)+len(localData.Truststates))
+	var resp api.ApiResponse
+	resp.Prefill()
+	resp.Endpoint = "entities"
+	// The entities can be of any type. The name of the endpoint stands in for the entity type, which the bounds check on the receiving end needs.
+	resp.Entity = "entities"
+	resp.ResponseBody.Boards = localData.Boards
+	resp.ResponseBody.Threads = localData.Threads
+	resp.ResponseBody.Posts = localData.Posts
+	resp.ResponseBody.Votes = localData.Votes
+	resp.ResponseBody.Keys = localData.Keys
+	resp.ResponseBody.Truststates = localData.Truststates
+	signingErr := resp.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+	if signingErr != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The entity response that was prepared to respond to this query failed to be page-signed. Error: %#v", signingErr))
+	}
+	jsonResp, err := resp.ToJSON()
+	if err != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The entity response that was prepared to respond to this query failed to convert to JSON. Error: %#v", err))
+	}
+	return jsonResp, nil
+}
@@ -38,6 +38,7 @@ func createThreadIndex(entity *api.Thread, pageNum int) api.ThreadIndex {
 	entityIndex.Board = entity.Board
 	entityIndex.Creation = entity.Creation
 	entityIndex.Fingerprint = entity.GetFingerprint()
+	entityIndex.LastUpdate = entity.LastUpdate
 	entityIndex.PageNumber = pageNum
 	entityIndex.EntityVersion = entity.EntityVersion
 	return entityIndex
@@ -49,6 +50,7 @@ func createPostIndex(entity *api.Post, pageNum int) api.PostIndex {
 	entityIndex.Thread = entity.Thread
 	entityIndex.Creation = entity.Creation
 	entityIndex.Fingerprint = entity.GetFingerprint()
+	entityIndex.LastUpdate = entity.LastUpdate
 	entityIndex.PageNumber = pageNum
 	entityIndex.EntityVersion = entity.EntityVersion
 	return entityIndex
@@ -182,3 +184,27 @@ func bakeIndexes(indexPages *[]api.ApiResponse, entityCounts *[]api.EntityCount,
 		saveFileToDisk(indexJsonResp, indexdir, filename)
 	}
 }
+
+// CreateAnnouncement creates the index forms of the entities in the response, for gossip to announce them to other nodes. Addresses are not announced.
+func CreateAnnouncement(r *api.Response) api.Answer {
+	var a api.Answer
+	for key, _ := range r.Boards {
+		a.BoardIndexes = append(a.BoardIndexes, createBoardIndex(&r.Boards[key], 0))
+	}
+	for key, _ := range r.Threads {
+		a.ThreadIndexes = append(a.ThreadIndexes, createThreadIndex(&r.Threads[key], 0))
+	}
+	for key, _ := range r.Posts {
+		a.PostIndexes = append(a.PostIndexes, createPostIndex(&r.Posts[key], 0))
+	}
+	for key, _ := range r.Votes {
+		a.VoteIndexes = append(a.VoteIndexes, createVoteIndex(&r.Votes[key], 0))
+	}
+	for key, _ := range r.Keys {
+		a.KeyIndexes = append(a.KeyIndexes, createKeyIndex(&r.Keys[key], 0))
+	}
+	for key, _ := range r.Truststates {
+		a.TruststateIndexes = append(a.TruststateIndexes, createTruststateIndex(&r.Truststates[key], 0))
+	}
+	return a
+}
@@ -24,7 +24,7 @@ import (
 	"time"
 )
 
-func bakeEntityPages(resultPages *[]api.ApiResponse, entityCounts *[]api.EntityCount, filters *[]api.Filter, foldername string, isPOST bool, respType string, entityType string) {
+func bakeEntityPages(resultPages *[]api.ApiResponse, entityCounts *[]api.EntityCount, filters *[]api.Filter, foldername string, isPOST bool, respType string, entityType string, reusable bool) {
 	protv := globals.BackendConfig.GetProtURLVersion()
 	var responsedir string
 	if isPOST {
@@ -67,7 +67,7 @@ func bakeEntityPages(resultPages *[]api.ApiResponse, entityCounts *[]api.EntityC
 		name := fmt.Sprint(i, ".json")
 		saveFileToDisk(jsonResp, responsedir, name)
 	}
-	if isPOST {
+	if isPOST && reusable {
 		start, _ := strconv.Atoi((*filters)[0].Values[0])
 		dbReadStartLoc := api.Timestamp(start)
 		insertIntoPOSTResponseReuseTracker(&(*resultPages)[0], foldername, dbReadStartLoc)
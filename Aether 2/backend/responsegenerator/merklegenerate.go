@@ -0,0 +1,172 @@
+// Backend > ResponseGenerator > MerkleGenerate
+// This file provides the functions that build the Merkle trees of the local entity set, and the responses to the Merkle tree requests from remotes.
+
+package responsegenerator
+
+import (
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MerkleWindowStart returns the beginning of the time range our Merkle trees cover. This is the later one of the network memory and the event horizon, rounded up to the next full bucket. A bucket that is only partially within our memory would never match the remote's, so we leave it out entirely.
+func MerkleWindowStart() api.Timestamp {
+	start := time.Now().Add(-time.Duration(globals.BackendConfig.GetNetworkMemoryDays()*24) * time.Hour).Unix()
+	eh := globals.BackendConfig.GetEventHorizonTimestamp()
+	if eh > start {
+		start = eh
+	}
+	bucketStart := api.MerkleBucketStart(api.Timestamp(start))
+	if bucketStart < start {
+		bucketStart = bucketStart + api.MerkleBucketSeconds
+	}
+	return api.Timestamp(bucketStart)
+}
+
+// BuildLocalMerkleTree builds the Merkle tree of the entities of the given type that we have in our database.
+func BuildLocalMerkleTree(entityType string) (*api.MerkleTree, error) {
+	return buildMerkleTree(entityType, MerkleWindowStart(), api.Timestamp(time.Now().Unix()))
+}
+
+func buildMerkleTree(entityType string, start, end api.Timestamp) (*api.MerkleTree, error) {
+	leaves, err := persistence.ReadMerkleLeaves(entityType, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewMerkleTree(leaves), nil
+}
+
+/*
+How do we keep the remotes from making us read our database over and over?
+
+Building a tree reads every entity of the type within our memory. A remote walking our tree asks for a few dozen parts of it, and building the tree for each of those would have a remote make us read the whole database tens of times per sync. So the trees we serve are built once, and kept until the cache generation cycle, or until they're older than merkleTreeMaxAge, whichever comes first. What arrives in between shows up in the tree at the next build. The reconciliation skips the bucket that is being filled anyway.
+
+The trees a remote can make us walk are capped as well, per remote, over a window. (See merkleRequestLimiter)
+*/
+
+const (
+	// How long a tree we serve is kept at most.
+	merkleTreeMaxAge = 10 * time.Minute
+	// How many parts of our trees a remote can ask for per window. A reconciliation of all the entity types with a remote that is far behind us takes a few hundred.
+	maxMerkleRequestsPerRemote = 600
+	merkleRateWindow           = 10 * time.Minute
+)
+
+type servedMerkleTree struct {
+	tree  *api.MerkleTree
+	start api.Timestamp
+	end   api.Timestamp
+	built time.Time
+}
+
+type merkleTreeCache struct {
+	lock  sync.Mutex
+	trees map[string]servedMerkleTree
+}
+
+var servedMerkleTrees = merkleTreeCache{trees: make(map[string]servedMerkleTree)}
+
+// get returns the tree of the entity type, and builds it if we don't have it, or if it's too old. The requests that come in while it's being built wait for it, instead of building it again.
+func (c *merkleTreeCache) get(entityType string) (servedMerkleTree, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if t, ok := c.trees[entityType]; ok && time.Since(t.built) < merkleTreeMaxAge {
+		return t, nil
+	}
+	start, end := MerkleWindowStart(), api.Timestamp(time.Now().Unix())
+	tree, err := buildMerkleTree(entityType, start, end)
+	if err != nil {
+		return servedMerkleTree{}, err
+	}
+	t := servedMerkleTree{tree: tree, start: start, end: end, built: time.Now()}
+	c.trees[entityType] = t
+	return t, nil
+}
+
+// DropServedMerkleTrees drops the trees we serve to the remotes, so that they are built again at the next request.
+func DropServedMerkleTrees() {
+	servedMerkleTrees.lock.Lock()
+	defer servedMerkleTrees.lock.Unlock()
+	servedMerkleTrees.trees = make(map[string]servedMerkleTree)
+}
+
+type merkleRequestLimiter struct {
+	lock        sync.Mutex
+	windowStart time.Time
+	counts      map[string]int
+}
+
+var merkleRequestLimits = merkleRequestLimiter{counts: make(map[string]int)}
+
+// take returns whether the remote has requests left in this window, and uses one if so.
+func (l *merkleRequestLimiter) take(remote string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if time.Since(l.windowStart) > merkleRateWindow {
+		l.windowStart = time.Now()
+		l.counts = make(map[string]int)
+	}
+	if l.counts[remote] >= maxMerkleRequestsPerRemote {
+		return false
+	}
+	l.counts[remote]++
+	return true
+}
+
+// GenerateMerkleResponse responds to a remote asking for a part of our Merkle tree. The entity type is in the Entity field of the request, and the path in the tree is given as a 'merkle' filter.
+func GenerateMerkleResponse(req api.ApiResponse) ([]byte, error) {
+	allowed := []string{"boards", "threads", "posts", "votes", "keys", "truststates"}
+	entityType := ""
+	for _, val := range allowed {
+		if req.Entity == val {
+			entityType = val
+		}
+	}
+	if len(entityType) == 0 {
+		return []byte{}, errors.New(fmt.Sprintf("A Merkle tree was requested for an entity type we do not support. Entity type: %s", req.Entity))
+	}
+	path := ""
+	for _, filter := range req.Filters {
+		if filter.Type == "merkle" && len(filter.Values) > 0 {
+			path = filter.Values[0]
+		}
+	}
+	remote := req.NodePublicKey
+	if len(remote) == 0 {
+		remote = string(req.Address.Location)
+	}
+	if !merkleRequestLimits.take(remote) {
+		return []byte{}, errors.New(fmt.Sprintf("This remote has asked for too many parts of our Merkle trees. Remote: %s, Max: %d per %v", remote, maxMerkleRequestsPerRemote, merkleRateWindow))
+	}
+	served, err := servedMerkleTrees.get(entityType)
+	if err != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The Merkle tree for this entity type could not be built. Entity type: %s, Error: %#v", entityType, err))
+	}
+	nodes, err := served.tree.Children(path)
+	if err != nil {
+		return []byte{}, err
+	}
+	logging.Logf(2, "Responding to a Merkle tree request. Entity type: %s, Path: '%s', Nodes: %d", entityType, path, len(nodes))
+	var resp api.ApiResponse
+	resp.Prefill()
+	resp.Endpoint = "merkle"
+	resp.Entity = entityType
+	resp.Filters = []api.Filter{api.Filter{Type: "merkle", Values: []string{path}}}
+	resp.StartsFrom = served.start
+	resp.EndsAt = served.end
+	resp.ResponseBody.MerkleNodes = nodes
+	signingErr := resp.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+	if signingErr != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The Merkle response that was prepared to respond to this query failed to be page-signed. Error: %#v", signingErr))
+	}
+	jsonResp, err := resp.ToJSON()
+	if err != nil {
+		return []byte{}, errors.New(fmt.Sprintf("The Merkle response that was prepared to respond to this query failed to convert to JSON. Error: %#v", err))
+	}
+	return jsonResp, nil
+}
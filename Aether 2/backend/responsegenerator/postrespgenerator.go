@@ -113,10 +113,11 @@ func bakeFinalPOSTApiResponse(
 	mergedEntityCounts *[]api.EntityCount,
 	reusedPostResponses *[]configstore.POSTResponseEntry,
 	dbReadStartLoc api.Timestamp, // This is needed in the case of container generation.
+	reusable bool, // Whether the container can be reused for other remotes.
 ) (*api.ApiResponse, error) {
 	if len(*resultPages) > 1 {
 		logging.Logf(2, "This result is still more than one page after the chain addition. We are generating the container %s", dirname)
-		generateContainer(resultPages, indexPages, manifestPages, entityCounts, filters, dirname, true, "", dbReadStartLoc) // this will save to disk, doesn't return anything.
+		generateContainer(resultPages, indexPages, manifestPages, entityCounts, filters, dirname, true, "", dbReadStartLoc, reusable) // this will save to disk, doesn't return anything.
 		// Generate container needs to generate its own entity
 		resp := generatePostFaceResponse(resultPages, mergedEntityCounts, filters, dirname, reusedPostResponses)
 		return resp, nil
@@ -160,6 +161,16 @@ func GeneratePOSTResponse(respType string, req api.ApiResponse) ([]byte, error)
 	filter := reconstructFilters(filterset)
 	logging.Logf(2, "Filters reconstructed: %#v", filter)
 	filters := []api.Filter{filter}
+	// The board filter only applies to board-scoped entities, for the rest, we respond as usual.
+	boardScoped := len(filterset.Boards) > 0 && api.IsBoardScoped(respType)
+	if boardScoped {
+		// Having a second filter also makes sure this response won't be put into the reuse tracker. It only has the content of some boards, so it cannot be reused for other remotes.
+		boardFilter := api.Filter{Type: "board"}
+		for _, fp := range filterset.Boards {
+			boardFilter.Values = append(boardFilter.Values, string(fp))
+		}
+		filters = append(filters, boardFilter)
+	}
 	// Create a random SHA256 hash as folder name to use in the case the response has more than one page.
 	dirname, err := randomhashgen.GenerateInsecureRandomHash()
 	if err != nil {
@@ -176,6 +187,11 @@ func GeneratePOSTResponse(respType string, req api.ApiResponse) ([]byte, error)
 		start := configstore.Timestamp(filterset.TimeStart)
 		end := configstore.Timestamp(filterset.TimeEnd)
 		chain, _, chainEnd, chainCount := globals.BackendTransientConfig.POSTResponseRepo.GetPostResponseChain(start, end, respType)
+		if boardScoped {
+			// Reused post responses carry the content of all boards. We don't want to make the remote download those, so we skip the chain and read the whole range from the database.
+			chain = &[]configstore.POSTResponseEntry{}
+			chainCount = configstore.EntityCount{}
+		}
 		dbReadStartLoc := api.Timestamp(0)
 		if len(*chain) == 0 {
 			dbReadStartLoc = filterset.TimeStart
@@ -187,11 +203,19 @@ func GeneratePOSTResponse(respType string, req api.ApiResponse) ([]byte, error)
 		// test end
 		logging.Logf(2, "Chain: %#v, Start: %v, End: %v Chain Count: %#v Time: %s", chain, start, chainEnd, chainCount, time.Now())
 		logging.Logf(2, "These are the values being fed to the persistence.Read. RespType: %s, filterset.Fingerprints: %v, filterset.Embeds: %v, dbReadStartLoc: %v, filterset.TimeEnd: %v", respType, filterset.Fingerprints, filterset.Embeds, dbReadStartLoc, filterset.TimeEnd)
-		localData, dbError := persistence.Read(respType, filterset.Fingerprints, filterset.Embeds, dbReadStartLoc, filterset.TimeEnd, false, nil)
+		var localData api.Response
+		var dbError error
+		if boardScoped {
+			localData, dbError = readBoardScoped(respType, filterset, dbReadStartLoc)
+		} else {
+			localData, dbError = persistence.Read(respType, filterset.Fingerprints, filterset.Embeds, dbReadStartLoc, filterset.TimeEnd, false, nil)
+		}
 
 		if dbError != nil {
 			return []byte{}, errors.New(fmt.Sprintf("The query coming from the remote caused an error in the local database while trying to respond to this request. Error: %#v\n, Request: %#v\n", dbError, req))
 		}
+		// Leave out the entity versions the remote can't verify. If that leaves anything out, this response is only right for this remote, and it can't be reused for others. (The reused responses in the chain can still carry those, the remote skips them.)
+		reusable := localData.FilterForRemote(&req.Address) == 0
 		// Generate main data & count the entities resulting. This will go to all three of the response entity pages themselves, the index and the manifest pages.
 		pages := splitEntitiesToPages(&localData)
 		pagesAsApiResponses := convertResponsesToApiResponses(pages)
@@ -219,7 +243,7 @@ func GeneratePOSTResponse(respType string, req api.ApiResponse) ([]byte, error)
 			(*manifestApiResponse)[key].Endpoint = "manifest_post"
 		}
 		// bakeFinalPOSTApiResponse wraps the data up and assigns proper metadata. It does not pull any further data in.
-		finalResponse, err := bakeFinalPOSTApiResponse(pagesAsApiResponses, indexApiResponse, manifestApiResponse, entityCounts, &filters, dirname, &mergedEntityCounts, chain, dbReadStartLoc)
+		finalResponse, err := bakeFinalPOSTApiResponse(pagesAsApiResponses, indexApiResponse, manifestApiResponse, entityCounts, &filters, dirname, &mergedEntityCounts, chain, dbReadStartLoc, reusable)
 		// fmt.Printf("%#v", finalResponse)
 		if err != nil {
 			return []byte{}, errors.New(fmt.Sprintf("An error was encountered while trying to finalise the API response. Error: %#v\n, Request: %#v\n", err, req))
@@ -256,7 +280,7 @@ func GeneratePOSTResponse(respType string, req api.ApiResponse) ([]byte, error)
 		entityCounts := countEntities(&localData)
 		// mergedEntityCounts should be used ONLY by the post face response.
 		mergedEntityCounts := mergeCounts(entityCounts, chainCount)
-		finalResponse, err := bakeFinalPOSTApiResponse(pagesAsApiResponses, nil, nil, entityCounts, &filters, dirname, &mergedEntityCounts, chain, dbReadStartLoc)
+		finalResponse, err := bakeFinalPOSTApiResponse(pagesAsApiResponses, nil, nil, entityCounts, &filters, dirname, &mergedEntityCounts, chain, dbReadStartLoc, true)
 		if err != nil {
 			return []byte{}, errors.New(fmt.Sprintf("An error was encountered while trying to finalise the API response. Error: %#v\n, Request: %#v\n", err, req))
 		}
@@ -309,3 +333,30 @@ func generateResultCachesFromPostRespChain(chain []configstore.POSTResponseEntry
 	}
 	return rcachs
 }
+
+// readBoardScoped reads the board-scoped entities of the given type for the boards in the filterset, one board at a time, and merges them.
+func readBoardScoped(respType string, filterset FilterSet, dbReadStartLoc api.Timestamp) (api.Response, error) {
+	var result api.Response
+	for _, board := range filterset.Boards {
+		opts := persistence.OptionalReadInputs{
+			Vote_TypeClass:       -1,
+			Vote_Type:            -1,
+			Truststate_TypeClass: -1,
+			Truststate_Type:      -1,
+		}
+		switch respType {
+		case "threads":
+			opts.Thread_Board = string(board)
+		case "posts":
+			opts.Post_Board = string(board)
+		case "votes":
+			opts.Vote_Board = string(board)
+		}
+		resp, err := persistence.Read(respType, []api.Fingerprint{}, filterset.Embeds, dbReadStartLoc, filterset.TimeEnd, false, &opts)
+		if err != nil {
+			return result, err
+		}
+		result.Insert(&resp)
+	}
+	return result, nil
+}
@@ -29,6 +29,7 @@ type FilterSet struct {
 	TimeStart    api.Timestamp
 	TimeEnd      api.Timestamp
 	Embeds       []string
+	Boards       []api.Fingerprint
 }
 
 func processFilters(req *api.ApiResponse) FilterSet {
@@ -46,6 +47,12 @@ func processFilters(req *api.ApiResponse) FilterSet {
 				fs.Embeds = append(fs.Embeds, embed)
 			}
 		}
+		// Boards (the remote is in selective sync, and only wants the content of these boards)
+		if filter.Type == "board" {
+			for _, fp := range filter.Values {
+				fs.Boards = append(fs.Boards, api.Fingerprint(fp))
+			}
+		}
 		// If a time filter is given, timeStart is either the timestamp provided by the remote if it's larger than the end date of the last cache, or the end timestamp of the last cache.
 		// In essence, we do not provide anything that is already cached from the live server.
 		if filter.Type == "timestamp" {
@@ -206,6 +213,7 @@ func generateContainer(
 	isPOST bool,
 	respType string,
 	dbReadStartLoc api.Timestamp,
+	reusable bool,
 ) {
 	foldername := ""
 	// Gate the filter in such a way that the beginning of the range will be the beginning of the DB read that this container will hold, NOT the beginning of the scan range. Scan range can include the chain with other reused responses, but dbReadStartLoc is the range of the DB read only.
@@ -235,7 +243,7 @@ func generateContainer(
 		bakeManifests(manifestPages, entityCounts, &flt, foldername, isPOST, respType, entityType)
 	}
 	// Bake the main entity pages.
-	bakeEntityPages(entityPages, entityCounts, &flt, foldername, isPOST, respType, entityType)
+	bakeEntityPages(entityPages, entityCounts, &flt, foldername, isPOST, respType, entityType, reusable)
 }
 
 func constructResultCache(beg api.Timestamp, end api.Timestamp, url string) api.ResultCache {
@@ -0,0 +1,90 @@
+// Backend > Server > Hidden Service
+// This file serves Mim on the local socket that the Tor client or the I2P router forwards the connections to our hidden service to.
+
+package server
+
+import (
+	"aether-core/io/api"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"aether-core/services/toolbox"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+type hiddenServiceCtxKey struct{}
+
+// markHiddenService marks the requests that came in through the hidden service, so that the handlers know not to trust the IP they came from. It's in the context, not in a header, since a header could be set by the remote.
+func markHiddenService(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), hiddenServiceCtxKey{}, true)))
+	})
+}
+
+func isFromHiddenService(r *http.Request) bool {
+	v, _ := r.Context().Value(hiddenServiceCtxKey{}).(bool)
+	return v
+}
+
+// insertOverlayRemoteAddressDetails is insertLocallySourcedRemoteAddressDetails for the remotes that come in through our hidden service, or that tell us they are on an overlay network. We can't see where these are, so the location they give is the one we keep, if it is a well-formed overlay address. If it is not where they are, the pings to it will fail, as with any other address that went offline. A remote that comes in through the hidden service without one is served, but not saved, since we'd have no way to reach it.
+func insertOverlayRemoteAddressDetails(req *api.ApiResponse) {
+	loc := string(req.Address.Location)
+	if toolbox.ValidOverlayLocation(loc) {
+		req.Address.LocationType = toolbox.IPType(loc)
+	} else {
+		loc = ""
+		req.Address.LocationType = 0
+	}
+	req.Address.Location = api.Location(loc)
+	req.Address.Sublocation = ""
+	req.Address.LastSuccessfulPing = api.Timestamp(time.Now().Unix())
+	req.Address.Type = 2
+}
+
+// startHiddenService serves Mim on HiddenServiceListenAddress, with the same handlers as the public server. This blocks, run it in a goroutine.
+func startHiddenService() {
+	listenAddr := globals.BackendConfig.GetHiddenServiceListenAddress()
+	host, _ := toolbox.SplitHostPort(listenAddr)
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		// Anything that comes in through this is taken to be from the overlay network. If this is open to the world, anybody can claim to be an onion.
+		logging.Logf(1, "The hidden service listen address is not a loopback address. Make sure it is not reachable from the outside. Address: %s", listenAddr)
+	}
+	srv := &http.Server{
+		Handler:      markHiddenService(http.DefaultServeMux),
+		TLSNextProto: make(map[string]func(*http.Server, *tls.Conn, http.Handler)),
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 60 * time.Second,
+	}
+	l, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		logging.Logf(1, "The hidden service could not start listening. Address: %s, Error: %v", listenAddr, err)
+		return
+	}
+	logging.Logf(1, "Serving Mim as a hidden service. Hidden service: %s:%d, Listening at: %s", globals.BackendConfig.GetHiddenServiceAddress(), globals.BackendConfig.GetHiddenServicePort(), listenAddr)
+	var srvErr error
+	if globals.BackendTransientConfig.TLSEnabled {
+		// The remotes pick http or https the same way for every address, so the hidden service has to speak the same as the public server, even if Tor already encrypts the connection.
+		certLoc := filepath.Join(globals.BackendConfig.GetUserDirectory(), "backend", "tls", "cert.pem")
+		keyLoc := filepath.Join(globals.BackendConfig.GetUserDirectory(), "backend", "tls", "key.pub")
+		srv.TLSConfig = &tls.Config{
+			MinVersion:               tls.VersionTLS12,
+			CurvePreferences:         []tls.CurveID{tls.CurveP521, tls.CurveP384, tls.CurveP256},
+			PreferServerCipherSuites: true,
+			CipherSuites: []uint16{
+				tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			},
+		}
+		srvErr = srv.ServeTLS(l, certLoc, keyLoc)
+	} else {
+		srvErr = srv.Serve(l)
+	}
+	logging.Logf(1, "The hidden service stopped. Error: %v", srvErr)
+}
+
+func hiddenServiceEnabled() bool {
+	return len(globals.BackendConfig.GetHiddenServiceAddress()) > 0
+}
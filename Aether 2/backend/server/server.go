@@ -4,7 +4,7 @@
 package server
 
 import (
-	// "aether-core/backend/dispatch"
+	"aether-core/backend/dispatch"
 	"aether-core/backend/responsegenerator"
 	"aether-core/io/api"
 	"aether-core/io/persistence"
@@ -36,6 +36,9 @@ func isReverseConn(host string, port uint16) bool {
 // Bouncer gate
 func isAllowedByBouncer(r *http.Request) bool {
 	remoteHost, remotePort := toolbox.SplitHostPort(r.RemoteAddr)
+	if isFromHiddenService(r) {
+		return globals.BackendTransientConfig.Bouncer.RequestHiddenServiceLease(remotePort)
+	}
 	reverse := isReverseConn(remoteHost, remotePort)
 	return globals.BackendTransientConfig.Bouncer.RequestInboundLease(remoteHost, "", remotePort, reverse)
 }
@@ -292,6 +295,54 @@ func StartMimServer() {
 					w.Write(resp)
 				}
 
+			case "/" + protv + "/c0/merkle", "/" + protv + "/c0/merkle/":
+				resp, err := MerklePOST(r)
+				if err != nil {
+					logging.Log(1, err)
+				}
+				if len(resp) == 0 {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte{})
+				} else {
+					w.Write(resp)
+				}
+
+			case "/" + protv + "/c0/blobs", "/" + protv + "/c0/blobs/":
+				resp, err := BlobsPOST(r)
+				if err != nil {
+					logging.Log(1, err)
+				}
+				if len(resp) == 0 {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte{})
+				} else {
+					w.Write(resp)
+				}
+
+			case "/" + protv + "/c0/entities", "/" + protv + "/c0/entities/":
+				resp, err := EntitiesPOST(r)
+				if err != nil {
+					logging.Log(1, err)
+				}
+				if len(resp) == 0 {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte{})
+				} else {
+					w.Write(resp)
+				}
+
+			case "/" + protv + "/c0/announce", "/" + protv + "/c0/announce/":
+				resp, err := AnnouncePOST(r)
+				if err != nil {
+					logging.Log(1, err)
+				}
+				if len(resp) == 0 {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte{})
+				} else {
+					w.Write(resp)
+				}
+
 			case "/" + protv + "/addresses", "/" + protv + "/addresses/":
 				resp, err := AddressesPOST(r)
 				if err != nil {
@@ -325,13 +376,16 @@ func StartMimServer() {
 	extIp := globals.BackendConfig.GetExternalIp()
 	logging.Log(1, fmt.Sprintf("Serving setup complete. Starting to serve Mim publicly on port %d", port))
 	srv := &http.Server{
-		Addr:         fmt.Sprint(extIp, ":", port),
+		Addr:         toolbox.JoinHostPort(extIp, port),
 		TLSNextProto: make(map[string]func(*http.Server, *tls.Conn, http.Handler)), // Disables HTTP2 because HTTP2 doesn't support Hijack, which we need to use to access the underlying TCP connection to perform a reverse open that we need to access remote nodes behind uncooperating NATs.
 		// ConnState:    ConnStateListener,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 60 * time.Second,
 	}
 	// srv.SetKeepAlivesEnabled(true)
+	if hiddenServiceEnabled() {
+		go startHiddenService()
+	}
 	if globals.BackendTransientConfig.TLSEnabled {
 		// certLoc := fmt.Sprintf("%s/backend/tls/cert.pem", )
 		certLoc := filepath.Join(globals.BackendConfig.GetUserDirectory(), "backend", "tls", "cert.pem")
@@ -349,7 +403,7 @@ func StartMimServer() {
 		srv.TLSConfig = tlsConfig
 		// HSTS header is not set because node IP addresses are dynamic, and us setting HSTS for an address might mean the next user of that IP address might end up having trouble getting people to connect to it through non-TLS.
 
-		l, err := net.Listen("tcp4", fmt.Sprint(":", port))
+		l, err := net.Listen(api.TCPNetwork(), toolbox.JoinHostPort("", port))
 		// l, err := reuseport.Listen("tcp4", fmt.Sprint(extIp, ":", port))
 		if err != nil {
 			logging.LogCrash(err)
@@ -360,7 +414,7 @@ func StartMimServer() {
 			logging.LogCrash(fmt.Sprintf("Server encountered a fatal error. (Heads up, server also exits with error even when it quits normally) Error: %s", srvErr))
 		}
 	} else {
-		l, err := net.Listen("tcp4", fmt.Sprint(":", port))
+		l, err := net.Listen(api.TCPNetwork(), toolbox.JoinHostPort("", port))
 		// l, err := reuseport.Listen("tcp4", fmt.Sprint(extIp, ":", port))
 		if err != nil {
 			logging.LogCrash(err)
@@ -376,6 +430,10 @@ func StartMimServer() {
 // SaveRemote checks if the database has data about the remote that is reaching out. If not, save a new address. We don't insert the node, only the address. Because the remote data is untrustable.
 func SaveRemote(req api.ApiResponse) error {
 	// spew.Dump(req.Address.Client)
+	if len(req.Address.Location) == 0 {
+		// A remote on an overlay network that did not tell us its address. Nothing to save.
+		return nil
+	}
 	addrs := []api.Address{req.Address}
 	errs := persistence.InsertOrUpdateAddresses(&addrs)
 	if len(errs) > 0 {
@@ -393,6 +451,10 @@ func insertLocallySourcedRemoteAddressDetails(r *http.Request, req *api.ApiRespo
 	// LITTLE-TRUSTED ADDRESS ENTRY
 	// Data to keep: Location, Sublocation, Port, LastSuccessfulPing (sublocation is guaranteed to be empty since the connection is coming from an IP, not a static IP)
 	// Delete everything else, they're untrustable.
+	if isFromHiddenService(r) || api.IsOverlay(req.Address.Location) {
+		insertOverlayRemoteAddressDetails(req)
+		return nil
+	}
 	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
 		return errors.New(fmt.Sprintf("The address from which the remote is connecting could not be parsed. Remote Address: %s, Error: %s", r.RemoteAddr, err))
@@ -400,14 +462,9 @@ func insertLocallySourcedRemoteAddressDetails(r *http.Request, req *api.ApiRespo
 	if len(host) == 0 {
 		return errors.New(fmt.Sprintf("The address from which the remote is connecting seems to be empty. Remote Address: %#v. %#v", r.RemoteAddr, err))
 	}
-	ipAddrAsIP := net.ParseIP(host)
-	ipV4Test := ipAddrAsIP.To4()
-	if ipV4Test == nil {
-		// This is an IpV6 address
-		req.Address.LocationType = 6
-	} else {
-		req.Address.LocationType = 4
-	}
+	// A dual-stack listener sees IPv4 remotes as IPv4 mapped into IPv6. Normalising brings those back to plain IPv4, so the same remote is always saved the same way.
+	host = toolbox.NormalizeLocation(host)
+	req.Address.LocationType = toolbox.IPType(host)
 	req.Address.Sublocation = "" // It's coming from an IP address, not a URL.
 	req.Address.Location = api.Location(host)
 	req.Address.LastSuccessfulPing = api.Timestamp(time.Now().Unix())
@@ -431,7 +488,7 @@ func ParsePOSTRequest(r *http.Request) (api.ApiResponse, error) {
 	// - Node Id always 64 chars long
 	// - Port has to exist, and > 0
 	// - Type cannot be 0
-	// - Protocol subprotocols have to include "c0" (aether subprotocol of mim)
+	// - Protocol and subprotocols have to be compatible with ours, and include "c0" (aether subprotocol of mim)
 	// - Has a valid nonce (by proxy, the timestamp is within our allowed clock skew bracket)
 	// - PoW is verified.
 	if r.Header["Content-Type"][0] == "application/json" &&
@@ -443,6 +500,12 @@ func ParsePOSTRequest(r *http.Request) (api.ApiResponse, error) {
 			logging.Logf(1, "This ApiResponse is created by a remote client we do not support. Client: %#v", req.Address.Client)
 			return req, errors.New(fmt.Sprintf("This ApiResponse is created by a remote client we do not support. Client: %#v", req.Address.Client))
 		}
+		// The remote has to speak a protocol and a c0 we are compatible with.
+		_, negotiationErr := api.NegotiateCapabilities(api.LocalProtocol(), req.Address.Protocol)
+		if negotiationErr != nil {
+			logging.Logf(1, "This ApiResponse is from a remote we can't talk to. Error: %v", negotiationErr)
+			return req, negotiationErr
+		}
 
 		// Check PoW, since this is a POST request, it is required to have a PoW.
 		valid, err := req.VerifyPoW()
@@ -450,16 +513,17 @@ func ParsePOSTRequest(r *http.Request) (api.ApiResponse, error) {
 			logging.Logf(1, "This ApiResponse failed PoW verification. Possible error: %v", err)
 			return req, errors.New(fmt.Sprintf("This ApiResponse failed PoW verification. Possible error: %v", err))
 		}
-		for _, ext := range req.Address.Protocol.Subprotocols {
-			if ext.Name == "c0" {
-				// We insert to the POST request the locally sourced details. (Location, Sublocation, LocationType [ipv4 or 6], LastSuccessfulPing)
-				err := insertLocallySourcedRemoteAddressDetails(r, &req)
-				if err != nil {
-					return req, err
-				}
-				return req, nil
-			}
+		// The PoW is signed with the node key, so now we know the remote holds it. Charge the key, so that a node can't get around the IP limits of the bouncer by connecting from many addresses.
+		if !globals.BackendTransientConfig.Bouncer.ChargeNodeKey(req.NodePublicKey) {
+			logging.Logf(1, "This ApiResponse was declined, because its node key is making too many requests. NodePublicKey: %v", req.NodePublicKey)
+			return req, errors.New(fmt.Sprintf("This ApiResponse was declined, because its node key is making too many requests. NodePublicKey: %v", req.NodePublicKey))
+		}
+		// We insert to the POST request the locally sourced details. (Location, Sublocation, LocationType [ipv4 or 6], LastSuccessfulPing)
+		err3 := insertLocallySourcedRemoteAddressDetails(r, &req)
+		if err3 != nil {
+			return req, err3
 		}
+		return req, nil
 	}
 	return req, errors.New(fmt.Sprintf("The request is syntactically valid JSON, but it does not include certain vital information"))
 }
@@ -632,3 +696,89 @@ func AddressesPOST(r *http.Request) ([]byte, error) {
 	}
 	return respAsByte, nil
 }
+
+// MerklePOST responds with the requested part of our Merkle tree for an entity type. Remotes use this to find the entities they are missing from us, regardless of when those entities were created.
+func MerklePOST(r *http.Request) ([]byte, error) {
+	req, err := ParsePOSTRequest(r)
+	if err != nil {
+		logging.Log(1, fmt.Sprintf("POST request parsing failed. Error: %#v\n, Request Header: %#v\n, Request Body: %#v\n", err, r.Header, req))
+		return []byte{}, nil
+	}
+	err2 := SaveRemote(req)
+	if err2 != nil {
+		return []byte{}, err2
+	}
+	respAsByte, err3 := responsegenerator.GenerateMerkleResponse(req)
+	if err3 != nil {
+		return respAsByte, err3
+	}
+	if r != nil {
+		r.Body.Close()
+	}
+	return respAsByte, nil
+}
+
+// BlobsPOST responds with the chunks of attachments the remote asked for, the ones we have of them.
+func BlobsPOST(r *http.Request) ([]byte, error) {
+	req, err := ParsePOSTRequest(r)
+	if err != nil {
+		logging.Log(1, fmt.Sprintf("POST request parsing failed. Error: %#v\n, Request Header: %#v\n, Request Body: %#v\n", err, r.Header, req))
+		return []byte{}, nil
+	}
+	err2 := SaveRemote(req)
+	if err2 != nil {
+		return []byte{}, err2
+	}
+	respAsByte, err3 := responsegenerator.GenerateBlobsResponse(req)
+	if err3 != nil {
+		return respAsByte, err3
+	}
+	if r != nil {
+		r.Body.Close()
+	}
+	return respAsByte, nil
+}
+
+func EntitiesPOST(r *http.Request) ([]byte, error) {
+	req, err := ParsePOSTRequest(r)
+	if err != nil {
+		logging.Log(1, fmt.Sprintf("POST request parsing failed. Error: %#v\n, Request Header: %#v\n, Request Body: %#v\n", err, r.Header, req))
+		return []byte{}, nil
+	}
+	err2 := SaveRemote(req)
+	if err2 != nil {
+		return []byte{}, err2
+	}
+	respAsByte, err3 := responsegenerator.GenerateEntitiesResponse(req)
+	if err3 != nil {
+		return respAsByte, err3
+	}
+	if r != nil {
+		r.Body.Close()
+	}
+	return respAsByte, nil
+}
+
+// AnnouncePOST receives the entities a remote announces to us. If gossip is enabled, the ones we don't have are fetched in the background, after we respond.
+func AnnouncePOST(r *http.Request) ([]byte, error) {
+	req, err := ParsePOSTRequest(r)
+	if err != nil {
+		logging.Log(1, fmt.Sprintf("POST request parsing failed. Error: %#v\n, Request Header: %#v\n, Request Body: %#v\n", err, r.Header, req))
+		return []byte{}, nil
+	}
+	err2 := SaveRemote(req)
+	if err2 != nil {
+		return []byte{}, err2
+	}
+	if globals.BackendConfig.GetGossipEnabled() {
+		dispatch.ReceiveAnnouncement(req.Address, req.ResponseBody)
+	}
+	respAsByte, err3 := responsegenerator.GenerateAnnounceResponse()
+	if err3 != nil {
+		return respAsByte, err3
+	}
+	if r != nil {
+		r.Body.Close()
+	}
+	return respAsByte, nil
+}
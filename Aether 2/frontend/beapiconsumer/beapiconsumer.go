@@ -8,6 +8,7 @@ import (
 	pbstructs "aether-core/protos/mimapi"
 	"aether-core/services/globals"
 	"aether-core/services/logging"
+	"errors"
 	"fmt"
 	// "github.com/davecgh/go-spew/spew"
 	"golang.org/x/net/context"
@@ -534,3 +535,101 @@ func SendConnectToRemoteRequest(req *pb.ConnectToRemoteRequest) (statusCode int,
 	errMessage := resp.GetStatus().GetErrorMessage()
 	return r, errMessage
 }
+
+/*----------  Backend send subscribed boards  ----------*/
+
+func SendSubscribedBoards(req *pb.SubscribedBoardsPayload) (statusCode int) {
+	c, conn := StartBackendAPIConnection()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), globals.FrontendConfig.GetGRPCServiceTimeout())
+	defer cancel()
+	req.RequesterId = createRequesterId()
+	resp, err := c.SendSubscribedBoards(ctx, req)
+	if err != nil {
+		logging.Logf(1, "SendSubscribedBoards encountered an error. Error: %v", err)
+	}
+	r := int(resp.GetStatus().GetStatusCode())
+	return r
+}
+
+// PushSubscribedBoards sends the boards the user is subscribed to to the backend, so that a backend in selective sync knows which boards to replicate. This is called at start, and every time a subscription changes.
+func PushSubscribedBoards() {
+	cr := globals.FrontendConfig.GetContentRelations()
+	sbs := cr.GetAllSubbedBoards()
+	fps := []string{}
+	for _, sb := range sbs {
+		fps = append(fps, sb.Fingerprint)
+	}
+	r := SendSubscribedBoards(&pb.SubscribedBoardsPayload{Fingerprints: fps})
+	if r != 200 {
+		logging.Logf(1, "Pushing the subscribed boards to the backend failed. Status code: %v", r)
+	}
+}
+
+// SendPinRequest asks the backend to pin or unpin a board, thread or key, so that it is exempt from the event horizon.
+func SendPinRequest(req *pb.PinPayload) (statusCode int) {
+	c, conn := StartBackendAPIConnection()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), globals.FrontendConfig.GetGRPCServiceTimeout())
+	defer cancel()
+	req.RequesterId = createRequesterId()
+	resp, err := c.SendPinRequest(ctx, req)
+	if err != nil {
+		logging.Logf(1, "SendPinRequest encountered an error. Error: %v", err)
+	}
+	r := int(resp.GetStatus().GetStatusCode())
+	return r
+}
+
+// SendBlobs hands the chunks of an attachment the user is posting to the backend, which keeps and serves them.
+func SendBlobs(blobs []*pb.Blob) (statusCode int, errMessage string) {
+	c, conn := StartBackendAPIConnection()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), globals.FrontendConfig.GetGRPCServiceTimeout())
+	defer cancel()
+	req := pb.BlobsPayload{Blobs: blobs}
+	req.RequesterId = createRequesterId()
+	resp, err := c.SendBlobs(ctx, &req)
+	if err != nil {
+		logging.Logf(1, "SendBlobs encountered an error. Error: %v", err)
+	}
+	return int(resp.GetStatus().GetStatusCode()), resp.GetStatus().GetErrorMessage()
+}
+
+// GetBlobs asks the backend for the chunks with the given hashes. The ones it doesn't have are left out.
+func GetBlobs(hashes []string) map[string][]byte {
+	c, conn := StartBackendAPIConnection()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), globals.FrontendConfig.GetGRPCServiceTimeout())
+	defer cancel()
+	req := pb.BlobsRequest{Hashes: hashes}
+	req.RequesterId = createRequesterId()
+	resp, err := c.GetBlobs(ctx, &req)
+	blobs := make(map[string][]byte)
+	if err != nil {
+		logging.Logf(1, "GetBlobs encountered an error. Error: %v", err)
+		return blobs
+	}
+	for _, b := range resp.GetBlobs() {
+		blobs[b.GetHash()] = b.GetData()
+	}
+	return blobs
+}
+
+// GetRevisions returns the versions of a thread or post that the backend has kept, oldest first.
+func GetRevisions(entityType, fingerprint string) ([]*pb.Revision, error) {
+	c, conn := StartBackendAPIConnection()
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), globals.FrontendConfig.GetGRPCServiceTimeout())
+	defer cancel()
+	req := pb.RevisionsRequest{EntityType: entityType, Fingerprint: fingerprint}
+	req.RequesterId = createRequesterId()
+	resp, err := c.GetRevisions(ctx, &req)
+	if err != nil {
+		return []*pb.Revision{}, err
+	}
+	if resp.GetStatus().GetStatusCode() != 200 {
+		return []*pb.Revision{}, errors.New(fmt.Sprintf("The backend couldn't return the revisions. Status code: %v, Error: %s", resp.GetStatus().GetStatusCode(), resp.GetStatus().GetErrorMessage()))
+	}
+	return resp.GetRevisions(), nil
+}
@@ -5,6 +5,7 @@
 package besupervisor
 
 import (
+	"aether-core/services/configstore"
 	"aether-core/services/globals"
 	"aether-core/services/logging"
 	"fmt"
@@ -23,6 +24,10 @@ func StartLocalBackend() {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Dir = "../../aether-core/backend"
+	// The backend unlocks its key pair with the same passphrase the user unlocked the frontend with. This goes through the environment, not the args, because the args of a process are visible to every user on the machine.
+	if passphrase := globals.FrontendConfig.GetKeystorePassphrase(); len(passphrase) > 0 {
+		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", configstore.KeystorePassphraseEnvVar, passphrase))
+	}
 	logging.Log(1, "Local backend being started")
 	err := cmd.Run()
 	if err != nil {
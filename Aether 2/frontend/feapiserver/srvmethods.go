@@ -7,15 +7,19 @@ import (
 	"aether-core/frontend/beapiconsumer"
 	"aether-core/frontend/clapiconsumer"
 	"aether-core/frontend/festructs"
+	"aether-core/frontend/identity"
 	// "aether-core/frontend/objpool"
 	"aether-core/frontend/inflights"
+	"aether-core/frontend/refresher"
 	// "aether-core/io/api"
+	"aether-core/io/blobstore"
 	"aether-core/protos/beapi"
 	"aether-core/protos/clapi"
 	pb "aether-core/protos/feapi"
 	"aether-core/protos/feobjects"
 	"aether-core/services/globals"
 	"aether-core/services/logging"
+	"aether-core/services/metaparse"
 	// "encoding/json"
 	"errors"
 	"fmt"
@@ -185,6 +189,10 @@ func (s *server) SetBoardSignal(ctx context.Context, req *pb.BoardSignalRequest)
 	committed := cr.SetBoardSignal(req.Fingerprint, req.Subscribed, req.Notify, req.LastSeen, req.LastSeenOnly)
 	globals.FrontendConfig.SetContentRelations(cr)
 	resp := pb.BoardSignalResponse{Committed: committed}
+	if committed && !req.LastSeenOnly {
+		// The subscriptions might have changed, let the backend know in case it's only replicating the boards we're subscribed to.
+		go beapiconsumer.PushSubscribedBoards()
+	}
 	clapiconsumer.DeliverAmbients()
 	return &resp, nil
 }
@@ -216,9 +224,13 @@ func (s *server) GetUserAndGraph(ctx context.Context, req *pb.UserAndGraphReques
 
 func (s *server) SendContentEvent(ctx context.Context, req *pb.ContentEventPayload) (*pb.ContentEventResponse, error) {
 	logging.Logf(1, "We've received a content event. Event: %v", *req)
-	inflights := inflights.GetInflights()
-	inflights.Insert(*req)
-	as := clapi.AmbientStatusPayload{Inflights: inflights.Protobuf()}
+	ifl := inflights.GetInflights()
+	ifl.Insert(*req)
+	err := inflights.DeleteDraft(req.GetDraftId())
+	if err != nil {
+		logging.Logf(1, "The draft of the content that was sent could not be deleted. Draft: %v, Error: %v", req.GetDraftId(), err)
+	}
+	as := clapi.AmbientStatusPayload{Inflights: ifl.Protobuf()}
 	clapiconsumer.SendAmbientStatus(&as)
 	resp := pb.ContentEventResponse{}
 	return &resp, nil
@@ -401,6 +413,200 @@ func (s *server) RequestBoardReports(ctx context.Context, req *pb.BoardReportsRe
 	return &resp, nil
 }
 
+// SetPinSignal pins or unpins a board, thread or key in the backend, so that it survives the event horizon even when the database is full.
+func (s *server) SetPinSignal(ctx context.Context, req *pb.PinSignalRequest) (*pb.PinSignalResponse, error) {
+	statusCode := beapiconsumer.SendPinRequest(&beapi.PinPayload{
+		EntityType:  req.GetEntityType(),
+		Fingerprint: req.GetFingerprint(),
+		Pinned:      req.GetPinned(),
+	})
+	if statusCode != 200 {
+		logging.Logf(1, "The pin request failed. Entity type: %v, Fingerprint: %v, Status code: %v", req.GetEntityType(), req.GetFingerprint(), statusCode)
+	}
+	resp := pb.PinSignalResponse{Committed: statusCode == 200}
+	return &resp, nil
+}
+
+// SendAttachment splits the file the user is attaching into chunks, and hands them to the backend. The client puts the attachment it gets back into the meta of the thread or the post.
+func (s *server) SendAttachment(ctx context.Context, req *pb.AttachmentUploadRequest) (*pb.AttachmentUploadResponse, error) {
+	a, chunks, err := blobstore.Split(req.GetData(), req.GetName(), req.GetMimeType())
+	if err != nil {
+		return &pb.AttachmentUploadResponse{Error: err.Error()}, nil
+	}
+	blobs := []*beapi.Blob{}
+	for h, c := range chunks {
+		blobs = append(blobs, &beapi.Blob{Hash: h, Data: c})
+	}
+	statusCode, errMessage := beapiconsumer.SendBlobs(blobs)
+	if statusCode != 200 {
+		logging.Logf(1, "The attachment could not be saved in the backend. Hash: %v, Status code: %v, Error: %v", a.Hash, statusCode, errMessage)
+		return &pb.AttachmentUploadResponse{Error: fmt.Sprintf("The attachment could not be saved. %s", errMessage)}, nil
+	}
+	resp := pb.AttachmentUploadResponse{
+		Attachment: &pb.Attachment{
+			Hash:     a.Hash,
+			Name:     a.Name,
+			MimeType: a.MimeType,
+			Size:     a.Size,
+			Chunks:   a.Chunks,
+		},
+	}
+	return &resp, nil
+}
+
+// GetAttachment puts an attachment together from its chunks in the backend. If the backend doesn't have all of them yet, the attachment is not available, and the client can try again later.
+func (s *server) GetAttachment(ctx context.Context, req *pb.AttachmentRequest) (*pb.AttachmentResponse, error) {
+	pa := req.GetAttachment()
+	a := metaparse.Attachment{
+		Hash:     pa.GetHash(),
+		Name:     pa.GetName(),
+		MimeType: pa.GetMimeType(),
+		Size:     pa.GetSize(),
+		Chunks:   pa.GetChunks(),
+	}
+	if !blobstore.ValidAttachment(&a) {
+		return &pb.AttachmentResponse{}, nil
+	}
+	data, err := blobstore.Join(&a, beapiconsumer.GetBlobs(a.Chunks))
+	if err != nil {
+		logging.Logf(2, "This attachment is not available yet. Hash: %v, Error: %v", a.Hash, err)
+		return &pb.AttachmentResponse{}, nil
+	}
+	return &pb.AttachmentResponse{Available: true, Data: data}, nil
+}
+
+// UnlockKeystore unlocks the user and frontend keys with the passphrase the user entered. If the keys are not encrypted yet, this encrypts them with that passphrase. The frontend does not start the backend until this succeeds, if the keystore is encrypted.
+func (s *server) UnlockKeystore(ctx context.Context, req *pb.KeystoreUnlockRequest) (*pb.KeystoreUnlockResponse, error) {
+	migrated, err := globals.FrontendConfig.UnlockKeystore(req.GetPassphrase())
+	if err != nil {
+		logging.Logf(1, "The keystore unlock failed. Error: %v", err)
+		return &pb.KeystoreUnlockResponse{Unlocked: false, Error: err.Error()}, nil
+	}
+	if migrated {
+		logging.Log(1, "The user and frontend keys have been encrypted with the keystore passphrase.")
+	}
+	resp := pb.KeystoreUnlockResponse{
+		Unlocked: !globals.FrontendConfig.IsKeystoreLocked(),
+		Migrated: migrated,
+	}
+	return &resp, nil
+}
+
+// GetKeystoreStatus tells the client whether it needs to ask the user for the passphrase.
+func (s *server) GetKeystoreStatus(ctx context.Context, req *pb.KeystoreStatusRequest) (*pb.KeystoreStatusResponse, error) {
+	resp := pb.KeystoreStatusResponse{
+		Encrypted: globals.FrontendConfig.IsKeystoreEncrypted(),
+		Locked:    globals.FrontendConfig.IsKeystoreLocked(),
+	}
+	return &resp, nil
+}
+
+// RotateUserKey moves the local user to a new key. The old key hands its identity over to the new one, so the user keeps their name, followers and mod status.
+func (s *server) RotateUserKey(ctx context.Context, req *pb.UserKeyRotationRequest) (*pb.UserKeyRotationResponse, error) {
+	newfp, err := inflights.RotateLocalUserKey(req.GetRevoke())
+	if err != nil {
+		logging.Logf(1, "The user key rotation failed. Error: %v", err)
+		return &pb.UserKeyRotationResponse{Rotated: false, Error: err.Error()}, nil
+	}
+	resp := pb.UserKeyRotationResponse{Rotated: true, NewFingerprint: newfp}
+	return &resp, nil
+}
+
+// ExportIdentity writes the local user's identity into a file sealed with the given passphrase, so that it can be imported on another install.
+func (s *server) ExportIdentity(ctx context.Context, req *pb.IdentityExportRequest) (*pb.IdentityExportResponse, error) {
+	err := identity.Export(req.GetPath(), req.GetPassphrase())
+	if err != nil {
+		logging.Logf(1, "The identity export failed. Error: %v", err)
+		return &pb.IdentityExportResponse{Exported: false, Error: err.Error()}, nil
+	}
+	return &pb.IdentityExportResponse{Exported: true}, nil
+}
+
+// ImportIdentity imports an identity exported from another install. If the local user changes, the new user's key goes to the backend and the frontend is recompiled for them.
+func (s *server) ImportIdentity(ctx context.Context, req *pb.IdentityImportRequest) (*pb.IdentityImportResponse, error) {
+	res, err := identity.Import(req.GetPath(), req.GetPassphrase(), req.GetConflictResolution())
+	resp := pb.IdentityImportResponse{
+		Fingerprint:        res.Fingerprint,
+		Conflict:           res.Conflict,
+		Replaced:           res.Replaced,
+		ReplacedBackupPath: res.ReplacedBackupPath,
+	}
+	if err != nil {
+		logging.Logf(1, "The identity import failed. Error: %v", err)
+		resp.Error = err.Error()
+		return &resp, nil
+	}
+	resp.Imported = true
+	identity.PushLocalUserKey()
+	beapiconsumer.PushSubscribedBoards()
+	go func() {
+		refresher.Refresh()
+		clapiconsumer.PushLocalUserAmbient()
+	}()
+	return &resp, nil
+}
+
+// GetRevisions returns the versions of a thread or post, each with the diff of its body against the one before. The blocked ones are returned too: the point is to see what a post said before it was edited, and the mods need that most for the ones they're about to block.
+func (s *server) GetRevisions(ctx context.Context, req *pb.RevisionsRequest) (*pb.RevisionsResponse, error) {
+	resp := pb.RevisionsResponse{}
+	revs, err := beapiconsumer.GetRevisions(req.GetEntityType(), req.GetFingerprint())
+	if err != nil {
+		logging.Logf(1, "Getting the revisions failed. Entity type: %v, Fingerprint: %v, Error: %v", req.GetEntityType(), req.GetFingerprint(), err)
+		resp.Error = err.Error()
+		return &resp, nil
+	}
+	prevBody := ""
+	for key, _ := range revs {
+		entry := pb.RevisionEntry{
+			LastUpdate:        revs[key].GetLastUpdate(),
+			Body:              revs[key].GetBody(),
+			Meta:              revs[key].GetMeta(),
+			UpdateProofOfWork: revs[key].GetUpdateProofOfWork(),
+			UpdateSignature:   revs[key].GetUpdateSignature(),
+		}
+		if key > 0 {
+			diff := festructs.LineDiff(prevBody, revs[key].GetBody())
+			for k, _ := range diff {
+				entry.BodyDiff = append(entry.BodyDiff, &pb.DiffLine{Op: diff[k].Op, Text: diff[k].Text})
+			}
+		}
+		prevBody = revs[key].GetBody()
+		resp.Revisions = append(resp.Revisions, &entry)
+	}
+	return &resp, nil
+}
+
+// SaveDraft saves the thread or post the user is writing. The client calls this as the user writes, so that it survives the app closing.
+func (s *server) SaveDraft(ctx context.Context, req *pb.DraftPayload) (*pb.DraftResponse, error) {
+	resp := pb.DraftResponse{}
+	d, err := inflights.SaveDraft(inflights.NewDraft(req.GetDraft()))
+	if err != nil {
+		logging.Logf(1, "Saving the draft failed. Error: %v", err)
+		resp.Error = err.Error()
+		return &resp, nil
+	}
+	resp.Draft = d.Protobuf()
+	return &resp, nil
+}
+
+func (s *server) GetDrafts(ctx context.Context, req *pb.DraftsRequest) (*pb.DraftsResponse, error) {
+	resp := pb.DraftsResponse{}
+	drafts := inflights.GetDrafts(req.GetBoard(), req.GetThread())
+	for key, _ := range drafts {
+		resp.Drafts = append(resp.Drafts, drafts[key].Protobuf())
+	}
+	return &resp, nil
+}
+
+func (s *server) DeleteDraft(ctx context.Context, req *pb.DraftDeleteRequest) (*pb.DraftDeleteResponse, error) {
+	err := inflights.DeleteDraft(req.GetId())
+	if err != nil {
+		logging.Logf(1, "Deleting the draft failed. Draft: %v, Error: %v", req.GetId(), err)
+	}
+	resp := pb.DraftDeleteResponse{Deleted: err == nil}
+	return &resp, nil
+}
+
 func getReportedThreads(sl []festructs.CompiledThread) []festructs.CompiledThread {
 	reported := []festructs.CompiledThread{}
 	for k, _ := range sl {
@@ -0,0 +1,99 @@
+package fecmd
+
+import (
+	"aether-core/frontend/identity"
+	"aether-core/services/configstore"
+	"aether-core/services/globals"
+	"fmt"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+	"os"
+)
+
+func init() {
+	var conflict string
+	cmdIdentityImport.Flags().StringVarP(&conflict, "conflict", "", identity.ConflictFail, `What to do if this install already has a different user. "fail": do nothing, "replace": replace the current user with the imported one (the current user is backed up next to the config first), "keep": keep the current user, and only import follows, blocks and subscriptions.`)
+	cmdIdentity.AddCommand(cmdIdentityExport)
+	cmdIdentity.AddCommand(cmdIdentityImport)
+	cmdRoot.AddCommand(cmdIdentity)
+}
+
+var cmdIdentity = &cobra.Command{
+	Use:   "identity",
+	Short: "Back up your identity to a file, or restore it from one.",
+	Long: `Back up your identity to a file, or restore it from one.
+
+The backup carries your private key, your user (name and info), who you follow, block and made mod, and your subscriptions. It is encrypted with a passphrase you choose when exporting, and you'll need the same passphrase to import it. This should not be run while the app is running, use the app's settings for that instead.
+`,
+}
+
+var cmdIdentityExport = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write your identity into an encrypted backup file.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		EstablishConfigs(cmd)
+		unlockKeystoreFromTerminal()
+		passphrase := readPassphrase("Backup passphrase: ")
+		if passphrase != readPassphrase("Backup passphrase again: ") {
+			fmt.Println("The passphrases do not match.")
+			os.Exit(1)
+		}
+		err := identity.Export(args[0], passphrase)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Identity exported. Path: %s\n", args[0])
+	},
+}
+
+var cmdIdentityImport = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Restore your identity from an encrypted backup file.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		EstablishConfigs(cmd)
+		unlockKeystoreFromTerminal()
+		conflict, _ := cmd.Flags().GetString("conflict")
+		res, err := identity.Import(args[0], readPassphrase("Backup passphrase: "), conflict)
+		if err != nil {
+			fmt.Println(err)
+			if res.Conflict {
+				fmt.Println(`Run again with --conflict=replace or --conflict=keep to choose what happens to the current user.`)
+			}
+			os.Exit(1)
+		}
+		if res.Replaced {
+			fmt.Printf("The previous user of this install has been backed up. Path: %s\n", res.ReplacedBackupPath)
+		}
+		fmt.Printf("Identity imported. User: %s\n", res.Fingerprint)
+	},
+}
+
+func readPassphrase(prompt string) string {
+	fmt.Print(prompt)
+	p, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return string(p)
+}
+
+// unlockKeystoreFromTerminal asks for the keystore passphrase if the keys of this install are encrypted. The environment variable is checked first, so that this can be scripted.
+func unlockKeystoreFromTerminal() {
+	if !globals.FrontendConfig.IsKeystoreLocked() {
+		return
+	}
+	passphrase := os.Getenv(configstore.KeystorePassphraseEnvVar)
+	if len(passphrase) == 0 {
+		passphrase = readPassphrase("Keystore passphrase: ")
+	}
+	_, err := globals.FrontendConfig.UnlockKeystore(passphrase)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
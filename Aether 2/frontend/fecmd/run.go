@@ -1,13 +1,15 @@
 package fecmd
 
 import (
-	// "aether-core/frontend/beapiconsumer"
+	"aether-core/frontend/beapiconsumer"
 	"aether-core/frontend/besupervisor"
 	// "aether-core/frontend/clapiconsumer"
 	"aether-core/frontend/feapiserver"
+	"aether-core/frontend/feeds"
 	// "aether-core/protos/clapi"
 	"aether-core/frontend/festructs"
-	// "aether-core/frontend/inflights"
+	"aether-core/frontend/identity"
+	"aether-core/frontend/inflights"
 	"aether-core/frontend/kvstore"
 	"aether-core/services/globals"
 	"aether-core/services/logging"
@@ -47,6 +49,13 @@ var cmdRun = &cobra.Command{
 		gotValidPort := make(chan bool)
 		go feapiserver.StartFrontendServer(gotValidPort)
 		<-gotValidPort // Only proceed after this is true.
+		if globals.FrontendConfig.IsKeystoreLocked() {
+			logging.Log(1, "The keystore is encrypted. Waiting for the client to unlock it before starting the backend.")
+		}
+		for globals.FrontendConfig.IsKeystoreLocked() {
+			// Block until the client unlocks the keystore via gRPC. Nothing below can run without the keys.
+			time.Sleep(time.Millisecond * 100)
+		}
 		go besupervisor.StartLocalBackend()
 		for globals.FrontendTransientConfig.BackendReady != true {
 			// Block until the backend tells the frontend via gRPC that it is ready.
@@ -56,7 +65,11 @@ var cmdRun = &cobra.Command{
 		// debug
 		// go testBackend()
 		// end debug
-
+		beapiconsumer.PushSubscribedBoards()
+		identity.PushLocalUserKey() // In case the identity was imported while we were not running.
+		if globals.FrontendConfig.GetFeedsEnabled() {
+			go feeds.StartFeedsServer()
+		}
 		startSchedules()
 		// feapiserver.SendAmbients(false)
 
@@ -96,6 +109,11 @@ func startSchedules() {
 		festructs.NotificationsSingleton.Prune()
 
 	}, 1*time.Hour, time.Duration(0), nil)
+
+	// Send the scheduled threads and posts whose time has come. This runs at start as well, for the ones that came due while the app was closed.
+	globals.FrontendTransientConfig.StopScheduledContentCycle = scheduling.ScheduleRepeat(func() {
+		inflights.GetInflights().ReleaseScheduled()
+	}, 1*time.Minute, time.Duration(0), nil)
 }
 
 // func testBackend() {
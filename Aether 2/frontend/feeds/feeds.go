@@ -0,0 +1,125 @@
+// Frontend > Feeds
+// This package serves the boards, threads and users as RSS and Atom feeds, so that they can be followed from a feed reader.
+
+package feeds
+
+import (
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+How do the feeds work?
+
+If FeedsEnabled is set, the frontend serves these on 127.0.0.1:FeedsPort:
+
+	/home                    the home view
+	/board/<fingerprint>     the new threads of a board
+	/thread/<fingerprint>    the new posts of a thread
+	/user/<fingerprint>      the new threads and posts of a user
+
+Every one of them is Atom, or RSS with ?format=rss. Every one of them needs ?token=<FeedsToken>, or the token in an "Authorization: Bearer" header. Most feed readers can't set headers, so the token in the URL is the usual way.
+
+The feeds are built from what the refresher has compiled into the KV store, so they're as fresh as the app, and they hide what the app hides. Nothing here writes anything.
+
+Why the token, if it's only on 127.0.0.1? Every program on this machine can reach 127.0.0.1, and so can every web page open in a browser on it. Without the token, any of them could read what the user is subscribed to.
+*/
+
+// authorised checks the token of the request. The comparison takes the same time whatever the token is, so that it can't be guessed a character at a time.
+func authorised(r *http.Request) bool {
+	given := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		given = strings.TrimPrefix(auth, "Bearer ")
+	}
+	expected := globals.FrontendConfig.GetFeedsToken()
+	return subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
+
+// validFingerprint checks the fingerprint in the path before it goes to the KV store.
+func validFingerprint(fp string) bool {
+	if len(fp) == 0 || len(fp) > 64 {
+		return false
+	}
+	for _, r := range fp {
+		if !(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// build builds the feed at the path.
+func build(path string) (Feed, error) {
+	if path == "/home" || path == "/home/" {
+		return HomeFeed()
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 2 || !validFingerprint(parts[1]) {
+		return Feed{}, errNotFound
+	}
+	switch parts[0] {
+	case "board":
+		return BoardFeed(parts[1])
+	case "thread":
+		return ThreadFeed(parts[1])
+	case "user":
+		return UserFeed(parts[1])
+	}
+	return Feed{}, errNotFound
+}
+
+func handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if r.Method != "GET" && r.Method != "HEAD" {
+		w.Header().Set("Allow", "GET, HEAD")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !authorised(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	f, err := build(r.URL.Path)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var out []byte
+	var err2 error
+	if r.URL.Query().Get("format") == "rss" {
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		out, err2 = RenderRSS(&f)
+	} else {
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+		out, err2 = RenderAtom(&f)
+	}
+	if err2 != nil {
+		logging.Logf(1, "This feed failed to render. Path: %s, Error: %v", r.URL.Path, err2)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Cache-Control", "private, max-age=120")
+	w.Write(out)
+}
+
+// StartFeedsServer serves the feeds on 127.0.0.1:FeedsPort. This blocks, run it in a goroutine.
+func StartFeedsServer() {
+	addr := fmt.Sprint("127.0.0.1:", globals.FrontendConfig.GetFeedsPort())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", handle)
+	srv := &http.Server{
+		Addr:         addr,
+		Handler:      mux,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 60 * time.Second,
+	}
+	// The port isn't moved if it's taken, as the frontend API's is: the feed URLs are in the user's feed reader, and they have to stay the same.
+	logging.Logf(1, "Serving the feeds at: http://%s/home?token=<FeedsToken>", addr)
+	err := srv.ListenAndServe()
+	logging.Logf(1, "The feeds server stopped. Error: %v", err)
+}
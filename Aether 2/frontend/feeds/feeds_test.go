@@ -0,0 +1,329 @@
+// Unlike others, this test package is not named feeds_test because we need access to the token check, the routing and the rendering helpers, which are internal to the package.
+
+package feeds
+
+import (
+	"aether-core/frontend/festructs"
+	"aether-core/services/configstore"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"encoding/xml"
+	"github.com/asdine/storm"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// Infrastructure, setup and teardown
+
+var kvdir string
+
+func TestMain(m *testing.M) {
+	setup()
+	exitVal := m.Run()
+	teardown()
+	os.Exit(exitVal)
+}
+
+func setup() {
+	// Not through fecmd, which imports this package.
+	globals.FrontendTransientConfig = &configstore.Ftc
+	globals.FrontendTransientConfig.SetDefaults()
+	fecfg, err0 := configstore.EstablishFrontendConfig()
+	if err0 != nil {
+		logging.LogCrash(err0)
+	}
+	fecfg.Cycle()
+	globals.FrontendConfig = fecfg
+	globals.FrontendTransientConfig.PermConfigReadOnly = true
+	dir, err := ioutil.TempDir("", "feeds_test")
+	if err != nil {
+		panic(err)
+	}
+	kvdir = dir
+	kv, err2 := storm.Open(filepath.Join(kvdir, "KVStore.kv"))
+	if err2 != nil {
+		panic(err2)
+	}
+	globals.KvInstance = kv
+	festructs.InitialiseKvStore()
+}
+
+func teardown() {
+	globals.KvInstance.Close()
+	os.RemoveAll(kvdir)
+}
+
+// Helpers
+
+// The characters that can't be in XML at all, next to the ones that have to be escaped.
+const unsafeText = "a < b & c \x00\x01\x1b d"
+
+func unsafeFeed() Feed {
+	return Feed{
+		Id:      "urn:aether:board:unsafe",
+		Title:   unsafeText,
+		Updated: 100,
+		Items: []FeedItem{FeedItem{
+			Id:        "urn:aether:post:unsafe",
+			Title:     unsafeText,
+			Content:   "<script>alert(1)</script> & \x01\nsecond line",
+			Author:    unsafeText,
+			Published: 100,
+			Updated:   100,
+		}},
+	}
+}
+
+func hasControlCharacters(s string) bool {
+	for _, r := range s {
+		if r < 0x20 && r != '\n' && r != '\r' && r != '\t' {
+			return true
+		}
+	}
+	return false
+}
+
+// Tests
+
+func TestAuthorised(t *testing.T) {
+	token := globals.FrontendConfig.GetFeedsToken()
+	cases := []struct {
+		name     string
+		url      string
+		header   string
+		expected bool
+	}{
+		{"query token", "/home?token=" + token, "", true},
+		{"bearer header", "/home", "Bearer " + token, true},
+		{"bearer header over a wrong query token", "/home?token=wrong", "Bearer " + token, true},
+		{"wrong query token", "/home?token=wrong", "", false},
+		{"wrong bearer header", "/home?token=" + token, "Bearer wrong", false},
+		{"token prefix", "/home?token=" + token[:len(token)-1], "", false},
+		{"empty query token", "/home?token=", "", false},
+		{"empty bearer header", "/home", "Bearer ", false},
+		{"no token", "/home", "", false},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest("GET", c.url, nil)
+		if len(c.header) > 0 {
+			r.Header.Set("Authorization", c.header)
+		}
+		if authorised(r) != c.expected {
+			t.Errorf("The token check of the %s case is not the expected one. Expected: %v", c.name, c.expected)
+		}
+	}
+}
+
+func TestBuild_Routing(t *testing.T) {
+	bc := festructs.NewBoardCarrier("routingboard", 100)
+	bc.Boards = append(bc.Boards, festructs.CompiledBoard{Fingerprint: "routingboard", Name: "Routing board", Creation: 100})
+	bc.Threads = append(bc.Threads, festructs.CompiledThread{Fingerprint: "routingthread", Board: "routingboard", Name: "Routing thread", Creation: 110})
+	if err := globals.KvInstance.Save(&bc); err != nil {
+		t.Fatalf("The board could not be saved. Error: %v", err)
+	}
+	tc := festructs.NewThreadCarrier("routingthread", "routingboard", 100)
+	tc.Threads = append(tc.Threads, festructs.CompiledThread{Fingerprint: "routingthread", Board: "routingboard", Name: "Routing thread", Creation: 110})
+	if err := globals.KvInstance.Save(&tc); err != nil {
+		t.Fatalf("The thread could not be saved. Error: %v", err)
+	}
+	cases := map[string]string{
+		"/home":                 "urn:aether:home",
+		"/home/":                "urn:aether:home",
+		"/board/routingboard":   "urn:aether:board:routingboard",
+		"/board/routingboard/":  "urn:aether:board:routingboard",
+		"/thread/routingthread": "urn:aether:thread:routingthread",
+	}
+	for path, id := range cases {
+		f, err := build(path)
+		if err != nil {
+			t.Errorf("The feed at %s was not built. Error: %v", path, err)
+			continue
+		}
+		if f.Id != id {
+			t.Errorf("The feed at %s is not the expected one. Expected: %s, Got: %s", path, id, f.Id)
+		}
+	}
+	f, _ := build("/board/routingboard")
+	if f.Title != "Routing board" || len(f.Items) != 1 || f.Items[0].Title != "Routing thread" {
+		t.Errorf("The board feed does not have the board and its thread. Feed: %#v", f)
+	}
+	for _, path := range []string{"/board/nonexistentboard", "/thread/nonexistentthread", "/user/nonexistentuser"} {
+		if _, err := build(path); err != errNotFound {
+			t.Errorf("The feed at %s, which does not exist, was not rejected. Error: %v", path, err)
+		}
+	}
+}
+
+func TestBuild_BadPaths(t *testing.T) {
+	paths := []string{
+		"/",
+		"",
+		"/board",
+		"/board/",
+		"/board/routing-board",
+		"/board/routing_board",
+		"/board/../home",
+		"/board/routingboard/extra",
+		"/board/routingboard?x",
+		"/board/" + strings.Repeat("a", 65),
+		"/board/routing\x00board",
+		"/board/routingbóard",
+		"/forum/routingboard",
+		"/home/extra",
+	}
+	for _, path := range paths {
+		if _, err := build(path); err != errNotFound {
+			t.Errorf("The path '%s' was not rejected. Error: %v", path, err)
+		}
+	}
+	if !validFingerprint(strings.Repeat("a", 64)) {
+		t.Errorf("A fingerprint of 64 characters was rejected.")
+	}
+}
+
+func TestRenderAtom_Escaping(t *testing.T) {
+	f := unsafeFeed()
+	out, err := RenderAtom(&f)
+	if err != nil {
+		t.Fatalf("The Atom feed failed to render. Error: %v", err)
+	}
+	if strings.Contains(string(out), "<script>") {
+		t.Errorf("The content was not escaped.")
+	}
+	var af atomFeed
+	if err2 := xml.Unmarshal(out, &af); err2 != nil {
+		t.Fatalf("The Atom feed is not valid XML. Error: %v", err2)
+	}
+	if len(af.Entries) != 1 {
+		t.Fatalf("The Atom feed does not have the entry.")
+	}
+	e := af.Entries[0]
+	if !strings.HasPrefix(af.Title, "a < b & c ") || !strings.HasPrefix(e.Title, "a < b & c ") || !strings.HasPrefix(e.Content.Body, "<script>alert(1)</script> & ") {
+		t.Errorf("The text did not come back as it was given. Title: %q, Content: %q", e.Title, e.Content.Body)
+	}
+	for _, s := range []string{af.Title, e.Title, e.Author.Name, e.Content.Body} {
+		if hasControlCharacters(s) {
+			t.Errorf("A control character made it into the Atom feed: %q", s)
+		}
+	}
+	if e.Content.Type != "text" {
+		t.Errorf("The content is not marked as plain text. Type: %s", e.Content.Type)
+	}
+}
+
+func TestRenderRSS_Escaping(t *testing.T) {
+	f := unsafeFeed()
+	out, err := RenderRSS(&f)
+	if err != nil {
+		t.Fatalf("The RSS feed failed to render. Error: %v", err)
+	}
+	if strings.Contains(string(out), "<script>") {
+		t.Errorf("The content was not escaped.")
+	}
+	var rf rssFeed
+	if err2 := xml.Unmarshal(out, &rf); err2 != nil {
+		t.Fatalf("The RSS feed is not valid XML. Error: %v", err2)
+	}
+	if len(rf.Channel.Items) != 1 {
+		t.Fatalf("The RSS feed does not have the item.")
+	}
+	it := rf.Channel.Items[0]
+	if !strings.HasPrefix(it.Title, "a < b & c ") {
+		t.Errorf("The title did not come back as it was given. Title: %q", it.Title)
+	}
+	// The description is HTML, so once the XML is read, the markup the user wrote has to still be escaped.
+	if !strings.HasPrefix(it.Description, "&lt;script&gt;alert(1)&lt;/script&gt; &amp; ") || !strings.HasSuffix(it.Description, "<br>\nsecond line") {
+		t.Errorf("The description is not the escaped content. Description: %q", it.Description)
+	}
+	for _, s := range []string{rf.Channel.Title, it.Title, it.Description} {
+		if hasControlCharacters(s) {
+			t.Errorf("A control character made it into the RSS feed: %q", s)
+		}
+	}
+}
+
+func TestRssDescription(t *testing.T) {
+	cases := map[string]string{
+		"":                           "",
+		"plain":                      "plain",
+		"a <b>bold</b> & \"quoted\"": "a &lt;b&gt;bold&lt;/b&gt; &amp; &#34;quoted&#34;",
+		"first\nsecond\n":            "first<br>\nsecond<br>\n",
+		"<img src=x onerror=y>":      "&lt;img src=x onerror=y&gt;",
+	}
+	for content, expected := range cases {
+		if got := rssDescription(content); got != expected {
+			t.Errorf("The description of %q is not the expected one. Expected: %q, Got: %q", content, expected, got)
+		}
+	}
+}
+
+func TestDerivedTitle(t *testing.T) {
+	long := strings.Repeat("é", maxDerivedTitleLength+20)
+	title := derivedTitle(long)
+	if !utf8.ValidString(title) {
+		t.Errorf("The title was cut in the middle of a character.")
+	}
+	if title != strings.Repeat("é", maxDerivedTitleLength)+"…" {
+		t.Errorf("The title was not cut to %d characters. Got: %q", maxDerivedTitleLength, title)
+	}
+	exact := strings.Repeat("日", maxDerivedTitleLength)
+	if derivedTitle(exact) != exact {
+		t.Errorf("A title of exactly the maximum length was cut.")
+	}
+	cases := map[string]string{
+		"":                            "",
+		"short":                       "short",
+		"  \n  first line  \nsecond":  "first line",
+		"first line\nsecond line":     "first line",
+		"\n\n   \n\nafter the blanks": "after the blanks",
+	}
+	for body, expected := range cases {
+		if got := derivedTitle(body); got != expected {
+			t.Errorf("The title of %q is not the expected one. Expected: %q, Got: %q", body, expected, got)
+		}
+	}
+}
+
+func TestFinalise_OrderAndCap(t *testing.T) {
+	f := Feed{}
+	// In the order the carriers would have them, which is not the order of time.
+	for i := 0; i < maxFeedItems+10; i++ {
+		published := int64((i*37)%(maxFeedItems+10) + 1)
+		f.Items = append(f.Items, FeedItem{Published: published, Updated: published + 5})
+	}
+	f.finalise()
+	if len(f.Items) != maxFeedItems {
+		t.Fatalf("The feed was not cut to %d items. Got: %d", maxFeedItems, len(f.Items))
+	}
+	for key, _ := range f.Items {
+		if key > 0 && f.Items[key].Published > f.Items[key-1].Published {
+			t.Errorf("The items are not newest first. At: %d", key)
+		}
+	}
+	if f.Items[0].Published != int64(maxFeedItems+10) || f.Items[len(f.Items)-1].Published != 11 {
+		t.Errorf("The oldest items were not the ones cut. Newest: %d, Oldest: %d", f.Items[0].Published, f.Items[len(f.Items)-1].Published)
+	}
+	if f.Updated != int64(maxFeedItems+10+5) {
+		t.Errorf("The update time of the feed is not that of its latest item. Got: %d", f.Updated)
+	}
+}
+
+func TestFinalise_Empty(t *testing.T) {
+	f := Feed{}
+	before := time.Now().Unix()
+	f.finalise()
+	if len(f.Items) != 0 || f.Updated < before {
+		t.Errorf("An empty feed does not have the current time as its update time. Got: %d", f.Updated)
+	}
+	g := Feed{Updated: 500, Items: []FeedItem{FeedItem{Published: 100, Updated: 200}}}
+	g.finalise()
+	if g.Updated != 500 {
+		t.Errorf("The update time of the feed was moved back by an older item. Got: %d", g.Updated)
+	}
+}
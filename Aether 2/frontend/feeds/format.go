@@ -0,0 +1,152 @@
+// Frontend > Feeds > Format
+// This file writes the feeds out as Atom or RSS.
+
+package feeds
+
+import (
+	"encoding/xml"
+	"html"
+	"strings"
+	"time"
+)
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Id        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Author    atomAuthor `xml:"author"`
+	Link      *atomLink  `xml:"link,omitempty"`
+	Content   atomText   `xml:"content"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Id       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Author   atomAuthor  `xml:"author"`
+	Link     *atomLink   `xml:"link,omitempty"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type rssGuid struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description"`
+	Author      string  `xml:"dc:creator"`
+	Guid        rssGuid `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+func atomTime(ts int64) string {
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
+
+func rssTime(ts int64) string {
+	return time.Unix(ts, 0).UTC().Format(time.RFC1123Z)
+}
+
+// RenderAtom writes the feed as an Atom feed.
+func RenderAtom(f *Feed) ([]byte, error) {
+	af := atomFeed{
+		Id:       f.Id,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  atomTime(f.Updated),
+		Author:   atomAuthor{Name: "Aether"},
+	}
+	if len(f.Link) > 0 {
+		af.Link = &atomLink{Href: f.Link, Rel: "alternate"}
+	}
+	for key, _ := range f.Items {
+		it := f.Items[key]
+		e := atomEntry{
+			Id:        it.Id,
+			Title:     it.Title,
+			Updated:   atomTime(it.Updated),
+			Published: atomTime(it.Published),
+			Author:    atomAuthor{Name: it.Author},
+			Content:   atomText{Type: "text", Body: it.Content},
+		}
+		if len(it.Link) > 0 {
+			e.Link = &atomLink{Href: it.Link, Rel: "alternate"}
+		}
+		af.Entries = append(af.Entries, e)
+	}
+	out, err := xml.MarshalIndent(af, "", "  ")
+	if err != nil {
+		return []byte{}, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// RenderRSS writes the feed as an RSS 2.0 feed.
+func RenderRSS(f *Feed) ([]byte, error) {
+	rf := rssFeed{
+		Version: "2.0",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			LastBuildDate: rssTime(f.Updated),
+		},
+	}
+	for key, _ := range f.Items {
+		it := f.Items[key]
+		rf.Channel.Items = append(rf.Channel.Items, rssItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			Description: rssDescription(it.Content),
+			Author:      it.Author,
+			Guid:        rssGuid{IsPermaLink: "false", Value: it.Id},
+			PubDate:     rssTime(it.Published),
+		})
+	}
+	out, err := xml.MarshalIndent(rf, "", "  ")
+	if err != nil {
+		return []byte{}, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// rssDescription makes the plain text content into the HTML that RSS readers expect in a description. Atom can say that the content is plain text, RSS can't, so it's escaped here, otherwise the readers would render whatever markup the users wrote.
+func rssDescription(content string) string {
+	return strings.Replace(html.EscapeString(content), "\n", "<br>\n", -1)
+}
@@ -0,0 +1,278 @@
+// Frontend > Feeds > Items
+// This file builds the feeds out of the compiled boards, threads and users in the KV store.
+
+package feeds
+
+import (
+	"aether-core/frontend/beapiconsumer"
+	"aether-core/frontend/festructs"
+	pbstructs "aether-core/protos/mimapi"
+	"aether-core/services/globals"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// How many items a feed has at most. The readers keep the older ones they've already seen.
+	maxFeedItems = 50
+	// How long the title of an item made out of the body of a post can be.
+	maxDerivedTitleLength = 80
+)
+
+var errNotFound = errors.New("Not found.")
+
+// Feed is a feed in neither of the formats. RenderAtom and RenderRSS write it out.
+type Feed struct {
+	Id          string
+	Title       string
+	Description string
+	Link        string
+	Updated     int64
+	Items       []FeedItem
+}
+
+type FeedItem struct {
+	Id        string
+	Title     string
+	Content   string
+	Author    string
+	Link      string
+	Published int64
+	Updated   int64
+}
+
+// finalise sorts the items newest first, cuts them to size, and sets the feed's update time.
+func (f *Feed) finalise() {
+	sort.SliceStable(f.Items, func(i, j int) bool { return f.Items[i].Published > f.Items[j].Published })
+	if len(f.Items) > maxFeedItems {
+		f.Items = f.Items[:maxFeedItems]
+	}
+	for key, _ := range f.Items {
+		if f.Items[key].Updated > f.Updated {
+			f.Updated = f.Items[key].Updated
+		}
+	}
+	if f.Updated == 0 {
+		f.Updated = time.Now().Unix()
+	}
+}
+
+// visible is the rule the frontend uses for the threads and posts it shows when it's not showing the deleted ones.
+func visible(s *festructs.CompiledContentSignals) bool {
+	if s.ModApproved || s.SelfModApproved {
+		return true
+	}
+	return !(s.ModBlocked || s.SelfModBlocked)
+}
+
+func userName(u *festructs.CompiledUser) string {
+	if len(u.CompiledUserSignals.CanonicalName) > 0 {
+		return u.CompiledUserSignals.CanonicalName
+	}
+	if len(u.NonCanonicalName) > 0 {
+		return u.NonCanonicalName
+	}
+	return shortFp(u.Fingerprint)
+}
+
+func shortFp(fp string) string {
+	if len(fp) > 12 {
+		return fp[:12]
+	}
+	return fp
+}
+
+// derivedTitle makes a title out of the first line of a body, for the posts, which don't have titles.
+func derivedTitle(body string) string {
+	line := strings.TrimSpace(strings.SplitN(strings.TrimSpace(body), "\n", 2)[0])
+	if r := []rune(line); len(r) > maxDerivedTitleLength {
+		line = string(r[:maxDerivedTitleLength]) + "…"
+	}
+	return line
+}
+
+func later(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// The links are to the web gateway, if there is one. See FeedsLinkBase.
+func boardLink(fp string) string {
+	if base := globals.FrontendConfig.GetFeedsLinkBase(); len(base) > 0 {
+		return fmt.Sprintf("%s/board/%s", strings.TrimSuffix(base, "/"), fp)
+	}
+	return ""
+}
+
+func threadLink(fp string) string {
+	if base := globals.FrontendConfig.GetFeedsLinkBase(); len(base) > 0 {
+		return fmt.Sprintf("%s/thread/%s", strings.TrimSuffix(base, "/"), fp)
+	}
+	return ""
+}
+
+func postLink(threadfp, fp string) string {
+	if l := threadLink(threadfp); len(l) > 0 {
+		return l + "#" + fp
+	}
+	return ""
+}
+
+func threadItem(t *festructs.CompiledThread) FeedItem {
+	content := t.Body
+	if len(t.Link) > 0 {
+		content = strings.TrimSpace(t.Link + "\n\n" + t.Body)
+	}
+	return FeedItem{
+		Id:        "urn:aether:thread:" + t.Fingerprint,
+		Title:     t.Name,
+		Content:   content,
+		Author:    userName(&t.Owner),
+		Link:      threadLink(t.Fingerprint),
+		Published: t.Creation,
+		Updated:   later(t.Creation, t.LastUpdate),
+	}
+}
+
+// BoardFeed is the new threads of a board.
+func BoardFeed(fp string) (Feed, error) {
+	bc := festructs.BoardCarrier{}
+	err := globals.KvInstance.One("Fingerprint", fp, &bc)
+	if err != nil {
+		return Feed{}, errNotFound
+	}
+	f := Feed{Id: "urn:aether:board:" + fp, Link: boardLink(fp)}
+	for key, _ := range bc.Boards {
+		if bc.Boards[key].Fingerprint == fp {
+			f.Title = bc.Boards[key].Name
+			f.Description = bc.Boards[key].Description
+			f.Updated = later(bc.Boards[key].Creation, bc.Boards[key].LastUpdate)
+		}
+	}
+	for key, _ := range bc.Threads {
+		t := &bc.Threads[key]
+		if t.Board != fp || !visible(&t.CompiledContentSignals) {
+			continue
+		}
+		f.Items = append(f.Items, threadItem(t))
+	}
+	f.finalise()
+	return f, nil
+}
+
+// ThreadFeed is the new posts of a thread, at any depth.
+func ThreadFeed(fp string) (Feed, error) {
+	tc := festructs.ThreadCarrier{}
+	err := globals.KvInstance.One("Fingerprint", fp, &tc)
+	if err != nil {
+		return Feed{}, errNotFound
+	}
+	f := Feed{Id: "urn:aether:thread:" + fp, Link: threadLink(fp)}
+	for key, _ := range tc.Threads {
+		if tc.Threads[key].Fingerprint == fp {
+			f.Title = tc.Threads[key].Name
+			f.Description = derivedTitle(tc.Threads[key].Body)
+			f.Updated = later(tc.Threads[key].Creation, tc.Threads[key].LastUpdate)
+		}
+	}
+	for key, _ := range tc.Posts {
+		p := &tc.Posts[key]
+		if !visible(&p.CompiledContentSignals) {
+			continue
+		}
+		f.Items = append(f.Items, FeedItem{
+			Id:        "urn:aether:post:" + p.Fingerprint,
+			Title:     derivedTitle(p.Body),
+			Content:   p.Body,
+			Author:    userName(&p.Owner),
+			Link:      postLink(p.Thread, p.Fingerprint),
+			Published: p.Creation,
+			Updated:   later(p.Creation, p.LastUpdate),
+		})
+	}
+	f.finalise()
+	return f, nil
+}
+
+// UserFeed is the new threads and posts of a user. These come from the backend, the same way the profile of the user in the app gets them, since the KV store keeps the content by where it is, not by who wrote it.
+func UserFeed(fp string) (Feed, error) {
+	uh := festructs.UserHeaderCarrier{}
+	err := globals.KvInstance.One("Fingerprint", fp, &uh)
+	if err != nil {
+		return Feed{}, errNotFound
+	}
+	name := shortFp(fp)
+	f := Feed{Id: "urn:aether:user:" + fp}
+	for key, _ := range uh.Users {
+		if uh.Users[key].Fingerprint == fp {
+			name = userName(&uh.Users[key])
+			f.Description = uh.Users[key].Info
+		}
+	}
+	f.Title = name
+	threads := beapiconsumer.GetThreadsByKeyFingerprint(fp, maxFeedItems, 0)
+	for key, _ := range threads {
+		t := threads[key]
+		if len(t.GetEncrContent()) > 0 {
+			continue
+		}
+		ct := festructs.CompiledThread{
+			Fingerprint: t.GetProvable().GetFingerprint(),
+			Name:        t.GetName(),
+			Body:        t.GetBody(),
+			Link:        t.GetLink(),
+			Creation:    t.GetProvable().GetCreation(),
+			LastUpdate:  t.GetUpdateable().GetLastUpdate(),
+		}
+		item := threadItem(&ct)
+		item.Author = name
+		f.Items = append(f.Items, item)
+	}
+	posts := beapiconsumer.GetPostsByKeyFingerprint(fp, maxFeedItems, 0)
+	for key, _ := range posts {
+		if len(posts[key].GetEncrContent()) > 0 {
+			continue
+		}
+		f.Items = append(f.Items, userPostItem(posts[key], name))
+	}
+	f.finalise()
+	return f, nil
+}
+
+func userPostItem(p *pbstructs.Post, name string) FeedItem {
+	fp := p.GetProvable().GetFingerprint()
+	return FeedItem{
+		Id:        "urn:aether:post:" + fp,
+		Title:     derivedTitle(p.GetBody()),
+		Content:   p.GetBody(),
+		Author:    name,
+		Link:      postLink(p.GetThread(), fp),
+		Published: p.GetProvable().GetCreation(),
+		Updated:   later(p.GetProvable().GetCreation(), p.GetUpdateable().GetLastUpdate()),
+	}
+}
+
+// HomeFeed is the threads in the home view: the popular ones in the boards the user is subscribed to with notifications on.
+func HomeFeed() (Feed, error) {
+	hvc := festructs.HomeViewCarrier{}
+	err := globals.KvInstance.One("Id", 1, &hvc)
+	if err != nil {
+		// The home view isn't generated yet.
+		hvc = festructs.HomeViewCarrier{}
+	}
+	f := Feed{Id: "urn:aether:home", Title: "Home", Description: "The popular threads in the boards you're subscribed to."}
+	for key, _ := range hvc.Threads {
+		t := &hvc.Threads[key]
+		if !visible(&t.CompiledContentSignals) {
+			continue
+		}
+		f.Items = append(f.Items, threadItem(t))
+	}
+	f.finalise()
+	return f, nil
+}
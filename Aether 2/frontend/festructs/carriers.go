@@ -644,7 +644,10 @@ type UserHeaderCarrier struct {
 	LastRefreshed  int64
 	LastReferenced int64
 	Self           bool
-	now            int64
+	// Every key of this user, oldest first, if they have rotated their key. Signals for any of these count as signals for this one.
+	SuccessionChain []string
+	now             int64
+	keys            *KeyCache
 }
 
 func NewUserHeaderCarrier(fp, domain string, nowts int64) UserHeaderCarrier {
@@ -655,18 +658,72 @@ func NewUserHeaderCarrier(fp, domain string, nowts int64) UserHeaderCarrier {
 	}
 }
 
+func (c *UserHeaderCarrier) chain() []string {
+	if len(c.SuccessionChain) == 0 {
+		return []string{c.Fingerprint}
+	}
+	return c.SuccessionChain
+}
+
+// needs to run before signals tables refresh. If the chain has changed, we rebuild the signals tables from scratch, because the signals for a key that just joined the chain can be older than our last refresh.
+func (c *UserHeaderCarrier) refreshSuccessionChain() {
+	keys := c.keys
+	c.keys = nil // The cache is only good for the refresh it was made for.
+	if keys == nil {
+		kc := NewKeyCache([]string{}, c.now)
+		keys = &kc
+	}
+	chain := ResolveSuccessionChain(c.Fingerprint, keys)
+	if sameChain(chain, c.chain()) {
+		c.SuccessionChain = chain
+		return
+	}
+	logging.Logf(1, "The succession chain of this user has changed. Rebuilding its signals. User: %v, Chain: %v", c.Fingerprint, chain)
+	c.SuccessionChain = chain
+	c.PublicTrusts = CPTBatch{}
+	c.CanonicalNames = CCNBatch{}
+	c.F451s = CF451Batch{}
+	c.PublicElects = CPEBatch{}
+	c.LastReferenced = 0
+}
+
 // needs to run before user entity refresh
 func (c *UserHeaderCarrier) refreshSignalsTables() {
-	c.PublicTrusts.Insert(GetPTs(c.Fingerprint, c.Domain, c.LastReferenced, c.now), c.now)
-	c.CanonicalNames.Insert(GetCNs(c.Fingerprint, c.Domain, c.LastReferenced, c.now), c.now)
-	c.F451s.Insert(GetF451s(c.Fingerprint, c.Domain, c.LastReferenced, c.now), c.now)
-	c.PublicElects.Insert(GetPEs(c.Fingerprint, c.Domain, c.LastReferenced, c.now), c.now)
+	for _, fp := range c.chain() {
+		pts := GetPTs(fp, c.Domain, c.LastReferenced, c.now)
+		cns := GetCNs(fp, c.Domain, c.LastReferenced, c.now)
+		f451s := GetF451s(fp, c.Domain, c.LastReferenced, c.now)
+		pes := GetPEs(fp, c.Domain, c.LastReferenced, c.now)
+		if fp != c.Fingerprint {
+			// Signals about the other keys of this user count as signals about this key.
+			for k, _ := range pts {
+				pts[k].TargetFingerprint = c.Fingerprint
+			}
+			for k, _ := range cns {
+				cns[k].TargetFingerprint = c.Fingerprint
+			}
+			for k, _ := range f451s {
+				f451s[k].TargetFingerprint = c.Fingerprint
+			}
+			for k, _ := range pes {
+				pes[k].TargetFingerprint = c.Fingerprint
+			}
+		}
+		c.PublicTrusts.Insert(pts, c.now)
+		c.CanonicalNames.Insert(cns, c.now)
+		c.F451s.Insert(f451s, c.now)
+		c.PublicElects.Insert(pes, c.now)
+	}
 }
 
 func (c *UserHeaderCarrier) refreshUserEntity(localDefaultMods []string, totalPop int) {
 	newUserEntities := beapiconsumer.GetKeys(c.LastReferenced, c.now, []string{c.Fingerprint}, false, false)
+	RecordRevocations(newUserEntities)
 	// Attempt to insert every user update we have - the newest will prevail. There will most likely be only one in reality, but it's good to be defensive.
 	c.Users.InsertFromProtobuf(newUserEntities, c.now)
+	for k, _ := range c.Users {
+		c.Users[k].SuccessionChain = c.chain()
+	}
 	c.Users.Refresh(&c.PublicTrusts, &c.CanonicalNames, &c.F451s, &c.PublicElects, localDefaultMods, c.Domain, totalPop)
 }
 
@@ -689,6 +746,19 @@ func (c *UHCBatch) Find(fp string) int {
 	return -1
 }
 
+// Refresh refreshes every user header carrier in the batch. The keys the succession chains need are pulled once for the whole batch.
+func (c *UHCBatch) Refresh(localDefaultMods []string, totalPop int, nowts int64) {
+	fps := []string{}
+	for k, _ := range *c {
+		fps = append(fps, (*c)[k].Fingerprint)
+	}
+	keys := NewKeyCache(fps, nowts)
+	for k, _ := range *c {
+		(*c)[k].keys = &keys
+		(*c)[k].Refresh(localDefaultMods, totalPop, nowts)
+	}
+}
+
 // We could also do something like total karma, but then that would be a little tricky.. because what happens is that it would both be probabilistic, and might hit the ceiling. Also might be fairly drastically different for different people because we're deleting the votes after 2 weeks.
 
 // func (c *UserHeaderCarrier) Reset() {
@@ -698,6 +768,8 @@ func (c *UHCBatch) Find(fp string) int {
 
 func (c *UserHeaderCarrier) Refresh(localDefaultMods []string, totalPop int, nowts int64) {
 	c.now = nowts
+	// Find the other keys of this user, if any
+	c.refreshSuccessionChain()
 	// Generate signals tables we need to use
 	c.refreshSignalsTables()
 	// Using those tables, refresh the user entity
@@ -773,6 +845,7 @@ func (g *GlobalStatisticsCarrier) Refresh(nowts int64) []*pbstructs.Key {
 	g.now = nowts
 	// Get all new user entities / updates since lastref
 	newUserEntities := beapiconsumer.GetKeys(g.LastReferenced, g.now, []string{}, false, false)
+	RecordRevocations(newUserEntities)
 	fps := []string{}
 	// Put their fingerprints into the bloom
 	for k, _ := range newUserEntities {
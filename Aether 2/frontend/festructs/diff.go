@@ -0,0 +1,97 @@
+// FEStructs > Diff
+// This file compares the revisions of thread and post bodies, line by line.
+
+package festructs
+
+import (
+	"strings"
+)
+
+// Above this many line pairs, the lines in between the common start and end are shown as removed and added as a block, instead of being compared one by one. The comparison needs memory that grows with the number of pairs.
+const maxDiffLinePairs = 4000000
+
+type DiffLine struct {
+	Op   string // "=" unchanged, "+" added, "-" removed
+	Text string
+}
+
+// LineDiff returns what changed between two versions of a body, in the order of the lines.
+func LineDiff(from, to string) []DiffLine {
+	a := strings.Split(from, "\n")
+	b := strings.Split(to, "\n")
+	if len(from) == 0 {
+		a = []string{}
+	}
+	if len(to) == 0 {
+		b = []string{}
+	}
+	// The lines at the start and the end that didn't change don't need to be compared.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	diff := []DiffLine{}
+	for _, l := range a[:prefix] {
+		diff = append(diff, DiffLine{Op: "=", Text: l})
+	}
+	diff = append(diff, middleDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, l := range a[len(a)-suffix:] {
+		diff = append(diff, DiffLine{Op: "=", Text: l})
+	}
+	return diff
+}
+
+// middleDiff compares the lines by their longest common subsequence.
+func middleDiff(a, b []string) []DiffLine {
+	diff := []DiffLine{}
+	if len(a)*len(b) > maxDiffLinePairs {
+		for _, l := range a {
+			diff = append(diff, DiffLine{Op: "-", Text: l})
+		}
+		for _, l := range b {
+			diff = append(diff, DiffLine{Op: "+", Text: l})
+		}
+		return diff
+	}
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] == b[j] {
+			diff = append(diff, DiffLine{Op: "=", Text: a[i]})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			diff = append(diff, DiffLine{Op: "-", Text: a[i]})
+			i++
+		} else {
+			diff = append(diff, DiffLine{Op: "+", Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, DiffLine{Op: "-", Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, DiffLine{Op: "+", Text: b[j]})
+	}
+	return diff
+}
@@ -0,0 +1,98 @@
+package festructs_test
+
+import (
+	"aether-core/frontend/festructs"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Helpers
+
+// diffString writes the diff out one line per line, as "<op> <text>", so that it can be compared to the expected one at a glance.
+func diffString(diff []festructs.DiffLine) string {
+	lines := []string{}
+	for _, l := range diff {
+		lines = append(lines, l.Op+" "+l.Text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func lines(l ...string) string {
+	return strings.Join(l, "\n")
+}
+
+// Tests
+
+func TestLineDiff(t *testing.T) {
+	cases := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{"both empty", "", "", ""},
+		{"unchanged", lines("a", "b"), lines("a", "b"), lines("= a", "= b")},
+		{"empty to text", "", lines("a", "b"), lines("+ a", "+ b")},
+		{"text to empty", lines("a", "b"), "", lines("- a", "- b")},
+		{"line added at the end", lines("a", "b"), lines("a", "b", "c"), lines("= a", "= b", "+ c")},
+		{"line added at the start", lines("b", "c"), lines("a", "b", "c"), lines("+ a", "= b", "= c")},
+		{"line removed at the end", lines("a", "b", "c"), lines("a", "b"), lines("= a", "= b", "- c")},
+		{"line removed at the start", lines("a", "b", "c"), lines("b", "c"), lines("- a", "= b", "= c")},
+		{"interior edit", lines("a", "b", "c", "d", "e"), lines("a", "b", "x", "d", "e"), lines("= a", "= b", "- c", "+ x", "= d", "= e")},
+		{"interior edit around a kept line", lines("a", "b", "c", "d", "e"), lines("a", "x", "c", "y", "e"), lines("= a", "- b", "+ x", "= c", "- d", "+ y", "= e")},
+		{"interior insert", lines("a", "b", "e"), lines("a", "b", "c", "d", "e"), lines("= a", "= b", "+ c", "+ d", "= e")},
+		{"repeated lines", lines("a", "a", "a"), lines("a", "a"), lines("= a", "= a", "- a")},
+		{"trailing newline added", "a", "a\n", lines("= a", "+ ")},
+	}
+	for _, c := range cases {
+		got := diffString(festructs.LineDiff(c.from, c.to))
+		if got != c.expected {
+			t.Errorf("The diff of the %s case is not the expected one.\nExpected:\n%s\nGot:\n%s", c.name, c.expected, got)
+		}
+	}
+}
+
+// The lines in between the common start and the end all get compared to one another, unless there are too many of them. Then they're shown as removed and added as a block, even where some of them are the same.
+func TestLineDiff_TooManyLinePairs(t *testing.T) {
+	// 2001 x 2001 lines is over the 4 million pairs that are compared.
+	count := 2001
+	from, to := []string{"start"}, []string{"start"}
+	for i := 0; i < count; i++ {
+		if i%2 == 1 {
+			from = append(from, "kept")
+			to = append(to, "kept")
+			continue
+		}
+		from = append(from, fmt.Sprintf("from %d", i))
+		to = append(to, fmt.Sprintf("to %d", i))
+	}
+	from, to = append(from, "end"), append(to, "end")
+	diff := festructs.LineDiff(strings.Join(from, "\n"), strings.Join(to, "\n"))
+	if len(diff) != 2+2*count {
+		t.Fatalf("The diff does not have every line of both versions. Expected: %d, Got: %d", 2+2*count, len(diff))
+	}
+	if diff[0].Op != "=" || diff[0].Text != "start" || diff[len(diff)-1].Op != "=" || diff[len(diff)-1].Text != "end" {
+		t.Errorf("The common start and end were not kept. Start: %#v, End: %#v", diff[0], diff[len(diff)-1])
+	}
+	for key, l := range diff[1 : len(diff)-1] {
+		expected := "-"
+		if key >= count {
+			expected = "+"
+		}
+		if l.Op != expected {
+			t.Fatalf("The lines in between were not shown as removed and added as a block. At: %d, Line: %#v", key, l)
+		}
+	}
+	// Under the limit, the same lines in between are found.
+	small := festructs.LineDiff(strings.Join(from[:20], "\n"), strings.Join(to[:20], "\n"))
+	kept := 0
+	for _, l := range small {
+		if l.Op == "=" && l.Text == "kept" {
+			kept++
+		}
+	}
+	if kept == 0 {
+		t.Errorf("Under the limit, the lines that are the same in between were not found.")
+	}
+}
@@ -26,6 +26,7 @@ type CompiledPost struct {
 	Owner                  CompiledUser
 	Creation               int64
 	LastUpdate             int64
+	Edited                 bool
 	Meta                   string
 }
 
@@ -44,6 +45,8 @@ func NewCPost(rp *pbstructs.Post) CompiledPost {
 		},
 		Creation:   rp.GetProvable().GetCreation(),
 		LastUpdate: rp.GetUpdateable().GetLastUpdate(),
+		// An update that isn't after the creation doesn't go into the database, so any update we have is an edit.
+		Edited: rp.GetUpdateable().GetLastUpdate() > 0,
 	}
 	// Needs: Compiledcontentsignals, owner, bymod, byop, blocked, approved flags
 }
@@ -257,6 +260,7 @@ type CompiledThread struct {
 	Owner                  CompiledUser
 	Creation               int64
 	LastUpdate             int64
+	Edited                 bool
 	Meta                   string
 	PostsCount             int
 	Score                  float64
@@ -277,6 +281,7 @@ func NewCThread(rp *pbstructs.Thread) CompiledThread {
 		},
 		Creation:   rp.GetProvable().GetCreation(),
 		LastUpdate: rp.GetUpdateable().GetLastUpdate(),
+		Edited:     rp.GetUpdateable().GetLastUpdate() > 0,
 	}
 	// Needs: Compiledcontentsignals, owner, bymod, byop, blocked, approved flags
 }
@@ -484,9 +489,16 @@ type CompiledUser struct {
 	LastRefreshed       int64
 	Meta                string
 	CompiledUserSignals CompiledUserSignals
+	// Key succession. Successor and Predecessor are what this key claims, SuccessionChain is what has been verified from both sides. (See succession.go)
+	Successor       string
+	Predecessor     string
+	Revoked         bool
+	RevokedAt       int64
+	SuccessionChain []string
 }
 
 func NewCUser(u *pbstructs.Key, nowts int64) CompiledUser {
+	km := keyMeta(u)
 	return CompiledUser{
 		Fingerprint:      u.GetProvable().GetFingerprint(),
 		NonCanonicalName: u.GetName(),
@@ -496,6 +508,10 @@ func NewCUser(u *pbstructs.Key, nowts int64) CompiledUser {
 		LastUpdate:       u.GetUpdateable().GetLastUpdate(),
 		Meta:             u.GetMeta(),
 		LastRefreshed:    nowts,
+		Successor:        km.Successor,
+		Predecessor:      km.Predecessor,
+		Revoked:          km.Revoked,
+		RevokedAt:        km.RevokedAt,
 	}
 	// needs: compiledusersignals
 }
@@ -505,13 +521,23 @@ func (c *CompiledUser) RefreshUserSignals(
 	cpts *CPTBatch, ccns *CCNBatch, cf451s *CF451Batch, cpes *CPEBatch, localDefaultMods []string, domainfp string, totalPop int) {
 	cs := CompiledUserSignals{}
 	cs.Insert(c.Fingerprint, domainfp, localDefaultMods, totalPop, cpts, ccns, cf451s, cpes)
+	for k, _ := range c.SuccessionChain {
+		if c.SuccessionChain[k] != c.Fingerprint {
+			cs.insertSuccessionAlias(c.SuccessionChain[k], domainfp, localDefaultMods, cf451s)
+		}
+	}
 	c.CompiledUserSignals = cs
 }
 
 // Insert is a full-on override - anything from the prior compiled user will be wiped out, including signals. If you want a soft merge where signals are merged, not replaced with the new signals, see InsertWithSignalMerge.
 func (c *CompiledUser) Insert(ce CompiledUser) {
 	if c.LastUpdate < ce.LastUpdate {
+		wasRevoked, revokedAt, successor := c.Revoked, c.RevokedAt, c.Successor
 		*c = ce
+		if wasRevoked && !c.Revoked {
+			// Once we've seen a key revoked, we don't take that back. An update that un-revokes a key can only come from whoever stole it.
+			c.Revoked, c.RevokedAt, c.Successor = true, revokedAt, successor
+		}
 	}
 }
 
@@ -1027,6 +1053,15 @@ func (s *CompiledUserSignals) Insert(
 	s.SelfPELastUpdate = cpe.SelfLastUpdate
 }
 
+// insertSuccessionAlias applies the local user's own decisions about another key of the same person to this key. The network signals are already merged in the signals tables the user header carrier builds, so this only needs to look at the local config.
+func (s *CompiledUserSignals) insertSuccessionAlias(aliasfp, domainfp string, localDefaultMods []string, cf451s *CF451Batch) {
+	s.FollowedBySelf = s.FollowedBySelf || globals.FrontendConfig.UserRelations.Following.Find(aliasfp, domainfp) != -1
+	s.BlockedBySelf = s.BlockedBySelf || globals.FrontendConfig.UserRelations.Blocked.Find(aliasfp, domainfp) != -1
+	s.MadeModBySelf = s.MadeModBySelf || (globals.FrontendConfig.UserRelations.ModElected.Find(aliasfp, domainfp) != -1 && isF451Mod(s.TargetFingerprint, cf451s.FindObj(s.TargetFingerprint)))
+	s.MadeNonModBySelf = s.MadeNonModBySelf || globals.FrontendConfig.UserRelations.ModDisqualified.Find(aliasfp, domainfp) != -1
+	s.MadeModByDefault = s.MadeModByDefault || isModByDefault(aliasfp, localDefaultMods)
+}
+
 func parsePublicElectByNetwork(targetfp string, totalPop int, cpe CompiledPE) (elected, disqualified bool) {
 	totalVoteCount := cpe.ElectsCount + cpe.DisqualifiesCount
 	totalVoteRequired := int(float64(totalPop) * (float64(globals.FrontendConfig.GetThresholdForElectionValidityPercent()) / 100))
@@ -1286,6 +1321,10 @@ func InitialiseKvStore() {
 	if err5 != nil {
 		logging.Logf(1, "UserHeaderCarrier init encountered a problem. Error: %v", err5)
 	}
+	err6 := globals.KvInstance.Init(&KeyRevocation{})
+	if err6 != nil {
+		logging.Logf(1, "KeyRevocation init encountered a problem. Error: %v", err6)
+	}
 }
 
 /*----------  Reports tab entry  ----------*/
@@ -37,6 +37,7 @@ func (e *CompiledThread) Protobuf() *pb.CompiledThreadEntity {
 		Owner:      e.Owner.Protobuf(),
 		Creation:   e.Creation,
 		LastUpdate: e.LastUpdate,
+		Edited:     e.Edited,
 		Meta:       e.Meta,
 		PostsCount: int32(e.PostsCount),
 		Score:      e.Score,
@@ -55,6 +56,7 @@ func (e *CompiledPost) Protobuf() *pb.CompiledPostEntity {
 		Owner:      e.Owner.Protobuf(),
 		Creation:   e.Creation,
 		LastUpdate: e.LastUpdate,
+		Edited:     e.Edited,
 		Meta:       e.Meta,
 	}
 }
@@ -135,6 +137,11 @@ func (e *CompiledUser) Protobuf() *pb.CompiledUserEntity {
 		LastRefreshed:       e.LastRefreshed,
 		Meta:                e.Meta,
 		CompiledUserSignals: e.CompiledUserSignals.Protobuf(),
+		Successor:           e.Successor,
+		Predecessor:         e.Predecessor,
+		Revoked:             e.Revoked,
+		RevokedAt:           e.RevokedAt,
+		SuccessionChain:     e.SuccessionChain,
 	}
 }
 
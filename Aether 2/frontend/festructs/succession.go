@@ -0,0 +1,206 @@
+// Frontend > FEStructs > Succession
+// This library resolves key succession chains, so that a user who has moved to a new key is compiled as the same person across all the keys they have used.
+
+package festructs
+
+import (
+	"aether-core/frontend/beapiconsumer"
+	pbstructs "aether-core/protos/mimapi"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"aether-core/services/metaparse"
+	"aether-core/services/signaturing"
+)
+
+/*
+How does succession work?
+
+When a user rotates their key, two things get published:
+
+- A new key, whose meta has the fingerprint of the old key as the predecessor, and the signature of the old key over the public key of the new key. The new key is signed by itself, like every key.
+- An update to the old key, whose meta has the fingerprint of the new key as the successor. This update is signed by the old key.
+
+A link is only valid if both sides agree: the old key names the new key, the new key names the old key, and the old key's signature over the new key checks out. That way, nobody can claim someone else's key as their predecessor to inherit their reputation, and nobody can push their reputation onto someone else's key.
+
+If the old key was compromised, the user can revoke it with the same update that names the successor. A revoked key cannot be succeeded by a key created after the revocation, so whoever stole the key cannot later point it at a key of their own.
+
+The 'last update wins' rule of the network would let whoever has the old key publish a later update that takes the revocation back, or points the successor elsewhere. So revocation is sticky here: the first version of a key that we see revoked is recorded in the KV store when we pull the key from the backend, and from then on, the key is read as that version. Any other update to a revoked key is ignored, including another revocation. The timestamps of a revocation are signed by the key that is being revoked, so whoever stole it can backdate their own revocation to before the real one. The only order they can't forge is the order we saw them in.
+
+The backend keeps only the newest version of a key, so a revocation that we never saw before it was overwritten can't be recovered. Revocation limits the damage, it cannot undo a theft.
+*/
+
+const maxSuccessionChainLength = 16 // Defensive cap, a real user will rotate a handful of times at most.
+
+func readKeyMeta(k *pbstructs.Key) metaparse.KeyMeta {
+	if k == nil {
+		return metaparse.KeyMeta{}
+	}
+	m, err := metaparse.ReadMeta("Key", k.GetMeta())
+	if err != nil {
+		logging.Logf(2, "We failed to parse this key's Meta field. Key: %v, Error: %v", k.GetProvable().GetFingerprint(), err)
+		return metaparse.KeyMeta{}
+	}
+	if m == nil {
+		return metaparse.KeyMeta{}
+	}
+	return *m.(*metaparse.KeyMeta)
+}
+
+// KeyRevocation is the version of a key that revoked it, kept so that a later update can't take the revocation back.
+type KeyRevocation struct {
+	Fingerprint string `storm:"id"`
+	Meta        string // The meta of the key at the revocation.
+	LastUpdate  int64  // The update that revoked the key.
+}
+
+// RecordRevocations records the revocation of every revoked key in the batch, unless we already have a revocation for that key. This needs to run wherever we pull keys from the backend, before they are compiled.
+func RecordRevocations(keys []*pbstructs.Key) {
+	for k, _ := range keys {
+		if keys[k] == nil || !readKeyMeta(keys[k]).Revoked {
+			continue
+		}
+		fp := keys[k].GetProvable().GetFingerprint()
+		rev := KeyRevocation{}
+		if err := globals.KvInstance.One("Fingerprint", fp, &rev); err == nil {
+			continue
+		}
+		rev = KeyRevocation{Fingerprint: fp, Meta: keys[k].GetMeta(), LastUpdate: keys[k].GetUpdateable().GetLastUpdate()}
+		err2 := globals.KvInstance.Save(&rev)
+		if err2 != nil {
+			logging.Logf(1, "We could not record the revocation of this key. Key: %v, Error: %v", fp, err2)
+		}
+	}
+}
+
+// keyMeta returns the meta of the key, or of the version of it that revoked it, if we have recorded a revocation for it.
+func keyMeta(k *pbstructs.Key) metaparse.KeyMeta {
+	if k == nil {
+		return metaparse.KeyMeta{}
+	}
+	rev := KeyRevocation{}
+	err := globals.KvInstance.One("Fingerprint", k.GetProvable().GetFingerprint(), &rev)
+	if err != nil {
+		return readKeyMeta(k)
+	}
+	rm, err2 := metaparse.ReadMeta("Key", rev.Meta)
+	if err2 != nil || rm == nil {
+		logging.Logf(1, "We failed to parse the recorded revocation of this key. Key: %v, Error: %v", rev.Fingerprint, err2)
+		return metaparse.KeyMeta{Revoked: true}
+	}
+	return *rm.(*metaparse.KeyMeta)
+}
+
+// IsValidSuccession returns whether the new key is a valid successor of the old key.
+func IsValidSuccession(oldKey, newKey *pbstructs.Key) bool {
+	if oldKey == nil || newKey == nil {
+		return false
+	}
+	om := keyMeta(oldKey)
+	nm := keyMeta(newKey)
+	if om.Successor != newKey.GetProvable().GetFingerprint() ||
+		nm.Predecessor != oldKey.GetProvable().GetFingerprint() {
+		return false
+	}
+	if !signaturing.Verify(newKey.GetKey(), nm.PredecessorSignature, oldKey.GetKey()) {
+		return false
+	}
+	if om.Revoked && newKey.GetProvable().GetCreation() > om.RevokedAt {
+		return false
+	}
+	return true
+}
+
+// KeyCache holds the newest version of the keys we have pulled for resolving succession chains in one refresh, so that a key shared by many chains is only asked from the backend once.
+type KeyCache struct {
+	keys  map[string]*pbstructs.Key
+	nowts int64
+}
+
+// NewKeyCache pulls the given keys from the backend in one request.
+func NewKeyCache(fps []string, nowts int64) KeyCache {
+	c := KeyCache{keys: make(map[string]*pbstructs.Key), nowts: nowts}
+	if len(fps) > 0 {
+		// An empty fingerprints list would pull every key.
+		c.insert(beapiconsumer.GetKeys(0, nowts, fps, true, false))
+	}
+	return c
+}
+
+func (c *KeyCache) insert(keys []*pbstructs.Key) {
+	RecordRevocations(keys)
+	for k, _ := range keys {
+		if keys[k] == nil {
+			continue
+		}
+		fp := keys[k].GetProvable().GetFingerprint()
+		if c.keys[fp] == nil || keys[k].GetUpdateable().GetLastUpdate() > c.keys[fp].GetUpdateable().GetLastUpdate() {
+			c.keys[fp] = keys[k]
+		}
+	}
+}
+
+// get returns the newest version of the key with the given fingerprint, or nil. Keys that weren't pulled in the batch are asked from the backend one by one, and a miss is remembered, too.
+func (c *KeyCache) get(fp string) *pbstructs.Key {
+	if c.keys == nil {
+		c.keys = make(map[string]*pbstructs.Key)
+	}
+	if k, ok := c.keys[fp]; ok {
+		return k
+	}
+	c.keys[fp] = nil
+	c.insert(beapiconsumer.GetKeys(0, c.nowts, []string{fp}, true, false))
+	return c.keys[fp]
+}
+
+// ResolveSuccessionChain returns the fingerprints of every key in the succession chain the given key is a part of, oldest first. If the key has never been rotated, the chain is only the key itself.
+func ResolveSuccessionChain(fp string, keys *KeyCache) []string {
+	chain := []string{fp}
+	self := keys.get(fp)
+	if self == nil {
+		return chain
+	}
+	seen := map[string]bool{fp: true}
+	// Walk back to the oldest predecessor.
+	cur := self
+	for len(chain) < maxSuccessionChainLength {
+		pfp := keyMeta(cur).Predecessor
+		if len(pfp) == 0 || seen[pfp] {
+			break
+		}
+		pred := keys.get(pfp)
+		if !IsValidSuccession(pred, cur) {
+			break
+		}
+		chain = append([]string{pfp}, chain...)
+		seen[pfp] = true
+		cur = pred
+	}
+	// Walk forward to the newest successor.
+	cur = self
+	for len(chain) < maxSuccessionChainLength {
+		sfp := keyMeta(cur).Successor
+		if len(sfp) == 0 || seen[sfp] {
+			break
+		}
+		succ := keys.get(sfp)
+		if !IsValidSuccession(cur, succ) {
+			break
+		}
+		chain = append(chain, sfp)
+		seen[sfp] = true
+		cur = succ
+	}
+	return chain
+}
+
+func sameChain(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, _ := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
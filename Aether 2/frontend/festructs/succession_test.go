@@ -0,0 +1,179 @@
+package festructs_test
+
+import (
+	"aether-core/frontend/fecmd"
+	"aether-core/frontend/festructs"
+	pbstructs "aether-core/protos/mimapi"
+	"aether-core/services/globals"
+	"aether-core/services/signaturing"
+	"encoding/json"
+	"github.com/asdine/storm"
+	"golang.org/x/crypto/ed25519"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Infrastructure, setup and teardown
+
+var kvdir string
+
+func TestMain(m *testing.M) {
+	setup()
+	exitVal := m.Run()
+	teardown()
+	os.Exit(exitVal)
+}
+
+func setup() {
+	fecmd.EstablishConfigs(nil)
+	dir, err := ioutil.TempDir("", "festructs_test")
+	if err != nil {
+		panic(err)
+	}
+	kvdir = dir
+	kv, err2 := storm.Open(filepath.Join(kvdir, "KVStore.kv"))
+	if err2 != nil {
+		panic(err2)
+	}
+	globals.KvInstance = kv
+	festructs.InitialiseKvStore()
+}
+
+func teardown() {
+	globals.KvInstance.Close()
+	os.RemoveAll(kvdir)
+}
+
+// Helpers
+
+type keyMeta struct {
+	Successor            string `json:"successor,omitempty"`
+	Predecessor          string `json:"predecessor,omitempty"`
+	PredecessorSignature string `json:"predecessor_signature,omitempty"`
+	Revoked              bool   `json:"revoked,omitempty"`
+	RevokedAt            int64  `json:"revoked_at,omitempty"`
+}
+
+func newPrivKey(t *testing.T) *ed25519.PrivateKey {
+	pk, err := signaturing.CreateKeyPair()
+	if err != nil {
+		t.Fatalf("Key pair creation failed. Error: %v", err)
+	}
+	return pk
+}
+
+func pubKey(pk *ed25519.PrivateKey) string {
+	return signaturing.MarshalPublicKey(pk.Public().(ed25519.PublicKey))
+}
+
+func makeKey(fp string, pk *ed25519.PrivateKey, creation, lastUpdate int64, m keyMeta) *pbstructs.Key {
+	meta, _ := json.Marshal(m)
+	return &pbstructs.Key{
+		Provable:   &pbstructs.Provable{Fingerprint: fp, Creation: creation},
+		Key:        pubKey(pk),
+		Meta:       string(meta),
+		Updateable: &pbstructs.Updateable{LastUpdate: lastUpdate},
+	}
+}
+
+// makeSuccessor creates a key that names the old key as its predecessor, signed over by the old key.
+func makeSuccessor(t *testing.T, fp string, pk *ed25519.PrivateKey, creation int64, oldfp string, oldpk *ed25519.PrivateKey) *pbstructs.Key {
+	sig, err := signaturing.Sign(pubKey(pk), oldpk)
+	if err != nil {
+		t.Fatalf("Signing failed. Error: %v", err)
+	}
+	return makeKey(fp, pk, creation, 0, keyMeta{Predecessor: oldfp, PredecessorSignature: sig})
+}
+
+// Tests
+
+func TestIsValidSuccession_Success(t *testing.T) {
+	oldpk, newpk := newPrivKey(t), newPrivKey(t)
+	newKey := makeSuccessor(t, "valid-new", newpk, 100, "valid-old", oldpk)
+	oldKey := makeKey("valid-old", oldpk, 10, 150, keyMeta{Successor: "valid-new"})
+	if !festructs.IsValidSuccession(oldKey, newKey) {
+		t.Errorf("A succession both keys agree on was rejected.")
+	}
+}
+
+func TestIsValidSuccession_WrongPredecessorSignature_Fail(t *testing.T) {
+	oldpk, newpk, otherpk := newPrivKey(t), newPrivKey(t), newPrivKey(t)
+	newKey := makeSuccessor(t, "wrongsig-new", newpk, 100, "wrongsig-old", otherpk)
+	oldKey := makeKey("wrongsig-old", oldpk, 10, 150, keyMeta{Successor: "wrongsig-new"})
+	if festructs.IsValidSuccession(oldKey, newKey) {
+		t.Errorf("A succession whose predecessor signature is not by the old key was accepted.")
+	}
+}
+
+func TestIsValidSuccession_CreatedAfterRevocation_Fail(t *testing.T) {
+	oldpk, newpk := newPrivKey(t), newPrivKey(t)
+	newKey := makeSuccessor(t, "late-new", newpk, 300, "late-old", oldpk)
+	oldKey := makeKey("late-old", oldpk, 10, 200, keyMeta{Successor: "late-new", Revoked: true, RevokedAt: 200})
+	festructs.RecordRevocations([]*pbstructs.Key{oldKey})
+	if festructs.IsValidSuccession(oldKey, newKey) {
+		t.Errorf("A key created after the revocation of its predecessor was accepted as the successor.")
+	}
+}
+
+// Whoever stole the old key publishes a later update that takes the revocation back and points the successor at their own key. The revocation should stick.
+func TestIsValidSuccession_RevocationOverride_Fail(t *testing.T) {
+	oldpk, newpk, evilpk := newPrivKey(t), newPrivKey(t), newPrivKey(t)
+	newKey := makeSuccessor(t, "override-new", newpk, 100, "override-old", oldpk)
+	revoked := makeKey("override-old", oldpk, 10, 200, keyMeta{Successor: "override-new", Revoked: true, RevokedAt: 200})
+	festructs.RecordRevocations([]*pbstructs.Key{revoked})
+	if !festructs.IsValidSuccession(revoked, newKey) {
+		t.Fatalf("A succession made before the revocation was rejected.")
+	}
+	evilKey := makeSuccessor(t, "override-evil", evilpk, 300, "override-old", oldpk)
+	override := makeKey("override-old", oldpk, 10, 400, keyMeta{Successor: "override-evil"})
+	festructs.RecordRevocations([]*pbstructs.Key{override})
+	if festructs.IsValidSuccession(override, evilKey) {
+		t.Errorf("A later update to a revoked key was able to take the revocation back and name a new successor.")
+	}
+	if !festructs.IsValidSuccession(override, newKey) {
+		t.Errorf("A later update to a revoked key was able to undo the successor named at the revocation.")
+	}
+}
+
+// The thief revokes the key too, and backdates their revocation to before the real one, so that it looks like the earlier of the two. The revocation we saw first should win.
+func TestIsValidSuccession_BackdatedRevocation_Fail(t *testing.T) {
+	oldpk, newpk, evilpk := newPrivKey(t), newPrivKey(t), newPrivKey(t)
+	newKey := makeSuccessor(t, "backdated-new", newpk, 100, "backdated-old", oldpk)
+	evilKey := makeSuccessor(t, "backdated-evil", evilpk, 50, "backdated-old", oldpk)
+	realRevocation := makeKey("backdated-old", oldpk, 10, 200, keyMeta{Successor: "backdated-new", Revoked: true, RevokedAt: 200})
+	festructs.RecordRevocations([]*pbstructs.Key{realRevocation})
+	backdatedRevocation := makeKey("backdated-old", oldpk, 10, 60, keyMeta{Successor: "backdated-evil", Revoked: true, RevokedAt: 60})
+	festructs.RecordRevocations([]*pbstructs.Key{backdatedRevocation})
+	if festructs.IsValidSuccession(backdatedRevocation, evilKey) {
+		t.Errorf("A backdated revocation seen after the real one was able to name a new successor.")
+	}
+	if !festructs.IsValidSuccession(backdatedRevocation, newKey) {
+		t.Errorf("A backdated revocation seen after the real one was able to undo the real successor.")
+	}
+}
+
+// Reading a key should not record its revocation, only pulling it from the backend does.
+func TestIsValidSuccession_ReadDoesNotRecord_Success(t *testing.T) {
+	oldpk, newpk := newPrivKey(t), newPrivKey(t)
+	newKey := makeSuccessor(t, "readonly-new", newpk, 100, "readonly-old", oldpk)
+	revoked := makeKey("readonly-old", oldpk, 10, 200, keyMeta{Successor: "readonly-other", Revoked: true, RevokedAt: 200})
+	festructs.IsValidSuccession(revoked, newKey)
+	updated := makeKey("readonly-old", oldpk, 10, 400, keyMeta{Successor: "readonly-new"})
+	if !festructs.IsValidSuccession(updated, newKey) {
+		t.Errorf("Checking a succession recorded the revocation of the old key.")
+	}
+}
+
+func TestNewCUser_RevocationSticks_Success(t *testing.T) {
+	oldpk := newPrivKey(t)
+	festructs.RecordRevocations([]*pbstructs.Key{
+		makeKey("cuser-old", oldpk, 10, 200, keyMeta{Successor: "cuser-new", Revoked: true, RevokedAt: 200}),
+		makeKey("cuser-old", oldpk, 10, 400, keyMeta{Successor: "cuser-evil"}),
+	})
+	u := festructs.NewCUser(makeKey("cuser-old", oldpk, 10, 400, keyMeta{Successor: "cuser-evil"}), 1000)
+	if !u.Revoked || u.RevokedAt != 200 || u.Successor != "cuser-new" {
+		t.Errorf("The compiled user does not show the revocation. Revoked: %v, RevokedAt: %v, Successor: %v", u.Revoked, u.RevokedAt, u.Successor)
+	}
+}
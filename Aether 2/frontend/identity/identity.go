@@ -0,0 +1,272 @@
+// Frontend > Identity
+// This package exports the local user's identity to a portable, encrypted file, and imports it back on another install.
+
+package identity
+
+import (
+	"aether-core/frontend/inflights"
+	"aether-core/io/api"
+	pbstructs "aether-core/protos/mimapi"
+	"aether-core/services/configstore"
+	"aether-core/services/globals"
+	"aether-core/services/keystore"
+	"aether-core/services/logging"
+	"aether-core/services/signaturing"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/ed25519"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+/*
+An identity backup is everything that makes a user 'them' on a new install: the private key, the key entity (the username and info), who they follow, block and made mod, and what they're subscribed to. It does not carry any content, that comes from the network.
+
+The whole backup is sealed with a passphrase the user chooses at export, in the same way as the keystore (see services/keystore), and the file is written only readable by the user. The passphrase is independent from the keystore passphrase of either install.
+*/
+
+const (
+	IDENTITY_BACKUP_VERSION = 1
+)
+
+// Conflict resolutions, for when the install we're importing into already has a user that is not the one in the backup.
+const (
+	ConflictFail        = "fail"    // Refuse to import. This is the default.
+	ConflictReplace     = "replace" // The imported user replaces the current one. The current one is backed up next to the config first.
+	ConflictKeepCurrent = "keep"    // Keep the current user, only bring in the follows, blocks and subscriptions.
+)
+
+type backup struct {
+	BackupVersion                int
+	ExportedAt                   int64
+	UserKeyPair                  string // Plain hex, the whole backup is sealed.
+	MarshaledUserPublicKey       string
+	DehydratedLocalUserKeyEntity string
+	Following                    configstore.BatchUser
+	Blocked                      configstore.BatchUser
+	ModElected                   configstore.BatchUser
+	ModDisqualified              configstore.BatchUser
+	SubbedBoards                 []configstore.Board
+	SubbedThreads                []configstore.Thread
+}
+
+// envelope is what actually goes into the file.
+type envelope struct {
+	IdentityBackupVersion int    `json:"identity_backup_version"`
+	Sealed                string `json:"sealed"`
+}
+
+type ImportResult struct {
+	Fingerprint        string // The fingerprint of the user that is now the local user.
+	Conflict           bool   // The install had a different user.
+	Replaced           bool   // ... and it was replaced.
+	ReplacedBackupPath string // Where the replaced user was backed up to.
+}
+
+func localUserFingerprint() string {
+	alu := globals.FrontendConfig.GetDehydratedLocalUserKeyEntity()
+	if len(alu) == 0 {
+		return ""
+	}
+	var key api.Key
+	json.Unmarshal([]byte(alu), &key)
+	return string(key.Fingerprint)
+}
+
+func currentBackup() backup {
+	ur := globals.FrontendConfig.GetUserRelations()
+	cr := globals.FrontendConfig.GetContentRelations()
+	return backup{
+		BackupVersion:                IDENTITY_BACKUP_VERSION,
+		ExportedAt:                   time.Now().Unix(),
+		UserKeyPair:                  signaturing.MarshalPrivateKey(*globals.FrontendConfig.GetUserKeyPair()),
+		MarshaledUserPublicKey:       globals.FrontendConfig.GetMarshaledUserPublicKey(),
+		DehydratedLocalUserKeyEntity: globals.FrontendConfig.GetDehydratedLocalUserKeyEntity(),
+		Following:                    ur.Following,
+		Blocked:                      ur.Blocked,
+		ModElected:                   ur.ModElected,
+		ModDisqualified:              ur.ModDisqualified,
+		SubbedBoards:                 cr.SubbedBoards,
+		SubbedThreads:                cr.SubbedThreads,
+	}
+}
+
+func writeBackup(b backup, path, passphrase string) error {
+	bJson, err := json.Marshal(b)
+	if err != nil {
+		return errors.New(fmt.Sprintf("The identity could not be converted to JSON. Error: %v", err))
+	}
+	sealed, err2 := keystore.Seal(string(bJson), passphrase)
+	if err2 != nil {
+		return err2
+	}
+	eJson, err3 := json.MarshalIndent(envelope{IdentityBackupVersion: IDENTITY_BACKUP_VERSION, Sealed: sealed}, "", "    ")
+	if err3 != nil {
+		return err3
+	}
+	f, err4 := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err4 != nil {
+		return errors.New(fmt.Sprintf("The identity backup file could not be created. Path: %s, Error: %v", path, err4))
+	}
+	defer f.Close()
+	_, err5 := f.Write(eJson)
+	return err5
+}
+
+func readBackup(path, passphrase string) (backup, error) {
+	var b backup
+	eJson, err := ioutil.ReadFile(path)
+	if err != nil {
+		return b, errors.New(fmt.Sprintf("The identity backup file could not be read. Path: %s, Error: %v", path, err))
+	}
+	var e envelope
+	err2 := json.Unmarshal(eJson, &e)
+	if err2 != nil {
+		return b, errors.New(fmt.Sprintf("This is not an identity backup file. Path: %s, Error: %v", path, err2))
+	}
+	if e.IdentityBackupVersion != IDENTITY_BACKUP_VERSION {
+		return b, errors.New(fmt.Sprintf("This version of the identity backup is not supported. Version: %d", e.IdentityBackupVersion))
+	}
+	bJson, err3 := keystore.Open(e.Sealed, passphrase)
+	if err3 != nil {
+		return b, err3
+	}
+	err4 := json.Unmarshal([]byte(bJson), &b)
+	if err4 != nil {
+		return b, errors.New(fmt.Sprintf("The identity backup is malformed. Error: %v", err4))
+	}
+	return b, nil
+}
+
+// verify checks that the parts of the backup belong together: the private key matches the public key, and the key entity is the entity of that public key.
+func (b *backup) verify() (ed25519.PrivateKey, error) {
+	privKey, err := signaturing.UnmarshalPrivateKey(b.UserKeyPair)
+	if err != nil || len(privKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("The private key in the identity backup is malformed.")
+	}
+	if signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey)) != b.MarshaledUserPublicKey {
+		return nil, errors.New("The private key in the identity backup does not match its public key.")
+	}
+	if len(b.DehydratedLocalUserKeyEntity) > 0 {
+		var key api.Key
+		err2 := json.Unmarshal([]byte(b.DehydratedLocalUserKeyEntity), &key)
+		if err2 != nil {
+			return nil, errors.New(fmt.Sprintf("The key entity in the identity backup is malformed. Error: %v", err2))
+		}
+		if key.Key != b.MarshaledUserPublicKey {
+			return nil, errors.New("The key entity in the identity backup belongs to a different key.")
+		}
+	}
+	return privKey, nil
+}
+
+func (b *backup) fingerprint() string {
+	if len(b.DehydratedLocalUserKeyEntity) == 0 {
+		return ""
+	}
+	var key api.Key
+	json.Unmarshal([]byte(b.DehydratedLocalUserKeyEntity), &key)
+	return string(key.Fingerprint)
+}
+
+// Export writes the local user's identity to the given path, sealed with the passphrase. It refuses to overwrite an existing file.
+func Export(path, passphrase string) error {
+	if len(globals.FrontendConfig.GetDehydratedLocalUserKeyEntity()) == 0 {
+		return errors.New("The local user does not have a key entity yet, there is no identity to export.")
+	}
+	err := writeBackup(currentBackup(), path, passphrase)
+	if err != nil {
+		return err
+	}
+	logging.Logf(1, "The local user identity has been exported. Path: %s", path)
+	return nil
+}
+
+// Import reads the identity at the given path and makes it the local user's identity. If this install already has a different user, the conflict resolution decides what happens (see the constants above).
+func Import(path, passphrase, resolution string) (ImportResult, error) {
+	var res ImportResult
+	if len(resolution) == 0 {
+		resolution = ConflictFail
+	}
+	if resolution != ConflictFail && resolution != ConflictReplace && resolution != ConflictKeepCurrent {
+		return res, errors.New(fmt.Sprintf("Unknown conflict resolution. You gave: %s", resolution))
+	}
+	b, err := readBackup(path, passphrase)
+	if err != nil {
+		return res, err
+	}
+	privKey, err2 := b.verify()
+	if err2 != nil {
+		return res, err2
+	}
+	currentFp := localUserFingerprint()
+	backupFp := b.fingerprint()
+	res.Conflict = len(currentFp) > 0 && currentFp != backupFp
+	if res.Conflict && resolution == ConflictFail {
+		return res, errors.New(fmt.Sprintf("This install already has a different user. Current user: %s, User in the backup: %s", currentFp, backupFp))
+	}
+	if !res.Conflict || resolution == ConflictReplace {
+		if res.Conflict {
+			// Never throw away a user. Back the current one up before we replace it.
+			res.ReplacedBackupPath = filepath.Join(filepath.Dir(globals.GetFrontendConfigLocation()), fmt.Sprintf("replaced_identity_%s_%d.mimidentity", currentFp, time.Now().Unix()))
+			err3 := writeBackup(currentBackup(), res.ReplacedBackupPath, passphrase)
+			if err3 != nil {
+				return res, errors.New(fmt.Sprintf("The current user could not be backed up, so it was not replaced. Error: %v", err3))
+			}
+			res.Replaced = true
+		}
+		globals.FrontendConfig.SetUserKeyPair(&privKey)
+		globals.FrontendConfig.SetMarshaledUserPublicKey(&privKey)
+		globals.FrontendConfig.SetDehydratedLocalUserKeyEntity(b.DehydratedLocalUserKeyEntity)
+	}
+	// Follows, blocks and subscriptions are merged in every case, so that nothing the user had on this install is lost.
+	ur := globals.FrontendConfig.GetUserRelations()
+	for _, u := range b.Following {
+		ur.Following.Insert(u.Fingerprint, u.Domain)
+	}
+	for _, u := range b.Blocked {
+		ur.Blocked.Insert(u.Fingerprint, u.Domain)
+	}
+	for _, u := range b.ModElected {
+		ur.ModElected.Insert(u.Fingerprint, u.Domain)
+	}
+	for _, u := range b.ModDisqualified {
+		ur.ModDisqualified.Insert(u.Fingerprint, u.Domain)
+	}
+	globals.FrontendConfig.SetUserRelations(ur)
+	cr := globals.FrontendConfig.GetContentRelations()
+	for _, sb := range b.SubbedBoards {
+		cr.SetBoardSignal(sb.Fingerprint, true, sb.Notify, sb.LastSeen, false)
+	}
+	for _, st := range b.SubbedThreads {
+		if cr.FindThread(st.Fingerprint) == -1 {
+			cr.SubbedThreads = append(cr.SubbedThreads, st)
+		}
+	}
+	globals.FrontendConfig.SetContentRelations(cr)
+	res.Fingerprint = localUserFingerprint()
+	logging.Logf(1, "An identity has been imported. Path: %s, Local user: %s, Conflict: %v, Replaced: %v", path, res.Fingerprint, res.Conflict, res.Replaced)
+	return res, nil
+}
+
+// PushLocalUserKey sends the local user's key entity to the backend. The backend might not have it if the identity was imported while the frontend was not running, or if the backend is new. This is safe to call any time, the backend only keeps the newest version of a key.
+func PushLocalUserKey() {
+	alu := globals.FrontendConfig.GetDehydratedLocalUserKeyEntity()
+	if len(alu) == 0 {
+		return
+	}
+	var key api.Key
+	err := json.Unmarshal([]byte(alu), &key)
+	if err != nil {
+		logging.Logf(1, "The local user key entity could not be read. Error: %v", err)
+		return
+	}
+	kp := key.Protobuf()
+	statusCode := inflights.SendToBackend([]*pbstructs.Key{&kp})
+	if statusCode != 200 {
+		logging.Logf(1, "The local user key could not be pushed to the backend. Status code: %v", statusCode)
+	}
+}
@@ -0,0 +1,254 @@
+package identity_test
+
+import (
+	"aether-core/frontend/fecmd"
+	"aether-core/frontend/identity"
+	"aether-core/io/api"
+	"aether-core/services/configstore"
+	"aether-core/services/globals"
+	"aether-core/services/signaturing"
+	"encoding/json"
+	"fmt"
+	"golang.org/x/crypto/ed25519"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Infrastructure, setup and teardown
+
+var testDir string
+
+func TestMain(m *testing.M) {
+	setup()
+	exitVal := m.Run()
+	teardown()
+	os.Exit(exitVal)
+}
+
+func setup() {
+	fecmd.EstablishConfigs(nil)
+	globals.FrontendTransientConfig.PermConfigReadOnly = true
+	dir, err := ioutil.TempDir("", "identity_test")
+	if err != nil {
+		panic(err)
+	}
+	testDir = dir
+}
+
+func teardown() {
+	os.RemoveAll(testDir)
+}
+
+// Helpers
+
+const passphrase = "correct horse battery staple"
+
+// setLocalUser makes a new user the local user, following only the given user. It returns the fingerprint of the new user.
+func setLocalUser(t *testing.T, name, follows string) string {
+	privKey, err := signaturing.CreateKeyPair()
+	if err != nil {
+		t.Fatalf("Key pair creation failed. Error: %v", err)
+	}
+	pk := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
+	fp := fmt.Sprintf("%s-%s", name, pk[:16])
+	key := api.Key{}
+	key.Fingerprint = api.Fingerprint(fp)
+	key.Key = pk
+	key.Name = name
+	keyJson, _ := json.Marshal(key)
+	globals.FrontendConfig.SetUserKeyPair(privKey)
+	globals.FrontendConfig.SetMarshaledUserPublicKey(privKey)
+	globals.FrontendConfig.SetDehydratedLocalUserKeyEntity(string(keyJson))
+	// A new user starts with none of the relations of the previous one.
+	ur := globals.FrontendConfig.GetUserRelations()
+	ur.Following = configstore.BatchUser{}
+	ur.Blocked = configstore.BatchUser{}
+	ur.ModElected = configstore.BatchUser{}
+	ur.ModDisqualified = configstore.BatchUser{}
+	ur.Following.Insert(follows, "")
+	globals.FrontendConfig.SetUserRelations(ur)
+	return fp
+}
+
+// clearLocalUser leaves the install without a user, as a new install is.
+func clearLocalUser() {
+	globals.FrontendConfig.SetDehydratedLocalUserKeyEntity("")
+}
+
+func backupPath(name string) string {
+	return filepath.Join(testDir, name+".mimidentity")
+}
+
+func export(t *testing.T, name string) string {
+	path := backupPath(name)
+	err := identity.Export(path, passphrase)
+	if err != nil {
+		t.Fatalf("Export failed. Error: %v", err)
+	}
+	return path
+}
+
+func localUserFingerprint() string {
+	var key api.Key
+	json.Unmarshal([]byte(globals.FrontendConfig.GetDehydratedLocalUserKeyEntity()), &key)
+	return string(key.Fingerprint)
+}
+
+func isFollowing(fp string) bool {
+	ur := globals.FrontendConfig.GetUserRelations()
+	return ur.Following.Find(fp, "") != -1
+}
+
+// Tests
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	fp := setLocalUser(t, "roundtrip", "roundtrip-followed")
+	pk := globals.FrontendConfig.GetMarshaledUserPublicKey()
+	cr := globals.FrontendConfig.GetContentRelations()
+	cr.SetBoardSignal("roundtrip-board", true, false, 0, false)
+	globals.FrontendConfig.SetContentRelations(cr)
+	path := export(t, "roundtrip")
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("The backup file is not readable only by the user. Error: %v", err)
+	}
+	clearLocalUser()
+	res, err2 := identity.Import(path, passphrase, "")
+	if err2 != nil {
+		t.Fatalf("Import failed. Error: %v", err2)
+	}
+	if res.Fingerprint != fp || res.Conflict || res.Replaced {
+		t.Errorf("The import result is not the expected one. Result: %#v", res)
+	}
+	if globals.FrontendConfig.GetMarshaledUserPublicKey() != pk {
+		t.Errorf("The imported user does not have the exported key.")
+	}
+	if signaturing.MarshalPublicKey(globals.FrontendConfig.GetUserKeyPair().Public().(ed25519.PublicKey)) != pk {
+		t.Errorf("The imported private key does not match the exported public key.")
+	}
+	if !isFollowing("roundtrip-followed") {
+		t.Errorf("The follows of the exported user were not imported.")
+	}
+	cr2 := globals.FrontendConfig.GetContentRelations()
+	if cr2.FindBoard("roundtrip-board") == -1 {
+		t.Errorf("The subscriptions of the exported user were not imported.")
+	}
+}
+
+func TestExport_DoesNotOverwrite(t *testing.T) {
+	setLocalUser(t, "overwrite", "overwrite-followed")
+	path := export(t, "overwrite")
+	if identity.Export(path, passphrase) == nil {
+		t.Errorf("Export overwrote an existing file.")
+	}
+}
+
+func TestImport_WrongPassphrase(t *testing.T) {
+	setLocalUser(t, "wrongpass", "wrongpass-followed")
+	path := export(t, "wrongpass")
+	clearLocalUser()
+	_, err := identity.Import(path, "not the passphrase", "")
+	if err == nil {
+		t.Errorf("A backup was imported with the wrong passphrase.")
+	}
+	if len(globals.FrontendConfig.GetDehydratedLocalUserKeyEntity()) != 0 {
+		t.Errorf("A failed import changed the local user.")
+	}
+}
+
+func TestImport_CorruptedFile(t *testing.T) {
+	setLocalUser(t, "corrupted", "corrupted-followed")
+	path := export(t, "corrupted")
+	clearLocalUser()
+	original, _ := ioutil.ReadFile(path)
+	var e map[string]interface{}
+	json.Unmarshal(original, &e)
+	sealed := e["sealed"].(string)
+	tampered := []byte(sealed)
+	tampered[len(tampered)/2] = tampered[len(tampered)/2] ^ 1
+	e["sealed"] = string(tampered)
+	tamperedJson, _ := json.Marshal(e)
+	cases := map[string][]byte{
+		"truncated": original[:len(original)/2],
+		"garbage":   []byte("this is not an identity backup"),
+		"empty":     []byte{},
+		"tampered":  tamperedJson,
+	}
+	for name, content := range cases {
+		p := backupPath("corrupted-" + name)
+		ioutil.WriteFile(p, content, 0600)
+		if _, err := identity.Import(p, passphrase, ""); err == nil {
+			t.Errorf("A %s backup was imported.", name)
+		}
+	}
+	if _, err := identity.Import(backupPath("corrupted-nonexistent"), passphrase, ""); err == nil {
+		t.Errorf("A backup that does not exist was imported.")
+	}
+	if len(globals.FrontendConfig.GetDehydratedLocalUserKeyEntity()) != 0 {
+		t.Errorf("A failed import changed the local user.")
+	}
+}
+
+func TestImport_UnknownResolution(t *testing.T) {
+	setLocalUser(t, "unknownres", "unknownres-followed")
+	path := export(t, "unknownres")
+	if _, err := identity.Import(path, passphrase, "merge"); err == nil {
+		t.Errorf("An unknown conflict resolution was accepted.")
+	}
+}
+
+func TestImport_ConflictFail(t *testing.T) {
+	setLocalUser(t, "conflictfail-a", "conflictfail-a-followed")
+	path := export(t, "conflictfail")
+	current := setLocalUser(t, "conflictfail-b", "conflictfail-b-followed")
+	for _, resolution := range []string{"", identity.ConflictFail} {
+		res, err := identity.Import(path, passphrase, resolution)
+		if err == nil || !res.Conflict {
+			t.Errorf("An import over a different user did not fail. Resolution: '%s', Result: %#v", resolution, res)
+		}
+	}
+	if fp := localUserFingerprint(); fp != current {
+		t.Errorf("A failed import changed the local user. Expected: %s, Got: %s", current, fp)
+	}
+	if isFollowing("conflictfail-a-followed") {
+		t.Errorf("A failed import brought in the follows.")
+	}
+}
+
+func TestImport_ConflictKeepCurrent(t *testing.T) {
+	setLocalUser(t, "conflictkeep-a", "conflictkeep-a-followed")
+	path := export(t, "conflictkeep")
+	current := setLocalUser(t, "conflictkeep-b", "conflictkeep-b-followed")
+	res, err := identity.Import(path, passphrase, identity.ConflictKeepCurrent)
+	if err != nil {
+		t.Fatalf("Import failed. Error: %v", err)
+	}
+	if !res.Conflict || res.Replaced || res.Fingerprint != current {
+		t.Errorf("The import result is not the expected one. Result: %#v", res)
+	}
+	if !isFollowing("conflictkeep-a-followed") || !isFollowing("conflictkeep-b-followed") {
+		t.Errorf("The follows of both users should be kept.")
+	}
+}
+
+func TestImport_ConflictReplace(t *testing.T) {
+	imported := setLocalUser(t, "conflictreplace-a", "conflictreplace-a-followed")
+	path := export(t, "conflictreplace")
+	setLocalUser(t, "conflictreplace-b", "conflictreplace-b-followed")
+	res, err := identity.Import(path, passphrase, identity.ConflictReplace)
+	if err != nil {
+		t.Fatalf("Import failed. Error: %v", err)
+	}
+	defer os.Remove(res.ReplacedBackupPath)
+	if !res.Conflict || !res.Replaced || res.Fingerprint != imported {
+		t.Errorf("The import result is not the expected one. Result: %#v", res)
+	}
+	if _, err2 := os.Stat(res.ReplacedBackupPath); err2 != nil {
+		t.Errorf("The replaced user was not backed up. Error: %v", err2)
+	}
+	if !isFollowing("conflictreplace-a-followed") || !isFollowing("conflictreplace-b-followed") {
+		t.Errorf("The follows of both users should be kept.")
+	}
+}
@@ -0,0 +1,166 @@
+// Frontend > Inflights > Drafts
+
+// This file keeps the threads and posts the user is still writing, so that they survive the app closing.
+
+package inflights
+
+import (
+	"aether-core/io/api"
+	"aether-core/protos/feapi"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/*
+What is a draft?
+
+A draft is a thread or post that isn't sent yet. The client autosaves what's in the composer through SaveDraft, and it's kept in the KV store until the content is sent (the ContentEventPayload carries the draft's id) or the draft is deleted.
+
+There is one draft per place content can be written in: a new thread in a board, a reply to a post or to the thread itself, an edit of a thread or post. The id is made from that place, so that saving again from the same composer overwrites the same draft, and reopening the composer finds it.
+
+Drafts aren't minted, and they're never sent anywhere. They're not inflights: an inflight is content that is going out. When the user schedules content for later, that's an inflight that waits, not a draft. See ReleaseScheduled.
+*/
+
+const (
+	// The length of the names and bodies of drafts is capped at what the network accepts. The name limit is the thread's.
+	maxDraftNameLength = api.MAX_THREAD_NAME_V1
+	maxDraftBodyLength = api.MAX_POST_BODY_V1
+	maxDraftLinkLength = api.MAX_THREAD_LINK_V1
+	// How many drafts the user can have. Autosave shouldn't be able to fill the KV store.
+	maxDrafts = 1000
+)
+
+type Draft struct {
+	Id               string `storm:"id"`
+	EntityType       string // thread, post
+	Board            string `storm:"index"`
+	Thread           string `storm:"index"`
+	Parent           string
+	PriorFingerprint string // If this is a draft of an edit.
+	Name             string
+	Body             string
+	Link             string
+	Meta             string
+	Creation         int64
+	LastSaved        int64
+}
+
+// draftId is the id of the draft written at this place.
+func draftId(d *Draft) string {
+	return strings.Join([]string{d.EntityType, d.Board, d.Thread, d.Parent, d.PriorFingerprint}, ":")
+}
+
+func (d *Draft) validate() error {
+	if d.EntityType != "thread" && d.EntityType != "post" {
+		return errors.New(fmt.Sprintf("Only threads and posts can have drafts. Entity type: %s", d.EntityType))
+	}
+	if len(d.Board) == 0 {
+		return errors.New("This draft does not have a board.")
+	}
+	if d.EntityType == "post" && (len(d.Thread) == 0 || len(d.Parent) == 0) {
+		return errors.New("This post draft does not have a thread or a parent.")
+	}
+	if len(d.Name) > maxDraftNameLength || len(d.Body) > maxDraftBodyLength || len(d.Link) > maxDraftLinkLength {
+		return errors.New(fmt.Sprintf("This draft is longer than it can be sent. Name length: %d, Body length: %d, Link length: %d", len(d.Name), len(d.Body), len(d.Link)))
+	}
+	return nil
+}
+
+// SaveDraft saves the draft, over the one at the same place if there is one. It returns the draft as saved.
+func SaveDraft(d Draft) (Draft, error) {
+	if d.EntityType == "thread" {
+		// A new thread is at the board, not in any thread.
+		d.Thread = ""
+		d.Parent = ""
+	}
+	err := d.validate()
+	if err != nil {
+		return Draft{}, err
+	}
+	d.Id = draftId(&d)
+	now := time.Now().Unix()
+	d.Creation = now
+	d.LastSaved = now
+	existing := Draft{}
+	err2 := globals.KvInstance.One("Id", d.Id, &existing)
+	if err2 == nil {
+		d.Creation = existing.Creation
+	} else {
+		count, err3 := globals.KvInstance.Count(&Draft{})
+		if err3 == nil && count >= maxDrafts {
+			return Draft{}, errors.New(fmt.Sprintf("There are too many drafts. Delete some to save new ones. Max: %d", maxDrafts))
+		}
+	}
+	err4 := globals.KvInstance.Save(&d)
+	if err4 != nil {
+		return Draft{}, errors.New(fmt.Sprintf("The draft could not be saved. Error: %v", err4))
+	}
+	return d, nil
+}
+
+// GetDrafts returns the drafts in the board, or in the thread if one is given. If neither is given, it returns all of them.
+func GetDrafts(boardfp, threadfp string) []Draft {
+	drafts := []Draft{}
+	var err error
+	if len(threadfp) > 0 {
+		err = globals.KvInstance.Find("Thread", threadfp, &drafts)
+	} else if len(boardfp) > 0 {
+		err = globals.KvInstance.Find("Board", boardfp, &drafts)
+	} else {
+		err = globals.KvInstance.All(&drafts)
+	}
+	if err != nil && err.Error() != "not found" {
+		logging.Logf(1, "Getting the drafts failed. Board: %v, Thread: %v, Error: %v", boardfp, threadfp, err)
+	}
+	return drafts
+}
+
+// DeleteDraft deletes the draft. Deleting a draft that isn't there isn't an error: it's what happens when content written without a draft is sent.
+func DeleteDraft(id string) error {
+	if len(id) == 0 {
+		return nil
+	}
+	d := Draft{}
+	err := globals.KvInstance.One("Id", id, &d)
+	if err != nil {
+		return nil
+	}
+	return globals.KvInstance.DeleteStruct(&d)
+}
+
+/*----------  Protobuf conversions  ----------*/
+
+func (d *Draft) Protobuf() *feapi.Draft {
+	return &feapi.Draft{
+		Id:               d.Id,
+		EntityType:       d.EntityType,
+		Board:            d.Board,
+		Thread:           d.Thread,
+		Parent:           d.Parent,
+		PriorFingerprint: d.PriorFingerprint,
+		Name:             d.Name,
+		Body:             d.Body,
+		Link:             d.Link,
+		Meta:             d.Meta,
+		Creation:         d.Creation,
+		LastSaved:        d.LastSaved,
+	}
+}
+
+func NewDraft(d *feapi.Draft) Draft {
+	return Draft{
+		EntityType:       d.GetEntityType(),
+		Board:            d.GetBoard(),
+		Thread:           d.GetThread(),
+		Parent:           d.GetParent(),
+		PriorFingerprint: d.GetPriorFingerprint(),
+		Name:             d.GetName(),
+		Body:             d.GetBody(),
+		Link:             d.GetLink(),
+		Meta:             d.GetMeta(),
+	}
+}
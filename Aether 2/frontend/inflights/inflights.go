@@ -130,6 +130,7 @@ type InflightStatus struct {
 	RequestedTimestamp  int64 // We grab the oldest requested to start the process
 	LastActionTimestamp int64
 	EventType           string
+	ScheduledFor        int64 // If STATUS_SCHEDULED, when it's released into the queue.
 }
 
 func (s *InflightStatus) Fulfilled() bool {
@@ -140,6 +141,7 @@ func (s *InflightStatus) Fulfilled() bool {
 }
 
 const (
+	STATUS_SCHEDULED                 = "Scheduled to be posted later"
 	STATUS_WAITING                   = "Waiting for processing"
 	STATUS_MINTING                   = "Minting proof-of-work for the entity..."
 	STATUS_ADDING_TO_BACKEND         = "Adding to the local backend"
@@ -179,6 +181,20 @@ func (o *InflightStatus) Update(status string) {
 	o.setCompletionPercent()
 }
 
+// schedule holds the entity until the given time, if it's in the future. The ingestor only takes the waiting ones, so a scheduled entity stays where it is, saved with the rest of the inflights, until ReleaseScheduled makes it waiting.
+func (o *InflightStatus) schedule(ts int64) {
+	if ts <= time.Now().Unix() {
+		return
+	}
+	o.ScheduledFor = ts
+	o.Update(STATUS_SCHEDULED)
+}
+
+// due returns whether this is a scheduled entity whose time has come.
+func (o *InflightStatus) due(now int64) bool {
+	return o.StatusText == STATUS_SCHEDULED && o.ScheduledFor <= now
+}
+
 func (o *InflightStatus) setCompletionPercent() {
 	if o.StatusText == STATUS_FAILED {
 		o.CompletionPercent = -1
@@ -278,6 +294,7 @@ func (o *inflights) Insert(input interface{}) {
 		}
 		if i.GetThreadData() != nil {
 			ifObj := createInflightThread(&i)
+			ifObj.Status.schedule(i.GetScheduledFor())
 			o.InflightThreads = append(o.InflightThreads, ifObj)
 			o.commit()
 			go o.Ingest()
@@ -285,6 +302,7 @@ func (o *inflights) Insert(input interface{}) {
 		}
 		if i.GetPostData() != nil {
 			ifObj := createInflightPost(&i)
+			ifObj.Status.schedule(i.GetScheduledFor())
 			o.InflightPosts = append(o.InflightPosts, ifObj)
 			o.commit()
 			go o.Ingest()
@@ -699,6 +717,36 @@ func createInflightTruststate(i *feapi.SignalEventPayload) InflightTruststate {
 	}
 }
 
+/*----------  Release the scheduled items  ----------*/
+
+// ReleaseScheduled puts the scheduled threads and posts whose time has come into the queue, and starts the ingest for them. They're minted and sent then, not when they were scheduled, so their creation is the time they go out. If the app was closed at the scheduled time, they go out the first time this runs after it's opened again.
+func (o *inflights) ReleaseScheduled() {
+	o.lock.Lock()
+	now := time.Now().Unix()
+	released := 0
+	for k, _ := range o.InflightThreads {
+		if o.InflightThreads[k].Status.due(now) {
+			o.InflightThreads[k].Status.Update(STATUS_WAITING)
+			released++
+		}
+	}
+	for k, _ := range o.InflightPosts {
+		if o.InflightPosts[k].Status.due(now) {
+			o.InflightPosts[k].Status.Update(STATUS_WAITING)
+			released++
+		}
+	}
+	if released == 0 {
+		o.lock.Unlock()
+		return
+	}
+	o.commit()
+	o.lock.Unlock()
+	logging.Logf(1, "Released %d scheduled items into the inflights queue.", released)
+	o.PushChangesToClient()
+	go o.Ingest()
+}
+
 /*----------  Push inflight changes to client  ----------*/
 
 func (o *inflights) PushChangesToClient() {
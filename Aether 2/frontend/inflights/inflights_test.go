@@ -0,0 +1,330 @@
+// Unlike others, this test package is not named inflights_test because we need access to the queue internals, and to keep the ingestor from minting what the tests put into the queue.
+
+package inflights
+
+import (
+	"aether-core/frontend/festructs"
+	"aether-core/protos/feapi"
+	beObj "aether-core/protos/mimapi"
+	"aether-core/services/configstore"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"fmt"
+	"github.com/asdine/storm"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Infrastructure, setup and teardown
+
+var kvdir string
+
+func TestMain(m *testing.M) {
+	setup()
+	exitVal := m.Run()
+	teardown()
+	os.Exit(exitVal)
+}
+
+func setup() {
+	// Not through fecmd, which imports this package.
+	globals.FrontendTransientConfig = &configstore.Ftc
+	globals.FrontendTransientConfig.SetDefaults()
+	fecfg, err0 := configstore.EstablishFrontendConfig()
+	if err0 != nil {
+		logging.LogCrash(err0)
+	}
+	fecfg.Cycle()
+	globals.FrontendConfig = fecfg
+	globals.FrontendTransientConfig.PermConfigReadOnly = true
+	dir, err := ioutil.TempDir("", "inflights_test")
+	if err != nil {
+		panic(err)
+	}
+	kvdir = dir
+	kv, err2 := storm.Open(filepath.Join(kvdir, "KVStore.kv"))
+	if err2 != nil {
+		panic(err2)
+	}
+	globals.KvInstance = kv
+	festructs.InitialiseKvStore()
+}
+
+func teardown() {
+	globals.KvInstance.Close()
+	os.RemoveAll(kvdir)
+}
+
+// Helpers
+
+// newQueue is an inflights queue whose ingestor counts as already running, so that what the tests insert stays in the queue, and isn't minted.
+func newQueue() *inflights {
+	return &inflights{ingestRunning: true}
+}
+
+func threadPayload(name string, scheduledFor int64) feapi.ContentEventPayload {
+	return feapi.ContentEventPayload{
+		Event:        &feapi.Event{OwnerFingerprint: "owner", EventType: feapi.EventType_CREATE},
+		ThreadData:   &beObj.Thread{Board: "board", Name: name},
+		ScheduledFor: scheduledFor,
+	}
+}
+
+func postPayload(body string, scheduledFor int64) feapi.ContentEventPayload {
+	return feapi.ContentEventPayload{
+		Event:        &feapi.Event{OwnerFingerprint: "owner", EventType: feapi.EventType_CREATE},
+		PostData:     &beObj.Post{Board: "board", Thread: "thread", Parent: "thread", Body: body},
+		ScheduledFor: scheduledFor,
+	}
+}
+
+func scheduledStatus(ts int64) InflightStatus {
+	st := NewInflightStatus(STATUS_WAITING, "CREATE")
+	st.RequestedTimestamp = time.Now().Unix() - 10
+	st.ScheduledFor = ts
+	st.Update(STATUS_SCHEDULED)
+	return st
+}
+
+func waitingStatus() InflightStatus {
+	st := NewInflightStatus(STATUS_WAITING, "CREATE")
+	st.RequestedTimestamp = time.Now().Unix() - 10
+	return st
+}
+
+// Tests
+
+func TestSaveDraft_OverwritesAtSamePlace(t *testing.T) {
+	d := Draft{EntityType: "post", Board: "overwriteboard", Thread: "overwritethread", Parent: "overwriteparent", Body: "first"}
+	first, err := SaveDraft(d)
+	if err != nil {
+		t.Fatalf("The draft could not be saved. Error: %v", err)
+	}
+	// Move the creation of the saved draft back, so that it's told apart from the time of the second save.
+	first.Creation = 100
+	if err2 := globals.KvInstance.Save(&first); err2 != nil {
+		t.Fatalf("The draft could not be saved. Error: %v", err2)
+	}
+	d.Body = "second"
+	second, err3 := SaveDraft(d)
+	if err3 != nil {
+		t.Fatalf("The draft could not be saved again. Error: %v", err3)
+	}
+	if second.Id != first.Id {
+		t.Errorf("The draft at the same place got a new id. First: %s, Second: %s", first.Id, second.Id)
+	}
+	if second.Creation != 100 {
+		t.Errorf("Saving the draft again moved its creation. Got: %d", second.Creation)
+	}
+	if second.LastSaved < time.Now().Unix()-5 {
+		t.Errorf("Saving the draft again did not update its last save. Got: %d", second.LastSaved)
+	}
+	drafts := GetDrafts("", "overwritethread")
+	if len(drafts) != 1 || drafts[0].Body != "second" || drafts[0].Creation != 100 {
+		t.Errorf("The draft was not overwritten in place. Drafts: %#v", drafts)
+	}
+	// Another place in the same thread is another draft.
+	d.Parent = "overwriteotherparent"
+	if _, err4 := SaveDraft(d); err4 != nil {
+		t.Fatalf("The draft could not be saved. Error: %v", err4)
+	}
+	if drafts := GetDrafts("", "overwritethread"); len(drafts) != 2 {
+		t.Errorf("A draft at another place overwrote this one. Drafts: %d", len(drafts))
+	}
+}
+
+func TestSaveDraft_NewThreadIsAtTheBoard(t *testing.T) {
+	a, err := SaveDraft(Draft{EntityType: "thread", Board: "threadboard", Name: "first"})
+	if err != nil {
+		t.Fatalf("The draft could not be saved. Error: %v", err)
+	}
+	b, err2 := SaveDraft(Draft{EntityType: "thread", Board: "threadboard", Thread: "stray", Parent: "stray", Name: "second"})
+	if err2 != nil {
+		t.Fatalf("The draft could not be saved. Error: %v", err2)
+	}
+	if a.Id != b.Id || len(b.Thread) > 0 || len(b.Parent) > 0 {
+		t.Errorf("A new thread draft was not kept at its board. First: %#v, Second: %#v", a, b)
+	}
+}
+
+func TestSaveDraft_Cap(t *testing.T) {
+	existing, err := SaveDraft(Draft{EntityType: "thread", Board: "capboard", Name: "existing"})
+	if err != nil {
+		t.Fatalf("The draft could not be saved. Error: %v", err)
+	}
+	count, _ := globals.KvInstance.Count(&Draft{})
+	tx, err2 := globals.KvInstance.Begin(true)
+	if err2 != nil {
+		t.Fatalf("The transaction could not be started. Error: %v", err2)
+	}
+	fillers := []Draft{}
+	for i := count; i < maxDrafts; i++ {
+		f := Draft{Id: fmt.Sprintf("capfiller-%d", i), EntityType: "thread", Board: "capfillerboard"}
+		tx.Save(&f)
+		fillers = append(fillers, f)
+	}
+	if err3 := tx.Commit(); err3 != nil {
+		t.Fatalf("The filler drafts could not be saved. Error: %v", err3)
+	}
+	defer func() {
+		tx, _ := globals.KvInstance.Begin(true)
+		for key, _ := range fillers {
+			tx.DeleteStruct(&fillers[key])
+		}
+		tx.Commit()
+	}()
+	if _, err4 := SaveDraft(Draft{EntityType: "thread", Board: "capotherboard", Name: "new"}); err4 == nil {
+		t.Errorf("A new draft was saved over the cap.")
+	}
+	existing.Name = "existing, edited"
+	if _, err5 := SaveDraft(existing); err5 != nil {
+		t.Errorf("A draft already saved could not be saved again at the cap. Error: %v", err5)
+	}
+	if c, _ := globals.KvInstance.Count(&Draft{}); c != maxDrafts {
+		t.Errorf("The number of drafts is not at the cap. Expected: %d, Got: %d", maxDrafts, c)
+	}
+}
+
+func TestDraftValidate(t *testing.T) {
+	cases := []struct {
+		name  string
+		draft Draft
+		valid bool
+	}{
+		{"thread", Draft{EntityType: "thread", Board: "b"}, true},
+		{"post", Draft{EntityType: "post", Board: "b", Thread: "t", Parent: "p"}, true},
+		{"board", Draft{EntityType: "board", Board: "b"}, false},
+		{"no entity type", Draft{Board: "b"}, false},
+		{"no board", Draft{EntityType: "thread"}, false},
+		{"post without thread", Draft{EntityType: "post", Board: "b", Parent: "p"}, false},
+		{"post without parent", Draft{EntityType: "post", Board: "b", Thread: "t"}, false},
+		{"name at the limit", Draft{EntityType: "thread", Board: "b", Name: strings.Repeat("a", maxDraftNameLength)}, true},
+		{"name over the limit", Draft{EntityType: "thread", Board: "b", Name: strings.Repeat("a", maxDraftNameLength+1)}, false},
+		{"body at the limit", Draft{EntityType: "thread", Board: "b", Body: strings.Repeat("a", maxDraftBodyLength)}, true},
+		{"body over the limit", Draft{EntityType: "thread", Board: "b", Body: strings.Repeat("a", maxDraftBodyLength+1)}, false},
+		{"link at the limit", Draft{EntityType: "thread", Board: "b", Link: strings.Repeat("a", maxDraftLinkLength)}, true},
+		{"link over the limit", Draft{EntityType: "thread", Board: "b", Link: strings.Repeat("a", maxDraftLinkLength+1)}, false},
+	}
+	for _, c := range cases {
+		err := c.draft.validate()
+		if (err == nil) != c.valid {
+			t.Errorf("The validation of the %s case is not the expected one. Expected valid: %v, Error: %v", c.name, c.valid, err)
+		}
+	}
+	if _, err := SaveDraft(Draft{EntityType: "thread", Board: "invalidboard", Body: strings.Repeat("a", maxDraftBodyLength+1)}); err == nil {
+		t.Errorf("A draft over the limit was saved.")
+	}
+	if drafts := GetDrafts("invalidboard", ""); len(drafts) != 0 {
+		t.Errorf("A draft that failed the validation is in the KV store.")
+	}
+}
+
+func TestDeleteDraft(t *testing.T) {
+	if err := DeleteDraft("thread:nonexistentboard::::"); err != nil {
+		t.Errorf("Deleting a draft that does not exist failed. Error: %v", err)
+	}
+	if err := DeleteDraft(""); err != nil {
+		t.Errorf("Deleting a draft with no id failed. Error: %v", err)
+	}
+	d, err := SaveDraft(Draft{EntityType: "thread", Board: "deleteboard", Name: "delete"})
+	if err != nil {
+		t.Fatalf("The draft could not be saved. Error: %v", err)
+	}
+	if err2 := DeleteDraft(d.Id); err2 != nil {
+		t.Errorf("Deleting the draft failed. Error: %v", err2)
+	}
+	if drafts := GetDrafts("deleteboard", ""); len(drafts) != 0 {
+		t.Errorf("The deleted draft is still in the KV store.")
+	}
+	if err3 := DeleteDraft(d.Id); err3 != nil {
+		t.Errorf("Deleting the draft a second time failed. Error: %v", err3)
+	}
+}
+
+func TestInsert_Schedule(t *testing.T) {
+	o := newQueue()
+	now := time.Now().Unix()
+	o.Insert(threadPayload("past", now-60))
+	o.Insert(threadPayload("now", now))
+	o.Insert(threadPayload("unscheduled", 0))
+	o.Insert(threadPayload("future", now+3600))
+	o.Insert(postPayload("past", now-60))
+	o.Insert(postPayload("future", now+3600))
+	for _, th := range o.InflightThreads {
+		expected := STATUS_WAITING
+		if th.Entity.Name == "future" {
+			expected = STATUS_SCHEDULED
+		}
+		if th.Status.StatusText != expected {
+			t.Errorf("The thread scheduled for '%s' is not in the expected status. Expected: %s, Got: %s", th.Entity.Name, expected, th.Status.StatusText)
+		}
+		if expected == STATUS_WAITING && th.Status.ScheduledFor != 0 {
+			t.Errorf("The thread scheduled for '%s' kept a scheduled time. Got: %d", th.Entity.Name, th.Status.ScheduledFor)
+		}
+	}
+	for _, p := range o.InflightPosts {
+		expected := STATUS_WAITING
+		if p.Entity.Body == "future" {
+			expected = STATUS_SCHEDULED
+		}
+		if p.Status.StatusText != expected {
+			t.Errorf("The post scheduled for '%s' is not in the expected status. Expected: %s, Got: %s", p.Entity.Body, expected, p.Status.StatusText)
+		}
+	}
+}
+
+func TestReleaseScheduled_OnlyDue(t *testing.T) {
+	o := newQueue()
+	now := time.Now().Unix()
+	o.InflightThreads = []InflightThread{
+		InflightThread{Status: scheduledStatus(now - 60), Entity: beObj.Thread{Name: "due"}},
+		InflightThread{Status: scheduledStatus(now + 3600), Entity: beObj.Thread{Name: "notdue"}},
+	}
+	o.InflightPosts = []InflightPost{
+		InflightPost{Status: scheduledStatus(now - 60), Entity: beObj.Post{Body: "due"}},
+		InflightPost{Status: scheduledStatus(now + 3600), Entity: beObj.Post{Body: "notdue"}},
+	}
+	failed := NewInflightStatus(STATUS_FAILED, "CREATE")
+	o.InflightPosts = append(o.InflightPosts, InflightPost{Status: failed, Entity: beObj.Post{Body: "failed"}})
+	o.ReleaseScheduled()
+	for _, th := range o.InflightThreads {
+		expected := STATUS_SCHEDULED
+		if th.Entity.Name == "due" {
+			expected = STATUS_WAITING
+		}
+		if th.Status.StatusText != expected {
+			t.Errorf("The %s thread is not in the expected status. Expected: %s, Got: %s", th.Entity.Name, expected, th.Status.StatusText)
+		}
+	}
+	for _, p := range o.InflightPosts {
+		expected := STATUS_SCHEDULED
+		switch p.Entity.Body {
+		case "due":
+			expected = STATUS_WAITING
+		case "failed":
+			expected = STATUS_FAILED
+		}
+		if p.Status.StatusText != expected {
+			t.Errorf("The %s post is not in the expected status. Expected: %s, Got: %s", p.Entity.Body, expected, p.Status.StatusText)
+		}
+	}
+}
+
+func TestGetNextItem_SkipsScheduled(t *testing.T) {
+	o := newQueue()
+	now := time.Now().Unix()
+	o.InflightThreads = []InflightThread{InflightThread{Status: scheduledStatus(now + 3600), Entity: beObj.Thread{Name: "scheduled"}}}
+	o.InflightPosts = []InflightPost{InflightPost{Status: scheduledStatus(now - 60), Entity: beObj.Post{Body: "scheduled"}}}
+	if next := o.getNextItem(); next != nil {
+		t.Errorf("A scheduled item was taken from the queue. Item: %#v", next)
+	}
+	o.InflightPosts = append(o.InflightPosts, InflightPost{Status: waitingStatus(), Entity: beObj.Post{Body: "waiting"}})
+	next, ok := o.getNextItem().(*InflightPost)
+	if !ok || next.Entity.Body != "waiting" {
+		t.Errorf("The waiting item was not taken from the queue. Item: %#v", next)
+	}
+}
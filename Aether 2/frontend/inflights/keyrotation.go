@@ -0,0 +1,99 @@
+// Frontend > Inflights > Key Rotation
+
+// This file moves the local user to a new key, keeping their identity through a signed succession from the old key.
+
+package inflights
+
+import (
+	"aether-core/frontend/clapiconsumer"
+	"aether-core/frontend/refresher"
+	"aether-core/io/api"
+	pbstructs "aether-core/protos/mimapi"
+	"aether-core/services/create"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"aether-core/services/metaparse"
+	"aether-core/services/signaturing"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+/*
+RotateLocalUserKey creates a new key pair and a new key entity for the local user, and hands over the identity of the old key to it. The old key signs the new one, and is updated to point at it. If revoke is true, the old key is also marked compromised in the same update. (See festructs/succession.go for how the other side reads this.)
+
+This is not an inflight, because it has to happen all at once: if the new key makes it to the backend but the frontend doesn't switch to it, the user would end up posting with a key they've already left behind.
+
+Returns the fingerprint of the new key.
+*/
+func RotateLocalUserKey(revoke bool) (string, error) {
+	alu := globals.FrontendConfig.GetDehydratedLocalUserKeyEntity()
+	if len(alu) == 0 {
+		return "", errors.New("The local user does not have a key entity yet, there is nothing to rotate.")
+	}
+	var oldKey api.Key
+	err := json.Unmarshal([]byte(alu), &oldKey)
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("The local user key entity could not be read. Error: %v", err))
+	}
+	newPrivKey, err2 := signaturing.CreateKeyPair()
+	if err2 != nil {
+		return "", err2
+	}
+	/*----------  Mint the new key  ----------*/
+	newKey, err3 := create.CreateSuccessorKey(&oldKey, newPrivKey, oldKey.Name, oldKey.Info, oldKey.Expiry, oldKey.RealmId)
+	if err3 != nil {
+		return "", errors.New(fmt.Sprintf("The successor key could not be minted. Error: %v", err3))
+	}
+	err4 := api.Verify(api.Provable(&newKey))
+	if err4 != nil {
+		return "", errors.New(fmt.Sprintf("Verification of the successor key failed. Error: %v", err4))
+	}
+	/*----------  Point the old key at the new key  ----------*/
+	oldMeta := metaparse.KeyMeta{}
+	if m, _ := metaparse.ReadMeta("Key", oldKey.Meta); m != nil {
+		oldMeta = *m.(*metaparse.KeyMeta)
+	}
+	oldMeta.Successor = string(newKey.Fingerprint)
+	if revoke {
+		oldMeta.Revoked = true
+		oldMeta.RevokedAt = time.Now().Unix()
+	}
+	oldMetaStr, err5 := metaparse.CreateMetaString(&oldMeta)
+	if err5 != nil {
+		return "", err5
+	}
+	ur := create.KeyUpdateRequest{}
+	ur.Entity = &oldKey
+	ur.MetaUpdated = true
+	ur.NewMeta = oldMetaStr
+	err6 := create.UpdateKey(ur) // Signed by the old key, since we haven't switched yet.
+	if err6 != nil {
+		return "", errors.New(fmt.Sprintf("The update to the old key could not be minted. Error: %v", err6))
+	}
+	err7 := api.Verify(api.Provable(&oldKey))
+	if err7 != nil {
+		return "", errors.New(fmt.Sprintf("Verification of the old key update failed. Error: %v", err7))
+	}
+	/*----------  Send to backend  ----------*/
+	nkp := newKey.Protobuf()
+	okp := oldKey.Protobuf()
+	eps := []*pbstructs.Key{&nkp, &okp}
+	statusCode := SendToBackend(eps)
+	if statusCode != 200 {
+		return "", errors.New(fmt.Sprintf("The backend did not accept the rotated keys. Status code: %v", statusCode))
+	}
+	/*----------  Switch the frontend to the new key  ----------*/
+	kJson, err8 := json.Marshal(newKey)
+	if err8 != nil {
+		return "", errors.New(fmt.Sprintf("The successor key could not be converted to JSON. Error: %v", err8))
+	}
+	globals.FrontendConfig.SetUserKeyPair(newPrivKey)
+	globals.FrontendConfig.SetMarshaledUserPublicKey(newPrivKey)
+	globals.FrontendConfig.SetDehydratedLocalUserKeyEntity(string(kJson))
+	logging.Logf(1, "The local user key has been rotated. Old: %v, New: %v, Revoked: %v", oldKey.Fingerprint, newKey.Fingerprint, revoke)
+	refresher.RefreshGlobalUserHeaders(eps, time.Now().Unix())
+	clapiconsumer.PushLocalUserAmbient()
+	return string(newKey.Fingerprint), nil
+}
@@ -125,14 +125,12 @@ func RefreshGlobalUserHeaders(newUserEntities []*pbstructs.Key, nowts int64) {
 			uhcBatch = append(uhcBatch, uhc)
 		}
 	}
-	for k, _ := range uhcBatch {
-		uhcBatch[k].Refresh([]string{}, GlobalStatistics.UserCount, nowts)
-		// ^ We have no default mods in global, and totalPop comes from global statistics.
-		/*
-			TODO FUTURE
-			This is where you calculate and insert the global mods assigned by the CA.
-		*/
-	}
+	uhcBatch.Refresh([]string{}, GlobalStatistics.UserCount, nowts)
+	// ^ We have no default mods in global, and totalPop comes from global statistics.
+	/*
+		TODO FUTURE
+		This is where you calculate and insert the global mods assigned by the CA.
+	*/
 	// We need to add items coming in from this delta.
 
 	// logging.Logf(1, "This is the refreshed global user headers. %s", spew.Sdump(uhcBatch))
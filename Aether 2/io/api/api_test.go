@@ -2,13 +2,10 @@ package api_test
 
 import (
 	"aether-core/backend/cmd"
-	"aether-core/backend/responsegenerator"
 	"aether-core/io/api"
 	"aether-core/io/persistence"
 	"aether-core/services/globals"
 	"aether-core/services/signaturing"
-	"crypto/elliptic"
-	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -16,6 +13,7 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -36,6 +34,7 @@ var testNodeAddress string
 var testNodePort uint16
 var nodeLocation string
 var protv string
+var nodeAvailable bool
 
 func TestMain(m *testing.M) {
 	// Create the database and configs.
@@ -48,9 +47,12 @@ func TestMain(m *testing.M) {
 	globals.BackendTransientConfig.ProofOfWorkCheckEnabled = false
 	globals.BackendTransientConfig.PageSignatureCheckEnabled = false
 	globals.BackendTransientConfig.PermConfigReadOnly = true
-	globals.FrontendTransientConfig.PermConfigReadOnly = true
-	globals.BackendConfig.SetMinimumPoWStrengths(5)
+	globals.BackendConfig.SetMinimumPoWStrengths(7)
 	globals.BackendConfig.SetLoggingLevel(0)
+	// Inserts tell the admin frontend what the backend is doing. There is none here, so point it to a port nothing listens on, which fails the call right away.
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	globals.BackendConfig.SetAdminFrontendAddress(l.Addr().String())
+	l.Close()
 	testNodeAddress = "127.0.0.1"
 	testNodePort = 8089
 	setup(testNodeAddress, testNodePort)
@@ -191,6 +193,12 @@ func setup(testNodeAddress string, testNodePort uint16) {
 		// If no node location is given, assume default. This will break when you move that folder off desktop...
 		nodeLocation = "/Users/Helios/Desktop/Hazel Desktop/2015-Q4 /generated nodes/node-newest_16/static_mim_node"
 	}
+	if _, err := os.Stat(nodeLocation); err != nil {
+		// No static node to serve. The tests that fetch from it will skip, the rest can still run.
+		log.Printf("There is no Mim node at the node location, the tests that need one will be skipped. Node location: %s", nodeLocation)
+		return
+	}
+	nodeAvailable = true
 
 	// // Vote endpoint borkage test setup start.
 
@@ -331,10 +339,10 @@ func setup(testNodeAddress string, testNodePort uint16) {
 	// Create a HTTP server serving the nodeloc.
 	fs := http.FileServer(http.Dir(nodeLocation))
 	http.Handle("/", fs)
-	http.HandleFunc("/", protv, "/timeouter", func(w http.ResponseWriter, r *http.Request) {
+	http.HandleFunc(fmt.Sprint("/", protv, "/timeouter"), func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(30000 * time.Second)
 	})
-	http.HandleFunc("/", protv, "/c0/invalid_data.json", func(w http.ResponseWriter, r *http.Request) {
+	http.HandleFunc(fmt.Sprint("/", protv, "/c0/invalid_data.json"), func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("This is some invalid JSON."))
 	})
 	go http.ListenAndServe(fmt.Sprint(testNodeAddress, ":", testNodePort), nil)
@@ -343,6 +351,13 @@ func setup(testNodeAddress string, testNodePort uint16) {
 func teardown() {
 }
 
+func requireNode(t *testing.T) {
+	t.Helper()
+	if !nodeAvailable {
+		t.Skip("This test needs a Mim node, give its location with -nodeloc.")
+	}
+}
+
 func ValidateTest(expected interface{}, actual interface{}, t *testing.T) {
 	t.Helper()
 	if actual != expected {
@@ -355,6 +370,7 @@ func ValidateTest(expected interface{}, actual interface{}, t *testing.T) {
 // Fetch tests
 
 func TestFetch_Success(t *testing.T) {
+	requireNode(t)
 	httpResp, err :=
 		api.Fetch(testNodeAddress, "", testNodePort, "status", "GET", []byte{}, nil)
 	if err != nil {
@@ -366,6 +382,7 @@ func TestFetch_Success(t *testing.T) {
 }
 
 func TestFetch_404(t *testing.T) {
+	requireNode(t)
 	_, err := api.Fetch(testNodeAddress, "", testNodePort, "this is a nonexistent location", "GET", []byte{}, nil)
 	expected := "Non-200 status code returned from Fetch. Received status code: 404, Host: 127.0.0.1, Subhost: , Port: 8089, Location: this is a nonexistent location, Method: GET"
 	actual := err.Error()
@@ -380,6 +397,7 @@ func TestFetch_Refused(t *testing.T) {
 }
 
 func TestFetch_Timeout(t *testing.T) {
+	requireNode(t)
 	_, err := api.Fetch(testNodeAddress, "", testNodePort, "timeouter", "GET", []byte{}, nil)
 	expected := "Timeout exceeded. Host:127.0.0.1, Subhost: , Port: 8089, Location: timeouter"
 	actual := err.Error()
@@ -388,6 +406,7 @@ func TestFetch_Timeout(t *testing.T) {
 
 // Get Page tests
 func TestGetPageRaw_Success(t *testing.T) {
+	requireNode(t)
 	resp, err := api.GetPageRaw(testNodeAddress, "", testNodePort, "c0/boards/index.json", "GET", []byte{}, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -397,6 +416,7 @@ func TestGetPageRaw_Success(t *testing.T) {
 }
 
 func TestGetPageRaw_Unparsable(t *testing.T) {
+	requireNode(t)
 	_, err := api.GetPageRaw(testNodeAddress, "", testNodePort, "c0/invalid_data.json", "GET", []byte{}, nil)
 	expected := "The JSON that arrived over the network is malformed. JSON: This is some invalid JSON., Host: 127.0.0.1, Subhost: , Port: 8089, Location: c0/invalid_data.json"
 	actual := err.Error()
@@ -404,6 +424,7 @@ func TestGetPageRaw_Unparsable(t *testing.T) {
 }
 
 func TestGetPage_Success(t *testing.T) {
+	requireNode(t)
 	resp, _, err := api.GetPage(testNodeAddress, "", testNodePort, "c0/boards/index.json", "GET", []byte{}, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -415,9 +436,10 @@ func TestGetPage_Success(t *testing.T) {
 // Get Cache tests
 
 func TestGetCache_Success(t *testing.T) {
+	requireNode(t)
 	cacheName, _, _ := getValidEntity("cache")
 	// fmt.Printf("cachename: %#v\n", cacheName)
-	resp, err := api.GetCache(testNodeAddress, "", testNodePort, cacheName)
+	resp, err := api.GetCache(testNodeAddress, "", testNodePort, cacheName, false, nil)
 	// Pointing out the name directly here is brittle. We have no others, so fix this.
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -427,7 +449,8 @@ func TestGetCache_Success(t *testing.T) {
 }
 
 func TestGetCache_InvalidPageCount_CountNegative(t *testing.T) {
-	_, err := api.GetCache(testNodeAddress, "", testNodePort, "c0/posts/cache_negative_page_count/")
+	requireNode(t)
+	_, err := api.GetCache(testNodeAddress, "", testNodePort, "c0/posts/cache_negative_page_count/", false, nil)
 	errMessage := "The JSON that arrived over the network is malformed"
 	if err == nil {
 		t.Errorf("JSON parser failed to catch the error. No error from parser.")
@@ -437,8 +460,9 @@ func TestGetCache_InvalidPageCount_CountNegative(t *testing.T) {
 }
 
 func TestGetCache_InvalidPageCount_HugePageCount(t *testing.T) {
+	requireNode(t)
 	// This also tests for the 3 consequent missing pages safeguard, as the huge fake page count is stopped by the 3 pages after the last real page failing.
-	_, err := api.GetCache(testNodeAddress, "", testNodePort, "c0/posts/cache_huge_page_number/")
+	_, err := api.GetCache(testNodeAddress, "", testNodePort, "c0/posts/cache_huge_page_number/", false, nil)
 	errMessage := "3 or more broken pages"
 	if err == nil {
 		t.Errorf("GetCache failed to stop when 3 missing pages followed each other.")
@@ -448,7 +472,8 @@ func TestGetCache_InvalidPageCount_HugePageCount(t *testing.T) {
 }
 
 func TestGetCache_MissingPage(t *testing.T) {
-	resp, err := api.GetCache(testNodeAddress, "", testNodePort, "c0/posts/cache_missing_pages/")
+	requireNode(t)
+	resp, err := api.GetCache(testNodeAddress, "", testNodePort, "c0/posts/cache_missing_pages/", false, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Posts) == 0 {
@@ -459,7 +484,8 @@ func TestGetCache_MissingPage(t *testing.T) {
 // Get Endpoint tests
 
 func TestGetGETEndpoint_Success(t *testing.T) {
-	resp, err := api.GetGETEndpoint(testNodeAddress, "", testNodePort, "threads", 0)
+	requireNode(t)
+	resp, err := api.GetGETEndpoint(testNodeAddress, "", testNodePort, "threads", 0, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Threads) == 0 {
@@ -468,7 +494,8 @@ func TestGetGETEndpoint_Success(t *testing.T) {
 }
 
 func TestGetGETEndpoint_3ConsequentCachesMissingFailure(t *testing.T) {
-	_, err := api.GetGETEndpoint(testNodeAddress, "", testNodePort, "votes", 0)
+	requireNode(t)
+	_, err := api.GetGETEndpoint(testNodeAddress, "", testNodePort, "votes", 0, nil)
 	errMessage := "3 or more cache failures"
 	if err == nil {
 		t.Errorf("Did not notice the cache being missing.")
@@ -478,7 +505,8 @@ func TestGetGETEndpoint_3ConsequentCachesMissingFailure(t *testing.T) {
 }
 
 func TestGetGETEndpoint_NonexistentEndpoint(t *testing.T) {
-	_, err := api.GetGETEndpoint(testNodeAddress, "", testNodePort, "fakeendpoint", 0)
+	requireNode(t)
+	_, err := api.GetGETEndpoint(testNodeAddress, "", testNodePort, "fakeendpoint", 0, nil)
 	errMessage := "Get Endpoint failed because it couldn't get the index of the endpoint."
 	if err == nil {
 		t.Errorf("Did not notice the endpoint being missing.")
@@ -488,8 +516,9 @@ func TestGetGETEndpoint_NonexistentEndpoint(t *testing.T) {
 }
 
 func TestGetGETEndpoint_EndpointNameAndContentsMismatch(t *testing.T) {
+	requireNode(t)
 	// This test is present to make sure that endpoints have no dependence on their names. The parsing logic should be global.
-	resp, err := api.GetGETEndpoint(testNodeAddress, "", testNodePort, "invalidendpoint", 0)
+	resp, err := api.GetGETEndpoint(testNodeAddress, "", testNodePort, "invalidendpoint", 0, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Posts) == 0 {
@@ -500,7 +529,8 @@ func TestGetGETEndpoint_EndpointNameAndContentsMismatch(t *testing.T) {
 // Get Remote Node tests
 
 func TestGetRemoteNode_Success(t *testing.T) {
-	resp, err := api.GetRemoteNode(testNodeAddress, "", testNodePort)
+	requireNode(t)
+	resp, err := api.GetRemoteNode(testNodeAddress, "", testNodePort, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Boards) == 0 ||
@@ -524,9 +554,10 @@ func TestGetRemoteNode_Success(t *testing.T) {
 // Query tests
 
 func TestQuery_Fingerprint_Success(t *testing.T) {
+	requireNode(t)
 	entityFp, _, _ := getValidEntity("boards")
 	data := api.QueryData{"boards", api.Fingerprint(entityFp), 0, 0}
-	resp, err := api.Query(testNodeAddress, "", testNodePort, data)
+	resp, err := api.Query(testNodeAddress, "", testNodePort, data, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Boards) == 0 {
@@ -535,11 +566,12 @@ func TestQuery_Fingerprint_Success(t *testing.T) {
 }
 
 func TestQuery_FingerprintAndCreation_Success(t *testing.T) {
+	requireNode(t)
 	// fmt.Printf("valid entity: %#v\n", api.Fingerprint(getValidEntity("posts")))
 	// Mind that it's asking for something created AFTER 0451102626
 	entityFp, creation, _ := getValidEntity("posts")
 	data := api.QueryData{"posts", api.Fingerprint(entityFp), creation, 0}
-	resp, err := api.Query(testNodeAddress, "", testNodePort, data)
+	resp, err := api.Query(testNodeAddress, "", testNodePort, data, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Posts) == 0 {
@@ -548,9 +580,10 @@ func TestQuery_FingerprintAndCreation_Success(t *testing.T) {
 }
 
 func TestQuery_FingerprintAndCreationAndLastUpdate_Success(t *testing.T) {
+	requireNode(t)
 	entityFp, creation, lastUpdate := getValidEntity("truststates")
 	data := api.QueryData{"truststates", api.Fingerprint(entityFp), creation, lastUpdate}
-	resp, err := api.Query(testNodeAddress, "", testNodePort, data)
+	resp, err := api.Query(testNodeAddress, "", testNodePort, data, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Truststates) == 0 {
@@ -559,9 +592,10 @@ func TestQuery_FingerprintAndCreationAndLastUpdate_Success(t *testing.T) {
 }
 
 func TestQuery_FingerprintAndLastUpdate_Success(t *testing.T) {
+	requireNode(t)
 	entityFp, _, lastUpdate := getValidEntity("truststates")
 	data := api.QueryData{"truststates", api.Fingerprint(entityFp), 0, lastUpdate}
-	resp, err := api.Query(testNodeAddress, "", testNodePort, data)
+	resp, err := api.Query(testNodeAddress, "", testNodePort, data, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Truststates) == 0 {
@@ -570,8 +604,9 @@ func TestQuery_FingerprintAndLastUpdate_Success(t *testing.T) {
 }
 
 func TestQuery_NotFound(t *testing.T) {
+	requireNode(t)
 	data := api.QueryData{"truststates", "0af3473c5a3ae6376f0d3824b16d2ef90510973c75f889557d39f6616ea55535", 0, 0}
-	resp, err := api.Query(testNodeAddress, "", testNodePort, data)
+	resp, err := api.Query(testNodeAddress, "", testNodePort, data, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Truststates) > 0 {
@@ -580,8 +615,9 @@ func TestQuery_NotFound(t *testing.T) {
 }
 
 func TestQuery_InvalidTimeRange(t *testing.T) {
+	requireNode(t)
 	data := api.QueryData{"truststates", "7bb882b1e9b679948478266c6ccdd153cb71fbcb2e58bf1237f30d43245eed5d", 1449122236523, 1451543248432}
-	resp, err := api.Query(testNodeAddress, "", testNodePort, data)
+	resp, err := api.Query(testNodeAddress, "", testNodePort, data, nil)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp.Truststates) > 0 {
@@ -590,9 +626,10 @@ func TestQuery_InvalidTimeRange(t *testing.T) {
 }
 
 func TestQuery_TheItemDoesNotExistAtLocationGivenByIndex(t *testing.T) {
+	requireNode(t)
 	entityFp, _, _ := getValidEntity("threads_index")
 	data := api.QueryData{"threads", api.Fingerprint(entityFp), 0, 0}
-	_, err := api.Query(testNodeAddress, "", testNodePort, data)
+	_, err := api.Query(testNodeAddress, "", testNodePort, data, nil)
 	errMessage := "Could not pull entity from cache. The item is indexed as available in the remote node, but the actual body of the item is not available."
 	if err == nil {
 		t.Errorf("This should have caused an error.")
@@ -946,7 +983,7 @@ func TestBoardCreateSignature_Success(t *testing.T) {
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else {
-		marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+		marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 		result, err3 := newboard2.VerifySignature(marshaledPubKey)
 		if err3 != nil {
 			t.Errorf("Test failed, err: '%s'", err3)
@@ -972,7 +1009,7 @@ func TestThreadCreateSignature_Success(t *testing.T) {
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else {
-		marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+		marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 		result, err3 := newthread.VerifySignature(marshaledPubKey)
 		if err3 != nil {
 			t.Errorf("Test failed, err: '%s'", err3)
@@ -997,7 +1034,7 @@ func TestPostCreateSignature_Success(t *testing.T) {
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else {
-		marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+		marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 		result, err3 := newpost.VerifySignature(marshaledPubKey)
 		if err3 != nil {
 			t.Errorf("Test failed, err: '%s'", err3)
@@ -1021,7 +1058,7 @@ func TestVoteCreateSignature_Success(t *testing.T) {
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else {
-		marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+		marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 		result, err3 := newvote.VerifySignature(marshaledPubKey)
 		if err3 != nil {
 			t.Errorf("Test failed, err: '%s'", err3)
@@ -1045,7 +1082,7 @@ func TestKeyCreateSignature_Success(t *testing.T) {
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else {
-		marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+		marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 		result, err3 := newkey.VerifySignature(marshaledPubKey)
 		if err3 != nil {
 			t.Errorf("Test failed, err: '%s'", err3)
@@ -1069,7 +1106,7 @@ func TestTruststateCreateSignature_Success(t *testing.T) {
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else {
-		marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+		marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 		result, err3 := newtruststate.VerifySignature(marshaledPubKey)
 		if err3 != nil {
 			t.Errorf("Test failed, err: '%s'", err3)
@@ -1101,7 +1138,7 @@ func TestBoardCreateUpdateSignature_Success(t *testing.T) {
 		if err2 != nil {
 			t.Errorf("Test failed, err: '%s'", err2)
 		} else {
-			marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+			marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 			result, err3 := newboard.VerifySignature(marshaledPubKey)
 			if err3 != nil {
 				t.Errorf("Test failed, err: '%s'", err3)
@@ -1131,7 +1168,7 @@ func TestVoteCreateUpdateSignature_Success(t *testing.T) {
 		if err2 != nil {
 			t.Errorf("Test failed, err: '%s'", err2)
 		} else {
-			marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+			marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 			result, err3 := newvote.VerifySignature(marshaledPubKey)
 			if err3 != nil {
 				t.Errorf("Test failed, err: '%s'", err3)
@@ -1162,7 +1199,7 @@ func TestKeyCreateUpdateSignature_Success(t *testing.T) {
 		if err2 != nil {
 			t.Errorf("Test failed, err: '%s'", err2)
 		} else {
-			marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+			marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 			result, err3 := newkey.VerifySignature(marshaledPubKey)
 			if err3 != nil {
 				t.Errorf("Test failed, err: '%s'", err3)
@@ -1192,7 +1229,7 @@ func TestTruststateCreateUpdateSignature_Success(t *testing.T) {
 		if err2 != nil {
 			t.Errorf("Test failed, err: '%s'", err2)
 		} else {
-			marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+			marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 			result, err3 := newtruststate.VerifySignature(marshaledPubKey)
 			if err3 != nil {
 				t.Errorf("Test failed, err: '%s'", err3)
@@ -1223,7 +1260,7 @@ func TestApiResponseCreateSignature_Success(t *testing.T) {
 
 func TestApiResponseCreateSignature_Fail(t *testing.T) {
 	globals.BackendTransientConfig.PageSignatureCheckEnabled = true
-	apiResp := api.ApiResponse()
+	apiResp := api.ApiResponse{}
 	apiResp.Prefill()
 	// apiResp := responsegenerator.GeneratePrefilledApiResponse()
 	err := apiResp.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
@@ -1237,3 +1274,144 @@ func TestApiResponseCreateSignature_Fail(t *testing.T) {
 		}
 	}
 }
+
+func TestAddressCheckBounds_IPv6(t *testing.T) {
+	cases := []struct {
+		loc     string
+		locType uint8
+		valid   bool
+	}{
+		{"2001:db8::1", 6, true},
+		{"2001:db8::1", 0, true},
+		{"2001:db8::1", 4, false},
+		{"203.0.113.5", 4, true},
+		{"203.0.113.5", 6, false},
+		{"[2001:db8::1]", 6, false},
+		{"fe80::1%eth0", 6, false},
+		{"example.org:49999", 0, false},
+		{"example.org", 3, true},
+	}
+	for _, c := range cases {
+		a := api.Address{Location: api.Location(c.loc), LocationType: c.locType, Port: 49999, Type: 2, EntityVersion: 1}
+		valid, err := a.CheckBounds()
+		if err != nil {
+			t.Errorf("Test failed, err: '%s'", err)
+		} else if valid != c.valid {
+			t.Errorf("Test failed, the bounds check of this location gave the wrong result. Location: %s, Type: %d, Expected: %v", c.loc, c.locType, c.valid)
+		}
+	}
+}
+
+// startSOCKSStub runs a SOCKS5 proxy that writes down where it was asked to connect to, and refuses.
+func startSOCKSStub(t *testing.T, targets chan string) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("The SOCKS stub could not start. Error: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func(c net.Conn) {
+				defer c.Close()
+				head := make([]byte, 2)
+				if _, err := io.ReadFull(c, head); err != nil {
+					return
+				}
+				io.ReadFull(c, make([]byte, head[1])) // Auth methods, we take none.
+				c.Write([]byte{5, 0})
+				req := make([]byte, 4)
+				if _, err := io.ReadFull(c, req); err != nil || req[3] != 3 {
+					// We only expect names, never IPs.
+					targets <- "not a name"
+					return
+				}
+				nameLen := make([]byte, 1)
+				io.ReadFull(c, nameLen)
+				name := make([]byte, nameLen[0])
+				io.ReadFull(c, name)
+				port := make([]byte, 2)
+				io.ReadFull(c, port)
+				targets <- fmt.Sprintf("%s:%d", name, int(port[0])<<8|int(port[1]))
+				c.Write([]byte{5, 4, 0, 1, 0, 0, 0, 0, 0, 0}) // Host unreachable.
+			}(conn)
+		}
+	}()
+	return l
+}
+
+func TestFetch_OverlayGoesThroughItsProxy(t *testing.T) {
+	targets := make(chan string, 10)
+	stub := startSOCKSStub(t, targets)
+	defer stub.Close()
+	globals.BackendConfig.SetTorSOCKSProxyAddress(stub.Addr().String())
+	defer globals.BackendConfig.SetTorSOCKSProxyAddress("")
+	onion := strings.Repeat("a", 56) + ".onion"
+	_, err := api.Fetch(onion, "", 49999, "status", "GET", []byte{}, nil)
+	if err == nil {
+		t.Errorf("Test failed, the fetch succeeded even though the proxy refused it.")
+	}
+	select {
+	case target := <-targets:
+		if target != onion+":49999" {
+			t.Errorf("Test failed, the proxy was asked for the wrong address. Expected: %s:49999, Got: %s", onion, target)
+		}
+	case <-time.After(10 * time.Second):
+		t.Errorf("Test failed, the onion address was not fetched through the Tor proxy.")
+	}
+	// Clearnet addresses go directly.
+	l, err2 := net.Listen("tcp", "127.0.0.1:0")
+	if err2 != nil {
+		t.Fatalf("The listener could not start. Error: %v", err2)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	api.Fetch("127.0.0.1", "", uint16(l.Addr().(*net.TCPAddr).Port), "status", "GET", []byte{}, nil)
+	select {
+	case target := <-targets:
+		t.Errorf("Test failed, a clearnet address was fetched through the Tor proxy. Target: %s", target)
+	default:
+	}
+	// I2P has no proxy configured, so it can't be reached.
+	if api.OverlayReachable(api.Location(strings.Repeat("a", 52) + ".b32.i2p")) {
+		t.Errorf("Test failed, an I2P address is reachable without an I2P proxy.")
+	}
+}
+
+func TestAddressCheckBounds_Overlay(t *testing.T) {
+	onion := strings.Repeat("a", 56) + ".onion"
+	i2p := strings.Repeat("b", 52) + ".b32.i2p"
+	cases := []struct {
+		loc     string
+		locType uint8
+		valid   bool
+	}{
+		{onion, 7, true},
+		{onion, 0, true},
+		{onion, 3, false},
+		{i2p, 8, true},
+		{i2p, 7, false},
+		{"short.onion", 7, false},
+		{strings.Repeat("A", 56) + ".onion", 7, false},
+		{"example.org", 7, false},
+	}
+	for _, c := range cases {
+		a := api.Address{Location: api.Location(c.loc), LocationType: c.locType, Port: 49999, Type: 2, EntityVersion: 1}
+		valid, err := a.CheckBounds()
+		if err != nil {
+			t.Errorf("Test failed, err: '%s'", err)
+		} else if valid != c.valid {
+			t.Errorf("Test failed, the bounds check of this location gave the wrong result. Location: %s, Type: %d, Expected: %v", c.loc, c.locType, c.valid)
+		}
+	}
+}
@@ -445,6 +445,10 @@ type Answer struct { // Bodies of API Endpoint responses from remote. This will
 	KeyManifests        []PageManifest `json:"keys_manifest,omitempty"`
 	TruststateManifests []PageManifest `json:"truststates_manifest,omitempty"`
 	AddressManifests    []PageManifest `json:"addresses_manifest,omitempty"`
+
+	MerkleNodes []MerkleNode `json:"merkle_nodes,omitempty"`
+
+	Blobs []Blob `json:"blobs,omitempty"`
 }
 
 // Manifest type
@@ -554,16 +558,14 @@ func (r *ApiResponse) Verify() []error {
 		}
 	}
 	provables := r.GetProvables()
-	for _, e := range *provables { // provable is an interface, so pointer..
-		err := Verify(e)
-		if err != nil {
-			errs = append(errs, err)
-			continue
-		}
-	}
+	provErrs, stats := VerifyProvables(*provables) // provable is an interface, so pointer..
+	errs = append(errs, provErrs...)
 	for _, err := range errs {
 		logging.Log(1, err)
 	}
+	if stats.Entities > 0 {
+		logging.Logf(2, "Verification of the entities in this page is complete. %s", stats.String())
+	}
 	return errs
 }
 
@@ -577,19 +579,18 @@ func (r *ApiResponse) ToJSON() ([]byte, error) {
 }
 
 func (r *ApiResponse) Prefill() {
-	subprotsAsShims := globals.BackendConfig.GetServingSubprotocols()
-	subprotsSupported := []Subprotocol{}
-	for _, val := range subprotsAsShims {
-		subprotsSupported = append(subprotsSupported, Subprotocol(val))
-	}
 	r.NodePublicKey = globals.BackendConfig.GetMarshaledBackendPublicKey()
 	addr := Address{}
 	addr.LocationType = globals.BackendConfig.GetExternalIpType()
 	addr.Type = globals.BackendConfig.GetNodeType()
 	addr.Port = uint16(globals.BackendConfig.GetExternalPort())
-	addr.Protocol.VersionMajor = globals.BackendConfig.GetProtocolVersionMajor()
-	addr.Protocol.VersionMinor = globals.BackendConfig.GetProtocolVersionMinor()
-	addr.Protocol.Subprotocols = subprotsSupported
+	if hs := globals.BackendConfig.GetHiddenServiceAddress(); len(hs) > 0 {
+		// We're a hidden service. The remote can't see this address, so we have to tell it.
+		addr.Location = Location(hs)
+		addr.LocationType = toolbox.IPType(hs)
+		addr.Port = globals.BackendConfig.GetHiddenServicePort()
+	}
+	addr.Protocol = LocalProtocol()
 	addr.Client.VersionMajor = globals.BackendConfig.GetClientVersionMajor()
 	addr.Client.VersionMinor = globals.BackendConfig.GetClientVersionMinor()
 	addr.Client.VersionPatch = globals.BackendConfig.GetClientVersionPatch()
@@ -855,6 +856,10 @@ type Response struct {
 	TruststateManifests []PageManifest
 	AddressManifests    []PageManifest
 
+	MerkleNodes []MerkleNode
+
+	Blobs []Blob
+
 	CacheLinks                []ResultCache
 	MostRecentSourceTimestamp Timestamp
 }
@@ -884,6 +889,10 @@ func (r *Response) Empty() bool {
 		len(r.TruststateManifests) == 0 &&
 		len(r.AddressManifests) == 0 &&
 
+		len(r.MerkleNodes) == 0 &&
+
+		len(r.Blobs) == 0 &&
+
 		len(r.CacheLinks) == 0
 }
 
@@ -912,6 +921,10 @@ func (r *Response) Insert(r2 *Response) {
 	r.TruststateManifests = append(r.TruststateManifests, r2.TruststateManifests...)
 	r.AddressManifests = append(r.AddressManifests, r2.AddressManifests...)
 
+	r.MerkleNodes = append(r.MerkleNodes, r2.MerkleNodes...)
+
+	r.Blobs = append(r.Blobs, r2.Blobs...)
+
 	r.CacheLinks = append(r.CacheLinks, r2.CacheLinks...)
 
 	if r.MostRecentSourceTimestamp < r2.MostRecentSourceTimestamp {
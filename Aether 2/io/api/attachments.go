@@ -0,0 +1,93 @@
+// API > Attachments
+// This file has the parts of the API that deal with the attachments of threads and posts: the proof of work they cost, their bounds, and the endpoint their chunks are fetched from.
+
+package api
+
+import (
+	"aether-core/io/blobstore"
+	"aether-core/services/globals"
+	"aether-core/services/metaparse"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net"
+)
+
+// Blob is a chunk of an attachment, as it is sent over the wire. Data is base64 in JSON.
+type Blob struct {
+	Hash string `json:"hash"`
+	Data []byte `json:"data"`
+}
+
+const (
+	// How many chunks can be asked for, and given, in one request. 16 chunks is 4Mb.
+	MaxBlobsPerRequest = 16
+)
+
+/*
+AttachmentPoWBits is how much stronger the proof of work of a thread or a post has to be, because of its attachments. Every bit doubles the work, and we add as many bits as it takes to count the chunks, so the work grows with the size of the attachments: one chunk doubles it, a full 3Mb attachment (12 chunks) makes it 16 times what it is without.
+*/
+func AttachmentPoWBits(entityType, meta string) int {
+	chunks := 0
+	for _, a := range metaparse.ReadAttachments(entityType, meta) {
+		chunks = chunks + len(a.Chunks)
+	}
+	return bits.Len(uint(chunks))
+}
+
+// attachmentsBC checks the attachments in the meta of a thread or a post.
+func attachmentsBC(entityType, meta string) bool {
+	atts := metaparse.ReadAttachments(entityType, meta)
+	if len(atts) > blobstore.MaxAttachmentsPerEntity {
+		return false
+	}
+	for key, _ := range atts {
+		if !blobstore.ValidAttachment(&atts[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+func blobBC(item *Blob) bool {
+	return stringBC(item.Hash, 64, 64) &&
+		intBC(int64(len(item.Data)), 1, blobstore.ChunkSize)
+}
+
+func blobSliceBC(item *[]Blob, minLen, maxLen int) bool {
+	sliceValid := intBC(int64(len(*item)), int64(minLen), int64(maxLen))
+	if !sliceValid {
+		return false
+	}
+	for key, _ := range *item {
+		if !blobBC(&(*item)[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetBlobs asks the remote for the chunks with the given hashes. The remote gives the ones it has, and leaves out the rest. The chunks are not checked against their hashes here, blobstore.Put does that.
+func GetBlobs(host string, subhost string, port uint16, hashes []string, reverseConn *net.Conn) ([]Blob, error) {
+	if len(hashes) > MaxBlobsPerRequest {
+		hashes = hashes[:MaxBlobsPerRequest]
+	}
+	apiReq := ApiResponse{}
+	apiReq.Prefill()
+	apiReq.Endpoint = "blobs"
+	apiReq.Filters = []Filter{Filter{Type: "blob", Values: hashes}}
+	signingErr := apiReq.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+	if signingErr != nil {
+		return []Blob{}, signingErr
+	}
+	apiReq.CreatePoW()
+	reqAsJson, err := apiReq.ToJSON()
+	if err != nil {
+		return []Blob{}, err
+	}
+	resp, _, err2 := GetPage(host, subhost, port, "c0/blobs", "POST", reqAsJson, reverseConn)
+	if err2 != nil {
+		return []Blob{}, errors.New(fmt.Sprintf("Getting the chunks from the remote failed. Hashes: %v, Error: %s", hashes, err2))
+	}
+	return resp.Blobs, nil
+}
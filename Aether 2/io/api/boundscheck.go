@@ -5,9 +5,11 @@ package api
 
 import (
 	"aether-core/services/logging"
+	"aether-core/services/toolbox"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -161,6 +163,27 @@ const (
 	MIN_APIRESONSE_RESPONSEBODY_MANIFEST_ENTITY_V1_0 = 0
 	MAX_APIRESONSE_RESPONSEBODY_MANIFEST_ENTITY_V1_0 = 50000
 
+	MIN_APIRESPONSE_FILTER_VALUES_MERKLE_V1_0 = 1
+	MAX_APIRESPONSE_FILTER_VALUES_MERKLE_V1_0 = 1
+
+	MIN_APIRESPONSE_FILTER_VALUES_BOARD_V1_0 = 1
+	MAX_APIRESPONSE_FILTER_VALUES_BOARD_V1_0 = 1000 // A node syncing more boards than this is better off syncing everything.
+
+	MIN_APIRESPONSE_MERKLE_PATH_V1_0 = 0
+	MAX_APIRESPONSE_MERKLE_PATH_V1_0 = 32 // bucket start timestamp, a separator and a fingerprint prefix
+
+	MIN_APIRESPONSE_RESPONSEBODY_MERKLE_NODES_V1_0 = 0
+	MAX_APIRESPONSE_RESPONSEBODY_MERKLE_NODES_V1_0 = MAX_UINT16
+
+	MIN_APIRESPONSE_RESPONSEBODY_MERKLE_LEAVES_V1_0 = 0
+	MAX_APIRESPONSE_RESPONSEBODY_MERKLE_LEAVES_V1_0 = 50000
+
+	MIN_APIRESPONSE_FILTER_VALUES_BLOB_V1_0 = 1
+	MAX_APIRESPONSE_FILTER_VALUES_BLOB_V1_0 = MaxBlobsPerRequest
+
+	MIN_APIRESPONSE_RESPONSEBODY_BLOBS_V1_0 = 0
+	MAX_APIRESPONSE_RESPONSEBODY_BLOBS_V1_0 = MaxBlobsPerRequest
+
 	// Indexes
 
 	MIN_INDEX_PAGENUMBER_V1 = 0
@@ -273,6 +296,27 @@ func locationBC(item Location) bool {
 	// }
 	return stringBC(string(item), 0, 512)
 }
+
+// addressLocationBC checks the location of an address against its location type. A location with a colon in it can only be an IPv6 address, since host names can't have one, and IPv6 addresses in brackets, or with zones, are not allowed. Location type 0 is not checked against, since it is the type of third party addresses, whose types are stripped.
+func addressLocationBC(item Location, locType uint8) bool {
+	ipType := toolbox.IPType(string(item))
+	if strings.Contains(string(item), ":") && ipType != 6 {
+		return false
+	}
+	if strings.ContainsAny(string(item), "[]%") {
+		return false
+	}
+	if locType == 4 || locType == 6 || locType == toolbox.LocationTypeTor || locType == toolbox.LocationTypeI2P {
+		if ipType != locType {
+			return false
+		}
+	}
+	if ipType == toolbox.LocationTypeTor || ipType == toolbox.LocationTypeI2P {
+		// An overlay address that is not well-formed can't be reached, and a URL type can't be given to one, since it would then be looked up in the DNS.
+		return (locType == 0 || locType == ipType) && toolbox.ValidOverlayLocation(string(item))
+	}
+	return true
+}
 func publicKeyBC(item string, owner Fingerprint) bool {
 	// if !stringBC(item, MIN_PUBLICKEY_V1, MAX_PUBLICKEY_V1) {
 	// 	fmt.Printf("PK FAIL: %s\n", item)
@@ -380,7 +424,7 @@ func filterBC(item *Filter) bool {
 	if item.Type == "" && len(item.Values) == 0 {
 		return true
 	}
-	allowed := (item.Type == "fingerprint" || item.Type == "embed" || item.Type == "timestamp")
+	allowed := (item.Type == "fingerprint" || item.Type == "embed" || item.Type == "timestamp" || item.Type == "merkle" || item.Type == "board" || item.Type == "blob")
 	if !allowed {
 		return false
 	}
@@ -401,6 +445,18 @@ func filterBC(item *Filter) bool {
 		valid = timestampSliceBC(&tss,
 			MIN_APIRESPONSE_FILTER_VALUES_TIMESTAMP_V1_0,
 			MAX_APIRESPONSE_FILTER_VALUES_TIMESTAMP_V1_0)
+	} else if item.Type == "merkle" {
+		valid = stringSliceBC(item.Values,
+			MIN_APIRESPONSE_FILTER_VALUES_MERKLE_V1_0, MAX_APIRESPONSE_FILTER_VALUES_MERKLE_V1_0,
+			MIN_APIRESPONSE_MERKLE_PATH_V1_0, MAX_APIRESPONSE_MERKLE_PATH_V1_0)
+	} else if item.Type == "board" {
+		valid = stringSliceBC(item.Values,
+			MIN_APIRESPONSE_FILTER_VALUES_BOARD_V1_0, MAX_APIRESPONSE_FILTER_VALUES_BOARD_V1_0,
+			1, 64) // Fingerprint, but in string form
+	} else if item.Type == "blob" {
+		valid = stringSliceBC(item.Values,
+			MIN_APIRESPONSE_FILTER_VALUES_BLOB_V1_0, MAX_APIRESPONSE_FILTER_VALUES_BLOB_V1_0,
+			64, 64) // Chunk hash
 	}
 	return valid
 }
@@ -491,6 +547,41 @@ func pageManifestSliceBC(item *[]PageManifest, minLen, maxLen int) bool {
 	return true
 }
 
+func merkleLeafBC(item *MerkleLeaf) bool {
+	return fingerprintBC(item.Fingerprint) &&
+		timestampBC(item.Creation)
+}
+
+func merkleNodeBC(item *MerkleNode) bool {
+	sliceValid := intBC(int64(len(item.Leaves)),
+		MIN_APIRESPONSE_RESPONSEBODY_MERKLE_LEAVES_V1_0,
+		MAX_APIRESPONSE_RESPONSEBODY_MERKLE_LEAVES_V1_0)
+	if !sliceValid {
+		return false
+	}
+	for key, _ := range item.Leaves {
+		if !merkleLeafBC(&item.Leaves[key]) {
+			return false
+		}
+	}
+	return stringBC(item.Path, MIN_APIRESPONSE_MERKLE_PATH_V1_0, MAX_APIRESPONSE_MERKLE_PATH_V1_0) &&
+		stringBC(item.Hash, 0, 64) &&
+		intBC(int64(item.Count), 0, MAX_INT64)
+}
+
+func merkleNodeSliceBC(item *[]MerkleNode, minLen, maxLen int) bool {
+	sliceValid := intBC(int64(len(*item)), int64(minLen), int64(maxLen))
+	if !sliceValid {
+		return false
+	}
+	for key, _ := range *item {
+		if !merkleNodeBC(&(*item)[key]) {
+			return false
+		}
+	}
+	return true
+}
+
 func entityCountBC(item *EntityCount) bool {
 	return stringBC(item.Protocol, MIN_ADDRESS_PROTOCOL_SUBPROTOCOL_NAME_V1, MAX_ADDRESS_PROTOCOL_SUBPROTOCOL_NAME_V1) &&
 		stringBC(item.Name,
@@ -544,6 +635,7 @@ func checkThreadBounds_V1(item *Thread) bool {
 		publicKeyBC(item.OwnerPublicKey, item.Owner) &&
 		intBC(int64(item.EntityVersion), MIN_ENTITYVERSION, MAX_ENTITYVERSION) &&
 		stringBC(item.Meta, MIN_META_V1, MAX_META_V1) &&
+		attachmentsBC("Thread", item.Meta) &&
 		fingerprintBC(item.RealmId) &&
 		stringBC(item.EncrContent, MIN_ENCR_CONTENT_V1, MAX_ENCR_CONTENT_V1)
 }
@@ -558,6 +650,7 @@ func checkPostBounds_V1(item *Post) bool {
 		publicKeyBC(item.OwnerPublicKey, item.Owner) &&
 		intBC(int64(item.EntityVersion), MIN_ENTITYVERSION, MAX_ENTITYVERSION) &&
 		stringBC(item.Meta, MIN_META_V1, MAX_META_V1) &&
+		attachmentsBC("Post", item.Meta) &&
 		fingerprintBC(item.RealmId) &&
 		stringBC(item.EncrContent, MIN_ENCR_CONTENT_V1, MAX_ENCR_CONTENT_V1)
 }
@@ -605,6 +698,7 @@ func checkTruststateBounds_V1(item *Truststate) bool {
 }
 func checkAddressBounds_V1(item *Address) bool {
 	return locationBC(item.Location) &&
+		addressLocationBC(item.Location, item.LocationType) &&
 		locationBC(item.Sublocation) &&
 		intBC(int64(item.LocationType), MIN_ADDRESS_LOCATIONTYPE_V1, MAX_ADDRESS_LOCATIONTYPE_V1) &&
 		intBC(int64(item.Port), MIN_ADDRESS_PORT_V1, MAX_ADDRESS_PORT_V1) &&
@@ -640,6 +734,8 @@ func checkApiResponseBounds_V1_0(item *ApiResponse) bool {
 		pageManifestSliceBC(&item.ResponseBody.KeyManifests, MIN_APIRESONSE_RESPONSEBODY_MANIFEST_PAGES_V1_0, MAX_APIRESONSE_RESPONSEBODY_MANIFEST_PAGES_V1_0) &&
 		pageManifestSliceBC(&item.ResponseBody.TruststateManifests, MIN_APIRESONSE_RESPONSEBODY_MANIFEST_PAGES_V1_0, MAX_APIRESONSE_RESPONSEBODY_MANIFEST_PAGES_V1_0) &&
 		pageManifestSliceBC(&item.ResponseBody.AddressManifests, MIN_APIRESONSE_RESPONSEBODY_MANIFEST_PAGES_V1_0, MAX_APIRESONSE_RESPONSEBODY_MANIFEST_PAGES_V1_0) &&
+		merkleNodeSliceBC(&item.ResponseBody.MerkleNodes, MIN_APIRESPONSE_RESPONSEBODY_MERKLE_NODES_V1_0, MAX_APIRESPONSE_RESPONSEBODY_MERKLE_NODES_V1_0) &&
+		blobSliceBC(&item.ResponseBody.Blobs, MIN_APIRESPONSE_RESPONSEBODY_BLOBS_V1_0, MAX_APIRESPONSE_RESPONSEBODY_BLOBS_V1_0) &&
 		entityCountSliceBC(&item.Caching.EntityCounts, 0, MAX_ADDRESS_PROTOCOL_SUBPROTOCOL_V1*MAX_ADDRESS_PROTOCOL_SUBPROTOCOL_SUPPORTEDENTITIES_V1) // 32 subprotocols with 128 entities each is our max.
 	if !bodyOk {
 		logging.Logf(1, "This ApiResponse failed Boundscheck: %#v", item)
@@ -720,42 +816,43 @@ func checkTruststateIndexBounds_V1(item *TruststateIndex) bool {
 
 // High level version-independent API.
 func (item *Board) CheckBounds() (bool, error) {
-	if item.EntityVersion == 1 {
+	// v2 has the same fields as v1, only the hashing and the signing is different.
+	if item.EntityVersion == 1 || item.EntityVersion == 2 {
 		return checkBoardBounds_V1(item), nil
 	} else {
 		return false, errors.New(fmt.Sprintf("We do not support this version of this entity for bounds checking. Entity: %#v", item))
 	}
 }
 func (item *Thread) CheckBounds() (bool, error) {
-	if item.EntityVersion == 1 {
+	if item.EntityVersion == 1 || item.EntityVersion == 2 {
 		return checkThreadBounds_V1(item), nil
 	} else {
 		return false, errors.New(fmt.Sprintf("We do not support this version of this entity for bounds checking. Entity: %#v", item))
 	}
 }
 func (item *Post) CheckBounds() (bool, error) {
-	if item.EntityVersion == 1 {
+	if item.EntityVersion == 1 || item.EntityVersion == 2 {
 		return checkPostBounds_V1(item), nil
 	} else {
 		return false, errors.New(fmt.Sprintf("We do not support this version of this entity for bounds checking. Entity: %#v", item))
 	}
 }
 func (item *Vote) CheckBounds() (bool, error) {
-	if item.EntityVersion == 1 {
+	if item.EntityVersion == 1 || item.EntityVersion == 2 {
 		return checkVoteBounds_V1(item), nil
 	} else {
 		return false, errors.New(fmt.Sprintf("We do not support this version of this entity for bounds checking. Entity: %#v", item))
 	}
 }
 func (item *Key) CheckBounds() (bool, error) {
-	if item.EntityVersion == 1 {
+	if item.EntityVersion == 1 || item.EntityVersion == 2 {
 		return checkKeyBounds_V1(item), nil
 	} else {
 		return false, errors.New(fmt.Sprintf("We do not support this version of this entity for bounds checking. Entity: %#v", item))
 	}
 }
 func (item *Truststate) CheckBounds() (bool, error) {
-	if item.EntityVersion == 1 {
+	if item.EntityVersion == 1 || item.EntityVersion == 2 {
 		return checkTruststateBounds_V1(item), nil
 	} else {
 		return false, errors.New(fmt.Sprintf("We do not support this version of this entity for bounds checking. Entity: %#v", item))
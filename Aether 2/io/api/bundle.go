@@ -0,0 +1,84 @@
+// API > Bundle
+// This file implements the bundle, the file form of a sync. A bundle carries the same signed pages that a remote would serve us over the network, so that nodes that are only occasionally connected can exchange content over a USB drive.
+
+package api
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	BUNDLE_VERSION = 1
+)
+
+// Bundle is a set of signed pages, written into a gzipped JSON file.
+type Bundle struct {
+	BundleVersion int           `json:"bundle_version"`
+	NodePublicKey string        `json:"node_public_key"`
+	Since         Timestamp     `json:"since"`
+	Until         Timestamp     `json:"until"`
+	Pages         []ApiResponse `json:"pages"`
+}
+
+// WriteToFile writes the bundle to the given path. It refuses to overwrite an existing file.
+func (b *Bundle) WriteToFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.New(fmt.Sprintf("The bundle file could not be created. Path: %s, Error: %v", path, err))
+	}
+	defer f.Close()
+	zw := gzip.NewWriter(f)
+	err2 := json.NewEncoder(zw).Encode(b)
+	if err2 != nil {
+		return errors.New(fmt.Sprintf("The bundle could not be written. Path: %s, Error: %v", path, err2))
+	}
+	return zw.Close()
+}
+
+// ReadBundleFromFile reads a bundle from the given path. This does not verify the bundle, see Verify.
+func ReadBundleFromFile(path string) (Bundle, error) {
+	var b Bundle
+	f, err := os.Open(path)
+	if err != nil {
+		return b, errors.New(fmt.Sprintf("The bundle file could not be opened. Path: %s, Error: %v", path, err))
+	}
+	defer f.Close()
+	zr, err2 := gzip.NewReader(f)
+	if err2 != nil {
+		return b, errors.New(fmt.Sprintf("The bundle file is not a valid bundle. Path: %s, Error: %v", path, err2))
+	}
+	defer zr.Close()
+	err3 := json.NewDecoder(zr).Decode(&b)
+	if err3 != nil {
+		return b, errors.New(fmt.Sprintf("The bundle file is malformed. Path: %s, Error: %v", path, err3))
+	}
+	if b.BundleVersion != BUNDLE_VERSION {
+		return b, errors.New(fmt.Sprintf("This version of the bundle is not supported. Bundle version: %d", b.BundleVersion))
+	}
+	return b, nil
+}
+
+// Verify verifies every page of the bundle the same way we verify the pages that arrive over the network, and returns the entities in the pages that passed. All pages have to be signed by the node that created the bundle, a bundle cannot be stitched together from pages of different nodes.
+func (b *Bundle) Verify() (Response, []error) {
+	var resp Response
+	errs := []error{}
+	for i, _ := range b.Pages {
+		page := b.Pages[i]
+		if page.NodePublicKey != b.NodePublicKey {
+			errs = append(errs, errors.New(fmt.Sprintf("This page of the bundle is signed by a different node than the bundle. Page: %d", i)))
+			continue
+		}
+		err := VerifyPage(&page)
+		if err != nil {
+			errs = append(errs, errors.New(fmt.Sprintf("This page of the bundle failed verification. Page: %d, Error: %v", i, err)))
+			continue
+		}
+		pageResp := InsertApiResponseToResponse(Response{}, page)
+		resp.Insert(&pageResp)
+	}
+	return resp, errs
+}
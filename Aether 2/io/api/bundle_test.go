@@ -0,0 +1,47 @@
+package api_test
+
+import (
+	"aether-core/io/api"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Tests
+
+func TestBundle_WriteRead_Success(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "bundletest")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.mimbundle")
+	b := api.Bundle{BundleVersion: api.BUNDLE_VERSION, NodePublicKey: "pk", Since: 1, Until: 2}
+	page := api.ApiResponse{NodePublicKey: "pk"}
+	page.ResponseBody.Posts = []api.Post{api.Post{}}
+	page.ResponseBody.Posts[0].Fingerprint = "postfp"
+	b.Pages = append(b.Pages, page)
+	err := b.WriteToFile(path)
+	if err != nil {
+		t.Errorf("Test failed, err: '%s'", err)
+	}
+	b2, err2 := api.ReadBundleFromFile(path)
+	if err2 != nil {
+		t.Errorf("Test failed, err: '%s'", err2)
+	}
+	if len(b2.Pages) != 1 || len(b2.Pages[0].ResponseBody.Posts) != 1 || b2.Pages[0].ResponseBody.Posts[0].Fingerprint != "postfp" {
+		t.Errorf("Test failed, the bundle read back is not the same as the one written. Bundle: %#v", b2)
+	}
+	// A bundle should never overwrite an existing file.
+	err3 := b.WriteToFile(path)
+	if err3 == nil {
+		t.Errorf("Test failed, writing a bundle over an existing file should fail.")
+	}
+}
+
+func TestBundle_ForeignPage_Fail(t *testing.T) {
+	b := api.Bundle{BundleVersion: api.BUNDLE_VERSION, NodePublicKey: "pk"}
+	b.Pages = append(b.Pages, api.ApiResponse{NodePublicKey: "someotherpk"})
+	_, errs := b.Verify()
+	if len(errs) != 1 {
+		t.Errorf("Test failed, a page signed by a different node should fail verification. Errors: %#v", errs)
+	}
+}
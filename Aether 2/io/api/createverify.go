@@ -29,6 +29,8 @@ func isFrontend() bool {
 func (b *Board) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if b.GetVersion() == 1 {
 		return createBoardPoW_V1(b, keyPair, difficulty)
+	} else if b.GetVersion() == 2 {
+		return createBoardPoW_V2(b, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW creation of this version of this entity is not supported in this version of the app. Entity: %#v", b))
 	}
@@ -37,6 +39,8 @@ func (b *Board) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 func (t *Thread) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if t.GetVersion() == 1 {
 		return createThreadPoW_V1(t, keyPair, difficulty)
+	} else if t.GetVersion() == 2 {
+		return createThreadPoW_V2(t, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW creation of this version of this entity is not supported in this version of the app. Entity: %#v", t))
 	}
@@ -45,6 +49,8 @@ func (t *Thread) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 func (p *Post) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if p.GetVersion() == 1 {
 		return createPostPoW_V1(p, keyPair, difficulty)
+	} else if p.GetVersion() == 2 {
+		return createPostPoW_V2(p, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW creation of this version of this entity is not supported in this version of the app. Entity: %#v", p))
 	}
@@ -53,6 +59,8 @@ func (p *Post) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 func (v *Vote) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if v.GetVersion() == 1 {
 		return createVotePoW_V1(v, keyPair, difficulty)
+	} else if v.GetVersion() == 2 {
+		return createVotePoW_V2(v, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW creation of this version of this entity is not supported in this version of the app. Entity: %#v", v))
 	}
@@ -61,6 +69,8 @@ func (v *Vote) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 func (k *Key) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if k.GetVersion() == 1 {
 		return createKeyPoW_V1(k, keyPair, difficulty)
+	} else if k.GetVersion() == 2 {
+		return createKeyPoW_V2(k, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW creation of this version of this entity is not supported in this version of the app. Entity: %#v", k))
 	}
@@ -69,6 +79,8 @@ func (k *Key) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 func (ts *Truststate) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if ts.GetVersion() == 1 {
 		return createTruststatePoW_V1(ts, keyPair, difficulty)
+	} else if ts.GetVersion() == 2 {
+		return createTruststatePoW_V2(ts, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW creation of this version of this entity is not supported in this version of the app. Entity: %#v", ts))
 	}
@@ -79,6 +91,8 @@ func (ts *Truststate) CreatePoW(keyPair *ed25519.PrivateKey, difficulty int) err
 func (b *Board) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if b.GetVersion() == 1 {
 		return createBoardUpdatePoW_V1(b, keyPair, difficulty)
+	} else if b.GetVersion() == 2 {
+		return createBoardUpdatePoW_V2(b, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW update creation of this version of this entity is not supported in this version of the app. Entity: %#v", b))
 	}
@@ -87,6 +101,8 @@ func (b *Board) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) err
 func (t *Thread) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if t.GetVersion() == 1 {
 		return createThreadUpdatePoW_V1(t, keyPair, difficulty)
+	} else if t.GetVersion() == 2 {
+		return createThreadUpdatePoW_V2(t, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW update creation of this version of this entity is not supported in this version of the app. Entity: %#v", t))
 	}
@@ -95,6 +111,8 @@ func (t *Thread) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) er
 func (p *Post) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if p.GetVersion() == 1 {
 		return createPostUpdatePoW_V1(p, keyPair, difficulty)
+	} else if p.GetVersion() == 2 {
+		return createPostUpdatePoW_V2(p, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW update creation of this version of this entity is not supported in this version of the app. Entity: %#v", p))
 	}
@@ -103,6 +121,8 @@ func (p *Post) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) erro
 func (v *Vote) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if v.GetVersion() == 1 {
 		return createVoteUpdatePoW_V1(v, keyPair, difficulty)
+	} else if v.GetVersion() == 2 {
+		return createVoteUpdatePoW_V2(v, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW update creation of this version of this entity is not supported in this version of the app. Entity: %#v", v))
 	}
@@ -111,6 +131,8 @@ func (v *Vote) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) erro
 func (k *Key) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if k.GetVersion() == 1 {
 		return createKeyUpdatePoW_V1(k, keyPair, difficulty)
+	} else if k.GetVersion() == 2 {
+		return createKeyUpdatePoW_V2(k, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW update creation of this version of this entity is not supported in this version of the app. Entity: %#v", k))
 	}
@@ -119,6 +141,8 @@ func (k *Key) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) error
 func (ts *Truststate) CreateUpdatePoW(keyPair *ed25519.PrivateKey, difficulty int) error {
 	if ts.GetVersion() == 1 {
 		return createTruststateUpdatePoW_V1(ts, keyPair, difficulty)
+	} else if ts.GetVersion() == 2 {
+		return createTruststateUpdatePoW_V2(ts, keyPair, difficulty)
 	} else {
 		return errors.New(fmt.Sprintf("PoW update creation of this version of this entity is not supported in this version of the app. Entity: %#v", ts))
 	}
@@ -132,6 +156,8 @@ func (b *Board) VerifyPoW(pubKey string) (bool, error) {
 	}
 	if b.GetVersion() == 1 {
 		return verifyBoardPoW_V1(b, pubKey)
+	} else if b.GetVersion() == 2 {
+		return verifyBoardPoW_V2(b, pubKey)
 	} else {
 		logging.Log(1, fmt.Sprintf("PoW verification of this version of this entity is not supported in this version of the app. Entity: %#v", b))
 		return false, nil
@@ -144,6 +170,8 @@ func (t *Thread) VerifyPoW(pubKey string) (bool, error) {
 	}
 	if t.GetVersion() == 1 {
 		return verifyThreadPoW_V1(t, pubKey)
+	} else if t.GetVersion() == 2 {
+		return verifyThreadPoW_V2(t, pubKey)
 	} else {
 		logging.Log(1, fmt.Sprintf("PoW verification of this version of this entity is not supported in this version of the app. Entity: %#v", t))
 		return false, nil
@@ -156,6 +184,8 @@ func (p *Post) VerifyPoW(pubKey string) (bool, error) {
 	}
 	if p.GetVersion() == 1 {
 		return verifyPostPoW_V1(p, pubKey)
+	} else if p.GetVersion() == 2 {
+		return verifyPostPoW_V2(p, pubKey)
 	} else {
 		logging.Log(1, fmt.Sprintf("PoW verification of this version of this entity is not supported in this version of the app. Entity: %#v", p))
 		return false, nil
@@ -168,6 +198,8 @@ func (v *Vote) VerifyPoW(pubKey string) (bool, error) {
 	}
 	if v.GetVersion() == 1 {
 		return verifyVotePoW_V1(v, pubKey)
+	} else if v.GetVersion() == 2 {
+		return verifyVotePoW_V2(v, pubKey)
 	} else {
 		logging.Log(1, fmt.Sprintf("PoW verification of this version of this entity is not supported in this version of the app. Entity: %#v", v))
 		return false, nil
@@ -180,6 +212,8 @@ func (k *Key) VerifyPoW(pubKey string) (bool, error) {
 	}
 	if k.GetVersion() == 1 {
 		return verifyKeyPoW_V1(k, pubKey)
+	} else if k.GetVersion() == 2 {
+		return verifyKeyPoW_V2(k, pubKey)
 	} else {
 		logging.Log(1, fmt.Sprintf("PoW verification of this version of this entity is not supported in this version of the app. Entity: %#v", k))
 		return false, nil
@@ -192,6 +226,8 @@ func (ts *Truststate) VerifyPoW(pubKey string) (bool, error) {
 	}
 	if ts.GetVersion() == 1 {
 		return verifyTruststatePoW_V1(ts, pubKey)
+	} else if ts.GetVersion() == 2 {
+		return verifyTruststatePoW_V2(ts, pubKey)
 	} else {
 		logging.Log(1, fmt.Sprintf("PoW verification of this version of this entity is not supported in this version of the app. Entity: %#v", ts))
 		return false, nil
@@ -204,6 +240,9 @@ func (b *Board) CreateFingerprint() error {
 	if b.GetVersion() == 1 {
 		createBoardFp_V1(b)
 		return nil
+	} else if b.GetVersion() == 2 {
+		createBoardFp_V2(b)
+		return nil
 	} else {
 		return errors.New(fmt.Sprintf("Fingerprint creation of this version of this entity is not supported in this version of the app. Entity: %#v", b))
 	}
@@ -213,6 +252,9 @@ func (t *Thread) CreateFingerprint() error {
 	if t.GetVersion() == 1 {
 		createThreadFp_V1(t)
 		return nil
+	} else if t.GetVersion() == 2 {
+		createThreadFp_V2(t)
+		return nil
 	} else {
 		return errors.New(fmt.Sprintf("Fingerprint creation of this version of this entity is not supported in this version of the app. Entity: %#v", t))
 	}
@@ -222,6 +264,9 @@ func (p *Post) CreateFingerprint() error {
 	if p.GetVersion() == 1 {
 		createPostFp_V1(p)
 		return nil
+	} else if p.GetVersion() == 2 {
+		createPostFp_V2(p)
+		return nil
 	} else {
 		return errors.New(fmt.Sprintf("Fingerprint creation of this version of this entity is not supported in this version of the app. Entity: %#v", p))
 	}
@@ -231,6 +276,9 @@ func (v *Vote) CreateFingerprint() error {
 	if v.GetVersion() == 1 {
 		createVoteFp_V1(v)
 		return nil
+	} else if v.GetVersion() == 2 {
+		createVoteFp_V2(v)
+		return nil
 	} else {
 		return errors.New(fmt.Sprintf("Fingerprint creation of this version of this entity is not supported in this version of the app. Entity: %#v", v))
 	}
@@ -240,6 +288,9 @@ func (k *Key) CreateFingerprint() error {
 	if k.GetVersion() == 1 {
 		createKeyFp_V1(k)
 		return nil
+	} else if k.GetVersion() == 2 {
+		createKeyFp_V2(k)
+		return nil
 	} else {
 		return errors.New(fmt.Sprintf("Fingerprint creation of this version of this entity is not supported in this version of the app. Entity: %#v", k))
 	}
@@ -249,6 +300,9 @@ func (ts *Truststate) CreateFingerprint() error {
 	if ts.GetVersion() == 1 {
 		createTruststateFp_V1(ts)
 		return nil
+	} else if ts.GetVersion() == 2 {
+		createTruststateFp_V2(ts)
+		return nil
 	} else {
 		return errors.New(fmt.Sprintf("Fingerprint creation of this version of this entity is not supported in this version of the app. Entity: %#v", ts))
 	}
@@ -261,6 +315,8 @@ func (b *Board) VerifyFingerprint() bool {
 	}
 	if b.GetVersion() == 1 {
 		return verifyBoardFingerprint_V1(b)
+	} else if b.GetVersion() == 2 {
+		return verifyBoardFingerprint_V2(b)
 	} else {
 		logging.Log(1, fmt.Sprintf("Fingerprint verification of this version of this entity is not supported in this version of the app. Entity: %#v", b))
 		return false
@@ -273,6 +329,8 @@ func (t *Thread) VerifyFingerprint() bool {
 	}
 	if t.GetVersion() == 1 {
 		return verifyThreadFingerprint_V1(t)
+	} else if t.GetVersion() == 2 {
+		return verifyThreadFingerprint_V2(t)
 	} else {
 		logging.Log(1, fmt.Sprintf("Fingerprint verification of this version of this entity is not supported in this version of the app. Entity: %#v", t))
 		return false
@@ -285,6 +343,8 @@ func (p *Post) VerifyFingerprint() bool {
 	}
 	if p.GetVersion() == 1 {
 		return verifyPostFingerprint_V1(p)
+	} else if p.GetVersion() == 2 {
+		return verifyPostFingerprint_V2(p)
 	} else {
 		logging.Log(1, fmt.Sprintf("Fingerprint verification of this version of this entity is not supported in this version of the app. Entity: %#v", p))
 		return false
@@ -297,6 +357,8 @@ func (v *Vote) VerifyFingerprint() bool {
 	}
 	if v.GetVersion() == 1 {
 		return verifyVoteFingerprint_V1(v)
+	} else if v.GetVersion() == 2 {
+		return verifyVoteFingerprint_V2(v)
 	} else {
 		logging.Log(1, fmt.Sprintf("Fingerprint verification of this version of this entity is not supported in this version of the app. Entity: %#v", v))
 		return false
@@ -309,6 +371,8 @@ func (k *Key) VerifyFingerprint() bool {
 	}
 	if k.GetVersion() == 1 {
 		return verifyKeyFingerprint_V1(k)
+	} else if k.GetVersion() == 2 {
+		return verifyKeyFingerprint_V2(k)
 	} else {
 		logging.Log(1, fmt.Sprintf("Fingerprint verification of this version of this entity is not supported in this version of the app. Entity: %#v", k))
 		return false
@@ -321,6 +385,8 @@ func (ts *Truststate) VerifyFingerprint() bool {
 	}
 	if ts.GetVersion() == 1 {
 		return verifyTruststateFingerprint_V1(ts)
+	} else if ts.GetVersion() == 2 {
+		return verifyTruststateFingerprint_V2(ts)
 	} else {
 		logging.Log(1, fmt.Sprintf("Fingerprint verification of this version of this entity is not supported in this version of the app. Entity: %#v", ts))
 		return false
@@ -332,6 +398,8 @@ func (ts *Truststate) VerifyFingerprint() bool {
 func (b *Board) CreateSignature(keyPair *ed25519.PrivateKey) error {
 	if b.GetVersion() == 1 {
 		return createBoardSignature_V1(b, keyPair)
+	} else if b.GetVersion() == 2 {
+		return createBoardSignature_V2(b, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", b))
 	}
@@ -340,6 +408,8 @@ func (b *Board) CreateSignature(keyPair *ed25519.PrivateKey) error {
 func (t *Thread) CreateSignature(keyPair *ed25519.PrivateKey) error {
 	if t.GetVersion() == 1 {
 		return createThreadSignature_V1(t, keyPair)
+	} else if t.GetVersion() == 2 {
+		return createThreadSignature_V2(t, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", t))
 	}
@@ -348,6 +418,8 @@ func (t *Thread) CreateSignature(keyPair *ed25519.PrivateKey) error {
 func (p *Post) CreateSignature(keyPair *ed25519.PrivateKey) error {
 	if p.GetVersion() == 1 {
 		return createPostSignature_V1(p, keyPair)
+	} else if p.GetVersion() == 2 {
+		return createPostSignature_V2(p, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", p))
 	}
@@ -356,6 +428,8 @@ func (p *Post) CreateSignature(keyPair *ed25519.PrivateKey) error {
 func (v *Vote) CreateSignature(keyPair *ed25519.PrivateKey) error {
 	if v.GetVersion() == 1 {
 		return createVoteSignature_V1(v, keyPair)
+	} else if v.GetVersion() == 2 {
+		return createVoteSignature_V2(v, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", v))
 	}
@@ -364,6 +438,8 @@ func (v *Vote) CreateSignature(keyPair *ed25519.PrivateKey) error {
 func (k *Key) CreateSignature(keyPair *ed25519.PrivateKey) error {
 	if k.GetVersion() == 1 {
 		return createKeySignature_V1(k, keyPair)
+	} else if k.GetVersion() == 2 {
+		return createKeySignature_V2(k, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", k))
 	}
@@ -372,6 +448,8 @@ func (k *Key) CreateSignature(keyPair *ed25519.PrivateKey) error {
 func (ts *Truststate) CreateSignature(keyPair *ed25519.PrivateKey) error {
 	if ts.GetVersion() == 1 {
 		return createTruststateSignature_V1(ts, keyPair)
+	} else if ts.GetVersion() == 2 {
+		return createTruststateSignature_V2(ts, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", ts))
 	}
@@ -382,6 +460,8 @@ func (ts *Truststate) CreateSignature(keyPair *ed25519.PrivateKey) error {
 func (b *Board) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 	if b.GetVersion() == 1 {
 		return createBoardUpdateSignature_V1(b, keyPair)
+	} else if b.GetVersion() == 2 {
+		return createBoardUpdateSignature_V2(b, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", b))
 	}
@@ -390,6 +470,8 @@ func (b *Board) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 func (t *Thread) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 	if t.GetVersion() == 1 {
 		return createThreadUpdateSignature_V1(t, keyPair)
+	} else if t.GetVersion() == 2 {
+		return createThreadUpdateSignature_V2(t, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", t))
 	}
@@ -398,6 +480,8 @@ func (t *Thread) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 func (p *Post) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 	if p.GetVersion() == 1 {
 		return createPostUpdateSignature_V1(p, keyPair)
+	} else if p.GetVersion() == 2 {
+		return createPostUpdateSignature_V2(p, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", p))
 	}
@@ -406,6 +490,8 @@ func (p *Post) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 func (v *Vote) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 	if v.GetVersion() == 1 {
 		return createVoteUpdateSignature_V1(v, keyPair)
+	} else if v.GetVersion() == 2 {
+		return createVoteUpdateSignature_V2(v, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", v))
 	}
@@ -414,6 +500,8 @@ func (v *Vote) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 func (k *Key) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 	if k.GetVersion() == 1 {
 		return createKeyUpdateSignature_V1(k, keyPair)
+	} else if k.GetVersion() == 2 {
+		return createKeyUpdateSignature_V2(k, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", k))
 	}
@@ -422,6 +510,8 @@ func (k *Key) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 func (ts *Truststate) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 	if ts.GetVersion() == 1 {
 		return createTruststateUpdateSignature_V1(ts, keyPair)
+	} else if ts.GetVersion() == 2 {
+		return createTruststateUpdateSignature_V2(ts, keyPair)
 	} else {
 		return errors.New(fmt.Sprintf("Signature creation of this version of this entity is not supported in this version of the app. Entity: %#v", ts))
 	}
@@ -430,105 +520,101 @@ func (ts *Truststate) CreateUpdateSignature(keyPair *ed25519.PrivateKey) error {
 // Verify Signature
 
 func (b *Board) VerifySignature(pubKey string) (bool, error) {
-	if !isFrontend() && !globals.BackendTransientConfig.SignatureCheckEnabled {
-		// If signature check is disabled with a debug flag, then we unconditionally return true.
-		return true, nil
-	}
-	if !isFrontend() && globals.BackendConfig.GetAllowUnsignedEntities() && len(b.Signature) == 0 {
-		// If Allow Unsigned Entities is true, we allow for anonymous posts without signature, but if there is a signature present, we still want to do the signature check. Allow Unsigned Entities does not mean that we will allow invalid signatures.
-		return true, nil
-	}
-	if b.GetVersion() == 1 {
-		return verifyBoardSignature_V1(b, pubKey)
-	} else {
-		logging.Log(1, fmt.Sprintf("Signature verification of this version of this entity is not supported in this version of the app. Entity: %#v", b))
-		return false, nil
-	}
+	return verifySignature(b, pubKey)
 }
 
 func (t *Thread) VerifySignature(pubKey string) (bool, error) {
-	if !isFrontend() && !globals.BackendTransientConfig.SignatureCheckEnabled {
-		// If signature check is disabled with a debug flag, then we unconditionally return true.
-		return true, nil
-	}
-	if !isFrontend() && globals.BackendConfig.GetAllowUnsignedEntities() && len(t.Signature) == 0 {
-		// If Allow Unsigned Entities is true, we allow for anonymous posts without signature, but if there is a signature present, we still want to do the signature check. Allow Unsigned Entities does not mean that we will allow invalid signatures.
-		return true, nil
-	}
-	if t.GetVersion() == 1 {
-		return verifyThreadSignature_V1(t, pubKey)
-	} else {
-		logging.Log(1, fmt.Sprintf("Signature verification of this version of this entity is not supported in this version of the app. Entity: %#v", t))
-		return false, nil
-	}
+	return verifySignature(t, pubKey)
 }
 
 func (p *Post) VerifySignature(pubKey string) (bool, error) {
-	if !isFrontend() && !globals.BackendTransientConfig.SignatureCheckEnabled {
-		// If signature check is disabled with a debug flag, then we unconditionally return true.
-		return true, nil
-	}
-	if !isFrontend() && globals.BackendConfig.GetAllowUnsignedEntities() && len(p.Signature) == 0 {
-		// If Allow Unsigned Entities is true, we allow for anonymous posts without signature, but if there is a signature present, we still want to do the signature check. Allow Unsigned Entities does not mean that we will allow invalid signatures.
-		return true, nil
-	}
-	if p.GetVersion() == 1 {
-		return verifyPostSignature_V1(p, pubKey)
-	} else {
-		logging.Log(1, fmt.Sprintf("Signature verification of this version of this entity is not supported in this version of the app. Entity: %#v", p))
-		return false, nil
-	}
+	return verifySignature(p, pubKey)
 }
 
 func (v *Vote) VerifySignature(pubKey string) (bool, error) {
-	if !isFrontend() && !globals.BackendTransientConfig.SignatureCheckEnabled {
-		// If signature check is disabled with a debug flag, then we unconditionally return true.
-		return true, nil
-	}
-	if !isFrontend() && globals.BackendConfig.GetAllowUnsignedEntities() && len(v.Signature) == 0 {
-		// If Allow Unsigned Entities is true, we allow for anonymous posts without signature, but if there is a signature present, we still want to do the signature check. Allow Unsigned Entities does not mean that we will allow invalid signatures.
-		return true, nil
-	}
-	if v.GetVersion() == 1 {
-		return verifyVoteSignature_V1(v, pubKey)
-	} else {
-		logging.Log(1, fmt.Sprintf("Signature verification of this version of this entity is not supported in this version of the app. Entity: %#v", v))
-		return false, nil
-	}
+	return verifySignature(v, pubKey)
 }
 
 func (k *Key) VerifySignature(pubKey string) (bool, error) {
-	if !isFrontend() && !globals.BackendTransientConfig.SignatureCheckEnabled {
-		// If signature check is disabled with a debug flag, then we unconditionally return true.
-		return true, nil
+	return verifySignature(k, pubKey)
+}
+
+func (ts *Truststate) VerifySignature(pubKey string) (bool, error) {
+	return verifySignature(ts, pubKey)
+}
+
+func verifySignature(e Provable, pubKey string) (bool, error) {
+	input, signature, skip, err := signatureCheck(e)
+	if err != nil {
+		logging.Log(1, err.Error())
+		return false, nil
 	}
-	if !isFrontend() && globals.BackendConfig.GetAllowUnsignedEntities() && len(k.Signature) == 0 {
-		// If Allow Unsigned Entities is true, we allow for anonymous posts without signature, but if there is a signature present, we still want to do the signature check. Allow Unsigned Entities does not mean that we will allow invalid signatures.
+	if skip {
 		return true, nil
 	}
-	if k.GetVersion() == 1 {
-		return verifyKeySignature_V1(k, pubKey)
-	} else {
-		logging.Log(1, fmt.Sprintf("Signature verification of this version of this entity is not supported in this version of the app. Entity: %#v", k))
-		return false, nil
+	if signaturing.Verify(input, signature, pubKey) {
+		return true, nil
 	}
+	return false, errors.New(fmt.Sprint(
+		"This signature is invalid, but no reason given as to why. Signature: ", signature))
 }
 
-func (ts *Truststate) VerifySignature(pubKey string) (bool, error) {
+// signatureCheck returns what the signature of the entity is over, and the signature itself, for the version of the entity. skip is true if the signature is not to be checked at all. This is the one place that decides how a signature is checked, VerifySignature and the verify pipeline both go by it.
+func signatureCheck(e Provable) (input string, signature string, skip bool, err error) {
 	if !isFrontend() && !globals.BackendTransientConfig.SignatureCheckEnabled {
 		// If signature check is disabled with a debug flag, then we unconditionally return true.
-		return true, nil
+		return "", "", true, nil
 	}
-	if !isFrontend() && globals.BackendConfig.GetAllowUnsignedEntities() && len(ts.Signature) == 0 {
+	if !isFrontend() && globals.BackendConfig.GetAllowUnsignedEntities() && len(e.GetSignature()) == 0 {
 		// If Allow Unsigned Entities is true, we allow for anonymous posts without signature, but if there is a signature present, we still want to do the signature check. Allow Unsigned Entities does not mean that we will allow invalid signatures.
-		return true, nil
-	}
-	if ts.GetVersion() == 1 {
-		return verifyTruststateSignature_V1(ts, pubKey)
-	} else {
-		logging.Log(1, fmt.Sprintf("Signature verification of this version of this entity is not supported in this version of the app. Entity: %#v", ts))
-		return false, nil
+		return "", "", true, nil
+	}
+	v, ok := e.(Versionable)
+	if !ok || (v.GetVersion() != 1 && v.GetVersion() != 2) {
+		return "", "", false, errors.New(fmt.Sprintf("Signature verification of this version of this entity is not supported in this version of the app. Entity: %#v", e))
+	}
+	v2 := v.GetVersion() == 2
+	switch ent := e.(type) {
+	case *Board:
+		if v2 {
+			input, signature = boardSignatureInput_V2(ent)
+		} else {
+			input, signature = boardSignatureInput_V1(ent)
+		}
+	case *Thread:
+		if v2 {
+			input, signature = threadSignatureInput_V2(ent)
+		} else {
+			input, signature = threadSignatureInput_V1(ent)
+		}
+	case *Post:
+		if v2 {
+			input, signature = postSignatureInput_V2(ent)
+		} else {
+			input, signature = postSignatureInput_V1(ent)
+		}
+	case *Vote:
+		if v2 {
+			input, signature = voteSignatureInput_V2(ent)
+		} else {
+			input, signature = voteSignatureInput_V1(ent)
+		}
+	case *Key:
+		if v2 {
+			input, signature = keySignatureInput_V2(ent)
+		} else {
+			input, signature = keySignatureInput_V1(ent)
+		}
+	case *Truststate:
+		if v2 {
+			input, signature = truststateSignatureInput_V2(ent)
+		} else {
+			input, signature = truststateSignatureInput_V1(ent)
+		}
+	default:
+		return "", "", false, errors.New(fmt.Sprintf("Signature verification of this entity type is not supported. Entity: %#v", e))
 	}
+	return input, signature, false, nil
 }
 
 // Api Response Signature Create / Verify
@@ -628,37 +714,12 @@ func (ar *ApiResponse) VerifyNonce() bool {
 func Verify(e interface{}) error {
 	switch entity := e.(type) {
 	case Provable:
-		encrypted := len(entity.GetEncrContent()) > 0
-		if encrypted {
-			return errors.New(fmt.Sprintf("This item appears to be encrypted. Please decrypt before requesting verification. EncrContent: %s, Entity: %#v", entity.GetEncrContent(), entity))
-		}
-		realmed := len(entity.GetRealmId()) > 0
-		if realmed {
-			return errors.New(fmt.Sprintf("This item appears to belong to a realm that is different than the mainnet. Non-mainnet realms are currently not supported, but might be in the future. RealmId: %s, Entity: %#v", entity.GetRealmId(), entity))
-		}
-		boundsOk, err := entity.CheckBounds()
+		_, err := verifyUntilSignature(entity)
 		if err != nil {
 			return err
 		}
-		if !boundsOk {
-			return errors.New(fmt.Sprintf("Field boundaries of this entity is invalid. Entity: %#v", entity))
-		}
-		fpOk := entity.VerifyFingerprint()
-		if !fpOk {
-			return errors.New(fmt.Sprintf(
-				"Fingerprint of this entity is invalid. Fingerprint: %s, Entity: %#v\n", entity.GetFingerprint(), entity))
-		}
-		// Bounds ok, Fp ok
-		powOk, err2 := entity.VerifyPoW(entity.GetOwnerPublicKey())
-		if err2 != nil {
-			return err2
-		}
-		if !powOk {
-			return errors.New(fmt.Sprintf(
-				"ProofOfWork of this entity is invalid. ProofOfWork: %s, Entity: %#v\n", entity.GetProofOfWork(), entity))
-		}
 		// Bounds ok, Fp ok, PoW ok
-		sigOk, err3 := entity.VerifySignature(entity.GetOwnerPublicKey())
+		sigOk, err3 := entity.VerifySignature(signingKey(entity))
 		if err3 != nil {
 			return err3
 		}
@@ -667,13 +728,7 @@ func Verify(e interface{}) error {
 				"Signature of this entity is invalid. Signature: %s, Entity: %#v\n", entity.GetSignature(), entity))
 		}
 		// Bounds ok, Fp ok, PoW ok, Sig ok
-		entOk := entity.VerifyEntitlements()
-		if !entOk {
-			return errors.New(fmt.Sprintf(
-				"Entitlements of this entity is invalid. This entity is attempting to do something that it is not authorised to do. (Ex: A CA-specific TypeClass from a CA that we do not trust.) Entity: %#v\n", entity))
-		}
-		entity.SetVerified(true)
-		return nil
+		return verifyEntitlementsAndMark(entity)
 
 	case *Address:
 		boundsOk, err := entity.CheckBounds()
@@ -693,7 +748,62 @@ func Verify(e interface{}) error {
 
 }
 
+// verifyUntilSignature runs the checks of Verify that come before the signature, and returns the stage that failed, if any. (See verifypipeline.go for the stages.)
+func verifyUntilSignature(entity Provable) (verificationStage, error) {
+	encrypted := len(entity.GetEncrContent()) > 0
+	if encrypted {
+		return stagePreconditions, errors.New(fmt.Sprintf("This item appears to be encrypted. Please decrypt before requesting verification. EncrContent: %s, Entity: %#v", entity.GetEncrContent(), entity))
+	}
+	realmed := len(entity.GetRealmId()) > 0
+	if realmed {
+		return stagePreconditions, errors.New(fmt.Sprintf("This item appears to belong to a realm that is different than the mainnet. Non-mainnet realms are currently not supported, but might be in the future. RealmId: %s, Entity: %#v", entity.GetRealmId(), entity))
+	}
+	boundsOk, err := entity.CheckBounds()
+	if err != nil {
+		return stageBounds, err
+	}
+	if !boundsOk {
+		return stageBounds, errors.New(fmt.Sprintf("Field boundaries of this entity is invalid. Entity: %#v", entity))
+	}
+	fpOk := entity.VerifyFingerprint()
+	if !fpOk {
+		return stageFingerprint, errors.New(fmt.Sprintf(
+			"Fingerprint of this entity is invalid. Fingerprint: %s, Entity: %#v\n", entity.GetFingerprint(), entity))
+	}
+	// Bounds ok, Fp ok
+	powOk, err2 := entity.VerifyPoW(signingKey(entity))
+	if err2 != nil {
+		return stagePoW, err2
+	}
+	if !powOk {
+		return stagePoW, errors.New(fmt.Sprintf(
+			"ProofOfWork of this entity is invalid. ProofOfWork: %s, Entity: %#v\n", entity.GetProofOfWork(), entity))
+	}
+	return stageNone, nil
+}
+
+// verifyEntitlementsAndMark runs the last check of Verify, after the signature, and marks the entity as verified if it passes.
+func verifyEntitlementsAndMark(entity Provable) error {
+	entOk := entity.VerifyEntitlements()
+	if !entOk {
+		return errors.New(fmt.Sprintf(
+			"Entitlements of this entity is invalid. This entity is attempting to do something that it is not authorised to do. (Ex: A CA-specific TypeClass from a CA that we do not trust.) Entity: %#v\n", entity))
+	}
+	entity.SetVerified(true)
+	return nil
+}
+
+// The entitlements below are the ones that can be checked on the entity alone. The ones that need the entities it refers to are in CheckEntitlements. (See entitlements.go)
+
+// A key can be listed as a board owner only once.
 func (e *Board) VerifyEntitlements() bool {
+	seen := make(map[Fingerprint]bool)
+	for _, bo := range e.BoardOwners {
+		if seen[bo.KeyFingerprint] {
+			return false
+		}
+		seen[bo.KeyFingerprint] = true
+	}
 	return true
 }
 
@@ -701,16 +811,19 @@ func (e *Thread) VerifyEntitlements() bool {
 	return true
 }
 
+// A post can't be its own thread, or reply to itself.
 func (e *Post) VerifyEntitlements() bool {
-	return true
+	return e.Thread != e.Fingerprint && e.Parent != e.Fingerprint
 }
 
+// A vote can't target itself.
 func (e *Vote) VerifyEntitlements() bool {
-	return true
+	return e.Target != e.Fingerprint
 }
 
+// A key can't expire before it is created.
 func (e *Key) VerifyEntitlements() bool {
-	return true
+	return e.Expiry == 0 || e.Expiry > e.Creation
 }
 
 /*
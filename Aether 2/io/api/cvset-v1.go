@@ -360,6 +360,8 @@ func verifyThreadPoW_V1(t *Thread, pubKey string) (bool, error) {
 		// Delete PoW so that the PoW will match
 		cpI.ProofOfWork = ""
 	}
+	// Attachments make the proof of work harder, see AttachmentPoWBits.
+	neededStrength = neededStrength + AttachmentPoWBits("Thread", cpI.Meta)
 	// Convert to JSON
 	res, _ := json.Marshal(cpI)
 	// Verify PoW
@@ -416,6 +418,8 @@ func verifyPostPoW_V1(p *Post, pubKey string) (bool, error) {
 		// Delete PoW so that the PoW will match
 		cpI.ProofOfWork = ""
 	}
+	// Attachments make the proof of work harder, see AttachmentPoWBits.
+	neededStrength = neededStrength + AttachmentPoWBits("Post", cpI.Meta)
 	// Convert to JSON
 	res, _ := json.Marshal(cpI)
 	// Verify PoW
@@ -1140,7 +1144,8 @@ func createTruststateUpdateSignature_V1(ts *Truststate, keyPair *ed25519.Private
 
 // // Verify Signature
 
-func verifyBoardSignature_V1(b *Board, pubKey string) (bool, error) {
+// boardSignatureInput_V1 returns what the signature of the board is over, and the signature itself.
+func boardSignatureInput_V1(b *Board) (string, string) {
 	cpI := *b
 	var signature string
 	// Determine if we are checking for original or update signature
@@ -1167,18 +1172,11 @@ func verifyBoardSignature_V1(b *Board, pubKey string) (bool, error) {
 	}
 	// Convert to JSON
 	res, _ := json.Marshal(cpI)
-	// Verify Signature
-	verifyResult := signaturing.Verify(string(res), signature, pubKey)
-	// If the Signature is valid
-	if verifyResult {
-		return true, nil
-	} else {
-		return false, errors.New(fmt.Sprint(
-			"This signature is invalid, but no reason given as to why. Signature: ", signature))
-	}
+	return string(res), signature
 }
 
-func verifyThreadSignature_V1(t *Thread, pubKey string) (bool, error) {
+// threadSignatureInput_V1 returns what the signature of the thread is over, and the signature itself.
+func threadSignatureInput_V1(t *Thread) (string, string) {
 	cpI := *t
 	var signature string
 	// Determine if we are checking for original or update signature
@@ -1205,18 +1203,11 @@ func verifyThreadSignature_V1(t *Thread, pubKey string) (bool, error) {
 	}
 	// Convert to JSON
 	res, _ := json.Marshal(cpI)
-	// Verify Signature
-	verifyResult := signaturing.Verify(string(res), signature, pubKey)
-	// If the Signature is valid
-	if verifyResult {
-		return true, nil
-	} else {
-		return false, errors.New(fmt.Sprint(
-			"This signature is invalid, but no reason given as to why. Signature: ", signature))
-	}
+	return string(res), signature
 }
 
-func verifyPostSignature_V1(p *Post, pubKey string) (bool, error) {
+// postSignatureInput_V1 returns what the signature of the post is over, and the signature itself.
+func postSignatureInput_V1(p *Post) (string, string) {
 	cpI := *p
 	var signature string
 	// Determine if we are checking for original or update signature
@@ -1243,18 +1234,11 @@ func verifyPostSignature_V1(p *Post, pubKey string) (bool, error) {
 	}
 	// Convert to JSON
 	res, _ := json.Marshal(cpI)
-	// Verify Signature
-	verifyResult := signaturing.Verify(string(res), signature, pubKey)
-	// If the Signature is valid
-	if verifyResult {
-		return true, nil
-	} else {
-		return false, errors.New(fmt.Sprint(
-			"This signature is invalid, but no reason given as to why. Signature: ", signature))
-	}
+	return string(res), signature
 }
 
-func verifyVoteSignature_V1(v *Vote, pubKey string) (bool, error) {
+// voteSignatureInput_V1 returns what the signature of the vote is over, and the signature itself.
+func voteSignatureInput_V1(v *Vote) (string, string) {
 	cpI := *v
 	var signature string
 	// Determine if we are checking for original or update signature
@@ -1281,18 +1265,11 @@ func verifyVoteSignature_V1(v *Vote, pubKey string) (bool, error) {
 	}
 	// Convert to JSON
 	res, _ := json.Marshal(cpI)
-	// Verify Signature
-	verifyResult := signaturing.Verify(string(res), signature, pubKey)
-	// If the Signature is valid
-	if verifyResult {
-		return true, nil
-	} else {
-		return false, errors.New(fmt.Sprint(
-			"This signature is invalid, but no reason given as to why. Signature: ", signature))
-	}
+	return string(res), signature
 }
 
-func verifyKeySignature_V1(k *Key, pubKey string) (bool, error) {
+// keySignatureInput_V1 returns what the signature of the key is over, and the signature itself.
+func keySignatureInput_V1(k *Key) (string, string) {
 	cpI := *k
 	var signature string
 	// Determine if we are checking for original or update signature
@@ -1319,18 +1296,11 @@ func verifyKeySignature_V1(k *Key, pubKey string) (bool, error) {
 	}
 	// Convert to JSON
 	res, _ := json.Marshal(cpI)
-	// Verify Signature
-	verifyResult := signaturing.Verify(string(res), signature, pubKey)
-	// If the Signature is valid
-	if verifyResult {
-		return true, nil
-	} else {
-		return false, errors.New(fmt.Sprint(
-			"This signature is invalid, but no reason given as to why. Signature: ", signature))
-	}
+	return string(res), signature
 }
 
-func verifyTruststateSignature_V1(ts *Truststate, pubKey string) (bool, error) {
+// truststateSignatureInput_V1 returns what the signature of the truststate is over, and the signature itself.
+func truststateSignatureInput_V1(ts *Truststate) (string, string) {
 	cpI := *ts
 	var signature string
 	// Determine if we are checking for original or update signature
@@ -1357,13 +1327,5 @@ func verifyTruststateSignature_V1(ts *Truststate, pubKey string) (bool, error) {
 	}
 	// Convert to JSON
 	res, _ := json.Marshal(cpI)
-	// Verify Signature
-	verifyResult := signaturing.Verify(string(res), signature, pubKey)
-	// If the Signature is valid
-	if verifyResult {
-		return true, nil
-	} else {
-		return false, errors.New(fmt.Sprint(
-			"This signature is invalid, but no reason given as to why. Signature: ", signature))
-	}
+	return string(res), signature
 }
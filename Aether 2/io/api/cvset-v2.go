@@ -0,0 +1,699 @@
+// API > Create / Verify / EntitySet V2
+// This file provides the version specific create and verify methods based on entity versions. This file is for the v2 versions of the objects.
+
+package api
+
+import (
+	"aether-core/services/configstore"
+	"aether-core/services/fingerprinting"
+	"aether-core/services/globals"
+	"aether-core/services/proofofwork"
+	"aether-core/services/signaturing"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/ed25519"
+)
+
+/*
+What is different in v2?
+
+The fields of a v2 entity are the same as v1. What changes is what we hash and sign.
+
+v1 takes a copy of the entity, blanks the fields that should not be in the input, and converts it to JSON. That means the input depends on how the JSON encoder of the client writes things out: the order of the fields, whether an empty list is [] or null, how unicode is escaped. Two clients that disagree on any of those can't verify each other's entities, and the entity looks fine in both when you print it, so it is hard to find.
+
+v2 writes the input itself, in a canonical binary form:
+		A header: "aether", the entity type, and what the input is for (fingerprint, PoW, signature, or their update versions). This keeps the signature of a thread from being usable as the signature of anything else.
+		Then every field, in a fixed order:
+			Strings: their length as an uvarint, then their bytes, as they are.
+			Numbers: 8 bytes, big endian.
+			Lists: their length as an uvarint, then every item.
+
+The fields that are left out of an input are the same as v1: they are written as blank. (Which fields those are is in blankFieldSets_V2 and the input functions below.)
+
+The PoW of a v2 entity is MIM2, which hashes once with a named algorithm, instead of MIM1's SHA256x3. (See proofofwork.CreateV2)
+
+Remotes that can't verify v2 don't get v2 entities from us, see FilterForRemote.
+*/
+
+// The hash algorithm the v2 PoWs we create use. We verify all MIM2 algorithms.
+const powAlgorithm_V2 = proofofwork.AlgorithmSHA256
+
+type inputPurpose uint8
+
+const (
+	inputFingerprint inputPurpose = iota + 1
+	inputPoW
+	inputUpdatePoW
+	inputSignature
+	inputUpdateSignature
+)
+
+// canonicalWriter writes the canonical binary form of an entity.
+type canonicalWriter struct {
+	buf bytes.Buffer
+}
+
+func newCanonicalWriter(entityType string, purpose inputPurpose) *canonicalWriter {
+	w := canonicalWriter{}
+	w.str("aether")
+	w.str(entityType)
+	w.buf.WriteByte(byte(purpose))
+	return &w
+}
+
+func (w *canonicalWriter) uvarint(n uint64) {
+	b := make([]byte, binary.MaxVarintLen64)
+	w.buf.Write(b[:binary.PutUvarint(b, n)])
+}
+
+func (w *canonicalWriter) str(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+func (w *canonicalWriter) int(n int64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(n))
+	w.buf.Write(b)
+}
+
+func (w *canonicalWriter) provable(p *ProvableFieldSet) {
+	w.str(string(p.Fingerprint))
+	w.int(int64(p.Creation))
+	w.str(string(p.ProofOfWork))
+	w.str(string(p.Signature))
+}
+
+func (w *canonicalWriter) updateable(u *UpdateableFieldSet) {
+	w.int(int64(u.LastUpdate))
+	w.str(string(u.UpdateProofOfWork))
+	w.str(string(u.UpdateSignature))
+}
+
+func (w *canonicalWriter) boardOwners(bos []BoardOwner) {
+	w.uvarint(uint64(len(bos)))
+	for key, _ := range bos {
+		w.str(string(bos[key].KeyFingerprint))
+		w.int(int64(bos[key].Expiry))
+		w.int(int64(bos[key].Level))
+	}
+}
+
+func (w *canonicalWriter) bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// blankFieldSets_V2 blanks the provable and updateable fields that are not a part of the input for the given purpose. These are the same fields v1 blanks.
+func blankFieldSets_V2(p *ProvableFieldSet, u *UpdateableFieldSet, purpose inputPurpose) {
+	switch purpose {
+	case inputFingerprint:
+		// The fingerprint covers the PoW and the signature of the original, but nothing that can be updated.
+		p.Fingerprint = ""
+		*u = UpdateableFieldSet{}
+	case inputPoW:
+		p.Fingerprint = ""
+		p.ProofOfWork = ""
+		*u = UpdateableFieldSet{}
+	case inputSignature:
+		// The signature comes first, so neither the fingerprint nor the PoW exist when it is created.
+		p.Fingerprint = ""
+		p.ProofOfWork = ""
+		p.Signature = ""
+		*u = UpdateableFieldSet{}
+	case inputUpdatePoW:
+		u.UpdateProofOfWork = ""
+	case inputUpdateSignature:
+		u.UpdateProofOfWork = ""
+		u.UpdateSignature = ""
+	}
+}
+
+func minimumPoWStrengths() configstore.MinimumPoWStrengths {
+	if isFrontend() {
+		return globals.FrontendConfig.GetMinimumPoWStrengths()
+	}
+	return globals.BackendConfig.GetMinimumPoWStrengths()
+}
+
+// Inputs
+
+func boardInput_V2(b *Board, purpose inputPurpose) []byte {
+	cpI := *b
+	blankFieldSets_V2(&cpI.ProvableFieldSet, &cpI.UpdateableFieldSet, purpose)
+	if purpose == inputFingerprint {
+		// Remove ALL mutable fields
+		cpI.BoardOwners = []BoardOwner{}
+		cpI.Description = ""
+		cpI.Meta = ""
+	}
+	w := newCanonicalWriter("board", purpose)
+	w.provable(&cpI.ProvableFieldSet)
+	w.str(cpI.Name)
+	w.boardOwners(cpI.BoardOwners)
+	w.str(cpI.Description)
+	w.str(string(cpI.Owner))
+	w.str(cpI.OwnerPublicKey)
+	w.int(int64(cpI.EntityVersion))
+	w.str(cpI.Language)
+	w.str(cpI.Meta)
+	w.str(string(cpI.RealmId))
+	w.str(cpI.EncrContent)
+	w.updateable(&cpI.UpdateableFieldSet)
+	return w.bytes()
+}
+
+func threadInput_V2(t *Thread, purpose inputPurpose) []byte {
+	cpI := *t
+	blankFieldSets_V2(&cpI.ProvableFieldSet, &cpI.UpdateableFieldSet, purpose)
+	if purpose == inputFingerprint {
+		// Remove ALL mutable fields
+		cpI.Body = ""
+		cpI.Meta = ""
+	}
+	w := newCanonicalWriter("thread", purpose)
+	w.provable(&cpI.ProvableFieldSet)
+	w.str(string(cpI.Board))
+	w.str(cpI.Name)
+	w.str(cpI.Body)
+	w.str(cpI.Link)
+	w.str(string(cpI.Owner))
+	w.str(cpI.OwnerPublicKey)
+	w.int(int64(cpI.EntityVersion))
+	w.str(cpI.Meta)
+	w.str(string(cpI.RealmId))
+	w.str(cpI.EncrContent)
+	w.updateable(&cpI.UpdateableFieldSet)
+	return w.bytes()
+}
+
+func postInput_V2(p *Post, purpose inputPurpose) []byte {
+	cpI := *p
+	blankFieldSets_V2(&cpI.ProvableFieldSet, &cpI.UpdateableFieldSet, purpose)
+	if purpose == inputFingerprint {
+		// Remove ALL mutable fields
+		cpI.Body = ""
+		cpI.Meta = ""
+	}
+	w := newCanonicalWriter("post", purpose)
+	w.provable(&cpI.ProvableFieldSet)
+	w.str(string(cpI.Board))
+	w.str(string(cpI.Thread))
+	w.str(string(cpI.Parent))
+	w.str(cpI.Body)
+	w.str(string(cpI.Owner))
+	w.str(cpI.OwnerPublicKey)
+	w.int(int64(cpI.EntityVersion))
+	w.str(cpI.Meta)
+	w.str(string(cpI.RealmId))
+	w.str(cpI.EncrContent)
+	w.updateable(&cpI.UpdateableFieldSet)
+	return w.bytes()
+}
+
+func voteInput_V2(v *Vote, purpose inputPurpose) []byte {
+	cpI := *v
+	blankFieldSets_V2(&cpI.ProvableFieldSet, &cpI.UpdateableFieldSet, purpose)
+	if purpose == inputFingerprint {
+		// Remove ALL mutable fields
+		cpI.Type = 0
+		cpI.Meta = ""
+	}
+	w := newCanonicalWriter("vote", purpose)
+	w.provable(&cpI.ProvableFieldSet)
+	w.str(string(cpI.Board))
+	w.str(string(cpI.Thread))
+	w.str(string(cpI.Target))
+	w.str(string(cpI.Owner))
+	w.str(cpI.OwnerPublicKey)
+	w.int(int64(cpI.TypeClass))
+	w.int(int64(cpI.Type))
+	w.int(int64(cpI.EntityVersion))
+	w.str(cpI.Meta)
+	w.str(string(cpI.RealmId))
+	w.str(cpI.EncrContent)
+	w.updateable(&cpI.UpdateableFieldSet)
+	return w.bytes()
+}
+
+func keyInput_V2(k *Key, purpose inputPurpose) []byte {
+	cpI := *k
+	blankFieldSets_V2(&cpI.ProvableFieldSet, &cpI.UpdateableFieldSet, purpose)
+	if purpose == inputFingerprint {
+		// Remove ALL mutable fields
+		cpI.Info = ""
+		cpI.Expiry = 0
+		cpI.Meta = ""
+	}
+	w := newCanonicalWriter("key", purpose)
+	w.provable(&cpI.ProvableFieldSet)
+	w.str(cpI.Type)
+	w.str(cpI.Key)
+	w.int(int64(cpI.Expiry))
+	w.str(cpI.Name)
+	w.str(cpI.Info)
+	w.int(int64(cpI.EntityVersion))
+	w.str(cpI.Meta)
+	w.str(string(cpI.RealmId))
+	w.str(cpI.EncrContent)
+	w.updateable(&cpI.UpdateableFieldSet)
+	return w.bytes()
+}
+
+func truststateInput_V2(ts *Truststate, purpose inputPurpose) []byte {
+	cpI := *ts
+	blankFieldSets_V2(&cpI.ProvableFieldSet, &cpI.UpdateableFieldSet, purpose)
+	if purpose == inputFingerprint {
+		// Remove ALL mutable fields
+		cpI.Type = 0
+		cpI.Expiry = 0
+		cpI.Meta = ""
+	}
+	w := newCanonicalWriter("truststate", purpose)
+	w.provable(&cpI.ProvableFieldSet)
+	w.str(string(cpI.Target))
+	w.str(string(cpI.Owner))
+	w.str(cpI.OwnerPublicKey)
+	w.int(int64(cpI.TypeClass))
+	w.int(int64(cpI.Type))
+	w.str(string(cpI.Domain))
+	w.int(int64(cpI.Expiry))
+	w.int(int64(cpI.EntityVersion))
+	w.str(cpI.Meta)
+	w.str(string(cpI.RealmId))
+	w.str(cpI.EncrContent)
+	w.updateable(&cpI.UpdateableFieldSet)
+	return w.bytes()
+}
+
+// PoW
+
+func createPoW_V2(input []byte, keyPair *ed25519.PrivateKey, difficulty int) (ProofOfWork, error) {
+	pow, err := proofofwork.CreateV2(string(input), difficulty, powAlgorithm_V2, keyPair)
+	return ProofOfWork(pow), err
+}
+
+func verifyPoW_V2(input []byte, pow ProofOfWork, pubKey string, neededStrength int) (bool, error) {
+	verifyResult, strength, err := proofofwork.Verify(string(input), string(pow), pubKey)
+	if err != nil {
+		return false, err
+	}
+	if !verifyResult {
+		return false, errors.New(fmt.Sprint(
+			"This proof of work is invalid, but no reason given as to why. PoW: ", pow))
+	}
+	if strength < neededStrength {
+		return false, errors.New(fmt.Sprint(
+			"This proof of work is not strong enough. PoW: ", pow))
+	}
+	return true, nil
+}
+
+func createBoardPoW_V2(b *Board, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(boardInput_V2(b, inputPoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	b.ProofOfWork = pow
+	return nil
+}
+
+func createBoardUpdatePoW_V2(b *Board, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(boardInput_V2(b, inputUpdatePoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	b.UpdateProofOfWork = pow
+	return nil
+}
+
+func verifyBoardPoW_V2(b *Board, pubKey string) (bool, error) {
+	strengths := minimumPoWStrengths()
+	// Determine if we are checking for original or update PoW
+	if len(b.UpdateProofOfWork) > 0 {
+		return verifyPoW_V2(boardInput_V2(b, inputUpdatePoW), b.UpdateProofOfWork, pubKey, strengths.BoardUpdate)
+	}
+	return verifyPoW_V2(boardInput_V2(b, inputPoW), b.ProofOfWork, pubKey, strengths.Board)
+}
+
+func createThreadPoW_V2(t *Thread, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(threadInput_V2(t, inputPoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	t.ProofOfWork = pow
+	return nil
+}
+
+func createThreadUpdatePoW_V2(t *Thread, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(threadInput_V2(t, inputUpdatePoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	t.UpdateProofOfWork = pow
+	return nil
+}
+
+func verifyThreadPoW_V2(t *Thread, pubKey string) (bool, error) {
+	strengths := minimumPoWStrengths()
+	// Determine if we are checking for original or update PoW
+	if len(t.UpdateProofOfWork) > 0 {
+		return verifyPoW_V2(threadInput_V2(t, inputUpdatePoW), t.UpdateProofOfWork, pubKey, strengths.ThreadUpdate+AttachmentPoWBits("Thread", t.Meta))
+	}
+	return verifyPoW_V2(threadInput_V2(t, inputPoW), t.ProofOfWork, pubKey, strengths.Thread+AttachmentPoWBits("Thread", t.Meta))
+}
+
+func createPostPoW_V2(p *Post, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(postInput_V2(p, inputPoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	p.ProofOfWork = pow
+	return nil
+}
+
+func createPostUpdatePoW_V2(p *Post, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(postInput_V2(p, inputUpdatePoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	p.UpdateProofOfWork = pow
+	return nil
+}
+
+func verifyPostPoW_V2(p *Post, pubKey string) (bool, error) {
+	strengths := minimumPoWStrengths()
+	// Determine if we are checking for original or update PoW
+	if len(p.UpdateProofOfWork) > 0 {
+		return verifyPoW_V2(postInput_V2(p, inputUpdatePoW), p.UpdateProofOfWork, pubKey, strengths.PostUpdate+AttachmentPoWBits("Post", p.Meta))
+	}
+	return verifyPoW_V2(postInput_V2(p, inputPoW), p.ProofOfWork, pubKey, strengths.Post+AttachmentPoWBits("Post", p.Meta))
+}
+
+func createVotePoW_V2(v *Vote, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(voteInput_V2(v, inputPoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	v.ProofOfWork = pow
+	return nil
+}
+
+func createVoteUpdatePoW_V2(v *Vote, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(voteInput_V2(v, inputUpdatePoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	v.UpdateProofOfWork = pow
+	return nil
+}
+
+func verifyVotePoW_V2(v *Vote, pubKey string) (bool, error) {
+	strengths := minimumPoWStrengths()
+	// Determine if we are checking for original or update PoW
+	if len(v.UpdateProofOfWork) > 0 {
+		return verifyPoW_V2(voteInput_V2(v, inputUpdatePoW), v.UpdateProofOfWork, pubKey, strengths.VoteUpdate)
+	}
+	return verifyPoW_V2(voteInput_V2(v, inputPoW), v.ProofOfWork, pubKey, strengths.Vote)
+}
+
+func createKeyPoW_V2(k *Key, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(keyInput_V2(k, inputPoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	k.ProofOfWork = pow
+	return nil
+}
+
+func createKeyUpdatePoW_V2(k *Key, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(keyInput_V2(k, inputUpdatePoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	k.UpdateProofOfWork = pow
+	return nil
+}
+
+func verifyKeyPoW_V2(k *Key, pubKey string) (bool, error) {
+	strengths := minimumPoWStrengths()
+	// Determine if we are checking for original or update PoW
+	if len(k.UpdateProofOfWork) > 0 {
+		return verifyPoW_V2(keyInput_V2(k, inputUpdatePoW), k.UpdateProofOfWork, pubKey, strengths.KeyUpdate)
+	}
+	return verifyPoW_V2(keyInput_V2(k, inputPoW), k.ProofOfWork, pubKey, strengths.Key)
+}
+
+func createTruststatePoW_V2(ts *Truststate, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(truststateInput_V2(ts, inputPoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	ts.ProofOfWork = pow
+	return nil
+}
+
+func createTruststateUpdatePoW_V2(ts *Truststate, keyPair *ed25519.PrivateKey, difficulty int) error {
+	pow, err := createPoW_V2(truststateInput_V2(ts, inputUpdatePoW), keyPair, difficulty)
+	if err != nil {
+		return err
+	}
+	ts.UpdateProofOfWork = pow
+	return nil
+}
+
+func verifyTruststatePoW_V2(ts *Truststate, pubKey string) (bool, error) {
+	strengths := minimumPoWStrengths()
+	// Determine if we are checking for original or update PoW
+	if len(ts.UpdateProofOfWork) > 0 {
+		return verifyPoW_V2(truststateInput_V2(ts, inputUpdatePoW), ts.UpdateProofOfWork, pubKey, strengths.TruststateUpdate)
+	}
+	return verifyPoW_V2(truststateInput_V2(ts, inputPoW), ts.ProofOfWork, pubKey, strengths.Truststate)
+}
+
+// Fingerprint
+
+func createBoardFp_V2(b *Board) {
+	b.Fingerprint = Fingerprint(fingerprinting.Create(string(boardInput_V2(b, inputFingerprint))))
+}
+
+func verifyBoardFingerprint_V2(b *Board) bool {
+	if !isFrontend() && !globals.BackendTransientConfig.FingerprintCheckEnabled {
+		return true
+	}
+	return fingerprinting.Verify(string(boardInput_V2(b, inputFingerprint)), string(b.Fingerprint))
+}
+
+func createThreadFp_V2(t *Thread) {
+	t.Fingerprint = Fingerprint(fingerprinting.Create(string(threadInput_V2(t, inputFingerprint))))
+}
+
+func verifyThreadFingerprint_V2(t *Thread) bool {
+	if !isFrontend() && !globals.BackendTransientConfig.FingerprintCheckEnabled {
+		return true
+	}
+	return fingerprinting.Verify(string(threadInput_V2(t, inputFingerprint)), string(t.Fingerprint))
+}
+
+func createPostFp_V2(p *Post) {
+	p.Fingerprint = Fingerprint(fingerprinting.Create(string(postInput_V2(p, inputFingerprint))))
+}
+
+func verifyPostFingerprint_V2(p *Post) bool {
+	if !isFrontend() && !globals.BackendTransientConfig.FingerprintCheckEnabled {
+		return true
+	}
+	return fingerprinting.Verify(string(postInput_V2(p, inputFingerprint)), string(p.Fingerprint))
+}
+
+func createVoteFp_V2(v *Vote) {
+	v.Fingerprint = Fingerprint(fingerprinting.Create(string(voteInput_V2(v, inputFingerprint))))
+}
+
+func verifyVoteFingerprint_V2(v *Vote) bool {
+	if !isFrontend() && !globals.BackendTransientConfig.FingerprintCheckEnabled {
+		return true
+	}
+	return fingerprinting.Verify(string(voteInput_V2(v, inputFingerprint)), string(v.Fingerprint))
+}
+
+func createKeyFp_V2(k *Key) {
+	k.Fingerprint = Fingerprint(fingerprinting.Create(string(keyInput_V2(k, inputFingerprint))))
+}
+
+func verifyKeyFingerprint_V2(k *Key) bool {
+	if !isFrontend() && !globals.BackendTransientConfig.FingerprintCheckEnabled {
+		return true
+	}
+	return fingerprinting.Verify(string(keyInput_V2(k, inputFingerprint)), string(k.Fingerprint))
+}
+
+func createTruststateFp_V2(ts *Truststate) {
+	ts.Fingerprint = Fingerprint(fingerprinting.Create(string(truststateInput_V2(ts, inputFingerprint))))
+}
+
+func verifyTruststateFingerprint_V2(ts *Truststate) bool {
+	if !isFrontend() && !globals.BackendTransientConfig.FingerprintCheckEnabled {
+		return true
+	}
+	return fingerprinting.Verify(string(truststateInput_V2(ts, inputFingerprint)), string(ts.Fingerprint))
+}
+
+// Signature
+
+func createBoardSignature_V2(b *Board, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(boardInput_V2(b, inputSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	b.Signature = Signature(signature)
+	return nil
+}
+
+func createBoardUpdateSignature_V2(b *Board, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(boardInput_V2(b, inputUpdateSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	b.UpdateSignature = Signature(signature)
+	return nil
+}
+
+// boardSignatureInput_V2 returns what the signature of the board is over, and the signature itself.
+func boardSignatureInput_V2(b *Board) (string, string) {
+	if len(b.UpdateSignature) > 0 {
+		return string(boardInput_V2(b, inputUpdateSignature)), string(b.UpdateSignature)
+	}
+	return string(boardInput_V2(b, inputSignature)), string(b.Signature)
+}
+
+func createThreadSignature_V2(t *Thread, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(threadInput_V2(t, inputSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	t.Signature = Signature(signature)
+	return nil
+}
+
+func createThreadUpdateSignature_V2(t *Thread, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(threadInput_V2(t, inputUpdateSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	t.UpdateSignature = Signature(signature)
+	return nil
+}
+
+// threadSignatureInput_V2 returns what the signature of the thread is over, and the signature itself.
+func threadSignatureInput_V2(t *Thread) (string, string) {
+	if len(t.UpdateSignature) > 0 {
+		return string(threadInput_V2(t, inputUpdateSignature)), string(t.UpdateSignature)
+	}
+	return string(threadInput_V2(t, inputSignature)), string(t.Signature)
+}
+
+func createPostSignature_V2(p *Post, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(postInput_V2(p, inputSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	p.Signature = Signature(signature)
+	return nil
+}
+
+func createPostUpdateSignature_V2(p *Post, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(postInput_V2(p, inputUpdateSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	p.UpdateSignature = Signature(signature)
+	return nil
+}
+
+// postSignatureInput_V2 returns what the signature of the post is over, and the signature itself.
+func postSignatureInput_V2(p *Post) (string, string) {
+	if len(p.UpdateSignature) > 0 {
+		return string(postInput_V2(p, inputUpdateSignature)), string(p.UpdateSignature)
+	}
+	return string(postInput_V2(p, inputSignature)), string(p.Signature)
+}
+
+func createVoteSignature_V2(v *Vote, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(voteInput_V2(v, inputSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	v.Signature = Signature(signature)
+	return nil
+}
+
+func createVoteUpdateSignature_V2(v *Vote, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(voteInput_V2(v, inputUpdateSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	v.UpdateSignature = Signature(signature)
+	return nil
+}
+
+// voteSignatureInput_V2 returns what the signature of the vote is over, and the signature itself.
+func voteSignatureInput_V2(v *Vote) (string, string) {
+	if len(v.UpdateSignature) > 0 {
+		return string(voteInput_V2(v, inputUpdateSignature)), string(v.UpdateSignature)
+	}
+	return string(voteInput_V2(v, inputSignature)), string(v.Signature)
+}
+
+func createKeySignature_V2(k *Key, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(keyInput_V2(k, inputSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	k.Signature = Signature(signature)
+	return nil
+}
+
+func createKeyUpdateSignature_V2(k *Key, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(keyInput_V2(k, inputUpdateSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	k.UpdateSignature = Signature(signature)
+	return nil
+}
+
+// keySignatureInput_V2 returns what the signature of the key is over, and the signature itself.
+func keySignatureInput_V2(k *Key) (string, string) {
+	if len(k.UpdateSignature) > 0 {
+		return string(keyInput_V2(k, inputUpdateSignature)), string(k.UpdateSignature)
+	}
+	return string(keyInput_V2(k, inputSignature)), string(k.Signature)
+}
+
+func createTruststateSignature_V2(ts *Truststate, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(truststateInput_V2(ts, inputSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	ts.Signature = Signature(signature)
+	return nil
+}
+
+func createTruststateUpdateSignature_V2(ts *Truststate, keyPair *ed25519.PrivateKey) error {
+	signature, err := signaturing.Sign(string(truststateInput_V2(ts, inputUpdateSignature)), keyPair)
+	if err != nil {
+		return err
+	}
+	ts.UpdateSignature = Signature(signature)
+	return nil
+}
+
+// truststateSignatureInput_V2 returns what the signature of the truststate is over, and the signature itself.
+func truststateSignatureInput_V2(ts *Truststate) (string, string) {
+	if len(ts.UpdateSignature) > 0 {
+		return string(truststateInput_V2(ts, inputUpdateSignature)), string(ts.UpdateSignature)
+	}
+	return string(truststateInput_V2(ts, inputSignature)), string(ts.Signature)
+}
@@ -0,0 +1,235 @@
+// API > Entitlements
+// This file checks whether the owner of an entity is allowed to do what the entity does, against the other entities it refers to.
+
+package api
+
+import (
+	"aether-core/services/metaparse"
+	"errors"
+	"fmt"
+)
+
+/*
+What is an entitlement?
+
+A valid signature tells us who made an entity, not whether they were allowed to make it. A thread update signed by somebody else's key, a vote that says it is in one board but targets a post in another, or a post from a key that has expired are all properly signed, but we should not take them in.
+
+Some of this can be checked on the entity alone, and VerifyEntitlements on each entity does that. The rest needs the entities this one refers to: the previous version of it, the key of its owner, the thread it replies to, the post it votes on. The api package can't read the database, so whoever inserts the entities gives us an EntitlementLookup, and CheckEntitlements uses it.
+
+An update is signed by the key in the Owner field, so the owner of an update can't differ from the owner of what it updates. Boards are the one exception to who signs: a board owner at the mod level can update the description and the meta of a board, until their ownership expires. Their update keeps the creator in the Owner field, and names the board owner in the meta (see metaparse.BoardMeta). The update proof of work and the update signature are by the key of the board owner. Who the board owners are, only the creator can change.
+
+If we don't have what an entity refers to, we can't tell, and we let the entity in. It might have arrived before its parent, and rejecting it would mean we never get it. The check runs again every time the entity arrives, so once we have the parent, a bad entity won't make it in anymore.
+*/
+
+const (
+	// The level a board owner needs to be able to update the description and the meta of a board.
+	BoardOwnerLevelMod uint8 = 1
+)
+
+// EntitlementLookup gives CheckEntitlements the entities it needs. Both return a value type (Board, not *Board), or nil if we don't have it.
+type EntitlementLookup interface {
+	// Stored returns the version of the entity we already have, if any.
+	Stored(fp Fingerprint) interface{}
+	// Find returns the entity either from what we have, or from the entities that are being inserted alongside.
+	Find(fp Fingerprint) interface{}
+}
+
+// CheckEntitlements checks an entity against the entities it refers to. The entity is given as a value type, as it is given to the batch insert.
+func CheckEntitlements(e interface{}, l EntitlementLookup) error {
+	switch ent := e.(type) {
+	case Board:
+		if updater, updaterPk := boardUpdater(&ent); len(updater) > 0 {
+			return checkBoardOwnerUpdate(&ent, updater, updaterPk, l)
+		}
+		if prev, ok := l.Stored(ent.Fingerprint).(Board); ok {
+			if prev.Owner != ent.Owner || prev.OwnerPublicKey != ent.OwnerPublicKey {
+				return ownerChangedError(ent.Fingerprint)
+			}
+		}
+		return checkOwnerKeyNotExpired(ent.Owner, ent.Fingerprint, ent.Creation, ent.LastUpdate, l)
+	case Thread:
+		if prev, ok := l.Stored(ent.Fingerprint).(Thread); ok {
+			if prev.Owner != ent.Owner || prev.OwnerPublicKey != ent.OwnerPublicKey {
+				return ownerChangedError(ent.Fingerprint)
+			}
+		}
+		return checkOwnerKeyNotExpired(ent.Owner, ent.Fingerprint, ent.Creation, ent.LastUpdate, l)
+	case Post:
+		if prev, ok := l.Stored(ent.Fingerprint).(Post); ok {
+			if prev.Owner != ent.Owner || prev.OwnerPublicKey != ent.OwnerPublicKey {
+				return ownerChangedError(ent.Fingerprint)
+			}
+		}
+		err := checkPostPlacement(&ent, l)
+		if err != nil {
+			return err
+		}
+		return checkOwnerKeyNotExpired(ent.Owner, ent.Fingerprint, ent.Creation, ent.LastUpdate, l)
+	case Vote:
+		if prev, ok := l.Stored(ent.Fingerprint).(Vote); ok {
+			if prev.Owner != ent.Owner || prev.OwnerPublicKey != ent.OwnerPublicKey {
+				return ownerChangedError(ent.Fingerprint)
+			}
+		}
+		err := checkVoteTarget(&ent, l)
+		if err != nil {
+			return err
+		}
+		return checkOwnerKeyNotExpired(ent.Owner, ent.Fingerprint, ent.Creation, ent.LastUpdate, l)
+	case Key:
+		if prev, ok := l.Stored(ent.Fingerprint).(Key); ok {
+			if prev.Key != ent.Key {
+				return ownerChangedError(ent.Fingerprint)
+			}
+			// An expired key can't bring itself back by updating its own expiry.
+			if ent.LastUpdate > prev.LastUpdate && prev.Expiry > 0 && ent.LastUpdate > prev.Expiry {
+				return errors.New(fmt.Sprintf("This key is updated after it expired. Key: %s, Expiry: %d, Update: %d", ent.Fingerprint, prev.Expiry, ent.LastUpdate))
+			}
+		}
+		return nil
+	case Truststate:
+		if prev, ok := l.Stored(ent.Fingerprint).(Truststate); ok {
+			if prev.Owner != ent.Owner || prev.OwnerPublicKey != ent.OwnerPublicKey {
+				return ownerChangedError(ent.Fingerprint)
+			}
+		}
+		return checkOwnerKeyNotExpired(ent.Owner, ent.Fingerprint, ent.Creation, ent.LastUpdate, l)
+	default:
+		// Addresses are not owned by anybody.
+		return nil
+	}
+}
+
+func ownerChangedError(fp Fingerprint) error {
+	return errors.New(fmt.Sprintf("This entity has a different owner than the version of it we have. Fingerprint: %s", fp))
+}
+
+// checkOwnerKeyNotExpired checks that the key of the owner had not expired when the entity, or its latest update, was made.
+func checkOwnerKeyNotExpired(owner, fp Fingerprint, creation, lastUpdate Timestamp, l EntitlementLookup) error {
+	if len(owner) == 0 {
+		return nil
+	}
+	k, ok := l.Find(owner).(Key)
+	if !ok || k.Expiry == 0 {
+		return nil
+	}
+	if creation > k.Expiry || lastUpdate > k.Expiry {
+		return errors.New(fmt.Sprintf("This entity was made with a key that had expired. Entity: %s, Key: %s, Expiry: %d", fp, owner, k.Expiry))
+	}
+	return nil
+}
+
+// boardUpdater returns the board owner that made this board update, and their public key, if the update is not by the creator of the board.
+func boardUpdater(b *Board) (Fingerprint, string) {
+	if len(b.UpdateSignature) == 0 {
+		return "", ""
+	}
+	updater, updaterPk := metaparse.ReadBoardUpdater(b.Meta)
+	if len(updater) == 0 || len(updaterPk) == 0 || Fingerprint(updater) == b.Owner {
+		return "", ""
+	}
+	return Fingerprint(updater), updaterPk
+}
+
+// signingKey returns the public key the proof of work and the signature of the entity are by. That is the key of the owner, except for a board update by one of the board owners.
+func signingKey(e Provable) string {
+	if b, ok := e.(*Board); ok {
+		if _, updaterPk := boardUpdater(b); len(updaterPk) > 0 {
+			return updaterPk
+		}
+	}
+	return e.GetOwnerPublicKey()
+}
+
+// activeBoardOwner returns true if the key is a board owner of the board at the given level or above, and its ownership had not expired at the given time.
+func activeBoardOwner(b *Board, keyFp Fingerprint, minLevel uint8, at Timestamp) bool {
+	for key, _ := range b.BoardOwners {
+		bo := b.BoardOwners[key]
+		if bo.KeyFingerprint != keyFp || bo.Level < minLevel {
+			continue
+		}
+		if bo.Expiry == 0 || bo.Expiry >= at {
+			return true
+		}
+	}
+	return false
+}
+
+func sameBoardOwners(a, b []BoardOwner) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	index := make(map[BoardOwner]bool)
+	for _, bo := range a {
+		index[bo] = true
+	}
+	for _, bo := range b {
+		if !index[bo] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkBoardOwnerUpdate checks a board update made by one of the board owners against the version of the board we have. It goes by the board owners of our version, not of the update, otherwise anybody could list themselves and sign. So unlike the other checks, if we don't have the board, we don't let the update in.
+func checkBoardOwnerUpdate(b *Board, updater Fingerprint, updaterPk string, l EntitlementLookup) error {
+	prev, ok := l.Stored(b.Fingerprint).(Board)
+	if !ok {
+		return errors.New(fmt.Sprintf("This board update is from a board owner, but we don't have the board to check that they are one. Board: %s, Key: %s", b.Fingerprint, updater))
+	}
+	if prev.Owner != b.Owner || prev.OwnerPublicKey != b.OwnerPublicKey {
+		return ownerChangedError(b.Fingerprint)
+	}
+	if !activeBoardOwner(&prev, updater, BoardOwnerLevelMod, b.LastUpdate) {
+		return errors.New(fmt.Sprintf("This board update is from a key that is not a board owner of this board. Board: %s, Key: %s", b.Fingerprint, updater))
+	}
+	if !sameBoardOwners(prev.BoardOwners, b.BoardOwners) {
+		return errors.New(fmt.Sprintf("This board update changes the board owners, but it is not from the creator of the board. Board: %s, Key: %s", b.Fingerprint, updater))
+	}
+	// The update is signed by the public key in the meta. It has to be the key of the board owner it names.
+	k, ok2 := l.Find(updater).(Key)
+	if !ok2 || k.Key != updaterPk {
+		return errors.New(fmt.Sprintf("This board update is signed by a key that is not the one of the board owner it names. Board: %s, Key: %s", b.Fingerprint, updater))
+	}
+	return checkOwnerKeyNotExpired(updater, b.Fingerprint, b.LastUpdate, b.LastUpdate, l)
+}
+
+// checkPostPlacement checks that the thread of the post is in the board the post says it is in, and that the parent of the post is in the same thread.
+func checkPostPlacement(p *Post, l EntitlementLookup) error {
+	if t, ok := l.Find(p.Thread).(Thread); ok {
+		if t.Board != p.Board {
+			return errors.New(fmt.Sprintf("This post is in a thread that is not in the board the post says it is in. Post: %s, Thread: %s", p.Fingerprint, p.Thread))
+		}
+	}
+	if p.Parent == p.Thread {
+		return nil
+	}
+	switch parent := l.Find(p.Parent).(type) {
+	case Post:
+		if parent.Thread != p.Thread || parent.Board != p.Board {
+			return errors.New(fmt.Sprintf("This post replies to a post in another thread. Post: %s, Parent: %s", p.Fingerprint, p.Parent))
+		}
+	case nil:
+	default:
+		return errors.New(fmt.Sprintf("The parent of this post is neither its thread nor a post. Post: %s, Parent: %s", p.Fingerprint, p.Parent))
+	}
+	return nil
+}
+
+// checkVoteTarget checks that the vote targets a thread or a post in the board and the thread it says it is in.
+func checkVoteTarget(v *Vote, l EntitlementLookup) error {
+	switch t := l.Find(v.Target).(type) {
+	case Thread:
+		if t.Board != v.Board || t.Fingerprint != v.Thread {
+			return errors.New(fmt.Sprintf("This vote targets a thread that is not where the vote says it is. Vote: %s, Target: %s", v.Fingerprint, v.Target))
+		}
+	case Post:
+		if t.Board != v.Board || t.Thread != v.Thread {
+			return errors.New(fmt.Sprintf("This vote targets a post that is not where the vote says it is. Vote: %s, Target: %s", v.Fingerprint, v.Target))
+		}
+	case nil:
+	default:
+		return errors.New(fmt.Sprintf("This vote targets something that is neither a thread nor a post. Vote: %s, Target: %s", v.Fingerprint, v.Target))
+	}
+	return nil
+}
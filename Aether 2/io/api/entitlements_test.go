@@ -0,0 +1,230 @@
+package api_test
+
+import (
+	"aether-core/io/api"
+	"testing"
+)
+
+// mapLookup is an api.EntitlementLookup over two maps, in place of the database and the batch.
+type mapLookup struct {
+	stored map[api.Fingerprint]interface{}
+	batch  map[api.Fingerprint]interface{}
+}
+
+func (l *mapLookup) Stored(fp api.Fingerprint) interface{} {
+	return l.stored[fp]
+}
+
+func (l *mapLookup) Find(fp api.Fingerprint) interface{} {
+	if e, ok := l.batch[fp]; ok {
+		return e
+	}
+	return l.stored[fp]
+}
+
+func newMapLookup() *mapLookup {
+	return &mapLookup{stored: make(map[api.Fingerprint]interface{}), batch: make(map[api.Fingerprint]interface{})}
+}
+
+func TestCheckEntitlements_ThreadUpdateFromAnotherOwner(t *testing.T) {
+	l := newMapLookup()
+	var th api.Thread
+	th.Fingerprint = "thread"
+	th.Owner = "alice"
+	th.OwnerPublicKey = "alice pk"
+	l.stored[th.Fingerprint] = th
+	upd := th
+	upd.Owner = "mallory"
+	upd.OwnerPublicKey = "mallory pk"
+	upd.LastUpdate = 10
+	if api.CheckEntitlements(upd, l) == nil {
+		t.Errorf("A thread update from a different owner was allowed.")
+	}
+	upd.Owner = th.Owner
+	upd.OwnerPublicKey = th.OwnerPublicKey
+	if err := api.CheckEntitlements(upd, l); err != nil {
+		t.Errorf("A thread update from its owner was not allowed. Error: %v", err)
+	}
+}
+
+// A board update keeps its creator as the owner, even when a board owner makes it.
+func TestCheckEntitlements_BoardUpdateFromAnotherOwner(t *testing.T) {
+	l := newMapLookup()
+	var b api.Board
+	b.Fingerprint = "board"
+	b.Owner = "alice"
+	b.OwnerPublicKey = "alice pk"
+	b.LastUpdate = 5
+	b.BoardOwners = []api.BoardOwner{api.BoardOwner{KeyFingerprint: "bob", Expiry: 100, Level: 1}}
+	l.stored[b.Fingerprint] = b
+	upd := b
+	upd.Owner = "bob"
+	upd.OwnerPublicKey = "bob pk"
+	upd.Description = "new description"
+	upd.LastUpdate = 50
+	if api.CheckEntitlements(upd, l) == nil {
+		t.Errorf("A board update that changes the owner of the board was allowed.")
+	}
+	upd.Owner = b.Owner
+	upd.OwnerPublicKey = b.OwnerPublicKey
+	upd.BoardOwners = []api.BoardOwner{}
+	if err := api.CheckEntitlements(upd, l); err != nil {
+		t.Errorf("A board update from its creator was not allowed. Error: %v", err)
+	}
+}
+
+// boardOwnerUpdate returns a board alice made with bob as a board owner until 100, and an update to it by bob.
+func boardOwnerUpdate() (*mapLookup, api.Board, api.Board) {
+	l := newMapLookup()
+	var b api.Board
+	b.Fingerprint = "board"
+	b.Owner = "alice"
+	b.OwnerPublicKey = "alice pk"
+	b.LastUpdate = 5
+	b.BoardOwners = []api.BoardOwner{api.BoardOwner{KeyFingerprint: "bob", Expiry: 100, Level: api.BoardOwnerLevelMod}}
+	l.stored[b.Fingerprint] = b
+	var k api.Key
+	k.Fingerprint = "bob"
+	k.Key = "bob pk"
+	l.stored[k.Fingerprint] = k
+	upd := b
+	upd.Description = "new description"
+	upd.Meta = `{"updater":"bob","updater_publickey":"bob pk"}`
+	upd.UpdateSignature = "bob's signature"
+	upd.LastUpdate = 50
+	return l, b, upd
+}
+
+func TestCheckEntitlements_BoardUpdateFromBoardOwner(t *testing.T) {
+	l, _, upd := boardOwnerUpdate()
+	if err := api.CheckEntitlements(upd, l); err != nil {
+		t.Errorf("A board update from a board owner was not allowed. Error: %v", err)
+	}
+}
+
+func TestCheckEntitlements_BoardOwnerUpdateChangesOwners(t *testing.T) {
+	l, _, upd := boardOwnerUpdate()
+	upd.BoardOwners = []api.BoardOwner{api.BoardOwner{KeyFingerprint: "bob", Expiry: 0, Level: api.BoardOwnerLevelMod}}
+	if api.CheckEntitlements(upd, l) == nil {
+		t.Errorf("A board owner was allowed to change the board owners.")
+	}
+}
+
+func TestCheckEntitlements_BoardOwnerUpdateAfterExpiry(t *testing.T) {
+	l, _, upd := boardOwnerUpdate()
+	upd.LastUpdate = 150
+	if api.CheckEntitlements(upd, l) == nil {
+		t.Errorf("A board update from a board owner whose ownership had expired was allowed.")
+	}
+}
+
+func TestCheckEntitlements_BoardUpdateFromNonOwner(t *testing.T) {
+	l, _, upd := boardOwnerUpdate()
+	var k api.Key
+	k.Fingerprint = "mallory"
+	k.Key = "mallory pk"
+	l.stored[k.Fingerprint] = k
+	upd.Meta = `{"updater":"mallory","updater_publickey":"mallory pk"}`
+	if api.CheckEntitlements(upd, l) == nil {
+		t.Errorf("A board update from a key that is not a board owner was allowed.")
+	}
+}
+
+func TestCheckEntitlements_BoardOwnerUpdateWrongKey(t *testing.T) {
+	l, _, upd := boardOwnerUpdate()
+	upd.Meta = `{"updater":"bob","updater_publickey":"mallory pk"}`
+	if api.CheckEntitlements(upd, l) == nil {
+		t.Errorf("A board update signed by a key other than the board owner's was allowed.")
+	}
+}
+
+func TestCheckEntitlements_BoardOwnerUpdateWithoutBoard(t *testing.T) {
+	l, b, upd := boardOwnerUpdate()
+	delete(l.stored, b.Fingerprint)
+	if api.CheckEntitlements(upd, l) == nil {
+		t.Errorf("A board update from a board owner was allowed without the board to check their ownership against.")
+	}
+}
+
+func TestCheckEntitlements_VoteTarget(t *testing.T) {
+	l := newMapLookup()
+	var p api.Post
+	p.Fingerprint = "post"
+	p.Board = "board"
+	p.Thread = "thread"
+	l.stored[p.Fingerprint] = p
+	var v api.Vote
+	v.Fingerprint = "vote"
+	v.Board = "another board"
+	v.Thread = "thread"
+	v.Target = p.Fingerprint
+	if api.CheckEntitlements(v, l) == nil {
+		t.Errorf("A vote was allowed to target a post in another board than the one it claims.")
+	}
+	v.Board = "board"
+	if err := api.CheckEntitlements(v, l); err != nil {
+		t.Errorf("A vote on a post in its own board and thread was not allowed. Error: %v", err)
+	}
+	// A target we don't have can't be checked, and it is let in.
+	v.Target = "unknown"
+	if err := api.CheckEntitlements(v, l); err != nil {
+		t.Errorf("A vote on a target we do not have was not allowed. Error: %v", err)
+	}
+}
+
+func TestCheckEntitlements_PostPlacement(t *testing.T) {
+	l := newMapLookup()
+	var th api.Thread
+	th.Fingerprint = "thread"
+	th.Board = "board"
+	l.batch[th.Fingerprint] = th
+	var p api.Post
+	p.Fingerprint = "post"
+	p.Board = "another board"
+	p.Thread = th.Fingerprint
+	p.Parent = th.Fingerprint
+	if api.CheckEntitlements(p, l) == nil {
+		t.Errorf("A post was allowed in a thread that is in another board.")
+	}
+	p.Board = "board"
+	var other api.Post
+	other.Fingerprint = "other post"
+	other.Board = "board"
+	other.Thread = "other thread"
+	l.stored[other.Fingerprint] = other
+	p.Parent = other.Fingerprint
+	if api.CheckEntitlements(p, l) == nil {
+		t.Errorf("A post was allowed to reply to a post in another thread.")
+	}
+}
+
+func TestCheckEntitlements_ExpiredKey(t *testing.T) {
+	l := newMapLookup()
+	var k api.Key
+	k.Fingerprint = "alice"
+	k.Key = "alice pk"
+	k.Creation = 10
+	k.Expiry = 100
+	l.stored[k.Fingerprint] = k
+	var p api.Post
+	p.Fingerprint = "post"
+	p.Owner = k.Fingerprint
+	p.Creation = 200
+	if api.CheckEntitlements(p, l) == nil {
+		t.Errorf("A post made with an expired key was allowed.")
+	}
+	p.Creation = 50
+	if err := api.CheckEntitlements(p, l); err != nil {
+		t.Errorf("A post made before the key expired was not allowed. Error: %v", err)
+	}
+	p.LastUpdate = 200
+	if api.CheckEntitlements(p, l) == nil {
+		t.Errorf("A post update made with an expired key was allowed.")
+	}
+	upd := k
+	upd.Expiry = 1000
+	upd.LastUpdate = 150
+	if api.CheckEntitlements(upd, l) == nil {
+		t.Errorf("An expired key was allowed to extend its own expiry.")
+	}
+}
@@ -0,0 +1,51 @@
+// API > Entity Request
+// This file has the endpoint that gives the entities with the given fingerprints, whatever their type.
+
+package api
+
+import (
+	"aether-core/services/globals"
+	"errors"
+	"fmt"
+	"net"
+)
+
+const (
+	// How many fingerprints can be asked for in one request. The remote gives the entities for the first these many, and ignores the rest.
+	MaxEntitiesPerRequest = 64
+)
+
+/*
+Why not Query?
+
+Query finds one entity, and it finds it by walking the indexes of the caches of the remote, one cache at a time. That is fine for the odd entity, but when a sync leaves us with replies whose parents we don't have, we need tens of them at once, and some of them are older than any cache. This endpoint gives them all in one request, from the database of the remote, which has everything the caches have and more.
+*/
+
+// GetEntities asks the remote for the entities with the given fingerprints. The remote gives the ones it has, of any type, and leaves out the rest.
+func GetEntities(host string, subhost string, port uint16, fps []Fingerprint, reverseConn *net.Conn) (Response, error) {
+	if len(fps) > MaxEntitiesPerRequest {
+		fps = fps[:MaxEntitiesPerRequest]
+	}
+	values := []string{}
+	for _, fp := range fps {
+		values = append(values, string(fp))
+	}
+	apiReq := ApiResponse{}
+	apiReq.Prefill()
+	apiReq.Endpoint = "entities"
+	apiReq.Filters = []Filter{Filter{Type: "fingerprint", Values: values}}
+	signingErr := apiReq.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+	if signingErr != nil {
+		return Response{}, signingErr
+	}
+	apiReq.CreatePoW()
+	reqAsJson, err := apiReq.ToJSON()
+	if err != nil {
+		return Response{}, err
+	}
+	resp, _, err2 := GetPage(host, subhost, port, "c0/entities", "POST", reqAsJson, reverseConn)
+	if err2 != nil {
+		return Response{}, errors.New(fmt.Sprintf("Getting the entities from the remote failed. Fingerprints: %v, Error: %s", fps, err2))
+	}
+	return resp, nil
+}
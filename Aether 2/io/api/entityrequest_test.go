@@ -0,0 +1,133 @@
+package api_test
+
+import (
+	"aether-core/backend/responsegenerator"
+	"aether-core/io/api"
+	"aether-core/io/persistence"
+	"aether-core/services/globals"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// Helpers
+
+func insertEntityRequestPosts(t *testing.T, prefix string, count int) []api.Fingerprint {
+	fps := []api.Fingerprint{}
+	items := []interface{}{}
+	for i := 0; i < count; i++ {
+		var p api.Post
+		p.SetVerified(true)
+		p.Fingerprint = api.Fingerprint(fmt.Sprintf("%s-%d", prefix, i))
+		p.Body = "body"
+		p.EntityVersion = 1
+		p.Owner = "owner"
+		p.OwnerPublicKey = "ownerpk"
+		p.Creation = 1
+		p.Signature = "sig"
+		p.ProofOfWork = "pow"
+		p.Board = "boardpk"
+		p.Thread = "threadpk"
+		p.Parent = "threadpk"
+		fps = append(fps, p.Fingerprint)
+		items = append(items, p)
+	}
+	_, err := persistence.BatchInsert(items)
+	if err != nil {
+		t.Fatalf("Test failed, the posts could not be inserted. Err: '%s'", err)
+	}
+	return fps
+}
+
+func fingerprintFilterCount(req api.ApiResponse) int {
+	count := 0
+	for _, f := range req.Filters {
+		if f.Type == "fingerprint" {
+			count = count + len(f.Values)
+		}
+	}
+	return count
+}
+
+// entityRemote stands in for a remote, and answers the entity requests from the local database.
+func entityRemote(asked *[]int) (*httptest.Server, string, uint16) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req api.ApiResponse
+		json.Unmarshal(body, &req)
+		*asked = append(*asked, fingerprintFilterCount(req))
+		resp, err := responsegenerator.GenerateEntitiesResponse(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(resp)
+	}))
+	host, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return srv, host, uint16(p)
+}
+
+// Tests
+
+func TestGenerateEntitiesResponse_GivesWhatItHas_Success(t *testing.T) {
+	fps := insertEntityRequestPosts(t, "entitiesresp-have", 3)
+	var req api.ApiResponse
+	req.Filters = []api.Filter{api.Filter{Type: "fingerprint", Values: []string{string(fps[0]), string(fps[2]), "entitiesresp-nonexistent"}}}
+	result, err := responsegenerator.GenerateEntitiesResponse(req)
+	if err != nil {
+		t.Fatalf("Test failed, err: '%s'", err)
+	}
+	var resp api.ApiResponse
+	json.Unmarshal(result, &resp)
+	if len(resp.ResponseBody.Posts) != 2 {
+		t.Errorf("Test failed, expected 2 posts, got: %d", len(resp.ResponseBody.Posts))
+	}
+	for _, p := range resp.ResponseBody.Posts {
+		if p.Fingerprint != fps[0] && p.Fingerprint != fps[2] {
+			t.Errorf("Test failed, a post that was not asked for was given: '%s'", p.Fingerprint)
+		}
+	}
+}
+
+func TestGenerateEntitiesResponse_CapsFingerprints_Success(t *testing.T) {
+	fps := insertEntityRequestPosts(t, "entitiesresp-cap", api.MaxEntitiesPerRequest+20)
+	values := []string{}
+	for _, fp := range fps {
+		values = append(values, string(fp))
+	}
+	var req api.ApiResponse
+	req.Filters = []api.Filter{api.Filter{Type: "fingerprint", Values: values}}
+	result, err := responsegenerator.GenerateEntitiesResponse(req)
+	if err != nil {
+		t.Fatalf("Test failed, err: '%s'", err)
+	}
+	var resp api.ApiResponse
+	json.Unmarshal(result, &resp)
+	if len(resp.ResponseBody.Posts) != api.MaxEntitiesPerRequest {
+		t.Errorf("Test failed, expected %d posts, got: %d", api.MaxEntitiesPerRequest, len(resp.ResponseBody.Posts))
+	}
+}
+
+func TestGetEntities_CapsFingerprints_Success(t *testing.T) {
+	globals.BackendTransientConfig.TLSEnabled = false
+	fps := insertEntityRequestPosts(t, "getentities-cap", api.MaxEntitiesPerRequest+20)
+	asked := []int{}
+	srv, host, port := entityRemote(&asked)
+	defer srv.Close()
+	resp, err := api.GetEntities(host, "", port, fps, nil)
+	if err != nil {
+		t.Fatalf("Test failed, err: '%s'", err)
+	}
+	if len(asked) != 1 || asked[0] != api.MaxEntitiesPerRequest {
+		t.Errorf("Test failed, expected one request for %d fingerprints, got: '%v'", api.MaxEntitiesPerRequest, asked)
+	}
+	if len(resp.Posts) != api.MaxEntitiesPerRequest {
+		t.Errorf("Test failed, expected %d posts, got: %d", api.MaxEntitiesPerRequest, len(resp.Posts))
+	}
+}
@@ -0,0 +1,97 @@
+// API > Entity Versions
+// This file decides which versions of the entities a remote can take from us.
+
+package api
+
+// The newest entity version we can verify.
+const latestEntityVersion = 2
+
+/*
+How do we send v2 entities without breaking the remotes that only know v1?
+
+A remote tells us in the c0 subprotocol of its address which minor version of c0 it is at. The ones at 1 or above can verify v2 entities. The ones below can't, and they'd throw away any v2 entity they receive, after downloading it.
+
+So when a remote asks us for entities, we leave out the ones it can't verify. (See FilterForRemote) We accept both versions from everyone.
+
+The static caches are not made for any one remote, so they have both. An older remote skips the v2 entities in them the same way it skips any entity it can't verify.
+*/
+
+// AcceptsEntityVersion returns whether the node at this address can verify entities of the given version.
+func (a *Address) AcceptsEntityVersion(version int) bool {
+	if version <= 1 {
+		return true
+	}
+	for key, _ := range a.Protocol.Subprotocols {
+		if a.Protocol.Subprotocols[key].Name == CoreSubprotocol {
+			return c0AcceptsEntityVersion(a.Protocol.Subprotocols[key], version)
+		}
+	}
+	return false
+}
+
+func c0AcceptsEntityVersion(c0 Subprotocol, version int) bool {
+	switch version {
+	case 1:
+		return true
+	case 2:
+		return c0.VersionMinor >= 1
+	}
+	return false
+}
+
+// FilterForRemote removes the entities the remote can't verify from the response, and returns how many it removed.
+func (r *Response) FilterForRemote(remote *Address) int {
+	if remote.AcceptsEntityVersion(latestEntityVersion) {
+		return 0
+	}
+	removed := 0
+	boards := []Board{}
+	for key, _ := range r.Boards {
+		if remote.AcceptsEntityVersion(r.Boards[key].EntityVersion) {
+			boards = append(boards, r.Boards[key])
+		}
+	}
+	removed = removed + len(r.Boards) - len(boards)
+	r.Boards = boards
+	threads := []Thread{}
+	for key, _ := range r.Threads {
+		if remote.AcceptsEntityVersion(r.Threads[key].EntityVersion) {
+			threads = append(threads, r.Threads[key])
+		}
+	}
+	removed = removed + len(r.Threads) - len(threads)
+	r.Threads = threads
+	posts := []Post{}
+	for key, _ := range r.Posts {
+		if remote.AcceptsEntityVersion(r.Posts[key].EntityVersion) {
+			posts = append(posts, r.Posts[key])
+		}
+	}
+	removed = removed + len(r.Posts) - len(posts)
+	r.Posts = posts
+	votes := []Vote{}
+	for key, _ := range r.Votes {
+		if remote.AcceptsEntityVersion(r.Votes[key].EntityVersion) {
+			votes = append(votes, r.Votes[key])
+		}
+	}
+	removed = removed + len(r.Votes) - len(votes)
+	r.Votes = votes
+	keys := []Key{}
+	for key, _ := range r.Keys {
+		if remote.AcceptsEntityVersion(r.Keys[key].EntityVersion) {
+			keys = append(keys, r.Keys[key])
+		}
+	}
+	removed = removed + len(r.Keys) - len(keys)
+	r.Keys = keys
+	truststates := []Truststate{}
+	for key, _ := range r.Truststates {
+		if remote.AcceptsEntityVersion(r.Truststates[key].EntityVersion) {
+			truststates = append(truststates, r.Truststates[key])
+		}
+	}
+	removed = removed + len(r.Truststates) - len(truststates)
+	r.Truststates = truststates
+	return removed
+}
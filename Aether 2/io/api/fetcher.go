@@ -7,6 +7,7 @@ import (
 	"aether-core/services/fingerprinting"
 	"aether-core/services/globals"
 	"aether-core/services/logging"
+	"aether-core/services/toolbox"
 	"bytes"
 	"encoding/json"
 	"errors"
@@ -93,6 +94,10 @@ func InsertApiResponseToResponse(response Response, apiresp ApiResponse) Respons
 	response.TruststateManifests = apiresp.ResponseBody.TruststateManifests
 	response.AddressManifests = apiresp.ResponseBody.AddressManifests
 
+	response.MerkleNodes = apiresp.ResponseBody.MerkleNodes
+
+	response.Blobs = apiresp.ResponseBody.Blobs
+
 	response.CacheLinks = apiresp.Results
 
 	if response.MostRecentSourceTimestamp < apiresp.Timestamp {
@@ -200,13 +205,20 @@ func generateDialFunc(conn *net.Conn) func(network, address string) (net.Conn, e
 		}
 		return dialFunc
 	}
+	// Onion and I2P addresses go through the proxies of their networks, whatever the rest does (see overlay.go).
 	if globals.BackendConfig.GetSOCKS5ProxyEnabled() {
 		// We have a proxy. We'll use the proxy to make an outbound request.
 		dialer := makeProxyDialer()
-		return dialer.Dial
+		return routeDialFunc(dialer.Dial)
+	}
+	// We have neither a reverse open nor a proxy. Return the regular dialer we created at init, restricted to the IP versions we're allowed to use.
+	network := TCPNetwork()
+	if network == "tcp" {
+		return routeDialFunc(d.Dial)
 	}
-	// We have neither a reverse open nor a proxy. Return the regular dialer we created at init.
-	return d.Dial
+	return routeDialFunc(func(_, address string) (net.Conn, error) {
+		return d.Dial(network, address)
+	})
 }
 
 // Basic, reusable instances of transport and client.
@@ -258,10 +270,10 @@ func Fetch(host string, subhost string, port uint16, location string, method str
 	var fullLink string
 	if len(subhost) > 0 {
 		fullLink = fmt.Sprint(
-			prot, host, ":", strconv.Itoa(int(port)), "/", subhost, "/", protv, "/", location)
+			prot, toolbox.JoinHostPort(host, port), "/", subhost, "/", protv, "/", location)
 	} else {
 		fullLink = fmt.Sprint(
-			prot, host, ":", strconv.Itoa(int(port)), "/", protv, "/", location)
+			prot, toolbox.JoinHostPort(host, port), "/", protv, "/", location)
 	}
 	// if strings.Contains(fullLink, "127.0.0.1") {
 	// 	logging.Log(2, fmt.Sprintf("Fetch is being called for the URL: %s. ReverseConn: %v", fullLink, reverseConn != nil))
@@ -441,24 +453,33 @@ func GetPageRaw(host string, subhost string, port uint16, location string, metho
 	// if method == "POST" {
 	// 	apiresp.Dump() // let's see
 	// }
-	pageVerified, err := apiresp.VerifySignature() // If signature check is disabled, this will always return true.
+	err3 := VerifyPage(&apiresp)
+	if err3 != nil {
+		return ApiResponse{}, err3
+	}
+	return apiresp, nil
+}
+
+// VerifyPage verifies the page signature of a page, sets the node id of the signer, and verifies the entities in it. Entities that fail the verification are flagged, and the page fails entirely only if its structure is invalid, or if too many entities in it fail. Pages that arrive in a bundle go through this as well as the ones that arrive over the network.
+func VerifyPage(page *ApiResponse) error {
+	pageVerified, err := page.VerifySignature() // If signature check is disabled, this will always return true.
 	if err != nil {
-		return ApiResponse{}, errors.New(fmt.Sprintf("Page signature verification failed with an error. Error: %s", err))
+		return errors.New(fmt.Sprintf("Page signature verification failed with an error. Error: %s", err))
 	}
 	if !pageVerified {
-		return ApiResponse{}, errors.New("Page signature verification failed. The signature does not match.")
+		return errors.New("Page signature verification failed. The signature does not match.")
 	}
-	if len(apiresp.NodePublicKey) > 0 {
-		apiresp.NodeId = Fingerprint(fingerprinting.Create(apiresp.NodePublicKey))
+	if len(page.NodePublicKey) > 0 {
+		page.NodeId = Fingerprint(fingerprinting.Create(page.NodePublicKey))
 	} else {
 		/*
 			This makes it more obvious that the given field in the database is catchall for all nodes without a node public key. This should never happen in production because by default this check is enabled, and can only be disabled via a command line flag, which forces the app into read-only configs mode.
 		*/
-		apiresp.NodeId = "NODEID FOR NODE(S) WITH EMPTY NODEPUBLICKEY"
+		page.NodeId = "NODEID FOR NODE(S) WITH EMPTY NODEPUBLICKEY"
 	}
-	errs := apiresp.Verify()
+	errs := page.Verify()
 	if len(errs) == 1 && strings.Contains(errs[0].Error(), "This ApiResponse failed the boundary check") {
-		return ApiResponse{}, errs[0]
+		return errs[0]
 	}
 	if len(errs) >= 3 {
 		errStrs := []string{}
@@ -466,9 +487,9 @@ func GetPageRaw(host string, subhost string, port uint16, location string, metho
 			errStrs = append(errStrs, err.Error())
 		}
 		logging.Log(1, fmt.Sprintf("This page has 3 or more entities who has failed verification. Errors: %#v", errStrs))
-		return ApiResponse{}, errors.New(fmt.Sprintf("This page has 3 or more entities who has failed verification"))
+		return errors.New(fmt.Sprintf("This page has 3 or more entities who has failed verification"))
 	}
-	return apiresp, nil
+	return nil
 }
 
 // GetPage gets a page from a cache. This returns the data on the provided page.
@@ -678,7 +699,18 @@ func mapEndpointToEndpointAddress(endpoint string) string {
 // GetManifestGatedCache hits the manifests of the cache to determine which pages of the cache this computer needs to hit. This is useful in the case where you expect less than 50% of the cache will be downloaded. Mind that this adds a database check dependency (to know which one of these things we have at hand) and it will have to download the manifests for that cache, so it's a tradeoff.
 func GetManifestGatedCache(host string, subhost string, port uint16, location string, endpoint string, reverseConn *net.Conn) (Response, error) {
 	start := time.Now()
-	allPgs, err := generateHitlist(host, subhost, port, location, reverseConn)
+	var allPgs map[int]bool
+	var err error
+	if boards := SyncedBoards(); boards != nil && IsBoardScoped(endpoint) {
+		// Selective sync: the manifest cannot tell us which board an entity belongs to, but the index can.
+		allPgs, err = generateBoardScopedHitlist(host, subhost, port, location, endpoint, boards, reverseConn)
+		if err != nil && strings.Contains(err.Error(), "Non-200 status code returned from Fetch") {
+			// Index doesn't exist for this cache. Fall back to the manifest, and strip what we don't need after the fact.
+			allPgs, err = generateHitlist(host, subhost, port, location, reverseConn)
+		}
+	} else {
+		allPgs, err = generateHitlist(host, subhost, port, location, reverseConn)
+	}
 	if err != nil && strings.Contains(err.Error(), "Non-200 status code returned from Fetch") {
 		// Manifest doesn't exist for this cache.
 		logging.Log(1, fmt.Sprintf("This cache does not have a manifest. We'll be downloading the full cache. Host %s, Subhost: %s, Port: %d, Location: %s", host, subhost, port, location))
@@ -706,6 +738,45 @@ func GetManifestGatedCache(host string, subhost string, port uint16, location st
 	return mainResp, nil
 }
 
+// GetEndpointCaches returns the caches the remote has for the endpoint, as listed in the endpoint's index.
+func GetEndpointCaches(host string, subhost string, port uint16, endpoint string, reverseConn *net.Conn) ([]ResultCache, error) {
+	result, err := getIndexOfEndpoint(host, subhost, port, mapEndpointToEndpointAddress(endpoint), reverseConn)
+	if err != nil {
+		return []ResultCache{}, err
+	}
+	return result.CacheLinks, nil
+}
+
+// CacheLocation is the location of a cache of the endpoint, as used by the cache fetch functions.
+func CacheLocation(endpoint string, cache ResultCache) string {
+	return fmt.Sprint(mapEndpointToEndpointAddress(endpoint), "/", cache.ResponseUrl)
+}
+
+// GetCacheManifests returns the page manifests of a cache of the endpoint. These list every entity in the cache and the page it is on, without the entities themselves.
+func GetCacheManifests(host string, subhost string, port uint16, endpoint string, cache ResultCache, reverseConn *net.Conn) ([]PageManifest, error) {
+	resp, err := getManifestOfCache(host, subhost, port, CacheLocation(endpoint, cache), reverseConn)
+	if err != nil {
+		return []PageManifest{}, err
+	}
+	switch endpoint {
+	case "boards":
+		return resp.BoardManifests, nil
+	case "threads":
+		return resp.ThreadManifests, nil
+	case "posts":
+		return resp.PostManifests, nil
+	case "votes":
+		return resp.VoteManifests, nil
+	case "keys":
+		return resp.KeyManifests, nil
+	case "truststates":
+		return resp.TruststateManifests, nil
+	case "addresses":
+		return resp.AddressManifests, nil
+	}
+	return []PageManifest{}, errors.New(fmt.Sprintf("GetCacheManifests does not know this endpoint. Endpoint: %s", endpoint))
+}
+
 // GetGETEndpoint returns an entire endpoint from the remote node.
 func GetGETEndpoint(host string, subhost string, port uint16, endpoint string, lastCheckin Timestamp, reverseConn *net.Conn) (Response, error) {
 	// This is where the mapping for an endpoint to its respective subprotocol folder is mapped. Below this level, you have to supply your own subprotocol string.
@@ -783,6 +854,7 @@ func GetGETEndpoint(host string, subhost string, port uint16, endpoint string, l
 	truststatesCount := len(response.Truststates)
 	// logging.Log(1, fmt.Sprintf("Response for the endpoint %s was %#v\n", endpoint, response))
 	logging.Log(2, fmt.Sprintf("GetGETEndpoint returned for the endpoint: %s. Number of items: Boards: %d, Threads: %d, Posts: %d, Votes: %d, Addresses: %d, Keys: %d, Truststates: %d", endpoint, boardCount, threadCount, postCount, voteCount, addressCount, keysCount, truststatesCount))
+	response.StripUnsyncedBoards(SyncedBoards())
 
 	return response, nil
 }
@@ -836,6 +908,11 @@ func GetPOSTEndpoint(host string, subhost string, port uint16, endpoint string,
 	f.Type = "timestamp"
 	f.Values = []string{strconv.Itoa(int(lastCheckin)), strconv.Itoa(0)}
 	apiReq.Filters = []Filter{f}
+	// If we're in selective sync, ask only for the boards we track. The timestamp filter has to remain the first one.
+	boards := SyncedBoards()
+	if boards != nil && IsBoardScoped(endpoint) && len(boards) > 0 {
+		apiReq.Filters = append(apiReq.Filters, boardFilter(boards))
+	}
 	signingErr := apiReq.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
 	if signingErr != nil {
 		return Response{}, 0, signingErr
@@ -890,6 +967,7 @@ func GetPOSTEndpoint(host string, subhost string, port uint16, endpoint string,
 			logging.Logf(1, "%s was skipped because this container's end is older than our last sync with this node.", clink.ResponseUrl)
 		}
 	}
+	allResults.StripUnsyncedBoards(boards)
 	logging.Logf(2, "AllResults counts at the end of Get POST endpoint: \nB: %v, T: %v, P: %v, V: %v, K: %v, TS: %v, A: %v",
 		len(allResults.Boards),
 		len(allResults.Threads),
@@ -901,6 +979,32 @@ func GetPOSTEndpoint(host string, subhost string, port uint16, endpoint string,
 	return allResults, respDuration, nil
 }
 
+// GetMerkleNodes asks the remote for the children of the node at the given path in its Merkle tree for this entity type. The empty path is the root.
+func GetMerkleNodes(host string, subhost string, port uint16, endpoint string, path string, reverseConn *net.Conn) ([]MerkleNode, error) {
+	apiReq := ApiResponse{}
+	apiReq.Prefill()
+	apiReq.Entity = endpoint
+	apiReq.Endpoint = "merkle"
+	f := Filter{}
+	f.Type = "merkle"
+	f.Values = []string{path}
+	apiReq.Filters = []Filter{f}
+	signingErr := apiReq.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+	if signingErr != nil {
+		return []MerkleNode{}, signingErr
+	}
+	apiReq.CreatePoW()
+	reqAsJson, err := apiReq.ToJSON()
+	if err != nil {
+		return []MerkleNode{}, err
+	}
+	resp, _, err2 := GetPage(host, subhost, port, "c0/merkle", "POST", reqAsJson, reverseConn)
+	if err2 != nil {
+		return []MerkleNode{}, errors.New(fmt.Sprintf("Getting the Merkle nodes for this entity type failed. Endpoint type: %s, Path: %s, Error: %s", endpoint, path, err2))
+	}
+	return resp.MerkleNodes, nil
+}
+
 // GetRemoteNode downloads the entire remote node data by hitting all endpoints and all caches and all pages within them. This is the bootstrap function. This should be used when the local database is empty and the remote node is new. Never call this when the local database is not empty as that is fairly wasteful.
 func GetRemoteNode(host string, subhost string, port uint16, reverseConn *net.Conn) (Response, error) {
 	endpoints := []string{
@@ -1356,3 +1460,33 @@ CacheIterator: // Naming the for loop CacheIterator.
 	}
 	return r, nil
 }
+
+// QueryFresh is Query for an entity that might be newer than the last cache of the remote. The caches of the remote are behind its database by up to a cache generation, and such an entity is only in the database. If the caches don't have it, we ask the POST endpoint for it. Mind that a POST request is more expensive for the remote than reading its caches, so this is for the entities we know to be fresh.
+func QueryFresh(host string, subhost string, port uint16, q QueryData, reverseConn *net.Conn) (Response, error) {
+	r, err := Query(host, subhost, port, q, reverseConn)
+	if err != nil || !r.Empty() {
+		return r, err
+	}
+	return queryLive(host, subhost, port, q, reverseConn)
+}
+
+// queryLive asks the POST endpoint of the remote for the entity in the query, by its fingerprint. The remote reads it from its database, not its caches.
+func queryLive(host string, subhost string, port uint16, q QueryData, reverseConn *net.Conn) (Response, error) {
+	apiReq := ApiResponse{}
+	apiReq.Prefill()
+	apiReq.Filters = []Filter{Filter{Type: "fingerprint", Values: []string{string(q.Fingerprint)}}}
+	signingErr := apiReq.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+	if signingErr != nil {
+		return Response{}, signingErr
+	}
+	apiReq.CreatePoW()
+	reqAsJson, err := apiReq.ToJSON()
+	if err != nil {
+		return Response{}, err
+	}
+	resp, _, err2 := GetPage(host, subhost, port, mapEndpointToEndpointAddress(q.EntityType), "POST", reqAsJson, reverseConn)
+	if err2 != nil {
+		return Response{}, errors.New(fmt.Sprintf("Querying the POST endpoint of the remote failed. QueryData: %#v, Error: %s", q, err2))
+	}
+	return resp, nil
+}
@@ -0,0 +1,48 @@
+// API > Gossip
+// This file has the parts of the API that deal with announcing new entities to other nodes.
+
+package api
+
+import (
+	"aether-core/services/globals"
+	"errors"
+	"fmt"
+	"net"
+)
+
+const (
+	// How many entities can be announced in one request. The receiver ignores the ones after these. It's as many as the receiver can fetch back in one request.
+	MaxAnnouncedEntities = MaxEntitiesPerRequest
+)
+
+// Announce tells the remote about the entities in the announcement. The announcement has the index forms of the entities (PostIndex, etc.), not the entities themselves: the remote fetches the ones it doesn't have on its own, if it wants them.
+func Announce(host string, subhost string, port uint16, announcement Answer, reverseConn *net.Conn) error {
+	apiReq := ApiResponse{}
+	apiReq.Prefill()
+	apiReq.Endpoint = "announce"
+	apiReq.ResponseBody.BoardIndexes = announcement.BoardIndexes
+	apiReq.ResponseBody.ThreadIndexes = announcement.ThreadIndexes
+	apiReq.ResponseBody.PostIndexes = announcement.PostIndexes
+	apiReq.ResponseBody.VoteIndexes = announcement.VoteIndexes
+	apiReq.ResponseBody.KeyIndexes = announcement.KeyIndexes
+	apiReq.ResponseBody.TruststateIndexes = announcement.TruststateIndexes
+	signingErr := apiReq.CreateSignature(globals.BackendConfig.GetBackendKeyPair())
+	if signingErr != nil {
+		return signingErr
+	}
+	apiReq.CreatePoW()
+	reqAsJson, err := apiReq.ToJSON()
+	if err != nil {
+		return err
+	}
+	_, _, err2 := GetPage(host, subhost, port, "c0/announce", "POST", reqAsJson, reverseConn)
+	if err2 != nil {
+		return errors.New(fmt.Sprintf("Announcing the entities to the remote failed. Error: %s", err2))
+	}
+	return nil
+}
+
+// AnnouncementSize returns how many entities are announced in the announcement.
+func AnnouncementSize(a *Answer) int {
+	return len(a.BoardIndexes) + len(a.ThreadIndexes) + len(a.PostIndexes) + len(a.VoteIndexes) + len(a.KeyIndexes) + len(a.TruststateIndexes)
+}
@@ -0,0 +1,50 @@
+// API > IP Type
+// This file decides which IP versions we listen and connect over, based on the IP type preference in the config.
+
+package api
+
+import (
+	"aether-core/services/configstore"
+	"aether-core/services/globals"
+	"aether-core/services/toolbox"
+)
+
+// TCPNetwork is the network we listen and dial on. "tcp" is both IPv4 and IPv6: a listener on it is dual-stack where the machine has both, and a dialer on it picks whichever the address is.
+func TCPNetwork() string {
+	switch globals.BackendConfig.GetIpTypePreference() {
+	case configstore.IpTypeIPv4Only:
+		return "tcp4"
+	case configstore.IpTypeIPv6Only:
+		return "tcp6"
+	default:
+		return "tcp"
+	}
+}
+
+// IpTypeAllowed says whether we can connect to a location under the IP type preference. URLs are always allowed, since they might resolve to either.
+func IpTypeAllowed(loc Location, pref string) bool {
+	switch toolbox.IPType(string(loc)) {
+	case 4:
+		return pref != configstore.IpTypeIPv6Only
+	case 6:
+		return pref != configstore.IpTypeIPv4Only
+	default:
+		return true
+	}
+}
+
+// IpTypeRank orders the locations by the IP type preference. The lower, the more preferred. The preferred IP version comes first, then URLs and overlay addresses, then the other IP version.
+func IpTypeRank(loc Location, pref string) int {
+	preferred := uint8(6)
+	if pref == configstore.IpTypePreferIPv4 || pref == configstore.IpTypeIPv4Only {
+		preferred = 4
+	}
+	t := toolbox.IPType(string(loc))
+	if t == preferred {
+		return 0
+	}
+	if t != 4 && t != 6 {
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,208 @@
+// API > Merkle
+// This file provides the Merkle trees that nodes use to reconcile their entity sets with each other. Regular sync only looks at the caches and POST responses that fall within certain time windows, so an entity that was missed once is never looked for again. The trees allow two nodes to compare what they have over the whole of their memory, and find the exact fingerprints one side is missing.
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+The tree has three levels below the root.
+
+- Root: ("") Its children are the time buckets.
+- Time bucket: ("1530000000") Every entity is put into the bucket its creation falls in. Buckets are MerkleBucketSeconds wide and aligned to the unix epoch, so that every node computes the same bucket boundaries without having to coordinate.
+- Fingerprint bucket: ("1530000000/a") Within a time bucket, entities are split by the first character of their fingerprint.
+
+Asking for the children of a fingerprint bucket returns a single node that carries the leaves (fingerprint and creation) themselves.
+*/
+
+const (
+	MerkleBucketSeconds = 86400 // 1 day
+)
+
+// MerkleLeaf is the smallest unit of the tree. Creation is carried so that the requester can narrow down the caches it has to search when it goes to fetch the entity.
+type MerkleLeaf struct {
+	Fingerprint Fingerprint `json:"fingerprint"`
+	Creation    Timestamp   `json:"creation"`
+}
+
+// MerkleNode is the transport form of a node in the tree. Leaves are only present when the node is a fingerprint bucket.
+type MerkleNode struct {
+	Path   string       `json:"path"`
+	Hash   string       `json:"hash"`
+	Count  int          `json:"count"`
+	Leaves []MerkleLeaf `json:"leaves,omitempty"`
+}
+
+type MerkleTree struct {
+	buckets map[int64]map[string][]MerkleLeaf
+}
+
+// NewMerkleTree builds a tree from the given leaves.
+func NewMerkleTree(leaves []MerkleLeaf) *MerkleTree {
+	t := MerkleTree{buckets: make(map[int64]map[string][]MerkleLeaf)}
+	for _, l := range leaves {
+		if len(l.Fingerprint) == 0 {
+			continue
+		}
+		b := MerkleBucketStart(l.Creation)
+		if t.buckets[b] == nil {
+			t.buckets[b] = make(map[string][]MerkleLeaf)
+		}
+		prefix := string(l.Fingerprint)[0:1]
+		t.buckets[b][prefix] = append(t.buckets[b][prefix], l)
+	}
+	for _, prefixes := range t.buckets {
+		for p, _ := range prefixes {
+			sort.Slice(prefixes[p], func(i, j int) bool {
+				return prefixes[p][i].Fingerprint < prefixes[p][j].Fingerprint
+			})
+		}
+	}
+	return &t
+}
+
+// MerkleBucketStart returns the beginning of the time bucket the given timestamp falls in.
+func MerkleBucketStart(ts Timestamp) int64 {
+	return int64(ts) - (int64(ts) % MerkleBucketSeconds)
+}
+
+func hashStrings(items []string) string {
+	h := sha256.New()
+	for _, val := range items {
+		h.Write([]byte(val))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (t *MerkleTree) sortedBuckets() []int64 {
+	bs := []int64{}
+	for b, _ := range t.buckets {
+		bs = append(bs, b)
+	}
+	sort.Slice(bs, func(i, j int) bool { return bs[i] < bs[j] })
+	return bs
+}
+
+func sortedPrefixes(prefixes map[string][]MerkleLeaf) []string {
+	ps := []string{}
+	for p, _ := range prefixes {
+		ps = append(ps, p)
+	}
+	sort.Strings(ps)
+	return ps
+}
+
+func (t *MerkleTree) prefixNode(bucket int64, prefix string, withLeaves bool) MerkleNode {
+	leaves := t.buckets[bucket][prefix]
+	fps := []string{}
+	for _, l := range leaves {
+		fps = append(fps, string(l.Fingerprint))
+	}
+	n := MerkleNode{
+		Path:  fmt.Sprintf("%d/%s", bucket, prefix),
+		Hash:  hashStrings(fps),
+		Count: len(leaves),
+	}
+	if withLeaves {
+		n.Leaves = leaves
+	}
+	return n
+}
+
+func (t *MerkleTree) bucketNode(bucket int64) MerkleNode {
+	hashes := []string{}
+	count := 0
+	for _, p := range sortedPrefixes(t.buckets[bucket]) {
+		pn := t.prefixNode(bucket, p, false)
+		hashes = append(hashes, pn.Path, pn.Hash)
+		count = count + pn.Count
+	}
+	return MerkleNode{
+		Path:  strconv.FormatInt(bucket, 10),
+		Hash:  hashStrings(hashes),
+		Count: count,
+	}
+}
+
+// Root returns the root hash of the tree. Two trees with the same root hash have the same contents.
+func (t *MerkleTree) Root() MerkleNode {
+	hashes := []string{}
+	count := 0
+	for _, b := range t.sortedBuckets() {
+		bn := t.bucketNode(b)
+		hashes = append(hashes, bn.Path, bn.Hash)
+		count = count + bn.Count
+	}
+	return MerkleNode{Path: "", Hash: hashStrings(hashes), Count: count}
+}
+
+// Children returns the children of the node at the given path. Asking for the children of a fingerprint bucket returns the bucket itself, with its leaves.
+func (t *MerkleTree) Children(path string) ([]MerkleNode, error) {
+	nodes := []MerkleNode{}
+	if len(path) == 0 {
+		for _, b := range t.sortedBuckets() {
+			nodes = append(nodes, t.bucketNode(b))
+		}
+		return nodes, nil
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) > 2 {
+		return nodes, errors.New(fmt.Sprintf("This Merkle path is too deep. Path: %s", path))
+	}
+	bucket, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || bucket%MerkleBucketSeconds != 0 {
+		return nodes, errors.New(fmt.Sprintf("This Merkle path does not point to a valid time bucket. Path: %s", path))
+	}
+	if len(parts) == 1 {
+		for _, p := range sortedPrefixes(t.buckets[bucket]) {
+			nodes = append(nodes, t.prefixNode(bucket, p, false))
+		}
+		return nodes, nil
+	}
+	if len(parts[1]) != 1 {
+		return nodes, errors.New(fmt.Sprintf("This Merkle path does not point to a valid fingerprint bucket. Path: %s", path))
+	}
+	if _, ok := t.buckets[bucket][parts[1]]; ok {
+		nodes = append(nodes, t.prefixNode(bucket, parts[1], true))
+	}
+	return nodes, nil
+}
+
+// DiffMerkleNodes returns the remote nodes that either do not exist in the local set, or exist with a different hash. Nodes that only exist locally are not returned, since the remote is missing those, not us.
+func DiffMerkleNodes(local []MerkleNode, remote []MerkleNode) []MerkleNode {
+	localHashes := make(map[string]string)
+	for _, n := range local {
+		localHashes[n.Path] = n.Hash
+	}
+	diff := []MerkleNode{}
+	for _, n := range remote {
+		if h, ok := localHashes[n.Path]; !ok || h != n.Hash {
+			diff = append(diff, n)
+		}
+	}
+	return diff
+}
+
+// MissingMerkleLeaves returns the remote leaves that the local set does not have.
+func MissingMerkleLeaves(local []MerkleLeaf, remote []MerkleLeaf) []MerkleLeaf {
+	have := make(map[Fingerprint]bool)
+	for _, l := range local {
+		have[l.Fingerprint] = true
+	}
+	missing := []MerkleLeaf{}
+	for _, l := range remote {
+		if !have[l.Fingerprint] {
+			missing = append(missing, l)
+		}
+	}
+	return missing
+}
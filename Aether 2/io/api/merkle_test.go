@@ -0,0 +1,85 @@
+package api_test
+
+import (
+	"aether-core/io/api"
+	"fmt"
+	"testing"
+)
+
+// Tests
+
+func generateMerkleLeaves(count int, start api.Timestamp) []api.MerkleLeaf {
+	leaves := []api.MerkleLeaf{}
+	for i := 0; i < count; i++ {
+		leaves = append(leaves, api.MerkleLeaf{
+			Fingerprint: api.Fingerprint(fmt.Sprintf("%x%063d", i%16, i)),
+			Creation:    start + api.Timestamp(i*3600),
+		})
+	}
+	return leaves
+}
+
+func TestMerkleTree_SameContentsSameRoot_Success(t *testing.T) {
+	leaves := generateMerkleLeaves(100, 1530000000)
+	reversed := []api.MerkleLeaf{}
+	for i := len(leaves) - 1; i >= 0; i-- {
+		reversed = append(reversed, leaves[i])
+	}
+	t1 := api.NewMerkleTree(leaves)
+	t2 := api.NewMerkleTree(reversed)
+	if t1.Root().Hash != t2.Root().Hash {
+		t.Errorf("Test failed, trees with the same contents have different root hashes.")
+	}
+	if t1.Root().Count != 100 {
+		t.Errorf("Test failed, the root count should be 100, it is %d", t1.Root().Count)
+	}
+}
+
+func TestMerkleTree_FindsMissingLeaf_Success(t *testing.T) {
+	remoteLeaves := generateMerkleLeaves(100, 1530000000)
+	localLeaves := append([]api.MerkleLeaf{}, remoteLeaves[0:42]...)
+	localLeaves = append(localLeaves, remoteLeaves[43:]...)
+	local := api.NewMerkleTree(localLeaves)
+	remote := api.NewMerkleTree(remoteLeaves)
+	if local.Root().Hash == remote.Root().Hash {
+		t.Errorf("Test failed, trees with different contents have the same root hash.")
+	}
+	missing := []api.MerkleLeaf{}
+	lb, _ := local.Children("")
+	rb, _ := remote.Children("")
+	buckets := api.DiffMerkleNodes(lb, rb)
+	if len(buckets) != 1 {
+		t.Errorf("Test failed, exactly one time bucket should differ, %d did.", len(buckets))
+	}
+	for _, b := range buckets {
+		lp, _ := local.Children(b.Path)
+		rp, _ := remote.Children(b.Path)
+		for _, p := range api.DiffMerkleNodes(lp, rp) {
+			ll, _ := local.Children(p.Path)
+			rl, err := remote.Children(p.Path)
+			if err != nil {
+				t.Errorf("Test failed, err: '%s'", err)
+			}
+			localLeafSet := []api.MerkleLeaf{}
+			for _, n := range ll {
+				localLeafSet = append(localLeafSet, n.Leaves...)
+			}
+			missing = append(missing, api.MissingMerkleLeaves(localLeafSet, rl[0].Leaves)...)
+		}
+	}
+	if len(missing) != 1 || missing[0].Fingerprint != remoteLeaves[42].Fingerprint {
+		t.Errorf("Test failed, the missing leaf was not found. Found: %#v", missing)
+	}
+}
+
+func TestMerkleTree_InvalidPath_Fail(t *testing.T) {
+	tree := api.NewMerkleTree(generateMerkleLeaves(10, 1530000000))
+	_, err := tree.Children("1530000001")
+	if err == nil {
+		t.Errorf("Test failed, a path that is not aligned to a bucket should be refused.")
+	}
+	_, err2 := tree.Children("1529971200/a/b")
+	if err2 == nil {
+		t.Errorf("Test failed, a path that is too deep should be refused.")
+	}
+}
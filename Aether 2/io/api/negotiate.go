@@ -0,0 +1,125 @@
+// API > Negotiate
+// This file figures out what we and a remote have in common, so that we only ask it for what it can give.
+
+package api
+
+import (
+	"aether-core/services/globals"
+	"errors"
+	"fmt"
+)
+
+/*
+How does the negotiation work?
+
+Every node sends its protocol with its address: the Mim protocol version, and the subprotocols it speaks on top of it, each with its own version and the entity types it serves. (c0 is the one Aether speaks, dweb would be another.)
+
+1) The protocol major versions have to match. A different major version means the other side won't understand our requests, so there is no point in trying.
+
+2) For every subprotocol we speak, we look for it at the remote. If the major versions match, we have it in common, at the lower of the two minor versions, since that is what both of us understand. The entity types we have in common in it are the ones both of us serve.
+
+3) c0 is required. Everything else is optional: a remote that doesn't speak a subprotocol we do just doesn't get asked for its entities, and the same the other way around.
+
+This is also how we add entity types without breaking older nodes. A new entity type goes into the supported entities of a subprotocol, and an older node doesn't list it, so nobody asks it for one. A new subprotocol only needs to be added to the serving subprotocols in the config.
+*/
+
+// The subprotocol every Aether node has to speak.
+const CoreSubprotocol = "c0"
+
+// Capabilities is what we have in common with a remote.
+type Capabilities struct {
+	// The subprotocols both sides speak, at the lower of the two minor versions.
+	Subprotocols []Subprotocol
+	// The entity types both sides serve, in singular. ("thread", not "threads")
+	Entities []string
+}
+
+// Speaks returns whether the subprotocol is in common.
+func (c *Capabilities) Speaks(name string) bool {
+	_, ok := c.Subprotocol(name)
+	return ok
+}
+
+// Subprotocol returns the subprotocol in common by the given name, if any.
+func (c *Capabilities) Subprotocol(name string) (Subprotocol, bool) {
+	for key, _ := range c.Subprotocols {
+		if c.Subprotocols[key].Name == name {
+			return c.Subprotocols[key], true
+		}
+	}
+	return Subprotocol{}, false
+}
+
+// Serves returns whether both sides serve the entity type.
+func (c *Capabilities) Serves(entity string) bool {
+	for _, e := range c.Entities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// EntityVersion returns the newest entity version both sides can verify.
+func (c *Capabilities) EntityVersion() int {
+	c0, _ := c.Subprotocol(CoreSubprotocol)
+	for v := latestEntityVersion; v > 1; v-- {
+		if c0AcceptsEntityVersion(c0, v) {
+			return v
+		}
+	}
+	return 1
+}
+
+// LocalProtocol returns the protocol this node speaks, as it is sent to the remotes.
+func LocalProtocol() Protocol {
+	p := Protocol{}
+	p.VersionMajor = globals.BackendConfig.GetProtocolVersionMajor()
+	p.VersionMinor = globals.BackendConfig.GetProtocolVersionMinor()
+	for _, val := range globals.BackendConfig.GetServingSubprotocols() {
+		p.Subprotocols = append(p.Subprotocols, Subprotocol(val))
+	}
+	return p
+}
+
+// NegotiateCapabilities finds what the local and the remote protocols have in common. It returns an error if the two can't talk to each other at all.
+func NegotiateCapabilities(local Protocol, remote Protocol) (Capabilities, error) {
+	caps := Capabilities{}
+	if local.VersionMajor != remote.VersionMajor {
+		return caps, errors.New(fmt.Sprintf("This remote speaks a protocol major version we are not compatible with. Ours: %d, Remote: %d", local.VersionMajor, remote.VersionMajor))
+	}
+	for _, ours := range local.Subprotocols {
+		for _, theirs := range remote.Subprotocols {
+			if ours.Name != theirs.Name {
+				continue
+			}
+			if ours.VersionMajor != theirs.VersionMajor {
+				if ours.Name == CoreSubprotocol {
+					return caps, errors.New(fmt.Sprintf("This remote speaks a major version of %s we are not compatible with. Ours: %d, Remote: %d", CoreSubprotocol, ours.VersionMajor, theirs.VersionMajor))
+				}
+				break
+			}
+			shared := Subprotocol{Name: ours.Name, VersionMajor: ours.VersionMajor, VersionMinor: ours.VersionMinor}
+			if theirs.VersionMinor < shared.VersionMinor {
+				shared.VersionMinor = theirs.VersionMinor
+			}
+			for _, e := range ours.SupportedEntities {
+				for _, e2 := range theirs.SupportedEntities {
+					if e == e2 {
+						shared.SupportedEntities = append(shared.SupportedEntities, e)
+						if !caps.Serves(e) {
+							caps.Entities = append(caps.Entities, e)
+						}
+						break
+					}
+				}
+			}
+			caps.Subprotocols = append(caps.Subprotocols, shared)
+			break
+		}
+	}
+	if !caps.Speaks(CoreSubprotocol) {
+		return caps, errors.New(fmt.Sprintf("This remote does not speak %s. Remote subprotocols: %#v", CoreSubprotocol, remote.Subprotocols))
+	}
+	return caps, nil
+}
@@ -0,0 +1,60 @@
+package api_test
+
+import (
+	"aether-core/io/api"
+	"strings"
+	"testing"
+)
+
+func protocol(major uint8, subprots ...api.Subprotocol) api.Protocol {
+	return api.Protocol{VersionMajor: major, Subprotocols: subprots}
+}
+
+func TestNegotiateCapabilities_Intersects(t *testing.T) {
+	ours := protocol(1,
+		api.Subprotocol{Name: "c0", VersionMajor: 1, VersionMinor: 1, SupportedEntities: []string{"board", "thread", "post", "page"}},
+		api.Subprotocol{Name: "dweb", VersionMajor: 1, VersionMinor: 0, SupportedEntities: []string{"site"}})
+	theirs := protocol(1,
+		api.Subprotocol{Name: "c0", VersionMajor: 1, VersionMinor: 0, SupportedEntities: []string{"board", "thread", "post", "vote"}})
+	caps, err := api.NegotiateCapabilities(ours, theirs)
+	if err != nil {
+		t.Fatalf("Negotiation with a compatible remote failed. Error: %v", err)
+	}
+	if len(caps.Entities) != 3 || !caps.Serves("post") || caps.Serves("vote") || caps.Serves("page") {
+		t.Errorf("The entities in common are wrong. Entities: %v", caps.Entities)
+	}
+	c0, _ := caps.Subprotocol("c0")
+	if c0.VersionMinor != 0 {
+		t.Errorf("The subprotocol in common should be at the lower minor version. Got: %d", c0.VersionMinor)
+	}
+	if caps.Speaks("dweb") {
+		t.Errorf("A subprotocol only we speak was taken to be in common.")
+	}
+	if caps.EntityVersion() != 1 {
+		t.Errorf("A remote at c0 minor 0 was taken to verify entity version %d.", caps.EntityVersion())
+	}
+}
+
+func TestNegotiateCapabilities_IncompatibleMajors(t *testing.T) {
+	c0 := api.Subprotocol{Name: "c0", VersionMajor: 1, SupportedEntities: []string{"board"}}
+	c0v2 := api.Subprotocol{Name: "c0", VersionMajor: 2, SupportedEntities: []string{"board"}}
+	_, err := api.NegotiateCapabilities(protocol(1, c0), protocol(2, c0))
+	if err == nil || !strings.Contains(err.Error(), "protocol major version") {
+		t.Errorf("A remote at another protocol major version was not refused clearly. Error: %v", err)
+	}
+	_, err2 := api.NegotiateCapabilities(protocol(1, c0), protocol(1, c0v2))
+	if err2 == nil || !strings.Contains(err2.Error(), "major version of c0") {
+		t.Errorf("A remote at another c0 major version was not refused clearly. Error: %v", err2)
+	}
+	_, err3 := api.NegotiateCapabilities(protocol(1, c0), protocol(1))
+	if err3 == nil {
+		t.Errorf("A remote that does not speak c0 was not refused.")
+	}
+	// Another major version of an optional subprotocol only leaves that subprotocol out.
+	dweb := api.Subprotocol{Name: "dweb", VersionMajor: 1, SupportedEntities: []string{"site"}}
+	dwebv2 := api.Subprotocol{Name: "dweb", VersionMajor: 2, SupportedEntities: []string{"site"}}
+	caps, err4 := api.NegotiateCapabilities(protocol(1, c0, dweb), protocol(1, c0, dwebv2))
+	if err4 != nil || caps.Speaks("dweb") || !caps.Serves("board") {
+		t.Errorf("An optional subprotocol at another major version was not left out. Capabilities: %#v, Error: %v", caps, err4)
+	}
+}
@@ -0,0 +1,79 @@
+// API > Overlay
+// This file routes the connections to Tor and I2P addresses through the SOCKS proxies of those networks.
+
+package api
+
+import (
+	"aether-core/services/globals"
+	"aether-core/services/toolbox"
+	"errors"
+	"fmt"
+	"golang.org/x/net/proxy"
+	"net"
+)
+
+/*
+How do we reach an onion address?
+
+A .onion or a .b32.i2p address is not on the internet, only the Tor client or the I2P router on this machine knows how to get there. Both have a SOCKS proxy, and we give them the name of the address, not an IP, so that they can do the rest. The name is never looked up in the DNS: that would fail anyway, and it would tell the DNS server which onion we're about to visit.
+
+Every other address goes the way it always went, directly, or through the SOCKS5 proxy if that is enabled.
+*/
+
+// IsOverlay says whether a location is on the Tor or the I2P network.
+func IsOverlay(loc Location) bool {
+	t := toolbox.IPType(string(loc))
+	return t == toolbox.LocationTypeTor || t == toolbox.LocationTypeI2P
+}
+
+// overlayProxyAddress returns the SOCKS proxy for the network the location is in. Blank if the location is not on an overlay network, or if we don't have a proxy for it.
+func overlayProxyAddress(loc Location) string {
+	switch toolbox.IPType(string(loc)) {
+	case toolbox.LocationTypeTor:
+		return globals.BackendConfig.GetTorSOCKSProxyAddress()
+	case toolbox.LocationTypeI2P:
+		return globals.BackendConfig.GetI2PSOCKSProxyAddress()
+	default:
+		return ""
+	}
+}
+
+// OverlayReachable says whether we can connect to a location. Clearnet locations always are, overlay ones only if we have the proxy of their network.
+func OverlayReachable(loc Location) bool {
+	if !IsOverlay(loc) {
+		return true
+	}
+	return len(overlayProxyAddress(loc)) > 0
+}
+
+// dialOverlay connects to a host:port on an overlay network through the proxy of that network.
+func dialOverlay(address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("This address could not be parsed. Address: %s, Error: %v", address, err))
+	}
+	proxyAddr := overlayProxyAddress(Location(host))
+	if len(proxyAddr) == 0 {
+		return nil, errors.New(fmt.Sprintf("This address is on an overlay network we do not have a proxy for. Address: %s", address))
+	}
+	var forward proxy.Dialer = proxy.Direct
+	if d != nil {
+		forward = d
+	}
+	pdialer, err2 := proxy.SOCKS5("tcp", proxyAddr, nil, forward)
+	if err2 != nil {
+		return nil, errors.New(fmt.Sprintf("The overlay proxy could not be set up. Proxy: %s, Error: %v", proxyAddr, err2))
+	}
+	return pdialer.Dial("tcp", address)
+}
+
+// routeDialFunc wraps the clearnet dial function, so that the overlay addresses go through their own proxies instead.
+func routeDialFunc(clearnet func(network, address string) (net.Conn, error)) func(network, address string) (net.Conn, error) {
+	return func(network, address string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(address)
+		if err == nil && IsOverlay(Location(host)) {
+			return dialOverlay(address)
+		}
+		return clearnet(network, address)
+	}
+}
@@ -79,7 +79,7 @@ func FPSliceToProtobuf(fps []Fingerprint) []string {
 //////////////////////////////////
 
 func (e *Board) Protobuf() pb.Board {
-	if e.GetVersion() == 1 {
+	if e.GetVersion() == 1 || e.GetVersion() == 2 {
 		return pb.Board{
 			Provable:       e.ProvableFieldSet.Protobuf(),
 			Name:           e.Name,
@@ -99,7 +99,7 @@ func (e *Board) Protobuf() pb.Board {
 }
 
 func (e *Thread) Protobuf() pb.Thread {
-	if e.GetVersion() == 1 {
+	if e.GetVersion() == 1 || e.GetVersion() == 2 {
 		return pb.Thread{
 			Provable:       e.ProvableFieldSet.Protobuf(),
 			Board:          e.Board.Protobuf(),
@@ -118,7 +118,7 @@ func (e *Thread) Protobuf() pb.Thread {
 	return pb.Thread{}
 }
 func (e *Post) Protobuf() pb.Post {
-	if e.GetVersion() == 1 {
+	if e.GetVersion() == 1 || e.GetVersion() == 2 {
 		return pb.Post{
 			Provable:       e.ProvableFieldSet.Protobuf(),
 			Board:          e.Board.Protobuf(),
@@ -137,7 +137,7 @@ func (e *Post) Protobuf() pb.Post {
 	return pb.Post{}
 }
 func (e *Vote) Protobuf() pb.Vote {
-	if e.GetVersion() == 1 {
+	if e.GetVersion() == 1 || e.GetVersion() == 2 {
 		return pb.Vote{
 			Provable:       e.ProvableFieldSet.Protobuf(),
 			Board:          e.Board.Protobuf(),
@@ -157,7 +157,7 @@ func (e *Vote) Protobuf() pb.Vote {
 	return pb.Vote{}
 }
 func (e *Key) Protobuf() pb.Key {
-	if e.GetVersion() == 1 {
+	if e.GetVersion() == 1 || e.GetVersion() == 2 {
 		return pb.Key{
 			Provable:      e.ProvableFieldSet.Protobuf(),
 			Type:          e.Type,
@@ -175,7 +175,7 @@ func (e *Key) Protobuf() pb.Key {
 	return pb.Key{}
 }
 func (e *Truststate) Protobuf() pb.Truststate {
-	if e.GetVersion() == 1 {
+	if e.GetVersion() == 1 || e.GetVersion() == 2 {
 		return pb.Truststate{
 			Provable:       e.ProvableFieldSet.Protobuf(),
 			Target:         e.Target.Protobuf(),
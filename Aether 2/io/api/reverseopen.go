@@ -22,14 +22,19 @@ Why? Because it allows us to inject data to be sent to the remote server (the re
 
 func RequestInboundSync(host string, subhost string, port uint16) {
 	logging.Logf(1, "Attempting to request inbound sync from remote: %s/%s:%v", host, subhost, port)
-	to := fmt.Sprint(host, ":", port)
-	connToRemote, err := net.Dial("tcp4", to)
+	if IsOverlay(Location(host)) {
+		// Onion and I2P services are reachable by definition, there is no NAT for us to get around.
+		logging.Logf(1, "Request inbound sync was skipped, the remote is on an overlay network. Remote: %s:%v", host, port)
+		return
+	}
+	to := toolbox.JoinHostPort(host, port)
+	connToRemote, err := net.Dial(TCPNetwork(), to)
 	if err != nil {
 		logging.Logf(1, "Request inbound sync failed while attempting to establish a connection to the remote. Error: %v", err)
 		return
 	}
-	localSrvAddr := fmt.Sprint(":", globals.BackendConfig.GetExternalPort())
-	connToLocal, err := net.Dial("tcp4", localSrvAddr)
+	localSrvAddr := toolbox.JoinHostPort("", globals.BackendConfig.GetExternalPort())
+	connToLocal, err := net.Dial(TCPNetwork(), localSrvAddr)
 	if err != nil {
 		logging.Logf(1, "Request inbound sync failed while attempting to establish a connection to the local server. Error: %v", err)
 		return
@@ -0,0 +1,138 @@
+// API > SelectiveSync
+// This file provides the pieces that allow a node to replicate only the boards it cares about. When selective sync is on, we ask remotes only for the threads, posts and votes of the boards we track, we only hit the cache pages that contain those, and we strip whatever else arrives regardless (older remotes will ignore the board filter and send us everything).
+
+package api
+
+import (
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+)
+
+/*
+Which entities are board-scoped?
+
+Threads, posts and votes belong to a board, so these are the ones that we can choose to not replicate. Boards themselves, keys, truststates and addresses are always replicated in full. They are small, and without them, the users could neither discover new boards to subscribe to, nor verify the content of the boards they already track.
+*/
+
+// SyncedBoards returns the set of boards that we replicate. If it returns nil, selective sync is off and we replicate everything.
+// Scaled mode uses the same set: a node under disk pressure keeps syncing the boards its users track, and only stops syncing entirely if there are none.
+func SyncedBoards() map[Fingerprint]bool {
+	if !globals.BackendConfig.GetSelectiveSyncEnabled() && !globals.BackendConfig.GetScaledMode() {
+		return nil
+	}
+	boards := make(map[Fingerprint]bool)
+	for _, fp := range globals.BackendConfig.GetSelectiveSyncBoards() {
+		boards[Fingerprint(fp)] = true
+	}
+	for _, fp := range globals.BackendTransientConfig.SubscribedBoards.List() {
+		boards[Fingerprint(fp)] = true
+	}
+	return boards
+}
+
+// IsBoardScoped returns whether the entities of this endpoint belong to a board, and thus are subject to selective sync.
+func IsBoardScoped(endpoint string) bool {
+	return endpoint == "threads" || endpoint == "posts" || endpoint == "votes"
+}
+
+// boardFilter creates the filter we send to the remote to ask only for the entities of the given boards.
+func boardFilter(boards map[Fingerprint]bool) Filter {
+	f := Filter{Type: "board"}
+	for fp, _ := range boards {
+		f.Values = append(f.Values, string(fp))
+	}
+	sort.Strings(f.Values) // Keep it deterministic, so that the same request produces the same signature input.
+	if len(f.Values) > MAX_APIRESPONSE_FILTER_VALUES_BOARD_V1_0 {
+		f.Values = f.Values[0:MAX_APIRESPONSE_FILTER_VALUES_BOARD_V1_0]
+	}
+	return f
+}
+
+// StripUnsyncedBoards removes the board-scoped entities (and their indexes) that do not belong to one of the given boards. If boards is nil, selective sync is off and this is a no-op.
+func (r *Response) StripUnsyncedBoards(boards map[Fingerprint]bool) {
+	if boards == nil {
+		return
+	}
+	threads := []Thread{}
+	for _, e := range r.Threads {
+		if boards[e.Board] {
+			threads = append(threads, e)
+		}
+	}
+	posts := []Post{}
+	for _, e := range r.Posts {
+		if boards[e.Board] {
+			posts = append(posts, e)
+		}
+	}
+	votes := []Vote{}
+	for _, e := range r.Votes {
+		if boards[e.Board] {
+			votes = append(votes, e)
+		}
+	}
+	threadIndexes := []ThreadIndex{}
+	for _, e := range r.ThreadIndexes {
+		if boards[e.Board] {
+			threadIndexes = append(threadIndexes, e)
+		}
+	}
+	postIndexes := []PostIndex{}
+	for _, e := range r.PostIndexes {
+		if boards[e.Board] {
+			postIndexes = append(postIndexes, e)
+		}
+	}
+	voteIndexes := []VoteIndex{}
+	for _, e := range r.VoteIndexes {
+		if boards[e.Board] {
+			voteIndexes = append(voteIndexes, e)
+		}
+	}
+	stripped := (len(r.Threads) - len(threads)) + (len(r.Posts) - len(posts)) + (len(r.Votes) - len(votes))
+	if stripped > 0 {
+		logging.Logf(2, "Selective sync stripped %d entities that belong to boards we do not track.", stripped)
+	}
+	r.Threads, r.Posts, r.Votes = threads, posts, votes
+	r.ThreadIndexes, r.PostIndexes, r.VoteIndexes = threadIndexes, postIndexes, voteIndexes
+}
+
+// generateBoardScopedHitlist is the selective sync counterpart of generateHitlist. The manifests do not tell us which board an entity belongs to, so we look at the index of the cache instead, and only mark the pages that contain entities from the boards we track, and that we do not have yet.
+func generateBoardScopedHitlist(host string, subhost string, port uint16, location string, endpoint string, boards map[Fingerprint]bool, reverseConn *net.Conn) (map[int]bool, error) {
+	start := time.Now()
+	indexResponse, err := getIndexOfCache(host, subhost, port, location, reverseConn)
+	if err != nil {
+		return make(map[int]bool), errors.New(fmt.Sprintf("Error raised from getIndexOfCache inside generateBoardScopedHitlist. Error: %s", err))
+	}
+	allPgs := make(map[int]bool)
+	switch endpoint {
+	case "threads":
+		for _, val := range indexResponse.ThreadIndexes {
+			if boards[val.Board] && !allPgs[val.PageNumber] && !ExistsInDB("thread", val.Fingerprint, val.LastUpdate) {
+				allPgs[val.PageNumber] = true
+			}
+		}
+	case "posts":
+		for _, val := range indexResponse.PostIndexes {
+			if boards[val.Board] && !allPgs[val.PageNumber] && !ExistsInDB("post", val.Fingerprint, val.LastUpdate) {
+				allPgs[val.PageNumber] = true
+			}
+		}
+	case "votes":
+		for _, val := range indexResponse.VoteIndexes {
+			if boards[val.Board] && !allPgs[val.PageNumber] && !ExistsInDB("vote", val.Fingerprint, val.LastUpdate) {
+				allPgs[val.PageNumber] = true
+			}
+		}
+	default:
+		return allPgs, errors.New(fmt.Sprintf("generateBoardScopedHitlist does not support this endpoint. Endpoint: %s", endpoint))
+	}
+	elapsed := time.Since(start)
+	logging.Logf(1, "GenerateBoardScopedHitlist time spent: %#v\n", elapsed.String())
+	return allPgs, nil
+}
@@ -0,0 +1,44 @@
+package api_test
+
+import (
+	"aether-core/io/api"
+	"testing"
+)
+
+// Tests
+
+func TestStripUnsyncedBoards_Success(t *testing.T) {
+	var r api.Response
+	r.Boards = []api.Board{api.Board{}, api.Board{}}
+	r.Threads = []api.Thread{api.Thread{}, api.Thread{}}
+	r.Threads[0].Board = "tracked"
+	r.Threads[1].Board = "untracked"
+	r.Posts = []api.Post{api.Post{}}
+	r.Posts[0].Board = "untracked"
+	r.Votes = []api.Vote{api.Vote{}}
+	r.Votes[0].Board = "tracked"
+	r.Keys = []api.Key{api.Key{}}
+	r.StripUnsyncedBoards(map[api.Fingerprint]bool{"tracked": true})
+	if len(r.Threads) != 1 || r.Threads[0].Board != "tracked" {
+		t.Errorf("Test failed, the thread from the untracked board was not stripped. Threads: %#v", r.Threads)
+	}
+	if len(r.Posts) != 0 {
+		t.Errorf("Test failed, the post from the untracked board was not stripped.")
+	}
+	if len(r.Votes) != 1 {
+		t.Errorf("Test failed, the vote from the tracked board was stripped.")
+	}
+	if len(r.Boards) != 2 || len(r.Keys) != 1 {
+		t.Errorf("Test failed, entities that are not board-scoped were stripped.")
+	}
+}
+
+func TestStripUnsyncedBoards_NilIsNoop_Success(t *testing.T) {
+	var r api.Response
+	r.Threads = []api.Thread{api.Thread{}}
+	r.Threads[0].Board = "untracked"
+	r.StripUnsyncedBoards(nil)
+	if len(r.Threads) != 1 {
+		t.Errorf("Test failed, StripUnsyncedBoards stripped entities while selective sync was off.")
+	}
+}
@@ -0,0 +1,231 @@
+// API > Verify Pipeline
+// This file verifies the entities of a response together, across all cores, instead of one by one.
+
+package api
+
+import (
+	"aether-core/services/signaturing"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+/*
+How does the pipeline work?
+
+Verify checks one entity: bounds, fingerprint, proof of work, signature, entitlements, in that order. VerifyProvables does the same checks for a whole set, in stages:
+
+1) Entities we have already verified in exactly this form skip the checks up to the signature. Remotes give us the same entities all the time, and an update we have seen from one remote comes in again from the next. Their entitlements are checked again, since those depend on more than the entity itself, and that can change since we saw it.
+
+2) The checks up to the signature run on a pool of workers, one per core. The proof of work is the expensive one here.
+
+3) The signatures of everything that made it through are verified together, in batches, across all cores. (See signaturing.VerifyParallel)
+
+4) The entitlements are checked, and whatever passes is marked as verified, and remembered for step 1.
+
+The result is the same as calling Verify on each entity. The failures are counted per stage, so that we can see what the remotes are sending us that we throw away.
+*/
+
+type verificationStage int
+
+const (
+	stageNone verificationStage = iota
+	stagePreconditions
+	stageBounds
+	stageFingerprint
+	stagePoW
+	stageSignature
+	stageEntitlements
+)
+
+// VerificationStats says how a verification run went. The failures are counted at the stage they failed at.
+type VerificationStats struct {
+	Entities            int
+	Cached              int
+	Verified            int
+	FailedPreconditions int // Encrypted, or from another realm
+	FailedBounds        int
+	FailedFingerprint   int
+	FailedPoW           int
+	FailedSignature     int
+	FailedEntitlements  int
+}
+
+func (s *VerificationStats) fail(stage verificationStage) {
+	switch stage {
+	case stagePreconditions:
+		s.FailedPreconditions++
+	case stageBounds:
+		s.FailedBounds++
+	case stageFingerprint:
+		s.FailedFingerprint++
+	case stagePoW:
+		s.FailedPoW++
+	case stageSignature:
+		s.FailedSignature++
+	case stageEntitlements:
+		s.FailedEntitlements++
+	}
+}
+
+func (s *VerificationStats) Failed() int {
+	return s.FailedPreconditions + s.FailedBounds + s.FailedFingerprint + s.FailedPoW + s.FailedSignature + s.FailedEntitlements
+}
+
+func (s *VerificationStats) String() string {
+	return fmt.Sprintf("Entities: %d, Verified: %d (of which from cache: %d), Failed: %d (Preconditions: %d, Bounds: %d, Fingerprint: %d, PoW: %d, Signature: %d, Entitlements: %d)", s.Entities, s.Verified, s.Cached, s.Failed(), s.FailedPreconditions, s.FailedBounds, s.FailedFingerprint, s.FailedPoW, s.FailedSignature, s.FailedEntitlements)
+}
+
+/*----------  Verified cache  ----------*/
+
+// How many entities the verified cache remembers. When it is full, it starts over. An entity that falls out of it is only verified again, nothing else.
+const verifiedCacheSize = 200000
+
+/*
+verifiedCache remembers the entities we have verified. The key is the fingerprint and the last update, the value is the hash of the whole entity. The hash is what makes the cache safe: an entity that has the fingerprint and the last update of one we verified, but not the same content, is a different entity, and it gets verified in full.
+*/
+type verifiedCache struct {
+	lock    sync.Mutex
+	entries map[string][sha256.Size]byte
+}
+
+var verified = verifiedCache{entries: make(map[string][sha256.Size]byte)}
+
+func cacheKey(e Provable) string {
+	return fmt.Sprintf("%s:%d", e.GetFingerprint(), e.GetLastUpdate())
+}
+
+func contentHash(e Provable) ([sha256.Size]byte, bool) {
+	res, err := json.Marshal(e)
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(res), true
+}
+
+func (c *verifiedCache) has(e Provable) bool {
+	h, ok := contentHash(e)
+	if !ok {
+		return false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached, ok2 := c.entries[cacheKey(e)]
+	return ok2 && cached == h
+}
+
+func (c *verifiedCache) add(e Provable) {
+	h, ok := contentHash(e)
+	if !ok {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.entries) >= verifiedCacheSize {
+		c.entries = make(map[string][sha256.Size]byte)
+	}
+	c.entries[cacheKey(e)] = h
+}
+
+/*----------  Signature jobs  ----------*/
+
+// signatureJob returns the signature check VerifySignature would do for this entity (see signatureCheck), so that it can be done together with the others. skip is true if VerifySignature would pass the entity without checking.
+func signatureJob(e Provable) (signaturing.SignatureItem, bool, error) {
+	input, signature, skip, err := signatureCheck(e)
+	return signaturing.SignatureItem{Input: input, Signature: signature, PubKey: signingKey(e)}, skip, err
+}
+
+/*----------  Pipeline  ----------*/
+
+// VerifyProvables verifies the given entities, and marks the ones that pass as verified. It returns the errors of the ones that don't, and the counts per stage.
+func VerifyProvables(ps []Provable) ([]error, VerificationStats) {
+	stats := VerificationStats{Entities: len(ps)}
+	errs := []error{}
+	// 1) Cache
+	pending := []Provable{}
+	for _, e := range ps {
+		if verified.has(e) {
+			err := verifyEntitlementsAndMark(e)
+			if err != nil {
+				stats.fail(stageEntitlements)
+				errs = append(errs, err)
+				continue
+			}
+			stats.Cached++
+			stats.Verified++
+			continue
+		}
+		pending = append(pending, e)
+	}
+	// 2) Up to the signature, in parallel
+	stages := make([]verificationStage, len(pending))
+	stageErrs := make([]error, len(pending))
+	jobs := make([]signaturing.SignatureItem, len(pending))
+	skipSig := make([]bool, len(pending))
+	workers := runtime.NumCPU()
+	queue := make(chan int, len(pending))
+	for i := range pending {
+		queue <- i
+	}
+	close(queue)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Every index is written by one worker only.
+			for i := range queue {
+				stage, err := verifyUntilSignature(pending[i])
+				if err != nil {
+					stages[i], stageErrs[i] = stage, err
+					continue
+				}
+				item, skip, err2 := signatureJob(pending[i])
+				if err2 != nil {
+					stages[i], stageErrs[i] = stageSignature, err2
+					continue
+				}
+				jobs[i], skipSig[i] = item, skip
+			}
+		}()
+	}
+	wg.Wait()
+	// 3) Signatures, in batches
+	batch := []signaturing.SignatureItem{}
+	batchIndex := []int{}
+	for i := range pending {
+		if stages[i] == stageNone && !skipSig[i] {
+			batch = append(batch, jobs[i])
+			batchIndex = append(batchIndex, i)
+		}
+	}
+	results := signaturing.VerifyParallel(batch)
+	for k, ok := range results {
+		if !ok {
+			i := batchIndex[k]
+			stages[i] = stageSignature
+			stageErrs[i] = errors.New(fmt.Sprintf(
+				"Signature of this entity is invalid. Signature: %s, Entity: %#v\n", pending[i].GetSignature(), pending[i]))
+		}
+	}
+	// 4) Entitlements
+	for i, e := range pending {
+		if stages[i] == stageNone {
+			err := verifyEntitlementsAndMark(e)
+			if err != nil {
+				stages[i], stageErrs[i] = stageEntitlements, err
+			}
+		}
+		if stages[i] != stageNone {
+			stats.fail(stages[i])
+			errs = append(errs, stageErrs[i])
+			continue
+		}
+		verified.add(e)
+		stats.Verified++
+	}
+	return errs, stats
+}
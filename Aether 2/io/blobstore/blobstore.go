@@ -0,0 +1,243 @@
+// IO > BlobStore
+// This package keeps the chunks of the attachments of threads and posts, in files of their own, outside the database.
+
+package blobstore
+
+import (
+	"aether-core/services/fingerprinting"
+	"aether-core/services/globals"
+	"aether-core/services/logging"
+	"aether-core/services/metaparse"
+	"aether-core/services/toolbox"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*
+How are attachments stored?
+
+A file attached to a thread or a post is split into chunks of ChunkSize, and every chunk is saved under its hash (the same SHA256 we use for fingerprints). The hash of the attachment is the hash of its chunk hashes, so the attachment in the meta of an entity is enough to find all of its chunks, and to tell whether a chunk somebody gave us is the right one. A chunk that is in two attachments is only stored once.
+
+The chunks are not in the database. They can be large, and unlike the entities, we can lose them without breaking anything: a thread whose image we don't have is still a thread. So the store has a size cap of its own, and when it is over, the chunks that were read the longest time ago go first.
+
+Attachments are capped in size, and in how many an entity can have. Every chunk also makes the proof of work of the entity harder (see api.AttachmentPoWBits), so that the cost of sending a file to the whole network grows with its size.
+*/
+
+const (
+	ChunkSize               = 256 * 1024     // 256Kb
+	MaxAttachmentSize       = 12 * ChunkSize // 3Mb. Under the 4Mb gRPC message limit, so a whole attachment fits into one message between the client, the frontend and the backend.
+	MaxAttachmentsPerEntity = 4
+	blobsDirName            = "blobs"
+	hashLength              = 64
+)
+
+// Hash returns the hash a chunk is addressed by.
+func Hash(data []byte) string {
+	return fingerprinting.Create(string(data))
+}
+
+// AttachmentHash returns the hash of an attachment from the hashes of its chunks.
+func AttachmentHash(chunkHashes []string) string {
+	return fingerprinting.Create(strings.Join(chunkHashes, "\n"))
+}
+
+func validHash(h string) bool {
+	if len(h) != hashLength {
+		return false
+	}
+	for _, c := range h {
+		if !((c >= '0' && c <= '9') || (c >= 'a' && c <= 'f')) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidAttachment checks that an attachment is within the caps, and that it is consistent: the hash matches the chunks, and the size matches the number of chunks. It does not need the chunks themselves.
+func ValidAttachment(a *metaparse.Attachment) bool {
+	if a.Size <= 0 || a.Size > MaxAttachmentSize {
+		return false
+	}
+	if int64(len(a.Chunks)) != (a.Size+ChunkSize-1)/ChunkSize {
+		return false
+	}
+	if len(a.Name) > 255 || len(a.MimeType) > 255 {
+		return false
+	}
+	for _, c := range a.Chunks {
+		if !validHash(c) {
+			return false
+		}
+	}
+	return a.Hash == AttachmentHash(a.Chunks)
+}
+
+// Split cuts the data into chunks, and returns the attachment that describes them, and the chunks keyed by their hashes.
+func Split(data []byte, name, mimeType string) (metaparse.Attachment, map[string][]byte, error) {
+	a := metaparse.Attachment{Name: name, MimeType: mimeType, Size: int64(len(data))}
+	chunks := make(map[string][]byte)
+	if len(data) == 0 {
+		return a, chunks, errors.New("This attachment is empty.")
+	}
+	if len(data) > MaxAttachmentSize {
+		return a, chunks, errors.New(fmt.Sprintf("This attachment is too large. Size: %d, Maximum: %d", len(data), MaxAttachmentSize))
+	}
+	for i := 0; i < len(data); i += ChunkSize {
+		end := i + ChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		c := data[i:end]
+		h := Hash(c)
+		a.Chunks = append(a.Chunks, h)
+		chunks[h] = c
+	}
+	a.Hash = AttachmentHash(a.Chunks)
+	return a, chunks, nil
+}
+
+// Join puts the chunks of an attachment back together, and checks every chunk against its hash on the way.
+func Join(a *metaparse.Attachment, chunks map[string][]byte) ([]byte, error) {
+	if !ValidAttachment(a) {
+		return []byte{}, errors.New(fmt.Sprintf("This attachment is invalid. Hash: %s", a.Hash))
+	}
+	data := []byte{}
+	for _, h := range a.Chunks {
+		c, ok := chunks[h]
+		if !ok {
+			return []byte{}, errors.New(fmt.Sprintf("A chunk of this attachment is missing. Attachment: %s, Chunk: %s", a.Hash, h))
+		}
+		if Hash(c) != h {
+			return []byte{}, errors.New(fmt.Sprintf("A chunk of this attachment does not match its hash. Attachment: %s, Chunk: %s", a.Hash, h))
+		}
+		data = append(data, c...)
+	}
+	if int64(len(data)) != a.Size {
+		return []byte{}, errors.New(fmt.Sprintf("This attachment is not the size it says it is. Attachment: %s, Size: %d, Expected: %d", a.Hash, len(data), a.Size))
+	}
+	return data, nil
+}
+
+/*----------  The store  ----------*/
+
+func dir() string {
+	return filepath.Join(globals.BackendConfig.GetUserDirectory(), "backend", blobsDirName)
+}
+
+func path(hash string) string {
+	return filepath.Join(dir(), hash[:2], hash)
+}
+
+// Put saves a chunk. The chunk has to match its hash, so a remote can't give us something other than what we asked for.
+func Put(hash string, data []byte) error {
+	if !validHash(hash) {
+		return errors.New(fmt.Sprintf("This is not a valid chunk hash. Hash: %s", hash))
+	}
+	if len(data) == 0 || len(data) > ChunkSize {
+		return errors.New(fmt.Sprintf("This chunk is not a valid size. Hash: %s, Size: %d", hash, len(data)))
+	}
+	if Hash(data) != hash {
+		return errors.New(fmt.Sprintf("This chunk does not match its hash. Hash: %s", hash))
+	}
+	if Has(hash) {
+		return nil
+	}
+	toolbox.CreatePath(filepath.Dir(path(hash)))
+	// Write, then rename, so that a chunk that is there is always whole.
+	tmp := path(hash) + ".tmp"
+	err := ioutil.WriteFile(tmp, data, 0600)
+	if err != nil {
+		return errors.New(fmt.Sprintf("The chunk could not be saved. Hash: %s, Error: %v", hash, err))
+	}
+	return os.Rename(tmp, path(hash))
+}
+
+// Get reads a chunk. Reading a chunk marks it as used, so that it is pruned last.
+func Get(hash string) ([]byte, error) {
+	if !validHash(hash) {
+		return []byte{}, errors.New(fmt.Sprintf("This is not a valid chunk hash. Hash: %s", hash))
+	}
+	data, err := ioutil.ReadFile(path(hash))
+	if err != nil {
+		return []byte{}, err
+	}
+	now := time.Now()
+	os.Chtimes(path(hash), now, now)
+	return data, nil
+}
+
+func Has(hash string) bool {
+	if !validHash(hash) {
+		return false
+	}
+	_, err := os.Stat(path(hash))
+	return err == nil
+}
+
+// Missing returns the hashes of the chunks of the given list that we do not have.
+func Missing(hashes []string) []string {
+	missing := []string{}
+	for _, h := range hashes {
+		if !Has(h) {
+			missing = append(missing, h)
+		}
+	}
+	return missing
+}
+
+type chunkFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func listChunks() ([]chunkFile, int64) {
+	files := []chunkFile{}
+	var total int64
+	filepath.Walk(dir(), func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		files = append(files, chunkFile{path: p, size: info.Size(), modTime: info.ModTime()})
+		total = total + info.Size()
+		return nil
+	})
+	return files, total
+}
+
+// SizeMb is how much space the chunks take.
+func SizeMb() int {
+	_, total := listChunks()
+	return int(total / 1000000)
+}
+
+// Prune deletes the chunks that were used the longest time ago, until the store is under the cap in the config.
+func Prune() {
+	max := int64(globals.BackendConfig.GetMaxBlobStoreSizeMb()) * 1000000
+	files, total := listChunks()
+	if total <= max {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	removed := 0
+	for _, f := range files {
+		if total <= max {
+			break
+		}
+		if err := os.Remove(f.path); err != nil {
+			logging.Logf(1, "A chunk could not be pruned from the blob store. Path: %s, Error: %v", f.path, err)
+			continue
+		}
+		total = total - f.size
+		removed++
+	}
+	logging.Logf(1, "The blob store was over its size cap, and it was pruned. Chunks removed: %d, Size now: %dMb", removed, total/1000000)
+}
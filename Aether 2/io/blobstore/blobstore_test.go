@@ -0,0 +1,125 @@
+package blobstore_test
+
+import (
+	"aether-core/backend/cmd"
+	"aether-core/io/blobstore"
+	"aether-core/services/globals"
+	"bytes"
+	"os"
+	"testing"
+)
+
+// Infrastructure, setup and teardown
+
+func TestMain(m *testing.M) {
+	cmd.EstablishConfigs(nil)
+	globals.BackendTransientConfig.PermConfigReadOnly = true
+	globals.BackendConfig.SetLoggingLevel(0)
+	exitVal := m.Run()
+	os.Exit(exitVal)
+}
+
+func testData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+// Tests
+
+func TestSplitJoin_RoundTrip(t *testing.T) {
+	data := testData(blobstore.ChunkSize*2 + 100)
+	a, chunks, err := blobstore.Split(data, "image.png", "image/png")
+	if err != nil {
+		t.Fatalf("Split failed. Error: %v", err)
+	}
+	if len(a.Chunks) != 3 {
+		t.Errorf("Expected 3 chunks, got %d.", len(a.Chunks))
+	}
+	if !blobstore.ValidAttachment(&a) {
+		t.Errorf("The attachment Split returned is not valid. Attachment: %#v", a)
+	}
+	joined, err2 := blobstore.Join(&a, chunks)
+	if err2 != nil {
+		t.Fatalf("Join failed. Error: %v", err2)
+	}
+	if !bytes.Equal(data, joined) {
+		t.Errorf("The joined data is not the same as the original.")
+	}
+}
+
+func TestJoin_TamperedChunk(t *testing.T) {
+	a, chunks, _ := blobstore.Split(testData(blobstore.ChunkSize+1), "file", "application/octet-stream")
+	chunks[a.Chunks[1]] = []byte{1}
+	_, err := blobstore.Join(&a, chunks)
+	if err == nil {
+		t.Errorf("A chunk that does not match its hash was accepted.")
+	}
+}
+
+func TestJoin_MissingChunk(t *testing.T) {
+	a, chunks, _ := blobstore.Split(testData(blobstore.ChunkSize+1), "file", "application/octet-stream")
+	delete(chunks, a.Chunks[0])
+	_, err := blobstore.Join(&a, chunks)
+	if err == nil {
+		t.Errorf("An attachment with a missing chunk was joined.")
+	}
+}
+
+func TestSplit_SizeCap(t *testing.T) {
+	_, _, err := blobstore.Split(testData(blobstore.MaxAttachmentSize+1), "file", "application/octet-stream")
+	if err == nil {
+		t.Errorf("An attachment over the size cap was split.")
+	}
+	_, _, err2 := blobstore.Split([]byte{}, "file", "application/octet-stream")
+	if err2 == nil {
+		t.Errorf("An empty attachment was split.")
+	}
+}
+
+func TestValidAttachment_Inconsistent(t *testing.T) {
+	a, _, _ := blobstore.Split(testData(blobstore.ChunkSize*2), "file", "application/octet-stream")
+	wrongSize := a
+	wrongSize.Size = blobstore.ChunkSize * 3
+	if blobstore.ValidAttachment(&wrongSize) {
+		t.Errorf("An attachment whose size does not match its chunks was valid.")
+	}
+	wrongHash := a
+	wrongHash.Chunks = []string{a.Chunks[1], a.Chunks[0]}
+	if blobstore.ValidAttachment(&wrongHash) {
+		t.Errorf("An attachment whose hash does not match its chunks was valid.")
+	}
+}
+
+func TestPutGet(t *testing.T) {
+	data := testData(1000)
+	h := blobstore.Hash(data)
+	err := blobstore.Put(h, data)
+	if err != nil {
+		t.Fatalf("Put failed. Error: %v", err)
+	}
+	if !blobstore.Has(h) {
+		t.Errorf("The chunk we put in is not in the store.")
+	}
+	got, err2 := blobstore.Get(h)
+	if err2 != nil || !bytes.Equal(got, data) {
+		t.Errorf("The chunk we got is not the one we put in. Error: %v", err2)
+	}
+	if len(blobstore.Missing([]string{h})) != 0 {
+		t.Errorf("A chunk we have is listed as missing.")
+	}
+}
+
+func TestPut_WrongHash(t *testing.T) {
+	data := testData(1000)
+	h := blobstore.Hash(testData(999))
+	err := blobstore.Put(h, data)
+	if err == nil {
+		t.Errorf("A chunk that does not match its hash was saved.")
+	}
+	if blobstore.Has(h) {
+		t.Errorf("A chunk that does not match its hash is in the store.")
+	}
+}
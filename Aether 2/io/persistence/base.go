@@ -68,8 +68,8 @@ func createDatabase() error {
 	var schema10 string
 	var schema11 string
 	var schema12 string
-	// var schema13 string
-	// var schema14 string
+	var schema13 string
+	var schema14 string
 	// var schema15 string
 	var schema16 string
 	// var schema17 string
@@ -304,6 +304,25 @@ func createDatabase() error {
             AddressPort INTEGER NOT NULL,
             SubprotocolFingerprint VARCHAR(64) NOT NULL,
             PRIMARY KEY(AddressLocation, AddressSublocation, AddressPort, SubprotocolFingerprint)
+          )ROW_FORMAT=COMPRESSED;`
+		schema13 = `
+          CREATE TABLE IF NOT EXISTS Pins (
+            EntityType VARCHAR(16) NOT NULL,
+            Fingerprint VARCHAR(64) NOT NULL,
+            PinnedAt BIGINT NOT NULL,
+            PRIMARY KEY(EntityType, Fingerprint)
+          )ROW_FORMAT=COMPRESSED;`
+		schema14 = `
+          CREATE TABLE IF NOT EXISTS Revisions (
+            EntityType VARCHAR(16) NOT NULL,
+            Fingerprint VARCHAR(64) NOT NULL,
+            LastUpdate BIGINT NOT NULL,
+            Body MEDIUMTEXT NOT NULL,
+            Meta MEDIUMTEXT NOT NULL,
+            UpdateProofOfWork VARCHAR(1024) NOT NULL,
+            UpdateSignature VARCHAR(512) NOT NULL,
+            LocalArrival BIGINT NOT NULL,
+            PRIMARY KEY(EntityType, Fingerprint, LastUpdate)
           )ROW_FORMAT=COMPRESSED;`
 		schema16 = `
           CREATE TABLE IF NOT EXISTS Diagnostics (
@@ -507,6 +526,25 @@ func createDatabase() error {
           ,  "AddressPort" integer NOT NULL
           ,  "SubprotocolFingerprint" varchar(64) NOT NULL
           ,  PRIMARY KEY ("AddressLocation","AddressSublocation","AddressPort","SubprotocolFingerprint")
+          );`
+		schema13 = `
+          CREATE TABLE IF NOT EXISTS "Pins" (
+            "EntityType" varchar(16) NOT NULL
+          ,  "Fingerprint" varchar(64) NOT NULL
+          ,  "PinnedAt" integer NOT NULL
+          ,  PRIMARY KEY ("EntityType","Fingerprint")
+          );`
+		schema14 = `
+          CREATE TABLE IF NOT EXISTS "Revisions" (
+            "EntityType" varchar(16) NOT NULL
+          ,  "Fingerprint" varchar(64) NOT NULL
+          ,  "LastUpdate" integer NOT NULL
+          ,  "Body" text NOT NULL
+          ,  "Meta" text NOT NULL
+          ,  "UpdateProofOfWork" varchar(1024) NOT NULL
+          ,  "UpdateSignature" varchar(512) NOT NULL
+          ,  "LocalArrival" integer NOT NULL
+          ,  PRIMARY KEY ("EntityType","Fingerprint","LastUpdate")
           );`
 		schema16 = `
             CREATE TABLE IF NOT EXISTS "Diagnostics" (
@@ -608,6 +646,8 @@ func createDatabase() error {
 		creationSchemas = append(creationSchemas, schema10)
 		creationSchemas = append(creationSchemas, schema11)
 		creationSchemas = append(creationSchemas, schema12)
+		creationSchemas = append(creationSchemas, schema13)
+		creationSchemas = append(creationSchemas, schema14)
 		creationSchemas = append(creationSchemas, schema16)
 		creationSchemas = append(creationSchemas, idxSqlite1)
 		creationSchemas = append(creationSchemas, idxSqlite2)
@@ -649,6 +689,8 @@ func createDatabase() error {
 		creationSchemas = append(creationSchemas, schema10)
 		creationSchemas = append(creationSchemas, schema11)
 		creationSchemas = append(creationSchemas, schema12)
+		creationSchemas = append(creationSchemas, schema13)
+		creationSchemas = append(creationSchemas, schema14)
 		creationSchemas = append(creationSchemas, schema16)
 	}
 
@@ -731,6 +773,13 @@ func CheckDatabaseReady() {
 // `
 
 // NodeInsert just inserts the Node details into the entry. This is mutable.
+var pinInsert = `REPLACE INTO Pins
+(
+  EntityType, Fingerprint, PinnedAt
+) VALUES (
+  :EntityType, :Fingerprint, :PinnedAt
+)`
+
 var nodeInsert = `REPLACE INTO Nodes
 (
   Fingerprint, BoardsLastCheckin, ThreadsLastCheckin, PostsLastCheckin,
@@ -967,6 +1016,88 @@ WHERE (
     PublicKey = :OwnerPublicKey
 );
 `
+/*
+  The revisions. An update overwrites the row in Threads or Posts, so before the candidate goes in, the version it replaces is copied into Revisions. The candidate is kept there too if it's an edit, in case it's older than what we have and would otherwise never make it in. A thread or post that was never edited has no revisions, its only version is in its own table.
+
+  Only the newest maxRevisionsPerEntity revisions of an entity are kept. Every edit is a valid, signed update, so without a cap, the owner of a post could grow it without bound by editing it over and over.
+*/
+
+const maxRevisionsPerEntity = 20
+
+// MySQL doesn't let a DELETE read the table it deletes from in a subquery, unless the subquery is wrapped in a derived table. If the entity has fewer revisions than the cap, the derived table is empty and nothing is deleted.
+var revisionsTrimOldest = `
+/* Delete the revisions of %[1]s past the newest %[2]d */
+DELETE FROM Revisions
+WHERE EntityType = '%[1]s' AND
+  Fingerprint = :Fingerprint AND
+  LastUpdate < (
+    SELECT LastUpdate FROM
+      (SELECT LastUpdate FROM Revisions
+       WHERE EntityType = '%[1]s' AND Fingerprint = :Fingerprint
+       ORDER BY LastUpdate DESC
+       LIMIT 1 OFFSET %[3]d
+      ) AS Oldest
+  );
+`
+
+var threadInsert_Revisions_TrimOldest = fmt.Sprintf(revisionsTrimOldest, "thread", maxRevisionsPerEntity, maxRevisionsPerEntity-1)
+
+var threadInsert_Revisions_ArchivePrior = `
+/* Keep the version of THREAD that CANDIDATE will replace */
+REPLACE INTO Revisions
+(
+  EntityType, Fingerprint, LastUpdate, Body, Meta, UpdateProofOfWork,
+  UpdateSignature, LocalArrival
+)
+SELECT 'thread', Fingerprint, LastUpdate, Body, Meta, UpdateProofOfWork,
+  UpdateSignature, LocalArrival
+FROM Threads
+WHERE Threads.Fingerprint = :Fingerprint AND
+  :LastUpdate > Threads.LastUpdate AND
+  :LastUpdate > Threads.Creation;
+`
+
+// The candidate is kept as it was when it was first seen, so that its LocalArrival stays the time it arrived, and doesn't move every time it's received again.
+var threadInsert_Revisions_CandidateMySQL = `
+/* Keep CANDIDATE, if it's an edit */
+INSERT IGNORE INTO Revisions
+(
+  EntityType, Fingerprint, LastUpdate, Body, Meta, UpdateProofOfWork,
+  UpdateSignature, LocalArrival
+)
+SELECT Candidate.* FROM
+  (SELECT 'thread' AS EntityType,
+          :Fingerprint AS Fingerprint,
+          :LastUpdate AS LastUpdate,
+          :Body AS Body,
+          :Meta AS Meta,
+          :UpdateProofOfWork AS UpdateProofOfWork,
+          :UpdateSignature AS UpdateSignature,
+          :LocalArrival AS LocalArrival
+          ) AS Candidate
+WHERE Candidate.LastUpdate > :Creation;
+`
+
+var threadInsert_Revisions_CandidateSQLite = `
+/* Keep CANDIDATE, if it's an edit */
+INSERT OR IGNORE INTO Revisions
+(
+  EntityType, Fingerprint, LastUpdate, Body, Meta, UpdateProofOfWork,
+  UpdateSignature, LocalArrival
+)
+SELECT Candidate.* FROM
+  (SELECT 'thread' AS EntityType,
+          :Fingerprint AS Fingerprint,
+          :LastUpdate AS LastUpdate,
+          :Body AS Body,
+          :Meta AS Meta,
+          :UpdateProofOfWork AS UpdateProofOfWork,
+          :UpdateSignature AS UpdateSignature,
+          :LocalArrival AS LocalArrival
+          ) AS Candidate
+WHERE Candidate.LastUpdate > :Creation;
+`
+
 var threadInsert = `
 REPLACE INTO Threads
   SELECT Candidate.* FROM
@@ -1233,6 +1364,63 @@ WHERE (
     PublicKey = :OwnerPublicKey
 );
 `
+var postInsert_Revisions_ArchivePrior = `
+/* Keep the version of POST that CANDIDATE will replace */
+REPLACE INTO Revisions
+(
+  EntityType, Fingerprint, LastUpdate, Body, Meta, UpdateProofOfWork,
+  UpdateSignature, LocalArrival
+)
+SELECT 'post', Fingerprint, LastUpdate, Body, Meta, UpdateProofOfWork,
+  UpdateSignature, LocalArrival
+FROM Posts
+WHERE Posts.Fingerprint = :Fingerprint AND
+  :LastUpdate > Posts.LastUpdate AND
+  :LastUpdate > Posts.Creation;
+`
+
+var postInsert_Revisions_CandidateMySQL = `
+/* Keep CANDIDATE, if it's an edit */
+INSERT IGNORE INTO Revisions
+(
+  EntityType, Fingerprint, LastUpdate, Body, Meta, UpdateProofOfWork,
+  UpdateSignature, LocalArrival
+)
+SELECT Candidate.* FROM
+  (SELECT 'post' AS EntityType,
+          :Fingerprint AS Fingerprint,
+          :LastUpdate AS LastUpdate,
+          :Body AS Body,
+          :Meta AS Meta,
+          :UpdateProofOfWork AS UpdateProofOfWork,
+          :UpdateSignature AS UpdateSignature,
+          :LocalArrival AS LocalArrival
+          ) AS Candidate
+WHERE Candidate.LastUpdate > :Creation;
+`
+
+var postInsert_Revisions_CandidateSQLite = `
+/* Keep CANDIDATE, if it's an edit */
+INSERT OR IGNORE INTO Revisions
+(
+  EntityType, Fingerprint, LastUpdate, Body, Meta, UpdateProofOfWork,
+  UpdateSignature, LocalArrival
+)
+SELECT Candidate.* FROM
+  (SELECT 'post' AS EntityType,
+          :Fingerprint AS Fingerprint,
+          :LastUpdate AS LastUpdate,
+          :Body AS Body,
+          :Meta AS Meta,
+          :UpdateProofOfWork AS UpdateProofOfWork,
+          :UpdateSignature AS UpdateSignature,
+          :LocalArrival AS LocalArrival
+          ) AS Candidate
+WHERE Candidate.LastUpdate > :Creation;
+`
+
+var postInsert_Revisions_TrimOldest = fmt.Sprintf(revisionsTrimOldest, "post", maxRevisionsPerEntity, maxRevisionsPerEntity-1)
+
 var postInsert = `
 REPLACE INTO Posts
 SELECT Candidate.* FROM
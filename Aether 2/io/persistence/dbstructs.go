@@ -8,6 +8,7 @@ import (
 	// "aether-core/services/compress"
 	"aether-core/services/fingerprinting"
 	"aether-core/services/logging"
+	"aether-core/services/toolbox"
 	"encoding/csv"
 	"encoding/json"
 	"errors"
@@ -201,6 +202,25 @@ type DbNode struct {
 	AddressesLastCheckin   api.Timestamp   `db:"AddressesLastCheckin"`
 }
 
+// DbPin is a board, thread or key that the local user has pinned. Pinned entities (and their ancestry and descendants) are exempt from the event horizon. This is local only, it is never communicated to other nodes.
+type DbPin struct {
+	EntityType  string          `db:"EntityType"` // board, thread, key
+	Fingerprint api.Fingerprint `db:"Fingerprint"`
+	PinnedAt    api.Timestamp   `db:"PinnedAt"`
+}
+
+// DbRevision is a version of a thread or post body. Threads and posts are mutable, and the insert overwrites the row with the newer version, so the versions it replaces are kept here, with the signature and proof of work they were published with. This is local only, it is not served to other nodes.
+type DbRevision struct {
+	EntityType        string          `db:"EntityType"` // thread, post
+	Fingerprint       api.Fingerprint `db:"Fingerprint"`
+	LastUpdate        api.Timestamp   `db:"LastUpdate"`
+	Body              string          `db:"Body"`
+	Meta              string          `db:"Meta"`
+	UpdateProofOfWork api.ProofOfWork `db:"UpdateProofOfWork"`
+	UpdateSignature   api.Signature   `db:"UpdateSignature"`
+	LocalArrival      api.Timestamp   `db:"LocalArrival"`
+}
+
 // Return types of APIToDB. This is necessary because some API objects, when converted to their DB form, return more than one DB object.
 
 type BoardPack struct {
@@ -418,7 +438,8 @@ func APItoDB(object interface{}, ts time.Time) (interface{}, error) {
 			return AddressPack{}, errors.New(fmt.Sprintf("This Api entity failed verification (or the verification hasn't been run on it), thus is denied conversion to the Db entity. Entity %#v", obj))
 		}
 		var dbObj DbAddress
-		dbObj.Location = obj.Location
+		// The same IPv6 address can be written in many ways. Save it in one, so that it is one row, and so that the lookups by location find it.
+		dbObj.Location = api.Location(toolbox.NormalizeLocation(string(obj.Location)))
 		dbObj.Sublocation = obj.Sublocation
 		dbObj.LocationType = obj.LocationType
 		dbObj.Port = obj.Port
@@ -0,0 +1,173 @@
+// Persistence > Entitlements
+// This file gives the entitlement checks of the api package the entities they need, from the database and from the batch being inserted.
+
+package persistence
+
+import (
+	"aether-core/io/api"
+	"aether-core/services/logging"
+)
+
+// How many fingerprints we put into one IN (...) query. SQLite has a cap on the number of variables in a query.
+const entitlementReadChunk = 500
+
+// entitlementLookup implements api.EntitlementLookup for one batch insert. Everything the batch refers to is read from the database up front, in a few queries, not one per entity.
+type entitlementLookup struct {
+	stored map[api.Fingerprint]interface{}
+	batch  map[api.Fingerprint]interface{}
+}
+
+func (l *entitlementLookup) Stored(fp api.Fingerprint) interface{} {
+	return l.stored[fp]
+}
+
+func (l *entitlementLookup) Find(fp api.Fingerprint) interface{} {
+	if e, ok := l.batch[fp]; ok {
+		if s, ok2 := l.stored[fp]; ok2 && lastUpdateOf(s) > lastUpdateOf(e) {
+			return s
+		}
+		return e
+	}
+	return l.stored[fp]
+}
+
+func lastUpdateOf(e interface{}) api.Timestamp {
+	switch ent := e.(type) {
+	case api.Board:
+		return ent.LastUpdate
+	case api.Thread:
+		return ent.LastUpdate
+	case api.Post:
+		return ent.LastUpdate
+	case api.Vote:
+		return ent.LastUpdate
+	case api.Key:
+		return ent.LastUpdate
+	case api.Truststate:
+		return ent.LastUpdate
+	}
+	return 0
+}
+
+type fpSet map[api.Fingerprint]bool
+
+func (s fpSet) add(fps ...api.Fingerprint) {
+	for _, fp := range fps {
+		if len(fp) > 0 {
+			s[fp] = true
+		}
+	}
+}
+
+func (s fpSet) chunks() [][]api.Fingerprint {
+	all := []api.Fingerprint{}
+	for fp, _ := range s {
+		all = append(all, fp)
+	}
+	chunks := [][]api.Fingerprint{}
+	for i := 0; i < len(all); i += entitlementReadChunk {
+		end := i + entitlementReadChunk
+		if end > len(all) {
+			end = len(all)
+		}
+		chunks = append(chunks, all[i:end])
+	}
+	return chunks
+}
+
+// newEntitlementLookup indexes the batch, and reads the entities the batch refers to from the database.
+func newEntitlementLookup(apiObjects []interface{}) *entitlementLookup {
+	l := entitlementLookup{
+		stored: make(map[api.Fingerprint]interface{}),
+		batch:  make(map[api.Fingerprint]interface{}),
+	}
+	boards, threads, posts, votes, keys, truststates := fpSet{}, fpSet{}, fpSet{}, fpSet{}, fpSet{}, fpSet{}
+	for _, obj := range apiObjects {
+		var fp api.Fingerprint
+		switch ent := obj.(type) {
+		case api.Board:
+			fp = ent.Fingerprint
+			boards.add(ent.Fingerprint)
+			keys.add(ent.Owner)
+		case api.Thread:
+			fp = ent.Fingerprint
+			threads.add(ent.Fingerprint)
+			keys.add(ent.Owner)
+		case api.Post:
+			fp = ent.Fingerprint
+			posts.add(ent.Fingerprint, ent.Parent)
+			threads.add(ent.Thread)
+			keys.add(ent.Owner)
+		case api.Vote:
+			fp = ent.Fingerprint
+			votes.add(ent.Fingerprint)
+			// The target can be a thread or a post.
+			threads.add(ent.Target)
+			posts.add(ent.Target)
+			keys.add(ent.Owner)
+		case api.Key:
+			fp = ent.Fingerprint
+			keys.add(ent.Fingerprint)
+		case api.Truststate:
+			fp = ent.Fingerprint
+			truststates.add(ent.Fingerprint)
+			keys.add(ent.Owner)
+		default:
+			continue
+		}
+		// If the batch has more than one version of an entity, the newest one is the one that ends up in the database.
+		if prev, ok := l.batch[fp]; !ok || lastUpdateOf(obj) > lastUpdateOf(prev) {
+			l.batch[fp] = obj
+		}
+	}
+	for _, c := range boards.chunks() {
+		entities, err := ReadBoards(c, 0, 0, "", 0, 0)
+		logEntitlementReadError(err)
+		for key, _ := range entities {
+			l.stored[entities[key].Fingerprint] = entities[key]
+		}
+	}
+	for _, c := range threads.chunks() {
+		entities, err := ReadThreads(c, 0, 0, "", "", 0, 0)
+		logEntitlementReadError(err)
+		for key, _ := range entities {
+			l.stored[entities[key].Fingerprint] = entities[key]
+		}
+	}
+	for _, c := range posts.chunks() {
+		entities, err := ReadPosts(c, 0, 0, "", "", "", "", 0, 0)
+		logEntitlementReadError(err)
+		for key, _ := range entities {
+			l.stored[entities[key].Fingerprint] = entities[key]
+		}
+	}
+	for _, c := range votes.chunks() {
+		entities, err := ReadVotes(c, 0, 0, -1, -1, "", "", "", false, "", 0, 0)
+		logEntitlementReadError(err)
+		for key, _ := range entities {
+			l.stored[entities[key].Fingerprint] = entities[key]
+		}
+	}
+	for _, c := range keys.chunks() {
+		entities, err := ReadKeys(c, 0, 0, "", 0, 0)
+		logEntitlementReadError(err)
+		for key, _ := range entities {
+			l.stored[entities[key].Fingerprint] = entities[key]
+		}
+	}
+	for _, c := range truststates.chunks() {
+		entities, err := ReadTruststates(c, 0, 0, -1, -1, "", "", "", 0, 0)
+		logEntitlementReadError(err)
+		for key, _ := range entities {
+			l.stored[entities[key].Fingerprint] = entities[key]
+		}
+	}
+	return &l
+}
+
+func logEntitlementReadError(err error) {
+	if err != nil {
+		// We check against what we could read. What we couldn't is treated as not there, the same as an entity we have not received yet.
+		logging.Logf(1, "Reading the entities referred to by this batch for the entitlement checks failed. Error: %v", err)
+	}
+}
@@ -9,7 +9,9 @@ import (
 	// "github.com/davecgh/go-spew/spew"
 	"github.com/jmoiron/sqlx"
 	"log"
+	"net"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -33,6 +35,10 @@ func setup() {
 	globals.BackendTransientConfig.SignatureCheckEnabled = false
 	globals.BackendTransientConfig.ProofOfWorkCheckEnabled = false
 	globals.BackendTransientConfig.PageSignatureCheckEnabled = false
+	// No admin frontend is running, so the insert status updates go to a closed port and fail fast.
+	l, _ := net.Listen("tcp", "127.0.0.1:0")
+	globals.BackendConfig.SetAdminFrontendAddress(l.Addr().String())
+	l.Close()
 	// Insert some basic data.
 	createNodeData()
 }
@@ -43,6 +49,9 @@ func teardown() {
 	// Mind that this isn't as optional as you think. There are some tests, especially those related to updates below that need the database to be clean. Because we automatically switch to update when something is there, it breaks the creation tests (they end up being updates.)
 }
 
+// The keys in the tests expire in a year, so that the entities they own are not rejected as made with an expired key.
+var keyExpiry = api.Timestamp(time.Now().AddDate(1, 0, 0).Unix())
+
 func ValidateTest(expected interface{}, actual interface{}, t *testing.T) {
 	if actual != expected {
 		t.Errorf("Test failed, expected: '%s', got:  '%s'", expected, actual)
@@ -95,7 +104,7 @@ func createNodeData() {
 	k.ProofOfWork = "pow"
 	k.Signature = "sig"
 	k.Type = "key type"
-	k.Expiry = 1
+	k.Expiry = keyExpiry
 
 	k2.Fingerprint = "123asdfasfdfa9023424"
 	k2.Key = "public key2"
@@ -103,7 +112,7 @@ func createNodeData() {
 	k2.ProofOfWork = "pow2"
 	k2.Signature = "sig2"
 	k2.Type = "key type2"
-	k2.Expiry = 1
+	k2.Expiry = keyExpiry
 
 	b.Fingerprint = "my board fingerprint"
 	b.Name = "alice"
@@ -120,6 +129,8 @@ func createNodeData() {
 	b2.Name = "alice"
 	b2.Creation = 1
 	b2.ProofOfWork = "pow"
+	b2.Owner = k.Fingerprint
+	b2.OwnerPublicKey = k.Key
 	bo2.KeyFingerprint = k.Fingerprint
 	bo2.Level = 1
 	b2.BoardOwners = append(b2.BoardOwners, bo2)
@@ -129,6 +140,8 @@ func createNodeData() {
 	t.Name = "alice"
 	t.Creation = 1
 	t.ProofOfWork = "pow"
+	t.Owner = k.Fingerprint
+	t.OwnerPublicKey = k.Key
 
 	p.Fingerprint = "my post fingerprint"
 	p.Board = b.Fingerprint
@@ -137,6 +150,8 @@ func createNodeData() {
 	p.Body = "a"
 	p.Creation = 1
 	p.ProofOfWork = "pow"
+	p.Owner = k.Fingerprint
+	p.OwnerPublicKey = k.Key
 
 	v.Fingerprint = "my vote fingerprint"
 	v.Board = b.Fingerprint
@@ -213,7 +228,7 @@ func createNodeData() {
 
 func TestRead_Success(t *testing.T) {
 	fp := api.Fingerprint("my board fingerprint")
-	resp, err := persistence.Read("boards", []api.Fingerprint{api.Fingerprint(fp)}, []string{}, 0, 0)
+	resp, err := persistence.Read("boards", []api.Fingerprint{api.Fingerprint(fp)}, []string{}, 0, 0, false, nil)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -226,7 +241,7 @@ func TestRead_Success(t *testing.T) {
 
 func TestRead_SingleEmbed_BoardEmbedThread_Success(t *testing.T) {
 	fp := api.Fingerprint("my board fingerprint")
-	resp, err := persistence.Read("boards", []api.Fingerprint{api.Fingerprint(fp)}, []string{"threads"}, 0, 0)
+	resp, err := persistence.Read("boards", []api.Fingerprint{api.Fingerprint(fp)}, []string{"threads"}, 0, 0, false, nil)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -262,7 +277,7 @@ func TestRead_MultiEmbed_Success(t *testing.T) {
 	kOne.ProofOfWork = "pow"
 	kOne.Signature = "sig"
 	kOne.Type = "key type"
-	kOne.Expiry = 1
+	kOne.Expiry = keyExpiry
 
 	kTwo.Fingerprint = "second key fingerprint"
 	kTwo.Key = "public key2"
@@ -270,7 +285,7 @@ func TestRead_MultiEmbed_Success(t *testing.T) {
 	kTwo.ProofOfWork = "pow"
 	kTwo.Signature = "sig"
 	kTwo.Type = "key type"
-	kTwo.Expiry = 1
+	kTwo.Expiry = keyExpiry
 
 	bOne.Fingerprint = "my board fingerprint multi entity batch test"
 	bOne.Name = "alice"
@@ -296,7 +311,7 @@ func TestRead_MultiEmbed_Success(t *testing.T) {
 	_, err := persistence.BatchInsert(batch)
 
 	fp := api.Fingerprint("my board fingerprint multi entity batch test")
-	resp, err := persistence.Read("boards", []api.Fingerprint{api.Fingerprint(fp)}, []string{"threads", "keys"}, 0, 0)
+	resp, err := persistence.Read("boards", []api.Fingerprint{api.Fingerprint(fp)}, []string{"threads", "keys"}, 0, 0, false, nil)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -323,6 +338,8 @@ func TestRead_PostEmbedVote_Success(t *testing.T) {
 	p.Body = "a"
 	p.Creation = 1
 	p.ProofOfWork = "pow"
+	p.Owner = "key fingerprint"
+	p.OwnerPublicKey = "owner pk"
 
 	v.Fingerprint = "my vote fingerprint100"
 	v.Board = "board fingerprint"
@@ -341,7 +358,7 @@ func TestRead_PostEmbedVote_Success(t *testing.T) {
 	_, err := persistence.BatchInsert(batch)
 
 	fp := api.Fingerprint("my post fingerprint99")
-	resp, err := persistence.Read("posts", []api.Fingerprint{api.Fingerprint(fp)}, []string{"votes"}, 0, 0)
+	resp, err := persistence.Read("posts", []api.Fingerprint{api.Fingerprint(fp)}, []string{"votes"}, 0, 0, false, nil)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -366,12 +383,16 @@ func TestRead_ThreadEmbedPost_Success(t *testing.T) {
 	p.Body = "a"
 	p.Creation = 1
 	p.ProofOfWork = "pow"
+	p.Owner = "key fingerprint"
+	p.OwnerPublicKey = "owner pk"
 
 	t2.Fingerprint = "my thread fingerprint99"
 	t2.Board = "board fingerprint"
 	t2.Name = "alice"
 	t2.Creation = 1
 	t2.ProofOfWork = "pow"
+	t2.Owner = "key fingerprint"
+	t2.OwnerPublicKey = "owner pk"
 
 	var batch []interface{}
 	batch = append(batch, p)
@@ -379,7 +400,7 @@ func TestRead_ThreadEmbedPost_Success(t *testing.T) {
 	_, err := persistence.BatchInsert(batch)
 
 	fp := api.Fingerprint("my thread fingerprint99")
-	resp, err := persistence.Read("threads", []api.Fingerprint{api.Fingerprint(fp)}, []string{"posts"}, 0, 0)
+	resp, err := persistence.Read("threads", []api.Fingerprint{api.Fingerprint(fp)}, []string{"posts"}, 0, 0, false, nil)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -396,7 +417,7 @@ func TestRead_TruststateEmbedKey_Success(t *testing.T) {
 	var k4 api.Key
 	k4.SetVerified(true)
 	k4.EntityVersion = 1
-	k4.Expiry = 1
+	k4.Expiry = keyExpiry
 	k4.Fingerprint = "2389749283fasdf"
 	k4.Key = "public key"
 	k4.Creation = 1
@@ -418,7 +439,7 @@ func TestRead_TruststateEmbedKey_Success(t *testing.T) {
 	_, err := persistence.BatchInsert(batch)
 
 	fp := api.Fingerprint("my truststate fingerprint99")
-	resp, err := persistence.Read("truststates", []api.Fingerprint{api.Fingerprint(fp)}, []string{"keys"}, 0, 0)
+	resp, err := persistence.Read("truststates", []api.Fingerprint{api.Fingerprint(fp)}, []string{"keys"}, 0, 0, false, nil)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -442,7 +463,7 @@ func TestReadBasedOnArrivalTimeRange(t *testing.T) {
 	time.Sleep(1000 * time.Millisecond) // Wait a bit so we have a decent range.
 	now := api.Timestamp(time.Now().Unix())
 	// fmt.Printf("%#v\n", now)
-	resp, err := persistence.Read("boards", []api.Fingerprint{}, []string{}, 0, now)
+	resp, err := persistence.Read("boards", []api.Fingerprint{}, []string{}, 0, now, false, nil)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -456,7 +477,7 @@ func TestReadBasedOnArrivalTimeRange(t *testing.T) {
 func TestReadBoard_Success(t *testing.T) {
 	fp := api.Fingerprint("my board fingerprint")
 	resp, err := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -471,7 +492,7 @@ func TestReadMultipleBoards_Success(t *testing.T) {
 	fp := api.Fingerprint("my board fingerprint")
 	fp2 := api.Fingerprint("my board fingerprint_second")
 	resp, err := persistence.ReadBoards(
-		[]api.Fingerprint{fp, fp2}, 0, 0)
+		[]api.Fingerprint{fp, fp2}, 0, 0, "", 0, 0)
 	// fmt.Printf("%#v\n", resp)
 	// fmt.Printf("%#v\n", len(resp))
 	if err != nil {
@@ -487,7 +508,7 @@ func TestReadMultipleBoards_Success(t *testing.T) {
 
 func TestReadBoard_Empty(t *testing.T) {
 	resp, err := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint("fake board fingerprint")}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint("fake board fingerprint")}, 0, 0, "", 0, 0)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp) > 0 {
@@ -536,7 +557,7 @@ func TestReadDBBoardOwner_Empty(t *testing.T) {
 
 func TestReadThread_Success(t *testing.T) {
 	fp := api.Fingerprint("my thread fingerprint")
-	resp, err := persistence.ReadThreads([]api.Fingerprint{fp}, 0, 0)
+	resp, err := persistence.ReadThreads([]api.Fingerprint{fp}, 0, 0, "", "", 0, 0)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -548,7 +569,7 @@ func TestReadThread_Success(t *testing.T) {
 }
 
 func TestReadThread_Empty(t *testing.T) {
-	resp, err := persistence.ReadThreads([]api.Fingerprint{"fake thread fingerprint"}, 0, 0)
+	resp, err := persistence.ReadThreads([]api.Fingerprint{"fake thread fingerprint"}, 0, 0, "", "", 0, 0)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp) > 0 {
@@ -558,7 +579,7 @@ func TestReadThread_Empty(t *testing.T) {
 
 func TestReadPost_Success(t *testing.T) {
 	fp := api.Fingerprint("my post fingerprint")
-	resp, err := persistence.ReadPosts([]api.Fingerprint{fp}, 0, 0)
+	resp, err := persistence.ReadPosts([]api.Fingerprint{fp}, 0, 0, "", "", "", "", 0, 0)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -570,7 +591,7 @@ func TestReadPost_Success(t *testing.T) {
 }
 
 func TestReadPost_Empty(t *testing.T) {
-	resp, err := persistence.ReadPosts([]api.Fingerprint{"fake post fingerprint"}, 0, 0)
+	resp, err := persistence.ReadPosts([]api.Fingerprint{"fake post fingerprint"}, 0, 0, "", "", "", "", 0, 0)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp) > 0 {
@@ -580,7 +601,7 @@ func TestReadPost_Empty(t *testing.T) {
 
 func TestReadVote_Success(t *testing.T) {
 	fp := api.Fingerprint("my vote fingerprint")
-	resp, err := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0)
+	resp, err := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0, -1, -1, "", "", "", false, "", 0, 0)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -592,7 +613,7 @@ func TestReadVote_Success(t *testing.T) {
 }
 
 func TestReadVote_Empty(t *testing.T) {
-	resp, err := persistence.ReadVotes([]api.Fingerprint{"fake vote fingerprint"}, 0, 0)
+	resp, err := persistence.ReadVotes([]api.Fingerprint{"fake vote fingerprint"}, 0, 0, -1, -1, "", "", "", false, "", 0, 0)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp) > 0 {
@@ -605,7 +626,7 @@ func TestReadAddress_Success(t *testing.T) {
 	subloc := api.Location("example")
 	port := uint16(8090)
 	resp, err := persistence.ReadAddresses(
-		loc, subloc, port, 0, 0, 0, 0, 0, "timerange_all")
+		loc, subloc, port, 0, 0, 0, 0, 0, "basic")
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -640,30 +661,32 @@ func TestFirstPartyInsertReadAddress_Success(t *testing.T) {
 	a2.Client.ClientName = "client name"
 	a2.EntityVersion = 1
 	addressSet := []api.Address{a2}
-	persistence.InsertOrUpdateAddresses(&addressSet)
+	errs := persistence.InsertOrUpdateAddresses(&addressSet)
+	if len(errs) > 0 {
+		t.Fatalf("Test failed, the address could not be inserted. Errors: '%s'", errs)
+	}
 
 	loc := api.Location("www.example33.com")
 	subloc := api.Location("example33")
 	port := uint16(1111)
 
 	resp, err := persistence.ReadAddresses(
-		loc, subloc, port, 0, 0, 0, 0, 0, "timerange_all")
-	if !(resp[0].Protocol.Subprotocols[0].Name == "c0" || resp[0].Protocol.Subprotocols[1].Name == "c0") {
-		t.Errorf(fmt.Sprintf("Test failed, the subprotocol information has not been committed. Response: %#v", resp))
-	}
+		loc, subloc, port, 0, 0, 0, 0, 0, "basic")
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp) == 0 {
 		t.Errorf("Test failed, the response is empty.")
 	} else if resp[0].Location != loc {
 		t.Errorf("The response received isn't the expected one. Location: '%s'", resp[0].Location)
+	} else if len(resp[0].Protocol.Subprotocols) != 2 || !(resp[0].Protocol.Subprotocols[0].Name == "c0" || resp[0].Protocol.Subprotocols[1].Name == "c0") {
+		t.Errorf("Test failed, the subprotocol information has not been committed. Response: %#v", resp)
 	}
 
 }
 
 func TestReadAddress_Empty(t *testing.T) {
 	resp, err := persistence.ReadAddresses(
-		"fake loc", "fake subloc", 9090, 0, 0, 0, 0, 0, "timerange_all")
+		"fake loc", "fake subloc", 9090, 0, 0, 0, 0, 0, "basic")
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp) > 0 {
@@ -673,7 +696,7 @@ func TestReadAddress_Empty(t *testing.T) {
 
 func TestReadKey_Success(t *testing.T) {
 	fp := api.Fingerprint("2389749283fasdf")
-	resp, err := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0)
+	resp, err := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0, "", 0, 0)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -685,7 +708,7 @@ func TestReadKey_Success(t *testing.T) {
 }
 
 func TestReadKey_Empty(t *testing.T) {
-	resp, err := persistence.ReadKeys([]api.Fingerprint{"fake key fingerprint"}, 0, 0)
+	resp, err := persistence.ReadKeys([]api.Fingerprint{"fake key fingerprint"}, 0, 0, "", 0, 0)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp) > 0 {
@@ -695,7 +718,7 @@ func TestReadKey_Empty(t *testing.T) {
 
 func TestReadTruststate_Success(t *testing.T) {
 	fp := api.Fingerprint("my truststate fingerprint")
-	resp, err := persistence.ReadTruststates([]api.Fingerprint{fp}, 0, 0)
+	resp, err := persistence.ReadTruststates([]api.Fingerprint{fp}, 0, 0, -1, -1, "", "", "", 0, 0)
 	// fmt.Printf("%#v\n", resp)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -707,7 +730,7 @@ func TestReadTruststate_Success(t *testing.T) {
 }
 
 func TestReadTruststate_Empty(t *testing.T) {
-	resp, err := persistence.ReadTruststates([]api.Fingerprint{"fake truststate fingerprint"}, 0, 0)
+	resp, err := persistence.ReadTruststates([]api.Fingerprint{"fake truststate fingerprint"}, 0, 0, -1, -1, "", "", "", 0, 0)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if len(resp) > 0 {
@@ -733,12 +756,32 @@ func TestDbToApi_Success(t *testing.T) {
 }
 
 func TestDbToApi_ItemLengthLongerThanAllowed(t *testing.T) {
-	var ts persistence.DbTruststate
-	ts.Fingerprint = "my awesome truststate fingerprint"
-	ts.Target = "my target key"
-	ts.Owner = "my owner's key fingerprint"
-	ts.Domain = "my first domain fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint fingerprint, my second domain fingerprint, my third domain fingerprint" // heads up we converted this from domains plural this test might get weirded out
-	_, err := persistence.DBtoAPI(ts)
+	// Truststate domains are no longer a list, so this checks the one list the database still keeps as a comma separated string, the supported entities of a subprotocol.
+	var s api.Subprotocol
+	s.Name = "dbtoapi length test"
+	s.VersionMajor = 1
+	s.SupportedEntities = []string{"board"}
+	var a api.Address
+	a.Location = "www.example-dbtoapi.com"
+	a.Sublocation = "dbtoapi"
+	a.Port = 1112
+	a.LocationType = 1
+	a.LastSuccessfulPing = 1
+	a.Protocol.VersionMajor = 1
+	a.Protocol.Subprotocols = []api.Subprotocol{s}
+	a.Client.VersionMajor = 1
+	a.Client.ClientName = "client name"
+	a.EntityVersion = 1
+	errs := persistence.InsertOrUpdateAddresses(&[]api.Address{a})
+	if len(errs) > 0 {
+		t.Fatalf("Test failed, the address could not be inserted. Errors: '%s'", errs)
+	}
+	globals.DbInstance.Exec("UPDATE Subprotocols SET SupportedEntities = ? WHERE Name = ?", strings.Repeat("board", 20), s.Name)
+	var dba persistence.DbAddress
+	dba.Location = a.Location
+	dba.Sublocation = a.Sublocation
+	dba.Port = a.Port
+	_, err := persistence.DBtoAPI(dba)
 	errMessage := "This string is too long for this field."
 	if err == nil {
 		t.Errorf("Expected an error to be raised from this test.")
@@ -793,7 +836,7 @@ func TestApiToDb_Success(t *testing.T) {
 	s.VersionMinor = 0
 	s.SupportedEntities = []string{"board", "thread", "post", "vote", "key", "truststate"}
 	a.Protocol.Subprotocols = []api.Subprotocol{s}
-	addressPack, err := persistence.APItoDB(a)
+	addressPack, err := persistence.APItoDB(a, time.Now())
 	obj := addressPack.(persistence.AddressPack)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -817,7 +860,7 @@ func TestApiToDb_RepeatedItems(t *testing.T) {
 	s1.VersionMinor = 0
 	s1.SupportedEntities = []string{"board", "board"}
 	a.Protocol.Subprotocols = []api.Subprotocol{s1}
-	_, err := persistence.APItoDB(a)
+	_, err := persistence.APItoDB(a, time.Now())
 	errMessage := "This list includes items that are duplicates."
 	if err == nil {
 		t.Errorf("Expected an error to be raised from this test.")
@@ -836,9 +879,11 @@ func TestApiToDb_TooManyItems(t *testing.T) {
 	s.Name = "c0"
 	s.VersionMajor = 1
 	s.VersionMinor = 0
-	s.SupportedEntities = []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "30", "111", "211", "311", "411", "511", "611", "711", "811", "911", "1011", "1111", "1211", "1311", "1411", "1511", "1611", "1711", "1811", "1911", "2011", "2111", "2211", "2311", "2411", "2511", "2611", "2711", "2811", "2911", "3011", "11111", "21111", "31111", "41111", "51111", "61111", "71111", "81111", "91111", "101111", "111111", "121111", "131111", "141111", "151111", "161111", "171111", "181111", "191111", "201111", "211111", "221111", "231111", "241111", "251111", "261111", "271111", "281111", "291111", "301111", "1111111", "2111111", "3111111", "4111111", "5111111", "6111111", "7111111", "8111111", "9111111", "10111111", "11111111", "12111111", "13111111", "14111111", "15111111", "16111111", "17111111", "18111111", "19111111", "20111111", "21111111", "22111111", "23111111", "24111111", "25111111", "26111111", "27111111", "28111111", "29111111", "30111111"}
+	for i := 0; i <= api.MAX_ADDRESS_PROTOCOL_SUBPROTOCOL_SUPPORTEDENTITIES_V1; i++ {
+		s.SupportedEntities = append(s.SupportedEntities, strconv.Itoa(i))
+	}
 	a.Protocol.Subprotocols = []api.Subprotocol{s}
-	_, err := persistence.APItoDB(a)
+	_, err := persistence.APItoDB(a, time.Now())
 	errMessage := "The string slice provided has too many items."
 	if err == nil {
 		t.Errorf("Expected an error to be raised from this test.")
@@ -859,7 +904,7 @@ func TestApiToDb_ItemLengthLongerThanAllowed(t *testing.T) {
 	s.VersionMinor = 0
 	s.SupportedEntities = []string{"boaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaard"}
 	a.Protocol.Subprotocols = []api.Subprotocol{s}
-	_, err := persistence.APItoDB(a)
+	_, err := persistence.APItoDB(a, time.Now())
 	errMessage := "This string is too long for this field."
 	if err == nil {
 		t.Errorf("Expected an error to be raised from this test.")
@@ -889,7 +934,7 @@ func TestSingleInsert_Success(t *testing.T) {
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0, -1, -1, "", "", "", false, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if len(resp) == 0 {
@@ -931,7 +976,7 @@ func TestSingleInsert_Duplicate(t *testing.T) {
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0, -1, -1, "", "", "", false, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if len(resp) > 1 {
@@ -977,7 +1022,7 @@ func TestBatchInsert_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
 	// Check for first
-	resp, err2 := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0, -1, -1, "", "", "", false, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if len(resp) == 0 {
@@ -986,7 +1031,7 @@ func TestBatchInsert_Success(t *testing.T) {
 		t.Errorf("The response received isn't the expected one. Fingerprint: '%s'", resp[0].Fingerprint)
 	}
 	// Check for second
-	resp2, err3 := persistence.ReadVotes([]api.Fingerprint{fp2}, 0, 0)
+	resp2, err3 := persistence.ReadVotes([]api.Fingerprint{fp2}, 0, 0, -1, -1, "", "", "", false, "", 0, 0)
 	if err3 != nil {
 		t.Errorf("Test failed, err: '%s'", err3)
 	} else if len(resp2) == 0 {
@@ -1037,7 +1082,7 @@ func TestInsert_MultipleTypes_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
 	// Check for first
-	resp, err2 := persistence.ReadAddresses(addressLoc, addressSubloc, addressPort, 0, 0, 0, 0, 0, "timerange_all")
+	resp, err2 := persistence.ReadAddresses(addressLoc, addressSubloc, addressPort, 0, 0, 0, 0, 0, "basic")
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if len(resp) == 0 {
@@ -1046,7 +1091,7 @@ func TestInsert_MultipleTypes_Success(t *testing.T) {
 		t.Errorf("The response received isn't the expected one. Address: '%#v'", resp[0])
 	}
 	// Check for second
-	resp2, err3 := persistence.ReadTruststates([]api.Fingerprint{tfp}, 0, 0)
+	resp2, err3 := persistence.ReadTruststates([]api.Fingerprint{tfp}, 0, 0, -1, -1, "", "", "", 0, 0)
 	if err3 != nil {
 		t.Errorf("Test failed, err: '%s'", err3)
 	} else if len(resp2) == 0 {
@@ -1080,7 +1125,7 @@ func TestInsert_ItemsWithUpdates_Board_SimpleField_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
 	resp, err2 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
@@ -1098,7 +1143,7 @@ func TestInsert_ItemsWithUpdates_Board_SimpleField_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err9)
 	}
 	resp3, err10 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	if err10 != nil {
 		t.Errorf("Test failed, err: '%s'", err10)
 	}
@@ -1115,7 +1160,7 @@ func TestInsert_ItemsWithUpdates_Board_SimpleField_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err11)
 	}
 	resp4, err12 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	if err12 != nil {
 		t.Errorf("Test failed, err: '%s'", err12)
 	}
@@ -1132,7 +1177,7 @@ func TestInsert_ItemsWithUpdates_Board_SimpleField_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err13)
 	}
 	resp5, err14 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	if err14 != nil {
 		t.Errorf("Test failed, err: '%s'", err14)
 	}
@@ -1152,6 +1197,8 @@ func TestInsert_ItemsWithUpdates_Board_SubObject_Success(t *testing.T) {
 	b.Name = "alice"
 	// b.Creation = 1
 	b.ProofOfWork = "pow"
+	b.Owner = "board owner"
+	b.OwnerPublicKey = "board owner pk"
 	var bo1 api.BoardOwner
 	bo1.KeyFingerprint = "key fingerprint1"
 	bo1.Level = 1
@@ -1170,7 +1217,7 @@ func TestInsert_ItemsWithUpdates_Board_SubObject_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
 	resp, err2 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
@@ -1188,7 +1235,7 @@ func TestInsert_ItemsWithUpdates_Board_SubObject_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err3)
 	}
 	resp2, err4 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	if err4 != nil {
 		t.Errorf("Test failed, err: '%s'", err4)
 	}
@@ -1203,7 +1250,7 @@ func TestInsert_ItemsWithUpdates_Board_SubObject_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err5)
 	}
 	resp3, err6 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	if err6 != nil {
 		t.Errorf("Test failed, err: '%s'", err6)
 	}
@@ -1218,7 +1265,7 @@ func TestInsert_ItemsWithUpdates_Board_SubObject_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err7)
 	}
 	resp4, err8 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	if err8 != nil {
 		t.Errorf("Test failed, err: '%s'", err8)
 	}
@@ -1233,7 +1280,7 @@ func TestInsert_ItemsWithUpdates_Key_SimpleField_Success(t *testing.T) {
 	// Insert a key.
 	var k api.Key
 	k.SetVerified(true)
-	k.Expiry = 1
+	k.Expiry = keyExpiry
 	k.EntityVersion = 1
 	fp := api.Fingerprint("my cool key fingerprint5")
 	k.Fingerprint = fp
@@ -1246,7 +1293,7 @@ func TestInsert_ItemsWithUpdates_Key_SimpleField_Success(t *testing.T) {
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
@@ -1263,7 +1310,7 @@ func TestInsert_ItemsWithUpdates_Key_SimpleField_Success(t *testing.T) {
 	if err9 != nil {
 		t.Errorf("Test failed, err: '%s'", err9)
 	}
-	resp3, err10 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0)
+	resp3, err10 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0, "", 0, 0)
 	if err10 != nil {
 		t.Errorf("Test failed, err: '%s'", err10)
 	}
@@ -1279,7 +1326,7 @@ func TestInsert_ItemsWithUpdates_Key_SimpleField_Success(t *testing.T) {
 	if err11 != nil {
 		t.Errorf("Test failed, err: '%s'", err11)
 	}
-	resp4, err12 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0)
+	resp4, err12 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0, "", 0, 0)
 	if err12 != nil {
 		t.Errorf("Test failed, err: '%s'", err12)
 	}
@@ -1295,7 +1342,7 @@ func TestInsert_ItemsWithUpdates_Key_SimpleField_Success(t *testing.T) {
 	if err13 != nil {
 		t.Errorf("Test failed, err: '%s'", err13)
 	}
-	resp5, err14 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0)
+	resp5, err14 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0, "", 0, 0)
 	if err14 != nil {
 		t.Errorf("Test failed, err: '%s'", err14)
 	}
@@ -1309,7 +1356,7 @@ func TestInsert_ItemsWithUpdates_Key_SubObject_Success(t *testing.T) {
 	// Insert a board.
 	var k api.Key
 	k.SetVerified(true)
-	k.Expiry = 1
+	k.Expiry = keyExpiry
 	k.EntityVersion = 1
 	fp := api.Fingerprint("my cool key fingerprint subobject test")
 	k.Fingerprint = fp
@@ -1323,7 +1370,7 @@ func TestInsert_ItemsWithUpdates_Key_SubObject_Success(t *testing.T) {
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadKeys([]api.Fingerprint{fp}, 0, 0, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
@@ -1400,6 +1447,8 @@ func TestInsert_AddRemoveBoardOwner(t *testing.T) {
 	b.Name = "alice"
 	b.Creation = 1
 	b.ProofOfWork = "pow"
+	b.Owner = "board owner"
+	b.OwnerPublicKey = "board owner pk"
 	var bo1 api.BoardOwner
 	var bo2 api.BoardOwner
 	var bo3 api.BoardOwner
@@ -1424,7 +1473,7 @@ func TestInsert_AddRemoveBoardOwner(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
 	resp, err3 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	// fmt.Printf("%#v\n", resp[0].BoardOwners)
 	if err3 != nil {
 		t.Errorf("Test failed, err: '%s'", err3)
@@ -1443,6 +1492,8 @@ func TestInsert_AddRemoveAndEditBoardOwner(t *testing.T) {
 	b.Name = "alice"
 	b.Creation = 1
 	b.ProofOfWork = "pow"
+	b.Owner = "board owner"
+	b.OwnerPublicKey = "board owner pk"
 	var bo1 api.BoardOwner
 	var bo2 api.BoardOwner
 	var bo3 api.BoardOwner
@@ -1469,7 +1520,7 @@ func TestInsert_AddRemoveAndEditBoardOwner(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
 	resp, err3 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	// fmt.Printf("%#v\n", resp[0].BoardOwners)
 	if err3 != nil {
 		t.Errorf("Test failed, err: '%s'", err3)
@@ -1497,6 +1548,8 @@ func TestInsert_DuplicateBoardOwner(t *testing.T) {
 	b.Name = "alice"
 	b.Creation = 1
 	b.ProofOfWork = "pow"
+	b.Owner = "board owner"
+	b.OwnerPublicKey = "board owner pk"
 	var bo1 api.BoardOwner
 	var bo2 api.BoardOwner
 	var bo3 api.BoardOwner
@@ -1512,7 +1565,7 @@ func TestInsert_DuplicateBoardOwner(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
 	resp, err3 := persistence.ReadBoards(
-		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0)
+		[]api.Fingerprint{api.Fingerprint(fp)}, 0, 0, "", 0, 0)
 	if err3 != nil {
 		t.Errorf("Test failed, err: '%s'", err3)
 	} else if len(resp[0].BoardOwners) > 2 {
@@ -1532,12 +1585,14 @@ func TestSingleInsert_CircularPost(t *testing.T) {
 	p.Body = "a"
 	p.Creation = 1
 	p.ProofOfWork = "pow"
+	p.Owner = "key fingerprint"
+	p.OwnerPublicKey = "owner pk"
 
 	_, err := persistence.BatchInsert([]interface{}{p})
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadPosts([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadPosts([]api.Fingerprint{fp}, 0, 0, "", "", "", "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if len(resp) > 0 {
@@ -1550,7 +1605,7 @@ func TestBoardInsertNonrecursiveLastReferenced_Success(t *testing.T) {
 	k := api.Key{}
 	k.SetVerified(true)
 	k.EntityVersion = 1
-	k.Expiry = 1
+	k.Expiry = keyExpiry
 	k.Fingerprint = kfp
 	k.Key = "RECURSION TEST my awesome board key"
 	k.Creation = 1
@@ -1576,7 +1631,7 @@ func TestBoardInsertNonrecursiveLastReferenced_Success(t *testing.T) {
 	}
 	// read back and save last referenced
 	// fmt.Printf("Time at first DB Key Read: %d\n", time.Now().Unix())
-	resp, err2 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0)
+	resp, err2 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
@@ -1590,7 +1645,7 @@ func TestBoardInsertNonrecursiveLastReferenced_Success(t *testing.T) {
 	}
 	// get the key again and save its new last referenced
 	// fmt.Printf("Time at second DB Key Read: %d\n", time.Now().Unix())
-	resp2, err4 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0)
+	resp2, err4 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0, "", 0, 0)
 	if err4 != nil {
 		t.Errorf("Test failed, err: '%s'", err4)
 	}
@@ -1607,7 +1662,7 @@ func TestBoardInsertLastReferencedGating_MainObjectDidNotInsert(t *testing.T) {
 	k := api.Key{}
 	k.SetVerified(true)
 	k.EntityVersion = 1
-	k.Expiry = 1
+	k.Expiry = keyExpiry
 	k.Fingerprint = kfp
 	k.Key = "RECURSION TEST my awesome board key"
 	k.Creation = 1
@@ -1633,7 +1688,7 @@ func TestBoardInsertLastReferencedGating_MainObjectDidNotInsert(t *testing.T) {
 	}
 	// read back and save last referenced
 	// fmt.Printf("Time at first DB Key Read: %d\n", time.Now().Unix())
-	resp, err2 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0)
+	resp, err2 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
@@ -1647,7 +1702,7 @@ func TestBoardInsertLastReferencedGating_MainObjectDidNotInsert(t *testing.T) {
 	}
 	// get the key again and save its new last referenced
 	// fmt.Printf("Time at second DB Key Read: %d\n", time.Now().Unix())
-	resp2, err4 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0)
+	resp2, err4 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0, "", 0, 0)
 	if err4 != nil {
 		t.Errorf("Test failed, err: '%s'", err4)
 	}
@@ -1664,7 +1719,7 @@ func TestBoardInsertLastReferencedGating_MainObjectDidNotInsert(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err5)
 	}
 
-	resp3, err6 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0)
+	resp3, err6 := persistence.ReadDbKeys([]api.Fingerprint{kfp}, 0, 0, "", 0, 0)
 	if err6 != nil {
 		t.Errorf("Test failed, err: '%s'", err6)
 	}
@@ -1679,7 +1734,7 @@ func TestPostInsertRecursiveLastReferenced_Success(t *testing.T) {
 	k := api.Key{}
 	k.SetVerified(true)
 	k.EntityVersion = 1
-	k.Expiry = 1
+	k.Expiry = keyExpiry
 	k.Fingerprint = kfp
 	k.Key = "k1 key"
 	k.Creation = 1
@@ -1691,7 +1746,7 @@ func TestPostInsertRecursiveLastReferenced_Success(t *testing.T) {
 	k2 := api.Key{}
 	k2.SetVerified(true)
 	k2.EntityVersion = 1
-	k2.Expiry = 1
+	k2.Expiry = keyExpiry
 	k2.Fingerprint = kfp
 	k2.Key = "k2 key"
 	k2.Creation = 1
@@ -1769,7 +1824,7 @@ func TestPostInsertRecursiveLastReferenced_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
 	// read back and save last referenced
-	resp, err2 := persistence.ReadDbPosts([]api.Fingerprint{p.Fingerprint}, 0, 0)
+	resp, err2 := persistence.ReadDbPosts([]api.Fingerprint{p.Fingerprint}, 0, 0, "", "", "", "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
@@ -1781,7 +1836,7 @@ func TestPostInsertRecursiveLastReferenced_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err3)
 	}
 	// get the key again and save its new last referenced
-	resp2, err4 := persistence.ReadDbPosts([]api.Fingerprint{p.Fingerprint}, 0, 0)
+	resp2, err4 := persistence.ReadDbPosts([]api.Fingerprint{p.Fingerprint}, 0, 0, "", "", "", "", 0, 0)
 	if err4 != nil {
 		t.Errorf("Test failed, err: '%s'", err4)
 	}
@@ -1810,7 +1865,7 @@ func TestSingleInsert_Board_LangValid_Success(t *testing.T) {
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadBoards([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadBoards([]api.Fingerprint{fp}, 0, 0, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if len(resp) == 0 {
@@ -1823,7 +1878,6 @@ func TestSingleInsert_Board_LangValid_Success(t *testing.T) {
 func TestSingleInsert_Board_LangTooLong(t *testing.T) {
 	fp := api.Fingerprint("my awesome board fingerprint with too long a language")
 	var b api.Board
-	b.SetVerified(true)
 	b.EntityVersion = 1
 	b.Fingerprint = fp
 	b.Name = "yo"
@@ -1834,11 +1888,14 @@ func TestSingleInsert_Board_LangTooLong(t *testing.T) {
 	b.Signature = "sig"
 	b.ProofOfWork = "pow"
 	b.Language = "this language is way too long, this field accepts lang codes like ENG, not lang names"
+	// SQLite does not hold the language column to its length, the bounds check in the verification does. A board that fails it is never marked verified.
+	valid, _ := b.CheckBounds()
+	b.SetVerified(valid)
 	_, err := persistence.BatchInsert([]interface{}{b})
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadBoards([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadBoards([]api.Fingerprint{fp}, 0, 0, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if len(resp) > 0 {
@@ -1869,7 +1926,7 @@ func TestSingleInsert_Vote_UpdateEarlierThanCreationInsertedAtTheSameTime(t *tes
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadVotes([]api.Fingerprint{fp}, 0, 0, -1, -1, "", "", "", false, "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if len(resp) > 0 {
@@ -1898,7 +1955,7 @@ func TestSingleInsert_Thread_Update_InsertedAtTheSameTime(t *testing.T) {
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadThreads([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadThreads([]api.Fingerprint{fp}, 0, 0, "", "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if resp[0].GetUpdateSignature() == "" {
@@ -1929,7 +1986,7 @@ func TestSingleInsert_Post_UpdateEarlierThanCreationInsertedAtTheSameTime(t *tes
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
-	resp, err2 := persistence.ReadPosts([]api.Fingerprint{fp}, 0, 0)
+	resp, err2 := persistence.ReadPosts([]api.Fingerprint{fp}, 0, 0, "", "", "", "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	} else if len(resp) > 0 {
@@ -1983,7 +2040,7 @@ func TestPostInsertRecursiveDeepInsert_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err)
 	}
 	// read back and save last referenced
-	resp, err2 := persistence.ReadDbPosts([]api.Fingerprint{p1.Fingerprint}, 0, 0)
+	resp, err2 := persistence.ReadDbPosts([]api.Fingerprint{p1.Fingerprint}, 0, 0, "", "", "", "", 0, 0)
 	if err2 != nil {
 		t.Errorf("Test failed, err: '%s'", err2)
 	}
@@ -1996,7 +2053,7 @@ func TestPostInsertRecursiveDeepInsert_Success(t *testing.T) {
 		t.Errorf("Test failed, err: '%s'", err3)
 	}
 	// get the key again and save its new last referenced
-	resp2, err4 := persistence.ReadDbPosts([]api.Fingerprint{p1.Fingerprint}, 0, 0)
+	resp2, err4 := persistence.ReadDbPosts([]api.Fingerprint{p1.Fingerprint}, 0, 0, "", "", "", "", 0, 0)
 	if err4 != nil {
 		t.Errorf("Test failed, err: '%s'", err4)
 	}
@@ -2005,3 +2062,121 @@ func TestPostInsertRecursiveDeepInsert_Success(t *testing.T) {
 		t.Errorf("Test failed. The last referenced for the post did not update when a post underlying was inserted. LastReferencedOld: %d, LastReferencedNew: %d", lastReferencedOld, lastReferencedNew)
 	}
 }
+
+func TestInsert_PostEdits_KeepRevisions(t *testing.T) {
+	fp := api.Fingerprint("post fingerprint with revisions")
+	original := generatePost(fp, "threadpk")
+	original.Body = "original body"
+	original.LastUpdate = 0
+	original.UpdateProofOfWork = ""
+	original.UpdateSignature = ""
+	_, err := persistence.BatchInsert([]interface{}{original})
+	if err != nil {
+		t.Errorf("Test failed, err: '%s'", err)
+	}
+	revs, err2 := persistence.ReadRevisions("post", fp)
+	if err2 != nil {
+		t.Errorf("Test failed, err: '%s'", err2)
+	} else if len(revs) != 1 || revs[0].Body != "original body" {
+		t.Errorf("A post that was never edited should have its current version as its only revision. Got: %#v", revs)
+	}
+	edit := generatePost(fp, "threadpk")
+	edit.Body = "edited body"
+	edit.LastUpdate = 5
+	edit.UpdateSignature = "updatesig5"
+	_, err3 := persistence.BatchInsert([]interface{}{edit})
+	if err3 != nil {
+		t.Errorf("Test failed, err: '%s'", err3)
+	}
+	// This one is older than the edit we have, it doesn't replace it, but it's a version of the post all the same.
+	lateEdit := generatePost(fp, "threadpk")
+	lateEdit.Body = "late edit body"
+	lateEdit.LastUpdate = 3
+	lateEdit.UpdateSignature = "updatesig3"
+	_, err4 := persistence.BatchInsert([]interface{}{lateEdit})
+	if err4 != nil {
+		t.Errorf("Test failed, err: '%s'", err4)
+	}
+	revs2, err5 := persistence.ReadRevisions("post", fp)
+	if err5 != nil {
+		t.Errorf("Test failed, err: '%s'", err5)
+	}
+	expected := []string{"original body", "late edit body", "edited body"}
+	if len(revs2) != len(expected) {
+		t.Fatalf("The post should have %d revisions. Got: %#v", len(expected), revs2)
+	}
+	for key, _ := range expected {
+		if revs2[key].Body != expected[key] {
+			t.Errorf("Revision %d is wrong. Expected: '%s', got: '%s'", key, expected[key], revs2[key].Body)
+		}
+	}
+	if revs2[2].UpdateSignature != "updatesig5" {
+		t.Errorf("The revision should keep the update signature it was published with. Got: '%s'", revs2[2].UpdateSignature)
+	}
+	current, err6 := persistence.ReadPosts([]api.Fingerprint{fp}, 0, 0, "", "", "", "", 0, 0)
+	if err6 != nil || len(current) != 1 || current[0].Body != "edited body" {
+		t.Errorf("The most recent edit should be the current version of the post. Got: %#v, err: %v", current, err6)
+	}
+}
+
+func TestInsert_PostEdits_RevisionKeepsFirstArrival(t *testing.T) {
+	fp := api.Fingerprint("post fingerprint with a revision received twice")
+	edit := generatePost(fp, "threadpk")
+	edit.Body = "edited body"
+	edit.LastUpdate = 5
+	edit.UpdateSignature = "updatesig5"
+	_, err := persistence.BatchInsert([]interface{}{edit})
+	if err != nil {
+		t.Errorf("Test failed, err: '%s'", err)
+	}
+	revs, err2 := persistence.ReadRevisions("post", fp)
+	if err2 != nil || len(revs) != 1 {
+		t.Fatalf("The post should have its edit as its only revision. Got: %#v, err: %v", revs, err2)
+	}
+	firstArrival := revs[0].LocalArrival
+	time.Sleep(time.Duration(1) * time.Second)
+	// The same edit, received again from another remote.
+	_, err3 := persistence.BatchInsert([]interface{}{edit})
+	if err3 != nil {
+		t.Errorf("Test failed, err: '%s'", err3)
+	}
+	revs2, err4 := persistence.ReadRevisions("post", fp)
+	if err4 != nil || len(revs2) != 1 {
+		t.Fatalf("Receiving the same edit again should not add a revision. Got: %#v, err: %v", revs2, err4)
+	}
+	if revs2[0].LocalArrival != firstArrival {
+		t.Errorf("Receiving the same edit again moved its local arrival. First: %d, Now: %d", firstArrival, revs2[0].LocalArrival)
+	}
+}
+
+// The cap is 20 revisions per entity.
+func TestInsert_PostEdits_RevisionsCapped(t *testing.T) {
+	fp := api.Fingerprint("post fingerprint with too many revisions")
+	for i := 1; i <= 25; i++ {
+		edit := generatePost(fp, "threadpk")
+		edit.Body = fmt.Sprintf("edit %d", i)
+		edit.LastUpdate = api.Timestamp(i)
+		edit.UpdateSignature = api.Signature(fmt.Sprintf("updatesig%d", i))
+		_, err := persistence.BatchInsert([]interface{}{edit})
+		if err != nil {
+			t.Errorf("Test failed, err: '%s'", err)
+		}
+	}
+	revs, err2 := persistence.ReadRevisions("post", fp)
+	if err2 != nil {
+		t.Fatalf("Test failed, err: '%s'", err2)
+	}
+	if len(revs) != 20 {
+		t.Fatalf("The post should have kept its newest 20 revisions. Got: %d", len(revs))
+	}
+	if revs[0].Body != "edit 6" || revs[19].Body != "edit 25" {
+		t.Errorf("The revisions kept are not the newest ones. Oldest: '%s', Newest: '%s'", revs[0].Body, revs[19].Body)
+	}
+}
+
+func TestReadRevisions_WrongEntityType(t *testing.T) {
+	_, err := persistence.ReadRevisions("vote", "some fingerprint")
+	if err == nil {
+		t.Errorf("Votes don't have revisions, this should have failed.")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"aether-core/services/globals"
 	"aether-core/services/logging"
 	// "aether-core/services/toolbox"
+	"aether-core/services/toolbox"
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
@@ -42,6 +43,78 @@ func ReadNode(fingerprint api.Fingerprint) (DbNode, error) {
 	return n, nil
 }
 
+// IsPinnableEntityType returns whether entities of this type can be pinned. Only the entities that other content hangs off of can be pinned: pinning a board keeps its threads, posts and votes, pinning a thread keeps its posts and votes, and pinning a key keeps everything the key has created.
+func IsPinnableEntityType(entityType string) bool {
+	return entityType == "board" || entityType == "thread" || entityType == "key"
+}
+
+// ReadPins returns all pinned entities, keyed by entity type.
+func ReadPins() (map[string][]api.Fingerprint, error) {
+	pins := make(map[string][]api.Fingerprint)
+	rows, err := globals.DbInstance.Queryx("SELECT * FROM Pins;")
+	if err != nil {
+		return pins, err
+	}
+	defer rows.Close() // In case of premature exit.
+	for rows.Next() {
+		var p DbPin
+		err := rows.StructScan(&p)
+		if err != nil {
+			return pins, err
+		}
+		pins[p.EntityType] = append(pins[p.EntityType], p.Fingerprint)
+	}
+	rows.Close()
+	return pins, nil
+}
+
+// ReadRevisions returns the versions of a thread or post that this node has seen, oldest first. The last one is the current version. If the entity was never edited, that's the only one.
+func ReadRevisions(entityType string, fp api.Fingerprint) ([]DbRevision, error) {
+	revs := []DbRevision{}
+	tableName := ""
+	switch entityType {
+	case "thread":
+		tableName = "Threads"
+	case "post":
+		tableName = "Posts"
+	default:
+		return revs, errors.New(fmt.Sprintf("This entity type does not have revisions. Entity type: %s", entityType))
+	}
+	rows, err := globals.DbInstance.Queryx(globals.DbInstance.Rebind("SELECT * FROM Revisions WHERE EntityType = ? AND Fingerprint = ? ORDER BY LastUpdate ASC;"), entityType, fp)
+	if err != nil {
+		return revs, err
+	}
+	defer rows.Close() // In case of premature exit.
+	for rows.Next() {
+		var r DbRevision
+		err := rows.StructScan(&r)
+		if err != nil {
+			return revs, err
+		}
+		revs = append(revs, r)
+	}
+	rows.Close()
+	// The current version is in the entity's own table. It's also in the revisions if it's an edit, but not if it's the original.
+	query := fmt.Sprintf("SELECT Fingerprint, LastUpdate, Body, Meta, UpdateProofOfWork, UpdateSignature, LocalArrival FROM %s WHERE Fingerprint = ?;", tableName)
+	rows2, err2 := globals.DbInstance.Queryx(globals.DbInstance.Rebind(query), fp)
+	if err2 != nil {
+		return revs, err2
+	}
+	defer rows2.Close() // In case of premature exit.
+	for rows2.Next() {
+		current := DbRevision{EntityType: entityType}
+		err := rows2.StructScan(&current)
+		if err != nil {
+			return revs, err
+		}
+		if len(revs) == 0 || revs[len(revs)-1].LastUpdate < current.LastUpdate {
+			revs = append(revs, current)
+		}
+	}
+	rows2.Close()
+	return revs, nil
+}
+
 // enforceReadValidity enforces that, in a ReadX function (medium level API below), either a time range or a list of fingerprints are asked, and not both.
 func enforceReadValidity(
 	fingerprints []api.Fingerprint,
@@ -957,6 +1030,11 @@ func ReadDbVotes(
 		if err != nil {
 			return dbArr, err
 		}
+	case "(ts)(pbfp)": // timestamps, parent board fp (used when serving remotes that only sync some boards)
+		query, args, err = sqlx.In("SELECT * FROM Votes WHERE (Board = ?) AND (LastReferenced >= ? AND LastReferenced <= ?);", boardfp, beginTimestamp, endTimestamp)
+		if err != nil {
+			return dbArr, err
+		}
 	case "(ts)(tc)(pbfp)": // timestamps, typeclass, parent board fp
 		query, args, err = sqlx.In("SELECT * FROM Votes WHERE (Board = ?) AND (TypeClass = ?) AND (LastReferenced >= ? AND LastReferenced <= ?);", boardfp, vtypeclass, beginTimestamp, endTimestamp)
 		if err != nil {
@@ -1010,6 +1088,8 @@ func ReadDbVotes(
 
 func readDbAddressesBasicSearch(Location api.Location, Sublocation api.Location, Port uint16) (*[]DbAddress, error) {
 	var dbArr []DbAddress
+	// Addresses are saved with their locations normalised, see APItoDB.
+	Location = api.Location(toolbox.NormalizeLocation(string(Location)))
 	if len(Location) > 0 && Port > 0 { // Regular address search.
 		rows, err := globals.DbInstance.Queryx("SELECT * from Addresses WHERE Location = ? AND Sublocation = ? AND Port = ?", Location, Sublocation, Port)
 		defer rows.Close() // In case of premature exit.
@@ -1612,6 +1692,37 @@ func GetThreadPostsCount(fp string) int {
 	return count
 }
 
+// ReadMerkleLeaves reads the fingerprints and creation timestamps of all entities of the given type created within the time range. These are what the Merkle trees used in sync reconciliation are built out of.
+func ReadMerkleLeaves(entityType string, beginTimestamp api.Timestamp, endTimestamp api.Timestamp) ([]api.MerkleLeaf, error) {
+	tables := map[string]string{
+		"boards":      "Boards",
+		"threads":     "Threads",
+		"posts":       "Posts",
+		"votes":       "Votes",
+		"keys":        "PublicKeys",
+		"truststates": "Truststates",
+	}
+	leaves := []api.MerkleLeaf{}
+	table, ok := tables[entityType]
+	if !ok {
+		return leaves, errors.New(fmt.Sprintf("ReadMerkleLeaves was given an entity type it does not know. Entity type: %s", entityType))
+	}
+	rows, err := globals.DbInstance.Queryx(fmt.Sprintf("SELECT Fingerprint, Creation FROM %s WHERE Creation >= ? AND Creation <= ?", table), beginTimestamp, endTimestamp)
+	if err != nil {
+		return leaves, err
+	}
+	defer rows.Close() // In case of premature exit.
+	for rows.Next() {
+		var l api.MerkleLeaf
+		err := rows.Scan(&l.Fingerprint, &l.Creation)
+		if err != nil {
+			return leaves, err
+		}
+		leaves = append(leaves, l)
+	}
+	return leaves, nil
+}
+
 // func Dbg_convertAddrSliceToNameSlice(nodes []DbAddress) []string {
 // 	names := []string{}
 // 	for _, val := range nodes {
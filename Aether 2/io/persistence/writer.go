@@ -20,10 +20,11 @@ import (
 	"github.com/pkg/errors"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-// Node is a non-communicating entity that holds the LastCheckin timestamps of each of the entities provided in the remote node. There is no way to send this data over to somebody, this is entirely local. There is also no batch processing because there is no situation in which you would need to insert multiple nodes at the same time (since you won't be connecting to multiple nodes simultaneously)
+// Node is a non-communicating entity that holds the LastCheckin timestamps of each of the entities provided in the remote node. There is no way to send this data over to somebody, this is entirely local. There is also no batch processing because there is no situation in which you would need to insert multiple nodes at the same time (concurrent syncs are always with different nodes, and each writes its own node at the end)
 
 func InsertNode(n DbNode) error {
 	err := insertNode(n)
@@ -93,6 +94,60 @@ func insertNode(n DbNode) error {
 	return nil
 }
 
+// PinEntity marks a board, thread or key as pinned. Pinned entities, and the entities that hang off of them, are exempt from the event horizon.
+func PinEntity(entityType string, fp api.Fingerprint) error {
+	if !IsPinnableEntityType(entityType) {
+		return errors.New(fmt.Sprintf("This entity type cannot be pinned. Entity type: %s", entityType))
+	}
+	if len(fp) == 0 || len(fp) > 64 {
+		return errors.New(fmt.Sprintf("The fingerprint of the entity to be pinned is invalid. Fingerprint: %s", fp))
+	}
+	p := DbPin{
+		EntityType:  entityType,
+		Fingerprint: fp,
+		PinnedAt:    api.Timestamp(time.Now().Unix()),
+	}
+	tx, err := globals.DbInstance.Beginx()
+	if err != nil {
+		return err
+	}
+	_, err2 := tx.NamedExec(pinInsert, p)
+	if err2 != nil {
+		tx.Rollback()
+		return errors.New(fmt.Sprintf("PinEntity encountered an error. Error: %s", err2))
+	}
+	err3 := tx.Commit()
+	if err3 != nil {
+		tx.Rollback()
+		return errors.New(fmt.Sprintf("PinEntity encountered an error when trying to commit to the database. Error: %s", err3))
+	}
+	logging.Logf(1, "Pinned the %s %s.", entityType, fp)
+	return nil
+}
+
+// UnpinEntity removes the pin of a board, thread or key. The entity becomes subject to the event horizon again.
+func UnpinEntity(entityType string, fp api.Fingerprint) error {
+	if !IsPinnableEntityType(entityType) {
+		return errors.New(fmt.Sprintf("This entity type cannot be pinned. Entity type: %s", entityType))
+	}
+	tx, err := globals.DbInstance.Beginx()
+	if err != nil {
+		return err
+	}
+	_, err2 := tx.Exec(tx.Rebind("DELETE FROM Pins WHERE EntityType = ? AND Fingerprint = ?"), entityType, fp)
+	if err2 != nil {
+		tx.Rollback()
+		return errors.New(fmt.Sprintf("UnpinEntity encountered an error. Error: %s", err2))
+	}
+	err3 := tx.Commit()
+	if err3 != nil {
+		tx.Rollback()
+		return errors.New(fmt.Sprintf("UnpinEntity encountered an error when trying to commit to the database. Error: %s", err3))
+	}
+	logging.Logf(1, "Unpinned the %s %s.", entityType, fp)
+	return nil
+}
+
 func AddrTrustedInsert(a *[]api.Address) error {
 	if globals.BackendTransientConfig.ShutdownInitiated {
 		return nil
@@ -265,28 +320,40 @@ func generateAdrSprotJunctionItem(addr DbAddress, sprot DbSubprotocol) DbAddress
 	return adrSprot
 }
 
+// insertLock serialises batch inserts. Syncs run concurrently, and their inserts touch the same rows when two remotes send us the same entities. The update rules in the insert SQL (only newer updates win) only hold if the transactions don't interleave, and SQLite can only take one writer anyway.
+var insertLock sync.Mutex
+
 // This is where we capture DB errors like 'DB is locked' and take action, such as retrying.
 func BatchInsert(apiObjects []interface{}) (InsertMetrics, error) {
+	return BatchInsertAccepted(&apiObjects)
+}
+
+// BatchInsertAccepted is BatchInsert, but it also leaves in the slice only the entities that were accepted into the database. The ones that failed their entitlement or field checks are dropped, so that whoever gossips the batch onwards does not pass them on. If the insert fails, none of them were accepted.
+func BatchInsertAccepted(apiObjectsPtr *[]interface{}) (InsertMetrics, error) {
+	insertLock.Lock()
+	defer insertLock.Unlock()
 	var im InsertMetrics
 	var err error
-	im, err = batchInsert(&apiObjects)
+	im, err = batchInsert(apiObjectsPtr)
 	if err != nil {
 		if strings.Contains(err.Error(), "Database was locked") {
 			logging.Log(1, "This transaction was not committed because database was locked. We'll wait 10 seconds and retry the transaction.")
 			time.Sleep(10 * time.Second)
 			logging.Log(1, "Retrying the previously failed BatchInsert transaction.")
 			var err2 error
-			im, err2 = batchInsert(&apiObjects)
+			im, err2 = batchInsert(apiObjectsPtr)
 			if err2 != nil {
 				if strings.Contains(err.Error(), "Database was locked") {
 					logging.LogCrash(fmt.Sprintf("The second attempt to commit this data to the database failed. The first attempt had failed because the database was locked. The second attempt failed with the error: %s This database is corrupted. Quitting.", err2))
 				} else { // Error is not db locked
+					*apiObjectsPtr = []interface{}{}
 					return im, err2
 				}
 			} else { // If the reattempted transaction succeeds
 				logging.Log(1, "The retry attempt of the failed transaction succeeded.")
 			}
 		} else { // Error is not db locked
+			*apiObjectsPtr = []interface{}{}
 			return im, err
 		}
 	}
@@ -369,8 +436,17 @@ func batchInsert(apiObjectsPtr *[]interface{}) (InsertMetrics, error) {
 	start := time.Now()
 	insertTimestamp := time.Now() // This is used so that all entities inserted in this insert will have same LocalArrival, LastReferenced, etc. This makes our inserts atomic, single instants in time. This is to prevent the case where another node connects to you with a first sync timestamp acquired while you were inserting from another node.
 	bb := batchBucket{}
+	accepted := make([]interface{}, 0, len(apiObjects))
+	// The entitlement checks need the entities this batch refers to. We read those once for the whole batch.
+	lookup := newEntitlementLookup(apiObjects)
 	// For each API object, convert to DB object and add to transaction.
 	for _, apiObject := range apiObjects {
+		entErr := api.CheckEntitlements(apiObject, lookup) // hits DB only through the lookup, which is already read
+		if entErr != nil {
+			// The owner of this entity is not allowed to do what it does. We pass on adding it to the database.
+			logging.Log(2, entErr)
+			continue
+		}
 		// apiObject: API type, dbObj: DB type.
 		dbo, err := APItoDB(apiObject, insertTimestamp) // does not hit DB
 		if err != nil {
@@ -395,6 +471,7 @@ func batchInsert(apiObjectsPtr *[]interface{}) (InsertMetrics, error) {
 			logging.Log(2, err3)
 			continue
 		}
+		accepted = append(accepted, apiObject)
 		switch dbObject := dbo.(type) {
 		case BoardPack:
 			bb.DbBoards = append(bb.DbBoards, dbObject.Board)
@@ -415,7 +492,8 @@ func batchInsert(apiObjectsPtr *[]interface{}) (InsertMetrics, error) {
 
 			// This also means that we will actually be not using the Subprotocols data, as that would be untrusted data.
 
-			dbObject.Address.LocationType = 0       // IPv4 or 6
+			// IPv4 or 6, or URL. This comes from the location itself, which is a part of the primary key, so it is no less trusted than the key.
+			dbObject.Address.LocationType = toolbox.IPType(string(dbObject.Address.Location))
 			dbObject.Address.Type = 0               // 2 = live, 255 = static
 			dbObject.Address.LastSuccessfulPing = 0 // We cannot trust someone else's lsp timestamp
 			dbObject.Address.LastSuccessfulSync = 0 // We cannot trust someone else's lsc timestamp
@@ -441,6 +519,7 @@ func batchInsert(apiObjectsPtr *[]interface{}) (InsertMetrics, error) {
 	if err != nil {
 		return InsertMetrics{}, err
 	}
+	*apiObjectsPtr = accepted
 	im.BoardsReceived = len(bb.DbBoards)
 	im.ThreadsReceived = len(bb.DbThreads)
 	im.PostsReceived = len(bb.DbPosts)
@@ -691,6 +770,19 @@ func getSQLCommands(dbType string) []string {
 			append(sqlstrs, threadInsert_ThreadsKey_LastReferencedUpdate)
 		sqlstrs =
 			append(sqlstrs, threadInsert_ThreadsBoardsKey_LastReferencedUpdate)
+		sqlstrs =
+			append(sqlstrs, threadInsert_Revisions_ArchivePrior)
+		if globals.BackendConfig.GetDbEngine() == "mysql" {
+			sqlstrs =
+				append(sqlstrs, threadInsert_Revisions_CandidateMySQL)
+		} else if globals.BackendConfig.GetDbEngine() == "sqlite" {
+			sqlstrs =
+				append(sqlstrs, threadInsert_Revisions_CandidateSQLite)
+		} else {
+			logging.LogCrash(fmt.Sprintf("Db Engine type not recognised."))
+		}
+		sqlstrs =
+			append(sqlstrs, threadInsert_Revisions_TrimOldest)
 		sqlstrs =
 			append(sqlstrs, threadInsert)
 	} else if dbType == "dbPost" {
@@ -708,6 +800,19 @@ func getSQLCommands(dbType string) []string {
 			append(sqlstrs, postInsert_PostsPosts_Recursive_LastReferencedUpdate)
 		sqlstrs =
 			append(sqlstrs, postInsert_PostsPostsKeys_Recursive_LastReferencedUpdate)
+		sqlstrs =
+			append(sqlstrs, postInsert_Revisions_ArchivePrior)
+		if globals.BackendConfig.GetDbEngine() == "mysql" {
+			sqlstrs =
+				append(sqlstrs, postInsert_Revisions_CandidateMySQL)
+		} else if globals.BackendConfig.GetDbEngine() == "sqlite" {
+			sqlstrs =
+				append(sqlstrs, postInsert_Revisions_CandidateSQLite)
+		} else {
+			logging.LogCrash(fmt.Sprintf("Db Engine type not recognised."))
+		}
+		sqlstrs =
+			append(sqlstrs, postInsert_Revisions_TrimOldest)
 		sqlstrs =
 			append(sqlstrs, postInsert)
 	} else if dbType == "dbVote" {
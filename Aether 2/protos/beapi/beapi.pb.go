@@ -37,6 +37,17 @@ It has these top-level messages:
 	MintedContentResponse
 	ConnectToRemoteRequest
 	ConnectToRemoteResponse
+	SubscribedBoardsPayload
+	SubscribedBoardsResponse
+	PinPayload
+	PinResponse
+	Blob
+	BlobsPayload
+	BlobsRequest
+	BlobsResponse
+	Revision
+	RevisionsRequest
+	RevisionsResponse
 */
 package beapi
 
@@ -869,6 +880,318 @@ func (m *ConnectToRemoteResponse) GetStatus() *Status {
 	return nil
 }
 
+type SubscribedBoardsPayload struct {
+	RequesterId  *RequesterId `protobuf:"bytes,1,opt,name=RequesterId" json:"RequesterId,omitempty"`
+	Fingerprints []string     `protobuf:"bytes,2,rep,name=Fingerprints" json:"Fingerprints,omitempty"`
+}
+
+func (m *SubscribedBoardsPayload) Reset()                    { *m = SubscribedBoardsPayload{} }
+func (m *SubscribedBoardsPayload) String() string            { return proto.CompactTextString(m) }
+func (*SubscribedBoardsPayload) ProtoMessage()               {}
+func (*SubscribedBoardsPayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *SubscribedBoardsPayload) GetRequesterId() *RequesterId {
+	if m != nil {
+		return m.RequesterId
+	}
+	return nil
+}
+
+func (m *SubscribedBoardsPayload) GetFingerprints() []string {
+	if m != nil {
+		return m.Fingerprints
+	}
+	return nil
+}
+
+type SubscribedBoardsResponse struct {
+	Status *Status `protobuf:"bytes,1,opt,name=Status" json:"Status,omitempty"`
+}
+
+func (m *SubscribedBoardsResponse) Reset()                    { *m = SubscribedBoardsResponse{} }
+func (m *SubscribedBoardsResponse) String() string            { return proto.CompactTextString(m) }
+func (*SubscribedBoardsResponse) ProtoMessage()               {}
+func (*SubscribedBoardsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *SubscribedBoardsResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type PinPayload struct {
+	RequesterId *RequesterId `protobuf:"bytes,1,opt,name=RequesterId" json:"RequesterId,omitempty"`
+	EntityType  string       `protobuf:"bytes,2,opt,name=EntityType" json:"EntityType,omitempty"`
+	Fingerprint string       `protobuf:"bytes,3,opt,name=Fingerprint" json:"Fingerprint,omitempty"`
+	Pinned      bool         `protobuf:"varint,4,opt,name=Pinned" json:"Pinned,omitempty"`
+}
+
+func (m *PinPayload) Reset()                    { *m = PinPayload{} }
+func (m *PinPayload) String() string            { return proto.CompactTextString(m) }
+func (*PinPayload) ProtoMessage()               {}
+func (*PinPayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *PinPayload) GetRequesterId() *RequesterId {
+	if m != nil {
+		return m.RequesterId
+	}
+	return nil
+}
+
+func (m *PinPayload) GetEntityType() string {
+	if m != nil {
+		return m.EntityType
+	}
+	return ""
+}
+
+func (m *PinPayload) GetFingerprint() string {
+	if m != nil {
+		return m.Fingerprint
+	}
+	return ""
+}
+
+func (m *PinPayload) GetPinned() bool {
+	if m != nil {
+		return m.Pinned
+	}
+	return false
+}
+
+type PinResponse struct {
+	Status         *Status `protobuf:"bytes,1,opt,name=Status" json:"Status,omitempty"`
+	PinnedDbSizeMb int64   `protobuf:"varint,2,opt,name=PinnedDbSizeMb" json:"PinnedDbSizeMb,omitempty"`
+}
+
+func (m *PinResponse) Reset()                    { *m = PinResponse{} }
+func (m *PinResponse) String() string            { return proto.CompactTextString(m) }
+func (*PinResponse) ProtoMessage()               {}
+func (*PinResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *PinResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *PinResponse) GetPinnedDbSizeMb() int64 {
+	if m != nil {
+		return m.PinnedDbSizeMb
+	}
+	return 0
+}
+
+type Blob struct {
+	Hash string `protobuf:"bytes,1,opt,name=Hash" json:"Hash,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=Data" json:"Data,omitempty"`
+}
+
+func (m *Blob) Reset()                    { *m = Blob{} }
+func (m *Blob) String() string            { return proto.CompactTextString(m) }
+func (*Blob) ProtoMessage()               {}
+func (*Blob) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *Blob) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Blob) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type BlobsPayload struct {
+	RequesterId *RequesterId `protobuf:"bytes,1,opt,name=RequesterId" json:"RequesterId,omitempty"`
+	Blobs       []*Blob      `protobuf:"bytes,2,rep,name=Blobs" json:"Blobs,omitempty"`
+}
+
+func (m *BlobsPayload) Reset()                    { *m = BlobsPayload{} }
+func (m *BlobsPayload) String() string            { return proto.CompactTextString(m) }
+func (*BlobsPayload) ProtoMessage()               {}
+func (*BlobsPayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *BlobsPayload) GetRequesterId() *RequesterId {
+	if m != nil {
+		return m.RequesterId
+	}
+	return nil
+}
+
+func (m *BlobsPayload) GetBlobs() []*Blob {
+	if m != nil {
+		return m.Blobs
+	}
+	return nil
+}
+
+type BlobsRequest struct {
+	RequesterId *RequesterId `protobuf:"bytes,1,opt,name=RequesterId" json:"RequesterId,omitempty"`
+	Hashes      []string     `protobuf:"bytes,2,rep,name=Hashes" json:"Hashes,omitempty"`
+}
+
+func (m *BlobsRequest) Reset()                    { *m = BlobsRequest{} }
+func (m *BlobsRequest) String() string            { return proto.CompactTextString(m) }
+func (*BlobsRequest) ProtoMessage()               {}
+func (*BlobsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *BlobsRequest) GetRequesterId() *RequesterId {
+	if m != nil {
+		return m.RequesterId
+	}
+	return nil
+}
+
+func (m *BlobsRequest) GetHashes() []string {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+type BlobsResponse struct {
+	Status *Status `protobuf:"bytes,1,opt,name=Status" json:"Status,omitempty"`
+	Blobs  []*Blob `protobuf:"bytes,2,rep,name=Blobs" json:"Blobs,omitempty"`
+}
+
+func (m *BlobsResponse) Reset()                    { *m = BlobsResponse{} }
+func (m *BlobsResponse) String() string            { return proto.CompactTextString(m) }
+func (*BlobsResponse) ProtoMessage()               {}
+func (*BlobsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *BlobsResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *BlobsResponse) GetBlobs() []*Blob {
+	if m != nil {
+		return m.Blobs
+	}
+	return nil
+}
+
+type Revision struct {
+	LastUpdate        int64  `protobuf:"varint,1,opt,name=LastUpdate" json:"LastUpdate,omitempty"`
+	Body              string `protobuf:"bytes,2,opt,name=Body" json:"Body,omitempty"`
+	Meta              string `protobuf:"bytes,3,opt,name=Meta" json:"Meta,omitempty"`
+	UpdateProofOfWork string `protobuf:"bytes,4,opt,name=UpdateProofOfWork" json:"UpdateProofOfWork,omitempty"`
+	UpdateSignature   string `protobuf:"bytes,5,opt,name=UpdateSignature" json:"UpdateSignature,omitempty"`
+	LocalArrival      int64  `protobuf:"varint,6,opt,name=LocalArrival" json:"LocalArrival,omitempty"`
+}
+
+func (m *Revision) Reset()                    { *m = Revision{} }
+func (m *Revision) String() string            { return proto.CompactTextString(m) }
+func (*Revision) ProtoMessage()               {}
+func (*Revision) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *Revision) GetLastUpdate() int64 {
+	if m != nil {
+		return m.LastUpdate
+	}
+	return 0
+}
+
+func (m *Revision) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *Revision) GetMeta() string {
+	if m != nil {
+		return m.Meta
+	}
+	return ""
+}
+
+func (m *Revision) GetUpdateProofOfWork() string {
+	if m != nil {
+		return m.UpdateProofOfWork
+	}
+	return ""
+}
+
+func (m *Revision) GetUpdateSignature() string {
+	if m != nil {
+		return m.UpdateSignature
+	}
+	return ""
+}
+
+func (m *Revision) GetLocalArrival() int64 {
+	if m != nil {
+		return m.LocalArrival
+	}
+	return 0
+}
+
+type RevisionsRequest struct {
+	RequesterId *RequesterId `protobuf:"bytes,1,opt,name=RequesterId" json:"RequesterId,omitempty"`
+	EntityType  string       `protobuf:"bytes,2,opt,name=EntityType" json:"EntityType,omitempty"`
+	Fingerprint string       `protobuf:"bytes,3,opt,name=Fingerprint" json:"Fingerprint,omitempty"`
+}
+
+func (m *RevisionsRequest) Reset()                    { *m = RevisionsRequest{} }
+func (m *RevisionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevisionsRequest) ProtoMessage()               {}
+func (*RevisionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *RevisionsRequest) GetRequesterId() *RequesterId {
+	if m != nil {
+		return m.RequesterId
+	}
+	return nil
+}
+
+func (m *RevisionsRequest) GetEntityType() string {
+	if m != nil {
+		return m.EntityType
+	}
+	return ""
+}
+
+func (m *RevisionsRequest) GetFingerprint() string {
+	if m != nil {
+		return m.Fingerprint
+	}
+	return ""
+}
+
+type RevisionsResponse struct {
+	Status    *Status     `protobuf:"bytes,1,opt,name=Status" json:"Status,omitempty"`
+	Revisions []*Revision `protobuf:"bytes,2,rep,name=Revisions" json:"Revisions,omitempty"`
+}
+
+func (m *RevisionsResponse) Reset()                    { *m = RevisionsResponse{} }
+func (m *RevisionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*RevisionsResponse) ProtoMessage()               {}
+func (*RevisionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *RevisionsResponse) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *RevisionsResponse) GetRevisions() []*Revision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RequesterId)(nil), "beapi.RequesterId")
 	proto.RegisterType((*Status)(nil), "beapi.Status")
@@ -899,6 +1222,17 @@ func init() {
 	proto.RegisterType((*MintedContentResponse)(nil), "beapi.MintedContentResponse")
 	proto.RegisterType((*ConnectToRemoteRequest)(nil), "beapi.ConnectToRemoteRequest")
 	proto.RegisterType((*ConnectToRemoteResponse)(nil), "beapi.ConnectToRemoteResponse")
+	proto.RegisterType((*SubscribedBoardsPayload)(nil), "beapi.SubscribedBoardsPayload")
+	proto.RegisterType((*SubscribedBoardsResponse)(nil), "beapi.SubscribedBoardsResponse")
+	proto.RegisterType((*PinPayload)(nil), "beapi.PinPayload")
+	proto.RegisterType((*PinResponse)(nil), "beapi.PinResponse")
+	proto.RegisterType((*Blob)(nil), "beapi.Blob")
+	proto.RegisterType((*BlobsPayload)(nil), "beapi.BlobsPayload")
+	proto.RegisterType((*BlobsRequest)(nil), "beapi.BlobsRequest")
+	proto.RegisterType((*BlobsResponse)(nil), "beapi.BlobsResponse")
+	proto.RegisterType((*Revision)(nil), "beapi.Revision")
+	proto.RegisterType((*RevisionsRequest)(nil), "beapi.RevisionsRequest")
+	proto.RegisterType((*RevisionsResponse)(nil), "beapi.RevisionsResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -930,6 +1264,11 @@ type BackendAPIClient interface {
 	GetThreadPostsCount(ctx context.Context, in *ThreadPostsCountRequest, opts ...grpc.CallOption) (*ThreadPostsCountResponse, error)
 	SendMintedContent(ctx context.Context, in *MintedContentPayload, opts ...grpc.CallOption) (*MintedContentResponse, error)
 	SendConnectToRemoteRequest(ctx context.Context, in *ConnectToRemoteRequest, opts ...grpc.CallOption) (*ConnectToRemoteResponse, error)
+	SendSubscribedBoards(ctx context.Context, in *SubscribedBoardsPayload, opts ...grpc.CallOption) (*SubscribedBoardsResponse, error)
+	SendPinRequest(ctx context.Context, in *PinPayload, opts ...grpc.CallOption) (*PinResponse, error)
+	SendBlobs(ctx context.Context, in *BlobsPayload, opts ...grpc.CallOption) (*BlobsResponse, error)
+	GetBlobs(ctx context.Context, in *BlobsRequest, opts ...grpc.CallOption) (*BlobsResponse, error)
+	GetRevisions(ctx context.Context, in *RevisionsRequest, opts ...grpc.CallOption) (*RevisionsResponse, error)
 }
 
 type backendAPIClient struct {
@@ -1039,6 +1378,51 @@ func (c *backendAPIClient) SendConnectToRemoteRequest(ctx context.Context, in *C
 	return out, nil
 }
 
+func (c *backendAPIClient) SendSubscribedBoards(ctx context.Context, in *SubscribedBoardsPayload, opts ...grpc.CallOption) (*SubscribedBoardsResponse, error) {
+	out := new(SubscribedBoardsResponse)
+	err := grpc.Invoke(ctx, "/beapi.BackendAPI/SendSubscribedBoards", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendAPIClient) SendPinRequest(ctx context.Context, in *PinPayload, opts ...grpc.CallOption) (*PinResponse, error) {
+	out := new(PinResponse)
+	err := grpc.Invoke(ctx, "/beapi.BackendAPI/SendPinRequest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendAPIClient) SendBlobs(ctx context.Context, in *BlobsPayload, opts ...grpc.CallOption) (*BlobsResponse, error) {
+	out := new(BlobsResponse)
+	err := grpc.Invoke(ctx, "/beapi.BackendAPI/SendBlobs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendAPIClient) GetBlobs(ctx context.Context, in *BlobsRequest, opts ...grpc.CallOption) (*BlobsResponse, error) {
+	out := new(BlobsResponse)
+	err := grpc.Invoke(ctx, "/beapi.BackendAPI/GetBlobs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendAPIClient) GetRevisions(ctx context.Context, in *RevisionsRequest, opts ...grpc.CallOption) (*RevisionsResponse, error) {
+	out := new(RevisionsResponse)
+	err := grpc.Invoke(ctx, "/beapi.BackendAPI/GetRevisions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for BackendAPI service
 
 type BackendAPIServer interface {
@@ -1060,6 +1444,11 @@ type BackendAPIServer interface {
 	GetThreadPostsCount(context.Context, *ThreadPostsCountRequest) (*ThreadPostsCountResponse, error)
 	SendMintedContent(context.Context, *MintedContentPayload) (*MintedContentResponse, error)
 	SendConnectToRemoteRequest(context.Context, *ConnectToRemoteRequest) (*ConnectToRemoteResponse, error)
+	SendSubscribedBoards(context.Context, *SubscribedBoardsPayload) (*SubscribedBoardsResponse, error)
+	SendPinRequest(context.Context, *PinPayload) (*PinResponse, error)
+	SendBlobs(context.Context, *BlobsPayload) (*BlobsResponse, error)
+	GetBlobs(context.Context, *BlobsRequest) (*BlobsResponse, error)
+	GetRevisions(context.Context, *RevisionsRequest) (*RevisionsResponse, error)
 }
 
 func RegisterBackendAPIServer(s *grpc.Server, srv BackendAPIServer) {
@@ -1264,6 +1653,96 @@ func _BackendAPI_SendConnectToRemoteRequest_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackendAPI_SendSubscribedBoards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribedBoardsPayload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendAPIServer).SendSubscribedBoards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/beapi.BackendAPI/SendSubscribedBoards",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendAPIServer).SendSubscribedBoards(ctx, req.(*SubscribedBoardsPayload))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendAPI_SendPinRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinPayload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendAPIServer).SendPinRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/beapi.BackendAPI/SendPinRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendAPIServer).SendPinRequest(ctx, req.(*PinPayload))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendAPI_SendBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobsPayload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendAPIServer).SendBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/beapi.BackendAPI/SendBlobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendAPIServer).SendBlobs(ctx, req.(*BlobsPayload))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendAPI_GetBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendAPIServer).GetBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/beapi.BackendAPI/GetBlobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendAPIServer).GetBlobs(ctx, req.(*BlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendAPI_GetRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendAPIServer).GetRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/beapi.BackendAPI/GetRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendAPIServer).GetRevisions(ctx, req.(*RevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _BackendAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "beapi.BackendAPI",
 	HandlerType: (*BackendAPIServer)(nil),
@@ -1312,6 +1791,26 @@ var _BackendAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SendConnectToRemoteRequest",
 			Handler:    _BackendAPI_SendConnectToRemoteRequest_Handler,
 		},
+		{
+			MethodName: "SendSubscribedBoards",
+			Handler:    _BackendAPI_SendSubscribedBoards_Handler,
+		},
+		{
+			MethodName: "SendPinRequest",
+			Handler:    _BackendAPI_SendPinRequest_Handler,
+		},
+		{
+			MethodName: "SendBlobs",
+			Handler:    _BackendAPI_SendBlobs_Handler,
+		},
+		{
+			MethodName: "GetBlobs",
+			Handler:    _BackendAPI_GetBlobs_Handler,
+		},
+		{
+			MethodName: "GetRevisions",
+			Handler:    _BackendAPI_GetRevisions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "beapi/beapi.proto",
@@ -1320,77 +1819,100 @@ var _BackendAPI_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("beapi/beapi.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1143 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xeb, 0x4e, 0x1b, 0x47,
-	0x14, 0xc6, 0x18, 0xdb, 0xf8, 0x18, 0x28, 0x0c, 0x86, 0x2c, 0xdb, 0x94, 0x58, 0xa3, 0x46, 0x42,
-	0xaa, 0x0a, 0x12, 0x44, 0x8a, 0x14, 0xf5, 0x06, 0x0e, 0x41, 0x08, 0x92, 0x58, 0x83, 0xd5, 0xa6,
-	0x37, 0xa9, 0x8b, 0x77, 0x00, 0x2b, 0x78, 0xd7, 0x99, 0x19, 0xab, 0xf5, 0xaf, 0xaa, 0x0f, 0xd1,
-	0x87, 0xe8, 0xcb, 0xb5, 0xaf, 0x50, 0xcd, 0x6d, 0x3d, 0x63, 0x9b, 0xa6, 0x1b, 0x24, 0xff, 0x41,
-	0x7b, 0x2e, 0xdf, 0x39, 0xf3, 0xcd, 0x9c, 0x73, 0x66, 0x30, 0xac, 0x5d, 0xd2, 0xa8, 0xdf, 0xdd,
-	0x53, 0x7f, 0x77, 0xfb, 0x2c, 0x15, 0x29, 0x2a, 0x29, 0x21, 0xdc, 0xea, 0x75, 0x7b, 0xd2, 0xc4,
-	0x05, 0x1b, 0x74, 0x84, 0x32, 0x71, 0xed, 0x81, 0xff, 0x28, 0x40, 0x8d, 0xd0, 0x77, 0x03, 0xca,
-	0x05, 0x65, 0xa7, 0x31, 0x6a, 0x40, 0xed, 0xb0, 0xd3, 0xa1, 0x9c, 0xb7, 0xd3, 0xb7, 0x34, 0x09,
-	0x0a, 0x8d, 0xc2, 0x4e, 0x95, 0xb8, 0x2a, 0x54, 0x87, 0xd2, 0xab, 0x34, 0xe9, 0xd0, 0x60, 0x5e,
-	0xd9, 0xb4, 0x80, 0x1e, 0x42, 0xb5, 0x35, 0xb8, 0xbc, 0xed, 0x76, 0xce, 0xe8, 0x30, 0x28, 0x2a,
-	0xcb, 0x48, 0x21, 0xad, 0xed, 0x6e, 0x8f, 0x72, 0x11, 0xf5, 0xfa, 0xc1, 0x42, 0xa3, 0xb0, 0x53,
-	0x24, 0x23, 0x05, 0x3e, 0x87, 0xf2, 0x85, 0x88, 0xc4, 0x80, 0xa3, 0x6d, 0x00, 0xfd, 0xd5, 0x4c,
-	0x63, 0xaa, 0x92, 0x97, 0x88, 0xa3, 0x41, 0x18, 0x96, 0x8e, 0x19, 0x4b, 0xd9, 0x4b, 0xca, 0x79,
-	0x74, 0x6d, 0x97, 0xe0, 0xe9, 0xf0, 0x3f, 0x05, 0xa8, 0xbc, 0xe8, 0xde, 0x0a, 0xca, 0x38, 0xfa,
-	0x02, 0x56, 0xcf, 0x23, 0x2e, 0x08, 0xbd, 0x92, 0xd9, 0x48, 0x94, 0x5c, 0xeb, 0xa8, 0xb5, 0xfd,
-	0xd5, 0x5d, 0xbd, 0x4f, 0x99, 0x9e, 0x4c, 0x78, 0xa2, 0xa7, 0xb0, 0xf4, 0xa2, 0x9b, 0x5c, 0x53,
-	0xd6, 0x67, 0xdd, 0x44, 0x70, 0x95, 0xad, 0xb6, 0xbf, 0x6e, 0x90, 0xae, 0x89, 0x78, 0x8e, 0xe8,
-	0x09, 0xd4, 0xda, 0xc3, 0x3e, 0x35, 0xab, 0x50, 0xdb, 0x51, 0xdb, 0x47, 0x36, 0xe3, 0xc8, 0x42,
-	0x5c, 0x37, 0x99, 0xee, 0x84, 0x45, 0xfd, 0x1b, 0x0b, 0x5b, 0xf0, 0xd2, 0xb9, 0x26, 0xe2, 0x39,
-	0xe2, 0x03, 0xbd, 0xbb, 0x7a, 0xd1, 0x75, 0x28, 0x5d, 0x88, 0x88, 0x09, 0xc5, 0xb3, 0x48, 0xb4,
-	0x80, 0x56, 0xa1, 0x78, 0x9c, 0xc4, 0x6a, 0x25, 0x45, 0x22, 0x3f, 0xf1, 0xbe, 0x4f, 0x4e, 0x6e,
-	0xad, 0x47, 0xb6, 0xd0, 0x28, 0xca, 0xad, 0x75, 0x75, 0xf8, 0x6b, 0x8f, 0x97, 0x3a, 0xd5, 0x61,
-	0x9f, 0x36, 0x6f, 0x23, 0xce, 0xcd, 0x61, 0x8d, 0x14, 0x08, 0xc1, 0x82, 0x14, 0xd4, 0xae, 0x95,
-	0x88, 0xfa, 0xc6, 0x7f, 0x17, 0x7c, 0x8e, 0x72, 0xb5, 0x47, 0x69, 0xc4, 0x62, 0x53, 0x68, 0x5a,
-	0x40, 0x9b, 0x50, 0x6e, 0xdf, 0x30, 0x1a, 0xc5, 0xe6, 0x80, 0x8d, 0x24, 0xf5, 0xad, 0x88, 0xd1,
-	0x44, 0x98, 0x0a, 0x33, 0x92, 0x8c, 0xf2, 0xfa, 0xd7, 0x84, 0x32, 0xb5, 0x65, 0x55, 0xa2, 0x05,
-	0x15, 0x25, 0x62, 0xd7, 0x54, 0x04, 0x25, 0x13, 0x45, 0x49, 0x52, 0xff, 0x3c, 0xed, 0x45, 0xdd,
-	0x24, 0x28, 0x6b, 0xbd, 0x96, 0xd0, 0xa7, 0xb0, 0xfc, 0x2a, 0x7d, 0x4e, 0x79, 0x87, 0x26, 0x71,
-	0x24, 0xb7, 0xa0, 0xd2, 0x28, 0xec, 0x2c, 0x12, 0x5f, 0x29, 0x73, 0x9d, 0x77, 0x7b, 0x5d, 0x11,
-	0x2c, 0x2a, 0x5e, 0x5a, 0x90, 0x31, 0x5f, 0x5f, 0x5d, 0x71, 0x2a, 0x82, 0xaa, 0x52, 0x1b, 0x09,
-	0x1f, 0xc3, 0xb2, 0xee, 0x1d, 0xd3, 0x63, 0xb2, 0x34, 0x9c, 0x76, 0x33, 0xc5, 0x68, 0x4b, 0xc3,
-	0xb1, 0x10, 0xd7, 0x0d, 0x7f, 0x0f, 0x2b, 0x36, 0x0c, 0xef, 0xa7, 0x09, 0xa7, 0xe8, 0xb1, 0xed,
-	0x19, 0x13, 0x62, 0xd9, 0x84, 0xd0, 0x4a, 0x62, 0x1b, 0x6a, 0xac, 0x9d, 0xe7, 0x27, 0xda, 0x19,
-	0xa7, 0xb0, 0xac, 0x36, 0xfd, 0x7e, 0x2b, 0x44, 0x3b, 0x59, 0xd3, 0x99, 0x36, 0x59, 0xc9, 0xda,
-	0x44, 0x97, 0xac, 0x35, 0xe3, 0x18, 0x56, 0x6c, 0xc2, 0x7c, 0x5c, 0x3e, 0x83, 0xb2, 0x06, 0x06,
-	0xf3, 0x8d, 0xa2, 0xea, 0x0c, 0x6f, 0x9e, 0x29, 0x1b, 0x31, 0x2e, 0xb8, 0x0f, 0x2b, 0xba, 0x68,
-	0x66, 0xc6, 0xeb, 0x06, 0x3e, 0xca, 0x32, 0xe6, 0x23, 0xb6, 0x0b, 0x15, 0x83, 0x34, 0xcc, 0xea,
-	0x3e, 0x33, 0x6d, 0x24, 0xd6, 0x09, 0x27, 0xb0, 0xd4, 0x4a, 0xb9, 0x98, 0x19, 0xb3, 0x5f, 0x60,
-	0xd9, 0xe4, 0xcb, 0xc7, 0x6b, 0x07, 0x4a, 0x0a, 0x67, 0x58, 0x21, 0x9f, 0x95, 0x34, 0x11, 0xed,
-	0x20, 0x19, 0x7d, 0x9b, 0x0a, 0x3a, 0x4b, 0x46, 0x26, 0x5f, 0x6e, 0x46, 0x0a, 0x37, 0x9d, 0x91,
-	0x34, 0x11, 0xed, 0x80, 0x7b, 0x50, 0x3b, 0xa3, 0xc3, 0x99, 0x11, 0xfa, 0x09, 0x96, 0x74, 0xba,
-	0x7c, 0x7c, 0x1e, 0xc3, 0x82, 0x84, 0x19, 0x3a, 0x6b, 0x3e, 0x9d, 0x33, 0x3a, 0x24, 0xca, 0x8c,
-	0x05, 0xa0, 0x36, 0x1b, 0x70, 0xc1, 0x45, 0x34, 0xc3, 0x43, 0xfa, 0x0d, 0xd6, 0xbd, 0xac, 0xf9,
-	0xa8, 0x3d, 0x83, 0x9a, 0x83, 0x36, 0x0c, 0x83, 0xb1, 0xc6, 0xca, 0x1c, 0x88, 0xeb, 0x8c, 0x19,
-	0x04, 0x6a, 0x8c, 0x98, 0x86, 0x6b, 0xa6, 0x83, 0x44, 0xdc, 0x8f, 0x75, 0x03, 0x6a, 0xce, 0x4d,
-	0x6a, 0xe7, 0xb0, 0xa3, 0xc2, 0x6f, 0x60, 0x6b, 0x4a, 0xce, 0x7c, 0x9c, 0xeb, 0x50, 0xea, 0x48,
-	0x9c, 0xb9, 0x73, 0xb5, 0x80, 0xdf, 0xc1, 0x03, 0x1d, 0x54, 0xf5, 0xda, 0x4c, 0xc8, 0x7c, 0x07,
-	0xc1, 0x64, 0xca, 0xdc, 0x5c, 0x9a, 0x2e, 0x17, 0x25, 0xe0, 0x3f, 0x8b, 0x50, 0x7f, 0xd9, 0x4d,
-	0x04, 0x8d, 0x9b, 0x69, 0x22, 0x68, 0x22, 0x5a, 0xd1, 0xf0, 0x36, 0x8d, 0xe2, 0x0f, 0x64, 0x92,
-	0xe7, 0x4a, 0x71, 0xc7, 0x74, 0xf1, 0x7f, 0x8c, 0xe9, 0xd1, 0xf8, 0x5b, 0x78, 0xcf, 0xf8, 0x1b,
-	0x8d, 0x95, 0xd2, 0x7b, 0xc6, 0x4a, 0xd6, 0xb0, 0xe5, 0xff, 0x6c, 0xd8, 0xf1, 0xe2, 0xaf, 0xe4,
-	0x28, 0x7e, 0x74, 0x00, 0xd5, 0xc3, 0x38, 0x66, 0x94, 0x73, 0xca, 0x83, 0x45, 0x85, 0xdc, 0xf0,
-	0x91, 0xc6, 0x4c, 0x46, 0x7e, 0xf8, 0x2b, 0xd8, 0xf0, 0x8e, 0x25, 0xe7, 0x69, 0xe3, 0xdf, 0x61,
-	0xb3, 0x99, 0x26, 0x09, 0xed, 0x88, 0x76, 0x4a, 0x68, 0x4f, 0x32, 0xbe, 0x57, 0x89, 0xee, 0x41,
-	0xc5, 0x2c, 0xce, 0x4c, 0x99, 0x3b, 0x28, 0x58, 0x2f, 0xfc, 0x0d, 0x3c, 0x98, 0x58, 0x40, 0x2e,
-	0x0a, 0xfb, 0x7f, 0x95, 0x01, 0x8e, 0xa2, 0xce, 0x5b, 0x9a, 0xc4, 0x87, 0xad, 0x53, 0x74, 0x0c,
-	0x75, 0xb3, 0x20, 0xab, 0x54, 0x8f, 0x2e, 0x54, 0x37, 0x68, 0xef, 0x59, 0x18, 0x6e, 0x8c, 0x69,
-	0x75, 0x6a, 0x3c, 0x87, 0x9e, 0x41, 0xf5, 0x84, 0x0a, 0x53, 0x81, 0x16, 0xeb, 0x3d, 0xd8, 0x32,
-	0xac, 0xff, 0xaa, 0xc2, 0x73, 0xe8, 0x4b, 0x80, 0x13, 0x2a, 0x6c, 0x39, 0x5a, 0x37, 0xff, 0x59,
-	0x14, 0x6e, 0x8e, 0xab, 0x33, 0xf8, 0x53, 0x58, 0x3c, 0xa1, 0x42, 0x57, 0xa8, 0xfd, 0x2f, 0xc4,
-	0x7d, 0x77, 0x84, 0x75, 0x5f, 0x39, 0x06, 0xd4, 0x05, 0x6b, 0x81, 0xee, 0xf5, 0x9e, 0x01, 0xbd,
-	0x3b, 0x18, 0xcf, 0xa1, 0x27, 0x50, 0x39, 0xa1, 0x42, 0x55, 0xb0, 0x3d, 0x61, 0xe7, 0x12, 0x0d,
-	0xd7, 0x3d, 0x5d, 0x86, 0x3a, 0x85, 0x15, 0x49, 0xd3, 0x29, 0xe1, 0x2d, 0xcb, 0x69, 0xe2, 0xd2,
-	0x0a, 0xc3, 0x69, 0xa6, 0x2c, 0xd4, 0x8f, 0x50, 0xb7, 0xbb, 0xed, 0xce, 0x61, 0xf4, 0xc8, 0xdd,
-	0xe2, 0x29, 0xb7, 0x42, 0xd8, 0xb8, 0xdb, 0x21, 0x0b, 0xfe, 0x06, 0xd6, 0xb3, 0xe3, 0x18, 0xcd,
-	0x45, 0xb4, 0xed, 0x1d, 0xc0, 0xc4, 0x8c, 0x0e, 0x1f, 0xdd, 0x69, 0xcf, 0x22, 0xb7, 0x60, 0xed,
-	0x82, 0x26, 0xb1, 0xd7, 0x81, 0xe8, 0x63, 0x83, 0x9b, 0x36, 0x2e, 0xc3, 0x87, 0xd3, 0x8c, 0x4e,
-	0xc4, 0x9f, 0x21, 0x94, 0x11, 0xef, 0xe8, 0xc9, 0x4f, 0x0c, 0x7a, 0xba, 0x39, 0xdc, 0xbe, 0xcb,
-	0x6c, 0xc3, 0x1f, 0x85, 0x3f, 0x04, 0x11, 0x15, 0x37, 0x94, 0x7d, 0xde, 0x49, 0x19, 0xdd, 0xd3,
-	0x4d, 0xa9, 0x7f, 0xb9, 0xb8, 0x2c, 0x2b, 0xe9, 0xe0, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xab,
-	0xa1, 0x31, 0xc2, 0xcf, 0x10, 0x00, 0x00,
+	// 1519 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x5d, 0x6f, 0x1b, 0x45,
+	0x17, 0x7e, 0x5d, 0xc7, 0x49, 0x7c, 0xec, 0xb8, 0xc9, 0xc4, 0x49, 0xb6, 0xfb, 0x96, 0xd4, 0x8c,
+	0x28, 0x8a, 0x04, 0x4d, 0xa5, 0xb4, 0x52, 0xa1, 0xe2, 0x2b, 0x71, 0xd2, 0x50, 0x35, 0x69, 0xad,
+	0x49, 0xa0, 0x2d, 0x14, 0x89, 0xb1, 0x77, 0x92, 0xac, 0x6a, 0xef, 0xba, 0x3b, 0xe3, 0x82, 0xb9,
+	0x41, 0xdc, 0x72, 0xcf, 0x1d, 0xbf, 0x89, 0x9f, 0x02, 0x97, 0xdc, 0xa2, 0xf9, 0xd8, 0xf5, 0xec,
+	0xda, 0xa6, 0x5d, 0x2c, 0xe5, 0xc6, 0xda, 0xf3, 0x9c, 0x8f, 0x99, 0x67, 0xe6, 0x9c, 0x33, 0x33,
+	0x86, 0x95, 0x36, 0xa3, 0x7d, 0xff, 0xb6, 0xfa, 0xdd, 0xee, 0x47, 0xa1, 0x08, 0x51, 0x49, 0x09,
+	0xee, 0xb5, 0x9e, 0xdf, 0x93, 0x2a, 0x2e, 0xa2, 0x41, 0x47, 0x28, 0x15, 0xd7, 0x16, 0xf8, 0x97,
+	0x02, 0x54, 0x08, 0x7b, 0x35, 0x60, 0x5c, 0xb0, 0xe8, 0xa1, 0x87, 0x1a, 0x50, 0xd9, 0xed, 0x74,
+	0x18, 0xe7, 0xa7, 0xe1, 0x4b, 0x16, 0x38, 0x85, 0x46, 0x61, 0xab, 0x4c, 0x6c, 0x08, 0xd5, 0xa1,
+	0xf4, 0x38, 0x0c, 0x3a, 0xcc, 0xb9, 0xa2, 0x74, 0x5a, 0x40, 0xd7, 0xa1, 0xdc, 0x1a, 0xb4, 0xbb,
+	0x7e, 0xe7, 0x11, 0x1b, 0x3a, 0x45, 0xa5, 0x19, 0x01, 0x52, 0x7b, 0xea, 0xf7, 0x18, 0x17, 0xb4,
+	0xd7, 0x77, 0xe6, 0x1a, 0x85, 0xad, 0x22, 0x19, 0x01, 0xf8, 0x08, 0xe6, 0x4f, 0x04, 0x15, 0x03,
+	0x8e, 0x36, 0x01, 0xf4, 0x57, 0x33, 0xf4, 0x98, 0x1a, 0xbc, 0x44, 0x2c, 0x04, 0x61, 0xa8, 0x1e,
+	0x44, 0x51, 0x18, 0x1d, 0x33, 0xce, 0xe9, 0x79, 0x3c, 0x85, 0x14, 0x86, 0xff, 0x2a, 0xc0, 0xc2,
+	0x03, 0xbf, 0x2b, 0x58, 0xc4, 0xd1, 0x27, 0xb0, 0x7c, 0x44, 0xb9, 0x20, 0xec, 0x4c, 0x8e, 0x46,
+	0x68, 0x70, 0xae, 0xa3, 0x56, 0x76, 0x96, 0xb7, 0xf5, 0x3a, 0x25, 0x38, 0x19, 0xb3, 0x44, 0xf7,
+	0xa0, 0xfa, 0xc0, 0x0f, 0xce, 0x59, 0xd4, 0x8f, 0xfc, 0x40, 0x70, 0x35, 0x5a, 0x65, 0x67, 0xd5,
+	0x78, 0xda, 0x2a, 0x92, 0x32, 0x44, 0x77, 0xa1, 0x72, 0x3a, 0xec, 0x33, 0x33, 0x0b, 0xb5, 0x1c,
+	0x95, 0x1d, 0x14, 0x8f, 0x38, 0xd2, 0x10, 0xdb, 0x4c, 0x0e, 0x77, 0x18, 0xd1, 0xfe, 0x45, 0xec,
+	0x36, 0x97, 0x1a, 0xce, 0x56, 0x91, 0x94, 0x21, 0xbe, 0x03, 0xe5, 0xd1, 0xa4, 0xeb, 0x50, 0x3a,
+	0x11, 0x34, 0x12, 0x8a, 0x67, 0x91, 0x68, 0x01, 0x2d, 0x43, 0xf1, 0x20, 0xf0, 0xd4, 0x4c, 0x8a,
+	0x44, 0x7e, 0xe2, 0x9d, 0x34, 0x39, 0x84, 0xd3, 0xb2, 0x53, 0x68, 0x14, 0xe5, 0xd2, 0xda, 0x18,
+	0xfe, 0x3c, 0xc5, 0x4b, 0xed, 0xea, 0xb0, 0xcf, 0x9a, 0x5d, 0xca, 0xb9, 0xd9, 0xac, 0x11, 0x80,
+	0x10, 0xcc, 0x49, 0x41, 0xad, 0x5a, 0x89, 0xa8, 0x6f, 0xfc, 0x67, 0x21, 0xcd, 0x51, 0xce, 0x76,
+	0x2f, 0xa4, 0x91, 0x67, 0x12, 0x4d, 0x0b, 0x68, 0x1d, 0xe6, 0x4f, 0x2f, 0x22, 0x46, 0x3d, 0xb3,
+	0xc1, 0x46, 0x92, 0x78, 0x8b, 0x46, 0x2c, 0x10, 0x26, 0xc3, 0x8c, 0x24, 0xa3, 0x3c, 0xf9, 0x21,
+	0x60, 0x91, 0x5a, 0xb2, 0x32, 0xd1, 0x82, 0x8a, 0x42, 0xa3, 0x73, 0x26, 0x9c, 0x92, 0x89, 0xa2,
+	0x24, 0x89, 0xef, 0x87, 0x3d, 0xea, 0x07, 0xce, 0xbc, 0xc6, 0xb5, 0x84, 0xde, 0x83, 0xa5, 0xc7,
+	0xe1, 0x3e, 0xe3, 0x1d, 0x16, 0x78, 0x54, 0x2e, 0xc1, 0x42, 0xa3, 0xb0, 0xb5, 0x48, 0xd2, 0xa0,
+	0x1c, 0xeb, 0xc8, 0xef, 0xf9, 0xc2, 0x59, 0x54, 0xbc, 0xb4, 0x20, 0x63, 0x3e, 0x39, 0x3b, 0xe3,
+	0x4c, 0x38, 0x65, 0x05, 0x1b, 0x09, 0x1f, 0xc0, 0x92, 0xae, 0x1d, 0x53, 0x63, 0x32, 0x35, 0xac,
+	0x72, 0x73, 0x0a, 0xa9, 0xd4, 0xb0, 0x34, 0xc4, 0x36, 0xc3, 0xcf, 0xa1, 0x16, 0x87, 0xe1, 0xfd,
+	0x30, 0xe0, 0x0c, 0xdd, 0x8c, 0x6b, 0xc6, 0x84, 0x58, 0x32, 0x21, 0x34, 0x48, 0x8c, 0x32, 0x5b,
+	0xce, 0x57, 0xc6, 0xca, 0x19, 0x87, 0xb0, 0xa4, 0x16, 0x7d, 0xb6, 0x19, 0xa2, 0xad, 0xa4, 0xe8,
+	0x4c, 0x99, 0xd4, 0x92, 0x32, 0x51, 0x28, 0x89, 0xd5, 0xd8, 0x83, 0x5a, 0x3c, 0x60, 0x3e, 0x2e,
+	0x1f, 0xc0, 0xbc, 0x76, 0x74, 0xae, 0x34, 0x8a, 0xaa, 0x32, 0x52, 0xfd, 0x4c, 0xe9, 0x88, 0x31,
+	0xc1, 0x7d, 0xa8, 0xe9, 0xa4, 0xb9, 0x34, 0x5e, 0x17, 0x70, 0x35, 0x19, 0x31, 0x1f, 0xb1, 0x6d,
+	0x58, 0x30, 0x9e, 0x86, 0x59, 0x3d, 0xcd, 0x4c, 0x2b, 0x49, 0x6c, 0x84, 0x03, 0xa8, 0xb6, 0x42,
+	0x2e, 0x2e, 0x8d, 0xd9, 0xf7, 0xb0, 0x64, 0xc6, 0xcb, 0xc7, 0x6b, 0x0b, 0x4a, 0xca, 0xcf, 0xb0,
+	0x42, 0x69, 0x56, 0x52, 0x45, 0xb4, 0x81, 0x64, 0xf4, 0x75, 0x28, 0xd8, 0x65, 0x32, 0x32, 0xe3,
+	0xe5, 0x66, 0xa4, 0xfc, 0x26, 0x33, 0x92, 0x2a, 0xa2, 0x0d, 0x70, 0x0f, 0x2a, 0x8f, 0xd8, 0xf0,
+	0xd2, 0x08, 0xbd, 0x80, 0xaa, 0x1e, 0x2e, 0x1f, 0x9f, 0x9b, 0x30, 0x27, 0xdd, 0x0c, 0x9d, 0x95,
+	0x34, 0x9d, 0x47, 0x6c, 0x48, 0x94, 0x1a, 0x0b, 0x40, 0xa7, 0xd1, 0x80, 0x0b, 0x2e, 0xe8, 0x25,
+	0x6e, 0xd2, 0x8f, 0xb0, 0x9a, 0x1a, 0x35, 0x1f, 0xb5, 0xfb, 0x50, 0xb1, 0xbc, 0x0d, 0x43, 0x27,
+	0x53, 0x58, 0x89, 0x01, 0xb1, 0x8d, 0x71, 0x04, 0x8e, 0x6a, 0x23, 0xa6, 0xe0, 0x9a, 0xe1, 0x20,
+	0x10, 0xb3, 0xb1, 0x6e, 0x40, 0xc5, 0x3a, 0x49, 0xe3, 0x3e, 0x6c, 0x41, 0xf8, 0x19, 0x5c, 0x9b,
+	0x30, 0x66, 0x3e, 0xce, 0x75, 0x28, 0x75, 0xc2, 0x81, 0x89, 0x5f, 0x22, 0x5a, 0xc0, 0xaf, 0x60,
+	0x43, 0x07, 0x55, 0xb5, 0x76, 0x29, 0x64, 0x9e, 0x82, 0x33, 0x3e, 0x64, 0x6e, 0x2e, 0x4d, 0x9b,
+	0x8b, 0x12, 0xf0, 0x6f, 0x45, 0xa8, 0x1f, 0xfb, 0x81, 0x60, 0x5e, 0x33, 0x0c, 0x04, 0x0b, 0x44,
+	0x8b, 0x0e, 0xbb, 0x21, 0xf5, 0xfe, 0x23, 0x93, 0x3c, 0x47, 0x8a, 0xdd, 0xa6, 0x8b, 0x6f, 0xd1,
+	0xa6, 0x47, 0xed, 0x6f, 0xee, 0x0d, 0xed, 0x6f, 0xd4, 0x56, 0x4a, 0x6f, 0x68, 0x2b, 0x49, 0xc1,
+	0xce, 0xff, 0x6b, 0xc1, 0x66, 0x93, 0x7f, 0x21, 0x47, 0xf2, 0xa3, 0x3b, 0x50, 0xde, 0xf5, 0xbc,
+	0x88, 0x71, 0xce, 0xb8, 0xb3, 0xa8, 0x3c, 0xd7, 0xd2, 0x9e, 0x46, 0x4d, 0x46, 0x76, 0xf8, 0x33,
+	0x58, 0x4b, 0x6d, 0x4b, 0xce, 0xdd, 0xc6, 0x3f, 0xc3, 0x7a, 0x33, 0x0c, 0x02, 0xd6, 0x11, 0xa7,
+	0x21, 0x61, 0x3d, 0xc9, 0x78, 0xa6, 0x14, 0xbd, 0x0d, 0x0b, 0x66, 0x72, 0xa6, 0xcb, 0x4c, 0xa1,
+	0x10, 0x5b, 0xe1, 0x2f, 0x60, 0x63, 0x6c, 0x02, 0xf9, 0x28, 0x70, 0xd8, 0x38, 0x19, 0xb4, 0x79,
+	0x27, 0xf2, 0xdb, 0xcc, 0xd3, 0x29, 0x33, 0x5b, 0x72, 0xe2, 0xb1, 0xe7, 0xc7, 0xf8, 0x8d, 0x7c,
+	0x17, 0x9c, 0xec, 0xa0, 0x79, 0xe7, 0xfd, 0x7b, 0x01, 0xa0, 0xe5, 0x07, 0xb3, 0xcd, 0x75, 0x13,
+	0xe0, 0x20, 0x10, 0xbe, 0x18, 0x26, 0x57, 0xfe, 0x32, 0xb1, 0x90, 0x6c, 0xcb, 0x28, 0x8e, 0xb5,
+	0x0c, 0x75, 0xb7, 0xf7, 0x83, 0x80, 0x79, 0xea, 0x12, 0xbf, 0x48, 0x8c, 0x84, 0x5f, 0x40, 0xa5,
+	0xe5, 0x07, 0x79, 0xbb, 0xc7, 0xfb, 0x50, 0xd3, 0xfe, 0xfb, 0xed, 0x13, 0xff, 0x27, 0x76, 0xdc,
+	0x56, 0x73, 0x2a, 0x92, 0x0c, 0x8a, 0xb7, 0x61, 0x6e, 0xaf, 0x1b, 0xb6, 0xe5, 0x63, 0xe5, 0x4b,
+	0xca, 0x2f, 0xcc, 0x33, 0x44, 0x7d, 0x4b, 0x6c, 0x9f, 0x0a, 0xaa, 0x3c, 0xab, 0x44, 0x7d, 0xe3,
+	0x73, 0xa8, 0x4a, 0xfb, 0x19, 0x77, 0xf6, 0x5d, 0x28, 0xa9, 0x28, 0xa6, 0xeb, 0x54, 0x8c, 0xbd,
+	0xc4, 0x88, 0xd6, 0xc8, 0x03, 0x5d, 0x7d, 0xcc, 0x56, 0x06, 0xeb, 0x30, 0x2f, 0xa9, 0xb0, 0x38,
+	0x79, 0x8c, 0x84, 0x9f, 0xc3, 0x92, 0x89, 0x9e, 0x6f, 0x59, 0xdf, 0x62, 0xe2, 0x7f, 0x14, 0x60,
+	0x91, 0xb0, 0xd7, 0x3e, 0xf7, 0xc3, 0x40, 0xa6, 0x85, 0x7c, 0x55, 0x7f, 0xd5, 0xf7, 0xa8, 0x60,
+	0xe6, 0x45, 0x6a, 0x21, 0x72, 0x89, 0xf7, 0x42, 0x6f, 0x68, 0x12, 0x46, 0x7d, 0x4b, 0xec, 0x98,
+	0x09, 0x6a, 0x72, 0x44, 0x7d, 0xa3, 0x0f, 0x61, 0x45, 0x7b, 0xb4, 0xa2, 0x30, 0x3c, 0x7b, 0x72,
+	0xf6, 0x34, 0x8c, 0x5e, 0x9a, 0xc7, 0xde, 0xb8, 0x02, 0x6d, 0xc1, 0x55, 0x0d, 0x9e, 0xf8, 0xe7,
+	0x01, 0x15, 0x83, 0x88, 0x99, 0x17, 0x60, 0x16, 0x96, 0x25, 0x76, 0x14, 0x76, 0x68, 0x77, 0x37,
+	0x8a, 0xfc, 0xd7, 0xb4, 0xab, 0x1e, 0x84, 0x45, 0x92, 0xc2, 0xf0, 0xaf, 0x05, 0x58, 0x8e, 0x09,
+	0xcd, 0xb8, 0x1d, 0x33, 0x57, 0x09, 0xf6, 0x61, 0xc5, 0x9a, 0x4b, 0xbe, 0xcd, 0xbb, 0x05, 0xe5,
+	0xc4, 0xd7, 0x6c, 0xe0, 0xd5, 0x64, 0xc6, 0x1a, 0x27, 0x23, 0x8b, 0x9d, 0xbf, 0x17, 0x01, 0xf6,
+	0x68, 0xe7, 0x25, 0x0b, 0xbc, 0xdd, 0xd6, 0x43, 0x74, 0x00, 0x75, 0x43, 0x25, 0x06, 0xd5, 0x23,
+	0x12, 0xd5, 0x4d, 0x88, 0xd4, 0x33, 0xd7, 0x5d, 0xcb, 0xa0, 0x7a, 0xa6, 0xf8, 0x7f, 0xe8, 0x3e,
+	0x94, 0x0f, 0x99, 0x30, 0x27, 0x6a, 0xec, 0x9b, 0x7a, 0x80, 0xba, 0x6b, 0x19, 0x34, 0xf1, 0xfd,
+	0x14, 0xe0, 0x90, 0x89, 0xf8, 0x78, 0x8d, 0xcd, 0xd2, 0xcf, 0x3c, 0x77, 0x3d, 0x0b, 0x27, 0xee,
+	0xf7, 0x60, 0xf1, 0x90, 0x09, 0x7d, 0xe2, 0xc6, 0xff, 0xaa, 0xd8, 0xef, 0x28, 0xb7, 0x9e, 0x06,
+	0x33, 0x8e, 0xfa, 0x00, 0x8e, 0x1d, 0xed, 0xe7, 0x8a, 0x5b, 0x4f, 0x83, 0x89, 0xe3, 0x5d, 0x58,
+	0x38, 0x64, 0x42, 0x9d, 0xc8, 0x71, 0x6e, 0x58, 0x8f, 0x02, 0x77, 0x35, 0x85, 0x25, 0x5e, 0x0f,
+	0xa1, 0x26, 0x69, 0x5a, 0x47, 0xf2, 0xb5, 0x98, 0xd3, 0xd8, 0x25, 0xdc, 0x75, 0x27, 0xa9, 0x92,
+	0x50, 0xdf, 0x42, 0x3d, 0x5e, 0x6d, 0xfb, 0x5e, 0x89, 0x6e, 0xd8, 0x4b, 0x3c, 0xe1, 0x96, 0xeb,
+	0x36, 0xa6, 0x1b, 0x24, 0xc1, 0x9f, 0xc1, 0x6a, 0xb2, 0x1d, 0xa3, 0x7b, 0x1e, 0xda, 0x4c, 0x6d,
+	0xc0, 0xd8, 0x9d, 0xd3, 0xbd, 0x31, 0x55, 0x9f, 0x44, 0x6e, 0xc1, 0xca, 0x09, 0x0b, 0xbc, 0xd4,
+	0x8d, 0x02, 0xfd, 0xdf, 0xf8, 0x4d, 0xba, 0xfe, 0xb9, 0xd7, 0x27, 0x29, 0xad, 0x88, 0xdf, 0x81,
+	0x2b, 0x23, 0x4e, 0xb9, 0x63, 0xbc, 0x63, 0xbc, 0x27, 0xab, 0xdd, 0xcd, 0x69, 0xea, 0x24, 0xfc,
+	0x73, 0xa8, 0xcb, 0xf0, 0xd9, 0xa3, 0x38, 0x59, 0x8b, 0x29, 0x17, 0x03, 0xf7, 0xc6, 0x14, 0xbd,
+	0x15, 0xfa, 0x63, 0xa8, 0xc9, 0xd0, 0xea, 0x0c, 0xd4, 0xb3, 0x5d, 0x89, 0xd3, 0x34, 0x39, 0xb4,
+	0x5d, 0x34, 0x82, 0x2c, 0xd7, 0x8f, 0xa0, 0x2c, 0x5d, 0x55, 0x5f, 0x4e, 0x12, 0xd7, 0x3e, 0xbe,
+	0xdc, 0xba, 0x0d, 0x8e, 0x65, 0xfc, 0x04, 0xc7, 0x6c, 0xc6, 0x67, 0x1d, 0x9b, 0x50, 0x3d, 0x64,
+	0x22, 0x69, 0x22, 0x68, 0x23, 0xd3, 0x60, 0x92, 0x00, 0xce, 0xb8, 0x22, 0x0e, 0xb2, 0xe7, 0x7e,
+	0xe3, 0x50, 0x26, 0x2e, 0x58, 0x74, 0xab, 0x13, 0x46, 0xec, 0xb6, 0xbe, 0xb2, 0xe9, 0xff, 0xb5,
+	0xdb, 0xf3, 0x4a, 0xba, 0xf3, 0xcf, 0x00, 0x9f, 0xb1, 0x37, 0x03, 0xed, 0x16, 0x00, 0x00,
 }
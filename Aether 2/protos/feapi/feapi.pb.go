@@ -51,6 +51,34 @@ It has these top-level messages:
 	FEConfigChangesResponse
 	BoardReportsRequest
 	BoardReportsResponse
+	PinSignalRequest
+	PinSignalResponse
+	Attachment
+	AttachmentUploadRequest
+	AttachmentUploadResponse
+	AttachmentRequest
+	AttachmentResponse
+	KeystoreUnlockRequest
+	KeystoreUnlockResponse
+	UserKeyRotationRequest
+	UserKeyRotationResponse
+	IdentityExportRequest
+	IdentityExportResponse
+	IdentityImportRequest
+	IdentityImportResponse
+	KeystoreStatusRequest
+	KeystoreStatusResponse
+	RevisionsRequest
+	DiffLine
+	RevisionEntry
+	RevisionsResponse
+	Draft
+	DraftPayload
+	DraftResponse
+	DraftsRequest
+	DraftsResponse
+	DraftDeleteRequest
+	DraftDeleteResponse
 */
 package feapi
 
@@ -678,6 +706,10 @@ type ContentEventPayload struct {
 	ThreadData *structprotos.Thread `protobuf:"bytes,3,opt,name=ThreadData" json:"ThreadData,omitempty"`
 	PostData   *structprotos.Post   `protobuf:"bytes,4,opt,name=PostData" json:"PostData,omitempty"`
 	KeyData    *structprotos.Key    `protobuf:"bytes,5,opt,name=KeyData" json:"KeyData,omitempty"`
+	// Threads and posts only. If this is in the future, the content waits in the inflights until then, and it's minted and sent at that time.
+	ScheduledFor int64 `protobuf:"varint,6,opt,name=ScheduledFor" json:"ScheduledFor,omitempty"`
+	// The draft this content was written in, if any. It's deleted once the content is queued.
+	DraftId string `protobuf:"bytes,7,opt,name=DraftId" json:"DraftId,omitempty"`
 }
 
 func (m *ContentEventPayload) Reset()                    { *m = ContentEventPayload{} }
@@ -720,6 +752,20 @@ func (m *ContentEventPayload) GetKeyData() *structprotos.Key {
 	return nil
 }
 
+func (m *ContentEventPayload) GetScheduledFor() int64 {
+	if m != nil {
+		return m.ScheduledFor
+	}
+	return 0
+}
+
+func (m *ContentEventPayload) GetDraftId() string {
+	if m != nil {
+		return m.DraftId
+	}
+	return ""
+}
+
 type ContentEventResponse struct {
 }
 
@@ -1197,11 +1243,818 @@ func (m *BoardReportsResponse) String() string            { return proto.Compact
 func (*BoardReportsResponse) ProtoMessage()               {}
 func (*BoardReportsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
-func (m *BoardReportsResponse) GetReportsTabEntries() []*feobjects.ReportsTabEntry {
+func (m *BoardReportsResponse) GetReportsTabEntries() []*feobjects.ReportsTabEntry {
+	if m != nil {
+		return m.ReportsTabEntries
+	}
+	return nil
+}
+
+type PinSignalRequest struct {
+	EntityType  string `protobuf:"bytes,1,opt,name=EntityType" json:"EntityType,omitempty"`
+	Fingerprint string `protobuf:"bytes,2,opt,name=Fingerprint" json:"Fingerprint,omitempty"`
+	Pinned      bool   `protobuf:"varint,3,opt,name=Pinned" json:"Pinned,omitempty"`
+}
+
+func (m *PinSignalRequest) Reset()                    { *m = PinSignalRequest{} }
+func (m *PinSignalRequest) String() string            { return proto.CompactTextString(m) }
+func (*PinSignalRequest) ProtoMessage()               {}
+func (*PinSignalRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *PinSignalRequest) GetEntityType() string {
+	if m != nil {
+		return m.EntityType
+	}
+	return ""
+}
+
+func (m *PinSignalRequest) GetFingerprint() string {
+	if m != nil {
+		return m.Fingerprint
+	}
+	return ""
+}
+
+func (m *PinSignalRequest) GetPinned() bool {
+	if m != nil {
+		return m.Pinned
+	}
+	return false
+}
+
+type PinSignalResponse struct {
+	Committed bool `protobuf:"varint,1,opt,name=Committed" json:"Committed,omitempty"`
+}
+
+func (m *PinSignalResponse) Reset()                    { *m = PinSignalResponse{} }
+func (m *PinSignalResponse) String() string            { return proto.CompactTextString(m) }
+func (*PinSignalResponse) ProtoMessage()               {}
+func (*PinSignalResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *PinSignalResponse) GetCommitted() bool {
+	if m != nil {
+		return m.Committed
+	}
+	return false
+}
+
+type Attachment struct {
+	Hash     string   `protobuf:"bytes,1,opt,name=Hash" json:"Hash,omitempty"`
+	Name     string   `protobuf:"bytes,2,opt,name=Name" json:"Name,omitempty"`
+	MimeType string   `protobuf:"bytes,3,opt,name=MimeType" json:"MimeType,omitempty"`
+	Size     int64    `protobuf:"varint,4,opt,name=Size" json:"Size,omitempty"`
+	Chunks   []string `protobuf:"bytes,5,rep,name=Chunks" json:"Chunks,omitempty"`
+}
+
+func (m *Attachment) Reset()                    { *m = Attachment{} }
+func (m *Attachment) String() string            { return proto.CompactTextString(m) }
+func (*Attachment) ProtoMessage()               {}
+func (*Attachment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *Attachment) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *Attachment) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Attachment) GetMimeType() string {
+	if m != nil {
+		return m.MimeType
+	}
+	return ""
+}
+
+func (m *Attachment) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Attachment) GetChunks() []string {
+	if m != nil {
+		return m.Chunks
+	}
+	return nil
+}
+
+type AttachmentUploadRequest struct {
+	Name     string `protobuf:"bytes,1,opt,name=Name" json:"Name,omitempty"`
+	MimeType string `protobuf:"bytes,2,opt,name=MimeType" json:"MimeType,omitempty"`
+	Data     []byte `protobuf:"bytes,3,opt,name=Data" json:"Data,omitempty"`
+}
+
+func (m *AttachmentUploadRequest) Reset()                    { *m = AttachmentUploadRequest{} }
+func (m *AttachmentUploadRequest) String() string            { return proto.CompactTextString(m) }
+func (*AttachmentUploadRequest) ProtoMessage()               {}
+func (*AttachmentUploadRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *AttachmentUploadRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AttachmentUploadRequest) GetMimeType() string {
+	if m != nil {
+		return m.MimeType
+	}
+	return ""
+}
+
+func (m *AttachmentUploadRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type AttachmentUploadResponse struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=Attachment" json:"Attachment,omitempty"`
+	Error      string      `protobuf:"bytes,2,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *AttachmentUploadResponse) Reset()                    { *m = AttachmentUploadResponse{} }
+func (m *AttachmentUploadResponse) String() string            { return proto.CompactTextString(m) }
+func (*AttachmentUploadResponse) ProtoMessage()               {}
+func (*AttachmentUploadResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *AttachmentUploadResponse) GetAttachment() *Attachment {
+	if m != nil {
+		return m.Attachment
+	}
+	return nil
+}
+
+func (m *AttachmentUploadResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type AttachmentRequest struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=Attachment" json:"Attachment,omitempty"`
+}
+
+func (m *AttachmentRequest) Reset()                    { *m = AttachmentRequest{} }
+func (m *AttachmentRequest) String() string            { return proto.CompactTextString(m) }
+func (*AttachmentRequest) ProtoMessage()               {}
+func (*AttachmentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *AttachmentRequest) GetAttachment() *Attachment {
+	if m != nil {
+		return m.Attachment
+	}
+	return nil
+}
+
+type AttachmentResponse struct {
+	Available bool   `protobuf:"varint,1,opt,name=Available" json:"Available,omitempty"`
+	Data      []byte `protobuf:"bytes,2,opt,name=Data" json:"Data,omitempty"`
+}
+
+func (m *AttachmentResponse) Reset()                    { *m = AttachmentResponse{} }
+func (m *AttachmentResponse) String() string            { return proto.CompactTextString(m) }
+func (*AttachmentResponse) ProtoMessage()               {}
+func (*AttachmentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *AttachmentResponse) GetAvailable() bool {
+	if m != nil {
+		return m.Available
+	}
+	return false
+}
+
+func (m *AttachmentResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type KeystoreUnlockRequest struct {
+	// If the keystore is not encrypted yet, this passphrase encrypts it.
+	Passphrase string `protobuf:"bytes,1,opt,name=Passphrase" json:"Passphrase,omitempty"`
+}
+
+func (m *KeystoreUnlockRequest) Reset()                    { *m = KeystoreUnlockRequest{} }
+func (m *KeystoreUnlockRequest) String() string            { return proto.CompactTextString(m) }
+func (*KeystoreUnlockRequest) ProtoMessage()               {}
+func (*KeystoreUnlockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *KeystoreUnlockRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+type KeystoreUnlockResponse struct {
+	Unlocked bool `protobuf:"varint,1,opt,name=Unlocked" json:"Unlocked,omitempty"`
+	// True if the keys were not encrypted before, and have been encrypted with the given passphrase.
+	Migrated bool   `protobuf:"varint,2,opt,name=Migrated" json:"Migrated,omitempty"`
+	Error    string `protobuf:"bytes,3,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *KeystoreUnlockResponse) Reset()                    { *m = KeystoreUnlockResponse{} }
+func (m *KeystoreUnlockResponse) String() string            { return proto.CompactTextString(m) }
+func (*KeystoreUnlockResponse) ProtoMessage()               {}
+func (*KeystoreUnlockResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *KeystoreUnlockResponse) GetUnlocked() bool {
+	if m != nil {
+		return m.Unlocked
+	}
+	return false
+}
+
+func (m *KeystoreUnlockResponse) GetMigrated() bool {
+	if m != nil {
+		return m.Migrated
+	}
+	return false
+}
+
+func (m *KeystoreUnlockResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type UserKeyRotationRequest struct {
+	// If true, the old key is marked as compromised, not just retired.
+	Revoke bool `protobuf:"varint,1,opt,name=Revoke" json:"Revoke,omitempty"`
+}
+
+func (m *UserKeyRotationRequest) Reset()                    { *m = UserKeyRotationRequest{} }
+func (m *UserKeyRotationRequest) String() string            { return proto.CompactTextString(m) }
+func (*UserKeyRotationRequest) ProtoMessage()               {}
+func (*UserKeyRotationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *UserKeyRotationRequest) GetRevoke() bool {
+	if m != nil {
+		return m.Revoke
+	}
+	return false
+}
+
+type UserKeyRotationResponse struct {
+	Rotated        bool   `protobuf:"varint,1,opt,name=Rotated" json:"Rotated,omitempty"`
+	NewFingerprint string `protobuf:"bytes,2,opt,name=NewFingerprint" json:"NewFingerprint,omitempty"`
+	Error          string `protobuf:"bytes,3,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *UserKeyRotationResponse) Reset()                    { *m = UserKeyRotationResponse{} }
+func (m *UserKeyRotationResponse) String() string            { return proto.CompactTextString(m) }
+func (*UserKeyRotationResponse) ProtoMessage()               {}
+func (*UserKeyRotationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *UserKeyRotationResponse) GetRotated() bool {
+	if m != nil {
+		return m.Rotated
+	}
+	return false
+}
+
+func (m *UserKeyRotationResponse) GetNewFingerprint() string {
+	if m != nil {
+		return m.NewFingerprint
+	}
+	return ""
+}
+
+func (m *UserKeyRotationResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type IdentityExportRequest struct {
+	Path string `protobuf:"bytes,1,opt,name=Path" json:"Path,omitempty"`
+	// The backup is sealed with this. It is independent from the keystore passphrase.
+	Passphrase string `protobuf:"bytes,2,opt,name=Passphrase" json:"Passphrase,omitempty"`
+}
+
+func (m *IdentityExportRequest) Reset()                    { *m = IdentityExportRequest{} }
+func (m *IdentityExportRequest) String() string            { return proto.CompactTextString(m) }
+func (*IdentityExportRequest) ProtoMessage()               {}
+func (*IdentityExportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *IdentityExportRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *IdentityExportRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+type IdentityExportResponse struct {
+	Exported bool   `protobuf:"varint,1,opt,name=Exported" json:"Exported,omitempty"`
+	Error    string `protobuf:"bytes,2,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *IdentityExportResponse) Reset()                    { *m = IdentityExportResponse{} }
+func (m *IdentityExportResponse) String() string            { return proto.CompactTextString(m) }
+func (*IdentityExportResponse) ProtoMessage()               {}
+func (*IdentityExportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *IdentityExportResponse) GetExported() bool {
+	if m != nil {
+		return m.Exported
+	}
+	return false
+}
+
+func (m *IdentityExportResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type IdentityImportRequest struct {
+	Path       string `protobuf:"bytes,1,opt,name=Path" json:"Path,omitempty"`
+	Passphrase string `protobuf:"bytes,2,opt,name=Passphrase" json:"Passphrase,omitempty"`
+	// What to do if this install already has a different user: "fail" (default), "replace" or "keep".
+	ConflictResolution string `protobuf:"bytes,3,opt,name=ConflictResolution" json:"ConflictResolution,omitempty"`
+}
+
+func (m *IdentityImportRequest) Reset()                    { *m = IdentityImportRequest{} }
+func (m *IdentityImportRequest) String() string            { return proto.CompactTextString(m) }
+func (*IdentityImportRequest) ProtoMessage()               {}
+func (*IdentityImportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *IdentityImportRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *IdentityImportRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+func (m *IdentityImportRequest) GetConflictResolution() string {
+	if m != nil {
+		return m.ConflictResolution
+	}
+	return ""
+}
+
+type IdentityImportResponse struct {
+	Imported           bool   `protobuf:"varint,1,opt,name=Imported" json:"Imported,omitempty"`
+	Fingerprint        string `protobuf:"bytes,2,opt,name=Fingerprint" json:"Fingerprint,omitempty"`
+	Conflict           bool   `protobuf:"varint,3,opt,name=Conflict" json:"Conflict,omitempty"`
+	Replaced           bool   `protobuf:"varint,4,opt,name=Replaced" json:"Replaced,omitempty"`
+	ReplacedBackupPath string `protobuf:"bytes,5,opt,name=ReplacedBackupPath" json:"ReplacedBackupPath,omitempty"`
+	Error              string `protobuf:"bytes,6,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *IdentityImportResponse) Reset()                    { *m = IdentityImportResponse{} }
+func (m *IdentityImportResponse) String() string            { return proto.CompactTextString(m) }
+func (*IdentityImportResponse) ProtoMessage()               {}
+func (*IdentityImportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *IdentityImportResponse) GetImported() bool {
+	if m != nil {
+		return m.Imported
+	}
+	return false
+}
+
+func (m *IdentityImportResponse) GetFingerprint() string {
+	if m != nil {
+		return m.Fingerprint
+	}
+	return ""
+}
+
+func (m *IdentityImportResponse) GetConflict() bool {
+	if m != nil {
+		return m.Conflict
+	}
+	return false
+}
+
+func (m *IdentityImportResponse) GetReplaced() bool {
+	if m != nil {
+		return m.Replaced
+	}
+	return false
+}
+
+func (m *IdentityImportResponse) GetReplacedBackupPath() string {
+	if m != nil {
+		return m.ReplacedBackupPath
+	}
+	return ""
+}
+
+func (m *IdentityImportResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type KeystoreStatusRequest struct {
+}
+
+func (m *KeystoreStatusRequest) Reset()                    { *m = KeystoreStatusRequest{} }
+func (m *KeystoreStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*KeystoreStatusRequest) ProtoMessage()               {}
+func (*KeystoreStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+type KeystoreStatusResponse struct {
+	Encrypted bool `protobuf:"varint,1,opt,name=Encrypted" json:"Encrypted,omitempty"`
+	// If locked, the frontend waits for UnlockKeystore before starting the backend.
+	Locked bool `protobuf:"varint,2,opt,name=Locked" json:"Locked,omitempty"`
+}
+
+func (m *KeystoreStatusResponse) Reset()                    { *m = KeystoreStatusResponse{} }
+func (m *KeystoreStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*KeystoreStatusResponse) ProtoMessage()               {}
+func (*KeystoreStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *KeystoreStatusResponse) GetEncrypted() bool {
+	if m != nil {
+		return m.Encrypted
+	}
+	return false
+}
+
+func (m *KeystoreStatusResponse) GetLocked() bool {
+	if m != nil {
+		return m.Locked
+	}
+	return false
+}
+
+type RevisionsRequest struct {
+	EntityType  string `protobuf:"bytes,1,opt,name=EntityType" json:"EntityType,omitempty"`
+	Fingerprint string `protobuf:"bytes,2,opt,name=Fingerprint" json:"Fingerprint,omitempty"`
+}
+
+func (m *RevisionsRequest) Reset()                    { *m = RevisionsRequest{} }
+func (m *RevisionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevisionsRequest) ProtoMessage()               {}
+func (*RevisionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *RevisionsRequest) GetEntityType() string {
+	if m != nil {
+		return m.EntityType
+	}
+	return ""
+}
+
+func (m *RevisionsRequest) GetFingerprint() string {
+	if m != nil {
+		return m.Fingerprint
+	}
+	return ""
+}
+
+type DiffLine struct {
+	Op   string `protobuf:"bytes,1,opt,name=Op" json:"Op,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=Text" json:"Text,omitempty"`
+}
+
+func (m *DiffLine) Reset()                    { *m = DiffLine{} }
+func (m *DiffLine) String() string            { return proto.CompactTextString(m) }
+func (*DiffLine) ProtoMessage()               {}
+func (*DiffLine) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *DiffLine) GetOp() string {
+	if m != nil {
+		return m.Op
+	}
+	return ""
+}
+
+func (m *DiffLine) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+type RevisionEntry struct {
+	LastUpdate        int64       `protobuf:"varint,1,opt,name=LastUpdate" json:"LastUpdate,omitempty"`
+	Body              string      `protobuf:"bytes,2,opt,name=Body" json:"Body,omitempty"`
+	Meta              string      `protobuf:"bytes,3,opt,name=Meta" json:"Meta,omitempty"`
+	UpdateProofOfWork string      `protobuf:"bytes,4,opt,name=UpdateProofOfWork" json:"UpdateProofOfWork,omitempty"`
+	UpdateSignature   string      `protobuf:"bytes,5,opt,name=UpdateSignature" json:"UpdateSignature,omitempty"`
+	BodyDiff          []*DiffLine `protobuf:"bytes,6,rep,name=BodyDiff" json:"BodyDiff,omitempty"`
+}
+
+func (m *RevisionEntry) Reset()                    { *m = RevisionEntry{} }
+func (m *RevisionEntry) String() string            { return proto.CompactTextString(m) }
+func (*RevisionEntry) ProtoMessage()               {}
+func (*RevisionEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *RevisionEntry) GetLastUpdate() int64 {
+	if m != nil {
+		return m.LastUpdate
+	}
+	return 0
+}
+
+func (m *RevisionEntry) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *RevisionEntry) GetMeta() string {
+	if m != nil {
+		return m.Meta
+	}
+	return ""
+}
+
+func (m *RevisionEntry) GetUpdateProofOfWork() string {
+	if m != nil {
+		return m.UpdateProofOfWork
+	}
+	return ""
+}
+
+func (m *RevisionEntry) GetUpdateSignature() string {
+	if m != nil {
+		return m.UpdateSignature
+	}
+	return ""
+}
+
+func (m *RevisionEntry) GetBodyDiff() []*DiffLine {
+	if m != nil {
+		return m.BodyDiff
+	}
+	return nil
+}
+
+type RevisionsResponse struct {
+	Revisions []*RevisionEntry `protobuf:"bytes,1,rep,name=Revisions" json:"Revisions,omitempty"`
+	Error     string           `protobuf:"bytes,2,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *RevisionsResponse) Reset()                    { *m = RevisionsResponse{} }
+func (m *RevisionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*RevisionsResponse) ProtoMessage()               {}
+func (*RevisionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *RevisionsResponse) GetRevisions() []*RevisionEntry {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+func (m *RevisionsResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type Draft struct {
+	Id               string `protobuf:"bytes,1,opt,name=Id" json:"Id,omitempty"`
+	EntityType       string `protobuf:"bytes,2,opt,name=EntityType" json:"EntityType,omitempty"`
+	Board            string `protobuf:"bytes,3,opt,name=Board" json:"Board,omitempty"`
+	Thread           string `protobuf:"bytes,4,opt,name=Thread" json:"Thread,omitempty"`
+	Parent           string `protobuf:"bytes,5,opt,name=Parent" json:"Parent,omitempty"`
+	PriorFingerprint string `protobuf:"bytes,6,opt,name=PriorFingerprint" json:"PriorFingerprint,omitempty"`
+	Name             string `protobuf:"bytes,7,opt,name=Name" json:"Name,omitempty"`
+	Body             string `protobuf:"bytes,8,opt,name=Body" json:"Body,omitempty"`
+	Link             string `protobuf:"bytes,9,opt,name=Link" json:"Link,omitempty"`
+	Meta             string `protobuf:"bytes,10,opt,name=Meta" json:"Meta,omitempty"`
+	Creation         int64  `protobuf:"varint,11,opt,name=Creation" json:"Creation,omitempty"`
+	LastSaved        int64  `protobuf:"varint,12,opt,name=LastSaved" json:"LastSaved,omitempty"`
+}
+
+func (m *Draft) Reset()                    { *m = Draft{} }
+func (m *Draft) String() string            { return proto.CompactTextString(m) }
+func (*Draft) ProtoMessage()               {}
+func (*Draft) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *Draft) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Draft) GetEntityType() string {
+	if m != nil {
+		return m.EntityType
+	}
+	return ""
+}
+
+func (m *Draft) GetBoard() string {
+	if m != nil {
+		return m.Board
+	}
+	return ""
+}
+
+func (m *Draft) GetThread() string {
+	if m != nil {
+		return m.Thread
+	}
+	return ""
+}
+
+func (m *Draft) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *Draft) GetPriorFingerprint() string {
+	if m != nil {
+		return m.PriorFingerprint
+	}
+	return ""
+}
+
+func (m *Draft) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Draft) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *Draft) GetLink() string {
+	if m != nil {
+		return m.Link
+	}
+	return ""
+}
+
+func (m *Draft) GetMeta() string {
+	if m != nil {
+		return m.Meta
+	}
+	return ""
+}
+
+func (m *Draft) GetCreation() int64 {
+	if m != nil {
+		return m.Creation
+	}
+	return 0
+}
+
+func (m *Draft) GetLastSaved() int64 {
+	if m != nil {
+		return m.LastSaved
+	}
+	return 0
+}
+
+type DraftPayload struct {
+	Draft *Draft `protobuf:"bytes,1,opt,name=Draft" json:"Draft,omitempty"`
+}
+
+func (m *DraftPayload) Reset()                    { *m = DraftPayload{} }
+func (m *DraftPayload) String() string            { return proto.CompactTextString(m) }
+func (*DraftPayload) ProtoMessage()               {}
+func (*DraftPayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *DraftPayload) GetDraft() *Draft {
+	if m != nil {
+		return m.Draft
+	}
+	return nil
+}
+
+type DraftResponse struct {
+	Draft *Draft `protobuf:"bytes,1,opt,name=Draft" json:"Draft,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=Error" json:"Error,omitempty"`
+}
+
+func (m *DraftResponse) Reset()                    { *m = DraftResponse{} }
+func (m *DraftResponse) String() string            { return proto.CompactTextString(m) }
+func (*DraftResponse) ProtoMessage()               {}
+func (*DraftResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *DraftResponse) GetDraft() *Draft {
+	if m != nil {
+		return m.Draft
+	}
+	return nil
+}
+
+func (m *DraftResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DraftsRequest struct {
+	// The drafts in the thread, or if not given, in the board. If neither is given, all drafts.
+	Board  string `protobuf:"bytes,1,opt,name=Board" json:"Board,omitempty"`
+	Thread string `protobuf:"bytes,2,opt,name=Thread" json:"Thread,omitempty"`
+}
+
+func (m *DraftsRequest) Reset()                    { *m = DraftsRequest{} }
+func (m *DraftsRequest) String() string            { return proto.CompactTextString(m) }
+func (*DraftsRequest) ProtoMessage()               {}
+func (*DraftsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *DraftsRequest) GetBoard() string {
+	if m != nil {
+		return m.Board
+	}
+	return ""
+}
+
+func (m *DraftsRequest) GetThread() string {
+	if m != nil {
+		return m.Thread
+	}
+	return ""
+}
+
+type DraftsResponse struct {
+	Drafts []*Draft `protobuf:"bytes,1,rep,name=Drafts" json:"Drafts,omitempty"`
+}
+
+func (m *DraftsResponse) Reset()                    { *m = DraftsResponse{} }
+func (m *DraftsResponse) String() string            { return proto.CompactTextString(m) }
+func (*DraftsResponse) ProtoMessage()               {}
+func (*DraftsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *DraftsResponse) GetDrafts() []*Draft {
+	if m != nil {
+		return m.Drafts
+	}
+	return nil
+}
+
+type DraftDeleteRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=Id" json:"Id,omitempty"`
+}
+
+func (m *DraftDeleteRequest) Reset()                    { *m = DraftDeleteRequest{} }
+func (m *DraftDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*DraftDeleteRequest) ProtoMessage()               {}
+func (*DraftDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *DraftDeleteRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DraftDeleteResponse struct {
+	Deleted bool `protobuf:"varint,1,opt,name=Deleted" json:"Deleted,omitempty"`
+}
+
+func (m *DraftDeleteResponse) Reset()                    { *m = DraftDeleteResponse{} }
+func (m *DraftDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*DraftDeleteResponse) ProtoMessage()               {}
+func (*DraftDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+func (m *DraftDeleteResponse) GetDeleted() bool {
 	if m != nil {
-		return m.ReportsTabEntries
+		return m.Deleted
 	}
-	return nil
+	return false
 }
 
 func init() {
@@ -1248,6 +2101,34 @@ func init() {
 	proto.RegisterType((*FEConfigChangesResponse)(nil), "feapi.FEConfigChangesResponse")
 	proto.RegisterType((*BoardReportsRequest)(nil), "feapi.BoardReportsRequest")
 	proto.RegisterType((*BoardReportsResponse)(nil), "feapi.BoardReportsResponse")
+	proto.RegisterType((*PinSignalRequest)(nil), "feapi.PinSignalRequest")
+	proto.RegisterType((*PinSignalResponse)(nil), "feapi.PinSignalResponse")
+	proto.RegisterType((*Attachment)(nil), "feapi.Attachment")
+	proto.RegisterType((*AttachmentUploadRequest)(nil), "feapi.AttachmentUploadRequest")
+	proto.RegisterType((*AttachmentUploadResponse)(nil), "feapi.AttachmentUploadResponse")
+	proto.RegisterType((*AttachmentRequest)(nil), "feapi.AttachmentRequest")
+	proto.RegisterType((*AttachmentResponse)(nil), "feapi.AttachmentResponse")
+	proto.RegisterType((*KeystoreUnlockRequest)(nil), "feapi.KeystoreUnlockRequest")
+	proto.RegisterType((*KeystoreUnlockResponse)(nil), "feapi.KeystoreUnlockResponse")
+	proto.RegisterType((*UserKeyRotationRequest)(nil), "feapi.UserKeyRotationRequest")
+	proto.RegisterType((*UserKeyRotationResponse)(nil), "feapi.UserKeyRotationResponse")
+	proto.RegisterType((*IdentityExportRequest)(nil), "feapi.IdentityExportRequest")
+	proto.RegisterType((*IdentityExportResponse)(nil), "feapi.IdentityExportResponse")
+	proto.RegisterType((*IdentityImportRequest)(nil), "feapi.IdentityImportRequest")
+	proto.RegisterType((*IdentityImportResponse)(nil), "feapi.IdentityImportResponse")
+	proto.RegisterType((*KeystoreStatusRequest)(nil), "feapi.KeystoreStatusRequest")
+	proto.RegisterType((*KeystoreStatusResponse)(nil), "feapi.KeystoreStatusResponse")
+	proto.RegisterType((*RevisionsRequest)(nil), "feapi.RevisionsRequest")
+	proto.RegisterType((*DiffLine)(nil), "feapi.DiffLine")
+	proto.RegisterType((*RevisionEntry)(nil), "feapi.RevisionEntry")
+	proto.RegisterType((*RevisionsResponse)(nil), "feapi.RevisionsResponse")
+	proto.RegisterType((*Draft)(nil), "feapi.Draft")
+	proto.RegisterType((*DraftPayload)(nil), "feapi.DraftPayload")
+	proto.RegisterType((*DraftResponse)(nil), "feapi.DraftResponse")
+	proto.RegisterType((*DraftsRequest)(nil), "feapi.DraftsRequest")
+	proto.RegisterType((*DraftsResponse)(nil), "feapi.DraftsResponse")
+	proto.RegisterType((*DraftDeleteRequest)(nil), "feapi.DraftDeleteRequest")
+	proto.RegisterType((*DraftDeleteResponse)(nil), "feapi.DraftDeleteResponse")
 	proto.RegisterEnum("feapi.EventType", EventType_name, EventType_value)
 	proto.RegisterEnum("feapi.SignalTargetType", SignalTargetType_name, SignalTargetType_value)
 	proto.RegisterEnum("feapi.SignalTypeClass", SignalTypeClass_name, SignalTypeClass_value)
@@ -1288,6 +2169,18 @@ type FrontendAPIClient interface {
 	SendAddress(ctx context.Context, in *SendAddressPayload, opts ...grpc.CallOption) (*SendAddressResponse, error)
 	SendFEConfigChanges(ctx context.Context, in *FEConfigChangesPayload, opts ...grpc.CallOption) (*FEConfigChangesResponse, error)
 	RequestBoardReports(ctx context.Context, in *BoardReportsRequest, opts ...grpc.CallOption) (*BoardReportsResponse, error)
+	SetPinSignal(ctx context.Context, in *PinSignalRequest, opts ...grpc.CallOption) (*PinSignalResponse, error)
+	SendAttachment(ctx context.Context, in *AttachmentUploadRequest, opts ...grpc.CallOption) (*AttachmentUploadResponse, error)
+	GetAttachment(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (*AttachmentResponse, error)
+	UnlockKeystore(ctx context.Context, in *KeystoreUnlockRequest, opts ...grpc.CallOption) (*KeystoreUnlockResponse, error)
+	GetKeystoreStatus(ctx context.Context, in *KeystoreStatusRequest, opts ...grpc.CallOption) (*KeystoreStatusResponse, error)
+	RotateUserKey(ctx context.Context, in *UserKeyRotationRequest, opts ...grpc.CallOption) (*UserKeyRotationResponse, error)
+	ExportIdentity(ctx context.Context, in *IdentityExportRequest, opts ...grpc.CallOption) (*IdentityExportResponse, error)
+	ImportIdentity(ctx context.Context, in *IdentityImportRequest, opts ...grpc.CallOption) (*IdentityImportResponse, error)
+	GetRevisions(ctx context.Context, in *RevisionsRequest, opts ...grpc.CallOption) (*RevisionsResponse, error)
+	SaveDraft(ctx context.Context, in *DraftPayload, opts ...grpc.CallOption) (*DraftResponse, error)
+	GetDrafts(ctx context.Context, in *DraftsRequest, opts ...grpc.CallOption) (*DraftsResponse, error)
+	DeleteDraft(ctx context.Context, in *DraftDeleteRequest, opts ...grpc.CallOption) (*DraftDeleteResponse, error)
 	// ----------  Methods used by backend  ----------
 	BackendReady(ctx context.Context, in *BEReadyRequest, opts ...grpc.CallOption) (*BEReadyResponse, error)
 	SendBackendAmbientStatus(ctx context.Context, in *BackendAmbientStatusPayload, opts ...grpc.CallOption) (*BackendAmbientStatusResponse, error)
@@ -1472,6 +2365,114 @@ func (c *frontendAPIClient) RequestBoardReports(ctx context.Context, in *BoardRe
 	return out, nil
 }
 
+func (c *frontendAPIClient) SetPinSignal(ctx context.Context, in *PinSignalRequest, opts ...grpc.CallOption) (*PinSignalResponse, error) {
+	out := new(PinSignalResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/SetPinSignal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) SendAttachment(ctx context.Context, in *AttachmentUploadRequest, opts ...grpc.CallOption) (*AttachmentUploadResponse, error) {
+	out := new(AttachmentUploadResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/SendAttachment", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) GetAttachment(ctx context.Context, in *AttachmentRequest, opts ...grpc.CallOption) (*AttachmentResponse, error) {
+	out := new(AttachmentResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/GetAttachment", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) UnlockKeystore(ctx context.Context, in *KeystoreUnlockRequest, opts ...grpc.CallOption) (*KeystoreUnlockResponse, error) {
+	out := new(KeystoreUnlockResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/UnlockKeystore", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) GetKeystoreStatus(ctx context.Context, in *KeystoreStatusRequest, opts ...grpc.CallOption) (*KeystoreStatusResponse, error) {
+	out := new(KeystoreStatusResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/GetKeystoreStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) RotateUserKey(ctx context.Context, in *UserKeyRotationRequest, opts ...grpc.CallOption) (*UserKeyRotationResponse, error) {
+	out := new(UserKeyRotationResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/RotateUserKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) ExportIdentity(ctx context.Context, in *IdentityExportRequest, opts ...grpc.CallOption) (*IdentityExportResponse, error) {
+	out := new(IdentityExportResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/ExportIdentity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) ImportIdentity(ctx context.Context, in *IdentityImportRequest, opts ...grpc.CallOption) (*IdentityImportResponse, error) {
+	out := new(IdentityImportResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/ImportIdentity", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) GetRevisions(ctx context.Context, in *RevisionsRequest, opts ...grpc.CallOption) (*RevisionsResponse, error) {
+	out := new(RevisionsResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/GetRevisions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) SaveDraft(ctx context.Context, in *DraftPayload, opts ...grpc.CallOption) (*DraftResponse, error) {
+	out := new(DraftResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/SaveDraft", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) GetDrafts(ctx context.Context, in *DraftsRequest, opts ...grpc.CallOption) (*DraftsResponse, error) {
+	out := new(DraftsResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/GetDrafts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendAPIClient) DeleteDraft(ctx context.Context, in *DraftDeleteRequest, opts ...grpc.CallOption) (*DraftDeleteResponse, error) {
+	out := new(DraftDeleteResponse)
+	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/DeleteDraft", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *frontendAPIClient) BackendReady(ctx context.Context, in *BEReadyRequest, opts ...grpc.CallOption) (*BEReadyResponse, error) {
 	out := new(BEReadyResponse)
 	err := grpc.Invoke(ctx, "/feapi.FrontendAPI/BackendReady", in, out, c.cc, opts...)
@@ -1515,6 +2516,18 @@ type FrontendAPIServer interface {
 	SendAddress(context.Context, *SendAddressPayload) (*SendAddressResponse, error)
 	SendFEConfigChanges(context.Context, *FEConfigChangesPayload) (*FEConfigChangesResponse, error)
 	RequestBoardReports(context.Context, *BoardReportsRequest) (*BoardReportsResponse, error)
+	SetPinSignal(context.Context, *PinSignalRequest) (*PinSignalResponse, error)
+	SendAttachment(context.Context, *AttachmentUploadRequest) (*AttachmentUploadResponse, error)
+	GetAttachment(context.Context, *AttachmentRequest) (*AttachmentResponse, error)
+	UnlockKeystore(context.Context, *KeystoreUnlockRequest) (*KeystoreUnlockResponse, error)
+	GetKeystoreStatus(context.Context, *KeystoreStatusRequest) (*KeystoreStatusResponse, error)
+	RotateUserKey(context.Context, *UserKeyRotationRequest) (*UserKeyRotationResponse, error)
+	ExportIdentity(context.Context, *IdentityExportRequest) (*IdentityExportResponse, error)
+	ImportIdentity(context.Context, *IdentityImportRequest) (*IdentityImportResponse, error)
+	GetRevisions(context.Context, *RevisionsRequest) (*RevisionsResponse, error)
+	SaveDraft(context.Context, *DraftPayload) (*DraftResponse, error)
+	GetDrafts(context.Context, *DraftsRequest) (*DraftsResponse, error)
+	DeleteDraft(context.Context, *DraftDeleteRequest) (*DraftDeleteResponse, error)
 	// ----------  Methods used by backend  ----------
 	BackendReady(context.Context, *BEReadyRequest) (*BEReadyResponse, error)
 	SendBackendAmbientStatus(context.Context, *BackendAmbientStatusPayload) (*BackendAmbientStatusResponse, error)
@@ -1866,6 +2879,222 @@ func _FrontendAPI_RequestBoardReports_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FrontendAPI_SetPinSignal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinSignalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).SetPinSignal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/SetPinSignal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).SetPinSignal(ctx, req.(*PinSignalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_SendAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachmentUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).SendAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/SendAttachment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).SendAttachment(ctx, req.(*AttachmentUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_GetAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).GetAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/GetAttachment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).GetAttachment(ctx, req.(*AttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_UnlockKeystore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeystoreUnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).UnlockKeystore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/UnlockKeystore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).UnlockKeystore(ctx, req.(*KeystoreUnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_GetKeystoreStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeystoreStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).GetKeystoreStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/GetKeystoreStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).GetKeystoreStatus(ctx, req.(*KeystoreStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_RotateUserKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserKeyRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).RotateUserKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/RotateUserKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).RotateUserKey(ctx, req.(*UserKeyRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_ExportIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).ExportIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/ExportIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).ExportIdentity(ctx, req.(*IdentityExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_ImportIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).ImportIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/ImportIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).ImportIdentity(ctx, req.(*IdentityImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_GetRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).GetRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/GetRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).GetRevisions(ctx, req.(*RevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DraftPayload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).SaveDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/SaveDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).SaveDraft(ctx, req.(*DraftPayload))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_GetDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DraftsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).GetDrafts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/GetDrafts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).GetDrafts(ctx, req.(*DraftsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendAPI_DeleteDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DraftDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendAPIServer).DeleteDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/feapi.FrontendAPI/DeleteDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendAPIServer).DeleteDraft(ctx, req.(*DraftDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FrontendAPI_BackendReady_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BEReadyRequest)
 	if err := dec(in); err != nil {
@@ -1982,6 +3211,54 @@ var _FrontendAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RequestBoardReports",
 			Handler:    _FrontendAPI_RequestBoardReports_Handler,
 		},
+		{
+			MethodName: "SetPinSignal",
+			Handler:    _FrontendAPI_SetPinSignal_Handler,
+		},
+		{
+			MethodName: "SendAttachment",
+			Handler:    _FrontendAPI_SendAttachment_Handler,
+		},
+		{
+			MethodName: "GetAttachment",
+			Handler:    _FrontendAPI_GetAttachment_Handler,
+		},
+		{
+			MethodName: "UnlockKeystore",
+			Handler:    _FrontendAPI_UnlockKeystore_Handler,
+		},
+		{
+			MethodName: "GetKeystoreStatus",
+			Handler:    _FrontendAPI_GetKeystoreStatus_Handler,
+		},
+		{
+			MethodName: "RotateUserKey",
+			Handler:    _FrontendAPI_RotateUserKey_Handler,
+		},
+		{
+			MethodName: "ExportIdentity",
+			Handler:    _FrontendAPI_ExportIdentity_Handler,
+		},
+		{
+			MethodName: "ImportIdentity",
+			Handler:    _FrontendAPI_ImportIdentity_Handler,
+		},
+		{
+			MethodName: "GetRevisions",
+			Handler:    _FrontendAPI_GetRevisions_Handler,
+		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _FrontendAPI_SaveDraft_Handler,
+		},
+		{
+			MethodName: "GetDrafts",
+			Handler:    _FrontendAPI_GetDrafts_Handler,
+		},
+		{
+			MethodName: "DeleteDraft",
+			Handler:    _FrontendAPI_DeleteDraft_Handler,
+		},
 		{
 			MethodName: "BackendReady",
 			Handler:    _FrontendAPI_BackendReady_Handler,
@@ -1998,147 +3275,209 @@ var _FrontendAPI_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("feapi/feapi.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2268 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0xef, 0x72, 0xe3, 0x48,
-	0x11, 0xf7, 0xdf, 0xfc, 0x69, 0x67, 0x37, 0xca, 0x24, 0x71, 0xb4, 0xde, 0x6c, 0x08, 0xba, 0x3b,
-	0x2a, 0x95, 0x83, 0xec, 0x6d, 0xf6, 0x80, 0x82, 0xa3, 0x00, 0xc5, 0x56, 0xb2, 0x26, 0xb6, 0xe5,
-	0x1b, 0xc9, 0xd9, 0xca, 0x7d, 0x09, 0x4a, 0x3c, 0x49, 0x04, 0xb6, 0xe4, 0x93, 0x94, 0xdb, 0xf3,
-	0x2b, 0xf0, 0x28, 0x54, 0xc1, 0x37, 0x3e, 0x50, 0xc5, 0xd3, 0xdc, 0x13, 0x50, 0xbc, 0x00, 0xd4,
-	0x8c, 0x46, 0x63, 0x49, 0x1e, 0xef, 0x9f, 0xbb, 0x2a, 0xbe, 0xb8, 0xa4, 0xee, 0x5f, 0xf7, 0x74,
-	0xf7, 0xcc, 0xf4, 0xfc, 0x46, 0x86, 0x8d, 0x5b, 0xe2, 0x4c, 0xdc, 0xe7, 0xec, 0xf7, 0x68, 0x12,
-	0xf8, 0x91, 0x8f, 0xaa, 0xec, 0xa5, 0xf1, 0xe4, 0x96, 0xf8, 0xd7, 0x7f, 0x22, 0x37, 0x51, 0xf8,
-	0x5c, 0x3c, 0xc5, 0x88, 0xc6, 0x93, 0xb1, 0x3b, 0xa6, 0x56, 0x61, 0x14, 0x3c, 0xdc, 0x44, 0x4c,
-	0xc6, 0x55, 0xda, 0x6f, 0xe1, 0xf1, 0x89, 0x81, 0x89, 0x33, 0x9c, 0x62, 0xf2, 0xf5, 0x03, 0x09,
-	0x23, 0xa4, 0xc2, 0xb2, 0x33, 0x1c, 0x06, 0x24, 0x0c, 0xd5, 0xe2, 0x7e, 0xf1, 0x60, 0x15, 0x27,
-	0xaf, 0x08, 0x41, 0x65, 0xe2, 0x07, 0x91, 0x5a, 0xda, 0x2f, 0x1e, 0x54, 0x31, 0x7b, 0xd6, 0x36,
-	0x60, 0x5d, 0xd8, 0x87, 0x13, 0xdf, 0x0b, 0x89, 0xf6, 0x12, 0x9e, 0x59, 0x24, 0x6a, 0x8e, 0x5c,
-	0xe2, 0x45, 0x7a, 0xbf, 0x6d, 0x91, 0xe0, 0x1b, 0x12, 0xf4, 0xfd, 0x20, 0x4a, 0x46, 0x40, 0x50,
-	0xa1, 0xaf, 0xcc, 0x7d, 0x15, 0xb3, 0x67, 0x6d, 0x1f, 0xf6, 0x16, 0x19, 0x71, 0xb7, 0x08, 0x14,
-	0x7d, 0x34, 0x3a, 0xf1, 0x9d, 0x60, 0x18, 0x72, 0x4f, 0xda, 0x97, 0xb0, 0x91, 0x92, 0xc5, 0x40,
-	0xf4, 0x1b, 0x58, 0x15, 0x42, 0xb5, 0xb8, 0x5f, 0x3e, 0xa8, 0x1d, 0xef, 0x1d, 0xcd, 0x4a, 0xd2,
-	0xf4, 0xc7, 0x13, 0x77, 0x44, 0x86, 0x0c, 0x60, 0x78, 0x91, 0x1b, 0x4d, 0xf1, 0xcc, 0x40, 0xfb,
-	0x1a, 0xb6, 0xed, 0xfb, 0x80, 0x38, 0x43, 0xdd, 0x1b, 0xf6, 0xfd, 0x30, 0x4a, 0xc6, 0x42, 0x87,
-	0xa0, 0x30, 0xc8, 0xa9, 0xeb, 0xdd, 0x91, 0x60, 0x12, 0xb8, 0x5e, 0xc4, 0x0b, 0x34, 0x27, 0x47,
-	0x3f, 0x85, 0x8d, 0xd8, 0x49, 0x1a, 0x5c, 0x62, 0xe0, 0x79, 0x85, 0xf6, 0xaf, 0x22, 0xd4, 0xf3,
-	0x63, 0xf2, 0x5c, 0x3e, 0x87, 0x2a, 0x73, 0xce, 0x46, 0x7a, 0x77, 0x1e, 0x31, 0x18, 0xfd, 0x12,
-	0x96, 0x62, 0x7f, 0x6c, 0xcc, 0xda, 0xf1, 0x8f, 0x24, 0x66, 0x31, 0x80, 0xdb, 0x71, 0x38, 0x7a,
-	0x09, 0x55, 0x36, 0xbe, 0x5a, 0x66, 0x65, 0x7b, 0x26, 0xb1, 0xa3, 0xfa, 0x64, 0x34, 0x86, 0xd5,
-	0x26, 0x50, 0x67, 0xc3, 0xea, 0x1e, 0x77, 0xfa, 0xbd, 0x4a, 0x76, 0x08, 0x8a, 0xe5, 0x07, 0x11,
-	0xf7, 0x70, 0x32, 0xed, 0x91, 0x37, 0x2c, 0xfa, 0x15, 0x3c, 0x27, 0xd7, 0xfe, 0x52, 0x84, 0x9d,
-	0xb9, 0x21, 0x7f, 0x50, 0xc5, 0x7e, 0x05, 0xcb, 0xdc, 0x91, 0x5a, 0x62, 0xa9, 0xbf, 0xb3, 0x64,
-	0x09, 0x5e, 0xfb, 0x7b, 0x11, 0x10, 0x73, 0x62, 0xb9, 0x77, 0x9e, 0x33, 0x4a, 0x72, 0xdf, 0x87,
-	0xda, 0x7c, 0xda, 0x69, 0x11, 0xda, 0x03, 0xb0, 0x1e, 0xae, 0xc3, 0x9b, 0xc0, 0xbd, 0x26, 0x43,
-	0x9e, 0x6b, 0x4a, 0x82, 0xea, 0xb0, 0xd4, 0xf3, 0x23, 0xf7, 0x76, 0xaa, 0x96, 0x99, 0x8e, 0xbf,
-	0xa1, 0x06, 0xac, 0x74, 0x9c, 0x30, 0xb2, 0x08, 0xf1, 0xd4, 0xca, 0x7e, 0xf1, 0xa0, 0x8c, 0xc5,
-	0x3b, 0xd2, 0x60, 0x2d, 0x79, 0x36, 0xbd, 0xd1, 0x54, 0xad, 0x32, 0xcb, 0x8c, 0x4c, 0x7b, 0x09,
-	0x9b, 0x99, 0x78, 0x79, 0xe1, 0x76, 0x61, 0xb5, 0xe9, 0x8f, 0xc7, 0x6e, 0x14, 0x91, 0xb8, 0x78,
-	0x2b, 0x78, 0x26, 0xd0, 0xfe, 0x5b, 0x84, 0xcd, 0x41, 0x48, 0x02, 0xdd, 0x1b, 0x9e, 0x05, 0xce,
-	0xe4, 0xfe, 0xfd, 0xd3, 0xfc, 0x2c, 0x36, 0xe4, 0x65, 0x8b, 0xcd, 0x44, 0xbe, 0x32, 0x55, 0x62,
-	0x91, 0xd9, 0xea, 0x64, 0xa8, 0x2e, 0xcd, 0x2c, 0x72, 0x2a, 0x74, 0x0c, 0x5b, 0x54, 0x9c, 0x5d,
-	0x7e, 0x64, 0xc8, 0xca, 0xb3, 0x82, 0xa5, 0x3a, 0x74, 0x04, 0x88, 0xca, 0xd3, 0x7b, 0x9c, 0x0c,
-	0x79, 0xc1, 0x24, 0x1a, 0xed, 0x9f, 0xe5, 0x78, 0x90, 0x59, 0x05, 0x78, 0xe1, 0x5e, 0x40, 0x85,
-	0xca, 0xf9, 0x82, 0x93, 0xed, 0x99, 0x54, 0x92, 0x0c, 0x8a, 0x7e, 0x01, 0x4b, 0xbc, 0x3f, 0x95,
-	0xde, 0xab, 0x3f, 0x71, 0x74, 0x7a, 0x99, 0x96, 0x3f, 0x6c, 0x99, 0xce, 0xb6, 0x76, 0xe5, 0xfd,
-	0xb7, 0xf6, 0xa2, 0xb9, 0xab, 0xfe, 0x3f, 0xe6, 0x6e, 0xf9, 0x83, 0xe7, 0x6e, 0x65, 0xe1, 0xdc,
-	0xfd, 0xad, 0x08, 0x55, 0xe3, 0x1b, 0x12, 0xb7, 0x19, 0xf3, 0x8d, 0x47, 0x02, 0x49, 0x4b, 0xca,
-	0xcb, 0x29, 0xb6, 0x1f, 0xb8, 0x7e, 0x30, 0xdf, 0xc4, 0xe7, 0xe4, 0xe8, 0x08, 0x56, 0xd9, 0x00,
-	0xf6, 0x74, 0x42, 0xd8, 0x7e, 0x7d, 0x7c, 0xac, 0x1c, 0xc5, 0xa7, 0xb4, 0x90, 0xe3, 0x19, 0x84,
-	0xee, 0x36, 0xdb, 0x1d, 0x93, 0x30, 0x72, 0xc6, 0x13, 0xbe, 0x8b, 0x67, 0x02, 0xb6, 0xdb, 0x9a,
-	0xbe, 0x17, 0x11, 0x2f, 0x62, 0x26, 0x7d, 0x67, 0x3a, 0xf2, 0x9d, 0x21, 0xd2, 0x78, 0x1a, 0x7c,
-	0xad, 0xad, 0xa5, 0x47, 0xc0, 0x3c, 0xc3, 0x17, 0xb0, 0xca, 0x4a, 0xdc, 0x72, 0x22, 0x87, 0xf7,
-	0xff, 0xcd, 0xa3, 0xcc, 0xc9, 0xcf, 0xd4, 0x78, 0x86, 0x42, 0x9f, 0x03, 0xc4, 0x25, 0x66, 0x36,
-	0x65, 0x66, 0xb3, 0x95, 0xb5, 0x89, 0xf5, 0x38, 0x85, 0x43, 0x47, 0xb0, 0x42, 0xcb, 0xcc, 0x6c,
-	0x2a, 0xcc, 0x06, 0x65, 0x6d, 0xa8, 0x16, 0x0b, 0x0c, 0xfa, 0x14, 0x96, 0xcf, 0xc9, 0x94, 0xc1,
-	0xab, 0x0c, 0xbe, 0x91, 0x85, 0x9f, 0x93, 0x29, 0x4e, 0x10, 0x5a, 0x1d, 0xb6, 0xd2, 0x05, 0x10,
-	0x2c, 0xe0, 0xbb, 0x32, 0xa0, 0xb8, 0x71, 0x7d, 0x70, 0x61, 0x9a, 0xa0, 0xc4, 0x96, 0xb6, 0x13,
-	0xdc, 0x91, 0x78, 0xa6, 0x4a, 0x6c, 0xa6, 0x76, 0x38, 0x3c, 0xaf, 0xc6, 0x73, 0x06, 0xb4, 0xdf,
-	0xc5, 0x6f, 0xf1, 0x21, 0x53, 0x8e, 0xfb, 0x5d, 0x4a, 0x44, 0x5b, 0x30, 0xc7, 0xc7, 0x47, 0x70,
-	0x85, 0x41, 0x32, 0xb2, 0x19, 0xa6, 0xe5, 0x8f, 0x1d, 0xd7, 0x63, 0xf5, 0x10, 0x98, 0x58, 0x36,
-	0xc3, 0x18, 0xdf, 0x4e, 0xdc, 0x60, 0xca, 0xb6, 0x50, 0x19, 0x67, 0x64, 0x94, 0x49, 0x75, 0x49,
-	0xe4, 0xb0, 0xbd, 0xb2, 0x8a, 0xd9, 0x33, 0xe3, 0x1e, 0x0c, 0x93, 0x5e, 0xb6, 0x2b, 0x9c, 0x7b,
-	0xe4, 0x15, 0xe8, 0xf7, 0xb0, 0xce, 0x73, 0x9c, 0x4e, 0x48, 0x73, 0xe4, 0x84, 0xa1, 0xba, 0xca,
-	0x6a, 0x52, 0xcf, 0xd6, 0x24, 0xd1, 0xe2, 0x3c, 0x1c, 0xbd, 0x00, 0x98, 0x89, 0x54, 0x60, 0xc6,
-	0x1b, 0x73, 0xc6, 0x38, 0x05, 0x62, 0x27, 0x5f, 0xfc, 0x46, 0xbe, 0x8d, 0xd4, 0x1a, 0x8b, 0x2d,
-	0x25, 0xd1, 0xb6, 0x61, 0x33, 0x35, 0xc7, 0x62, 0xee, 0xff, 0x51, 0x84, 0xdd, 0x81, 0x77, 0xc3,
-	0xbb, 0x55, 0xdc, 0x79, 0x4e, 0xa6, 0x74, 0xd9, 0xf0, 0xc3, 0xe8, 0x0b, 0x80, 0x58, 0xca, 0x42,
-	0x29, 0xb2, 0x50, 0x9e, 0xf2, 0x50, 0xf2, 0x86, 0x71, 0x50, 0xb3, 0x67, 0xb4, 0x05, 0xd5, 0x8e,
-	0x3b, 0x76, 0x13, 0x7a, 0x1b, 0xbf, 0xd0, 0x43, 0xd8, 0xbc, 0xbd, 0x0d, 0x49, 0xc4, 0xa6, 0xba,
-	0x8a, 0xf9, 0x9b, 0xb4, 0x8f, 0x54, 0xe4, 0x7d, 0x44, 0xfb, 0x77, 0x09, 0x9e, 0x2d, 0x88, 0x9b,
-	0x1f, 0x21, 0x3f, 0x28, 0xf0, 0x4f, 0x73, 0x87, 0x89, 0x74, 0xb7, 0x27, 0x27, 0xc8, 0x51, 0xfe,
-	0x04, 0x91, 0xef, 0x73, 0x71, 0x6c, 0x1c, 0x64, 0x8f, 0x0d, 0xd9, 0x0e, 0xe7, 0x67, 0xc5, 0x01,
-	0x54, 0x2f, 0xfc, 0x88, 0x84, 0x6a, 0x55, 0x86, 0xa4, 0x2a, 0x1c, 0x03, 0xd0, 0x27, 0x50, 0x39,
-	0x27, 0xd3, 0x50, 0x5d, 0x62, 0x40, 0x49, 0x17, 0x60, 0x6a, 0xf4, 0x6b, 0xa8, 0xd9, 0xc1, 0x43,
-	0x18, 0x85, 0x91, 0x43, 0xdd, 0x2e, 0x33, 0xb4, 0x9a, 0x0b, 0x57, 0x00, 0x70, 0x1a, 0xac, 0xed,
-	0xc0, 0x76, 0xdb, 0xbb, 0x1d, 0xb9, 0x77, 0xf7, 0x51, 0xd8, 0x0f, 0x1e, 0x3c, 0x92, 0xdc, 0x18,
-	0x54, 0xa8, 0xe7, 0x15, 0x7c, 0x75, 0x05, 0xf0, 0xf4, 0xc4, 0xb9, 0xf9, 0x33, 0xf1, 0x86, 0xfa,
-	0xf8, 0x9a, 0x5e, 0x43, 0xac, 0xc8, 0x89, 0x1e, 0xc2, 0xa4, 0xc3, 0x58, 0xb0, 0x25, 0x53, 0xf3,
-	0x86, 0x93, 0x3e, 0x87, 0x65, 0x30, 0x2c, 0x35, 0xd6, 0xf6, 0x60, 0x57, 0x8a, 0x4e, 0x62, 0xaa,
-	0xc3, 0x56, 0x4e, 0x11, 0x67, 0xb1, 0x03, 0xdb, 0x72, 0x83, 0x0d, 0x58, 0x7f, 0xe5, 0x8f, 0xc9,
-	0x85, 0x4b, 0xde, 0x24, 0x58, 0x04, 0xca, 0x4c, 0xc4, 0x61, 0x5b, 0x80, 0xfa, 0xfe, 0xe4, 0x61,
-	0xe4, 0x04, 0x69, 0xe4, 0x36, 0x6c, 0x66, 0xa4, 0xb3, 0x20, 0x18, 0xf3, 0x74, 0x6f, 0x9c, 0xc8,
-	0xf5, 0xbd, 0x74, 0x10, 0x39, 0x39, 0x37, 0xb8, 0x86, 0x46, 0x46, 0x11, 0xef, 0xe5, 0xa4, 0x90,
-	0x08, 0x2a, 0x8c, 0xba, 0xc6, 0x14, 0x93, 0x3d, 0x53, 0xd6, 0x40, 0xef, 0x90, 0xed, 0x88, 0x8c,
-	0xe7, 0x0f, 0x5b, 0x99, 0x4a, 0x7b, 0x06, 0x4f, 0x25, 0x63, 0x88, 0x10, 0x4e, 0xa0, 0x6e, 0x7a,
-	0xd7, 0x74, 0xc9, 0x53, 0x72, 0x33, 0x22, 0x51, 0xb2, 0x00, 0xd0, 0x01, 0xac, 0xe7, 0x34, 0x3c,
-	0x92, 0xbc, 0x58, 0x7b, 0x02, 0x3b, 0x73, 0x3e, 0xb8, 0x7b, 0x03, 0x90, 0x45, 0x27, 0x2d, 0xbe,
-	0x18, 0x27, 0x99, 0x3d, 0x87, 0x65, 0x3d, 0x75, 0x73, 0xae, 0x1d, 0x6f, 0x67, 0x17, 0x2b, 0x57,
-	0xe2, 0x04, 0xa5, 0x5d, 0xc2, 0x66, 0xca, 0x8d, 0xe8, 0x06, 0xb4, 0x3d, 0xb2, 0x69, 0x6d, 0xfa,
-	0x43, 0xc2, 0x6f, 0xc9, 0x29, 0x09, 0x3d, 0x19, 0x8c, 0x20, 0xf0, 0x83, 0x2e, 0x09, 0x43, 0xe7,
-	0x8e, 0xf0, 0x32, 0x65, 0x64, 0x5a, 0x00, 0xf5, 0x53, 0xa3, 0xe9, 0x7b, 0xb7, 0xee, 0x5d, 0xf3,
-	0xde, 0xf1, 0xee, 0x88, 0x88, 0xf2, 0x33, 0xd8, 0xec, 0xfa, 0xc3, 0xae, 0x3f, 0x24, 0x86, 0xe7,
-	0x5c, 0x8f, 0xc8, 0xb0, 0x1d, 0x5a, 0x24, 0xe2, 0x45, 0x90, 0xa9, 0xd0, 0x4f, 0xe0, 0x71, 0x56,
-	0xcc, 0xc9, 0x7b, 0x4e, 0x4a, 0x0b, 0x96, 0x1b, 0x53, 0x14, 0x4c, 0xe7, 0x77, 0x0e, 0x4c, 0x26,
-	0x7e, 0xf0, 0xbd, 0xee, 0xd4, 0xda, 0x1f, 0x61, 0x2b, 0xeb, 0x82, 0x57, 0xeb, 0x15, 0x6c, 0x70,
-	0x91, 0xed, 0x5c, 0x1b, 0x5e, 0x14, 0xb8, 0x24, 0xb9, 0xf6, 0x37, 0x52, 0xbb, 0x32, 0x8b, 0x99,
-	0xe2, 0x79, 0xa3, 0xc3, 0x2f, 0x52, 0x1c, 0x0e, 0xd5, 0x01, 0x0d, 0x7a, 0xe7, 0x3d, 0xf3, 0x75,
-	0xef, 0xca, 0xb8, 0x30, 0x7a, 0xf6, 0x95, 0x7d, 0xd9, 0x37, 0x94, 0x02, 0x02, 0x58, 0x6a, 0x62,
-	0x43, 0xb7, 0x0d, 0xa5, 0x48, 0x9f, 0x07, 0xfd, 0x16, 0x7d, 0x2e, 0x1d, 0xb6, 0xe7, 0xd9, 0x05,
-	0xda, 0x83, 0x46, 0xe2, 0xc3, 0x6a, 0x9f, 0xf5, 0xf4, 0xce, 0x95, 0xad, 0xe3, 0x33, 0x43, 0xf8,
-	0xaa, 0xc1, 0x72, 0xd3, 0xec, 0xd9, 0x46, 0xcf, 0x56, 0x8a, 0x68, 0x05, 0x2a, 0x03, 0xcb, 0xc0,
-	0x4a, 0xe9, 0xf0, 0xaf, 0xc5, 0xb9, 0x43, 0x19, 0xed, 0x82, 0x9a, 0x77, 0x75, 0xd9, 0x37, 0x9a,
-	0x1d, 0xdd, 0xb2, 0x94, 0x02, 0x0d, 0x56, 0x6f, 0xb5, 0xac, 0x2b, 0xdb, 0xbc, 0x6a, 0xb5, 0xad,
-	0xe6, 0xc0, 0xb2, 0xda, 0x66, 0x4f, 0x29, 0x52, 0xf9, 0xa9, 0xd9, 0xe9, 0x98, 0xaf, 0xad, 0xab,
-	0xb3, 0x41, 0xbb, 0x65, 0x74, 0xda, 0x3d, 0xc3, 0x52, 0x4a, 0x68, 0x1d, 0x6a, 0x5d, 0xb3, 0x75,
-	0xa5, 0x37, 0xed, 0xb6, 0xd9, 0xb3, 0x94, 0x32, 0x52, 0x60, 0xad, 0x3f, 0x38, 0xe9, 0xb4, 0x9b,
-	0x57, 0x36, 0x1e, 0x58, 0xb6, 0x52, 0xa1, 0xb9, 0xf5, 0xf4, 0x6e, 0xbb, 0x77, 0xa6, 0x54, 0x69,
-	0x68, 0xa7, 0x9f, 0xff, 0xfc, 0x85, 0xb2, 0x94, 0xc2, 0x19, 0x1d, 0xa3, 0x69, 0x2b, 0xcb, 0x87,
-	0xdf, 0x15, 0xd3, 0xe7, 0x3f, 0xda, 0x81, 0x4d, 0x49, 0x9c, 0x71, 0xdd, 0x06, 0xfd, 0x0b, 0x93,
-	0xd5, 0x6d, 0x0d, 0x56, 0x5a, 0xe6, 0xeb, 0x1e, 0x7b, 0x2b, 0xa1, 0x0d, 0x78, 0x84, 0x8d, 0xbe,
-	0x89, 0x6d, 0x1a, 0x7e, 0xd7, 0x6c, 0x29, 0x65, 0x0a, 0xe8, 0x9a, 0xad, 0x93, 0x8e, 0xd9, 0x3c,
-	0x57, 0x2a, 0xe8, 0x31, 0x40, 0xd7, 0x6c, 0xe9, 0xfd, 0x3e, 0x36, 0x2f, 0x0c, 0xa5, 0x8a, 0x1e,
-	0xc1, 0x6a, 0xd7, 0x6c, 0xb5, 0xcf, 0x7a, 0x26, 0x36, 0x94, 0x25, 0xea, 0x39, 0x4e, 0x52, 0x59,
-	0x46, 0xab, 0x50, 0x8d, 0xad, 0x56, 0x68, 0x8e, 0x3d, 0xbd, 0x6b, 0x5c, 0xe9, 0x16, 0x0d, 0x44,
-	0x59, 0xa5, 0xe3, 0x34, 0x8d, 0x9e, 0x65, 0xe2, 0x44, 0x04, 0x14, 0x1e, 0xe7, 0x51, 0xa3, 0x83,
-	0xb4, 0xda, 0xd6, 0x97, 0x03, 0xbd, 0xd3, 0x3e, 0xbd, 0x54, 0xd6, 0xe8, 0xdc, 0x60, 0xc3, 0xc6,
-	0x7a, 0xd3, 0x56, 0x1e, 0x1d, 0x86, 0xb0, 0x25, 0x3b, 0x86, 0xd3, 0xd9, 0x1a, 0x3d, 0xbb, 0x6d,
-	0x5f, 0x26, 0xd9, 0xd2, 0x38, 0x4c, 0x1d, 0xb7, 0xe2, 0x45, 0x62, 0xbf, 0xc2, 0x86, 0xde, 0x52,
-	0x4a, 0xb4, 0x90, 0x7d, 0xd3, 0xb2, 0x95, 0x32, 0x7d, 0x62, 0xe9, 0x57, 0xd0, 0x32, 0x94, 0xcf,
-	0x8d, 0x4b, 0xa5, 0x4a, 0x23, 0x60, 0xc5, 0xb7, 0x6c, 0xba, 0xa2, 0x96, 0x8e, 0xff, 0xf3, 0x08,
-	0x6a, 0xa7, 0x01, 0x23, 0xc1, 0x43, 0xbd, 0xdf, 0x46, 0x77, 0x50, 0x97, 0x7f, 0x22, 0x43, 0x1f,
-	0x27, 0x74, 0xeb, 0x6d, 0x9f, 0xdd, 0x1a, 0x9f, 0xbc, 0x03, 0xc5, 0xb7, 0x6a, 0x01, 0x61, 0xd8,
-	0x38, 0x4b, 0xa8, 0x6a, 0xf2, 0x45, 0x0a, 0xed, 0x72, 0x6b, 0xe9, 0xc7, 0xb1, 0xc6, 0xb3, 0x05,
-	0x5a, 0xe1, 0x73, 0x00, 0xe8, 0x8c, 0x33, 0xe4, 0xd9, 0x47, 0x1b, 0x94, 0x98, 0xc9, 0xbf, 0x1f,
-	0x35, 0xf6, 0x16, 0xa9, 0x85, 0xdb, 0x26, 0xac, 0x9d, 0x91, 0x48, 0x7c, 0xbd, 0x43, 0x09, 0x93,
-	0xcf, 0x7f, 0x29, 0x6c, 0xa8, 0xf3, 0x0a, 0xe1, 0xa4, 0x0d, 0x8f, 0x2d, 0x1e, 0x5b, 0xbc, 0x92,
-	0xd1, 0x93, 0xf4, 0xc0, 0x99, 0xef, 0x3a, 0x8d, 0x86, 0x4c, 0x25, 0x5c, 0x75, 0x60, 0xfd, 0x8c,
-	0x44, 0xe9, 0xcf, 0x04, 0x28, 0x31, 0x90, 0x7c, 0x3d, 0x69, 0x3c, 0x95, 0xea, 0x84, 0xb7, 0x2e,
-	0x28, 0xf4, 0x7c, 0x48, 0x5f, 0x84, 0x84, 0x3b, 0xc9, 0xf5, 0x50, 0xb8, 0x93, 0xde, 0x9c, 0x0a,
-	0xe8, 0x0f, 0xb0, 0x4e, 0xdd, 0xa5, 0xa8, 0xb5, 0x48, 0x74, 0xfe, 0x4a, 0x25, 0x12, 0x95, 0x31,
-	0xf1, 0x02, 0xba, 0x03, 0x95, 0x26, 0x2a, 0x63, 0xb5, 0xe8, 0xa3, 0x05, 0xcc, 0x35, 0xcd, 0xd5,
-	0x1b, 0x1f, 0xbf, 0x1d, 0x24, 0x06, 0xfa, 0x0a, 0x9e, 0xd0, 0xa0, 0xa5, 0x6c, 0x4e, 0x2c, 0x4a,
-	0xa9, 0x56, 0x2c, 0xca, 0x05, 0x84, 0xaf, 0x40, 0x39, 0x1d, 0xc7, 0x66, 0xd8, 0x14, 0x4a, 0xea,
-	0x28, 0xe3, 0x5e, 0x8d, 0x5d, 0xb9, 0x52, 0x38, 0x6d, 0xc1, 0x3a, 0x87, 0x26, 0xb4, 0x0b, 0x25,
-	0x77, 0xa9, 0x1c, 0x35, 0x6b, 0xec, 0xcc, 0xc9, 0x53, 0x53, 0x8f, 0x38, 0x2a, 0x45, 0xc9, 0xc4,
-	0x74, 0xcd, 0x93, 0x37, 0x31, 0x5d, 0x32, 0x06, 0x97, 0xce, 0x34, 0xc3, 0x9a, 0x44, 0xa6, 0x32,
-	0x82, 0x27, 0x32, 0x95, 0xb3, 0xbc, 0x02, 0x72, 0x58, 0x43, 0x92, 0xd0, 0x30, 0xf4, 0x63, 0x99,
-	0x65, 0x86, 0x06, 0x36, 0xb4, 0xc5, 0x90, 0x6c, 0xdb, 0xb0, 0x48, 0x94, 0xa3, 0x61, 0xa2, 0x6d,
-	0xc8, 0x29, 0x9e, 0x68, 0x1b, 0x8b, 0xd8, 0x5b, 0x01, 0x9d, 0x42, 0x2d, 0x45, 0xbc, 0x66, 0xbb,
-	0x60, 0x8e, 0xd3, 0xcd, 0x76, 0xc1, 0x3c, 0x4f, 0xd3, 0x0a, 0xe8, 0x22, 0x26, 0x70, 0x39, 0xd6,
-	0x23, 0xe2, 0x93, 0x33, 0x30, 0x11, 0xdf, 0x22, 0xb2, 0x54, 0x40, 0x7d, 0xca, 0x87, 0x59, 0x32,
-	0x69, 0xca, 0x83, 0x32, 0xbd, 0x27, 0x4b, 0xa5, 0xc4, 0xde, 0x97, 0x71, 0x24, 0xad, 0x80, 0x7e,
-	0x07, 0x6b, 0xfc, 0xa6, 0xc1, 0xfe, 0xac, 0x41, 0xdb, 0x09, 0x3c, 0xf3, 0xe7, 0x4f, 0xa3, 0x9e,
-	0x17, 0x0b, 0x07, 0x04, 0x54, 0x9a, 0xaa, 0xec, 0xba, 0x82, 0x92, 0xb9, 0x7c, 0xcb, 0xfd, 0xa9,
-	0xf1, 0xd1, 0x5b, 0x30, 0xb3, 0x61, 0x4e, 0x1a, 0x5f, 0xa9, 0x0e, 0x89, 0xee, 0x49, 0xf0, 0xb3,
-	0x1b, 0x3f, 0x20, 0xcf, 0x63, 0xe6, 0x1c, 0xff, 0xdd, 0x75, 0xbd, 0xc4, 0xde, 0x5e, 0xfe, 0x2f,
-	0x00, 0x00, 0xff, 0xff, 0x28, 0xc1, 0x70, 0xee, 0x04, 0x1b, 0x00, 0x00,
+	// 3261 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3a, 0xdb, 0x72, 0x1b, 0xc7,
+	0xb1, 0x02, 0x40, 0x90, 0x44, 0x93, 0x22, 0x97, 0x43, 0x10, 0x84, 0x56, 0x22, 0xcd, 0xb3, 0x96,
+	0x4f, 0xa9, 0xe4, 0x73, 0x28, 0x8b, 0xf2, 0xb1, 0x4f, 0xec, 0xdc, 0x40, 0x60, 0x49, 0xc1, 0x02,
+	0x01, 0x78, 0x17, 0x90, 0x4a, 0xae, 0x72, 0x31, 0x4b, 0x62, 0x48, 0x6e, 0x04, 0xec, 0xc2, 0xbb,
+	0x4b, 0xc9, 0x48, 0xf2, 0x05, 0xf9, 0x94, 0x54, 0x25, 0x2f, 0xa9, 0x3c, 0xa4, 0x2a, 0xf9, 0x8b,
+	0x3c, 0xe6, 0xc9, 0x5f, 0xe0, 0x3f, 0x48, 0x6a, 0xae, 0x3b, 0x7b, 0x81, 0x2c, 0x59, 0x55, 0x79,
+	0x41, 0x4d, 0x5f, 0xa7, 0xbb, 0x67, 0xa6, 0x67, 0xba, 0x17, 0xb0, 0x71, 0x81, 0x9d, 0xa9, 0xfb,
+	0x80, 0xfe, 0xee, 0x4f, 0x03, 0x3f, 0xf2, 0x51, 0x99, 0x02, 0xfa, 0xad, 0x0b, 0xec, 0x9f, 0xfd,
+	0x1a, 0x9f, 0x47, 0xe1, 0x03, 0x39, 0x62, 0x1c, 0xfa, 0xad, 0x89, 0x3b, 0x21, 0x52, 0x61, 0x14,
+	0x5c, 0x9f, 0x47, 0x14, 0xc7, 0x49, 0xc6, 0xcf, 0x61, 0xed, 0xd0, 0xb4, 0xb0, 0x33, 0x9a, 0x59,
+	0xf8, 0x9b, 0x6b, 0x1c, 0x46, 0xa8, 0x0e, 0x4b, 0xce, 0x68, 0x14, 0xe0, 0x30, 0xac, 0x17, 0xf6,
+	0x0a, 0xf7, 0x2a, 0x96, 0x00, 0x11, 0x82, 0x85, 0xa9, 0x1f, 0x44, 0xf5, 0xe2, 0x5e, 0xe1, 0x5e,
+	0xd9, 0xa2, 0x63, 0x63, 0x03, 0xd6, 0xa5, 0x7c, 0x38, 0xf5, 0xbd, 0x10, 0x1b, 0x8f, 0x60, 0xc7,
+	0xc6, 0x51, 0x73, 0xec, 0x62, 0x2f, 0x6a, 0xf4, 0xdb, 0x36, 0x0e, 0x5e, 0xe2, 0xa0, 0xef, 0x07,
+	0x91, 0x98, 0x01, 0xc1, 0x02, 0x01, 0xa9, 0xfa, 0xb2, 0x45, 0xc7, 0xc6, 0x1e, 0xec, 0xce, 0x13,
+	0xe2, 0x6a, 0x11, 0x68, 0x8d, 0xf1, 0xf8, 0xd0, 0x77, 0x82, 0x51, 0xc8, 0x35, 0x19, 0x5f, 0xc2,
+	0x86, 0x82, 0x63, 0x8c, 0xe8, 0xa7, 0x50, 0x91, 0xc8, 0x7a, 0x61, 0xaf, 0x74, 0x6f, 0xe5, 0x60,
+	0x77, 0x3f, 0x0e, 0x49, 0xd3, 0x9f, 0x4c, 0xdd, 0x31, 0x1e, 0x51, 0x06, 0xd3, 0x8b, 0xdc, 0x68,
+	0x66, 0xc5, 0x02, 0xc6, 0x37, 0xb0, 0x35, 0xb8, 0x0a, 0xb0, 0x33, 0x6a, 0x78, 0xa3, 0xbe, 0x1f,
+	0x46, 0x62, 0x2e, 0x74, 0x1f, 0x34, 0xca, 0x72, 0xe4, 0x7a, 0x97, 0x38, 0x98, 0x06, 0xae, 0x17,
+	0xf1, 0x00, 0x65, 0xf0, 0xe8, 0x7f, 0x60, 0x83, 0x29, 0x51, 0x99, 0x8b, 0x94, 0x39, 0x4b, 0x30,
+	0xfe, 0x56, 0x80, 0x5a, 0x7a, 0x4e, 0xee, 0xcb, 0xc7, 0x50, 0xa6, 0xca, 0xe9, 0x4c, 0x3f, 0xec,
+	0x07, 0x63, 0x46, 0x9f, 0xc2, 0x22, 0xd3, 0x47, 0xe7, 0x5c, 0x39, 0x78, 0x2f, 0x47, 0x8c, 0x31,
+	0x70, 0x39, 0xce, 0x8e, 0x1e, 0x41, 0x99, 0xce, 0x5f, 0x2f, 0xd1, 0xb0, 0xed, 0xe4, 0xc8, 0x11,
+	0xba, 0x98, 0x8d, 0xf2, 0x1a, 0x53, 0xa8, 0xd1, 0x69, 0x1b, 0x1e, 0x57, 0xfa, 0xa3, 0x42, 0x76,
+	0x1f, 0x34, 0xdb, 0x0f, 0x22, 0xae, 0xe1, 0x70, 0xd6, 0xc5, 0xaf, 0xa8, 0xf5, 0xcb, 0x56, 0x06,
+	0x6f, 0xfc, 0xbe, 0x00, 0xdb, 0x99, 0x29, 0xdf, 0x29, 0x62, 0x3f, 0x81, 0x25, 0xae, 0xa8, 0x5e,
+	0xdc, 0x2b, 0xbd, 0x49, 0xc8, 0x04, 0xbf, 0xf1, 0xa7, 0x02, 0x20, 0xaa, 0xc4, 0x76, 0x2f, 0x3d,
+	0x67, 0x2c, 0x7c, 0xdf, 0x83, 0x95, 0xac, 0xdb, 0x2a, 0x0a, 0xed, 0x02, 0xd8, 0xd7, 0x67, 0xe1,
+	0x79, 0xe0, 0x9e, 0xe1, 0x11, 0xf7, 0x55, 0xc1, 0xa0, 0x1a, 0x2c, 0x76, 0xfd, 0xc8, 0xbd, 0x98,
+	0xd5, 0x4b, 0x94, 0xc6, 0x21, 0xa4, 0xc3, 0x72, 0xc7, 0x09, 0x23, 0x1b, 0x63, 0xaf, 0xbe, 0xb0,
+	0x57, 0xb8, 0x57, 0xb2, 0x24, 0x8c, 0x0c, 0x58, 0x15, 0xe3, 0x9e, 0x37, 0x9e, 0xd5, 0xcb, 0x54,
+	0x32, 0x81, 0x33, 0x1e, 0xc1, 0x66, 0xc2, 0x5e, 0x1e, 0xb8, 0x3b, 0x50, 0x69, 0xfa, 0x93, 0x89,
+	0x1b, 0x45, 0x98, 0x05, 0x6f, 0xd9, 0x8a, 0x11, 0xc6, 0xbf, 0x0a, 0xb0, 0x39, 0x0c, 0x71, 0xd0,
+	0xf0, 0x46, 0xc7, 0x81, 0x33, 0xbd, 0x7a, 0x73, 0x37, 0x3f, 0x62, 0x82, 0x3c, 0x6c, 0x4c, 0x4c,
+	0xfa, 0x9b, 0x47, 0x12, 0x12, 0x89, 0xa3, 0x8e, 0x47, 0xf5, 0xc5, 0x58, 0x22, 0x45, 0x42, 0x07,
+	0x50, 0x25, 0xe8, 0xe4, 0xf6, 0xc3, 0x23, 0x1a, 0x9e, 0x65, 0x2b, 0x97, 0x86, 0xf6, 0x01, 0x11,
+	0xbc, 0x7a, 0xc6, 0xf1, 0x88, 0x07, 0x2c, 0x87, 0x62, 0xfc, 0xb5, 0x04, 0xd5, 0x64, 0x04, 0x78,
+	0xe0, 0x1e, 0xc2, 0x02, 0xc1, 0xf3, 0x0d, 0x97, 0x77, 0x66, 0x14, 0x27, 0x29, 0x2b, 0xfa, 0x04,
+	0x16, 0x79, 0x7e, 0x2a, 0xbe, 0x51, 0x7e, 0xe2, 0xdc, 0xea, 0x36, 0x2d, 0xbd, 0xdd, 0x36, 0x8d,
+	0x8f, 0xf6, 0xc2, 0x9b, 0x1f, 0xed, 0x79, 0x6b, 0x57, 0xfe, 0x4f, 0xac, 0xdd, 0xd2, 0x5b, 0xaf,
+	0xdd, 0xf2, 0xdc, 0xb5, 0xfb, 0x63, 0x01, 0xca, 0xe6, 0x4b, 0xcc, 0xd2, 0x4c, 0xef, 0x95, 0x87,
+	0x83, 0x9c, 0x94, 0x94, 0xc6, 0x13, 0xde, 0x7e, 0xe0, 0xfa, 0x41, 0x36, 0x89, 0x67, 0xf0, 0x68,
+	0x1f, 0x2a, 0x74, 0x82, 0xc1, 0x6c, 0x8a, 0xe9, 0x79, 0x5d, 0x3b, 0xd0, 0xf6, 0xd9, 0x2d, 0x2d,
+	0xf1, 0x56, 0xcc, 0x42, 0x4e, 0xdb, 0xc0, 0x9d, 0xe0, 0x30, 0x72, 0x26, 0x53, 0x7e, 0x8a, 0x63,
+	0x84, 0xf1, 0xf7, 0x22, 0x6c, 0x36, 0x7d, 0x2f, 0xc2, 0x5e, 0x44, 0x45, 0xfa, 0xce, 0x6c, 0xec,
+	0x3b, 0x23, 0x64, 0x70, 0x37, 0xf8, 0x5e, 0x5b, 0x55, 0x67, 0xb0, 0xb8, 0x87, 0x0f, 0xa1, 0x42,
+	0x43, 0xdc, 0x72, 0x22, 0x87, 0xe7, 0xff, 0xcd, 0xfd, 0xc4, 0xcd, 0x4f, 0xc9, 0x56, 0xcc, 0x85,
+	0x3e, 0x06, 0x60, 0x21, 0xa6, 0x32, 0x25, 0x2a, 0x53, 0x4d, 0xca, 0x30, 0xba, 0xa5, 0xf0, 0xa1,
+	0x7d, 0x58, 0x26, 0x61, 0xa6, 0x32, 0x0b, 0x54, 0x06, 0x25, 0x65, 0x08, 0xd5, 0x92, 0x3c, 0xe8,
+	0x43, 0x58, 0x7a, 0x82, 0x67, 0x94, 0xbd, 0x4c, 0xd9, 0x37, 0x92, 0xec, 0x4f, 0xf0, 0xcc, 0x12,
+	0x1c, 0x24, 0x91, 0xd9, 0xe7, 0x57, 0x78, 0x74, 0x3d, 0xc6, 0xa3, 0x23, 0x3f, 0xa0, 0x1b, 0xa8,
+	0x64, 0x25, 0x70, 0xe4, 0xa5, 0xd2, 0x0a, 0x9c, 0x8b, 0xa8, 0xcd, 0x36, 0x4b, 0xc5, 0x12, 0xa0,
+	0x51, 0x83, 0xaa, 0x1a, 0x3e, 0xf9, 0x86, 0xf8, 0xae, 0x04, 0x88, 0xa5, 0xbd, 0xb7, 0x0e, 0x6b,
+	0x13, 0x34, 0x26, 0x39, 0x70, 0x82, 0x4b, 0xcc, 0xd6, 0xb9, 0x48, 0xd7, 0x79, 0x9b, 0xb3, 0xa7,
+	0xc9, 0x56, 0x46, 0x80, 0x64, 0x4b, 0x06, 0xb1, 0x2b, 0xaa, 0xc4, 0xb2, 0xa5, 0x82, 0x22, 0x7e,
+	0x73, 0x7e, 0x76, 0x81, 0x2f, 0x50, 0x96, 0x04, 0x2e, 0xe6, 0x69, 0xf9, 0x13, 0xc7, 0xf5, 0xea,
+	0x65, 0x95, 0x87, 0xe1, 0x62, 0x1e, 0xf3, 0xdb, 0xa9, 0x1b, 0xcc, 0x44, 0xfc, 0x54, 0x1c, 0x79,
+	0x87, 0x9d, 0xe0, 0xc8, 0xe1, 0xc1, 0xa3, 0x63, 0xfa, 0x72, 0xa1, 0x3c, 0xea, 0xa6, 0x5f, 0xe6,
+	0x2f, 0x97, 0x34, 0x01, 0xfd, 0x12, 0xd6, 0xb9, 0x8f, 0xb3, 0x29, 0x6e, 0x8e, 0x9d, 0x30, 0xac,
+	0x57, 0x68, 0x4c, 0x6a, 0xc9, 0x98, 0x08, 0xaa, 0x95, 0x66, 0x47, 0x0f, 0x01, 0x62, 0x54, 0x1d,
+	0xa8, 0xf0, 0x46, 0x46, 0xd8, 0x52, 0x98, 0xe8, 0xbd, 0xc9, 0x20, 0xfc, 0x6d, 0x54, 0x5f, 0xa1,
+	0xb6, 0x29, 0x18, 0x63, 0x0b, 0x36, 0x95, 0x35, 0x96, 0x6b, 0xff, 0x97, 0x02, 0xdc, 0x19, 0x7a,
+	0xe7, 0x3c, 0xd7, 0xb1, 0xbc, 0x75, 0x38, 0x23, 0x9b, 0x8e, 0x5f, 0x65, 0x9f, 0x03, 0x30, 0x2c,
+	0x35, 0xa5, 0x40, 0x4d, 0xb9, 0xcd, 0x4d, 0x49, 0x0b, 0x32, 0xa3, 0xe2, 0x31, 0xaa, 0x42, 0xb9,
+	0xe3, 0x4e, 0x5c, 0xf1, 0x38, 0x66, 0x00, 0xb9, 0xc2, 0x7b, 0x17, 0x17, 0x21, 0x8e, 0xe8, 0x52,
+	0x97, 0x2d, 0x0e, 0xe5, 0x66, 0xa1, 0x85, 0xfc, 0x2c, 0x64, 0x7c, 0x5f, 0x84, 0x9d, 0x39, 0x76,
+	0xf3, 0x0b, 0xe8, 0x9d, 0x0c, 0xff, 0x30, 0x75, 0x15, 0xe5, 0xe6, 0x0a, 0xce, 0x82, 0xf6, 0xd3,
+	0xf7, 0x4f, 0x7e, 0x96, 0x10, 0x4c, 0xe8, 0x5e, 0xf2, 0xd2, 0xc9, 0xcb, 0x0f, 0x8c, 0x81, 0x70,
+	0x3e, 0xf5, 0x23, 0x1c, 0xd6, 0xcb, 0x79, 0x9c, 0x84, 0x64, 0x31, 0x06, 0xf4, 0x01, 0x2c, 0x3c,
+	0xc1, 0xb3, 0xb0, 0xbe, 0xb8, 0x57, 0xca, 0xcf, 0x21, 0x94, 0x8c, 0x3e, 0x83, 0x95, 0x41, 0x70,
+	0x1d, 0x46, 0x61, 0xe4, 0x10, 0xb5, 0x4b, 0x94, 0xbb, 0x9e, 0x32, 0x57, 0x32, 0x58, 0x2a, 0xb3,
+	0xb1, 0x0d, 0x5b, 0x6d, 0xef, 0x62, 0xec, 0x5e, 0x5e, 0x45, 0x61, 0x3f, 0xb8, 0xf6, 0xb0, 0xa8,
+	0x37, 0xea, 0x50, 0x4b, 0x13, 0xf8, 0xee, 0x0a, 0xe0, 0xf6, 0xa1, 0x73, 0xfe, 0x02, 0x7b, 0xa3,
+	0xc6, 0xe4, 0xcc, 0xc5, 0x5e, 0x64, 0x47, 0x4e, 0x74, 0x1d, 0x8a, 0x0c, 0x63, 0x43, 0x35, 0x8f,
+	0xcc, 0x13, 0x8e, 0x7a, 0x8b, 0xe7, 0xb1, 0x59, 0xb9, 0xc2, 0xc6, 0x2e, 0xdc, 0xc9, 0xe5, 0x16,
+	0x36, 0xd5, 0xa0, 0x9a, 0x22, 0x30, 0x2f, 0xb6, 0x61, 0x2b, 0x5f, 0x60, 0x03, 0xd6, 0x1f, 0xfb,
+	0x13, 0xfc, 0xd4, 0xc5, 0xaf, 0x04, 0x2f, 0x02, 0x2d, 0x46, 0x71, 0xb6, 0x2a, 0xa0, 0xbe, 0x3f,
+	0xbd, 0x1e, 0x3b, 0x81, 0xca, 0xb9, 0x05, 0x9b, 0x09, 0x6c, 0x6c, 0x04, 0x7d, 0xb7, 0xba, 0xe7,
+	0x4e, 0xe4, 0xfa, 0x9e, 0x6a, 0x44, 0x0a, 0xcf, 0x05, 0xce, 0x40, 0x4f, 0x10, 0xd8, 0x59, 0x16,
+	0x81, 0x44, 0xb0, 0x40, 0x1f, 0xbe, 0xec, 0x81, 0x4a, 0xc7, 0xe4, 0xcd, 0x41, 0x2a, 0xd0, 0x76,
+	0x84, 0x27, 0xd9, 0xab, 0x3a, 0x8f, 0x64, 0xec, 0xc0, 0xed, 0x9c, 0x39, 0xa4, 0x09, 0x87, 0x50,
+	0xeb, 0x79, 0x67, 0x64, 0xcb, 0x93, 0xa7, 0xd1, 0x18, 0x47, 0x62, 0x03, 0xa0, 0x7b, 0xb0, 0x9e,
+	0xa2, 0x70, 0x4b, 0xd2, 0x68, 0xe3, 0x16, 0x6c, 0x67, 0x74, 0x70, 0xf5, 0x26, 0x20, 0x9b, 0x2c,
+	0x1a, 0x2b, 0xab, 0x85, 0x67, 0x0f, 0x60, 0xa9, 0xa1, 0xd4, 0xdd, 0x2b, 0x07, 0x5b, 0xc9, 0xcd,
+	0xca, 0x89, 0x96, 0xe0, 0x32, 0x9e, 0xc3, 0xa6, 0xa2, 0x46, 0x66, 0x03, 0x92, 0x1e, 0xe9, 0xb2,
+	0x36, 0xfd, 0x11, 0xe6, 0x35, 0xb6, 0x82, 0x21, 0x37, 0x83, 0x19, 0x04, 0x7e, 0x70, 0x82, 0xc3,
+	0xd0, 0xb9, 0xc4, 0x3c, 0x4c, 0x09, 0x9c, 0x11, 0x40, 0xed, 0xc8, 0x6c, 0xfa, 0xde, 0x85, 0x7b,
+	0xd9, 0xbc, 0x72, 0xbc, 0x4b, 0x2c, 0xad, 0xfc, 0x08, 0x36, 0x4f, 0xfc, 0xd1, 0x89, 0x3f, 0xc2,
+	0xa6, 0xe7, 0x9c, 0x8d, 0xf1, 0xa8, 0x1d, 0xda, 0x38, 0xe2, 0x41, 0xc8, 0x23, 0xa1, 0xff, 0x86,
+	0xb5, 0x24, 0x9a, 0x3f, 0xfd, 0x53, 0x58, 0x12, 0xb0, 0xd4, 0x9c, 0x32, 0x60, 0x0d, 0x5e, 0xb1,
+	0x58, 0x98, 0xf4, 0x1c, 0x7e, 0x4c, 0x79, 0x69, 0xfc, 0x0a, 0xaa, 0x49, 0x15, 0x3c, 0x5a, 0x8f,
+	0x61, 0x83, 0xa3, 0x06, 0xce, 0x99, 0xe9, 0x45, 0x81, 0x8b, 0x45, 0xd3, 0x40, 0x57, 0x4e, 0x65,
+	0x92, 0x67, 0x66, 0x65, 0x85, 0x8c, 0x31, 0x68, 0x7d, 0xd7, 0x4b, 0x16, 0x81, 0xbb, 0x99, 0xcc,
+	0x5c, 0x49, 0x24, 0xdf, 0x54, 0xf5, 0x54, 0xcc, 0x56, 0x4f, 0x35, 0x58, 0xec, 0xbb, 0x9e, 0x87,
+	0x47, 0xa2, 0x08, 0x64, 0x90, 0xf1, 0x10, 0x36, 0x94, 0xd9, 0xde, 0xa8, 0x84, 0xfb, 0x1d, 0x40,
+	0x23, 0x8a, 0x9c, 0xf3, 0xab, 0x09, 0xf6, 0x68, 0x13, 0xe6, 0xb1, 0x13, 0x5e, 0x71, 0xa3, 0xe8,
+	0x98, 0xe0, 0xba, 0xce, 0x44, 0x6c, 0x09, 0x3a, 0x26, 0xd5, 0xe6, 0x89, 0x3b, 0xc1, 0xf2, 0x5d,
+	0x5b, 0xb1, 0x24, 0x4c, 0x0f, 0xa3, 0xfb, 0x1b, 0xcc, 0xdf, 0xaf, 0x74, 0x4c, 0x0c, 0x6e, 0x5e,
+	0x5d, 0x7b, 0x2f, 0x58, 0x26, 0xaf, 0x58, 0x1c, 0x32, 0xbe, 0x86, 0xed, 0x78, 0xf6, 0xe1, 0x94,
+	0x6c, 0x26, 0xa5, 0x1f, 0x44, 0xa7, 0x2d, 0xcc, 0x99, 0xb6, 0x98, 0x9d, 0x56, 0x3e, 0x54, 0x57,
+	0x2d, 0x3a, 0x36, 0xce, 0xa1, 0x9e, 0x55, 0x2f, 0x0b, 0x34, 0xc5, 0x71, 0x7e, 0xb8, 0xc4, 0x1b,
+	0x23, 0x26, 0x58, 0x6a, 0x74, 0xaa, 0x50, 0xa6, 0x07, 0x82, 0xcf, 0xcd, 0x00, 0xe3, 0x08, 0x36,
+	0x14, 0x7e, 0x6e, 0xfd, 0xdb, 0x6b, 0x37, 0x8e, 0x00, 0xa9, 0x7a, 0xe2, 0xd5, 0x6b, 0xbc, 0x74,
+	0xdc, 0x31, 0x39, 0x0e, 0x62, 0xf5, 0x24, 0x42, 0x3a, 0x5d, 0x54, 0x9c, 0xfe, 0x14, 0xb6, 0xc8,
+	0x5d, 0x17, 0xf9, 0x01, 0x1e, 0x7a, 0x63, 0xff, 0xfc, 0x85, 0xb2, 0xef, 0xfa, 0x4e, 0x18, 0x4e,
+	0xaf, 0x02, 0x27, 0x94, 0xfb, 0x2e, 0xc6, 0x18, 0x17, 0x50, 0x4b, 0x0b, 0x72, 0x23, 0x74, 0x58,
+	0x66, 0x18, 0xb9, 0x83, 0x24, 0xcc, 0xd6, 0xe4, 0x32, 0x70, 0xe2, 0xf2, 0x5d, 0xc2, 0x71, 0xc0,
+	0x4a, 0x6a, 0xc0, 0x3e, 0x82, 0x1a, 0xa9, 0xc6, 0xc8, 0xad, 0xec, 0x47, 0x34, 0xd5, 0x0a, 0x0b,
+	0x6b, 0xb0, 0x68, 0xe1, 0x97, 0xfe, 0x0b, 0xe1, 0x29, 0x87, 0x8c, 0x6f, 0x60, 0x3b, 0x23, 0xc1,
+	0x4d, 0xab, 0xc3, 0x12, 0xc5, 0x49, 0xcb, 0x04, 0x48, 0x52, 0x4c, 0x17, 0xbf, 0xca, 0x9e, 0xa4,
+	0x14, 0x76, 0x8e, 0x91, 0x4f, 0x60, 0xab, 0x3d, 0xc2, 0xf4, 0x50, 0x9a, 0xdf, 0x4e, 0x53, 0x7d,
+	0x4a, 0x27, 0x92, 0x47, 0x84, 0x8c, 0x53, 0x91, 0x2d, 0x66, 0x22, 0xfb, 0x05, 0xd4, 0xd2, 0xca,
+	0xe2, 0xc8, 0x32, 0x4c, 0x1c, 0x59, 0x01, 0xcf, 0xd9, 0x6e, 0xbf, 0x8d, 0x0d, 0x6b, 0x4f, 0xde,
+	0xd1, 0x30, 0x52, 0x32, 0x93, 0xe4, 0x3a, 0x76, 0xcf, 0x89, 0x49, 0xfe, 0xf8, 0x9a, 0xc4, 0x96,
+	0x07, 0x22, 0x87, 0x62, 0xfc, 0xa3, 0x00, 0xb5, 0xf4, 0xec, 0xb1, 0x27, 0xed, 0x49, 0xd2, 0x13,
+	0x01, 0xbf, 0x41, 0x46, 0xd3, 0x61, 0x59, 0x4c, 0xc7, 0x73, 0x9a, 0x84, 0x09, 0xcd, 0xc2, 0xd3,
+	0xb1, 0x73, 0x2e, 0x7b, 0x37, 0x12, 0x26, 0x0e, 0x88, 0x31, 0x79, 0xf5, 0x5c, 0x4f, 0x69, 0x08,
+	0x58, 0xed, 0x93, 0x43, 0x89, 0x63, 0xba, 0xa8, 0xc6, 0x74, 0x3b, 0x3e, 0x32, 0xc9, 0x47, 0x51,
+	0x17, 0x6a, 0x69, 0x42, 0x7c, 0x2e, 0x4d, 0xef, 0x3c, 0x98, 0x4d, 0x95, 0xac, 0x2a, 0x11, 0x64,
+	0x23, 0x77, 0xd8, 0x71, 0x61, 0x47, 0x82, 0x43, 0xc6, 0x00, 0x34, 0x0b, 0xbf, 0x74, 0x43, 0xe5,
+	0xcd, 0xf3, 0xee, 0xd7, 0x81, 0xb1, 0x0f, 0xcb, 0x2d, 0xf7, 0xe2, 0xa2, 0xe3, 0x7a, 0x18, 0xad,
+	0x41, 0xb1, 0x37, 0xe5, 0x5a, 0x8a, 0xbd, 0x29, 0xd9, 0x15, 0xb4, 0x22, 0xe2, 0xd9, 0x9b, 0x8c,
+	0x8d, 0x7f, 0x16, 0xe0, 0xa6, 0x30, 0x83, 0xde, 0x5c, 0xc4, 0x06, 0xd2, 0x0d, 0x1c, 0x4e, 0x47,
+	0x0e, 0x7f, 0xbc, 0x94, 0x2c, 0x05, 0x43, 0xb4, 0x1c, 0xfa, 0xa3, 0x99, 0xd0, 0x42, 0xc6, 0xb2,
+	0x50, 0x2c, 0x25, 0x0b, 0x45, 0x26, 0xd1, 0x0f, 0x7c, 0xff, 0xa2, 0x77, 0xf1, 0xcc, 0x0f, 0x5e,
+	0xf0, 0x1a, 0x26, 0x4b, 0x20, 0xef, 0x26, 0x86, 0xa4, 0x37, 0x56, 0x74, 0x1d, 0x60, 0xbe, 0x72,
+	0x69, 0x34, 0xfa, 0x10, 0x96, 0xc9, 0x9c, 0xc4, 0x4b, 0xfe, 0xc4, 0x5f, 0xe7, 0xc9, 0x54, 0x38,
+	0x6e, 0x49, 0x06, 0xe3, 0x6b, 0xd8, 0x10, 0xde, 0xc5, 0xeb, 0x75, 0x00, 0x15, 0x89, 0xe4, 0x57,
+	0x79, 0x95, 0xab, 0x48, 0x84, 0xc2, 0x8a, 0xd9, 0xe6, 0x1c, 0xc0, 0x3f, 0x17, 0xa1, 0x4c, 0x5b,
+	0x0a, 0x24, 0xd6, 0xed, 0x91, 0x88, 0x75, 0x7b, 0x94, 0x5a, 0xc9, 0x62, 0x66, 0x25, 0xab, 0xa2,
+	0x0b, 0xcd, 0x33, 0x0d, 0x05, 0xc8, 0x5e, 0x49, 0x94, 0xf5, 0x1c, 0xa2, 0x97, 0xbc, 0x13, 0x90,
+	0xeb, 0x83, 0x05, 0x85, 0x43, 0xb9, 0x0d, 0xa8, 0xc5, 0x39, 0x0d, 0x28, 0x71, 0x89, 0x2e, 0x29,
+	0x97, 0xa8, 0x58, 0xcb, 0xe5, 0xe4, 0x5a, 0x76, 0x5c, 0xef, 0x05, 0xad, 0xd3, 0x2b, 0x16, 0x1d,
+	0xcb, 0xf5, 0x05, 0x65, 0x7d, 0xc9, 0x31, 0x0d, 0x30, 0xcd, 0xc0, 0xb4, 0xc6, 0x2e, 0x59, 0x12,
+	0x26, 0x27, 0x82, 0x76, 0x94, 0x9d, 0x97, 0x78, 0x54, 0x5f, 0xa5, 0xc4, 0x18, 0x61, 0x1c, 0xc0,
+	0x2a, 0x0d, 0x9a, 0xd2, 0x5d, 0xa1, 0x70, 0xaa, 0xbb, 0x42, 0x71, 0x16, 0x23, 0x19, 0x6d, 0xb8,
+	0xc9, 0x60, 0xb1, 0x88, 0x6f, 0x20, 0x34, 0x67, 0xd1, 0x7e, 0xc6, 0x55, 0xc9, 0x53, 0x57, 0x55,
+	0xbf, 0x08, 0xe4, 0xac, 0x45, 0x51, 0x5d, 0x0b, 0xe3, 0x13, 0x58, 0x13, 0xe2, 0xdc, 0x94, 0xbb,
+	0xb0, 0xc8, 0x30, 0x7c, 0x33, 0x25, 0x6d, 0xe1, 0x34, 0xe3, 0x2e, 0x20, 0x3a, 0x6a, 0x61, 0xb5,
+	0x5e, 0x48, 0xed, 0x1b, 0xe3, 0x01, 0x6c, 0x26, 0xb8, 0xe2, 0xab, 0x8d, 0x61, 0xe4, 0xd5, 0xc6,
+	0xc1, 0xfb, 0x9f, 0x2b, 0x7d, 0x45, 0x54, 0x03, 0x34, 0xec, 0x3e, 0xe9, 0xf6, 0x9e, 0x75, 0x4f,
+	0xcd, 0xa7, 0x66, 0x77, 0x70, 0x3a, 0x78, 0xde, 0x37, 0xb5, 0x1b, 0x08, 0x60, 0xb1, 0x69, 0x99,
+	0x8d, 0x81, 0xa9, 0x15, 0xc8, 0x78, 0xd8, 0x6f, 0x91, 0x71, 0xf1, 0x7e, 0x3b, 0xdb, 0xb3, 0x42,
+	0xbb, 0xa0, 0x0b, 0x1d, 0x76, 0xfb, 0xb8, 0xdb, 0xe8, 0x9c, 0x0e, 0x1a, 0xd6, 0xb1, 0x29, 0x75,
+	0xad, 0xc0, 0x52, 0xb3, 0xd7, 0x1d, 0x98, 0xdd, 0x81, 0x56, 0x40, 0xcb, 0xb0, 0x30, 0xb4, 0x4d,
+	0x4b, 0x2b, 0xde, 0xff, 0x43, 0x21, 0xd3, 0xea, 0x41, 0x77, 0xa0, 0x9e, 0x56, 0xf5, 0xbc, 0x6f,
+	0x36, 0x3b, 0x0d, 0xdb, 0xd6, 0x6e, 0x10, 0x63, 0x1b, 0xad, 0x96, 0x7d, 0x3a, 0xe8, 0x9d, 0xb6,
+	0xda, 0x76, 0x73, 0x68, 0xdb, 0xed, 0x5e, 0x57, 0x2b, 0x10, 0xfc, 0x51, 0xaf, 0xd3, 0xe9, 0x3d,
+	0xb3, 0x4f, 0x8f, 0x87, 0xed, 0x96, 0xd9, 0x69, 0x77, 0x4d, 0x5b, 0x2b, 0xa2, 0x75, 0x58, 0x39,
+	0xe9, 0xb5, 0x4e, 0x1b, 0xcd, 0x41, 0xbb, 0xd7, 0xb5, 0xb5, 0x12, 0xd2, 0x60, 0xb5, 0x3f, 0x3c,
+	0xec, 0xb4, 0x9b, 0xa7, 0x03, 0x6b, 0x68, 0x0f, 0xb4, 0x05, 0xe2, 0x5b, 0xb7, 0x71, 0xd2, 0xee,
+	0x1e, 0x6b, 0x65, 0x62, 0xda, 0xd1, 0xc7, 0xff, 0xf7, 0x50, 0x5b, 0x54, 0xf8, 0xcc, 0x8e, 0xd9,
+	0x1c, 0x68, 0x4b, 0xf7, 0xbf, 0x2b, 0xa8, 0x5d, 0x25, 0xb4, 0x0d, 0x9b, 0x39, 0x76, 0xb2, 0xb8,
+	0x0d, 0xfb, 0x4f, 0x7b, 0x34, 0x6e, 0xab, 0xb0, 0xdc, 0xea, 0x3d, 0xeb, 0x52, 0xa8, 0x88, 0x36,
+	0xe0, 0xa6, 0x65, 0xf6, 0x7b, 0xd6, 0x80, 0x98, 0x7f, 0xd2, 0x6b, 0x69, 0x25, 0xc2, 0x70, 0xd2,
+	0x6b, 0x1d, 0x76, 0x7a, 0xcd, 0x27, 0xda, 0x02, 0x5a, 0x03, 0x38, 0xe9, 0xb5, 0x1a, 0xfd, 0xbe,
+	0xd5, 0x7b, 0x6a, 0x6a, 0x65, 0x74, 0x13, 0x2a, 0x27, 0xbd, 0x56, 0xfb, 0xb8, 0xdb, 0xb3, 0x4c,
+	0x6d, 0x91, 0x68, 0x66, 0x4e, 0x6a, 0x4b, 0xa8, 0x02, 0x65, 0x26, 0xb5, 0x4c, 0x7c, 0xec, 0x36,
+	0x4e, 0xcc, 0xd3, 0x86, 0x4d, 0x0c, 0xd1, 0x2a, 0x64, 0x9e, 0xa6, 0xd9, 0xb5, 0x7b, 0x96, 0x40,
+	0x01, 0x61, 0x67, 0x7e, 0xac, 0x90, 0x49, 0x5a, 0x6d, 0xfb, 0xcb, 0x61, 0xa3, 0xd3, 0x3e, 0x7a,
+	0xae, 0xad, 0x92, 0xb5, 0xb1, 0xcc, 0x81, 0xd5, 0x68, 0x0e, 0xb4, 0x9b, 0xf7, 0x43, 0xa8, 0xe6,
+	0x35, 0x77, 0x54, 0x6f, 0xcd, 0xee, 0xa0, 0x3d, 0x78, 0x2e, 0xbc, 0x25, 0x76, 0xf4, 0x1a, 0x56,
+	0x8b, 0x6d, 0x92, 0xc1, 0x63, 0xcb, 0x6c, 0xb4, 0xb4, 0x22, 0x09, 0x64, 0xbf, 0x67, 0x0f, 0xb4,
+	0x12, 0x19, 0x51, 0xf7, 0x17, 0xd0, 0x12, 0x94, 0x9e, 0x98, 0xcf, 0xb5, 0x32, 0xb1, 0x80, 0x06,
+	0xdf, 0x1e, 0x90, 0x1d, 0xb5, 0x78, 0xf0, 0x7d, 0x15, 0x56, 0x8e, 0x02, 0xda, 0x5a, 0x1d, 0x35,
+	0xfa, 0x6d, 0x74, 0x09, 0xb5, 0xfc, 0xcf, 0xb6, 0xe8, 0xae, 0x68, 0xe2, 0xbd, 0xee, 0x53, 0xb0,
+	0xfe, 0xc1, 0x0f, 0x70, 0xf1, 0x02, 0xf0, 0x06, 0xb2, 0x60, 0xe3, 0x18, 0x47, 0xc9, 0xaf, 0xa4,
+	0xe8, 0x0e, 0x97, 0xce, 0xfd, 0x60, 0xab, 0xef, 0xcc, 0xa1, 0x4a, 0x9d, 0x43, 0x40, 0xc7, 0x38,
+	0x4a, 0x7d, 0x48, 0x44, 0x42, 0x2c, 0xff, 0x9b, 0xa6, 0xbe, 0x3b, 0x8f, 0x2c, 0xd5, 0x36, 0x61,
+	0xf5, 0x18, 0x47, 0xf2, 0x8b, 0x32, 0x12, 0xfd, 0xe1, 0xf4, 0xd7, 0x6b, 0xbd, 0x9e, 0x25, 0x48,
+	0x25, 0x6d, 0x58, 0xb3, 0xb9, 0x6d, 0x6c, 0x27, 0xa3, 0x5b, 0xea, 0xc4, 0x89, 0x32, 0x53, 0xd7,
+	0xf3, 0x48, 0x52, 0x55, 0x07, 0xd6, 0x8f, 0x71, 0xa4, 0x7e, 0xba, 0x42, 0x42, 0x20, 0xe7, 0x8b,
+	0x9e, 0x7e, 0x3b, 0x97, 0x26, 0xb5, 0x9d, 0x80, 0x46, 0xba, 0x0e, 0x6a, 0x7b, 0x5d, 0xaa, 0xcb,
+	0xf9, 0x64, 0xa1, 0xdf, 0xce, 0xa1, 0x29, 0xea, 0xbe, 0x80, 0x75, 0xa2, 0x4e, 0x69, 0xd8, 0x4a,
+	0x47, 0xb3, 0x8d, 0x7a, 0x5d, 0xcf, 0x92, 0x14, 0x5d, 0x97, 0x50, 0x27, 0x8e, 0xe6, 0xf5, 0x4a,
+	0xd1, 0xfb, 0x73, 0xfa, 0xa1, 0x6a, 0x07, 0x58, 0xbf, 0xfb, 0x7a, 0x26, 0x39, 0xd1, 0x57, 0x70,
+	0x8b, 0x18, 0x9d, 0xdb, 0x23, 0x94, 0x9b, 0x32, 0x97, 0xaa, 0xef, 0xcc, 0xa1, 0x4a, 0xdd, 0x36,
+	0x54, 0x39, 0x6f, 0xa2, 0x47, 0x87, 0x44, 0x1c, 0xf3, 0x3a, 0x7a, 0xfa, 0x9d, 0x7c, 0xa2, 0x54,
+	0xda, 0x82, 0x75, 0xce, 0x2a, 0x9a, 0x79, 0x48, 0x74, 0xe8, 0x53, 0x0d, 0x3f, 0x7d, 0x3b, 0x83,
+	0x57, 0x96, 0x1e, 0x71, 0x2e, 0xa5, 0xd1, 0x27, 0x97, 0x2b, 0xdb, 0x12, 0xd4, 0xf5, 0x3c, 0x52,
+	0x8e, 0xa7, 0x89, 0x5e, 0x9c, 0xf4, 0x34, 0xaf, 0x6d, 0xa8, 0xdf, 0xc9, 0x27, 0x4a, 0xa5, 0x0e,
+	0x4d, 0x48, 0x39, 0xcd, 0x3d, 0xf4, 0x5f, 0x79, 0x92, 0x89, 0xe6, 0xa2, 0x6e, 0xcc, 0x67, 0x49,
+	0xa6, 0x0d, 0x1b, 0x47, 0xa9, 0xe6, 0x9e, 0x4c, 0x1b, 0xf9, 0x8d, 0x43, 0x7d, 0x77, 0x1e, 0x59,
+	0xaa, 0x3d, 0x82, 0x15, 0xa5, 0x9d, 0x17, 0x9f, 0x82, 0x4c, 0xa7, 0x50, 0xd7, 0xb3, 0x24, 0x45,
+	0xcf, 0x53, 0xd6, 0x16, 0x4c, 0xf5, 0xd2, 0xa4, 0x7d, 0xf9, 0x7d, 0x3d, 0x7d, 0x37, 0x9f, 0xac,
+	0xe8, 0xed, 0xc3, 0x26, 0x77, 0x46, 0x6d, 0xa4, 0xa1, 0x44, 0xee, 0x49, 0x36, 0xe8, 0xf4, 0xdb,
+	0xb9, 0x34, 0x35, 0x51, 0xda, 0x38, 0x92, 0x6d, 0x2c, 0x99, 0x28, 0xd3, 0x6d, 0x34, 0xbd, 0x9e,
+	0x25, 0x28, 0xbb, 0x68, 0x8d, 0xc6, 0x21, 0xee, 0xdd, 0xec, 0x66, 0x9a, 0x2f, 0x89, 0x76, 0x93,
+	0xfe, 0xde, 0x5c, 0xba, 0xb2, 0x16, 0x37, 0x49, 0x0a, 0x8f, 0x75, 0xd6, 0x33, 0x32, 0x42, 0xdb,
+	0xad, 0x1c, 0x8a, 0xd4, 0xd3, 0x83, 0x35, 0xd6, 0x3d, 0x11, 0xa5, 0xa5, 0xcc, 0x0e, 0xb9, 0x7d,
+	0x1b, 0x7d, 0x67, 0x0e, 0x35, 0x75, 0x0d, 0x26, 0x0b, 0xd5, 0x8c, 0xce, 0x64, 0x6e, 0xd8, 0x99,
+	0x43, 0x55, 0x16, 0xf6, 0x26, 0x6b, 0xa4, 0xf0, 0xc6, 0x8b, 0xdc, 0x2a, 0xf9, 0xad, 0x1b, 0x7d,
+	0x77, 0x1e, 0x59, 0x75, 0x9b, 0xb5, 0x36, 0x44, 0x03, 0x21, 0x4e, 0x8a, 0x79, 0x8d, 0x16, 0x7d,
+	0x67, 0x0e, 0x55, 0x55, 0xc8, 0x3a, 0x0c, 0x73, 0x15, 0xb6, 0x27, 0xaf, 0x53, 0x98, 0x6c, 0x60,
+	0xc8, 0x3b, 0x3a, 0xae, 0xff, 0xb6, 0x53, 0x05, 0x62, 0xe6, 0x8e, 0xce, 0x94, 0x99, 0xc6, 0x0d,
+	0xf4, 0xff, 0x50, 0x21, 0x15, 0x0f, 0x2b, 0x46, 0x36, 0xd5, 0xaa, 0x40, 0x9c, 0xaa, 0xaa, 0x8a,
+	0x54, 0x24, 0x3f, 0x83, 0xca, 0x31, 0x8e, 0x28, 0x36, 0x44, 0x09, 0x26, 0x39, 0xf1, 0x56, 0x0a,
+	0xab, 0xe6, 0x09, 0x56, 0x1c, 0xb0, 0x79, 0x6f, 0xa9, 0x7c, 0x89, 0xe2, 0x43, 0xd7, 0xf3, 0x48,
+	0x52, 0xcf, 0x2f, 0x60, 0x95, 0x7f, 0x3d, 0xa2, 0x7f, 0xdf, 0x43, 0x62, 0xc2, 0xe4, 0xdf, 0x01,
+	0xf5, 0x5a, 0x1a, 0x2d, 0x15, 0x60, 0xa8, 0x93, 0x93, 0x97, 0xf7, 0x09, 0x0a, 0x89, 0x4c, 0xfa,
+	0x9a, 0x6f, 0x62, 0xfa, 0xfb, 0xaf, 0xe1, 0x89, 0xa7, 0x39, 0xd4, 0xbf, 0xaa, 0x3b, 0x38, 0xba,
+	0xc2, 0xc1, 0xff, 0x9e, 0xfb, 0x01, 0x7e, 0xc0, 0xbe, 0x86, 0xb0, 0x3f, 0x40, 0x9e, 0x2d, 0x52,
+	0xe8, 0xd1, 0xbf, 0x07, 0x00, 0xf6, 0xbd, 0xdc, 0xcb, 0x16, 0x29, 0x00, 0x00,
 }
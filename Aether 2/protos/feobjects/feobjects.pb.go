@@ -17,6 +17,7 @@ It has these top-level messages:
 	CompiledUserSignalsEntity
 	AmbientBoardEntity
 	BackendAmbientStatus
+	BootstrapSourceProgress
 	FrontendAmbientStatus
 	CompiledNotification
 	ReportsTabEntry
@@ -221,6 +222,7 @@ type CompiledThreadEntity struct {
 	Children               []*CompiledPostEntity         `protobuf:"bytes,12,rep,name=Children" json:"Children,omitempty"`
 	PostsCount             int32                         `protobuf:"varint,13,opt,name=PostsCount" json:"PostsCount,omitempty"`
 	Score                  float64                       `protobuf:"fixed64,14,opt,name=Score" json:"Score,omitempty"`
+	Edited                 bool                          `protobuf:"varint,15,opt,name=Edited" json:"Edited,omitempty"`
 }
 
 func (m *CompiledThreadEntity) Reset()                    { *m = CompiledThreadEntity{} }
@@ -326,6 +328,13 @@ func (m *CompiledThreadEntity) GetScore() float64 {
 	return 0
 }
 
+func (m *CompiledThreadEntity) GetEdited() bool {
+	if m != nil {
+		return m.Edited
+	}
+	return false
+}
+
 type CompiledPostEntity struct {
 	Fingerprint            string                        `protobuf:"bytes,1,opt,name=Fingerprint" json:"Fingerprint,omitempty"`
 	Board                  string                        `protobuf:"bytes,2,opt,name=Board" json:"Board,omitempty"`
@@ -339,6 +348,7 @@ type CompiledPostEntity struct {
 	LastUpdate             int64                         `protobuf:"varint,10,opt,name=LastUpdate" json:"LastUpdate,omitempty"`
 	Meta                   string                        `protobuf:"bytes,11,opt,name=Meta" json:"Meta,omitempty"`
 	Children               []*CompiledPostEntity         `protobuf:"bytes,12,rep,name=Children" json:"Children,omitempty"`
+	Edited                 bool                          `protobuf:"varint,13,opt,name=Edited" json:"Edited,omitempty"`
 }
 
 func (m *CompiledPostEntity) Reset()                    { *m = CompiledPostEntity{} }
@@ -430,6 +440,13 @@ func (m *CompiledPostEntity) GetChildren() []*CompiledPostEntity {
 	return nil
 }
 
+func (m *CompiledPostEntity) GetEdited() bool {
+	if m != nil {
+		return m.Edited
+	}
+	return false
+}
+
 type CompiledUserEntity struct {
 	Fingerprint         string                     `protobuf:"bytes,1,opt,name=Fingerprint" json:"Fingerprint,omitempty"`
 	NonCanonicalName    string                     `protobuf:"bytes,2,opt,name=NonCanonicalName" json:"NonCanonicalName,omitempty"`
@@ -440,6 +457,12 @@ type CompiledUserEntity struct {
 	Expiry              int64                      `protobuf:"varint,7,opt,name=Expiry" json:"Expiry,omitempty"`
 	Info                string                     `protobuf:"bytes,8,opt,name=Info" json:"Info,omitempty"`
 	Meta                string                     `protobuf:"bytes,9,opt,name=Meta" json:"Meta,omitempty"`
+	// Key succession. The chain is every key of this user, oldest first, if they have rotated their key.
+	Successor       string   `protobuf:"bytes,10,opt,name=Successor" json:"Successor,omitempty"`
+	Predecessor     string   `protobuf:"bytes,11,opt,name=Predecessor" json:"Predecessor,omitempty"`
+	Revoked         bool     `protobuf:"varint,12,opt,name=Revoked" json:"Revoked,omitempty"`
+	RevokedAt       int64    `protobuf:"varint,13,opt,name=RevokedAt" json:"RevokedAt,omitempty"`
+	SuccessionChain []string `protobuf:"bytes,14,rep,name=SuccessionChain" json:"SuccessionChain,omitempty"`
 }
 
 func (m *CompiledUserEntity) Reset()                    { *m = CompiledUserEntity{} }
@@ -510,6 +533,41 @@ func (m *CompiledUserEntity) GetMeta() string {
 	return ""
 }
 
+func (m *CompiledUserEntity) GetSuccessor() string {
+	if m != nil {
+		return m.Successor
+	}
+	return ""
+}
+
+func (m *CompiledUserEntity) GetPredecessor() string {
+	if m != nil {
+		return m.Predecessor
+	}
+	return ""
+}
+
+func (m *CompiledUserEntity) GetRevoked() bool {
+	if m != nil {
+		return m.Revoked
+	}
+	return false
+}
+
+func (m *CompiledUserEntity) GetRevokedAt() int64 {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return 0
+}
+
+func (m *CompiledUserEntity) GetSuccessionChain() []string {
+	if m != nil {
+		return m.SuccessionChain
+	}
+	return nil
+}
+
 type CompiledContentSignalsEntity struct {
 	TargetFingerprint string `protobuf:"bytes,1,opt,name=TargetFingerprint" json:"TargetFingerprint,omitempty"`
 	// ATD
@@ -926,17 +984,22 @@ type BackendAmbientStatus struct {
 	LocalNodeExternalPort int32  `protobuf:"varint,7,opt,name=LocalNodeExternalPort" json:"LocalNodeExternalPort,omitempty"`
 	UPNPStatus            string `protobuf:"bytes,8,opt,name=UPNPStatus" json:"UPNPStatus,omitempty"`
 	// ----------  DATABASE  ----------
-	DatabaseStatus            string `protobuf:"bytes,9,opt,name=DatabaseStatus" json:"DatabaseStatus,omitempty"`
-	DbSizeMb                  int64  `protobuf:"varint,10,opt,name=DbSizeMb" json:"DbSizeMb,omitempty"`
-	MaxDbSizeMb               int64  `protobuf:"varint,11,opt,name=MaxDbSizeMb" json:"MaxDbSizeMb,omitempty"`
-	LastDbInsertTimestamp     int64  `protobuf:"varint,12,opt,name=LastDbInsertTimestamp" json:"LastDbInsertTimestamp,omitempty"`
-	LastInsertDurationSeconds int32  `protobuf:"varint,13,opt,name=LastInsertDurationSeconds" json:"LastInsertDurationSeconds,omitempty"`
+	DatabaseStatus string `protobuf:"bytes,9,opt,name=DatabaseStatus" json:"DatabaseStatus,omitempty"`
+	DbSizeMb       int64  `protobuf:"varint,10,opt,name=DbSizeMb" json:"DbSizeMb,omitempty"`
+	MaxDbSizeMb    int64  `protobuf:"varint,11,opt,name=MaxDbSizeMb" json:"MaxDbSizeMb,omitempty"`
+	// Pinned content is exempt from the event horizon, so it's accounted separately: it is part of DbSizeMb, but it does not count against MaxDbSizeMb.
+	PinnedDbSizeMb            int64 `protobuf:"varint,18,opt,name=PinnedDbSizeMb" json:"PinnedDbSizeMb,omitempty"`
+	LastDbInsertTimestamp     int64 `protobuf:"varint,12,opt,name=LastDbInsertTimestamp" json:"LastDbInsertTimestamp,omitempty"`
+	LastInsertDurationSeconds int32 `protobuf:"varint,13,opt,name=LastInsertDurationSeconds" json:"LastInsertDurationSeconds,omitempty"`
 	// ----------  CACHING  ----------
 	CachingStatus                      string `protobuf:"bytes,14,opt,name=CachingStatus" json:"CachingStatus,omitempty"`
 	LastCacheGenerationTimestamp       int64  `protobuf:"varint,15,opt,name=LastCacheGenerationTimestamp" json:"LastCacheGenerationTimestamp,omitempty"`
 	LastCacheGenerationDurationSeconds int32  `protobuf:"varint,16,opt,name=LastCacheGenerationDurationSeconds" json:"LastCacheGenerationDurationSeconds,omitempty"`
 	// ----------  CONFIG LOCATION  ----------
 	BackendConfigLocation string `protobuf:"bytes,17,opt,name=BackendConfigLocation" json:"BackendConfigLocation,omitempty"`
+	// ----------  BOOTSTRAP  ----------
+	BootstrapStatus  string                     `protobuf:"bytes,19,opt,name=BootstrapStatus" json:"BootstrapStatus,omitempty"`
+	BootstrapSources []*BootstrapSourceProgress `protobuf:"bytes,20,rep,name=BootstrapSources" json:"BootstrapSources,omitempty"`
 }
 
 func (m *BackendAmbientStatus) Reset()                    { *m = BackendAmbientStatus{} }
@@ -1021,6 +1084,13 @@ func (m *BackendAmbientStatus) GetMaxDbSizeMb() int64 {
 	return 0
 }
 
+func (m *BackendAmbientStatus) GetPinnedDbSizeMb() int64 {
+	if m != nil {
+		return m.PinnedDbSizeMb
+	}
+	return 0
+}
+
 func (m *BackendAmbientStatus) GetLastDbInsertTimestamp() int64 {
 	if m != nil {
 		return m.LastDbInsertTimestamp
@@ -1063,6 +1133,79 @@ func (m *BackendAmbientStatus) GetBackendConfigLocation() string {
 	return ""
 }
 
+func (m *BackendAmbientStatus) GetBootstrapStatus() string {
+	if m != nil {
+		return m.BootstrapStatus
+	}
+	return ""
+}
+
+func (m *BackendAmbientStatus) GetBootstrapSources() []*BootstrapSourceProgress {
+	if m != nil {
+		return m.BootstrapSources
+	}
+	return nil
+}
+
+// The progress of a bootstrap from a single bootstrapper.
+type BootstrapSourceProgress struct {
+	Address        string `protobuf:"bytes,1,opt,name=Address" json:"Address,omitempty"`
+	CachesAssigned int32  `protobuf:"varint,2,opt,name=CachesAssigned" json:"CachesAssigned,omitempty"`
+	CachesDone     int32  `protobuf:"varint,3,opt,name=CachesDone" json:"CachesDone,omitempty"`
+	CachesFailed   int32  `protobuf:"varint,4,opt,name=CachesFailed" json:"CachesFailed,omitempty"`
+	// Entities that other bootstrappers listed in a time range this one claims to cover, but this one did not.
+	EntitiesWithheld int32 `protobuf:"varint,5,opt,name=EntitiesWithheld" json:"EntitiesWithheld,omitempty"`
+	// Entities that were still missing after the first pass, and that we fetched from this one in the retry pass.
+	EntitiesRetried int32 `protobuf:"varint,6,opt,name=EntitiesRetried" json:"EntitiesRetried,omitempty"`
+}
+
+func (m *BootstrapSourceProgress) Reset()                    { *m = BootstrapSourceProgress{} }
+func (m *BootstrapSourceProgress) String() string            { return proto.CompactTextString(m) }
+func (*BootstrapSourceProgress) ProtoMessage()               {}
+func (*BootstrapSourceProgress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *BootstrapSourceProgress) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *BootstrapSourceProgress) GetCachesAssigned() int32 {
+	if m != nil {
+		return m.CachesAssigned
+	}
+	return 0
+}
+
+func (m *BootstrapSourceProgress) GetCachesDone() int32 {
+	if m != nil {
+		return m.CachesDone
+	}
+	return 0
+}
+
+func (m *BootstrapSourceProgress) GetCachesFailed() int32 {
+	if m != nil {
+		return m.CachesFailed
+	}
+	return 0
+}
+
+func (m *BootstrapSourceProgress) GetEntitiesWithheld() int32 {
+	if m != nil {
+		return m.EntitiesWithheld
+	}
+	return 0
+}
+
+func (m *BootstrapSourceProgress) GetEntitiesRetried() int32 {
+	if m != nil {
+		return m.EntitiesRetried
+	}
+	return 0
+}
+
 type FrontendAmbientStatus struct {
 	RefresherStatus            string `protobuf:"bytes,1,opt,name=RefresherStatus" json:"RefresherStatus,omitempty"`
 	LastRefreshTimestamp       int64  `protobuf:"varint,2,opt,name=LastRefreshTimestamp" json:"LastRefreshTimestamp,omitempty"`
@@ -1074,7 +1217,7 @@ type FrontendAmbientStatus struct {
 func (m *FrontendAmbientStatus) Reset()                    { *m = FrontendAmbientStatus{} }
 func (m *FrontendAmbientStatus) String() string            { return proto.CompactTextString(m) }
 func (*FrontendAmbientStatus) ProtoMessage()               {}
-func (*FrontendAmbientStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*FrontendAmbientStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *FrontendAmbientStatus) GetRefresherStatus() string {
 	if m != nil {
@@ -1125,7 +1268,7 @@ type CompiledNotification struct {
 func (m *CompiledNotification) Reset()                    { *m = CompiledNotification{} }
 func (m *CompiledNotification) String() string            { return proto.CompactTextString(m) }
 func (*CompiledNotification) ProtoMessage()               {}
-func (*CompiledNotification) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*CompiledNotification) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *CompiledNotification) GetType() NotificationType {
 	if m != nil {
@@ -1194,7 +1337,7 @@ type ReportsTabEntry struct {
 func (m *ReportsTabEntry) Reset()                    { *m = ReportsTabEntry{} }
 func (m *ReportsTabEntry) String() string            { return proto.CompactTextString(m) }
 func (*ReportsTabEntry) ProtoMessage()               {}
-func (*ReportsTabEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*ReportsTabEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *ReportsTabEntry) GetFingerprint() string {
 	if m != nil {
@@ -1241,6 +1384,7 @@ func init() {
 	proto.RegisterType((*CompiledUserSignalsEntity)(nil), "feobjects.CompiledUserSignalsEntity")
 	proto.RegisterType((*AmbientBoardEntity)(nil), "feobjects.AmbientBoardEntity")
 	proto.RegisterType((*BackendAmbientStatus)(nil), "feobjects.BackendAmbientStatus")
+	proto.RegisterType((*BootstrapSourceProgress)(nil), "feobjects.BootstrapSourceProgress")
 	proto.RegisterType((*FrontendAmbientStatus)(nil), "feobjects.FrontendAmbientStatus")
 	proto.RegisterType((*CompiledNotification)(nil), "feobjects.CompiledNotification")
 	proto.RegisterType((*ReportsTabEntry)(nil), "feobjects.ReportsTabEntry")
@@ -1250,118 +1394,131 @@ func init() {
 func init() { proto.RegisterFile("feobjects/feobjects.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1805 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x4b, 0x73, 0x1b, 0xc7,
-	0x11, 0x0e, 0x88, 0x07, 0x81, 0x01, 0xf8, 0x1a, 0x3e, 0xb4, 0x94, 0x28, 0x05, 0x85, 0x52, 0x25,
-	0x28, 0x55, 0x22, 0x25, 0x54, 0x92, 0x4a, 0x54, 0x89, 0x12, 0xe2, 0xc1, 0x04, 0x15, 0x12, 0x40,
-	0x2d, 0xc0, 0xa8, 0x94, 0x0b, 0x6b, 0x81, 0x1d, 0x92, 0x6b, 0x82, 0x33, 0xa8, 0xdd, 0x81, 0x48,
-	0xf8, 0xe6, 0xf2, 0xc1, 0x57, 0xfb, 0xe4, 0x8b, 0xed, 0x3f, 0xe5, 0xab, 0x7f, 0x89, 0x4f, 0xae,
-	0x9e, 0x99, 0xdd, 0x9d, 0x7d, 0x00, 0xa2, 0x6c, 0x1f, 0x7d, 0x9b, 0xf9, 0xba, 0x67, 0x76, 0xba,
-	0xfb, 0xeb, 0xee, 0x99, 0x45, 0xfb, 0x17, 0x84, 0x8d, 0x3e, 0x22, 0x63, 0xee, 0xbd, 0x08, 0x46,
-	0xcf, 0xa7, 0x2e, 0xe3, 0x0c, 0x97, 0x02, 0xa0, 0xf6, 0x79, 0x1e, 0x6d, 0x37, 0xd9, 0xcd, 0xd4,
-	0x99, 0x10, 0xbb, 0xc1, 0x2c, 0xd7, 0x6e, 0x53, 0xee, 0xf0, 0x39, 0xae, 0xa2, 0xf2, 0xb1, 0x43,
-	0x2f, 0x89, 0x3b, 0x75, 0x1d, 0xca, 0x8d, 0x4c, 0x35, 0x53, 0x2f, 0x99, 0x3a, 0x04, 0x1a, 0x03,
-	0x32, 0xb9, 0x68, 0xba, 0xc4, 0xe2, 0xc4, 0x36, 0x56, 0xaa, 0x99, 0x7a, 0xd1, 0xd4, 0x21, 0x8c,
-	0x51, 0xae, 0x6b, 0xdd, 0x10, 0x23, 0x2b, 0x16, 0x8b, 0x31, 0xac, 0x6a, 0x11, 0x6f, 0xec, 0x3a,
-	0x53, 0xee, 0x30, 0x6a, 0xe4, 0xe4, 0xbe, 0x1a, 0x84, 0xcf, 0xd1, 0x9e, 0x7f, 0xa0, 0x26, 0xa3,
-	0x9c, 0x50, 0x3e, 0x70, 0x2e, 0xa9, 0x35, 0xf1, 0x8c, 0x7c, 0x35, 0x53, 0x2f, 0x1f, 0xfe, 0xf6,
-	0x79, 0x68, 0x4e, 0xba, 0xa2, 0x34, 0xc1, 0x5c, 0xb0, 0x0d, 0x7e, 0x89, 0xf2, 0xbd, 0x5b, 0x4a,
-	0x5c, 0xa3, 0x20, 0xf6, 0x7b, 0x9c, 0xb2, 0xdf, 0x99, 0x47, 0x5c, 0xb5, 0x8b, 0xd4, 0x85, 0x73,
-	0x0b, 0xf7, 0x88, 0x99, 0x67, 0xac, 0x56, 0xb3, 0x70, 0x6e, 0x0d, 0xc2, 0x0f, 0x51, 0x51, 0x18,
-	0x0e, 0x66, 0x15, 0xab, 0x99, 0x7a, 0xd6, 0x0c, 0xe6, 0xf8, 0x09, 0x42, 0x27, 0x96, 0xc7, 0xcf,
-	0xa6, 0xb6, 0xc5, 0x89, 0x51, 0x12, 0x52, 0x0d, 0x01, 0x4f, 0x9d, 0x12, 0x6e, 0x19, 0x48, 0x7a,
-	0x0a, 0xc6, 0xb8, 0x89, 0x2a, 0xcd, 0x2b, 0x67, 0x62, 0x0f, 0xaf, 0x5c, 0x62, 0xd9, 0x9e, 0x51,
-	0xae, 0x66, 0xeb, 0xe5, 0xc3, 0x5f, 0xa7, 0x9c, 0x56, 0x6a, 0xa8, 0xf3, 0x46, 0x16, 0xe1, 0x1a,
-	0xaa, 0xa8, 0x61, 0x93, 0xcd, 0x28, 0x37, 0x2a, 0xd5, 0x4c, 0x3d, 0x6f, 0x46, 0x30, 0x7c, 0x80,
-	0x4a, 0x60, 0xaf, 0x54, 0x58, 0x13, 0x0a, 0x21, 0x00, 0x47, 0x1f, 0xcc, 0x46, 0x10, 0x9e, 0x11,
-	0xb1, 0x8d, 0x75, 0x11, 0x65, 0x0d, 0xc1, 0x7b, 0xa8, 0xd0, 0x65, 0xdc, 0xb9, 0x98, 0x1b, 0x1b,
-	0x42, 0xa6, 0x66, 0xe0, 0x0e, 0x30, 0x70, 0x40, 0x08, 0x35, 0x36, 0xa5, 0x3b, 0xfc, 0x39, 0x7c,
-	0x71, 0x70, 0xfc, 0xe6, 0xc4, 0xf1, 0x80, 0x38, 0x5b, 0x62, 0x59, 0x08, 0xd4, 0x3e, 0xcd, 0xa1,
-	0x9d, 0x34, 0xd3, 0xee, 0xc1, 0xc9, 0x1d, 0x94, 0x17, 0x21, 0x11, 0x6c, 0x2c, 0x99, 0x72, 0x12,
-	0x67, 0x6a, 0x76, 0x31, 0x53, 0x73, 0x1a, 0x53, 0x31, 0xca, 0x35, 0x98, 0x3d, 0x17, 0xac, 0x2b,
-	0x99, 0x62, 0x0c, 0xd8, 0x89, 0x43, 0xaf, 0x05, 0x73, 0x4a, 0xa6, 0x18, 0x2f, 0xe1, 0xeb, 0xea,
-	0xcf, 0xcc, 0xd7, 0xe2, 0x07, 0xf0, 0x55, 0x67, 0x63, 0x69, 0x29, 0x1b, 0xd1, 0x42, 0x36, 0x96,
-	0x35, 0x36, 0xfe, 0x0d, 0x15, 0x05, 0xb1, 0x5c, 0x42, 0x8d, 0x8a, 0x60, 0x62, 0xda, 0x39, 0xfa,
-	0xcc, 0xe3, 0xea, 0x1c, 0x81, 0x3a, 0x7c, 0x0e, 0x70, 0x4f, 0x27, 0x98, 0x86, 0x40, 0xd0, 0x06,
-	0x63, 0xe6, 0x12, 0x41, 0xae, 0x8c, 0x29, 0x27, 0xb5, 0x6f, 0xb3, 0x08, 0x27, 0xb7, 0xfd, 0xd1,
-	0x1c, 0xd8, 0x43, 0x05, 0xc9, 0x25, 0x55, 0x8d, 0xd4, 0x0c, 0xf0, 0xbe, 0xe5, 0x12, 0xca, 0x55,
-	0xec, 0xd5, 0x2c, 0xce, 0x99, 0x7c, 0x2a, 0x67, 0x04, 0x3f, 0x0a, 0x1a, 0x3f, 0x7e, 0xe1, 0xc2,
-	0x72, 0x2e, 0xd4, 0xbe, 0x5b, 0x09, 0xa3, 0x1a, 0x1e, 0xf4, 0x1e, 0x51, 0x7d, 0x86, 0x36, 0xbb,
-	0x8c, 0x36, 0x2d, 0xca, 0xa8, 0x33, 0xb6, 0x26, 0x22, 0x5b, 0x65, 0x80, 0x13, 0x78, 0xc4, 0xde,
-	0xec, 0x52, 0x7b, 0x73, 0x09, 0x7b, 0x9f, 0xa2, 0x35, 0x98, 0x99, 0xe4, 0xc2, 0x25, 0xde, 0x95,
-	0x8a, 0x7c, 0xd6, 0x8c, 0x82, 0xf8, 0x7f, 0x61, 0xd3, 0x04, 0x2b, 0xfc, 0x20, 0xcb, 0x86, 0xf2,
-	0x74, 0x41, 0x50, 0xa2, 0x11, 0x4e, 0xdb, 0x00, 0xd8, 0xd8, 0xbe, 0x9b, 0x3a, 0xee, 0x5c, 0xf0,
-	0x25, 0x6b, 0xaa, 0x19, 0x44, 0xa1, 0x43, 0x2f, 0x98, 0x88, 0x7a, 0xc9, 0x14, 0xe3, 0x20, 0x32,
-	0xa5, 0x30, 0x32, 0xb5, 0xaf, 0x56, 0xd1, 0xc1, 0x32, 0x5e, 0xe1, 0xdf, 0xa1, 0xad, 0xa1, 0xe5,
-	0x5e, 0x12, 0x9e, 0x74, 0x77, 0x52, 0x80, 0x0d, 0xb4, 0x7a, 0x36, 0x7d, 0xc7, 0x38, 0xf1, 0x84,
-	0xaf, 0xf3, 0xa6, 0x3f, 0x85, 0x0a, 0xde, 0x62, 0xb7, 0x54, 0xca, 0xb2, 0xb2, 0x67, 0x04, 0x80,
-	0x9f, 0x3c, 0x52, 0xd9, 0x16, 0x5e, 0x56, 0xc9, 0xa3, 0x20, 0x70, 0x33, 0x4c, 0xfd, 0x25, 0x7e,
-	0x82, 0x45, 0x41, 0x5c, 0x47, 0x1b, 0x00, 0x1c, 0x0d, 0x5b, 0x41, 0x3c, 0x0b, 0xc2, 0x2f, 0x71,
-	0x18, 0xec, 0x52, 0x90, 0x16, 0x5d, 0xe9, 0xc3, 0xa4, 0x00, 0x3f, 0x47, 0x58, 0x81, 0xba, 0x1b,
-	0xa4, 0x73, 0x53, 0x24, 0xf8, 0x15, 0x5a, 0x35, 0xc9, 0x94, 0xb9, 0xdc, 0x33, 0x4a, 0x82, 0xef,
-	0x55, 0x2d, 0xc4, 0xed, 0xbb, 0xe9, 0xc4, 0x72, 0x28, 0xb1, 0xa5, 0xa7, 0x55, 0x78, 0xfd, 0x05,
-	0xf8, 0x35, 0x2a, 0x9d, 0x32, 0xbb, 0x31, 0x61, 0xe3, 0x6b, 0xbf, 0x87, 0xbf, 0x7f, 0x75, 0xb8,
-	0x04, 0xb7, 0x50, 0xe5, 0x94, 0xd9, 0x47, 0xd3, 0xa9, 0xcb, 0xde, 0x01, 0xc7, 0x2a, 0xf7, 0xdc,
-	0x22, 0xb2, 0x4a, 0x14, 0xc5, 0xf9, 0x29, 0xb3, 0x45, 0xf9, 0x2d, 0x9a, 0x72, 0x02, 0x49, 0xd5,
-	0x98, 0x1f, 0xb3, 0xc9, 0x84, 0xdd, 0x12, 0xbb, 0x4f, 0x5c, 0x8f, 0x51, 0xd5, 0xe1, 0x13, 0x38,
-	0xc4, 0xa2, 0x31, 0x17, 0x67, 0x0a, 0x54, 0x65, 0xc3, 0x8f, 0xc3, 0xa2, 0x30, 0xce, 0x7b, 0x7d,
-	0xd1, 0xf5, 0x8b, 0xa6, 0x18, 0x43, 0xda, 0xf9, 0x26, 0x05, 0x2d, 0x5f, 0x43, 0x80, 0x31, 0xc1,
-	0x79, 0x89, 0x6d, 0x60, 0xc9, 0x18, 0x0d, 0x4a, 0x26, 0xe6, 0x76, 0x5a, 0x62, 0x2a, 0xc6, 0xe8,
-	0x7b, 0xed, 0xc8, 0x53, 0xc6, 0x60, 0xfc, 0x1b, 0xb4, 0xae, 0x20, 0xff, 0x54, 0xbb, 0x42, 0x31,
-	0x86, 0x6a, 0x7a, 0x9d, 0x4b, 0xca, 0x5c, 0x62, 0x1b, 0x7b, 0x11, 0x3d, 0x85, 0xc2, 0x4d, 0x0b,
-	0x10, 0x19, 0x76, 0x62, 0x1b, 0x0f, 0x84, 0x56, 0x04, 0xab, 0x7d, 0x96, 0x41, 0xbb, 0xa9, 0xd1,
-	0x82, 0x92, 0x35, 0x60, 0x33, 0x77, 0x4c, 0x8e, 0xa7, 0x2a, 0x1d, 0x83, 0x39, 0x14, 0x05, 0x93,
-	0x58, 0xe0, 0x70, 0x59, 0xf0, 0xd4, 0xec, 0xa7, 0x94, 0xb9, 0xda, 0x17, 0x79, 0xb4, 0xbf, 0xb0,
-	0x36, 0x7d, 0x60, 0x95, 0xd8, 0x43, 0x85, 0x16, 0xbb, 0xb1, 0x9c, 0xe0, 0x7c, 0x72, 0x06, 0x9e,
-	0xf3, 0x39, 0xd4, 0x98, 0x83, 0x1f, 0xd4, 0xcd, 0x2b, 0x86, 0x42, 0x64, 0x95, 0xb3, 0x95, 0x9a,
-	0xac, 0x17, 0x51, 0x10, 0xb4, 0xd4, 0x3a, 0x75, 0x53, 0xcd, 0x8b, 0xaa, 0x13, 0x05, 0x41, 0x2b,
-	0xda, 0x23, 0x64, 0x77, 0x8e, 0x82, 0xf8, 0x2f, 0x68, 0xaf, 0x09, 0x03, 0xe5, 0x62, 0xcd, 0xc8,
-	0x55, 0xa1, 0xbe, 0x40, 0xea, 0x57, 0x99, 0x7e, 0x3b, 0x59, 0x36, 0x92, 0x02, 0x9f, 0x39, 0xfd,
-	0x76, 0xac, 0xf9, 0xc6, 0x50, 0xc8, 0x42, 0x89, 0x24, 0x1a, 0x71, 0x02, 0x07, 0xfb, 0x4e, 0x2d,
-	0x9b, 0x00, 0x3f, 0xa5, 0xaf, 0xca, 0xd2, 0x57, 0x11, 0x10, 0x76, 0x04, 0xa0, 0xcb, 0x68, 0xa8,
-	0x58, 0x91, 0x79, 0x1d, 0xc7, 0x7d, 0x5d, 0x01, 0xb4, 0xc8, 0x85, 0x35, 0x9b, 0x70, 0x55, 0x24,
-	0x12, 0x78, 0x44, 0xb7, 0x4b, 0xf8, 0x2d, 0x73, 0xaf, 0xfd, 0x7a, 0x11, 0xc7, 0xf1, 0x1f, 0xd0,
-	0xb6, 0xfe, 0x2d, 0x5f, 0x5d, 0xd6, 0x8c, 0x34, 0x51, 0xed, 0x9b, 0x0c, 0xc2, 0x47, 0x37, 0x23,
-	0x87, 0x50, 0xfe, 0x61, 0x2f, 0x51, 0xff, 0xf6, 0xbe, 0xa2, 0xdd, 0xde, 0xa3, 0x09, 0x90, 0x4d,
-	0xf4, 0x79, 0xfd, 0x79, 0x92, 0x8b, 0x3d, 0x4f, 0xc2, 0x27, 0x4d, 0x5e, 0x7f, 0xd2, 0xd4, 0xbe,
-	0x2f, 0xa0, 0x9d, 0x86, 0x35, 0xbe, 0x26, 0xd4, 0x56, 0xe7, 0x1c, 0x70, 0x8b, 0xcf, 0x3c, 0xfc,
-	0x0a, 0x19, 0xb0, 0xb8, 0x43, 0x47, 0x6c, 0x46, 0xa1, 0xf1, 0xd2, 0xa1, 0x73, 0x43, 0x3c, 0x6e,
-	0xdd, 0xc8, 0x6c, 0xce, 0x9a, 0x0b, 0xe5, 0x50, 0xb1, 0x14, 0x2e, 0xaf, 0xc3, 0x7f, 0xfc, 0xb3,
-	0xea, 0xb5, 0x71, 0x18, 0xff, 0x1d, 0xed, 0xc3, 0x2e, 0xbd, 0x19, 0x4f, 0xf9, 0x8c, 0xb4, 0x70,
-	0xb1, 0x02, 0xc4, 0xce, 0x17, 0x04, 0x1f, 0xca, 0x89, 0x0f, 0x25, 0x70, 0xfc, 0x2f, 0xf4, 0x48,
-	0xdf, 0xa8, 0x35, 0x73, 0x05, 0x53, 0x07, 0x64, 0xcc, 0xa8, 0xed, 0xa9, 0xcc, 0x5b, 0xa6, 0x02,
-	0xd1, 0x3f, 0x61, 0x90, 0x6e, 0xcc, 0x26, 0xed, 0x3b, 0x4e, 0x5c, 0x6a, 0x4d, 0x3a, 0x7d, 0x95,
-	0x8d, 0x69, 0x22, 0xfc, 0x27, 0xb4, 0x9b, 0x80, 0xfb, 0xcc, 0x95, 0x29, 0x99, 0x37, 0xd3, 0x85,
-	0x10, 0xe6, 0xb3, 0x7e, 0xb7, 0x2f, 0xe3, 0xa0, 0x52, 0x51, 0x43, 0x20, 0x07, 0x5b, 0x16, 0xb7,
-	0x46, 0x96, 0x47, 0x94, 0x8e, 0xbc, 0x2e, 0xc5, 0x50, 0xa0, 0x43, 0x6b, 0x34, 0x70, 0x3e, 0x26,
-	0xa7, 0x23, 0x95, 0x7b, 0xc1, 0x5c, 0xf4, 0x26, 0xeb, 0x2e, 0x10, 0x97, 0x85, 0x58, 0x87, 0xc4,
-	0xd9, 0x2d, 0x8f, 0xb7, 0x46, 0x1d, 0xea, 0x11, 0x97, 0x87, 0x51, 0xa9, 0x08, 0xdd, 0x74, 0xa1,
-	0x1f, 0x4f, 0x09, 0xc7, 0x7d, 0x2c, 0x9f, 0x49, 0x8b, 0x15, 0x64, 0xa5, 0x1b, 0x5f, 0x39, 0xf4,
-	0x52, 0x19, 0xb6, 0xee, 0x57, 0x3a, 0x0d, 0xc4, 0x0d, 0x74, 0x00, 0x5b, 0x00, 0x48, 0xfe, 0x4d,
-	0x28, 0x91, 0x7b, 0x84, 0x07, 0xdc, 0x10, 0x07, 0x5c, 0xaa, 0x83, 0xbb, 0xa8, 0x96, 0x22, 0x8f,
-	0x1f, 0x78, 0x53, 0x1c, 0xf8, 0x1e, 0x9a, 0xe0, 0x2d, 0x95, 0x45, 0x4d, 0x46, 0x2f, 0x9c, 0x4b,
-	0x88, 0xac, 0x28, 0x8f, 0x5b, 0xc2, 0x82, 0x74, 0x61, 0xed, 0xcb, 0x15, 0xb4, 0x7b, 0xec, 0x8a,
-	0x3b, 0x6d, 0x2c, 0xfb, 0xea, 0x68, 0xc3, 0xbf, 0x00, 0xb8, 0xca, 0x17, 0xb2, 0x48, 0xc4, 0x61,
-	0x7c, 0x88, 0x76, 0xb4, 0xeb, 0x42, 0xe8, 0x85, 0x15, 0xe1, 0x85, 0x54, 0x19, 0x7e, 0x8d, 0x1e,
-	0x6a, 0x78, 0xdc, 0x6a, 0x79, 0xf5, 0x5d, 0xa2, 0x01, 0xbd, 0xc6, 0x3f, 0x76, 0xcc, 0x5c, 0xf9,
-	0xe0, 0x5c, 0x20, 0x15, 0x37, 0x19, 0xf9, 0x4b, 0xa4, 0xe5, 0x78, 0xd6, 0x68, 0x12, 0xdc, 0x91,
-	0xe3, 0x70, 0xed, 0x93, 0x6c, 0xf8, 0xbf, 0x44, 0x54, 0x2a, 0x47, 0x6d, 0xf1, 0x02, 0xe5, 0x86,
-	0xf3, 0x29, 0x11, 0xde, 0x58, 0x3f, 0x7c, 0xa4, 0x5d, 0x19, 0x75, 0x35, 0x50, 0x31, 0x85, 0x22,
-	0x14, 0xd2, 0x21, 0xb9, 0xe3, 0x7e, 0x21, 0x85, 0x31, 0xf0, 0xcc, 0x24, 0xde, 0x94, 0x51, 0x8f,
-	0x88, 0x37, 0xbb, 0x91, 0x15, 0xbf, 0xbe, 0xa2, 0x20, 0x6e, 0xa2, 0x8a, 0x7c, 0x38, 0xab, 0x47,
-	0x76, 0x4e, 0xbc, 0x84, 0xde, 0xff, 0xb3, 0x4a, 0x5f, 0x84, 0xff, 0x81, 0x90, 0x9c, 0xc3, 0x9e,
-	0xea, 0x6f, 0xdf, 0x7b, 0x5e, 0x96, 0xda, 0x02, 0xe8, 0xce, 0x7e, 0x4f, 0x0d, 0x43, 0x2b, 0xdf,
-	0x0b, 0x49, 0x01, 0xfe, 0x2b, 0x7a, 0xd0, 0x25, 0xb7, 0x04, 0xe2, 0x26, 0x0d, 0x09, 0xd7, 0xc8,
-	0x77, 0xc3, 0x22, 0x31, 0x78, 0xc9, 0x04, 0x1b, 0x8b, 0xf2, 0x7e, 0x0b, 0xe3, 0xda, 0xd7, 0x2b,
-	0x40, 0x42, 0x71, 0xe3, 0x1f, 0x5a, 0xa3, 0x36, 0xe5, 0xee, 0x7d, 0x1a, 0x57, 0x03, 0x55, 0x44,
-	0xa7, 0xeb, 0x5b, 0xf3, 0x09, 0xb3, 0xe4, 0x1f, 0x8b, 0xf2, 0xe1, 0x93, 0x14, 0x93, 0xb5, 0x86,
-	0x68, 0x46, 0xd6, 0xe0, 0x36, 0x5a, 0x93, 0xee, 0xf3, 0x37, 0xc9, 0xde, 0xcf, 0xf5, 0xd1, 0x55,
-	0xf8, 0x9f, 0xa8, 0x0c, 0x4e, 0xf4, 0x37, 0xc9, 0xdd, 0xc7, 0xf9, 0xfa, 0x0a, 0x78, 0x11, 0x86,
-	0x1e, 0x94, 0x8f, 0xe6, 0x10, 0x78, 0xf6, 0x16, 0x9e, 0xef, 0x51, 0xce, 0xe1, 0xc7, 0x68, 0xff,
-	0xac, 0xfb, 0xdf, 0x6e, 0xef, 0x4d, 0xf7, 0xbc, 0xdb, 0x1b, 0x76, 0x8e, 0x3b, 0xcd, 0xa3, 0x61,
-	0xa7, 0xd7, 0x3d, 0x1f, 0xbe, 0xed, 0xb7, 0x37, 0x7f, 0x85, 0xb7, 0xd1, 0x86, 0xd9, 0xee, 0x9f,
-	0xbc, 0x3d, 0x1f, 0xf6, 0xce, 0x87, 0xff, 0x31, 0xdb, 0x47, 0xad, 0xcd, 0x0c, 0xde, 0x42, 0x6b,
-	0x01, 0xd8, 0xef, 0x0d, 0x86, 0x9b, 0x2b, 0x8d, 0x27, 0xff, 0x3f, 0xb0, 0x08, 0xbf, 0x22, 0xee,
-	0xef, 0xc7, 0xcc, 0x25, 0x2f, 0xc4, 0x1f, 0x6e, 0xed, 0x97, 0xf7, 0xa8, 0x20, 0x90, 0x97, 0x3f,
-	0x04, 0x00, 0x00, 0xff, 0xff, 0xe8, 0x57, 0x6e, 0xfb, 0x10, 0x17, 0x00, 0x00,
+	// 2006 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0xcd, 0x73, 0x23, 0x3b,
+	0x11, 0xc7, 0xb1, 0x9d, 0xd8, 0xca, 0xe7, 0x2a, 0xd9, 0xec, 0xec, 0x7b, 0xfb, 0x16, 0x97, 0xeb,
+	0x15, 0xa4, 0x5e, 0xc1, 0x2e, 0xe4, 0x01, 0x05, 0xaf, 0xe0, 0x41, 0xfc, 0x11, 0x48, 0x91, 0x38,
+	0xae, 0xb1, 0xc3, 0xd6, 0x72, 0x49, 0x8d, 0x3d, 0x4a, 0x32, 0xc4, 0x91, 0x5c, 0x92, 0xb2, 0x89,
+	0xb9, 0x71, 0xe2, 0x0a, 0x27, 0x0e, 0x2c, 0xfc, 0x83, 0x9c, 0xb9, 0x52, 0x54, 0xb7, 0x34, 0x1e,
+	0xcd, 0x87, 0xb3, 0x59, 0xe0, 0xc8, 0x4d, 0xfa, 0x75, 0x4b, 0x23, 0x75, 0xff, 0xba, 0xd5, 0xd2,
+	0x90, 0xe7, 0x17, 0x4c, 0x8c, 0x7e, 0xc7, 0xc6, 0x5a, 0xbd, 0x9e, 0xb7, 0x5e, 0x4d, 0xa5, 0xd0,
+	0x82, 0xd6, 0xe7, 0x40, 0xf3, 0x4f, 0x55, 0xb2, 0xdd, 0x16, 0x37, 0xd3, 0x68, 0xc2, 0xc2, 0x96,
+	0x08, 0x64, 0xd8, 0xe5, 0x3a, 0xd2, 0x33, 0xda, 0x20, 0xab, 0x87, 0x11, 0xbf, 0x64, 0x72, 0x2a,
+	0x23, 0xae, 0xbd, 0x52, 0xa3, 0xb4, 0x57, 0xf7, 0x5d, 0x08, 0x34, 0x06, 0x6c, 0x72, 0xd1, 0x96,
+	0x2c, 0xd0, 0x2c, 0xf4, 0x96, 0x1a, 0xa5, 0xbd, 0x9a, 0xef, 0x42, 0x94, 0x92, 0x4a, 0x2f, 0xb8,
+	0x61, 0x5e, 0x19, 0x07, 0x63, 0x1b, 0x46, 0x75, 0x98, 0x1a, 0xcb, 0x68, 0xaa, 0x23, 0xc1, 0xbd,
+	0x8a, 0x99, 0xd7, 0x81, 0xe8, 0x39, 0xd9, 0x8d, 0x17, 0xd4, 0x16, 0x5c, 0x33, 0xae, 0x07, 0xd1,
+	0x25, 0x0f, 0x26, 0xca, 0xab, 0x36, 0x4a, 0x7b, 0xab, 0xfb, 0xdf, 0x7e, 0x95, 0x6c, 0xa7, 0x58,
+	0xd1, 0x6c, 0xc1, 0x5f, 0x30, 0x0d, 0xfd, 0x92, 0x54, 0x4f, 0xef, 0x38, 0x93, 0xde, 0x32, 0xce,
+	0xf7, 0x59, 0xc1, 0x7c, 0x67, 0x8a, 0x49, 0x3b, 0x8b, 0xd1, 0x85, 0x75, 0xa3, 0x79, 0xb0, 0xa7,
+	0xbc, 0x95, 0x46, 0x19, 0xd6, 0xed, 0x40, 0xf4, 0x13, 0x52, 0xc3, 0x8d, 0xc3, 0xb6, 0x6a, 0x8d,
+	0xd2, 0x5e, 0xd9, 0x9f, 0xf7, 0xe9, 0x4b, 0x42, 0x8e, 0x03, 0xa5, 0xcf, 0xa6, 0x61, 0xa0, 0x99,
+	0x57, 0x47, 0xa9, 0x83, 0x80, 0xa5, 0x4e, 0x98, 0x0e, 0x3c, 0x62, 0x2c, 0x05, 0x6d, 0xda, 0x26,
+	0x6b, 0xed, 0xab, 0x68, 0x12, 0x0e, 0xaf, 0x24, 0x0b, 0x42, 0xe5, 0xad, 0x36, 0xca, 0x7b, 0xab,
+	0xfb, 0xdf, 0x2c, 0x58, 0xad, 0xd1, 0xb0, 0xeb, 0x4d, 0x0d, 0xa2, 0x4d, 0xb2, 0x66, 0x9b, 0x6d,
+	0x71, 0xcb, 0xb5, 0xb7, 0xd6, 0x28, 0xed, 0x55, 0xfd, 0x14, 0x46, 0x5f, 0x90, 0x3a, 0xec, 0xd7,
+	0x28, 0xac, 0xa3, 0x42, 0x02, 0xc0, 0xd2, 0x07, 0xb7, 0x23, 0x70, 0xcf, 0x88, 0x85, 0xde, 0x06,
+	0x7a, 0xd9, 0x41, 0xe8, 0x2e, 0x59, 0xee, 0x09, 0x1d, 0x5d, 0xcc, 0xbc, 0x4d, 0x94, 0xd9, 0x1e,
+	0x98, 0x03, 0x36, 0x38, 0x60, 0x8c, 0x7b, 0x5b, 0xc6, 0x1c, 0x71, 0x1f, 0xbe, 0x38, 0x38, 0x7c,
+	0x73, 0x1c, 0x29, 0x20, 0xce, 0x13, 0x1c, 0x96, 0x00, 0xcd, 0xbf, 0x56, 0xc8, 0x4e, 0xd1, 0xd6,
+	0x1e, 0xc1, 0xc9, 0x1d, 0x52, 0x45, 0x97, 0x20, 0x1b, 0xeb, 0xbe, 0xe9, 0x64, 0x99, 0x5a, 0x5e,
+	0xcc, 0xd4, 0x8a, 0xc3, 0x54, 0x4a, 0x2a, 0x2d, 0x11, 0xce, 0x90, 0x75, 0x75, 0x1f, 0xdb, 0x80,
+	0x1d, 0x47, 0xfc, 0x1a, 0x99, 0x53, 0xf7, 0xb1, 0xfd, 0x00, 0x5f, 0x57, 0xfe, 0xc7, 0x7c, 0xad,
+	0x7d, 0x04, 0x5f, 0x5d, 0x36, 0xd6, 0x1f, 0x64, 0x23, 0x59, 0xc8, 0xc6, 0x55, 0x87, 0x8d, 0x3f,
+	0x21, 0x35, 0x24, 0x96, 0x64, 0xdc, 0x5b, 0x6b, 0x94, 0x17, 0xac, 0xa3, 0x2f, 0x94, 0xb6, 0xeb,
+	0x98, 0xab, 0xc3, 0xe7, 0x00, 0x57, 0x2e, 0xc1, 0x1c, 0x04, 0x9c, 0x36, 0x18, 0x0b, 0xc9, 0x90,
+	0x5c, 0x25, 0xdf, 0x74, 0x80, 0x57, 0xdd, 0x30, 0x02, 0x7f, 0x59, 0x5e, 0x99, 0x5e, 0xf3, 0x1f,
+	0x65, 0x42, 0xf3, 0x9f, 0xfb, 0x8f, 0xb9, 0xb1, 0x4b, 0x96, 0x0d, 0xc7, 0x6c, 0x96, 0xb2, 0x3d,
+	0xc0, 0xfb, 0x81, 0x64, 0x5c, 0x5b, 0x4e, 0xd8, 0x5e, 0x96, 0x4b, 0xd5, 0x42, 0x2e, 0x21, 0x6f,
+	0x96, 0x1d, 0xde, 0xfc, 0x9f, 0x23, 0x1f, 0xe0, 0x48, 0xe2, 0xed, 0xf5, 0x94, 0xb7, 0xff, 0xe5,
+	0x78, 0x3b, 0xd9, 0xc0, 0x23, 0xbc, 0xfd, 0x05, 0xd9, 0xea, 0x09, 0xde, 0x0e, 0xb8, 0xe0, 0xd1,
+	0x38, 0x98, 0x60, 0x74, 0x1b, 0xc7, 0xe7, 0xf0, 0x94, 0x1d, 0xca, 0x0f, 0xda, 0xa1, 0x92, 0xb3,
+	0xc3, 0xe7, 0x64, 0x1d, 0x7a, 0x3e, 0xbb, 0x90, 0x4c, 0x5d, 0x59, 0x46, 0x94, 0xfd, 0x34, 0x48,
+	0x7f, 0x43, 0xb6, 0xdd, 0x5d, 0xc4, 0xce, 0x37, 0x07, 0xd0, 0xe7, 0x0b, 0x9c, 0x95, 0xf6, 0x7c,
+	0xd1, 0x04, 0x68, 0xb6, 0xfb, 0x69, 0x24, 0x67, 0xc8, 0xa3, 0xb2, 0x6f, 0x7b, 0xe0, 0x9d, 0x23,
+	0x7e, 0x21, 0x90, 0x0d, 0x75, 0x1f, 0xdb, 0x73, 0x8f, 0xd5, 0x1d, 0x8f, 0x41, 0x22, 0xbe, 0x1d,
+	0x8f, 0x99, 0x52, 0x42, 0xda, 0xc3, 0x27, 0x01, 0xc0, 0xca, 0x7d, 0xc9, 0x42, 0x66, 0xe5, 0xc6,
+	0xd5, 0x2e, 0x44, 0x3d, 0xb2, 0xe2, 0xb3, 0x77, 0xe2, 0x9a, 0x85, 0x78, 0xb2, 0xd4, 0xfc, 0xb8,
+	0x0b, 0x33, 0xdb, 0xe6, 0x81, 0x89, 0xf9, 0xb2, 0x9f, 0x00, 0x74, 0x8f, 0x6c, 0xda, 0xcf, 0x44,
+	0x82, 0xb7, 0xaf, 0x82, 0x88, 0x7b, 0x1b, 0x78, 0xa2, 0x66, 0xe1, 0xe6, 0xfb, 0x15, 0xf2, 0xe2,
+	0xa1, 0x88, 0xa0, 0xdf, 0x21, 0x4f, 0x86, 0x81, 0xbc, 0x64, 0x3a, 0x4f, 0x88, 0xbc, 0x00, 0x16,
+	0x7c, 0x36, 0x7d, 0x27, 0x34, 0x53, 0xc8, 0x86, 0xaa, 0x1f, 0x77, 0x61, 0xc1, 0x1d, 0x71, 0xc7,
+	0x8d, 0xac, 0x6c, 0x4e, 0xc1, 0x39, 0x10, 0x87, 0xbd, 0x51, 0x0e, 0xbd, 0x4a, 0x12, 0xf6, 0x16,
+	0x02, 0x22, 0x40, 0x37, 0x1e, 0x12, 0xa7, 0x86, 0x34, 0x88, 0x1b, 0x67, 0x93, 0x8b, 0x83, 0x61,
+	0x67, 0xce, 0xb8, 0x65, 0x34, 0x4e, 0x16, 0x86, 0x7d, 0x59, 0xc8, 0xe1, 0x9f, 0xf1, 0x72, 0x5e,
+	0x40, 0x5f, 0x11, 0x6a, 0x41, 0xd7, 0x0c, 0xc6, 0xfd, 0x05, 0x12, 0xfa, 0x15, 0x38, 0x6e, 0x2a,
+	0xa4, 0x56, 0x5e, 0x1d, 0x23, 0xb5, 0xe1, 0x90, 0xb0, 0x7b, 0x3f, 0x9d, 0x04, 0x11, 0x67, 0xa1,
+	0xb1, 0xb4, 0x25, 0x60, 0x3c, 0x80, 0x7e, 0x4d, 0xea, 0x27, 0x22, 0x6c, 0x4d, 0xc4, 0xf8, 0x3a,
+	0xae, 0x4a, 0x3e, 0x3c, 0x3a, 0x19, 0x42, 0x3b, 0x64, 0xed, 0x44, 0x84, 0x07, 0xd3, 0xa9, 0x14,
+	0xef, 0x20, 0x0a, 0xd6, 0x1e, 0x39, 0x45, 0x6a, 0x14, 0xa6, 0xf3, 0xd9, 0x89, 0x88, 0x13, 0x86,
+	0xe9, 0x40, 0xd8, 0xb7, 0x66, 0x87, 0x62, 0x32, 0x11, 0x77, 0x2c, 0xec, 0x33, 0xa9, 0x04, 0xb7,
+	0x35, 0x4b, 0x0e, 0x07, 0x5f, 0xb4, 0x66, 0xb8, 0xa6, 0xb9, 0xaa, 0x39, 0x6a, 0xb2, 0x30, 0xa6,
+	0xf4, 0xd9, 0x69, 0x1f, 0xeb, 0x98, 0x9a, 0x8f, 0x6d, 0x48, 0x0c, 0xf1, 0x96, 0xe6, 0x45, 0x8c,
+	0x83, 0x00, 0x63, 0xe6, 0xeb, 0x65, 0xa1, 0x47, 0x0d, 0x63, 0x1c, 0x28, 0x9f, 0x3a, 0xb6, 0x8b,
+	0x52, 0x87, 0x65, 0x8c, 0x3b, 0xd7, 0x8e, 0x59, 0x65, 0x06, 0xa6, 0xdf, 0x22, 0x1b, 0x16, 0x8a,
+	0x57, 0xf5, 0x14, 0x15, 0x33, 0xa8, 0xa3, 0x77, 0x74, 0xc9, 0x85, 0x64, 0xa1, 0xb7, 0x9b, 0xd2,
+	0xb3, 0x28, 0xd4, 0x8e, 0x80, 0x18, 0xb7, 0xb3, 0xd0, 0x7b, 0x86, 0x5a, 0x29, 0xac, 0xf9, 0xc7,
+	0x12, 0x79, 0x5a, 0xe8, 0x2d, 0x48, 0xaa, 0x03, 0x71, 0x2b, 0xc7, 0xec, 0x70, 0x6a, 0xc3, 0x71,
+	0xde, 0x87, 0xb4, 0xe5, 0xb3, 0x00, 0x0c, 0x6e, 0x52, 0xb2, 0xed, 0xfd, 0x37, 0x89, 0xb8, 0xf9,
+	0xe7, 0x2a, 0x79, 0xbe, 0x30, 0x7b, 0x7e, 0x64, 0x96, 0xd8, 0x25, 0xcb, 0x1d, 0x71, 0x13, 0x44,
+	0xf3, 0xf5, 0x99, 0x1e, 0x58, 0x2e, 0xe6, 0x50, 0x6b, 0x06, 0x76, 0xb0, 0xb5, 0x64, 0x06, 0x05,
+	0xcf, 0x5a, 0x63, 0x5b, 0x35, 0x93, 0x2f, 0xd2, 0x20, 0x68, 0xd9, 0x71, 0xb6, 0xf6, 0xae, 0x62,
+	0xd6, 0x49, 0x83, 0xa0, 0x95, 0x3e, 0xc5, 0x4c, 0x5d, 0x91, 0x06, 0xe9, 0x8f, 0xc8, 0x6e, 0x1b,
+	0x1a, 0xd6, 0xc4, 0xce, 0x26, 0x57, 0x50, 0x7d, 0x81, 0x34, 0xce, 0x32, 0xfd, 0x6e, 0x3e, 0x6d,
+	0xe4, 0x05, 0x31, 0x73, 0xfa, 0xdd, 0x4c, 0xd9, 0x90, 0x41, 0x21, 0x0a, 0x0d, 0x92, 0x2b, 0x21,
+	0x72, 0x38, 0xec, 0xef, 0x24, 0x08, 0xd9, 0x89, 0xb0, 0x66, 0xc1, 0x63, 0xa6, 0xe6, 0xa7, 0x41,
+	0x98, 0x11, 0x80, 0x9e, 0xe0, 0x89, 0xa2, 0x39, 0x71, 0x72, 0x78, 0xac, 0x8b, 0x40, 0x87, 0x5d,
+	0x04, 0xb7, 0x13, 0x6d, 0x93, 0x44, 0x0e, 0x4f, 0xe9, 0xf6, 0x98, 0xbe, 0x13, 0xf2, 0x3a, 0xce,
+	0x17, 0x59, 0x9c, 0x7e, 0x8f, 0x6c, 0xbb, 0xdf, 0x8a, 0xd5, 0x4d, 0xce, 0x28, 0x12, 0x35, 0xff,
+	0x5e, 0x22, 0xf4, 0xe0, 0x66, 0x14, 0x31, 0xae, 0x3f, 0xee, 0x6e, 0x1d, 0xdf, 0x47, 0x96, 0x9c,
+	0xfb, 0x48, 0x3a, 0x00, 0xca, 0xb9, 0x4a, 0xc4, 0xbd, 0x70, 0x55, 0x32, 0x17, 0xae, 0xe4, 0x92,
+	0x56, 0x75, 0x2f, 0x69, 0xcd, 0xf7, 0x35, 0xb2, 0xd3, 0x0a, 0xc6, 0xd7, 0x8c, 0x87, 0x76, 0x9d,
+	0x03, 0x1d, 0xe8, 0x5b, 0x45, 0xbf, 0x22, 0x1e, 0x0c, 0x3e, 0xe2, 0x23, 0x71, 0xcb, 0xe1, 0xe0,
+	0xe5, 0xc3, 0xe8, 0x86, 0x29, 0x1d, 0xdc, 0x98, 0x68, 0x2e, 0xfb, 0x0b, 0xe5, 0x90, 0xb1, 0x2c,
+	0x6e, 0x0a, 0xfc, 0xef, 0xff, 0xd0, 0x9e, 0xb5, 0x59, 0x98, 0xfe, 0x94, 0x3c, 0x87, 0x59, 0x4e,
+	0x6f, 0x75, 0xc1, 0x67, 0xcc, 0x0e, 0x17, 0x2b, 0x80, 0xef, 0x62, 0xc1, 0xfc, 0x43, 0x15, 0xfc,
+	0x50, 0x0e, 0xa7, 0xbf, 0x20, 0x9f, 0xba, 0x13, 0x75, 0x6e, 0x25, 0x32, 0x75, 0xc0, 0xc6, 0x82,
+	0x87, 0xca, 0x46, 0xde, 0x43, 0x2a, 0xe0, 0xfd, 0x63, 0x01, 0xe1, 0x26, 0x42, 0xd6, 0xbd, 0xd7,
+	0x4c, 0xf2, 0x60, 0x72, 0xd4, 0xb7, 0xd1, 0x58, 0x24, 0xa2, 0x3f, 0x20, 0x4f, 0x73, 0x70, 0x5f,
+	0x48, 0x13, 0x92, 0x55, 0xbf, 0x58, 0x08, 0x6e, 0x3e, 0xeb, 0xf7, 0xfa, 0xc6, 0x0f, 0x36, 0x14,
+	0x1d, 0x04, 0x62, 0xb0, 0x13, 0xe8, 0x60, 0x14, 0x28, 0x66, 0x75, 0x4c, 0x41, 0x97, 0x41, 0x81,
+	0x0e, 0x9d, 0xd1, 0x20, 0xfa, 0x3d, 0x3b, 0x19, 0xd9, 0xd8, 0x9b, 0xf7, 0xf1, 0x6c, 0x0a, 0xee,
+	0xe7, 0xe2, 0x55, 0x14, 0xbb, 0x10, 0x7c, 0xa5, 0x1f, 0x71, 0xce, 0xc2, 0xb9, 0x12, 0x35, 0x91,
+	0x9e, 0x46, 0x71, 0x8f, 0x81, 0xd2, 0x9d, 0xd1, 0x11, 0x57, 0x4c, 0xea, 0xc4, 0x7b, 0x6b, 0xa8,
+	0x5e, 0x2c, 0x8c, 0xfd, 0x6e, 0xe0, 0xac, 0x2f, 0xcc, 0x05, 0x71, 0xb1, 0x82, 0xc9, 0x88, 0xe3,
+	0xab, 0x88, 0x5f, 0x5a, 0x03, 0x6c, 0xc4, 0x19, 0xd1, 0x01, 0x69, 0x8b, 0xbc, 0x80, 0x29, 0x00,
+	0x64, 0xbf, 0x64, 0x9c, 0x99, 0x39, 0x92, 0x05, 0x6e, 0xe2, 0x02, 0x1f, 0xd4, 0xa1, 0x3d, 0xd2,
+	0x2c, 0x90, 0x67, 0x17, 0xbc, 0x85, 0x0b, 0x7e, 0x84, 0x26, 0x58, 0xcb, 0x46, 0x5b, 0x5b, 0xf0,
+	0x8b, 0xe8, 0x12, 0x18, 0x00, 0x72, 0x2c, 0x1f, 0xea, 0x7e, 0xb1, 0x10, 0xeb, 0x14, 0x21, 0xb4,
+	0xd2, 0x32, 0x98, 0xda, 0x1d, 0x6f, 0xa3, 0x7e, 0x16, 0xa6, 0x3d, 0xb2, 0x95, 0x40, 0x98, 0xeb,
+	0x95, 0xb7, 0x83, 0xd5, 0x55, 0xd3, 0xa9, 0xae, 0x32, 0x2a, 0x7d, 0x29, 0x2e, 0x25, 0x53, 0xca,
+	0xcf, 0x8d, 0x6d, 0xfe, 0xb3, 0x44, 0x9e, 0x2d, 0xd0, 0x86, 0x4a, 0xfa, 0x20, 0x0c, 0xa1, 0x69,
+	0x13, 0x58, 0xdc, 0x05, 0xee, 0xa0, 0x1d, 0xd4, 0x81, 0x52, 0xd1, 0x25, 0xb7, 0x6f, 0x83, 0x55,
+	0x3f, 0x83, 0x02, 0xd3, 0x0d, 0xd2, 0x11, 0x9c, 0xd9, 0x92, 0xdb, 0x41, 0xa0, 0xfe, 0x30, 0xbd,
+	0xc3, 0x00, 0x0e, 0x75, 0x1b, 0xdb, 0x29, 0x0c, 0x72, 0x00, 0x26, 0xd5, 0x88, 0xa9, 0x37, 0x91,
+	0xbe, 0xba, 0x62, 0x93, 0xd0, 0x06, 0x73, 0x0e, 0x07, 0x3b, 0xc6, 0x98, 0xcf, 0xb4, 0x8c, 0x58,
+	0x88, 0xd1, 0x5b, 0xf5, 0xb3, 0x70, 0xf3, 0x2f, 0x4b, 0xe4, 0xe9, 0xa1, 0xc4, 0xdb, 0x46, 0x26,
+	0x2f, 0xee, 0x91, 0xcd, 0xb8, 0x34, 0x93, 0xd6, 0x17, 0x66, 0xf7, 0x59, 0x98, 0xee, 0x93, 0x1d,
+	0xa7, 0x90, 0x4b, 0x78, 0xb7, 0x84, 0xbc, 0x2b, 0x94, 0xd1, 0xaf, 0xc9, 0x27, 0x0e, 0x9e, 0xe5,
+	0x99, 0xb1, 0xd0, 0x03, 0x1a, 0x50, 0x05, 0xc4, 0xcb, 0xce, 0x10, 0xcc, 0x3c, 0x62, 0x2c, 0x90,
+	0x62, 0x8d, 0x69, 0x9e, 0xdf, 0x3a, 0x91, 0x0a, 0x46, 0x93, 0xf9, 0xed, 0x25, 0x0b, 0x37, 0xff,
+	0x50, 0x4e, 0xde, 0xe6, 0xf0, 0x0c, 0x89, 0xec, 0x14, 0xaf, 0x49, 0x65, 0x38, 0x9b, 0x32, 0xb4,
+	0xc6, 0xc6, 0xfe, 0xa7, 0x0e, 0xdd, 0x5c, 0x35, 0x50, 0xf1, 0x51, 0x11, 0x8e, 0xb8, 0x21, 0xbb,
+	0xd7, 0xf1, 0x11, 0x07, 0x6d, 0x88, 0x6c, 0x9f, 0xa9, 0xa9, 0xe0, 0x8a, 0xe1, 0xfb, 0x90, 0x57,
+	0xc6, 0x4b, 0x61, 0x1a, 0x84, 0x87, 0x51, 0xf3, 0x18, 0x63, 0x1f, 0x6e, 0x2a, 0x8d, 0xd2, 0xa3,
+	0x1e, 0x46, 0xdd, 0x41, 0xf4, 0x67, 0x84, 0x98, 0x3e, 0xcc, 0x69, 0x5f, 0x96, 0x3f, 0xf0, 0x5a,
+	0xe1, 0x0c, 0x80, 0xba, 0x29, 0xae, 0x76, 0x12, 0xd7, 0x9a, 0x9b, 0x5c, 0x5e, 0x40, 0x7f, 0x4c,
+	0x9e, 0xf5, 0xd8, 0x1d, 0x53, 0x3a, 0xde, 0x48, 0x32, 0xc6, 0xdc, 0xe8, 0x16, 0x89, 0xc1, 0x4a,
+	0x3e, 0xec, 0xb1, 0x66, 0x6e, 0x1e, 0xd0, 0x6e, 0xfe, 0x6d, 0x89, 0x6c, 0x9a, 0x02, 0x5c, 0x0d,
+	0x83, 0x51, 0x97, 0x6b, 0xf9, 0x98, 0x92, 0xa2, 0x45, 0xd6, 0xb0, 0x06, 0xe9, 0x07, 0xb3, 0x89,
+	0x08, 0x4c, 0x4c, 0xae, 0xee, 0xbf, 0x2c, 0xd8, 0xb2, 0x53, 0xaa, 0xf8, 0xa9, 0x31, 0xb4, 0x4b,
+	0xd6, 0x8d, 0xf9, 0xe2, 0x49, 0xca, 0x8f, 0x33, 0x7d, 0x7a, 0x14, 0xfd, 0x39, 0x59, 0x05, 0x23,
+	0xc6, 0x93, 0x54, 0x1e, 0x63, 0x7c, 0x77, 0x04, 0xdc, 0xd5, 0x13, 0x0b, 0x9a, 0x07, 0x97, 0x04,
+	0xf8, 0xe2, 0x2d, 0xd9, 0xca, 0x72, 0x8e, 0x7e, 0x46, 0x9e, 0x9f, 0xf5, 0x7e, 0xdd, 0x3b, 0x7d,
+	0xd3, 0x3b, 0xef, 0x9d, 0x0e, 0x8f, 0x0e, 0x8f, 0xda, 0x07, 0xc3, 0xa3, 0xd3, 0xde, 0xf9, 0xf0,
+	0x6d, 0xbf, 0xbb, 0xf5, 0x0d, 0xba, 0x4d, 0x36, 0xfd, 0x6e, 0xff, 0xf8, 0xed, 0xf9, 0xf0, 0xf4,
+	0x7c, 0xf8, 0x2b, 0xbf, 0x7b, 0xd0, 0xd9, 0x2a, 0xd1, 0x27, 0x64, 0x7d, 0x0e, 0xf6, 0x4f, 0x07,
+	0xc3, 0xad, 0xa5, 0xd6, 0xcb, 0xdf, 0xbe, 0x08, 0x98, 0xbe, 0x62, 0xf2, 0xbb, 0xf0, 0x46, 0xf9,
+	0x1a, 0xff, 0xa6, 0x38, 0xbf, 0x57, 0x46, 0xcb, 0x88, 0x7c, 0xf9, 0xef, 0x01, 0x00, 0xc0, 0x95,
+	0x5f, 0x38, 0x7c, 0x19, 0x00, 0x00,
 }
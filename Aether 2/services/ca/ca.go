@@ -20,6 +20,16 @@ func IsTrustedCAKey(publicKey string) bool {
 	return false
 }
 
+// IsListedCAKey checks whether a key is in the list of our trusted CA keys. Unlike IsTrustedCAKey, this does not trust every key while the CAs are being debugged, so use it where trusting a key that isn't a CA would let anyone act as one in front of everyone, like the gateway applying F451s.
+func IsListedCAKey(publicKey string) bool {
+	for key, _ := range trustedCAs {
+		if publicKey == trustedCAs[key] {
+			return true
+		}
+	}
+	return false
+}
+
 func IsTrustedCAKeyWithPriority(publicKey string) (bool, int) {
 	return true, 0 // TODO (debug)
 	for key, _ := range trustedCAs {
@@ -7,11 +7,30 @@ import (
 	"aether-core/services/toolbox"
 	"fmt"
 	// "github.com/davecgh/go-spew/spew"
+	"math"
+	"net"
 	"sync"
 	"time"
 )
 
-// These two are local variables that only affect this specific library. Since there is no reason to modify them from the outside, this is not brought into the main settings JSON.
+/*
+How does the bouncer decide?
+
+Every request that comes in asks for a lease. A lease is held by a location, sublocation and port, and it lasts until that remote stops making requests for a while. If the remote already has a lease, it is renewed, otherwise a new one is given if there is room.
+
+Having room is not only about the total count. Since a lease is held by a port, a single host could take every inbound slot we have by connecting from many ports. To stop that, a new lease also has to fit into these:
+
+- How many leases a single IP can hold at the same time, and how many a single IP prefix (/24 for IPv4, /48 for IPv6) can hold. A prefix is usually one network, and whoever controls one address in it can usually use many more.
+- A token bucket for every IP, and every IP prefix. Every request takes a token, and the bucket fills back up at a fixed rate. This is what keeps a remote that already has a lease from hammering us through it.
+- A token bucket for every remote node public key. A node proves it has the key in the signed POST requests it makes, so this one is charged once that is verified (see ChargeNodeKey). This stops one node from getting around the IP limits by coming in from many addresses.
+- Some of the inbound slots are reserved for our neighbours (see neighbours.go). Everybody else can fill the rest, but not those, so the nodes we already keep in touch with can always reach us.
+
+Reverse connections bypass all of this, as before, since all of them are triggered locally.
+
+The remotes that come in through our hidden service (Tor or I2P) all come from the local Tor client or I2P router, so their IPs tell us nothing. They share one lease location (hiddenServiceLocation), which has no per-IP limit, but counts as one prefix: together, they can hold as many leases as a single prefix, and they share its token bucket. Their node keys are charged like everybody else's.
+*/
+
+// These are local variables that only affect this specific library. Since there is no reason to modify them from the outside, this is not brought into the main settings JSON.
 const (
 	activeInboundLeaseDurationSeconds  = 60  // 1m
 	activeOutboundLeaseDurationSeconds = 900 // 15m
@@ -22,16 +41,34 @@ const (
 	minimumActivesFlushIntervalSeconds = 60   // 1m
 	minimumHistoryFlushIntervalSeconds = 3600 // 1h
 	// ^ This relates to dropping from history, not adding to history. Adding to history happens on active flush.
+
+	// Token buckets. Burst is how many requests can be made at once, rate is how many come back per second. The per-IP ones are in the backend config (IPBucketBurst, IPBucketRate), and a prefix gets this many times those.
+	prefixBucketMultiplier = 4
+	nodeKeyBucketBurst     = 60
+	nodeKeyBucketRate      = 1
+
+	// The fraction of MaxInboundConns a single IP, a single prefix can hold, and the fraction reserved for neighbours.
+	perIPLeaseDivisor        = 4
+	perPrefixLeaseDivisor    = 2
+	neighbourReservedDivisor = 5
+
+	ipv4PrefixBits = 24
+	ipv6PrefixBits = 48
+
+	hiddenServiceLocation = "hidden-service"
 )
 
 type Bouncer struct {
 	lock             sync.Mutex
-	Inbounds         []ConnectionRecord
-	Outbounds        []ConnectionRecord // as of now, not used
+	Inbounds         map[string]*ConnectionRecord
+	Outbounds        map[string]*ConnectionRecord
 	InboundHistory   []ConnectionRecord
 	OutboundHistory  []ConnectionRecord
 	ActivesLastFlush Timestamp
 	HistoryLastFlush Timestamp
+	ipBuckets        map[string]*tokenBucket
+	prefixBuckets    map[string]*tokenBucket
+	nodeKeyBuckets   map[string]*tokenBucket
 }
 
 type ConnectionRecord struct {
@@ -49,13 +86,9 @@ type ConnectionRecord struct {
 	ConnDurationSeconds float64
 }
 
-func (n *ConnectionRecord) equal(c ConnectionRecord) bool {
-	// fmt.Printf("Equal was called between %v and %v, and the result was %v\n", n, c, eqresult)
-	return n.Location == c.Location &&
-		n.Sublocation == n.Sublocation &&
-		n.Port == c.Port &&
-		n.Inbound_ReverseConn == c.Inbound_ReverseConn &&
-		n.Outbound_ReverseConn == c.Outbound_ReverseConn
+// key is what the record is indexed by in the active lease maps. Two records are the same lease if and only if their keys are equal.
+func (n *ConnectionRecord) key() string {
+	return fmt.Sprintf("%s/%s/%d/%t/%t", n.Location, n.Sublocation, n.Port, n.Inbound_ReverseConn, n.Outbound_ReverseConn)
 }
 func (n *ConnectionRecord) hasActiveInboundLease() bool {
 	cutoff := Timestamp(time.Now().Add(-(time.Duration(activeInboundLeaseDurationSeconds) * time.Second)).Unix())
@@ -91,24 +124,79 @@ func (n *ConnectionRecord) hasHistoryOutboundLease() bool {
 	}
 }
 
-func (n *Bouncer) indexOf(direction string, loc, subloc string, port uint16, inboundRev, outboundRev bool) int {
-	switch direction {
-	case "inbound":
-		for key, _ := range n.Inbounds {
-			if n.Inbounds[key].equal(ConnectionRecord{Location: loc, Sublocation: subloc, Port: port, Inbound_ReverseConn: inboundRev, Outbound_ReverseConn: outboundRev}) {
-				return key
-			}
-		}
-		return -1
-	case "outbound":
-		for key, _ := range n.Outbounds {
-			if n.Outbounds[key].equal(ConnectionRecord{Location: loc, Sublocation: subloc, Port: port, Inbound_ReverseConn: inboundRev, Outbound_ReverseConn: outboundRev}) {
-				return key
-			}
+/*----------  Token buckets  ----------*/
+
+type tokenBucket struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+// fill adds the tokens that came back since the last fill.
+func (t *tokenBucket) fill(burst, rate float64, now time.Time) {
+	t.tokens = math.Min(burst, t.tokens+now.Sub(t.lastFill).Seconds()*rate)
+	t.lastFill = now
+}
+
+// bucket returns the bucket for the key, filled up to now. A key without a bucket gets a full one.
+func bucket(buckets map[string]*tokenBucket, key string, burst, rate float64, now time.Time) *tokenBucket {
+	b, ok := buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, lastFill: now}
+		buckets[key] = b
+	}
+	b.fill(burst, rate, now)
+	return b
+}
+
+// ipBucketLimits returns the burst and the rate of the bucket of a single IP.
+func ipBucketLimits() (float64, float64) {
+	return float64(bc.GetIPBucketBurst()), float64(bc.GetIPBucketRate())
+}
+
+// pruneBuckets drops the buckets that are full. A full bucket is the same as no bucket, so there is no reason to keep it.
+func pruneBuckets(buckets map[string]*tokenBucket, burst, rate float64, now time.Time) {
+	for key, _ := range buckets {
+		buckets[key].fill(burst, rate, now)
+		if buckets[key].tokens >= burst {
+			delete(buckets, key)
 		}
-		return -1
-	default:
-		return -1
+	}
+}
+
+/*----------  Internal helpers  ----------*/
+
+// ipPrefix returns the network an IP is in: /24 for IPv4, /48 for IPv6. If the location is not an IP, the location itself is returned.
+func ipPrefix(loc string) string {
+	ip := net.ParseIP(loc)
+	if ip == nil {
+		return loc
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return fmt.Sprintf("%s/%d", v4.Mask(net.CIDRMask(ipv4PrefixBits, 32)).String(), ipv4PrefixBits)
+	}
+	return fmt.Sprintf("%s/%d", ip.Mask(net.CIDRMask(ipv6PrefixBits, 128)).String(), ipv6PrefixBits)
+}
+
+// divideUp is the integer division that rounds up, so that a small MaxInboundConns does not end up giving a share of zero.
+func divideUp(a, b int) int {
+	return (a + b - 1) / b
+}
+
+func (n *Bouncer) makeMapsIfNeeded() {
+	if n.Inbounds == nil {
+		n.Inbounds = make(map[string]*ConnectionRecord)
+	}
+	if n.Outbounds == nil {
+		n.Outbounds = make(map[string]*ConnectionRecord)
+	}
+	if n.ipBuckets == nil {
+		n.ipBuckets = make(map[string]*tokenBucket)
+	}
+	if n.prefixBuckets == nil {
+		n.prefixBuckets = make(map[string]*tokenBucket)
+	}
+	if n.nodeKeyBuckets == nil {
+		n.nodeKeyBuckets = make(map[string]*tokenBucket)
 	}
 }
 
@@ -118,44 +206,35 @@ func (n *Bouncer) insert(direction string, loc, subloc string, port uint16, isRe
 	switch direction {
 	case "inbound":
 		entry.Inbound_ReverseConn = isReverseConn
-		n.Inbounds = append(n.Inbounds, entry)
+		n.Inbounds[entry.key()] = &entry
 	case "outbound":
 		entry.Outbound_ReverseConn = isReverseConn
-		n.Outbounds = append(n.Outbounds, entry)
+		n.Outbounds[entry.key()] = &entry
 	default:
 		panic(fmt.Sprintf("You gave an invalid direction to insert in Bouncer. Direction: %v", direction))
 	}
 }
 
-func (n *Bouncer) removeItem(direction string, i int) {
-	finalList := []ConnectionRecord{}
+// remove moves an active lease into history.
+func (n *Bouncer) remove(direction string, key string) {
 	switch direction {
 	case "inbound":
-		finalList = append(n.Inbounds[0:i], n.Inbounds[i+1:len(n.Inbounds)]...)
-		n.Inbounds[i].ConnDurationSeconds = calcDuration(n.Inbounds[i])
-		// move to inbound history
-		n.InboundHistory = append(n.InboundHistory, n.Inbounds[i])
-		inboundExpiredHook(n.Inbounds[i])
-		n.Inbounds = finalList
+		c := *n.Inbounds[key]
+		c.ConnDurationSeconds = calcDuration(c)
+		n.InboundHistory = append(n.InboundHistory, c)
+		inboundExpiredHook(c)
+		delete(n.Inbounds, key)
 	case "outbound":
-		finalList = append(n.Outbounds[0:i], n.Outbounds[i+1:len(n.Outbounds)]...)
-		n.Outbounds[i].ConnDurationSeconds = calcDuration(n.Outbounds[i])
-		// move to outbound history
-		n.OutboundHistory = append(n.OutboundHistory, n.Outbounds[i])
-		outboundExpiredHook(n.Outbounds[i])
-		n.Outbounds = finalList
-	case "inboundHistory":
-		finalList = append(n.InboundHistory[0:i], n.InboundHistory[i+1:len(n.InboundHistory)]...)
-		n.InboundHistory[i].ConnDurationSeconds = calcDuration(n.InboundHistory[i])
-		n.InboundHistory = finalList
-	case "outboundHistory":
-		finalList = append(n.OutboundHistory[0:i], n.OutboundHistory[i+1:len(n.OutboundHistory)]...)
-		n.OutboundHistory[i].ConnDurationSeconds = calcDuration(n.OutboundHistory[i])
-		n.OutboundHistory = finalList
+		c := *n.Outbounds[key]
+		c.ConnDurationSeconds = calcDuration(c)
+		n.OutboundHistory = append(n.OutboundHistory, c)
+		outboundExpiredHook(c)
+		delete(n.Outbounds, key)
 	}
 }
 
 func (n *Bouncer) flush() {
+	n.makeMapsIfNeeded()
 	n.flushActives()
 	n.flushHistory()
 }
@@ -166,36 +245,103 @@ func (n *Bouncer) flushActives() {
 		return
 	}
 	// Set ActivesLastFlush to now if the gate above passes.
-	n.ActivesLastFlush = Timestamp(time.Now().Add(-(time.Duration(minimumActivesFlushIntervalSeconds) * time.Second)).Unix())
-	for i := len(n.Inbounds) - 1; i >= 0; i-- {
-		if !n.Inbounds[i].hasActiveInboundLease() {
-			n.removeItem("inbound", i)
+	n.ActivesLastFlush = Timestamp(time.Now().Unix())
+	for key, _ := range n.Inbounds {
+		if !n.Inbounds[key].hasActiveInboundLease() {
+			n.remove("inbound", key)
 		}
 	}
-	for i := len(n.Outbounds) - 1; i >= 0; i-- {
-		if !n.Outbounds[i].hasActiveOutboundLease() {
-			n.removeItem("outbound", i)
+	for key, _ := range n.Outbounds {
+		if !n.Outbounds[key].hasActiveOutboundLease() {
+			n.remove("outbound", key)
 		}
 	}
+	now := time.Now()
+	ipBurst, ipRate := ipBucketLimits()
+	pruneBuckets(n.ipBuckets, ipBurst, ipRate, now)
+	pruneBuckets(n.prefixBuckets, ipBurst*prefixBucketMultiplier, ipRate*prefixBucketMultiplier, now)
+	pruneBuckets(n.nodeKeyBuckets, nodeKeyBucketBurst, nodeKeyBucketRate, now)
 }
 
 func (n *Bouncer) flushHistory() {
 	if n.HistoryLastFlush > Timestamp(time.Now().Add(-(time.Duration(minimumHistoryFlushIntervalSeconds) * time.Second)).Unix()) {
 		return
 	}
-	n.HistoryLastFlush = Timestamp(time.Now().Add(-(time.Duration(minimumHistoryFlushIntervalSeconds) * time.Second)).Unix())
-	for i := len(n.InboundHistory) - 1; i >= 0; i-- {
-		if !n.InboundHistory[i].hasHistoryInboundLease() {
-			n.removeItem("inboundHistory", i)
+	n.HistoryLastFlush = Timestamp(time.Now().Unix())
+	inboundHistory := []ConnectionRecord{}
+	for key, _ := range n.InboundHistory {
+		if n.InboundHistory[key].hasHistoryInboundLease() {
+			inboundHistory = append(inboundHistory, n.InboundHistory[key])
 		}
 	}
-	for i := len(n.OutboundHistory) - 1; i >= 0; i-- {
-		if !n.OutboundHistory[i].hasHistoryOutboundLease() {
-			n.removeItem("outboundHistory", i)
+	n.InboundHistory = inboundHistory
+	outboundHistory := []ConnectionRecord{}
+	for key, _ := range n.OutboundHistory {
+		if n.OutboundHistory[key].hasHistoryOutboundLease() {
+			outboundHistory = append(outboundHistory, n.OutboundHistory[key])
 		}
 	}
+	n.OutboundHistory = outboundHistory
 }
 
+// takeInboundTokens takes a token from the buckets of the IP and of its prefix. If either is empty, it takes from neither.
+func (n *Bouncer) takeInboundTokens(ip, prefix string) bool {
+	now := time.Now()
+	ipBurst, ipRate := ipBucketLimits()
+	ipb := bucket(n.ipBuckets, ip, ipBurst, ipRate, now)
+	pb := bucket(n.prefixBuckets, prefix, ipBurst*prefixBucketMultiplier, ipRate*prefixBucketMultiplier, now)
+	if ipb.tokens < 1 || pb.tokens < 1 {
+		return false
+	}
+	ipb.tokens--
+	pb.tokens--
+	return true
+}
+
+// hasRoomForInbound checks whether a new inbound lease from this location fits into the total, per IP and per prefix limits, and leaves the slots reserved for neighbours alone.
+func (n *Bouncer) hasRoomForInbound(loc, prefix string) bool {
+	max := bc.GetMaxInboundConns()
+	neighbours := Btc.NeighboursList.Locations()
+	isNeighbour := neighbours[loc]
+	total, fromIP, fromPrefix, fromNeighbours := 0, 0, 0, 0
+	for key, _ := range n.Inbounds {
+		l := n.Inbounds[key]
+		total++
+		if l.Inbound_ReverseConn {
+			// Reverse conns don't count against the remote, they come from us.
+			continue
+		}
+		if l.Location == loc {
+			fromIP++
+		}
+		if ipPrefix(l.Location) == prefix {
+			fromPrefix++
+		}
+		if neighbours[l.Location] {
+			fromNeighbours++
+		}
+	}
+	if (loc != hiddenServiceLocation && fromIP >= divideUp(max, perIPLeaseDivisor)) ||
+		fromPrefix >= divideUp(max, perPrefixLeaseDivisor) {
+		return false
+	}
+	if isNeighbour {
+		return total < max
+	}
+	// The reserved slots that neighbours are not already using are kept empty.
+	reserved := max / neighbourReservedDivisor
+	if len(neighbours) < reserved {
+		reserved = len(neighbours)
+	}
+	reserved = reserved - fromNeighbours
+	if reserved < 0 {
+		reserved = 0
+	}
+	return total+reserved < max
+}
+
+/*----------  Main API  ----------*/
+
 func (n *Bouncer) RequestInboundLease(loc, subloc string, port uint16, isReverseConn bool) bool {
 	n.lock.Lock()
 	defer n.lock.Unlock()
@@ -204,30 +350,84 @@ func (n *Bouncer) RequestInboundLease(loc, subloc string, port uint16, isReverse
 		return false
 	}
 	n.flush()
-	// fmt.Println("An inbound lease was requested.")
+	lease := ConnectionRecord{Location: loc, Sublocation: subloc, Port: port, Inbound_ReverseConn: isReverseConn}
+	existing, ok := n.Inbounds[lease.key()]
 	/*
-		See "reverse" bypasses on both the true cases below. If it's a reverse connection, we always accept. If you want to limit reverse connections, do it from where we trigger them, all reverses are locally triggered.
+		If it's a reverse connection, we always accept. If you want to limit reverse connections, do it from where we trigger them, all reverses are locally triggered.
 	*/
-	leaseIndex := n.indexOf("inbound", loc, subloc, port, isReverseConn, false)
-	if leaseIndex != -1 && n.Inbounds[leaseIndex].hasActiveInboundLease() {
-		// fmt.Println("Lease was renewed.")
-		// fmt.Printf("lease index: %v, inbounds: %#v", leaseIndex, n.Inbounds)
-		n.Inbounds[leaseIndex].LastAccess = Timestamp(time.Now().Unix())
-		return true
-	} else {
-		if len(n.Inbounds) < bc.GetMaxInboundConns() ||
-			isReverseConn {
-			n.insert("inbound", loc, subloc, port, isReverseConn)
-			// fmt.Println("Lease was granted.")
+	if isReverseConn {
+		if ok && existing.hasActiveInboundLease() {
+			existing.LastAccess = Timestamp(time.Now().Unix())
 			return true
-		} else {
-			// fmt.Println("A lease was denied.")
-			return false
 		}
+		n.insert("inbound", loc, subloc, port, isReverseConn)
+		return true
+	}
+	// Every request pays a token, whether it has a lease or not.
+	prefix := ipPrefix(loc)
+	if !n.takeInboundTokens(loc, prefix) {
+		return false
+	}
+	if ok && existing.hasActiveInboundLease() {
+		existing.LastAccess = Timestamp(time.Now().Unix())
+		return true
+	}
+	if ok {
+		// Expired, but not flushed yet.
+		n.remove("inbound", lease.key())
+	}
+	if !n.hasRoomForInbound(loc, prefix) {
+		return false
 	}
+	n.insert("inbound", loc, subloc, port, isReverseConn)
+	return true
+}
+
+// RequestHiddenServiceLease is RequestInboundLease for the remotes that come in through our hidden service. The port is the one the remote is connecting from to our local socket, which is different for every connection the Tor client or the I2P router forwards to us.
+func (n *Bouncer) RequestHiddenServiceLease(port uint16) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if Btc.LameduckInitiated || Btc.ShutdownInitiated {
+		return false
+	}
+	n.flush()
+	lease := ConnectionRecord{Location: hiddenServiceLocation, Port: port}
+	existing, ok := n.Inbounds[lease.key()]
+	ipBurst, ipRate := ipBucketLimits()
+	b := bucket(n.prefixBuckets, hiddenServiceLocation, ipBurst*prefixBucketMultiplier, ipRate*prefixBucketMultiplier, time.Now())
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	if ok && existing.hasActiveInboundLease() {
+		existing.LastAccess = Timestamp(time.Now().Unix())
+		return true
+	}
+	if ok {
+		n.remove("inbound", lease.key())
+	}
+	if !n.hasRoomForInbound(hiddenServiceLocation, hiddenServiceLocation) {
+		return false
+	}
+	n.insert("inbound", hiddenServiceLocation, "", port, false)
+	return true
+}
+
+/*
+ChargeNodeKey takes a token from the bucket of the remote node public key. Call this after the remote has proven it holds the key (i.e. after the PoW or the signature of its ApiResponse is verified), since otherwise anybody could drain the bucket of another node by putting its key into their requests.
+*/
+func (n *Bouncer) ChargeNodeKey(nodePublicKey string) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.flush()
+	b := bucket(n.nodeKeyBuckets, nodePublicKey, nodeKeyBucketBurst, nodeKeyBucketRate, time.Now())
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
 }
 
-// Probably works but untested. We'll use it if we end up having to gate outbound connections.
 func (n *Bouncer) RequestOutboundLease(loc, subloc string, port uint16, isReverseConn bool) bool {
 	n.lock.Lock()
 	defer n.lock.Unlock()
@@ -235,21 +435,21 @@ func (n *Bouncer) RequestOutboundLease(loc, subloc string, port uint16, isRevers
 	if Btc.LameduckInitiated || Btc.ShutdownInitiated {
 		return false
 	}
-	// fmt.Println("outbound lease requested")
-	// fmt.Println(loc, subloc, port, isReverseConn)
 	n.flush()
-	leaseIndex := n.indexOf("outbound", loc, subloc, port, false, isReverseConn)
-	if leaseIndex != -1 && n.Outbounds[leaseIndex].hasActiveOutboundLease() {
-		n.Outbounds[leaseIndex].LastAccess = Timestamp(time.Now().Unix())
+	lease := ConnectionRecord{Location: loc, Sublocation: subloc, Port: port, Outbound_ReverseConn: isReverseConn}
+	existing, ok := n.Outbounds[lease.key()]
+	if ok && existing.hasActiveOutboundLease() {
+		existing.LastAccess = Timestamp(time.Now().Unix())
 		return true
-	} else {
-		if len(n.Outbounds) < bc.GetMaxOutboundConns() || isReverseConn {
-			n.insert("outbound", loc, subloc, port, isReverseConn)
-			return true
-		} else {
-			return false
-		}
 	}
+	if ok {
+		n.remove("outbound", lease.key())
+	}
+	if len(n.Outbounds) < bc.GetMaxOutboundConns() || isReverseConn {
+		n.insert("outbound", loc, subloc, port, isReverseConn)
+		return true
+	}
+	return false
 }
 
 // ReleaseOutboundLease is idempotent if there is no such lease.
@@ -257,13 +457,13 @@ func (n *Bouncer) ReleaseOutboundLease(loc, subloc string, port uint16, wasSucce
 	n.lock.Lock()
 	defer n.lock.Unlock()
 	n.flush()
-	leaseIndex := n.indexOf("outbound", loc, subloc, port, false, isReverseConn)
-	// fmt.Printf("Lease index was %v\n", leaseIndex)
-	if leaseIndex != -1 {
-		n.Outbounds[leaseIndex].LastAccess = Timestamp(time.Now().Unix())
-		n.Outbounds[leaseIndex].Outbound_Successful = wasSuccessful
+	lease := ConnectionRecord{Location: loc, Sublocation: subloc, Port: port, Outbound_ReverseConn: isReverseConn}
+	existing, ok := n.Outbounds[lease.key()]
+	if ok {
+		existing.LastAccess = Timestamp(time.Now().Unix())
+		existing.Outbound_Successful = wasSuccessful
 		// ^ This is the only place a success data is set to potentially true. Otherwise, it's all false. Inbounds don't have any success data, and unless closed with this specifically, outbounds are assumed failed by default when they expire.
-		n.removeItem("outbound", leaseIndex)
+		n.remove("outbound", lease.key())
 	}
 }
 
@@ -274,28 +474,15 @@ func (b *Bouncer) GetLastInboundSyncTimestamp(onlyReverseConn bool) int64 {
 	defer b.lock.Unlock()
 	b.flush()
 	ts := Timestamp(0)
-	if onlyReverseConn {
-		for key, _ := range b.Inbounds {
-			if b.Inbounds[key].Inbound_ReverseConn &&
-				b.Inbounds[key].LastAccess > ts {
-				ts = b.Inbounds[key].LastAccess
-			}
-		}
-		for key, _ := range b.InboundHistory {
-			if b.InboundHistory[key].Inbound_ReverseConn &&
-				b.InboundHistory[key].LastAccess > ts {
-				ts = b.InboundHistory[key].LastAccess
-			}
-		}
-		return int64(ts)
-	}
 	for key, _ := range b.Inbounds {
-		if b.Inbounds[key].LastAccess > ts {
+		if (!onlyReverseConn || b.Inbounds[key].Inbound_ReverseConn) &&
+			b.Inbounds[key].LastAccess > ts {
 			ts = b.Inbounds[key].LastAccess
 		}
 	}
 	for key, _ := range b.InboundHistory {
-		if b.InboundHistory[key].LastAccess > ts {
+		if (!onlyReverseConn || b.InboundHistory[key].Inbound_ReverseConn) &&
+			b.InboundHistory[key].LastAccess > ts {
 			ts = b.InboundHistory[key].LastAccess
 		}
 	}
@@ -307,28 +494,15 @@ func (b *Bouncer) GetLastOutboundSyncTimestamp(onlySuccessful bool) int64 {
 	defer b.lock.Unlock()
 	b.flush()
 	ts := Timestamp(0)
-	if onlySuccessful {
-		for key, _ := range b.Outbounds {
-			if b.Outbounds[key].Outbound_Successful &&
-				b.Outbounds[key].LastAccess > ts {
-				ts = b.Outbounds[key].LastAccess
-			}
-		}
-		for key, _ := range b.OutboundHistory {
-			if b.OutboundHistory[key].Outbound_Successful &&
-				b.OutboundHistory[key].LastAccess > ts {
-				ts = b.OutboundHistory[key].LastAccess
-			}
-		}
-		return int64(ts)
-	}
 	for key, _ := range b.Outbounds {
-		if b.Outbounds[key].LastAccess > ts {
+		if (!onlySuccessful || b.Outbounds[key].Outbound_Successful) &&
+			b.Outbounds[key].LastAccess > ts {
 			ts = b.Outbounds[key].LastAccess
 		}
 	}
 	for key, _ := range b.OutboundHistory {
-		if b.OutboundHistory[key].LastAccess > ts {
+		if (!onlySuccessful || b.OutboundHistory[key].Outbound_Successful) &&
+			b.OutboundHistory[key].LastAccess > ts {
 			ts = b.OutboundHistory[key].LastAccess
 		}
 	}
@@ -343,7 +517,7 @@ func (b *Bouncer) GetInboundsInLastXMinutes(min uint) []ConnectionRecord {
 	results := []ConnectionRecord{}
 	for key, _ := range b.Inbounds {
 		if b.Inbounds[key].LastAccess > cutoff {
-			results = append(results, b.Inbounds[key])
+			results = append(results, *b.Inbounds[key])
 		}
 	}
 	for key, _ := range b.InboundHistory {
@@ -360,28 +534,15 @@ func (b *Bouncer) GetOutboundsInLastXMinutes(min uint, onlySuccessful bool) []Co
 	b.flush()
 	cutoff := Timestamp(toolbox.CnvToCutoffMinutes(int(min)))
 	results := []ConnectionRecord{}
-	if onlySuccessful {
-		for key, _ := range b.Outbounds {
-			if b.Outbounds[key].Outbound_Successful &&
-				b.Outbounds[key].LastAccess > cutoff {
-				results = append(results, b.Outbounds[key])
-			}
-		}
-		for key, _ := range b.OutboundHistory {
-			if b.OutboundHistory[key].Outbound_Successful &&
-				b.OutboundHistory[key].LastAccess > cutoff {
-				results = append(results, b.OutboundHistory[key])
-			}
-		}
-		return results
-	}
 	for key, _ := range b.Outbounds {
-		if b.Outbounds[key].LastAccess > cutoff {
-			results = append(results, b.Outbounds[key])
+		if (!onlySuccessful || b.Outbounds[key].Outbound_Successful) &&
+			b.Outbounds[key].LastAccess > cutoff {
+			results = append(results, *b.Outbounds[key])
 		}
 	}
 	for key, _ := range b.OutboundHistory {
-		if b.OutboundHistory[key].LastAccess > cutoff {
+		if (!onlySuccessful || b.OutboundHistory[key].Outbound_Successful) &&
+			b.OutboundHistory[key].LastAccess > cutoff {
 			results = append(results, b.OutboundHistory[key])
 		}
 	}
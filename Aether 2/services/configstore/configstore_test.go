@@ -1,3 +1,173 @@
 package configstore_test
 
-import ()
+import (
+	"aether-core/backend/cmd"
+	"aether-core/services/configstore"
+	"aether-core/services/globals"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// Infrastructure, setup and teardown
+
+func TestMain(m *testing.M) {
+	cmd.EstablishConfigs(nil)
+	globals.BackendTransientConfig.PermConfigReadOnly = true
+	globals.BackendConfig.SetLoggingLevel(0)
+	exitVal := m.Run()
+	os.Exit(exitVal)
+}
+
+// Bouncer
+
+func TestBouncer_ManyPortsFromOneHost(t *testing.T) {
+	var b configstore.Bouncer
+	granted := 0
+	for i := 0; i < 50; i++ {
+		if b.RequestInboundLease("203.0.113.5", "", uint16(10000+i), false) {
+			granted++
+		}
+	}
+	if granted >= globals.BackendConfig.GetMaxInboundConns() {
+		t.Errorf("A single host took all of the inbound slots by connecting from many ports. Granted: %v", granted)
+	}
+	if !b.RequestInboundLease("198.51.100.7", "", 10000, false) {
+		t.Errorf("A different host could not get a lease after one host connected from many ports.")
+	}
+}
+
+func TestBouncer_ManyHostsFromOnePrefix(t *testing.T) {
+	var b configstore.Bouncer
+	granted := 0
+	for i := 1; i < 50; i++ {
+		if b.RequestInboundLease(fmt.Sprintf("203.0.113.%d", i), "", 10000, false) {
+			granted++
+		}
+	}
+	if granted >= globals.BackendConfig.GetMaxInboundConns() {
+		t.Errorf("A single /24 took all of the inbound slots. Granted: %v", granted)
+	}
+	var b6 configstore.Bouncer
+	v6granted := 0
+	for i := 1; i < 50; i++ {
+		// Different /64s, same /48.
+		if b6.RequestInboundLease(fmt.Sprintf("2001:db8:1:%x::1", i), "", 10000, false) {
+			v6granted++
+		}
+	}
+	if v6granted >= globals.BackendConfig.GetMaxInboundConns() {
+		t.Errorf("A single /48 took all of the inbound slots. Granted: %v", v6granted)
+	}
+	if !b6.RequestInboundLease("2001:db8:2::1", "", 10000, false) {
+		t.Errorf("A host in a different /48 could not get a lease.")
+	}
+}
+
+func TestBouncer_RenewalsAreRateLimited(t *testing.T) {
+	var b configstore.Bouncer
+	if !b.RequestInboundLease("203.0.113.9", "", 10000, false) {
+		t.Fatalf("The first lease was not granted.")
+	}
+	declined := false
+	// Twice the burst, so that what fills back up during the loop doesn't keep it going.
+	for i := 0; i < 2*globals.BackendConfig.GetIPBucketBurst(); i++ {
+		if !b.RequestInboundLease("203.0.113.9", "", 10000, false) {
+			declined = true
+			break
+		}
+	}
+	if !declined {
+		t.Errorf("A host that kept renewing its lease was never rate limited.")
+	}
+	if !b.RequestInboundLease("198.51.100.9", "", 10000, false) {
+		t.Errorf("The rate limit of one host spilled over to another.")
+	}
+}
+
+func TestBouncer_ChargeNodeKey(t *testing.T) {
+	var b configstore.Bouncer
+	declined := false
+	for i := 0; i < 1000; i++ {
+		if !b.ChargeNodeKey("node-key-a") {
+			declined = true
+			break
+		}
+	}
+	if !declined {
+		t.Errorf("A node key that kept making requests was never rate limited.")
+	}
+	if !b.ChargeNodeKey("node-key-b") {
+		t.Errorf("The rate limit of one node key spilled over to another.")
+	}
+}
+
+func TestBouncer_ReservedForNeighbours(t *testing.T) {
+	max := globals.BackendConfig.GetMaxInboundConns()
+	if max < 5 {
+		t.Skip("MaxInboundConns is too small to have a slot reserved for neighbours.")
+	}
+	globals.BackendTransientConfig.NeighboursList.Push("192.0.2.77", "", 49999)
+	var b configstore.Bouncer
+	granted := 0
+	for i := 1; i < 50; i++ {
+		// Every one of these is in a different /24.
+		if b.RequestInboundLease(fmt.Sprintf("10.%d.0.1", i), "", 10000, false) {
+			granted++
+		}
+	}
+	if granted >= max {
+		t.Errorf("The other remotes took the slots reserved for neighbours. Granted: %v", granted)
+	}
+	if !b.RequestInboundLease("192.0.2.77", "", 10000, false) {
+		t.Errorf("A neighbour could not get the slot reserved for it.")
+	}
+}
+
+func TestBouncer_SublocationIsPartOfTheLease(t *testing.T) {
+	if globals.BackendConfig.GetMaxOutboundConns() != 1 {
+		t.Skip("This test needs MaxOutboundConns to be 1.")
+	}
+	var b configstore.Bouncer
+	if !b.RequestOutboundLease("203.0.113.20", "a.example.org", 80, false) {
+		t.Fatalf("The first outbound lease was not granted.")
+	}
+	if b.RequestOutboundLease("203.0.113.20", "b.example.org", 80, false) {
+		t.Errorf("A different sublocation was treated as a renewal of the lease of another.")
+	}
+	b.ReleaseOutboundLease("203.0.113.20", "a.example.org", 80, true, false)
+	if !b.RequestOutboundLease("203.0.113.20", "b.example.org", 80, false) {
+		t.Errorf("The lease could not be given after the other one was released.")
+	}
+}
+
+func TestBouncer_HiddenServiceLeases(t *testing.T) {
+	var b configstore.Bouncer
+	granted := 0
+	for i := 0; i < 50; i++ {
+		if b.RequestHiddenServiceLease(uint16(10000 + i)) {
+			granted++
+		}
+	}
+	max := globals.BackendConfig.GetMaxInboundConns()
+	// They all come from the local Tor client, so the per-IP limit would let only a quarter in. They get a prefix's worth.
+	if granted != (max+1)/2 {
+		t.Errorf("The hidden service got the wrong number of leases. Expected: %v, Granted: %v", (max+1)/2, granted)
+	}
+	if !b.RequestInboundLease("203.0.113.5", "", 10000, false) {
+		t.Errorf("A clearnet host could not get a lease after the hidden service took its share.")
+	}
+}
+
+// Neighbours
+
+func TestNeighboursList_AddressesLeavesOutSpacers(t *testing.T) {
+	var n configstore.NeighboursList
+	n.Push("192.0.2.1", "", 49999)
+	n.Push("192.0.2.2", "", 49999)
+	// The rest of the list is spacers.
+	addrs := n.Addresses()
+	if len(addrs) != 2 || addrs[0].Location != "192.0.2.2" || addrs[1].Location != "192.0.2.1" {
+		t.Errorf("The addresses of the neighbours are wrong. Addresses: %#v", addrs)
+	}
+}
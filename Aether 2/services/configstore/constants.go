@@ -17,6 +17,10 @@ const (
 	protocolVersionMinor = 0
 )
 
+// The minor version of the c0 subprotocol we serve. A minor version adds to what c0 can carry, and the remotes that are at an earlier one keep working.
+// 1: Version 2 entities. A remote at 0 can't verify them, so we don't send them to it.
+const C0SubprotocolVersionMinor = 1
+
 // Bootstrapper of last resort, if no other bootstrapper is given or found. If the user or a library higher up in the stack provides a bootstrapper, that will be used instead.
 const (
 	DefaultBootstrapperLocation    = "127.0.0.1"
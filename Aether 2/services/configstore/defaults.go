@@ -44,6 +44,7 @@ const (
 	defaultPowStrength                             = 21
 	defaultExternalIp                              = "127.0.0.1" // Localhost, if this is still 127.0.0.1 at any point in the future we failed at finding this out.
 	defaultExternalIpType                          = 4           // IPv4
+	defaultIpTypePreference                        = IpTypePreferIPv6
 	defaultExternalPort                            = 49999
 	defaultDbEngine                                = "sqlite" // 'sqlite' or 'mysql'
 	defaultDBIp                                    = "127.0.0.1"
@@ -54,10 +55,15 @@ const (
 	defaultMaxAddressTableSize                     = 1000
 	defaultMaxInboundConns                         = 5
 	defaultMaxOutboundConns                        = 1
+	defaultIPBucketBurst                           = 8000 // A bootstrapper serves one remote the manifests of all of its caches, and its share of the caches. With the default network memory and cache duration, that's 7 endpoints * 180 days * 4 caches a day = 5040 manifests, and at least a quarter as many caches, since a bootstrap spreads them across up to four bootstrappers. A full sync from scratch without a bootstrap is 5040 caches.
+	defaultIPBucketRate                            = 4    // Enough for the syncs after the first, which only ask for what's new. A spent burst fills back up in about half an hour.
 	defaultMaxDbSizeMb                             = 10000
+	defaultMaxBlobStoreSizeMb                      = 2000
 	defaultVotesMemoryDays                         = 14
 	defaultBootstrapAfterOfflineMinutes            = 360
 	defaultNodeType                                = 2
+	defaultHiddenServicePort                       = 49999
+	defaultHiddenServiceListenAddress              = "127.0.0.1:49998"
 )
 
 // Frontend defaults
@@ -74,6 +80,7 @@ const (
 	defaultBloomFilterFalsePositiveRatePercent     = 50  // 50%, divide by 100 in use.
 	defaultMinimumVoteThresholdForElectionValidity = 100 // Short of 10 votes on any direction, an election is not valid because the size is too small.
 	defaultKvStoreRetentionDays                    = 180
+	defaultFeedsPort                               = 45002
 )
 
 // Shared defaults between frontend and backend
@@ -122,6 +129,7 @@ const (
 
 /*----------  Entity versions  ----------*/
 
+// We can verify v2 boards, threads, posts, votes, keys and truststates, but we keep making v1 until most of the network is at c0 minor 1 (see C0SubprotocolVersionMinor). A v2 entity made today would not reach the remotes that can't verify it.
 const (
 	defaultBoardEntityVersion       = 1
 	defaultThreadEntityVersion      = 1
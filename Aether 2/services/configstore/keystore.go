@@ -0,0 +1,173 @@
+// Services > ConfigStore > Keystore
+// This module keeps the private keys in the config files encrypted with the user's passphrase, and holds the decrypted keys in memory once the keystore is unlocked.
+
+/*
+How does this work?
+
+Without a passphrase, the key pairs are saved as plain hex strings, the way they always were. When the user provides a passphrase for the first time, every key pair in the config is sealed (see services/keystore) and the config is committed, which is the migration of an existing config. From that point on, the config file only ever holds the sealed values.
+
+At startup, the config is loaded with the keys still sealed. The keystore is locked until UnlockKeystore is called with the right passphrase. While it is locked, the key getters crash the app, because there is no sane value to return, so nothing that needs a private key should run before the unlock. For the frontend, the unlock comes from the client through the FrontendAPI. For the backend, it comes from the frontend that spawns it, through the environment.
+
+The decrypted keys live in memory only, keyed by their sealed value, so that the getters do not have to run the KDF on every call.
+*/
+
+package configstore
+
+import (
+	"aether-core/services/keystore"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// KeystorePassphraseEnvVar is the environment variable through which the frontend hands the passphrase to the backend it spawns. The environment of a process is only readable by its owner, unlike its command line arguments.
+const KeystorePassphraseEnvVar = "AETHER_KEYSTORE_PASSPHRASE"
+
+type keystoreCache struct {
+	lock       sync.RWMutex
+	passphrase string
+	unlocked   map[string]string // sealed value > plain hex
+}
+
+var beKeystore keystoreCache
+var feKeystore keystoreCache
+
+func (k *keystoreCache) isEncrypted(fields ...string) bool {
+	for _, f := range fields {
+		if keystore.IsSealed(f) {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *keystoreCache) isLocked(fields ...string) bool {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return k.isEncrypted(fields...) && len(k.passphrase) == 0
+}
+
+// unlock opens the sealed fields with the passphrase, and seals the ones that are still plain. It returns true if any field was sealed, in which case the caller needs to commit.
+func (k *keystoreCache) unlock(passphrase string, fields ...*string) (bool, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if len(passphrase) == 0 {
+		for _, f := range fields {
+			if keystore.IsSealed(*f) {
+				return false, errors.New("The keystore is encrypted, and no passphrase was provided to unlock it.")
+			}
+		}
+		return false, nil
+	}
+	unlocked := make(map[string]string)
+	// Open everything that is sealed first, so that a wrong passphrase does not leave us with a half-migrated config.
+	for _, f := range fields {
+		if !keystore.IsSealed(*f) {
+			continue
+		}
+		plain, err := keystore.Open(*f, passphrase)
+		if err != nil {
+			return false, err
+		}
+		unlocked[*f] = plain
+	}
+	migrated := false
+	for _, f := range fields {
+		if keystore.IsSealed(*f) || len(*f) == 0 {
+			continue
+		}
+		sealed, err := keystore.Seal(*f, passphrase)
+		if err != nil {
+			return false, err
+		}
+		unlocked[sealed] = *f
+		*f = sealed
+		migrated = true
+	}
+	k.passphrase = passphrase
+	k.unlocked = unlocked
+	return migrated, nil
+}
+
+// plain returns the plain hex of a key field, whether it is sealed or not.
+func (k *keystoreCache) plain(val string) (string, error) {
+	if !keystore.IsSealed(val) {
+		return val, nil
+	}
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	plain, ok := k.unlocked[val]
+	if !ok {
+		return "", errors.New("The keystore is locked. Unlock it with the passphrase before accessing the private keys.")
+	}
+	return plain, nil
+}
+
+// seal returns what should be saved into a key field. If the keystore has a passphrase, this is the sealed value, if not, the plain hex as is.
+func (k *keystoreCache) seal(plain string) string {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if len(k.passphrase) == 0 {
+		return plain
+	}
+	sealed, err := keystore.Seal(plain, k.passphrase)
+	if err != nil {
+		// This only fails if the system is out of randomness, and we should not save a key we can't protect.
+		panic(fmt.Sprintf("The keystore could not seal a key. Error: %v", err))
+	}
+	k.unlocked[sealed] = plain
+	return sealed
+}
+
+// Backend
+
+// UnlockKeystore unlocks the backend key pair with the passphrase. If the key pair is not encrypted yet and a passphrase is given, it gets encrypted with it and the config is committed. Returns whether such a migration happened.
+func (config *BackendConfig) UnlockKeystore(passphrase string) (bool, error) {
+	config.InitCheck()
+	migrated, err := beKeystore.unlock(passphrase, &config.BackendKeyPair)
+	if err != nil {
+		return false, err
+	}
+	if migrated {
+		return true, config.Commit()
+	}
+	return false, nil
+}
+
+func (config *BackendConfig) IsKeystoreEncrypted() bool {
+	return beKeystore.isEncrypted(config.BackendKeyPair)
+}
+
+func (config *BackendConfig) IsKeystoreLocked() bool {
+	return beKeystore.isLocked(config.BackendKeyPair)
+}
+
+// Frontend
+
+// UnlockKeystore unlocks the user and frontend key pairs with the passphrase. If they are not encrypted yet and a passphrase is given, they get encrypted with it and the config is committed. Returns whether such a migration happened.
+func (config *FrontendConfig) UnlockKeystore(passphrase string) (bool, error) {
+	config.InitCheck()
+	migrated, err := feKeystore.unlock(passphrase, &config.UserKeyPair, &config.FrontendKeyPair)
+	if err != nil {
+		return false, err
+	}
+	if migrated {
+		return true, config.Commit()
+	}
+	return false, nil
+}
+
+func (config *FrontendConfig) IsKeystoreEncrypted() bool {
+	return feKeystore.isEncrypted(config.UserKeyPair, config.FrontendKeyPair)
+}
+
+func (config *FrontendConfig) IsKeystoreLocked() bool {
+	return feKeystore.isLocked(config.UserKeyPair, config.FrontendKeyPair)
+}
+
+// GetKeystorePassphrase returns the passphrase the frontend keystore was unlocked with. The frontend passes this to the local backend it spawns, so that the backend can unlock its own key pair with the same passphrase.
+func (config *FrontendConfig) GetKeystorePassphrase() string {
+	feKeystore.lock.RLock()
+	defer feKeystore.lock.RUnlock()
+	return feKeystore.passphrase
+}
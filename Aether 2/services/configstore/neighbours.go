@@ -146,3 +146,29 @@ func (m *NeighboursList) Pop() (string, string, uint16) {
 	m.insertSpacer()
 	return ejectedItem.Location, ejectedItem.Sublocation, ejectedItem.Port
 }
+
+// Locations returns the locations of the neighbours in the list, without the spacers. The bouncer uses this to keep some inbound slots for them.
+func (m *NeighboursList) Locations() map[string]bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	locs := make(map[string]bool)
+	for key, _ := range m.Neighbours {
+		if !isSpacer(m.Neighbours[key]) {
+			locs[m.Neighbours[key].Location] = true
+		}
+	}
+	return locs
+}
+
+// Addresses returns the neighbours in the list, without the spacers. Gossip announces new entities to these.
+func (m *NeighboursList) Addresses() []Address {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	addrs := []Address{}
+	for key, _ := range m.Neighbours {
+		if !isSpacer(m.Neighbours[key]) {
+			addrs = append(addrs, m.Neighbours[key])
+		}
+	}
+	return addrs
+}
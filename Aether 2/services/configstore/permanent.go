@@ -7,6 +7,8 @@ import (
 	"aether-core/services/fingerprinting"
 	"aether-core/services/signaturing"
 	"aether-core/services/toolbox"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -36,6 +38,19 @@ func invalidDataError(input interface{}) error {
 	return errors.New(fmt.Sprintf("An invalid value for this setting was provided by the user / application (in Set) or by the storage backend (in Get). Value provided: %#v", input))
 }
 
+// IP type preferences. See IpTypePreference in the docs above.
+
+const (
+	IpTypePreferIPv6 = "prefer-ipv6"
+	IpTypePreferIPv4 = "prefer-ipv4"
+	IpTypeIPv6Only   = "ipv6-only"
+	IpTypeIPv4Only   = "ipv4-only"
+)
+
+func isValidIpTypePreference(val string) bool {
+	return val == IpTypePreferIPv6 || val == IpTypePreferIPv4 || val == IpTypeIPv6Only || val == IpTypeIPv4Only
+}
+
 // Maximums
 
 const (
@@ -47,6 +62,11 @@ const (
 	maxAbsolutePageSize             = 1000000
 	maxPOWStrength                  = 63 // Our PoWs are 64 bytes long
 	maxLocationSize                 = 2500
+	maxSelectiveSyncBoards          = 1000
+	maxGatewayExcludedBoards        = 1000
+	maxSeedDNSNames                 = 100
+	maxDomainNameSize               = 253
+	maxFingerprintSize              = 64
 )
 
 const (
@@ -232,6 +252,9 @@ The external IP of this machine.
 ## ExternalIpType
 The external IP type of this machine. 4: IPv4, 6: IPv6, 3: URL (in case of static)
 
+## IpTypePreference
+Which IP versions we use to connect to other nodes, and which one we pick when a node is reachable over both. "prefer-ipv6" and "prefer-ipv4" use both, but when we know a node through both an IPv4 and an IPv6 address, only the preferred one is used. "ipv6-only" and "ipv4-only" only listen and connect over that version, which is useful on machines that only have one of the two.
+
 ## ExternalPort
 The external port type of this machine.
 
@@ -279,7 +302,7 @@ The password of the DB user, if not SQLite3. By default it's "exventoveritas". I
 ## MetricsToken
 
 ## BackendKeyPair
-Backend key pair is the key for this specific backend by which it signs the pages it creates. This is a combination of both private and public keys.
+Backend key pair is the key for this specific backend by which it signs the pages it creates. This is a combination of both private and public keys. If the user has set a keystore passphrase, this is saved encrypted, and the backend needs the passphrase at startup to unlock it. (See keystore.go)
 
 ## AllowUnsignedEntities
 If this is set to true, the node accepts posts that are anonymous. (But still with PoW and Fingerprint). This is disabled by default.
@@ -297,11 +320,20 @@ How many nodes we are interested in keeping in touch with on a rolling basis.
 How many nodes do we allow to be simultaneously connected to this node. This number depends on your bandwidth and CPU resources. Setting this number to zero renders the config invalid (same as most things in config) and it will automatically regenerate from scratch, removing all prior config data.
 
 # MaxOutboundConns
-How many outbounds do we allow. Otherwise same as MaxInboundConns.
+How many outbounds do we allow. Otherwise same as MaxInboundConns. This is also how many syncs we run at the same time.
+
+# IPBucketBurst
+How many requests a single IP can make to us at once, before the bouncer starts declining them. This has to fit a whole sync, since a sync that gets declined halfway fails. The biggest one is a bootstrap, where we serve the manifests of all of our caches, and our share of the caches, to one remote (see defaults.go).
+
+# IPBucketRate
+How many requests a second a single IP gets back after it spends its burst. An IP prefix gets four times both of these.
 
 # MaxDbSizeMb
 This is the size that the user has allotted the application to use in the computer. Mind that this is only the database, and it is only the threshold where the event horizon starts to delete. Even when this threshold is not reached, if entities's last references reach the threshold of local memory, they will still be deleted.
 
+# MaxBlobStoreSizeMb
+This is how much space the attachments of threads and posts can take. These are kept outside the database (see io/blobstore), so they are not part of MaxDbSizeMb. When this is reached, the attachments that were looked at the longest time ago are deleted first. The threads and posts stay, and their attachments can come back from the network if somebody still has them.
+
 # VotesMemoryDays
 How long will the votes be retained in memory. This is a special case of LocalMemoryDays. We retain the votes much fewer days than the rest of the items because they're much more numerous and much less information dense. That does not mean all voting information will disappear though - when the frontend compiles votes, the compiled vote counts will be retained normally.
 
@@ -311,12 +343,27 @@ This mode is normally disabled, and it is automatically enabled if the event hor
 # ScaledModeUserSet
 If this is enabled, the user has made a decision to keep scaled mode on or off, and we will not be flipping it back and forth based on disk space pressure.
 
+# SelectiveSyncEnabled
+If this is enabled, this node only replicates the threads, posts and votes of the boards it has been asked to track, instead of everything on the network. The boards tracked are the union of SelectiveSyncBoards and the boards the frontends of this backend have subscribed to. Boards themselves, keys, truststates and addresses are still replicated in full, since they are small, and without them the users would not be able to discover new boards, or verify the content of the boards they track. Scaled mode, when it kicks in, also uses this set of boards if it's not empty.
+
+# SelectiveSyncBoards
+This is the list of board fingerprints that this backend always tracks in selective sync, regardless of what the frontends are subscribed to. Useful for nodes that have no frontend attached to them.
+
+# GossipEnabled
+If this is enabled, this node tells its neighbours about the new entities it gets, right when it gets them, and fetches the ones its neighbours tell it about. Without it, new content reaches other nodes only when they sync with us next. The announcements and the fetches that follow them are rate limited, and the neighbours that don't have this enabled ignore them.
+
 # LastBootstrapAddressConnectionTimestamp
 This is the last successful bootstrap timestamp. Every time a bootstrap is completed, this runs. If a node remains offline long enough that a given amount of time passes, bootstrap runs again.
 
 # BootstrapAfterOfflineMinutes
 This is how long a node can remain offline before a bootstrap kicks in at next restart. So if this value is 6 hours, if you're offline for more than 6h, this means when you start the app again, it will do a bootstrap. If you set this to -1, this will be disabled.
 
+# SeedDNSNames
+These are the domain names we look up for bootstrap seeds, in addition to the default bootstrapper and the seeds file (seeds.txt in the backend folder of the user directory). For every name, we read the seed lists in its TXT records, and the targets of its _aether._tcp SRV records. These lookups are skipped if the SOCKS5 proxy is enabled, since they would go around it.
+
+# SeedListPublicKey
+If this is set, the seed lists from the seeds file and from DNS TXT records are only accepted if they are signed by the private key of this public key, and SRV records, which cannot be signed, are ignored. The seed lists can be signed with 'mre seeds sign'.
+
 # SOCKS5ProxyEnabled
 If this is true, a proxy is used for all outbound connections. This is an advanced feature, please read below if you are planning to use this, and use this only if you understand what this means. This feature can be used to use Aether over Tor.
 
@@ -339,6 +386,28 @@ This is the address of the proxy that is going ot be used if the proxy is enable
 # SOCKS5ProxyPassword
 Username and password for the SOCKS5 proxy if required.
 
+# TorSOCKSProxyAddress
+# I2PSOCKSProxyAddress
+These are the SOCKS proxies of a Tor client (usually 127.0.0.1:9050) and of an I2P router (usually 127.0.0.1:4447) on this machine. Addresses on those networks (.onion and .b32.i2p) can only be reached through them, so if one of these is blank, we never connect to the addresses of that network. These are separate from the SOCKS5 proxy above: that one is for all the other addresses, and only if it is enabled. Clearnet addresses do not go through these, so if you want your node to be on both, and not to be seen on the clearnet with its own IP, you should also point the SOCKS5 proxy to your Tor client.
+
+# HiddenServiceAddress
+# HiddenServicePort
+# HiddenServiceListenAddress
+If this node is reachable as a Tor onion service or an I2P server tunnel, HiddenServiceAddress is its .onion or .b32.i2p address, and HiddenServicePort is the port others connect to on it. We publish this address to the nodes we connect to, in place of our IP, and we serve Mim on HiddenServiceListenAddress, a local socket that the Tor client or the I2P router forwards the incoming connections to. For Tor, this is what the torrc would say:
+
+	HiddenServiceDir /var/lib/tor/aether
+	HiddenServicePort 49999 127.0.0.1:49998
+
+with HiddenServicePort 49999 and HiddenServiceListenAddress 127.0.0.1:49998. HiddenServiceListenAddress should not be reachable from the outside, since everything that comes in through it is taken as coming in from the overlay network.
+
+Mind that if we connect to a clearnet node directly, that node sees both our IP and the hidden service address we publish. If you do not want the two to be linked, enable the SOCKS5 proxy as well (see above).
+
+# GatewayListenAddress
+If this is set, we serve a read-only web version of the boards, threads and posts this node has at this address, as plain HTML, so that the people who don't have Aether installed can read the discussions linked to them. It's off when blank. It has no write paths, and it hides the content the mods of a board have blocked, and the content of the users the CAs have F451'd. If you want it to be reachable from the outside, put it behind a reverse proxy, or give it a public address, such as ":8080".
+
+# GatewayExcludedBoards
+The fingerprints of the boards that the gateway won't show. Their threads and posts aren't shown either.
+
 # NodeType
 
 This value sets the node class. See below for potential values. Currently extant options: 2, 3, 254, 255
@@ -436,6 +505,7 @@ type BackendConfig struct {
 	LoggingLevel                            uint
 	ExternalIp                              string // addr
 	ExternalIpType                          uint8
+	IpTypePreference                        string
 	ExternalPort                            uint16
 	LastStaticAddressConnectionTimestamp    uint64
 	LastLiveAddressConnectionTimestamp      uint64
@@ -459,17 +529,32 @@ type BackendConfig struct {
 	MaxAddressTableSize                     uint
 	MaxInboundConns                         uint
 	MaxOutboundConns                        uint
+	IPBucketBurst                           uint
+	IPBucketRate                            uint
 	MaxDbSizeMb                             uint
+	MaxBlobStoreSizeMb                      uint
 	VotesMemoryDays                         uint // 14
 	EventHorizonTimestamp                   uint64
 	ScaledMode                              bool
 	ScaledModeUserSet                       bool
+	SelectiveSyncEnabled                    bool
+	SelectiveSyncBoards                     []string
+	GossipEnabled                           bool
 	LastBootstrapAddressConnectionTimestamp uint64
 	BootstrapAfterOfflineMinutes            int // 360
+	SeedDNSNames                            []string
+	SeedListPublicKey                       string
 	SOCKS5ProxyEnabled                      bool
 	SOCKS5ProxyAddress                      string // Format: "127.0.0.1:65535"
 	SOCKS5ProxyUsername                     string
 	SOCKS5ProxyPassword                     string
+	TorSOCKSProxyAddress                    string // Format: "127.0.0.1:9050"
+	I2PSOCKSProxyAddress                    string // Format: "127.0.0.1:4447"
+	HiddenServiceAddress                    string
+	HiddenServicePort                       uint16
+	HiddenServiceListenAddress              string // Format: "127.0.0.1:49998"
+	GatewayListenAddress                    string // Format: "127.0.0.1:8080"
+	GatewayExcludedBoards                   []string
 	NodeType                                uint8
 	BackendAPIPublic                        bool
 	BackendAPIPort                          uint16
@@ -907,6 +992,16 @@ func (config *BackendConfig) GetExternalIpType() uint8 {
 	log.Fatal("This should never happen." + toolbox.Trace())
 	return 0
 }
+func (config *BackendConfig) GetIpTypePreference() string {
+	config.InitCheck()
+	if isValidIpTypePreference(config.IpTypePreference) {
+		return config.IpTypePreference
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.IpTypePreference) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return ""
+}
 func (config *BackendConfig) GetNodeId() string {
 	config.InitCheck()
 	if len(config.NodeId) == 64 {
@@ -1028,7 +1123,11 @@ func (config *BackendConfig) GetMetricsToken() string {
 
 func (config *BackendConfig) GetBackendKeyPair() *ed25519.PrivateKey {
 	config.InitCheck()
-	keyPair, err := signaturing.UnmarshalPrivateKey(config.BackendKeyPair)
+	plain, ksErr := beKeystore.plain(config.BackendKeyPair)
+	if ksErr != nil {
+		log.Fatal(ksErr.Error() + " Trace: " + toolbox.Trace())
+	}
+	keyPair, err := signaturing.UnmarshalPrivateKey(plain)
 	if err != nil {
 		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.BackendKeyPair) + " Trace: " + toolbox.Trace() + "Error: " + err.Error()))
 	}
@@ -1105,6 +1204,30 @@ func (config *BackendConfig) GetMaxOutboundConns() int {
 	return 0
 }
 
+func (config *BackendConfig) GetIPBucketBurst() int {
+	config.InitCheck()
+	if config.IPBucketBurst < maxInt32 &&
+		config.IPBucketBurst > 0 {
+		return int(config.IPBucketBurst)
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.IPBucketBurst) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return 0
+}
+
+func (config *BackendConfig) GetIPBucketRate() int {
+	config.InitCheck()
+	if config.IPBucketRate < maxInt32 &&
+		config.IPBucketRate > 0 {
+		return int(config.IPBucketRate)
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.IPBucketRate) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return 0
+}
+
 func (config *BackendConfig) GetMaxDbSizeMb() int {
 	config.InitCheck()
 	if config.MaxDbSizeMb < maxInt64 &&
@@ -1117,6 +1240,18 @@ func (config *BackendConfig) GetMaxDbSizeMb() int {
 	return 0
 }
 
+func (config *BackendConfig) GetMaxBlobStoreSizeMb() int {
+	config.InitCheck()
+	if config.MaxBlobStoreSizeMb < maxInt64 &&
+		config.MaxBlobStoreSizeMb > 0 {
+		return int(config.MaxBlobStoreSizeMb)
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.MaxBlobStoreSizeMb) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return 0
+}
+
 func (config *BackendConfig) GetVotesMemoryDays() int {
 	config.InitCheck()
 	if config.VotesMemoryDays < maxInt64 &&
@@ -1151,6 +1286,49 @@ func (config *BackendConfig) GetScaledModeUserSet() bool {
 	return config.ScaledModeUserSet
 }
 
+func (config *BackendConfig) GetSelectiveSyncEnabled() bool {
+	config.InitCheck()
+	return config.SelectiveSyncEnabled
+}
+
+func (config *BackendConfig) GetSelectiveSyncBoards() []string {
+	config.InitCheck()
+	if len(config.SelectiveSyncBoards) <= maxSelectiveSyncBoards {
+		return config.SelectiveSyncBoards
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.SelectiveSyncBoards) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return []string{}
+}
+
+func (config *BackendConfig) GetGossipEnabled() bool {
+	config.InitCheck()
+	return config.GossipEnabled
+}
+
+func (config *BackendConfig) GetSeedDNSNames() []string {
+	config.InitCheck()
+	if len(config.SeedDNSNames) <= maxSeedDNSNames {
+		return config.SeedDNSNames
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.SeedDNSNames) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return []string{}
+}
+
+func (config *BackendConfig) GetSeedListPublicKey() string {
+	config.InitCheck()
+	if len(config.SeedListPublicKey) < maxUint16 {
+		return config.SeedListPublicKey
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.SeedListPublicKey) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return ""
+}
+
 func (config *BackendConfig) GetLastBootstrapAddressConnectionTimestamp() int64 {
 	config.InitCheck()
 	if config.LastBootstrapAddressConnectionTimestamp < maxInt64 &&
@@ -1217,6 +1395,84 @@ func (config *BackendConfig) GetSOCKS5ProxyPassword() string {
 	return ""
 }
 
+func (config *BackendConfig) GetTorSOCKSProxyAddress() string {
+	config.InitCheck()
+	if len(config.TorSOCKSProxyAddress) < maxLocationSize {
+		return config.TorSOCKSProxyAddress
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.TorSOCKSProxyAddress) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return ""
+}
+
+func (config *BackendConfig) GetI2PSOCKSProxyAddress() string {
+	config.InitCheck()
+	if len(config.I2PSOCKSProxyAddress) < maxLocationSize {
+		return config.I2PSOCKSProxyAddress
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.I2PSOCKSProxyAddress) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return ""
+}
+
+func (config *BackendConfig) GetHiddenServiceAddress() string {
+	config.InitCheck()
+	if len(config.HiddenServiceAddress) == 0 || toolbox.ValidOverlayLocation(config.HiddenServiceAddress) {
+		return config.HiddenServiceAddress
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.HiddenServiceAddress) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return ""
+}
+
+func (config *BackendConfig) GetHiddenServicePort() uint16 {
+	config.InitCheck()
+	if config.HiddenServicePort < maxUint16 && config.HiddenServicePort > 0 {
+		return config.HiddenServicePort
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.HiddenServicePort) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return 0
+}
+
+func (config *BackendConfig) GetHiddenServiceListenAddress() string {
+	config.InitCheck()
+	if len(config.HiddenServiceListenAddress) < maxLocationSize &&
+		len(config.HiddenServiceListenAddress) > 0 {
+		return config.HiddenServiceListenAddress
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.HiddenServiceListenAddress) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return ""
+}
+
+func (config *BackendConfig) GetGatewayListenAddress() string {
+	config.InitCheck()
+	if len(config.GatewayListenAddress) < maxLocationSize {
+		return config.GatewayListenAddress
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.GatewayListenAddress) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return ""
+}
+
+func (config *BackendConfig) GetGatewayExcludedBoards() []string {
+	config.InitCheck()
+	if len(config.GatewayExcludedBoards) <= maxGatewayExcludedBoards {
+		return config.GatewayExcludedBoards
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.GatewayExcludedBoards) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return []string{}
+}
+
 func (config *BackendConfig) GetNodeType() uint8 {
 	config.InitCheck()
 	if config.NodeType == 2 || config.NodeType == 3 || config.NodeType == 254 || config.NodeType == 255 {
@@ -1816,6 +2072,21 @@ func (config *BackendConfig) SetExternalIpType(val int) error {
 	log.Fatal("This should never happen." + toolbox.Trace())
 	return nil
 }
+func (config *BackendConfig) SetIpTypePreference(val string) error {
+	config.InitCheck()
+	if isValidIpTypePreference(val) {
+		config.IpTypePreference = val
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
 func (config *BackendConfig) SetNodeId(val string) error {
 	config.InitCheck()
 	if len(val) == 64 {
@@ -1986,7 +2257,7 @@ func (config *BackendConfig) SetMetricsToken(val string) error {
 
 func (config *BackendConfig) SetBackendKeyPair(val *ed25519.PrivateKey) error {
 	config.InitCheck()
-	config.BackendKeyPair = signaturing.MarshalPrivateKey(*val)
+	config.BackendKeyPair = beKeystore.seal(signaturing.MarshalPrivateKey(*val))
 	commitErr := config.Commit()
 	if commitErr != nil {
 		return commitErr
@@ -2095,6 +2366,38 @@ func (config *BackendConfig) SetMaxOutboundConns(val int) error {
 	return nil
 }
 
+func (config *BackendConfig) SetIPBucketBurst(val int) error {
+	config.InitCheck()
+	if val > 0 {
+		config.IPBucketBurst = uint(val)
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+func (config *BackendConfig) SetIPBucketRate(val int) error {
+	config.InitCheck()
+	if val > 0 {
+		config.IPBucketRate = uint(val)
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
 func (config *BackendConfig) SetMaxDbSizeMb(val int) error {
 	config.InitCheck()
 	if val >= 0 {
@@ -2110,6 +2413,22 @@ func (config *BackendConfig) SetMaxDbSizeMb(val int) error {
 	log.Fatal("This should never happen." + toolbox.Trace())
 	return nil
 }
+func (config *BackendConfig) SetMaxBlobStoreSizeMb(val int) error {
+	config.InitCheck()
+	if val > 0 {
+		config.MaxBlobStoreSizeMb = uint(val)
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
 func (config *BackendConfig) SetVotesMemoryDays(val int) error {
 	config.InitCheck()
 	if val >= 0 {
@@ -2161,6 +2480,78 @@ func (config *BackendConfig) SetScaledModeUserSet(val bool) error {
 	return nil
 }
 
+func (config *BackendConfig) SetSelectiveSyncEnabled(val bool) error {
+	config.InitCheck()
+	config.SelectiveSyncEnabled = val
+	commitErr := config.Commit()
+	if commitErr != nil {
+		return commitErr
+	}
+	return nil
+}
+
+func (config *BackendConfig) SetSelectiveSyncBoards(val []string) error {
+	config.InitCheck()
+	if len(val) > maxSelectiveSyncBoards {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	for _, fp := range val {
+		if len(fp) == 0 || len(fp) > maxFingerprintSize {
+			return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+		}
+	}
+	config.SelectiveSyncBoards = val
+	commitErr := config.Commit()
+	if commitErr != nil {
+		return commitErr
+	}
+	return nil
+}
+
+func (config *BackendConfig) SetGossipEnabled(val bool) error {
+	config.InitCheck()
+	config.GossipEnabled = val
+	commitErr := config.Commit()
+	if commitErr != nil {
+		return commitErr
+	}
+	return nil
+}
+
+func (config *BackendConfig) SetSeedDNSNames(val []string) error {
+	config.InitCheck()
+	if len(val) > maxSeedDNSNames {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	for _, name := range val {
+		if len(name) == 0 || len(name) > maxDomainNameSize {
+			return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+		}
+	}
+	config.SeedDNSNames = val
+	commitErr := config.Commit()
+	if commitErr != nil {
+		return commitErr
+	}
+	return nil
+}
+
+func (config *BackendConfig) SetSeedListPublicKey(val string) error {
+	config.InitCheck()
+	if len(val) > 0 {
+		pk, err := signaturing.UnmarshalPublicKey(val)
+		if err != nil || len(pk) != ed25519.PublicKeySize {
+			return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+		}
+	}
+	config.SeedListPublicKey = val
+	commitErr := config.Commit()
+	if commitErr != nil {
+		return commitErr
+	}
+	return nil
+}
+
 func (config *BackendConfig) SetLastBootstrapAddressConnectionTimestamp(val int64) error {
 	config.InitCheck()
 	if val >= 0 {
@@ -2251,6 +2642,124 @@ func (config *BackendConfig) SetSOCKS5ProxyPassword(val string) error {
 	return nil
 }
 
+// SetTorSOCKSProxyAddress takes a blank value, which means we do not connect to onion addresses.
+func (config *BackendConfig) SetTorSOCKSProxyAddress(val string) error {
+	config.InitCheck()
+	if len(val) < maxLocationSize {
+		config.TorSOCKSProxyAddress = val
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+// SetI2PSOCKSProxyAddress takes a blank value, which means we do not connect to I2P addresses.
+func (config *BackendConfig) SetI2PSOCKSProxyAddress(val string) error {
+	config.InitCheck()
+	if len(val) < maxLocationSize {
+		config.I2PSOCKSProxyAddress = val
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+// SetHiddenServiceAddress takes a blank value, which turns the hidden service off.
+func (config *BackendConfig) SetHiddenServiceAddress(val string) error {
+	config.InitCheck()
+	if len(val) == 0 || toolbox.ValidOverlayLocation(val) {
+		config.HiddenServiceAddress = val
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+func (config *BackendConfig) SetHiddenServicePort(val int) error {
+	config.InitCheck()
+	if val > 0 && val < maxUint16 {
+		config.HiddenServicePort = uint16(val)
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+func (config *BackendConfig) SetHiddenServiceListenAddress(val string) error {
+	config.InitCheck()
+	if len(val) > 0 && len(val) < maxLocationSize {
+		config.HiddenServiceListenAddress = val
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+// SetGatewayListenAddress takes a blank value, which turns the gateway off.
+func (config *BackendConfig) SetGatewayListenAddress(val string) error {
+	config.InitCheck()
+	if len(val) < maxLocationSize {
+		config.GatewayListenAddress = val
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+func (config *BackendConfig) SetGatewayExcludedBoards(val []string) error {
+	config.InitCheck()
+	if len(val) > maxGatewayExcludedBoards {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	for _, fp := range val {
+		if len(fp) == 0 || len(fp) > maxFingerprintSize {
+			return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+		}
+	}
+	config.GatewayExcludedBoards = val
+	commitErr := config.Commit()
+	if commitErr != nil {
+		return commitErr
+	}
+	return nil
+}
+
 func (config *BackendConfig) SetNodeType(val int) error {
 	config.InitCheck()
 	if val == 2 || val == 3 || val == 254 || val == 255 {
@@ -2463,6 +2972,9 @@ func (config *BackendConfig) BlankCheck() {
 	if config.ExternalIpType == 0 {
 		config.SetExternalIpType(defaultExternalIpType)
 	}
+	if config.IpTypePreference == "" {
+		config.SetIpTypePreference(defaultIpTypePreference)
+	}
 	if config.ExternalPort == 0 {
 		config.SetExternalPort(defaultExternalPort)
 	}
@@ -2476,10 +2988,14 @@ func (config *BackendConfig) BlankCheck() {
 			if len(val.SupportedEntities) == 0 {
 				servingSubprotocolsNeedRegeneration = true
 			}
+			// A config saved by an older version of the app advertises an older c0.
+			if val.Name == "c0" && val.VersionMinor < C0SubprotocolVersionMinor {
+				servingSubprotocolsNeedRegeneration = true
+			}
 		}
 	}
 	if servingSubprotocolsNeedRegeneration {
-		c0 := SubprotocolShim{Name: "c0", VersionMajor: 1, VersionMinor: 0, SupportedEntities: []string{"board", "thread", "post", "vote", "key", "truststate"}}
+		c0 := SubprotocolShim{Name: "c0", VersionMajor: 1, VersionMinor: C0SubprotocolVersionMinor, SupportedEntities: []string{"board", "thread", "post", "vote", "key", "truststate"}}
 		// dweb := SubprotocolShim{Name: "dweb", VersionMajor: 1, VersionMinor: 0, SupportedEntities: []string{"page"}}
 		config.SetServingSubprotocols([]interface{}{c0})
 	}
@@ -2535,9 +3051,18 @@ func (config *BackendConfig) BlankCheck() {
 	if config.MaxOutboundConns == 0 {
 		config.SetMaxOutboundConns(defaultMaxOutboundConns)
 	}
+	if config.IPBucketBurst == 0 {
+		config.SetIPBucketBurst(defaultIPBucketBurst)
+	}
+	if config.IPBucketRate == 0 {
+		config.SetIPBucketRate(defaultIPBucketRate)
+	}
 	if config.MaxDbSizeMb == 0 {
 		config.SetMaxDbSizeMb(defaultMaxDbSizeMb)
 	}
+	if config.MaxBlobStoreSizeMb == 0 {
+		config.SetMaxBlobStoreSizeMb(defaultMaxBlobStoreSizeMb)
+	}
 	if config.VotesMemoryDays == 0 {
 		config.SetVotesMemoryDays(defaultVotesMemoryDays)
 	}
@@ -2546,14 +3071,30 @@ func (config *BackendConfig) BlankCheck() {
 	}
 	// ::ScaledMode: can be false, no need to blank check.
 	// ::ScaledModeUserSet: can be false, no need to blank check.
+	// ::SelectiveSyncEnabled: can be false, no need to blank check.
+	// ::SelectiveSyncBoards: can be empty, no need to blank check.
+	// ::GossipEnabled: can be false, no need to blank check.
 	// ::LastBootstrapAddressConnectionTimestamp: can be 0, no need to blank check.
 	if config.BootstrapAfterOfflineMinutes == 0 {
 		config.SetBootstrapAfterOfflineMinutes(defaultBootstrapAfterOfflineMinutes)
 	}
+	// ::SeedDNSNames: can be empty, no need to blank check.
+	// ::SeedListPublicKey: can be blank, no need to blank check.
 	// ::SOCKS5ProxyEnabled: can be false, no need to blank check.
 	// ::SOCKS5ProxyAddress: can be blank, no need to blank check.
 	// ::SOCKS5ProxyUsername: can be blank, no need to blank check.
 	// ::SOCKS5ProxyPassword: can be blank, no need to blank check.
+	// ::TorSOCKSProxyAddress: can be blank, no need to blank check.
+	// ::I2PSOCKSProxyAddress: can be blank, no need to blank check.
+	// ::HiddenServiceAddress: can be blank, no need to blank check.
+	if config.HiddenServicePort == 0 {
+		config.SetHiddenServicePort(defaultHiddenServicePort)
+	}
+	if config.HiddenServiceListenAddress == "" {
+		config.SetHiddenServiceListenAddress(defaultHiddenServiceListenAddress)
+	}
+	// ::GatewayListenAddress: can be blank, no need to blank check.
+	// ::GatewayExcludedBoards: can be empty, no need to blank check.
 	if config.NodeType == 0 {
 		config.SetNodeType(defaultNodeType)
 	}
@@ -2623,6 +3164,7 @@ func (config *BackendConfig) SanityCheck() {
 		config.GetDispatchExclusionExpiryForStaticAddress()
 		config.GetLoggingLevel()
 		config.GetExternalIp()
+		config.GetIpTypePreference()
 		config.GetExternalPort()
 		config.GetLastStaticAddressConnectionTimestamp()
 		config.GetLastLiveAddressConnectionTimestamp()
@@ -2633,7 +3175,9 @@ func (config *BackendConfig) SanityCheck() {
 		config.GetDbPassword()
 		config.GetMetricsLevel()
 		config.GetMetricsToken()
-		config.GetBackendKeyPair()
+		if !config.IsKeystoreLocked() { // Locked until the passphrase arrives, see keystore.go.
+			config.GetBackendKeyPair()
+		}
 		config.GetMarshaledBackendPublicKey() // location sensitive, needs to happen after getbackendkeypair
 		config.GetNodeId()                    // location sensitive, needs to happen after getbackendkeypair
 		config.GetMaxInboundPageSizeKb()
@@ -2642,14 +3186,25 @@ func (config *BackendConfig) SanityCheck() {
 		config.GetMaxInboundConns()
 		config.GetMaxOutboundConns()
 		config.GetMaxDbSizeMb()
+		config.GetMaxBlobStoreSizeMb()
 		config.GetVotesMemoryDays()
 		config.GetEventHorizonTimestamp()
+		config.GetSelectiveSyncBoards()
 		config.GetLastBootstrapAddressConnectionTimestamp()
 		config.GetBootstrapAfterOfflineMinutes()
+		config.GetSeedDNSNames()
+		config.GetSeedListPublicKey()
 		config.GetSOCKS5ProxyEnabled()
 		config.GetSOCKS5ProxyAddress()
 		config.GetSOCKS5ProxyUsername()
 		config.GetSOCKS5ProxyPassword()
+		config.GetTorSOCKSProxyAddress()
+		config.GetI2PSOCKSProxyAddress()
+		config.GetHiddenServiceAddress()
+		config.GetHiddenServicePort()
+		config.GetHiddenServiceListenAddress()
+		config.GetGatewayListenAddress()
+		config.GetGatewayExcludedBoards()
 		config.GetNodeType()
 		config.GetBackendAPIPublic()
 		config.GetAdminFrontendAddress()
@@ -2676,7 +3231,7 @@ func (config *BackendConfig) Commit() error {
 	toolbox.CreatePath(filepath.Join(folders[0].Path, "backend"))
 	writeAheadPath := filepath.Join(folders[0].Path, "backend", "backend_config_writeahead.json")
 	targetPath := filepath.Join(folders[0].Path, "backend", "backend_config.json")
-	err := ioutil.WriteFile(writeAheadPath, confAsByte, 0644)
+	err := ioutil.WriteFile(writeAheadPath, confAsByte, 0600) // Only readable by the user, this holds the private keys.
 	if err != nil {
 		return err
 	}
@@ -2760,11 +3315,21 @@ This is the key of the user residing in this frontend. Every user has their own
 # MarshaledFrontendPublicKey
 This is the 'admin' key for either the local backend, or if this frontend is the maintainer of a remote backend, the key used to be authenticated with that backend. If multiple people are maintaining a backend, they can share this key between them without sharing their user accounts (their user keys).
 
+Both of these private keys are saved encrypted if the user has set a keystore passphrase. In that case, the frontend waits at startup until the client unlocks the keystore through the FrontendAPI. (See keystore.go)
+
 # DehydratedLocalUserKeyEntity
 This is the actual Key entity that the localUser owns (not just the PK, but also the actual entity, with its username, creation, etc.) This is a dehydrated (jsonified) copy of the entity. This is useful when you move your frontend to a different backend - it can push it to the new backend if it doesn't have it already.
 
 ## PoWBailoutTimeSeconds
 How long does it take before a PoW timestamp is marked unattainable by the local computer. This is to make sure that the app doesn't keep attempting forever for an unattainably strong PoW it attempted to generate.
+
+## FeedsEnabled
+## FeedsPort
+## FeedsToken
+If FeedsEnabled is true, the frontend serves RSS and Atom feeds of the boards, threads and users, and of the home view, on FeedsPort on 127.0.0.1, so that they can be followed from a feed reader. Every feed URL has to carry FeedsToken, so that the other programs and the web pages on this machine can't read what the user is subscribed to. The token is generated the first time. Blank it to have a new one generated at the next start, which makes the feed URLs given out so far stop working.
+
+## FeedsLinkBase
+The feed items link to nothing by default, since the app can't be opened from a link. If this is set to the address of an Aether web gateway, such as "https://aether.example.org", the items link to the thread in the gateway. (See GatewayListenAddress in the backend config.)
 */
 
 // Frontend config base
@@ -2803,6 +3368,10 @@ type FrontendConfig struct {
 	SFWListDisabled                         bool
 	ModModeEnabled                          bool
 	KvStoreRetentionDays                    uint
+	FeedsEnabled                            bool
+	FeedsPort                               uint16
+	FeedsToken                              string
+	FeedsLinkBase                           string // Format: "https://aether.example.org"
 }
 
 // Init check gate
@@ -2825,7 +3394,11 @@ func (config *FrontendConfig) InitCheck() {
 
 func (config *FrontendConfig) GetUserKeyPair() *ed25519.PrivateKey {
 	config.InitCheck()
-	keyPair, err := signaturing.UnmarshalPrivateKey(config.UserKeyPair)
+	plain, ksErr := feKeystore.plain(config.UserKeyPair)
+	if ksErr != nil {
+		log.Fatal(ksErr.Error() + " Trace: " + toolbox.Trace())
+	}
+	keyPair, err := signaturing.UnmarshalPrivateKey(plain)
 	if err != nil {
 		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.UserKeyPair) + " Trace: " + toolbox.Trace() + "Error: " + err.Error()))
 	}
@@ -2877,7 +3450,11 @@ func (config *FrontendConfig) GetLoggingLevel() int {
 
 func (config *FrontendConfig) GetFrontendKeyPair() *ed25519.PrivateKey {
 	config.InitCheck()
-	keyPair, err := signaturing.UnmarshalPrivateKey(config.FrontendKeyPair)
+	plain, ksErr := feKeystore.plain(config.FrontendKeyPair)
+	if ksErr != nil {
+		log.Fatal(ksErr.Error() + " Trace: " + toolbox.Trace())
+	}
+	keyPair, err := signaturing.UnmarshalPrivateKey(plain)
 	if err != nil {
 		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.GetFrontendKeyPair) + " Trace: " + toolbox.Trace() + "Error: " + err.Error()))
 	}
@@ -3083,26 +3660,27 @@ func (config *FrontendConfig) GetMinimumVoteThresholdForElectionValidity() int {
 	return 0
 }
 
-func (config *FrontendConfig) GetUserRelations() UserRelations {
+// The relations have a lock in them, so they are never copied. GetUserRelations and GetContentRelations give the ones in the config, which are changed in place, and SetUserRelations and SetContentRelations save them.
+func (config *FrontendConfig) GetUserRelations() *UserRelations {
 	config.InitCheck()
 	if config.UserRelations.Initialised {
-		return config.UserRelations
+		return &config.UserRelations
 	} else {
-		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.UserRelations) + " Trace: " + toolbox.Trace()))
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.UserRelations.Initialised) + " Trace: " + toolbox.Trace()))
 	}
 	log.Fatal("This should never happen." + toolbox.Trace())
-	return UserRelations{}
+	return nil
 }
 
-func (config *FrontendConfig) GetContentRelations() ContentRelations {
+func (config *FrontendConfig) GetContentRelations() *ContentRelations {
 	config.InitCheck()
 	if config.ContentRelations.Initialised {
-		return config.ContentRelations
+		return &config.ContentRelations
 	} else {
-		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.ContentRelations) + " Trace: " + toolbox.Trace()))
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.ContentRelations.Initialised) + " Trace: " + toolbox.Trace()))
 	}
 	log.Fatal("This should never happen." + toolbox.Trace())
-	return ContentRelations{}
+	return nil
 }
 
 func (config *FrontendConfig) GetDehydratedLocalUserKeyEntity() string {
@@ -3191,13 +3769,51 @@ func (config *FrontendConfig) GetKvStoreRetentionDays() int {
 	return 0
 }
 
+func (config *FrontendConfig) GetFeedsEnabled() bool {
+	config.InitCheck()
+	return config.FeedsEnabled
+}
+
+func (config *FrontendConfig) GetFeedsPort() uint16 {
+	config.InitCheck()
+	if config.FeedsPort < maxUint16 && config.FeedsPort > 0 {
+		return config.FeedsPort
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.FeedsPort) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return 0
+}
+
+func (config *FrontendConfig) GetFeedsToken() string {
+	config.InitCheck()
+	if len(config.FeedsToken) > 0 && len(config.FeedsToken) < 65 {
+		return config.FeedsToken
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.FeedsToken) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return ""
+}
+
+func (config *FrontendConfig) GetFeedsLinkBase() string {
+	config.InitCheck()
+	if len(config.FeedsLinkBase) < maxLocationSize {
+		return config.FeedsLinkBase
+	} else {
+		log.Fatal(invalidDataError(fmt.Sprintf("%#v", config.FeedsLinkBase) + " Trace: " + toolbox.Trace()))
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return ""
+}
+
 /*****************************************************************************/
 
 // Setters
 
 func (config *FrontendConfig) SetUserKeyPair(val *ed25519.PrivateKey) error {
 	config.InitCheck()
-	config.UserKeyPair = signaturing.MarshalPrivateKey(*val)
+	config.UserKeyPair = feKeystore.seal(signaturing.MarshalPrivateKey(*val))
 	commitErr := config.Commit()
 	if commitErr != nil {
 		return commitErr
@@ -3277,7 +3893,7 @@ func (config *FrontendConfig) SetLoggingLevel(val int) error {
 
 func (config *FrontendConfig) SetFrontendKeyPair(val *ed25519.PrivateKey) error {
 	config.InitCheck()
-	config.FrontendKeyPair = signaturing.MarshalPrivateKey(*val)
+	config.FrontendKeyPair = feKeystore.seal(signaturing.MarshalPrivateKey(*val))
 	commitErr := config.Commit()
 	if commitErr != nil {
 		return commitErr
@@ -3565,10 +4181,9 @@ func (config *FrontendConfig) SetMinimumVoteThresholdForElectionValidity(val int
 	return nil
 }
 
-func (config *FrontendConfig) SetUserRelations(val UserRelations) error {
-	if config.UserRelations.Initialised {
+func (config *FrontendConfig) SetUserRelations(val *UserRelations) error {
+	if config.UserRelations.Initialised && val == &config.UserRelations {
 		config.InitCheck()
-		config.UserRelations = val
 		commitErr := config.Commit()
 		if commitErr != nil {
 			return commitErr
@@ -3581,10 +4196,9 @@ func (config *FrontendConfig) SetUserRelations(val UserRelations) error {
 	return nil
 }
 
-func (config *FrontendConfig) SetContentRelations(val ContentRelations) error {
-	if config.ContentRelations.Initialised {
+func (config *FrontendConfig) SetContentRelations(val *ContentRelations) error {
+	if config.ContentRelations.Initialised && val == &config.ContentRelations {
 		config.InitCheck()
-		config.ContentRelations = val
 		commitErr := config.Commit()
 		if commitErr != nil {
 			return commitErr
@@ -3705,6 +4319,75 @@ func (config *FrontendConfig) SetKvStoreRetentionDays(val int) error {
 	return nil
 }
 
+func (config *FrontendConfig) SetFeedsEnabled(val bool) error {
+	config.InitCheck()
+	config.FeedsEnabled = val
+	commitErr := config.Commit()
+	if commitErr != nil {
+		return commitErr
+	}
+	return nil
+}
+
+func (config *FrontendConfig) SetFeedsPort(val int) error {
+	config.InitCheck()
+	if val > 0 && val < maxUint16 {
+		config.FeedsPort = uint16(val)
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+// SetFeedsToken takes a blank value, which makes a new token be generated at the next start.
+func (config *FrontendConfig) SetFeedsToken(val string) error {
+	config.InitCheck()
+	if len(val) < 65 {
+		config.FeedsToken = val
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+func (config *FrontendConfig) SetFeedsLinkBase(val string) error {
+	config.InitCheck()
+	if len(val) < maxLocationSize {
+		config.FeedsLinkBase = val
+		commitErr := config.Commit()
+		if commitErr != nil {
+			return commitErr
+		}
+		return nil
+	} else {
+		return invalidDataError(fmt.Sprintf("%#v", val) + " Trace: " + toolbox.Trace())
+	}
+	log.Fatal("This should never happen." + toolbox.Trace())
+	return nil
+}
+
+// generateFeedsToken makes a new FeedsToken. It guards the feeds, so it comes from crypto/rand.
+func generateFeedsToken() string {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		log.Fatal(fmt.Sprintf("The feeds token could not be generated. Error: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
 /*****************************************************************************/
 
 // Frontend config methods
@@ -3785,11 +4468,11 @@ func (config *FrontendConfig) BlankCheck() {
 	}
 	if config.UserRelations.Initialised == false {
 		config.UserRelations.Init()
-		config.SetUserRelations(config.UserRelations)
+		config.SetUserRelations(&config.UserRelations)
 	}
 	if config.ContentRelations.Initialised == false {
 		config.ContentRelations.Init()
-		config.SetContentRelations(config.ContentRelations)
+		config.SetContentRelations(&config.ContentRelations)
 	}
 	// ::DehydratedLocalUserKeyEntity: can be empty, no need to blank check.
 	if config.MinimumPoWStrengths.Board == 0 ||
@@ -3816,15 +4499,25 @@ func (config *FrontendConfig) BlankCheck() {
 	if config.KvStoreRetentionDays == 0 {
 		config.SetKvStoreRetentionDays(defaultKvStoreRetentionDays)
 	}
+	// ::FeedsEnabled: can be false, no need to blank check.
+	if config.FeedsPort == 0 {
+		config.SetFeedsPort(defaultFeedsPort)
+	}
+	if config.FeedsToken == "" {
+		config.SetFeedsToken(generateFeedsToken())
+	}
+	// ::FeedsLinkBase: can be blank, no need to blank check.
 }
 func (config *FrontendConfig) SanityCheck() {
 	if !config.GetInitialised() {
 		log.Fatal("Frontend configuration is not initialised. Please initialise it before use.")
 	} else {
-		config.GetUserKeyPair()
+		if !config.IsKeystoreLocked() { // Locked until the passphrase arrives, see keystore.go.
+			config.GetUserKeyPair()
+			config.GetFrontendKeyPair()
+		}
 		config.GetMetricsLevel()
 		config.GetMetricsToken()
-		config.GetFrontendKeyPair()
 		config.GetExternalIp()
 		config.GetFrontendAPIPort()
 		config.GetBackendAPIAddress()
@@ -3843,6 +4536,9 @@ func (config *FrontendConfig) SanityCheck() {
 		config.GetMinimumPoWStrengths()
 		config.GetPoWBailoutTimeSeconds()
 		config.GetKvStoreRetentionDays()
+		config.GetFeedsPort()
+		config.GetFeedsToken()
+		config.GetFeedsLinkBase()
 	}
 }
 
@@ -3864,7 +4560,7 @@ func (config *FrontendConfig) Commit() error {
 	toolbox.CreatePath(filepath.Join(folders[0].Path, "frontend"))
 	writeAheadPath := filepath.Join(folders[0].Path, "frontend", "frontend_config_writeahead.json")
 	targetPath := filepath.Join(folders[0].Path, "frontend", "frontend_config.json")
-	err := ioutil.WriteFile(writeAheadPath, confAsByte, 0644)
+	err := ioutil.WriteFile(writeAheadPath, confAsByte, 0600) // Only readable by the user, this holds the private keys.
 	if err != nil {
 		return err
 	}
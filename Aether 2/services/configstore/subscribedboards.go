@@ -0,0 +1,39 @@
+// Services > ConfigStore > SubscribedBoards
+// This module keeps the list of boards that the frontends of this backend are subscribed to, so that selective sync knows which boards to replicate.
+
+package configstore
+
+import (
+	"sync"
+)
+
+// SubscribedBoards is transient on purpose. The frontends push their subscriptions to us every time they connect, and every time a subscription changes, so there is no need to keep it across restarts. If we did, a frontend that no longer uses this backend would keep its boards replicated here indefinitely.
+type SubscribedBoards struct {
+	lock   sync.Mutex
+	boards []string
+}
+
+// Set replaces the list of subscribed boards with the one given.
+func (s *SubscribedBoards) Set(fps []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	boards := []string{}
+	seen := make(map[string]bool)
+	for _, fp := range fps {
+		if len(fp) == 0 || seen[fp] {
+			continue
+		}
+		seen[fp] = true
+		boards = append(boards, fp)
+	}
+	s.boards = boards
+}
+
+// List returns a copy of the list of subscribed boards.
+func (s *SubscribedBoards) List() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	boards := make([]string, len(s.boards))
+	copy(boards, s.boards)
+	return boards
+}
@@ -93,9 +93,6 @@ This is the channel to send the message to when you want to stop the cache gener
 ## AddressesScannerActive
 This is the mutex that gets activated when the address scanner is active, so that it cannot be triggered twice at the same time.
 
-## SyncActive
-There is currently an ongoing sync happening.
-
 ## CurrentMetricsPage
 This is the current metrics struct that we are building to send to the metrics server, if enabled.
 
@@ -124,7 +121,7 @@ This is the repository that we keep our post responses in, so that they can be r
 Our list of neighbours that we are checking in with at given intervals.
 
 # Bouncer
-Bouncer controls the inbound and outbound connections. This is the library that starts to refuse connections if the node gets too busy.
+Bouncer controls the inbound and outbound connections. This is the library that starts to refuse connections if the node gets too busy, or if a single remote (an IP, an IP prefix, or a node key) asks for more than its share.
 
 # ReverseConnData
 This is the place we use to save the data so that we know an inbound connection is a reverse-opened one.
@@ -137,6 +134,9 @@ We flip this flag to true whenever the users (frontends) of this backend send us
 
 # MinimumTrustedPoWStrength
 This is the PoW strength we ask for trusted entities coming from a CA that this node has explicitly chosen to trust.
+
+# SubscribedBoards
+The boards the frontends of this backend are subscribed to. Selective sync replicates these, together with the boards in the SelectiveSyncBoards permanent config.
 */
 
 type BackendTransientConfig struct {
@@ -158,7 +158,6 @@ type BackendTransientConfig struct {
 	StopUPNPCycle              chan bool
 	StopCacheGenerationCycle   chan bool
 	AddressesScannerActive     sync.Mutex
-	SyncActive                 sync.Mutex
 	CurrentMetricsPage         pb.Metrics
 	FingerprintCheckEnabled    bool
 	SignatureCheckEnabled      bool
@@ -174,6 +173,7 @@ type BackendTransientConfig struct {
 	NewContentCommitted        bool
 	BackendAPIPortVerified     bool
 	MinimumTrustedPoWStrength  int
+	SubscribedBoards           SubscribedBoards
 }
 
 // Set transient backend config defaults. Only need to set defaults that are not the type default.
@@ -240,6 +240,7 @@ type FrontendTransientConfig struct {
 	CurrentAmbientStatus       clapi.AmbientStatusPayload
 	StopRefresherCycle         chan bool
 	StopSFWListUpdateCycle     chan bool
+	StopScheduledContentCycle  chan bool
 	BackendReady               bool
 	DefaultKeyType             string
 	EntityVersions             entityVersions
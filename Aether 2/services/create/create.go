@@ -6,20 +6,29 @@ package create
 import (
 	"aether-core/io/api"
 	"aether-core/services/globals"
+	"aether-core/services/metaparse"
+	"aether-core/services/signaturing"
 	// "aether-core/services/logging"
 	// "aether-core/services/verify"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"golang.org/x/crypto/ed25519"
 	"time"
 )
 
 // Bake is the function that handles the core signature / pow / fingerprint trio.
 func Bake(entity api.Provable) error {
+	// logging.Logf(1, "globals.FrontendConfig.GetUserKeyPair(): %#s", globals.FrontendConfig.GetUserKeyPair())
+	return bakeWithKey(entity, globals.FrontendConfig.GetUserKeyPair())
+}
+
+// bakeWithKey bakes with a key pair that is not necessarily the user's current one. The only case where we need this is the creation of a successor key, which has to be signed by the new key pair before the user switches to it.
+func bakeWithKey(entity api.Provable, privKey *ed25519.PrivateKey) error {
 	// 1) Signature
 	// 2) PoW
 	// 3) Fingerprint
-	// logging.Logf(1, "globals.FrontendConfig.GetUserKeyPair(): %#s", globals.FrontendConfig.GetUserKeyPair())
-	err := entity.CreateSignature(globals.FrontendConfig.GetUserKeyPair())
+	err := entity.CreateSignature(privKey)
 	if err != nil {
 		return errors.New(fmt.Sprintf(
 			"Entity creation failed. Error: %s, Entity: %#v\n", err, entity))
@@ -27,17 +36,17 @@ func Bake(entity api.Provable) error {
 	err2 := *new(error)
 	switch ent := entity.(type) {
 	case *api.Board:
-		err2 = ent.CreatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().Board)
+		err2 = ent.CreatePoW(privKey, globals.FrontendConfig.GetMinimumPoWStrengths().Board)
 	case *api.Thread:
-		err2 = ent.CreatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().Thread)
+		err2 = ent.CreatePoW(privKey, globals.FrontendConfig.GetMinimumPoWStrengths().Thread+api.AttachmentPoWBits("Thread", ent.Meta))
 	case *api.Post:
-		err2 = ent.CreatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().Post)
+		err2 = ent.CreatePoW(privKey, globals.FrontendConfig.GetMinimumPoWStrengths().Post+api.AttachmentPoWBits("Post", ent.Meta))
 	case *api.Vote:
-		err2 = ent.CreatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().Vote)
+		err2 = ent.CreatePoW(privKey, globals.FrontendConfig.GetMinimumPoWStrengths().Vote)
 	case *api.Key:
-		err2 = ent.CreatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().Key)
+		err2 = ent.CreatePoW(privKey, globals.FrontendConfig.GetMinimumPoWStrengths().Key)
 	case *api.Truststate:
-		err2 = ent.CreatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().Truststate)
+		err2 = ent.CreatePoW(privKey, globals.FrontendConfig.GetMinimumPoWStrengths().Truststate)
 	}
 	if err2 != nil {
 		return errors.New(fmt.Sprintf(
@@ -60,9 +69,9 @@ func Rebake(entity api.Updateable) error {
 	case *api.Board:
 		err2 = ent.CreateUpdatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().BoardUpdate)
 	case *api.Thread:
-		err2 = ent.CreateUpdatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().ThreadUpdate)
+		err2 = ent.CreateUpdatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().ThreadUpdate+api.AttachmentPoWBits("Thread", ent.Meta))
 	case *api.Post:
-		err2 = ent.CreateUpdatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().PostUpdate)
+		err2 = ent.CreateUpdatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().PostUpdate+api.AttachmentPoWBits("Post", ent.Meta))
 	case *api.Vote:
 		err2 = ent.CreateUpdatePoW(globals.FrontendConfig.GetUserKeyPair(), globals.FrontendConfig.GetMinimumPoWStrengths().VoteUpdate)
 	case *api.Key:
@@ -288,6 +297,45 @@ func CreateKey(
 	return entity, nil
 }
 
+// CreateSuccessorKey creates a new key entity that succeeds the given key. The new key is signed by the new key pair, and carries the signature of the old (current) key pair over its public key, which is the proof that the old key handed over to it. The old key needs to be updated to point at the new key as well for the succession to be complete, see UpdateKey.
+func CreateSuccessorKey(
+	predecessor *api.Key,
+	newPrivKey *ed25519.PrivateKey,
+	name string,
+	info string,
+	expiry api.Timestamp,
+	realmId api.Fingerprint,
+) (api.Key, error) {
+	var blankEntity api.Key
+	newPubKey := signaturing.MarshalPublicKey(newPrivKey.Public().(ed25519.PublicKey))
+	succSig, err := signaturing.Sign(newPubKey, globals.FrontendConfig.GetUserKeyPair())
+	if err != nil {
+		return blankEntity, errors.New(fmt.Sprintf("Succession signature creation failed. Error: %v", err))
+	}
+	meta, err2 := metaparse.CreateMetaString(&metaparse.KeyMeta{
+		Predecessor:          string(predecessor.Fingerprint),
+		PredecessorSignature: succSig,
+	})
+	if err2 != nil {
+		return blankEntity, err2
+	}
+	var entity api.Key
+	entity.Creation = api.Timestamp(time.Now().Unix())
+	entity.Type = globals.FrontendTransientConfig.DefaultKeyType
+	entity.Key = newPubKey
+	entity.Name = name
+	entity.Info = info
+	entity.EntityVersion = globals.FrontendTransientConfig.EntityVersions.Key
+	entity.Expiry = expiry
+	entity.Meta = meta
+	entity.RealmId = realmId
+	err3 := bakeWithKey(&entity, newPrivKey)
+	if err3 != nil {
+		return blankEntity, err3
+	}
+	return entity, nil
+}
+
 func CreateTruststate(
 	targetFp api.Fingerprint,
 	ownerFp api.Fingerprint,
@@ -338,10 +386,45 @@ func UpdateBoard(request BoardUpdateRequest) error {
 		request.Entity.Description = request.NewDescription
 	}
 	request.Entity.LastUpdate = api.Timestamp(time.Now().Unix())
-	err := Rebake(request.Entity)
+	err := setBoardUpdater(request.Entity)
 	if err != nil {
 		return err
 	}
+	err2 := Rebake(request.Entity)
+	if err2 != nil {
+		return err2
+	}
+	return nil
+}
+
+// setBoardUpdater names the local user in the meta of the board update if they are a board owner and not the creator of the board, since the update is signed with their key. If the creator is updating, it removes the board owner an earlier update might have named. (See metaparse.BoardMeta)
+func setBoardUpdater(b *api.Board) error {
+	bm := metaparse.BoardMeta{}
+	m, err := metaparse.ReadMeta("Board", b.Meta)
+	if err == nil && m != nil {
+		bm = *m.(*metaparse.BoardMeta)
+	}
+	pk := globals.FrontendConfig.GetMarshaledUserPublicKey()
+	if pk == b.OwnerPublicKey {
+		if len(bm.Updater) == 0 && len(bm.UpdaterPublicKey) == 0 {
+			return nil
+		}
+		bm.Updater = ""
+		bm.UpdaterPublicKey = ""
+	} else {
+		var k api.Key
+		json.Unmarshal([]byte(globals.FrontendConfig.GetDehydratedLocalUserKeyEntity()), &k)
+		if len(k.Fingerprint) == 0 {
+			return errors.New(fmt.Sprintf("This board update is by a board owner, but the local user has no key entity to name them with. Board: %s", b.Fingerprint))
+		}
+		bm.Updater = string(k.Fingerprint)
+		bm.UpdaterPublicKey = pk
+	}
+	meta, err2 := metaparse.CreateMetaString(&bm)
+	if err2 != nil {
+		return err2
+	}
+	b.Meta = meta
 	return nil
 }
 
@@ -405,6 +488,8 @@ type KeyUpdateRequest struct {
 	NewInfo       string
 	ExpiryUpdated bool
 	NewExpiry     api.Timestamp
+	MetaUpdated   bool // Succession and revocation live in the meta.
+	NewMeta       string
 }
 
 func UpdateKey(request KeyUpdateRequest) error {
@@ -414,6 +499,9 @@ func UpdateKey(request KeyUpdateRequest) error {
 	if request.ExpiryUpdated {
 		request.Entity.Expiry = request.NewExpiry
 	}
+	if request.MetaUpdated {
+		request.Entity.Meta = request.NewMeta
+	}
 	request.Entity.LastUpdate = api.Timestamp(time.Now().Unix())
 	err := Rebake(request.Entity)
 	if err != nil {
@@ -1,16 +1,15 @@
 package create_test
 
 import (
+	"aether-core/frontend/fecmd"
 	"aether-core/io/api"
-	"aether-core/services/configstore"
 	"aether-core/services/create"
 	"aether-core/services/globals"
-	"aether-core/services/logging"
-	// "aether-core/services/signaturing"
-	"aether-core/services/verify"
+	"aether-core/services/metaparse"
+	"aether-core/services/signaturing"
 	// "fmt"
-	"crypto/elliptic"
-	"encoding/hex"
+	"encoding/json"
+	"golang.org/x/crypto/ed25519"
 	"os"
 	"strings"
 	"testing"
@@ -28,36 +27,32 @@ func TestMain(m *testing.M) {
 var UserKeyEntity api.Key
 var MarshaledPubKey string
 
-func startconfigs() {
-	becfg, err := configstore.EstablishBackendConfig()
-	if err != nil {
-		logging.LogCrash(err)
-	}
-	becfg.Cycle()
-	globals.BackendConfig = becfg
-
-	fecfg, err := configstore.EstablishFrontendConfig()
-	if err != nil {
-		logging.LogCrash(err)
-	}
-	fecfg.Cycle()
-	globals.FrontendConfig = fecfg
-}
-
 func setup() {
-
-	startconfigs()
-	globals.BackendConfig.SetMinimumPoWStrengths(16)
-
-	// globals.SetMinPoWStrengths(16)
-	MarshaledPubKey = hex.EncodeToString(elliptic.Marshal(elliptic.P521(), globals.FrontendConfig.GetUserKeyPair().PublicKey.X, globals.FrontendConfig.GetUserKeyPair().PublicKey.Y))
-	UserKeyEntity, _ = create.CreateKey("", MarshaledPubKey, "", "")
-
+	fecmd.EstablishConfigs(nil)
+	globals.FrontendTransientConfig.PermConfigReadOnly = true
+	globals.FrontendConfig.SetMinimumPoWStrengths(16)
+	MarshaledPubKey = globals.FrontendConfig.GetMarshaledUserPublicKey()
+	UserKeyEntity, _ = create.CreateKey(MarshaledPubKey, "user name", "", 0, "", "")
+	// Board owners name themselves in their board updates with their key entity.
+	keyJson, _ := json.Marshal(UserKeyEntity)
+	globals.FrontendConfig.SetDehydratedLocalUserKeyEntity(string(keyJson))
 }
 
 func teardown() {
 }
 
+// verifyValid checks that the entity verifies.
+func verifyValid(t *testing.T, entity api.Provable) {
+	t.Helper()
+	err := api.Verify(entity)
+	if err != nil {
+		t.Errorf("Object verification process failed. Err: '%s'", err)
+	}
+	if entity.GetVerified() != true {
+		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
+	}
+}
+
 // Tests
 
 // Tests for sub-entity creation
@@ -77,23 +72,17 @@ func TestCreateBoardOwner_Success(t *testing.T) {
 
 func TestCreateBoard_Success(t *testing.T) {
 	bo, _ :=
-		create.CreateBoardOwner(UserKeyEntity.GetOwner(), api.Timestamp(12345678), uint8(1))
+		create.CreateBoardOwner(UserKeyEntity.GetFingerprint(), api.Timestamp(12345678), uint8(1))
 	entity, err :=
 		create.CreateBoard(
 			"My board name",
-			bo.KeyFingerprint,
+			UserKeyEntity.GetFingerprint(), MarshaledPubKey,
 			[]api.BoardOwner{bo},
-			"my description")
+			"my description", "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
-	result, err2 := verify.Verify(&entity, UserKeyEntity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
-	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
-	}
+	verifyValid(t, &entity)
 }
 
 func TestCreateThread_Success(t *testing.T) {
@@ -103,17 +92,11 @@ func TestCreateThread_Success(t *testing.T) {
 			"thread name",
 			"thread body",
 			"thread link",
-			UserKeyEntity.GetOwner())
+			UserKeyEntity.GetFingerprint(), MarshaledPubKey, "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
-	result, err2 := verify.Verify(&entity, UserKeyEntity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
-	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
-	}
+	verifyValid(t, &entity)
 }
 
 func TestCreatePost_Success(t *testing.T) {
@@ -123,17 +106,11 @@ func TestCreatePost_Success(t *testing.T) {
 			"Post parent (thread) fingerprint",
 			"Post parent (post or thread) fingerprint",
 			"Post body",
-			UserKeyEntity.GetOwner())
+			UserKeyEntity.GetFingerprint(), MarshaledPubKey, "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
-	result, err2 := verify.Verify(&entity, UserKeyEntity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
-	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
-	}
+	verifyValid(t, &entity)
 }
 
 func TestCreateVote_Success(t *testing.T) {
@@ -142,18 +119,12 @@ func TestCreateVote_Success(t *testing.T) {
 			"board fp",
 			"thread fp",
 			"target fp",
-			UserKeyEntity.GetOwner(),
-			uint8(1))
+			UserKeyEntity.GetFingerprint(), MarshaledPubKey,
+			1, 1, "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
-	result, err2 := verify.Verify(&entity, UserKeyEntity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
-	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
-	}
+	verifyValid(t, &entity)
 }
 
 func TestCreateAddress_Success(t *testing.T) {
@@ -165,6 +136,7 @@ func TestCreateAddress_Success(t *testing.T) {
 			uint16(4732),
 			uint8(1),
 			api.Timestamp(12345678),
+			api.Timestamp(12345678),
 			uint8(1),
 			uint16(1),
 			[]api.Subprotocol{api.Subprotocol{"c0", 1, 0, []string{"board", "thread", "post", "vote", "key", "truststate"}}},
@@ -172,6 +144,7 @@ func TestCreateAddress_Success(t *testing.T) {
 			uint16(1),
 			uint16(0),
 			"my client name",
+			"",
 		)
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
@@ -181,41 +154,29 @@ func TestCreateAddress_Success(t *testing.T) {
 func TestCreateKey_Success(t *testing.T) {
 	entity, err :=
 		create.CreateKey(
-			"key type",
 			MarshaledPubKey,
 			"user name",
-			"key info")
+			"key info",
+			0, "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
 	// fmt.Printf("%#v\n", entity)
-	result, err2 := verify.Verify(&entity, entity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
-	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
-	}
+	verifyValid(t, &entity)
 }
 
 func TestCreateTruststate_Success(t *testing.T) {
 	entity, err :=
 		create.CreateTruststate(
 			"target fp",
-			UserKeyEntity.GetFingerprint(),
-			uint8(1),
-			[]api.Fingerprint{"domain1fp", "domain2fp"},
-			api.Timestamp(12345678))
+			UserKeyEntity.GetFingerprint(), MarshaledPubKey,
+			1, 1,
+			"domainfp",
+			api.Timestamp(12345678), "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
-	result, err2 := verify.Verify(&entity, UserKeyEntity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
-	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
-	}
+	verifyValid(t, &entity)
 }
 
 // Entity updates
@@ -226,9 +187,9 @@ func TestUpdateBoard_Success(t *testing.T) {
 	entity, err :=
 		create.CreateBoard(
 			"My board name",
-			bo.KeyFingerprint,
+			UserKeyEntity.GetFingerprint(), MarshaledPubKey,
 			[]api.BoardOwner{bo},
-			"my description")
+			"my description", "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
@@ -237,15 +198,37 @@ func TestUpdateBoard_Success(t *testing.T) {
 	updatereq.DescriptionUpdated = true
 	updatereq.NewDescription = "I changed the board description!"
 	create.UpdateBoard(updatereq)
-	result, err2 := verify.Verify(&entity, UserKeyEntity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
+	verifyValid(t, &entity)
+	// fmt.Printf("%#v\n", entity)
+}
+
+// A board owner updates a board somebody else made. The update is signed by the board owner's key, and names them in the meta.
+func TestUpdateBoard_ByBoardOwner_Success(t *testing.T) {
+	creatorKey, _ := signaturing.CreateKeyPair()
+	var entity api.Board
+	entity.Name = "My board name"
+	entity.Owner = "creator fp"
+	entity.OwnerPublicKey = signaturing.MarshalPublicKey(creatorKey.Public().(ed25519.PublicKey))
+	entity.BoardOwners = []api.BoardOwner{api.BoardOwner{KeyFingerprint: UserKeyEntity.GetFingerprint(), Level: api.BoardOwnerLevelMod}}
+	entity.Description = "my description"
+	entity.Creation = api.Timestamp(12345678)
+	entity.EntityVersion = globals.FrontendTransientConfig.EntityVersions.Board
+	entity.CreateSignature(creatorKey)
+	entity.CreatePoW(creatorKey, 16)
+	entity.CreateFingerprint()
+	updatereq := create.BoardUpdateRequest{}
+	updatereq.Entity = &entity
+	updatereq.DescriptionUpdated = true
+	updatereq.NewDescription = "A board owner changed the board description!"
+	err := create.UpdateBoard(updatereq)
+	if err != nil {
+		t.Errorf("Object update failed. Err: '%s'", err)
 	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
+	updater, updaterPk := metaparse.ReadBoardUpdater(entity.Meta)
+	if api.Fingerprint(updater) != UserKeyEntity.GetFingerprint() || updaterPk != MarshaledPubKey {
+		t.Errorf("The board update does not name the board owner that made it. Meta: '%s'", entity.Meta)
 	}
-	// fmt.Printf("%#v\n", entity)
-	// fmt.Printf("%#v\n", result)
+	verifyValid(t, &entity)
 }
 
 func TestUpdateVote_Success(t *testing.T) {
@@ -254,34 +237,27 @@ func TestUpdateVote_Success(t *testing.T) {
 			"board fp",
 			"thread fp",
 			"target fp",
-			UserKeyEntity.GetFingerprint(),
-			uint8(1))
+			UserKeyEntity.GetFingerprint(), MarshaledPubKey,
+			1, 1, "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
 	updatereq := create.VoteUpdateRequest{}
 	updatereq.Entity = &entity
 	updatereq.TypeUpdated = true
-	updatereq.NewType = 0
+	updatereq.NewType = 2
 	create.UpdateVote(updatereq)
-	result, err2 := verify.Verify(&entity, UserKeyEntity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
-	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
-	}
+	verifyValid(t, &entity)
 	// fmt.Printf("%#v\n", entity)
-	// fmt.Printf("%#v\n", result)
 }
 
 func TestUpdateKey_Success(t *testing.T) {
 	entity, err :=
 		create.CreateKey(
-			"key type",
 			MarshaledPubKey,
 			"user name",
-			"key info")
+			"key info",
+			0, "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
@@ -290,12 +266,28 @@ func TestUpdateKey_Success(t *testing.T) {
 	updatereq.InfoUpdated = true
 	updatereq.NewInfo = "This is my new key info."
 	create.UpdateKey(updatereq)
-	result, err2 := verify.Verify(&entity, entity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
+	verifyValid(t, &entity)
+}
+
+func TestCreateSuccessorKey_Success(t *testing.T) {
+	newPrivKey, _ := signaturing.CreateKeyPair()
+	entity, err :=
+		create.CreateSuccessorKey(&UserKeyEntity, newPrivKey, "user name", "key info", api.Timestamp(0), "")
+	if err != nil {
+		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
+	verifyValid(t, &entity)
+	m, err3 := metaparse.ReadMeta("Key", entity.Meta)
+	if err3 != nil || m == nil {
+		t.Errorf("The successor key meta could not be read. Err: '%s'", err3)
+		return
+	}
+	km := m.(*metaparse.KeyMeta)
+	if km.Predecessor != string(UserKeyEntity.Fingerprint) {
+		t.Errorf("The successor key does not point at its predecessor. Meta: '%#v\n'", km)
+	}
+	if !signaturing.Verify(entity.Key, km.PredecessorSignature, globals.FrontendConfig.GetMarshaledUserPublicKey()) {
+		t.Errorf("The succession signature does not verify against the predecessor key.")
 	}
 }
 
@@ -303,10 +295,10 @@ func TestUpdateTruststate_Success(t *testing.T) {
 	entity, err :=
 		create.CreateTruststate(
 			"target fp",
-			UserKeyEntity.GetFingerprint(),
-			uint8(1),
-			[]api.Fingerprint{"domain1fp", "domain2fp"},
-			api.Timestamp(12345678))
+			UserKeyEntity.GetFingerprint(), MarshaledPubKey,
+			1, 1,
+			"domainfp",
+			api.Timestamp(12345678), "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
@@ -315,15 +307,8 @@ func TestUpdateTruststate_Success(t *testing.T) {
 	updatereq.TypeUpdated = true
 	updatereq.NewType = 3
 	create.UpdateTruststate(updatereq)
-	result, err2 := verify.Verify(&entity, UserKeyEntity)
-	if err2 != nil {
-		t.Errorf("Object verification process failed. Err: '%s'", err2)
-	}
-	if result != true {
-		t.Errorf("This object should be valid, but it is invalid. Entity: '%#v\n'", entity)
-	}
+	verifyValid(t, &entity)
 	// fmt.Printf("%#v\n", entity)
-	// fmt.Printf("%#v\n", result)
 }
 
 func TestUpdateVote_EditAfter_Fail(t *testing.T) {
@@ -332,25 +317,23 @@ func TestUpdateVote_EditAfter_Fail(t *testing.T) {
 			"board fp",
 			"thread fp",
 			"target fp",
-			"owner fp",
-			uint8(1))
+			UserKeyEntity.GetFingerprint(), MarshaledPubKey,
+			1, 1, "", "")
 	if err != nil {
 		t.Errorf("Object creation failed. Err: '%s'", err)
 	}
 	updatereq := create.VoteUpdateRequest{}
 	updatereq.Entity = &entity
 	updatereq.TypeUpdated = true
-	updatereq.NewType = 0
+	updatereq.NewType = 2
 	create.UpdateVote(updatereq)
-	entity.Type = 2
+	entity.Type = 3
 	errMessage := "This proof of work is invalid or malformed"
-	result, err2 := verify.Verify(&entity, UserKeyEntity)
-	if err2 == nil || result == true {
+	err2 := api.Verify(&entity)
+	if err2 == nil || entity.GetVerified() == true {
 		t.Errorf("Expected an error to be raised from this test.")
-	}
-	if !strings.Contains(err2.Error(), errMessage) {
+	} else if !strings.Contains(err2.Error(), errMessage) {
 		t.Errorf("Test returned an error that did not include the expected one. Error: '%s', Expected error: '%s'", err2, errMessage)
 	}
 	// fmt.Printf("%#v\n", entity)
-	// fmt.Printf("%#v\n", result)
 }
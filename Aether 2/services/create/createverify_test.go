@@ -1,41 +1,17 @@
-package api_test
+package create_test
 
 import (
-	"aether-core/backend/cmd"
 	"aether-core/io/api"
 	// "aether-core/services/configstore"
 	"aether-core/services/create"
 	"aether-core/services/globals"
 	// "aether-core/services/logging"
 	"aether-core/services/signaturing"
-	"crypto/elliptic"
-	"encoding/hex"
 	// "fmt"
-	"os"
 	"strings"
 	"testing"
 )
 
-// Infrastructure, setup and teardown
-
-func TestMain(m *testing.M) {
-	setup()
-	exitVal := m.Run()
-	teardown()
-	os.Exit(exitVal)
-}
-
-var MarshaledPubKey string
-
-func setup() {
-	cmd.EstablishConfigs(nil)
-	globals.BackendConfig.SetMinimumPoWStrengths(16)
-	MarshaledPubKey = hex.EncodeToString(elliptic.Marshal(elliptic.P521(), globals.FrontendConfig.GetUserKeyPair().PublicKey.X, globals.FrontendConfig.GetUserKeyPair().PublicKey.Y))
-}
-
-func teardown() {
-}
-
 // Tests
 
 func TestVerify_Success(t *testing.T) {
@@ -362,3 +338,132 @@ func TestCreate_ImproperVersion_Fail(t *testing.T) {
 		t.Errorf("Test returned an error that did not include the expected one. Error: '%s', Expected error: '%s'", err2, errMessage)
 	}
 }
+
+// Test the verification pipeline
+
+func TestVerifyProvables_StatsAndCache(t *testing.T) {
+	privKey, err := signaturing.CreateKeyPair()
+	if err != nil {
+		t.Errorf("Key pair creation failed. Err: '%s'", err)
+	}
+	good, err :=
+		create.CreateThread(
+			"my board fingerprint",
+			"my good thread",
+			"my thread body",
+			"my thread link",
+			"keyfp", MarshaledPubKey, "", "")
+	if err != nil {
+		t.Errorf("Object creation failed. Err: '%s'", err)
+	}
+	bad, err :=
+		create.CreateThread(
+			"my board fingerprint",
+			"my bad thread",
+			"my thread body",
+			"my thread link",
+			"keyfp", MarshaledPubKey, "", "")
+	if err != nil {
+		t.Errorf("Object creation failed. Err: '%s'", err)
+	}
+	bad.CreateSignature(privKey) // Signing it with a new key
+	bad.CreatePoW(globals.FrontendConfig.GetUserKeyPair(), 20)
+	bad.CreateFingerprint()
+	errs, stats := api.VerifyProvables([]api.Provable{&good, &bad})
+	if len(errs) != 1 || stats.FailedSignature != 1 || stats.Verified != 1 {
+		t.Errorf("Expected one verified entity and one signature failure. Stats: %s, Errors: %v", stats.String(), errs)
+	}
+	if !good.GetVerified() || bad.GetVerified() {
+		t.Errorf("The entities were not marked as verified correctly. Good: %v, Bad: %v", good.GetVerified(), bad.GetVerified())
+	}
+	// Verifying the same again comes from the cache, but only for the one that passed.
+	good.SetVerified(false)
+	_, stats2 := api.VerifyProvables([]api.Provable{&good, &bad})
+	if stats2.Cached != 1 || stats2.FailedSignature != 1 || !good.GetVerified() {
+		t.Errorf("Expected the verified entity to come from the cache. Stats: %s", stats2.String())
+	}
+	// A changed entity with the same fingerprint and last update is not taken from the cache.
+	good.Body = "changed body"
+	_, stats3 := api.VerifyProvables([]api.Provable{&good})
+	if stats3.Cached != 0 || stats3.Verified != 0 {
+		t.Errorf("A changed entity was taken from the cache. Stats: %s", stats3.String())
+	}
+}
+
+func TestCreateVerify_V2_Success(t *testing.T) {
+	thr, err :=
+		create.CreateThread(
+			"my board fingerprint",
+			"my thread name",
+			"my thread body",
+			"my thread link",
+			"keyfp", MarshaledPubKey, "", "")
+	if err != nil {
+		t.Errorf("Object creation failed. Err: '%s'", err)
+	}
+	v1Fp := thr.Fingerprint
+	// Bake it again as v2.
+	thr.EntityVersion = 2
+	thr.Signature = ""
+	thr.ProofOfWork = ""
+	thr.Fingerprint = ""
+	thr.CreateSignature(globals.FrontendConfig.GetUserKeyPair())
+	thr.CreatePoW(globals.FrontendConfig.GetUserKeyPair(), 16)
+	thr.CreateFingerprint()
+	if !strings.HasPrefix(string(thr.ProofOfWork), "MIM2:") {
+		t.Errorf("A v2 entity was not given a MIM2 PoW. PoW: %s", thr.ProofOfWork)
+	}
+	if thr.Fingerprint == v1Fp {
+		t.Errorf("A v2 entity has the same fingerprint as its v1 version.")
+	}
+	err2 := api.Verify(&thr)
+	if err2 != nil || !thr.GetVerified() {
+		t.Errorf("This v2 object should be valid, but it is invalid. Error: '%v'", err2)
+	}
+	// A v2 entity verified with the v1 rules, or the other way around, fails.
+	thr.EntityVersion = 1
+	thr.SetVerified(false)
+	if api.Verify(&thr) == nil {
+		t.Errorf("A v2 entity passed verification as a v1 entity.")
+	}
+}
+
+func TestCreateVerify_V2_EmptyAndNilListsAreTheSame(t *testing.T) {
+	var b1, b2 api.Board
+	b1.Name, b2.Name = "my board", "my board"
+	b1.EntityVersion, b2.EntityVersion = 2, 2
+	b1.BoardOwners = nil
+	b2.BoardOwners = []api.BoardOwner{}
+	b1.CreateSignature(globals.FrontendConfig.GetUserKeyPair())
+	b2.CreateSignature(globals.FrontendConfig.GetUserKeyPair())
+	b1.CreateFingerprint()
+	b2.CreateFingerprint()
+	// JSON writes these two differently (null and []), the canonical form does not.
+	if b1.Fingerprint != b2.Fingerprint {
+		t.Errorf("A nil and an empty list of board owners gave different fingerprints. %s, %s", b1.Fingerprint, b2.Fingerprint)
+	}
+	b2.Language = "en"
+	b2.CreateFingerprint()
+	if b1.Fingerprint == b2.Fingerprint {
+		t.Errorf("Two boards with different immutable fields have the same fingerprint.")
+	}
+}
+
+func TestFilterForRemote(t *testing.T) {
+	var r api.Response
+	var th1, th2 api.Thread
+	th1.EntityVersion = 1
+	th2.EntityVersion = 2
+	r.Threads = []api.Thread{th1, th2}
+	var old api.Address
+	old.Protocol.Subprotocols = []api.Subprotocol{api.Subprotocol{Name: "c0", VersionMajor: 1, VersionMinor: 0}}
+	oldResp := r
+	if removed := oldResp.FilterForRemote(&old); removed != 1 || len(oldResp.Threads) != 1 || oldResp.Threads[0].EntityVersion != 1 {
+		t.Errorf("A remote that only knows v1 was going to be sent v2 entities. Threads: %#v", oldResp.Threads)
+	}
+	current := old
+	current.Protocol.Subprotocols = []api.Subprotocol{api.Subprotocol{Name: "c0", VersionMajor: 1, VersionMinor: 1}}
+	if removed := r.FilterForRemote(&current); removed != 0 || len(r.Threads) != 2 {
+		t.Errorf("A remote that knows v2 was not going to be sent v2 entities. Threads: %#v", r.Threads)
+	}
+}
@@ -0,0 +1,124 @@
+// Services > Keystore
+// This module encrypts the private keys we keep in the config files with a passphrase the user chooses.
+
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/scrypt"
+	"strings"
+)
+
+/*
+The private keys (the user key, the frontend key and the backend key) are saved into the config files as hex strings. Without a passphrase, anyone who can read those files can take the identity of the user. When the user sets a passphrase, we derive a key from it with scrypt, and we seal the private key with AES-256-GCM, which is authenticated, so a wrong passphrase or a tampered file fails to open instead of returning garbage.
+
+A sealed value is a single string, so it lives in the same config field the plain hex key used to live in:
+
+  mimks1:<salt>:<nonce>:<ciphertext>
+
+All parts are hex. The prefix is the version of this format, if we ever change the KDF parameters, we'll bump it.
+*/
+
+const (
+	sealedPrefix = "mimks1"
+	saltSize     = 32
+	keySize      = 32 // AES-256
+	// scrypt parameters. N=32768 takes about 100ms on a laptop, which is fine because we only do this once per startup per key.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+// IsSealed returns whether the given config value is a sealed key, as opposed to a plain hex key.
+func IsSealed(val string) bool {
+	return strings.HasPrefix(val, sealedPrefix+":")
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts the plaintext with a key derived from the passphrase. Every call uses a fresh salt and nonce, so sealing the same value twice gives different outputs.
+func Seal(plaintext string, passphrase string) (string, error) {
+	if len(passphrase) == 0 {
+		return "", errors.New("Keystore seal failed. The passphrase is empty.")
+	}
+	salt := make([]byte, saltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("Keystore seal failed. Salt could not be generated. Error: %v", err))
+	}
+	key, err2 := deriveKey(passphrase, salt)
+	if err2 != nil {
+		return "", errors.New(fmt.Sprintf("Keystore seal failed. Key derivation failed. Error: %v", err2))
+	}
+	gcm, err3 := newGCM(key)
+	if err3 != nil {
+		return "", errors.New(fmt.Sprintf("Keystore seal failed. Cipher could not be created. Error: %v", err3))
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err4 := rand.Read(nonce)
+	if err4 != nil {
+		return "", errors.New(fmt.Sprintf("Keystore seal failed. Nonce could not be generated. Error: %v", err4))
+	}
+	// The prefix goes in as additional data, so that the version can't be swapped out from under the ciphertext.
+	ct := gcm.Seal(nil, nonce, []byte(plaintext), []byte(sealedPrefix))
+	return strings.Join([]string{
+		sealedPrefix,
+		hex.EncodeToString(salt),
+		hex.EncodeToString(nonce),
+		hex.EncodeToString(ct),
+	}, ":"), nil
+}
+
+// Open decrypts a sealed value with the passphrase. It errors out if the passphrase is wrong, or if the sealed value was modified.
+func Open(sealed string, passphrase string) (string, error) {
+	if !IsSealed(sealed) {
+		return "", errors.New("Keystore open failed. This value is not sealed.")
+	}
+	parts := strings.Split(sealed, ":")
+	if len(parts) != 4 {
+		return "", errors.New(fmt.Sprintf("Keystore open failed. The sealed value is malformed. Parts: %d", len(parts)))
+	}
+	salt, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("Keystore open failed. The salt is malformed. Error: %v", err))
+	}
+	nonce, err2 := hex.DecodeString(parts[2])
+	if err2 != nil {
+		return "", errors.New(fmt.Sprintf("Keystore open failed. The nonce is malformed. Error: %v", err2))
+	}
+	ct, err3 := hex.DecodeString(parts[3])
+	if err3 != nil {
+		return "", errors.New(fmt.Sprintf("Keystore open failed. The ciphertext is malformed. Error: %v", err3))
+	}
+	key, err4 := deriveKey(passphrase, salt)
+	if err4 != nil {
+		return "", errors.New(fmt.Sprintf("Keystore open failed. Key derivation failed. Error: %v", err4))
+	}
+	gcm, err5 := newGCM(key)
+	if err5 != nil {
+		return "", errors.New(fmt.Sprintf("Keystore open failed. Cipher could not be created. Error: %v", err5))
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return "", errors.New(fmt.Sprintf("Keystore open failed. The nonce has the wrong size. Size: %d", len(nonce)))
+	}
+	pt, err6 := gcm.Open(nil, nonce, ct, []byte(sealedPrefix))
+	if err6 != nil {
+		return "", errors.New("Keystore open failed. The passphrase is wrong, or the sealed value has been tampered with.")
+	}
+	return string(pt), nil
+}
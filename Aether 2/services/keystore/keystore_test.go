@@ -0,0 +1,59 @@
+package keystore_test
+
+import (
+	"aether-core/services/keystore"
+	"strings"
+	"testing"
+)
+
+// Tests
+
+func TestSealOpen_Success(t *testing.T) {
+	sealed, err := keystore.Seal("deadbeef", "correct horse battery staple")
+	if err != nil {
+		t.Errorf("Seal failed. Err: '%s'", err)
+	}
+	if !keystore.IsSealed(sealed) {
+		t.Errorf("Sealed value is not recognised as sealed. Value: %s", sealed)
+	}
+	if strings.Contains(sealed, "deadbeef") {
+		t.Errorf("Sealed value contains the plaintext. Value: %s", sealed)
+	}
+	pt, err2 := keystore.Open(sealed, "correct horse battery staple")
+	if err2 != nil {
+		t.Errorf("Open failed. Err: '%s'", err2)
+	}
+	if pt != "deadbeef" {
+		t.Errorf("Opened value does not match the plaintext. Value: %s", pt)
+	}
+}
+
+func TestOpen_WrongPassphrase_Fail(t *testing.T) {
+	sealed, _ := keystore.Seal("deadbeef", "correct horse battery staple")
+	_, err := keystore.Open(sealed, "incorrect horse battery staple")
+	if err == nil {
+		t.Errorf("Open with the wrong passphrase should have failed.")
+	}
+}
+
+func TestOpen_Tampered_Fail(t *testing.T) {
+	sealed, _ := keystore.Seal("deadbeef", "correct horse battery staple")
+	// Flip the last hex digit of the ciphertext.
+	last := sealed[len(sealed)-1]
+	flipped := byte('0')
+	if last == '0' {
+		flipped = '1'
+	}
+	tampered := sealed[:len(sealed)-1] + string(flipped)
+	_, err := keystore.Open(tampered, "correct horse battery staple")
+	if err == nil {
+		t.Errorf("Open of a tampered value should have failed.")
+	}
+}
+
+func TestSeal_EmptyPassphrase_Fail(t *testing.T) {
+	_, err := keystore.Seal("deadbeef", "")
+	if err == nil {
+		t.Errorf("Seal with an empty passphrase should have failed.")
+	}
+}
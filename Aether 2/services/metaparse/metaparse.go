@@ -15,15 +15,45 @@ var (
 
 /*----------  Meta payloads  ----------*/
 
-type BoardMeta struct{}
-type ThreadMeta struct{}
-type PostMeta struct{}
+type BoardMeta struct {
+	/*----------  Board owner updates  ----------*/
+	// Set by a board owner that updates the board. The update proof of work and the update signature are by this key, not by the creator's. (See api.CheckEntitlements)
+	Updater          string `json:"updater,omitempty"`
+	UpdaterPublicKey string `json:"updater_publickey,omitempty"`
+}
+type ThreadMeta struct {
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+type PostMeta struct {
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+/*
+Attachment is a file that is a part of a thread or a post. The file itself is not in the entity, it is split into chunks, and the chunks are addressed by their hashes (see io/blobstore). The entity only carries this, which is enough to find the chunks, and to check that they are the right ones.
+*/
+type Attachment struct {
+	Hash     string   `json:"hash"` // The hash of the chunk hashes, in order. This is what identifies the file.
+	Name     string   `json:"name,omitempty"`
+	MimeType string   `json:"mime_type,omitempty"`
+	Size     int64    `json:"size"`
+	Chunks   []string `json:"chunks"`
+}
 type VoteMeta struct {
 	/*----------  Follows guidelines  ----------*/
 	FGReason string `json:"fg_reason,omitempty"`
 	MAReason string `json:"ma_reason,omitempty"`
 }
-type KeyMeta struct{}
+type KeyMeta struct {
+	/*----------  Key succession  ----------*/
+	// Set by the old key with an update, pointing at the key that replaces it.
+	Successor string `json:"successor,omitempty"`
+	// Set by the new key at creation. The signature is the predecessor key signing the public key of the new key, so that the new key can prove the old key handed over to it.
+	Predecessor          string `json:"predecessor,omitempty"`
+	PredecessorSignature string `json:"predecessor_signature,omitempty"`
+	// Set by the old key with an update, if it has been compromised.
+	Revoked   bool  `json:"revoked,omitempty"`
+	RevokedAt int64 `json:"revoked_at,omitempty"`
+}
 type TruststateMeta struct {
 	CanonicalName string `json:"canonical_name,omitempty"`
 }
@@ -45,11 +75,26 @@ func ReadMeta(entityType, metaAsString string) (MetaStruct, error) {
 	}
 	switch entityType {
 	case "Board":
-		return nil, nil
+		em := BoardMeta{}
+		err := json.Unmarshal([]byte(metaAsString), &em)
+		if err != nil {
+			return nil, err
+		}
+		return &em, nil
 	case "Thread":
-		return nil, nil
+		em := ThreadMeta{}
+		err := json.Unmarshal([]byte(metaAsString), &em)
+		if err != nil {
+			return nil, err
+		}
+		return &em, nil
 	case "Post":
-		return nil, nil
+		em := PostMeta{}
+		err := json.Unmarshal([]byte(metaAsString), &em)
+		if err != nil {
+			return nil, err
+		}
+		return &em, nil
 	case "Vote":
 		em := VoteMeta{}
 		err := json.Unmarshal([]byte(metaAsString), &em)
@@ -58,7 +103,12 @@ func ReadMeta(entityType, metaAsString string) (MetaStruct, error) {
 		}
 		return &em, nil
 	case "Key":
-		return nil, nil
+		em := KeyMeta{}
+		err := json.Unmarshal([]byte(metaAsString), &em)
+		if err != nil {
+			return nil, err
+		}
+		return &em, nil
 	case "Truststate":
 		em := TruststateMeta{}
 		err := json.Unmarshal([]byte(metaAsString), &em)
@@ -74,3 +124,28 @@ func CreateMetaString(payloadStruct MetaStruct) (string, error) {
 	jsonAsByte, err := json.Marshal(payloadStruct)
 	return string(jsonAsByte), err
 }
+
+// ReadAttachments returns the attachments in the meta of a thread or a post. A meta that does not parse has no attachments, the meta of the threads and posts made before attachments could be anything.
+func ReadAttachments(entityType, metaAsString string) []Attachment {
+	m, err := ReadMeta(entityType, metaAsString)
+	if err != nil || m == nil {
+		return []Attachment{}
+	}
+	switch em := m.(type) {
+	case *ThreadMeta:
+		return em.Attachments
+	case *PostMeta:
+		return em.Attachments
+	}
+	return []Attachment{}
+}
+
+// ReadBoardUpdater returns the board owner that made the board update, if it was not made by the creator of the board. A meta that does not parse names nobody.
+func ReadBoardUpdater(metaAsString string) (string, string) {
+	m, err := ReadMeta("Board", metaAsString)
+	if err != nil || m == nil {
+		return "", ""
+	}
+	em := m.(*BoardMeta)
+	return em.Updater, em.UpdaterPublicKey
+}
@@ -11,13 +11,13 @@ import (
 	"strings"
 )
 
-// GetFreePort returns a free port that is currently unused in the local system.
+// GetFreePort returns a free port that is currently unused in the local system. The checks here are on "tcp", so on a dual-stack machine, a port is only free if it is free on both IPv4 and IPv6, and on a machine that has only one of the two, that one is enough.
 func GetFreePort() int {
-	a, err := net.ResolveTCPAddr("tcp4", ":0")
+	a, err := net.ResolveTCPAddr("tcp", ":0")
 	if err != nil {
 		logging.LogCrash(fmt.Sprintf("We could not parse the TCP address in an attempt to get a free port. The error raised was: %s", err))
 	}
-	l, err := net.ListenTCP("tcp4", a)
+	l, err := net.ListenTCP("tcp", a)
 	defer l.Close()
 	if err != nil {
 		logging.LogCrash(fmt.Sprintf("We could not listen to TCP in an attempt to get a free port. The error raised was: %s", err))
@@ -53,11 +53,11 @@ func GetFreePorts(number int) []int {
 
 // CheckPortAvailability checks for whether a port that it is given is currently free to use.
 func CheckPortAvailability(port uint16) bool {
-	a, err := net.ResolveTCPAddr("tcp4", fmt.Sprintf(":%d", port))
+	a, err := net.ResolveTCPAddr("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		logging.LogCrash(fmt.Sprintf("We could not parse the TCP address in an attempt to check the availability of the given port. The error raised was: %s, The port attempted to be checked was: %d", err, port))
 	}
-	l, err := net.ListenTCP("tcp4", a)
+	l, err := net.ListenTCP("tcp", a)
 	defer l.Close()
 	if err != nil {
 		if strings.Contains(err.Error(), "address already in use") || strings.Contains(err.Error(), "permission denied") {
@@ -6,6 +6,7 @@ package proofofwork
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/ed25519"
 	// "encoding/json"
 	"errors"
@@ -32,6 +33,8 @@ The workings of this can be outlined as such:
 5) Check whether the generated hash in hex has the number of zeroes that the hex. If not, increment the counter and try again.
 6) If hex has enough digits, convert to binary, and check if binary has enough digits. Binary digits >= hex digits * 4. If so, congrats! You got a winner. If not, increment the counter and try again.
 7) Format the result as needed, sign if needed, and return.
+
+MIM2 is the same, with two differences. The hash is a single pass of the algorithm named in the extension field (sha256 or blake2b), instead of SHA256x3. And the fields that go into the hash are separated, so there is only one way to read them. MIM2 is what the version 2 entities use, and MIM1 stays for the version 1 entities.
 */
 
 // Constants
@@ -95,15 +98,7 @@ var bailoutTimeSeconds int
 
 // Create creates the Hashcash proof of with the given difficulty. This function has an inner loop which adds a random element to the input and tries to find enough zeros at the beginning of the SHA1 hash of the result.
 func Create(input string, difficulty int, privKey *ed25519.PrivateKey) (string, error) {
-	if bailoutTimeSeconds == 0 {
-		if globals.BackendConfig != nil {
-			bailoutTimeSeconds = globals.BackendConfig.GetPoWBailoutTimeSeconds()
-		}
-
-		if globals.FrontendConfig != nil {
-			bailoutTimeSeconds = globals.FrontendConfig.GetPoWBailoutTimeSeconds()
-		}
-	}
+	initBailoutTime()
 	// First of all, check if BailoutSeconds exists. If this does not exist we have to exit as the allotted maximum time until a PoW is created will be zero.
 	difficulty64 := int64(difficulty)
 	if bailoutTimeSeconds == 0 {
@@ -127,14 +122,9 @@ func Create(input string, difficulty int, privKey *ed25519.PrivateKey) (string,
 	// Before creating the salt, we need to seed the random number generator first. We check if it is already seeded, we do nothing.
 	// fmt.Printf("%#v\n", rand.Seed)
 	// Create the salt.
-	saltBytes := make([]byte, 16)
-	for i := range saltBytes {
-		randNum, err := rand.Int(rand.Reader, big.NewInt(int64(len(LETTERS))))
-		if err != nil {
-			return "", errors.New(fmt.Sprint(
-				"Random number generator generated an error. err: ", err))
-		}
-		saltBytes[i] = LETTERS[int(randNum.Int64())]
+	saltBytes, err := createSalt()
+	if err != nil {
+		return "", err
 	}
 	// Add salt to the end of the input string.
 	inputToBePoWd := strconv.FormatInt(difficulty64, 10) +
@@ -170,6 +160,36 @@ func Create(input string, difficulty int, privKey *ed25519.PrivateKey) (string,
 	// Mind the terminating ":" in case of no signature.
 	proofOfWork := "MIM1" + ":" + strconv.FormatInt(difficulty64, 10) + "::::" +
 		string(saltBytes) + ":" + strconv.FormatInt(counter, 10) + ":"
+	return signPoW(proofOfWork, privKey)
+}
+
+func initBailoutTime() {
+	if bailoutTimeSeconds == 0 {
+		if globals.BackendConfig != nil {
+			bailoutTimeSeconds = globals.BackendConfig.GetPoWBailoutTimeSeconds()
+		}
+
+		if globals.FrontendConfig != nil {
+			bailoutTimeSeconds = globals.FrontendConfig.GetPoWBailoutTimeSeconds()
+		}
+	}
+}
+
+func createSalt() ([]byte, error) {
+	saltBytes := make([]byte, 16)
+	for i := range saltBytes {
+		randNum, err := rand.Int(rand.Reader, big.NewInt(int64(len(LETTERS))))
+		if err != nil {
+			return saltBytes, errors.New(fmt.Sprint(
+				"Random number generator generated an error. err: ", err))
+		}
+		saltBytes[i] = LETTERS[int(randNum.Int64())]
+	}
+	return saltBytes, nil
+}
+
+// signPoW signs the finished PoW with the given key, if there is one.
+func signPoW(proofOfWork string, privKey *ed25519.PrivateKey) (string, error) {
 	if len(signaturing.MarshalPrivateKey(*privKey)) > 0 {
 		// We have a private key. Sign the hash with this key.
 		// The result will be in the format of [Rest of PoW]:[Signature]
@@ -187,6 +207,79 @@ func Create(input string, difficulty int, privKey *ed25519.PrivateKey) (string,
 	}
 }
 
+// The hash algorithms MIM2 can use. The algorithm is written into the extension field of the PoW, so that a verifier never has to guess.
+const (
+	AlgorithmSHA256  = "sha256"
+	AlgorithmBLAKE2b = "blake2b"
+)
+
+// CreateV2 creates a MIM2 proof of work. Unlike MIM1, which hashes with SHA256x3, MIM2 hashes once with the given algorithm, and names the algorithm in the PoW itself.
+func CreateV2(input string, difficulty int, algorithm string, privKey *ed25519.PrivateKey) (string, error) {
+	initBailoutTime()
+	difficulty64 := int64(difficulty)
+	if bailoutTimeSeconds == 0 {
+		return "", errors.New(fmt.Sprint(
+			"Please initialise BailoutSeconds first."))
+	}
+	if _, ok := hashV2(algorithm, []byte{}); !ok {
+		return "", errors.New(fmt.Sprint(
+			"This hash algorithm is not supported for proof of work. Algorithm: ", algorithm))
+	}
+	saltBytes, err := createSalt()
+	if err != nil {
+		return "", err
+	}
+	prefix := mim2Input(difficulty64, algorithm, string(saltBytes), input)
+	var counter int64
+	timeCounter := int(time.Now().Unix())
+	for {
+		// This is the tight loop.
+		now := int(time.Now().Unix())
+		if now-(timeCounter+bailoutTimeSeconds) > 0 {
+			return "", errors.New(fmt.Sprint(
+				"The timestamp took too long to create."))
+		}
+		result, _ := hashV2(algorithm, []byte(prefix+strconv.FormatInt(counter, 10)))
+		if hasLeadingZeroBits(result, difficulty) {
+			break
+		}
+		counter++
+	}
+	proofOfWork := "MIM2" + ":" + strconv.FormatInt(difficulty64, 10) + ":::" + algorithm + ":" +
+		string(saltBytes) + ":" + strconv.FormatInt(counter, 10) + ":"
+	return signPoW(proofOfWork, privKey)
+}
+
+// mim2Input is what MIM2 hashes, save for the counter, which goes at the end. Every field is separated, so that the difficulty, the algorithm and the salt can't run into the input.
+func mim2Input(difficulty int64, algorithm string, salt string, input string) string {
+	return "MIM2:" + strconv.FormatInt(difficulty, 10) + ":" + algorithm + ":" + salt + ":" + input + ":"
+}
+
+func hashV2(algorithm string, input []byte) ([]byte, bool) {
+	switch algorithm {
+	case AlgorithmSHA256:
+		h := sha256.Sum256(input)
+		return h[:], true
+	case AlgorithmBLAKE2b:
+		h := blake2b.Sum256(input)
+		return h[:], true
+	}
+	return []byte{}, false
+}
+
+// hasLeadingZeroBits checks whether the hash starts with at least the given number of zero bits.
+func hasLeadingZeroBits(hash []byte, bits int) bool {
+	if bits > len(hash)*8 {
+		return false
+	}
+	for i := 0; i < bits; i++ {
+		if hash[i/8]&(0x80>>uint(i%8)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // Verify validates whether the given Hashcash token is strong enough to satisfy the given difficulty.
 func Verify(input string, pow string, pubKey string) (bool, int, error) {
 	// MimHashcash syntax:
@@ -203,8 +296,8 @@ func Verify(input string, pow string, pubKey string) (bool, int, error) {
 		return false, 0, errors.New(fmt.Sprint(
 			"PoW had more or less fields than expected. PoW: ", pow))
 	}
-	// Second, check for whether they are empty or not. Date, input, extension should always be empty. Version, difficulty, salt and counter should always be non-empty.
-	if parsedStrings[2] != "" || parsedStrings[3] != "" || parsedStrings[4] != "" || parsedStrings[0] == "" || parsedStrings[1] == "" || parsedStrings[5] == "" || parsedStrings[6] == "" {
+	// Second, check for whether they are empty or not. Date and input should always be empty. Extension is empty in MIM1, and it holds the hash algorithm in MIM2. Version, difficulty, salt and counter should always be non-empty.
+	if parsedStrings[2] != "" || parsedStrings[3] != "" || (parsedStrings[0] == "MIM1" && parsedStrings[4] != "") || parsedStrings[0] == "" || parsedStrings[1] == "" || parsedStrings[5] == "" || parsedStrings[6] == "" {
 		return false, 0, errors.New(fmt.Sprint(
 			"This proof of work either has fields that should be empty and is not, or it does have empty fields which it should not. PoW: ", pow))
 	}
@@ -253,37 +346,53 @@ func Verify(input string, pow string, pubKey string) (bool, int, error) {
 		}
 		if resultBinary[:parsedDifficulty] == zeroBinDigits {
 			// SUCCESS, the PoW has *at least* the given number of zeroes at the beginning. We will still accept the difficulty at the declared level, so that the user gets no free zeroes.
-
-			// Signature check starts here.
-			// Check if there is a signature, if a key is provided, and if signature is valid.
-			if len(pubKey) > 0 && len(parsedSignature) > 0 {
-				// We have both the key and the signature.
-				stringToBeSignatureChecked := parsedStrings[0] + ":" + parsedStrings[1] + ":" + parsedStrings[2] + ":" + parsedStrings[3] + ":" + parsedStrings[4] + ":" + parsedStrings[5] + ":" + parsedStrings[6] + ":"
-				verifyResult := signaturing.Verify(stringToBeSignatureChecked, parsedSignature, pubKey)
-				if verifyResult != true {
-					return verifyResult, parsedDifficulty, errors.New(fmt.Sprint(
-						"The signature of this PoW is invalid. The PoW signature and the public key provided does not match. PoW: ", pow))
-				}
-				return verifyResult, parsedDifficulty, nil
-			} else if len(pubKey) > 0 {
-				// We have the key but no signature in PoW. Bail.
-				return false, 0, errors.New(fmt.Sprint(
-					"A key is provided, but the PoW is unsigned. PoW: ", pow))
-			} else if len(parsedSignature) > 0 {
-				// We have the signature but no key is provided. Bail.
-				return false, 0, errors.New(fmt.Sprint(
-					"The PoW is signed, but a key is not provided."))
-			} else {
-				// We have neither key nor signature. This means that this is a PoW for an anonymous object.
-				return true, parsedDifficulty, nil
-			}
+			return verifySignature(parsedStrings, parsedSignature, pubKey, parsedDifficulty, pow)
 		} else {
 			return false, 0, errors.New(fmt.Sprint(
 				"This proof of work is invalid or malformed. PoW: ", pow))
 		}
+	case "MIM2":
+		algorithm := parsedStrings[4]
+		result, ok := hashV2(algorithm, []byte(mim2Input(parsedDifficulty64, algorithm, parsedSalt, input)+strconv.FormatInt(parsedCounter, 10)))
+		if !ok {
+			return false, 0, errors.New(fmt.Sprint(
+				"This proof of work is in a format Mim does not support. PoW: ", pow))
+		}
+		if !hasLeadingZeroBits(result, parsedDifficulty) {
+			return false, 0, errors.New(fmt.Sprint(
+				"This proof of work is invalid or malformed. PoW: ", pow))
+		}
+		// Same as MIM1, we accept the difficulty at the declared level.
+		return verifySignature(parsedStrings, parsedSignature, pubKey, parsedDifficulty, pow)
 	default:
 		// If this has a different version, bail. This is where we would create the next version's code in, if there is any.
 		return false, 0, errors.New(fmt.Sprint(
 			"This proof of work is in a format Mim does not support. PoW: ", pow))
 	}
 }
+
+// verifySignature checks the signature of a PoW whose hash has already been verified. It is the same for all versions: the signature is over all the fields before it.
+func verifySignature(parsedStrings []string, parsedSignature string, pubKey string, parsedDifficulty int, pow string) (bool, int, error) {
+	// Check if there is a signature, if a key is provided, and if signature is valid.
+	if len(pubKey) > 0 && len(parsedSignature) > 0 {
+		// We have both the key and the signature.
+		stringToBeSignatureChecked := parsedStrings[0] + ":" + parsedStrings[1] + ":" + parsedStrings[2] + ":" + parsedStrings[3] + ":" + parsedStrings[4] + ":" + parsedStrings[5] + ":" + parsedStrings[6] + ":"
+		verifyResult := signaturing.Verify(stringToBeSignatureChecked, parsedSignature, pubKey)
+		if verifyResult != true {
+			return verifyResult, parsedDifficulty, errors.New(fmt.Sprint(
+				"The signature of this PoW is invalid. The PoW signature and the public key provided does not match. PoW: ", pow))
+		}
+		return verifyResult, parsedDifficulty, nil
+	} else if len(pubKey) > 0 {
+		// We have the key but no signature in PoW. Bail.
+		return false, 0, errors.New(fmt.Sprint(
+			"A key is provided, but the PoW is unsigned. PoW: ", pow))
+	} else if len(parsedSignature) > 0 {
+		// We have the signature but no key is provided. Bail.
+		return false, 0, errors.New(fmt.Sprint(
+			"The PoW is signed, but a key is not provided."))
+	} else {
+		// We have neither key nor signature. This means that this is a PoW for an anonymous object.
+		return true, parsedDifficulty, nil
+	}
+}
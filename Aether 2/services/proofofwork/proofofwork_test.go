@@ -1,15 +1,13 @@
 package proofofwork_test
 
 import (
+	"aether-core/backend/cmd"
 	"aether-core/io/api"
-	"aether-core/services/configstore"
 	"aether-core/services/globals"
-	"aether-core/services/logging"
+	"aether-core/services/proofofwork"
 	"aether-core/services/signaturing"
 	// "fmt"
 	// "log"
-	"crypto/elliptic"
-	"encoding/hex"
 	"golang.org/x/crypto/ed25519"
 	"os"
 	"strings"
@@ -23,6 +21,7 @@ import (
 var newboard api.Board
 var signedNewboard api.Board
 var signedNewboardUpdated api.Board
+var signedNewboardPubkey string
 var signedNewBoardUpdatedPubkey string
 
 var invalidPoWBoard api.Board
@@ -43,78 +42,61 @@ func TestMain(m *testing.M) {
 
 func setup() {
 
-	becfg, err := configstore.EstablishBackendConfig()
-	if err != nil {
-		logging.LogCrash(err)
-	}
-	becfg.Cycle()
-	globals.BackendConfig = becfg
-
-	fecfg, err := configstore.EstablishFrontendConfig()
-	if err != nil {
-		logging.LogCrash(err)
-	}
-	fecfg.Cycle()
-	globals.FrontendConfig = fecfg
+	cmd.EstablishConfigs(nil)
+	globals.BackendTransientConfig.PermConfigReadOnly = true
 
 	// Set up the min PoW strengths from services. This is normally in main()
 	globals.BackendConfig.SetMinimumPoWStrengths(16)
 
+	// The PoW checks are off by default in the backend. These tests are about them.
+	globals.BackendTransientConfig.ProofOfWorkCheckEnabled = true
+	// The bailout time is read once, at the first PoW. The default is too long for TestCreatePoW_Fail_TookTooLong.
+	globals.BackendConfig.SetPoWBailoutTimeSeconds(30)
+
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my board description"
-	newboard.ProofOfWork = ""
-	newboard.ProofOfWork = "MIM1:20::::hvMazmkOQUvYriEB:630538:"
-	// To regenerate:
-	// newboard.CreatePoW(new(ed25519.PrivateKey), 20)
-	// fmt.Println(newboard.ProofOfWork)
+	newboard.CreatePoW(new(ed25519.PrivateKey), 20)
 
 	signedNewboard.Fingerprint = "my random fingerprint3"
 	signedNewboard.Creation = 4564654
+	signedNewboard.EntityVersion = 1
 	signedNewboard.Name = "my board name"
 	signedNewboard.Description = "my board description"
-	signedNewboard.ProofOfWork = "MIM1:20::::cJSTqJNnTcTcYgzH:2947609:3338744f899d411e399e35b7f23a48a43790142ff8533e13f5383991700788108c32564e773bc40e484ac915dbf059edc97e605b66587c4c4e70143922a96f3de0-0133ea996835ece4dd192165bd0a60f78872bf7ce84d8b0481cdb772e711dde0a69426cab70ce50d4385b5637068322167e1fcce77159f14d37794d31f91d1a4e59f"
-
-	// Marshaled pub key for this is:
-	//0400fa3aa273d67a3161069414879677f0ecf554a13edaa7d85387fe8fafaacea4beb1daf0284efa5f6ac0bc5181f3d20f748d53f79f3c1979cc58b7210dd7a161b0a4007bfb1315a6e78cd43855a4a40401fdc977a01c9f53616b52c92b16dc42fab04b84cc1788fbebc4f1449634cffe35e457680c432f28232d49aa8fc73fde8f374525
+	privKey, _ := signaturing.CreateKeyPair()
+	signedNewboard.CreatePoW(privKey, 20)
+	signedNewboardPubkey = signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 
-	// To regenerate:
-	// privKey, _ := signaturing.CreateKeyPair()
-	// signedNewboard.CreatePoW(privKey, 20)
-	// marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
-	// fmt.Println(signedNewboard.ProofOfWork)
-	// fmt.Println(marshaledPubKey)
-
-	// privKey, _ := signaturing.CreateKeyPair()
-	// fmt.Println(hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y)))
-	signedNewBoardUpdatedPubkey = "04011c1b73221ac0afbd404ab0aa86ed7ea99c2042b2f05581bccd6b0e321edeab2eb5a56cbf2b0a952aed53cc18c47b2511552d1613eb710ea05b0b2646590e9b96ea01317c365886e4ec7302ad0ca12a667676c6fe2aff3afeeaa5439823976bfb2e9b60e787bceba8a2b529adb7a1057d60b054b62e333f8f84c37a9272ae3372e63952"
+	privKey2, _ := signaturing.CreateKeyPair()
+	signedNewBoardUpdatedPubkey = signaturing.MarshalPublicKey(privKey2.Public().(ed25519.PublicKey))
 	signedNewboardUpdated.Fingerprint = "my random fingerprint"
 	signedNewboardUpdated.Creation = 4564654
+	signedNewboardUpdated.EntityVersion = 1
 	signedNewboardUpdated.Name = "my board name"
 	signedNewboardUpdated.Description = "description"
-	// signedNewboardUpdated.CreatePoW(privKey, 20)
-	// fmt.Println(signedNewboardUpdated.ProofOfWork)
+	signedNewboardUpdated.CreatePoW(privKey2, 20)
 	signedNewboardUpdated.Description = "I updated this board's description"
-	// signedNewboardUpdated.CreateUpdatePoW(privKey, 20)
-	// fmt.Println(signedNewboardUpdated.UpdateProofOfWork)
-	signedNewboardUpdated.ProofOfWork = "MIM1:20::::nEGYzEPiiPlurnCt:630323:37767a77da2d0d6cb33042ad1e5841dd1acbac99ae13375555d5ffded151734d8493e39027259c2c0d5edc01f628f31b2a2e2ebf86952638992856b07c51fafa76-01ea31d15880cfc7c5d2519878f12c94fb4c8d7deebec6a06549a93d124ac754fc7087e4d1fbcb8a9d1bf2407fbf87b28d0c3aa0c7f4aea4319328866c090b9453d5"
-	signedNewboardUpdated.UpdateProofOfWork = "MIM1:20::::DQXchTAxFPlMMAXl:745645:a59a5df7c787b5742242de80b18850ec0dd5130ee9bd4d2beb3ae8d4e3bd8ced1342cba34767abc41df791a37d5b18a41ffaddc7d0929c398745703b49436ea581-c7f29291c813157c339560f1d349bf5d2d62d968f98950a0ff61db4ff6927120c03c0b2f80539b8b028b09d84b33b5a9df0c9b73d3397ad0108f871cfb2bd35dbd"
+	signedNewboardUpdated.CreateUpdatePoW(privKey2, 20)
 
 	invalidPoWBoard.Fingerprint = "my random fingerprint"
 	invalidPoWBoard.Creation = 4564654
+	invalidPoWBoard.EntityVersion = 1
 	invalidPoWBoard.Name = "my board name"
 	invalidPoWBoard.Description = "my board description"
 	invalidPoWBoard.ProofOfWork = "MIM1:21::::QkaMjkJbvXInQLtW:1166891:"
 
 	weakPoWBoard.Fingerprint = "my random fingerprint"
 	weakPoWBoard.Creation = 4564654
+	weakPoWBoard.EntityVersion = 1
 	weakPoWBoard.Name = "my board name"
 	weakPoWBoard.Description = "my board description"
-	weakPoWBoard.ProofOfWork = "MIM1:18::::ZVqtpNqdZXkNcSpc:290736:"
+	weakPoWBoard.CreatePoW(new(ed25519.PrivateKey), 18)
 
 	fakeSignedBoard.Fingerprint = "my random fingerprint"
 	fakeSignedBoard.Creation = 4564654
+	fakeSignedBoard.EntityVersion = 1
 	fakeSignedBoard.Name = "my board name"
 	fakeSignedBoard.Description = "my board description"
 	fakeSignedBoard.ProofOfWork = "MIM1:20::::xDQPQMOBXYIMCDvE:1912024:fake key"
@@ -146,9 +128,8 @@ func TestVerifyPoW_Success_WithoutKey(t *testing.T) {
 }
 
 func TestVerifyPoW_Success_WithKey(t *testing.T) {
-	marshaledPubKey := "0400fa3aa273d67a3161069414879677f0ecf554a13edaa7d85387fe8fafaacea4beb1daf0284efa5f6ac0bc5181f3d20f748d53f79f3c1979cc58b7210dd7a161b0a4007bfb1315a6e78cd43855a4a40401fdc977a01c9f53616b52c92b16dc42fab04b84cc1788fbebc4f1449634cffe35e457680c432f28232d49aa8fc73fde8f374525"
 	// fmt.Printf("%#v\n", signedNewboard)
-	result, err := signedNewboard.VerifyPoW(marshaledPubKey)
+	result, err := signedNewboard.VerifyPoW(signedNewboardPubkey)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else if result != true {
@@ -214,6 +195,7 @@ func TestVerifyPoW_Fail_UnrecognisedPoWVersion(t *testing.T) {
 	var brokenVersionPoWBoard api.Board
 	brokenVersionPoWBoard.Fingerprint = "my random fingerprint"
 	brokenVersionPoWBoard.Creation = 4564654
+	brokenVersionPoWBoard.EntityVersion = 1
 	brokenVersionPoWBoard.Name = "my board name"
 	brokenVersionPoWBoard.Description = "my board description"
 	brokenVersionPoWBoard.ProofOfWork = "MIM2:21::::QkaMjkJbvXInQLtW:1166891:"
@@ -230,6 +212,7 @@ func TestVerifyPoW_Fail_ParsedDifficultyIsNegative(t *testing.T) {
 	var brokenVersionPoWBoard api.Board
 	brokenVersionPoWBoard.Fingerprint = "my random fingerprint"
 	brokenVersionPoWBoard.Creation = 4564654
+	brokenVersionPoWBoard.EntityVersion = 1
 	brokenVersionPoWBoard.Name = "my board name"
 	brokenVersionPoWBoard.Description = "my board description"
 	brokenVersionPoWBoard.ProofOfWork = "MIM1:-20::::QkaMjkJbvXInQLtW:1166891:"
@@ -246,6 +229,7 @@ func TestVerifyPoW_Fail_ParsedCounterIsNegative(t *testing.T) {
 	var brokenVersionPoWBoard api.Board
 	brokenVersionPoWBoard.Fingerprint = "my random fingerprint"
 	brokenVersionPoWBoard.Creation = 4564654
+	brokenVersionPoWBoard.EntityVersion = 1
 	brokenVersionPoWBoard.Name = "my board name"
 	brokenVersionPoWBoard.Description = "my board description"
 	brokenVersionPoWBoard.ProofOfWork = "MIM1:20::::QkaMjkJbvXInQLtW:-1166891:"
@@ -262,6 +246,7 @@ func TestVerifyPoW_Fail_ParsingFailure(t *testing.T) {
 	var brokenVersionPoWBoard api.Board
 	brokenVersionPoWBoard.Fingerprint = "my random fingerprint"
 	brokenVersionPoWBoard.Creation = 4564654
+	brokenVersionPoWBoard.EntityVersion = 1
 	brokenVersionPoWBoard.Name = "my board name"
 	brokenVersionPoWBoard.Description = "my board description"
 	brokenVersionPoWBoard.ProofOfWork = "MIM1:20::::QkaMjkJbvXInQLtW:a1166891:"
@@ -278,6 +263,7 @@ func TestVerifyPoW_Fail_DataInFieldsThatShouldBeEmpty(t *testing.T) {
 	var brokenVersionPoWBoard api.Board
 	brokenVersionPoWBoard.Fingerprint = "my random fingerprint"
 	brokenVersionPoWBoard.Creation = 4564654
+	brokenVersionPoWBoard.EntityVersion = 1
 	brokenVersionPoWBoard.Name = "my board name"
 	brokenVersionPoWBoard.Description = "my board description"
 	brokenVersionPoWBoard.ProofOfWork = "MIM1:20:AA:AA:AA:QkaMjkJbvXInQLtW:a1166891:"
@@ -294,6 +280,7 @@ func TestVerifyPoW_Fail_NoDataInFieldsThatShouldBeNotEmpty(t *testing.T) {
 	var brokenVersionPoWBoard api.Board
 	brokenVersionPoWBoard.Fingerprint = "my random fingerprint"
 	brokenVersionPoWBoard.Creation = 4564654
+	brokenVersionPoWBoard.EntityVersion = 1
 	brokenVersionPoWBoard.Name = "my board name"
 	brokenVersionPoWBoard.Description = "my board description"
 	brokenVersionPoWBoard.ProofOfWork = "MIM1::::::a1166891:"
@@ -310,6 +297,7 @@ func TestVerifyPoW_Fail_PoWTooLong(t *testing.T) {
 	var brokenVersionPoWBoard api.Board
 	brokenVersionPoWBoard.Fingerprint = "my random fingerprint"
 	brokenVersionPoWBoard.Creation = 4564654
+	brokenVersionPoWBoard.EntityVersion = 1
 	brokenVersionPoWBoard.Name = "my board name"
 	brokenVersionPoWBoard.Description = "my board description"
 	brokenVersionPoWBoard.ProofOfWork = "MIM1:20::::QkaMjkJbvXInQLtWAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA:1166891:"
@@ -326,6 +314,7 @@ func TestVerifyPoW_Fail_TooManyFields(t *testing.T) {
 	var brokenVersionPoWBoard api.Board
 	brokenVersionPoWBoard.Fingerprint = "my random fingerprint"
 	brokenVersionPoWBoard.Creation = 4564654
+	brokenVersionPoWBoard.EntityVersion = 1
 	brokenVersionPoWBoard.Name = "my board name"
 	brokenVersionPoWBoard.Description = "my board description"
 	brokenVersionPoWBoard.ProofOfWork = "MIM1:20::::QkaMjkJbvXInQLtW:1166891::A:A:A"
@@ -342,6 +331,7 @@ func TestVerifyPoW_Fail_TooFeWFields(t *testing.T) {
 	var brokenVersionPoWBoard api.Board
 	brokenVersionPoWBoard.Fingerprint = "my random fingerprint"
 	brokenVersionPoWBoard.Creation = 4564654
+	brokenVersionPoWBoard.EntityVersion = 1
 	brokenVersionPoWBoard.Name = "my board name"
 	brokenVersionPoWBoard.Description = "my board description"
 	brokenVersionPoWBoard.ProofOfWork = "MIM1:20::::QkaMjkJbvXInQLtW:"
@@ -361,15 +351,12 @@ func TestVerifyPoW_Fail_TooFeWFields(t *testing.T) {
 func TestVerifyUpdatePoW_Success_WithoutKey(t *testing.T) {
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my description"
-	// newboard.CreatePoW(new(ed25519.PrivateKey), 20)
-	// fmt.Println(newboard.ProofOfWork)
-	newboard.ProofOfWork = "MIM1:20::::VcoyLilzglhVKYdG:687101:"
+	newboard.CreatePoW(new(ed25519.PrivateKey), 20)
 	newboard.Description = "my updated description"
-	// newboard.CreateUpdatePoW(new(ed25519.PrivateKey), 20)
-	// fmt.Println(newboard.UpdateProofOfWork)
-	newboard.UpdateProofOfWork = "MIM1:20::::fhUxvaQuQpePjkwr:3315783:"
+	newboard.CreateUpdatePoW(new(ed25519.PrivateKey), 20)
 	result, err := newboard.VerifyPoW("")
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -380,7 +367,6 @@ func TestVerifyUpdatePoW_Success_WithoutKey(t *testing.T) {
 
 func TestVerifyUpdatePoW_Success_WithKey(t *testing.T) {
 
-	// marshaledPubKey := "0401c6166de14f9a2e698951591c9c68f2b93b3f3c9f60c93785d0464d88845bfa4b849954a7b93f7e9c4a5f40acb8c8f59ea2183069ca9cdb57b4e528aaa86ebd58ec01e164ae48f0be3d63ed69e309760337047aea8b3d37f2c08f53e50937a94c2d44b2182055f34c4cef0aa5eb653c0af32493c65d8c95c79170742b352acd7af1647c"
 	result, err := signedNewboardUpdated.VerifyPoW(signedNewBoardUpdatedPubkey)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
@@ -392,6 +378,7 @@ func TestVerifyUpdatePoW_Success_WithKey(t *testing.T) {
 func TestVerifyUpdatePoW_Fail_UpdatePoWInvalid(t *testing.T) {
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my updated description"
 	newboard.ProofOfWork = "MIM1:20::::pLBjxwHwpcHNVGBk:928329:"
@@ -411,6 +398,7 @@ func TestCreatePoW_Success_WithoutKey(t *testing.T) {
 	var newboard2 api.Board
 	newboard2.Fingerprint = "my random fingerprint2"
 	newboard2.Creation = 4564654
+	newboard2.EntityVersion = 1
 	newboard2.Name = "my board name"
 	newboard2.Description = "my board description2"
 	err := newboard2.CreatePoW(new(ed25519.PrivateKey), 20)
@@ -430,6 +418,7 @@ func TestCreatePoW_RunTwice_Success_WithoutKey(t *testing.T) {
 	var newboard2 api.Board
 	newboard2.Fingerprint = "my random fingerprint2"
 	newboard2.Creation = 4564654
+	newboard2.EntityVersion = 1
 	newboard2.Name = "my board name"
 	newboard2.Description = "my board description2"
 	err := newboard2.CreatePoW(new(ed25519.PrivateKey), 20)
@@ -451,6 +440,7 @@ func TestCreatePoW_SaltIsDifferent_Success(t *testing.T) {
 	var newboard2 api.Board
 	newboard2.Fingerprint = "my random fingerprint2"
 	newboard2.Creation = 4564654
+	newboard2.EntityVersion = 1
 	newboard2.Name = "my board name"
 	newboard2.Description = "my board description2"
 	err := newboard2.CreatePoW(new(ed25519.PrivateKey), 20)
@@ -471,13 +461,14 @@ func TestCreatePoW_Success_WithKey(t *testing.T) {
 	var signedNewboard api.Board
 	signedNewboard.Fingerprint = "my random fingerprint3"
 	signedNewboard.Creation = 4564654
+	signedNewboard.EntityVersion = 1
 	signedNewboard.Name = "my board name"
 	signedNewboard.Description = "my board description"
 	err := signedNewboard.CreatePoW(privKey, 20)
 	if err != nil {
 		t.Errorf("Test failed, err: '%s'", err)
 	} else {
-		marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+		marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 		result, err := signedNewboard.VerifyPoW(marshaledPubKey)
 		if err != nil {
 			t.Errorf("Test failed, err: '%s'", err)
@@ -492,6 +483,7 @@ func TestCreatePoW_Fail_TookTooLong(t *testing.T) {
 	var newboard api.Board
 	newboard.Fingerprint = "my random fingerprint2"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my board description2"
 	// In the unlikely case that your test machine can create a 32 bit hash collision in less than 30 seconds, increase it to 36 or 40. If so, on a completely unrelated note: can I borrow your computer?
@@ -512,6 +504,7 @@ func TestCreateUpdatePoW_Success_WithoutKey(t *testing.T) {
 	var newboard api.Board
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my board description"
 	err := newboard.CreatePoW(new(ed25519.PrivateKey), 20)
@@ -541,6 +534,7 @@ func TestCreateUpdatePoW_Success_WithKey(t *testing.T) {
 	var newboard api.Board
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my board description"
 	err := newboard.CreatePoW(privKey, 20)
@@ -553,7 +547,7 @@ func TestCreateUpdatePoW_Success_WithKey(t *testing.T) {
 			t.Errorf("Test failed, err: '%s'", err2)
 		} else {
 			// fmt.Printf("%#v\n", newboard)
-			marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+			marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 			// fmt.Printf("%#v\n", marshaledPubKey)
 			result, err3 := newboard.VerifyPoW(marshaledPubKey)
 			if err3 != nil {
@@ -578,6 +572,7 @@ func TestCreateUpdatePoW_SignedByTwoSeparateKeys_WithKey(t *testing.T) {
 	var newboard api.Board
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my board description"
 	err := newboard.CreatePoW(privKey, 20)
@@ -589,7 +584,7 @@ func TestCreateUpdatePoW_SignedByTwoSeparateKeys_WithKey(t *testing.T) {
 		if err2 != nil {
 			t.Errorf("Test failed, err: '%s'", err2)
 		} else {
-			marshaledPubKey2 := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey2.PublicKey.X, privKey2.PublicKey.Y))
+			marshaledPubKey2 := signaturing.MarshalPublicKey(privKey2.Public().(ed25519.PublicKey))
 			result, err3 := newboard.VerifyPoW(marshaledPubKey2)
 			if err3 != nil {
 				t.Errorf("Test failed, err: '%s'", err3)
@@ -604,6 +599,7 @@ func TestCreateUpdatePoW_RunTwice_Success_WithoutKey(t *testing.T) {
 	var newboard api.Board
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my board description"
 	err := newboard.CreatePoW(new(ed25519.PrivateKey), 20)
@@ -639,6 +635,7 @@ func TestCreateUpdatePoW_RunTwice_Success_WithKey(t *testing.T) {
 	var newboard api.Board
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my board description"
 	err := newboard.CreatePoW(privKey, 20)
@@ -655,7 +652,7 @@ func TestCreateUpdatePoW_RunTwice_Success_WithKey(t *testing.T) {
 			if err3 != nil {
 				t.Errorf("Test failed, err: '%s'", err3)
 			} else {
-				marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+				marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 				result, err4 := newboard.VerifyPoW(marshaledPubKey)
 				if err4 != nil {
 					t.Errorf("Test failed, err: '%s'", err4)
@@ -671,6 +668,7 @@ func TestCreateUpdatePoW_RunTwice_ForgotReUpdatePoW_Fail_WithoutKey(t *testing.T
 	var newboard api.Board
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my board description"
 	err := newboard.CreatePoW(new(ed25519.PrivateKey), 20)
@@ -703,6 +701,7 @@ func TestCreateUpdatePoW_RunTwice_ForgotReUpdatePoW_Fail_WithKey(t *testing.T) {
 	var newboard api.Board
 	newboard.Fingerprint = "my random fingerprint"
 	newboard.Creation = 4564654
+	newboard.EntityVersion = 1
 	newboard.Name = "my board name"
 	newboard.Description = "my board description"
 	err := newboard.CreatePoW(privKey, 20)
@@ -716,7 +715,7 @@ func TestCreateUpdatePoW_RunTwice_ForgotReUpdatePoW_Fail_WithKey(t *testing.T) {
 		} else {
 			newboard.Description = "I updated this board's description twice"
 			// (but I forgot to generated a new UpdatePoW)
-			marshaledPubKey := hex.EncodeToString(elliptic.Marshal(elliptic.P521(), privKey.PublicKey.X, privKey.PublicKey.Y))
+			marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
 			_, err4 := newboard.VerifyPoW(marshaledPubKey)
 			errMessage := "This proof of work is invalid or malformed."
 			if err4 == nil {
@@ -727,3 +726,47 @@ func TestCreateUpdatePoW_RunTwice_ForgotReUpdatePoW_Fail_WithKey(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateV2_Success_BothAlgorithms(t *testing.T) {
+	privKey, err := signaturing.CreateKeyPair()
+	if err != nil {
+		t.Errorf("Key pair creation failed. Err: '%s'", err)
+	}
+	pubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
+	for _, alg := range []string{proofofwork.AlgorithmSHA256, proofofwork.AlgorithmBLAKE2b} {
+		pow, err := proofofwork.CreateV2("my input", 12, alg, privKey)
+		if err != nil {
+			t.Errorf("Test failed, err: '%s'", err)
+			continue
+		}
+		if !strings.HasPrefix(pow, "MIM2:12:::"+alg+":") {
+			t.Errorf("The PoW does not name its algorithm. PoW: %s", pow)
+		}
+		valid, strength, err := proofofwork.Verify("my input", pow, pubKey)
+		if !valid || strength != 12 || err != nil {
+			t.Errorf("Test failed, this PoW should be valid but it is not. Algorithm: %s, Err: '%v'", alg, err)
+		}
+		valid2, _, _ := proofofwork.Verify("another input", pow, pubKey)
+		if valid2 {
+			t.Errorf("A PoW was valid for an input it was not made for. Algorithm: %s", alg)
+		}
+	}
+}
+
+func TestVerifyV2_Fail_AlgorithmSwapped(t *testing.T) {
+	pow, err := proofofwork.CreateV2("my input", 12, proofofwork.AlgorithmSHA256, new(ed25519.PrivateKey))
+	if err != nil {
+		t.Errorf("Test failed, err: '%s'", err)
+	}
+	// The same PoW claiming to be made with another algorithm should not verify.
+	swapped := strings.Replace(pow, proofofwork.AlgorithmSHA256, proofofwork.AlgorithmBLAKE2b, 1)
+	valid, _, _ := proofofwork.Verify("my input", swapped, "")
+	if valid {
+		t.Errorf("A PoW verified under an algorithm it was not made with.")
+	}
+	unknown := strings.Replace(pow, proofofwork.AlgorithmSHA256, "md5", 1)
+	_, _, err2 := proofofwork.Verify("my input", unknown, "")
+	if err2 == nil || !strings.Contains(err2.Error(), "This proof of work is in a format Mim does not support.") {
+		t.Errorf("A PoW with an unknown algorithm did not fail as unsupported. Err: '%v'", err2)
+	}
+}
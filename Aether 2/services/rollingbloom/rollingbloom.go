@@ -41,11 +41,14 @@ func (cb *constituentBloom) TestString(str string) bool {
 	return cb.Bloom.TestString(str)
 }
 
-func newConstituentBloom(durationDays, maxSize uint, start int64) constituentBloom {
+func newConstituentBloom(durationDays, maxSize uint, start int64, fpRate float64) constituentBloom {
+	if fpRate == 0 {
+		fpRate = float64(globals.FrontendConfig.GetBloomFilterFalsePositiveRatePercent()) / 100
+	}
 	return constituentBloom{
 		StartTimestamp: start,
 		EndTimestamp:   time.Unix(start, 0).Add(time.Duration(int(durationDays)) * time.Hour * 24).Unix(),
-		Bloom:          *bloom.NewWithEstimates(maxSize, float64(globals.FrontendConfig.GetBloomFilterFalsePositiveRatePercent())/100),
+		Bloom:          *bloom.NewWithEstimates(maxSize, fpRate),
 	}
 }
 
@@ -55,6 +58,7 @@ type RollingBloom struct {
 	MaxDurationDays   uint
 	granularityDays   uint
 	MaxSize           uint
+	fpRate            float64 // 0: the rate in the frontend config.
 	lastMaintainRun   int64
 }
 
@@ -94,13 +98,19 @@ func (r *RollingBloom) Count() int {
 
 // NewRollingBloom creates a bloom filter that can keep track of a rolling, but limited time. Mind that maxSize is the size of every single constituent bloom - so if your maximum duration is 180 days and your resolution is 14 day blocks with a max size of 10000, you'll have 13~ blocks, each of which can hold 10000. Your total capacity at 50% fail rate and perfect distribution will be 130000. Since this calculation can depend on many things, you should go for a size an order of magnitude larger than you think you'll need.
 func NewRollingBloom(maxDurationDays, granularityDays, maxSize uint) RollingBloom {
-	if maxDurationDays < granularityDays || maxDurationDays == 0 || granularityDays == 0 {
+	return NewRollingBloomWithRate(maxDurationDays, granularityDays, maxSize, 0)
+}
+
+// NewRollingBloomWithRate is NewRollingBloom with a false positive rate of its own (0.01 is 1%), instead of the one in the frontend config. The backend has no frontend config, so the blooms it uses are created with this.
+func NewRollingBloomWithRate(maxDurationDays, granularityDays, maxSize uint, fpRate float64) RollingBloom {
+	if maxDurationDays < granularityDays || maxDurationDays == 0 || granularityDays == 0 || fpRate < 0 || fpRate >= 1 {
 		logging.LogCrash("You've provided an invalid combination for RollingBloom. This is a programming error.")
 	}
 	rb := RollingBloom{
 		MaxDurationDays: maxDurationDays,
 		granularityDays: granularityDays,
 		MaxSize:         maxSize,
+		fpRate:          fpRate,
 	}
 	rb.maintain()
 	return rb
@@ -143,7 +153,7 @@ func (r *RollingBloom) maintain() {
 	}
 	// Generate bloom buckets until the end reaches beyond one interval into the future. This is to prevent a case where the max duration and resolution are multiples of each other, and the last generated constitutent bloom terminates right at the moment of creation - with the lastMaintainRun gate preventing a new run until a day after. This way, the end date of the bloom filter will at least be one more cycle into the future.
 	for now.Add(24*time.Hour*time.Duration(int(r.granularityDays))).Unix() > lastNewBloomEnd {
-		cb := newConstituentBloom(r.granularityDays, r.MaxSize, lastNewBloomEnd)
+		cb := newConstituentBloom(r.granularityDays, r.MaxSize, lastNewBloomEnd, r.fpRate)
 		lastNewBloomEnd = cb.EndTimestamp
 		r.ConstituentBlooms = append(r.ConstituentBlooms, cb)
 	}
@@ -0,0 +1,191 @@
+// Services > Signaturing > Parallel
+// This file verifies many signatures at once, in batches, across all cores.
+
+package signaturing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/ed25519"
+	"runtime"
+	"sync"
+)
+
+/*
+Why verify in batches?
+
+A sync can bring in tens of thousands of entities, and checking their signatures one by one is where most of the CPU of the sync goes.
+
+Checking a signature is checking that [s]B = R + [h]A, where B is the base point, A is the public key, (R, s) is the signature, and h is the hash of R, A and the message. Checking n of them one by one takes n double scalar multiplications. Checking them together is one multi-scalar multiplication over all of the points:
+
+	[8]( -[Σ z·s]B + Σ [z]R + Σ [z·h]A ) = 0
+
+which is about twice as fast per signature. Every signature is weighed by its own random z, so that an invalid signature can't be made to cancel out against another one. The batches are also spread across all cores.
+
+If a batch fails, it doesn't say which of its signatures is invalid, so then we check them one by one with Verify. That only happens when a remote sends us invalid signatures.
+
+Heads up: the batch equation is the cofactored one (the [8] above), and Verify is not. So a batch accepts a signature whose R or public key has a small order component, which Verify rejects. Only the owner of a key can make a signature like that, so it can't be used to sign as somebody else, but an entity signed like that can pass here, and fail on a node that checks it with Verify.
+*/
+
+// How many signatures are checked together. Past this, a bigger batch is not much faster per signature, and a failed one has more to check one by one.
+const signatureBatchSize = 64
+
+// SignatureItem is one signature to verify, with the same inputs Verify takes.
+type SignatureItem struct {
+	Input     string
+	Signature string
+	PubKey    string
+}
+
+// VerifyParallel verifies all the signatures, and returns whether each one is valid, in the same order. The signatures are checked in batches, spread across the cores.
+func VerifyParallel(items []SignatureItem) []bool {
+	results := make([]bool, len(items))
+	if len(items) == 0 {
+		return results
+	}
+	batches := (len(items) + signatureBatchSize - 1) / signatureBatchSize
+	workers := runtime.NumCPU()
+	if workers > batches {
+		workers = batches
+	}
+	jobs := make(chan int, batches)
+	for i := 0; i < batches; i++ {
+		jobs <- i * signatureBatchSize
+	}
+	close(jobs)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Every batch is written by one worker only, so the results need no lock.
+			for start := range jobs {
+				end := start + signatureBatchSize
+				if end > len(items) {
+					end = len(items)
+				}
+				verifyBatch(items[start:end], results[start:end])
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// batchEntry is a signature, decoded for the batch equation.
+type batchEntry struct {
+	r *edwards25519.Point
+	a *edwards25519.Point
+	s *edwards25519.Scalar
+	h *edwards25519.Scalar
+}
+
+func verifyBatch(items []SignatureItem, results []bool) {
+	entries := []*batchEntry{}
+	indexes := []int{}
+	for i := range items {
+		e, ok := newBatchEntry(&items[i])
+		if !ok {
+			// Verify has the last word on the signatures that don't decode.
+			results[i] = verifyItem(&items[i])
+			continue
+		}
+		entries = append(entries, e)
+		indexes = append(indexes, i)
+	}
+	if len(entries) == 0 {
+		return
+	}
+	if batchHolds(entries) {
+		for _, i := range indexes {
+			results[i] = true
+		}
+		return
+	}
+	// At least one of them is invalid. Find out which.
+	for _, i := range indexes {
+		results[i] = verifyItem(&items[i])
+	}
+}
+
+func newBatchEntry(item *SignatureItem) (*batchEntry, bool) {
+	sig, err := hex.DecodeString(item.Signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, false
+	}
+	pk, err2 := UnmarshalPublicKey(item.PubKey)
+	if err2 != nil || len(pk) != ed25519.PublicKeySize {
+		return nil, false
+	}
+	r, err3 := new(edwards25519.Point).SetBytes(sig[:32])
+	// Verify compares R byte by byte, so it rejects the encodings of R that aren't the canonical one. The decoding here doesn't.
+	if err3 != nil || !bytes.Equal(r.Bytes(), sig[:32]) {
+		return nil, false
+	}
+	a, err4 := new(edwards25519.Point).SetBytes(pk)
+	if err4 != nil {
+		return nil, false
+	}
+	s, err5 := edwards25519.NewScalar().SetCanonicalBytes(sig[32:])
+	if err5 != nil {
+		return nil, false
+	}
+	// What is signed is the hash of the input, same as in Verify.
+	msg := sha256.Sum256([]byte(item.Input))
+	hasher := sha512.New()
+	hasher.Write(sig[:32])
+	hasher.Write(pk)
+	hasher.Write(msg[:])
+	h, err6 := edwards25519.NewScalar().SetUniformBytes(hasher.Sum(nil))
+	if err6 != nil {
+		return nil, false
+	}
+	return &batchEntry{r: r, a: a, s: s, h: h}, true
+}
+
+// batchHolds checks the batch equation above.
+func batchHolds(entries []*batchEntry) bool {
+	scalars := make([]*edwards25519.Scalar, 0, 2*len(entries)+1)
+	points := make([]*edwards25519.Point, 0, 2*len(entries)+1)
+	bSum := edwards25519.NewScalar()
+	for _, e := range entries {
+		z, ok := randomWeight()
+		if !ok {
+			return false
+		}
+		bSum.MultiplyAdd(z, e.s, bSum)
+		scalars = append(scalars, z, edwards25519.NewScalar().Multiply(z, e.h))
+		points = append(points, e.r, e.a)
+	}
+	scalars = append(scalars, bSum.Negate(bSum))
+	points = append(points, edwards25519.NewGeneratorPoint())
+	check := new(edwards25519.Point).VarTimeMultiScalarMult(scalars, points)
+	check.MultByCofactor(check)
+	return check.Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+// randomWeight returns a random 128-bit scalar. That's enough to make the chance of an invalid signature passing in a batch 2^-128.
+func randomWeight() (*edwards25519.Scalar, bool) {
+	var b [32]byte
+	if _, err := rand.Read(b[:16]); err != nil {
+		return nil, false
+	}
+	z, err := edwards25519.NewScalar().SetCanonicalBytes(b[:])
+	if err != nil {
+		return nil, false
+	}
+	return z, true
+}
+
+func verifyItem(item *SignatureItem) bool {
+	// ed25519.Verify panics on a public key of the wrong size.
+	pk, err := UnmarshalPublicKey(item.PubKey)
+	if err != nil || len(pk) != ed25519.PublicKeySize {
+		return false
+	}
+	return Verify(item.Input, item.Signature, item.PubKey)
+}
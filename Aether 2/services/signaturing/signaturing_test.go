@@ -5,10 +5,11 @@ import (
 	"aether-core/services/signaturing"
 	// "crypto/elliptic"
 	"aether-core/backend/cmd"
+	"fmt"
 	"golang.org/x/crypto/ed25519"
-	// "fmt"
 	// "encoding/hex"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -212,3 +213,56 @@ func TestBoardCreateUpdateSignature_Success(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyParallel(t *testing.T) {
+	privKey, err := signaturing.CreateKeyPair()
+	if err != nil {
+		t.Errorf("Key pair creation failed. Err: '%s'", err)
+	}
+	marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
+	items := []signaturing.SignatureItem{}
+	for _, input := range []string{"first input", "second input", "third input"} {
+		signature, err2 := signaturing.Sign(input, privKey)
+		if err2 != nil {
+			t.Errorf("Signing failed. Err: '%s'", err2)
+		}
+		items = append(items, signaturing.SignatureItem{Input: input, Signature: signature, PubKey: marshaledPubKey})
+	}
+	items[1].Input = "tampered input"
+	items = append(items, signaturing.SignatureItem{Input: "fourth input", Signature: items[0].Signature, PubKey: "fake pub key"})
+	results := signaturing.VerifyParallel(items)
+	expected := []bool{true, false, true, false}
+	for i, _ := range expected {
+		if results[i] != expected[i] || results[i] != signaturing.Verify(items[i].Input, items[i].Signature, items[i].PubKey) {
+			t.Errorf("Parallel verification result is not the expected one. Item: %d, Result: %v, Expected: %v", i, results[i], expected[i])
+		}
+	}
+}
+
+// Over a few batches, one of which has an invalid signature in it. The rest of that batch still has to come out valid.
+func TestVerifyParallel_Batches(t *testing.T) {
+	privKey, err := signaturing.CreateKeyPair()
+	if err != nil {
+		t.Errorf("Key pair creation failed. Err: '%s'", err)
+	}
+	marshaledPubKey := signaturing.MarshalPublicKey(privKey.Public().(ed25519.PublicKey))
+	items := []signaturing.SignatureItem{}
+	for i := 0; i < 150; i++ {
+		input := fmt.Sprintf("input %d", i)
+		signature, err2 := signaturing.Sign(input, privKey)
+		if err2 != nil {
+			t.Errorf("Signing failed. Err: '%s'", err2)
+		}
+		items = append(items, signaturing.SignatureItem{Input: input, Signature: signature, PubKey: marshaledPubKey})
+	}
+	items[100].Input = "tampered input"
+	// A signature with a valid R, but an s that is not reduced.
+	items[101].Signature = items[101].Signature[:64] + strings.Repeat("f", 64)
+	results := signaturing.VerifyParallel(items)
+	for i, _ := range items {
+		expected := i != 100 && i != 101
+		if results[i] != expected {
+			t.Errorf("Parallel verification result is not the expected one. Item: %d, Result: %v, Expected: %v", i, results[i], expected)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -60,8 +61,8 @@ func Plural(entityType string) string {
 		return "posts"
 	} else if entityType == "vote" {
 		return "votes"
-	} else if entityType == "vote" {
-		return "votes"
+	} else if entityType == "key" {
+		return "keys"
 	} else if entityType == "truststate" {
 		return "truststates"
 	} else if entityType == "address" {
@@ -154,8 +155,72 @@ func FileExists(filePath string) bool {
 	return true
 }
 
+// SplitHostPort splits a host:port, or [host]:port for IPv6, into its parts. An IPv6 host comes back without its brackets.
 func SplitHostPort(addr string) (string, uint16) {
 	host, portAsStr, _ := net.SplitHostPort(addr)
 	portAsInt, _ := strconv.Atoi(portAsStr)
 	return host, uint16(portAsInt)
 }
+
+// JoinHostPort is the reverse of SplitHostPort. IPv6 hosts are put into brackets, so that the port can be told apart from the address.
+func JoinHostPort(host string, port uint16) string {
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
+
+// Location types of the overlay networks. The others are 4 (IPv4), 6 (IPv6) and 3 (URL).
+const (
+	LocationTypeTor uint8 = 7
+	LocationTypeI2P uint8 = 8
+)
+
+// IPType returns 4 for an IPv4 address, 6 for an IPv6 address, 7 for a Tor onion address, 8 for an I2P address, and 3 for anything else (a URL). These are the location types of addresses. An IPv4 address mapped into IPv6 (::ffff:203.0.113.5) is IPv4.
+func IPType(loc string) uint8 {
+	ip := net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(loc, "["), "]"))
+	if ip == nil {
+		lower := strings.ToLower(strings.TrimSuffix(loc, "."))
+		if strings.HasSuffix(lower, ".onion") {
+			return LocationTypeTor
+		}
+		if strings.HasSuffix(lower, ".i2p") {
+			return LocationTypeI2P
+		}
+		return 3
+	}
+	if ip.To4() != nil {
+		return 4
+	}
+	return 6
+}
+
+// NormalizeLocation returns the one way we write an IP address, so that the same address is always the same string: IPv6 addresses lose their brackets and are written in their shortest form, and IPv4 addresses mapped into IPv6 become plain IPv4. Anything that is not an IP is returned as is.
+func NormalizeLocation(loc string) string {
+	ip := net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(loc, "["), "]"))
+	if ip == nil {
+		return loc
+	}
+	return ip.String()
+}
+
+// isBase32 checks for the lowercase base32 alphabet that onion and I2P addresses are written in.
+func isBase32(s string) bool {
+	for _, c := range s {
+		if !((c >= 'a' && c <= 'z') || (c >= '2' && c <= '7')) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidOverlayLocation checks that a location is a well-formed address on an overlay network: a v3 Tor onion address (56 base32 characters, then .onion), or an I2P base32 address (52 base32 characters, then .b32.i2p). The older and shorter forms of both are not accepted, v2 onions are gone from the Tor network, and the short I2P names need an address book to resolve, which every router has a different one of.
+func ValidOverlayLocation(loc string) bool {
+	switch {
+	case strings.HasSuffix(loc, ".onion"):
+		name := strings.TrimSuffix(loc, ".onion")
+		return len(name) == 56 && isBase32(name)
+	case strings.HasSuffix(loc, ".b32.i2p"):
+		name := strings.TrimSuffix(loc, ".b32.i2p")
+		return len(name) == 52 && isBase32(name)
+	default:
+		return false
+	}
+}